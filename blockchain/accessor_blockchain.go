@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"fmt"
 
+	"github.com/incognitochain/incognito-chain/blockchain/committeestate"
 	"github.com/incognitochain/incognito-chain/blockchain/types"
 	"github.com/incognitochain/incognito-chain/dataaccessobject/statedb"
 	"github.com/incognitochain/incognito-chain/incdb"
@@ -628,6 +629,36 @@ func (blockchain *BlockChain) GetBeaconRootsHash(height uint64) (*BeaconRootHash
 	return bRH, err
 }
 
+// GetBeaconCommitteeStateDiff return committee state diff of canonical beacon blocks in (fromHeight, toHeight],
+// diffs are only available when node runs with committee change index enabled
+func (blockchain *BlockChain) GetBeaconCommitteeStateDiff(fromHeight, toHeight uint64) ([]*committeestate.CommitteeStateDiff, error) {
+	if fromHeight >= toHeight {
+		return nil, fmt.Errorf("from height %+v must be less than to height %+v", fromHeight, toHeight)
+	}
+	if toHeight-fromHeight > MAX_COMMITTEE_STATE_DIFF_RANGE {
+		return nil, fmt.Errorf("range of beacon height must not exceed %+v blocks", MAX_COMMITTEE_STATE_DIFF_RANGE)
+	}
+	finalView := blockchain.BeaconChain.GetFinalView()
+	bestView := blockchain.BeaconChain.GetBestView()
+	res := []*committeestate.CommitteeStateDiff{}
+	for height := fromHeight + 1; height <= toHeight; height++ {
+		h, err := blockchain.GetBeaconBlockHashByHeight(finalView, bestView, height)
+		if err != nil {
+			return nil, err
+		}
+		data, err := rawdbv2.GetBeaconCommitteeStateDiff(blockchain.GetBeaconChainDatabase(), *h)
+		if err != nil {
+			return nil, fmt.Errorf("committee state diff of beacon height %+v not found, node must run with --indexcommitteechange, %+v", height, err)
+		}
+		diff := &committeestate.CommitteeStateDiff{}
+		if err := json.Unmarshal(data, diff); err != nil {
+			return nil, err
+		}
+		res = append(res, diff)
+	}
+	return res, nil
+}
+
 func (blockchain *BlockChain) GetBeaconCommitteeOfEpoch(epoch uint64) ([]incognitokey.CommitteePublicKey, error) {
 	lastHeightOfEpoch := blockchain.GetLastBeaconHeightInEpoch(epoch)
 	blkHeightForGetCmt := lastHeightOfEpoch - 1
@@ -672,6 +672,41 @@ func (blockchain *BlockChain) TryGetAllOutputCoinsByKeyset(keyset *incognitokey.
 	return res, err
 }
 
+// HasOutputCoinsByKeyset returns whether a key set has ever received PRV or token coins.
+// Coins ver 1 are looked up by public key, coins ver 2 are scanned with the OTA key from fromHeight up to the best height of its shard.
+func (blockchain *BlockChain) HasOutputCoinsByKeyset(keyset *incognitokey.KeySet, fromHeight uint64) (bool, error) {
+	pubKey := keyset.PaymentAddress.Pk
+	if len(pubKey) == 0 || keyset.OTAKey.GetOTASecretKey() == nil {
+		return false, fmt.Errorf("invalid key set")
+	}
+	shardID := common.GetShardIDFromLastByte(pubKey[len(pubKey)-1])
+	transactionStateDB := blockchain.GetBestStateTransactionStateDB(shardID)
+
+	outCoins, err := coinIndexer.QueryDbCoinVer1(pubKey, &common.PRVCoinID, transactionStateDB)
+	if err != nil {
+		return false, err
+	}
+	if len(outCoins) > 0 {
+		return true, nil
+	}
+
+	if lowestHeight := config.Param().CoinVersion2LowestHeight; fromHeight < lowestHeight {
+		fromHeight = lowestHeight
+	}
+	upToHeight := blockchain.GetBestStateShard(shardID).ShardHeight
+	filter := coinIndexer.GetCoinFilterByOTAKeyAndToken()
+	for _, tokenID := range []common.Hash{common.PRVCoinID, common.ConfidentialAssetID} {
+		outCoins, err := coinIndexer.QueryDbCoinVer2(keyset.OTAKey, &tokenID, fromHeight, upToHeight, transactionStateDB, filter)
+		if err != nil {
+			return false, err
+		}
+		if len(outCoins) > 0 {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
 // CreateAndSaveTxViewPointFromBlock - fetch data from block, put into txviewpoint variable and save into db
 // still storage full data of commitments, serial number, snderivator to check double spend
 // this function only work for transaction transfer token/prv within shard
@@ -0,0 +1,625 @@
+package blockchain
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/incognitochain/incognito-chain/blockchain/types"
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/common/base58"
+	"github.com/incognitochain/incognito-chain/config"
+	"github.com/incognitochain/incognito-chain/dataaccessobject/rawdbv2"
+	"github.com/incognitochain/incognito-chain/dataaccessobject/statedb"
+	"github.com/incognitochain/incognito-chain/incdb"
+	"github.com/incognitochain/incognito-chain/incognitokey"
+	"github.com/incognitochain/incognito-chain/privacy"
+	"github.com/incognitochain/incognito-chain/transaction"
+)
+
+const accountLedgerSyncInterval = 5 * time.Second
+
+// LedgerAccount is a privacy v2 account registered to account ledger, identified by its public spend key.
+// The private view key is kept on the node to decrypt amount of received coins
+type LedgerAccount struct {
+	PublicKey    []byte
+	OTASecretKey []byte
+	ViewKey      []byte
+	ShardID      byte
+	TokenHash    common.Hash // hash of access token registering the account, only this token can query the account
+	FromHeight   uint64
+	SyncedHeight uint64
+}
+
+// LedgerCoin is a coin received by an account of account ledger
+type LedgerCoin struct {
+	PublicKey   string // base58 encoded
+	TokenID     string
+	Amount      uint64
+	ShardHeight uint64
+	TxHash      string `json:",omitempty"` // empty if coin is received by cross shard
+	KeyImage    string `json:",omitempty"` // base58 encoded, known after submitted by owner of account
+	IsSpent     bool
+	SpentTxHash string `json:",omitempty"`
+}
+
+// LedgerTx is a tx sending coins to or spending coins of an account of account ledger, amounts are grouped by token id.
+// A tx spending coins of the account is outgoing, its change coins are in Received
+type LedgerTx struct {
+	TxHash              string `json:",omitempty"`
+	ShardHeight         uint64
+	ShardHash           string
+	CrossShardBlockHash string `json:",omitempty"` // coins received by cross shard are grouped by block of sender shard
+	MetadataType        int
+	Received            map[string]uint64 `json:",omitempty"`
+	Spent               map[string]uint64 `json:",omitempty"`
+}
+
+// LedgerKeyImage is key image of a coin of an account, computed by owner of account from its private key
+type LedgerKeyImage struct {
+	CoinPublicKey []byte
+	KeyImage      []byte
+}
+
+func (ledgerTx *LedgerTx) IsOutgoing() bool {
+	return len(ledgerTx.Spent) > 0
+}
+
+func (account *LedgerAccount) keySet() *incognitokey.KeySet {
+	keySet := &incognitokey.KeySet{}
+	keySet.OTAKey.SetPublicSpend(account.PublicKey)
+	keySet.OTAKey.SetOTASecretKey(account.OTASecretKey)
+	keySet.ReadonlyKey.Pk = account.PublicKey
+	keySet.ReadonlyKey.Rk = account.ViewKey
+	return keySet
+}
+
+// AccountLedger keeps received coins, spent coins and txs of registered privacy v2 accounts.
+// Accounts are synced with finalized shard blocks, so no record has to be reverted.
+// Spent coins are detected with key images submitted by owner of account, because key image can not be computed from view key
+type AccountLedger struct {
+	blockchain   *BlockChain
+	accessTokens map[string]bool
+	mtx          sync.Mutex
+	accounts     map[string]*LedgerAccount // by hex encoded public key
+	rawAssetTags map[string]*common.Hash
+	syncCh       chan struct{}
+}
+
+// NewAccountLedger create account ledger and load registered accounts of all shards
+func NewAccountLedger(blockchain *BlockChain, accessTokens string) (*AccountLedger, error) {
+	ledger := &AccountLedger{
+		blockchain:   blockchain,
+		accessTokens: make(map[string]bool),
+		accounts:     make(map[string]*LedgerAccount),
+		rawAssetTags: make(map[string]*common.Hash),
+		syncCh:       make(chan struct{}, 1),
+	}
+	for _, token := range strings.Split(accessTokens, ",") {
+		token = strings.TrimSpace(token)
+		if token == "" {
+			continue
+		}
+		if b, err := hex.DecodeString(token); err != nil || len(b) != 32 {
+			return nil, fmt.Errorf("account ledger access token %v is invalid", token)
+		}
+		ledger.accessTokens[token] = true
+	}
+	if len(ledger.accessTokens) == 0 {
+		return nil, fmt.Errorf("account ledger needs at least 1 access token")
+	}
+	for _, shardID := range blockchain.GetShardIDs() {
+		values, err := rawdbv2.GetAccountLedgerAccounts(blockchain.GetShardChainDatabase(byte(shardID)))
+		if err != nil {
+			return nil, err
+		}
+		for _, value := range values {
+			account := &LedgerAccount{}
+			if err := json.Unmarshal(value, account); err != nil {
+				return nil, err
+			}
+			ledger.accounts[hex.EncodeToString(account.PublicKey)] = account
+		}
+	}
+	return ledger, nil
+}
+
+// Start sync registered accounts with new finalized shard blocks until the node is interrupted
+func (ledger *AccountLedger) Start() {
+	ticker := time.NewTicker(accountLedgerSyncInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ledger.blockchain.config.Interrupt:
+			return
+		case <-ticker.C:
+		case <-ledger.syncCh:
+		}
+		for _, shardID := range ledger.blockchain.GetShardIDs() {
+			if err := ledger.syncShard(byte(shardID)); err != nil {
+				Logger.log.Errorf("Account ledger sync shard %v error: %v", shardID, err)
+			}
+		}
+	}
+}
+
+// RegisterAccount register an account by its OTA key and view key, coins are indexed from fromHeight.
+// Registering an account again with the same access token does not reset it
+func (ledger *AccountLedger) RegisterAccount(accessToken string, otaKey privacy.OTAKey, viewKey privacy.ViewingKey, fromHeight uint64) (*LedgerAccount, error) {
+	if !ledger.accessTokens[accessToken] {
+		return nil, fmt.Errorf("invalid access token")
+	}
+	if otaKey.GetPublicSpend() == nil || otaKey.GetOTASecretKey() == nil {
+		return nil, fmt.Errorf("invalid OTA key")
+	}
+	publicKey := otaKey.GetPublicSpend().ToBytesS()
+	if !bytes.Equal(viewKey.Pk, publicKey) || len(viewKey.Rk) != common.PrivateKeySize {
+		return nil, fmt.Errorf("view key does not match OTA key")
+	}
+
+	ledger.mtx.Lock()
+	defer ledger.mtx.Unlock()
+	tokenHash := common.Hash(sha256.Sum256([]byte(accessToken)))
+	if account, ok := ledger.accounts[hex.EncodeToString(publicKey)]; ok {
+		if account.TokenHash != tokenHash {
+			return nil, fmt.Errorf("account is registered with another access token")
+		}
+		res := *account
+		return &res, nil
+	}
+
+	if lowestHeight := config.Param().CoinVersion2LowestHeight; fromHeight < lowestHeight {
+		fromHeight = lowestHeight
+	}
+	if fromHeight == 0 {
+		fromHeight = 1
+	}
+	account := &LedgerAccount{
+		PublicKey:    publicKey,
+		OTASecretKey: otaKey.GetOTASecretKey().ToBytesS(),
+		ViewKey:      viewKey.Rk,
+		ShardID:      common.GetShardIDFromLastByte(publicKey[len(publicKey)-1]),
+		TokenHash:    tokenHash,
+		FromHeight:   fromHeight,
+		SyncedHeight: fromHeight - 1,
+	}
+	if err := rawdbv2.StoreAccountLedgerAccount(ledger.blockchain.GetShardChainDatabase(account.ShardID), publicKey, account); err != nil {
+		return nil, err
+	}
+	ledger.accounts[hex.EncodeToString(publicKey)] = account
+	ledger.triggerSync()
+	res := *account
+	return &res, nil
+}
+
+// GetAccount return registered account of public key, access token must be the one registering the account
+func (ledger *AccountLedger) GetAccount(accessToken string, publicKey []byte) (*LedgerAccount, error) {
+	ledger.mtx.Lock()
+	defer ledger.mtx.Unlock()
+	account, err := ledger.getAccount(accessToken, publicKey)
+	if err != nil {
+		return nil, err
+	}
+	res := *account
+	return &res, nil
+}
+
+// GetCoins return all coins received by an account
+func (ledger *AccountLedger) GetCoins(accessToken string, publicKey []byte) ([]LedgerCoin, error) {
+	account, err := ledger.GetAccount(accessToken, publicKey)
+	if err != nil {
+		return nil, err
+	}
+	values, err := rawdbv2.GetAccountLedgerCoins(ledger.blockchain.GetShardChainDatabase(account.ShardID), publicKey)
+	if err != nil {
+		return nil, err
+	}
+	res := []LedgerCoin{}
+	for _, value := range values {
+		ledgerCoin := LedgerCoin{}
+		if err := json.Unmarshal(value, &ledgerCoin); err != nil {
+			return nil, err
+		}
+		res = append(res, ledgerCoin)
+	}
+	return res, nil
+}
+
+// GetBalances return sum of unspent coins of an account by token id
+func (ledger *AccountLedger) GetBalances(accessToken string, publicKey []byte) (map[string]uint64, error) {
+	coins, err := ledger.GetCoins(accessToken, publicKey)
+	if err != nil {
+		return nil, err
+	}
+	res := make(map[string]uint64)
+	for _, ledgerCoin := range coins {
+		if !ledgerCoin.IsSpent {
+			res[ledgerCoin.TokenID] += ledgerCoin.Amount
+		}
+	}
+	return res, nil
+}
+
+// GetTxs return all txs of an account, sorted by shard height
+func (ledger *AccountLedger) GetTxs(accessToken string, publicKey []byte) ([]LedgerTx, error) {
+	account, err := ledger.GetAccount(accessToken, publicKey)
+	if err != nil {
+		return nil, err
+	}
+	values, err := rawdbv2.GetAccountLedgerTxs(ledger.blockchain.GetShardChainDatabase(account.ShardID), publicKey)
+	if err != nil {
+		return nil, err
+	}
+	res := []LedgerTx{}
+	for _, value := range values {
+		ledgerTx := LedgerTx{}
+		if err := json.Unmarshal(value, &ledgerTx); err != nil {
+			return nil, err
+		}
+		res = append(res, ledgerTx)
+	}
+	return res, nil
+}
+
+// SubmitKeyImages store key images of coins of an account so the ledger can detect when they are spent.
+// Coins already spent in synced blocks are marked spent right away, with their spending tx if it is found
+func (ledger *AccountLedger) SubmitKeyImages(accessToken string, publicKey []byte, keyImages []LedgerKeyImage) error {
+	ledger.mtx.Lock()
+	defer ledger.mtx.Unlock()
+	account, err := ledger.getAccount(accessToken, publicKey)
+	if err != nil {
+		return err
+	}
+	shardID := account.ShardID
+	db := ledger.blockchain.GetShardChainDatabase(shardID)
+	transactionStateDB := ledger.blockchain.ShardChain[shardID].GetFinalView().(*ShardBestState).GetCopiedTransactionStateDB()
+
+	batch := db.NewBatch()
+	spendingTxs := make(map[string]*LedgerTx)
+	spendingTxKeys := []string{}
+	for _, keyImage := range keyImages {
+		value, err := rawdbv2.GetAccountLedgerCoin(db, publicKey, keyImage.CoinPublicKey)
+		if err != nil {
+			return fmt.Errorf("coin %v is not received by account", base58.Base58Check{}.Encode(keyImage.CoinPublicKey, common.ZeroByte))
+		}
+		ledgerCoin := LedgerCoin{}
+		if err := json.Unmarshal(value, &ledgerCoin); err != nil {
+			return err
+		}
+		ledgerCoin.KeyImage = base58.Base58Check{}.Encode(keyImage.KeyImage, common.ZeroByte)
+		if err := rawdbv2.StoreAccountLedgerKeyImage(batch, publicKey, keyImage.KeyImage, keyImage.CoinPublicKey); err != nil {
+			return err
+		}
+
+		tokenID, err := common.Hash{}.NewHashFromStr(ledgerCoin.TokenID)
+		if err != nil {
+			return err
+		}
+		isSpent, err := statedb.HasSerialNumber(transactionStateDB, *tokenID, keyImage.KeyImage, shardID)
+		if err != nil {
+			return err
+		}
+		if isSpent && !ledgerCoin.IsSpent {
+			txHash, height, metadataType, found := ledger.findSpendingTx(keyImage.KeyImage, *tokenID, shardID)
+			switch {
+			case !found:
+				// spending tx is unknown, coin is only marked spent
+				ledgerCoin.IsSpent = true
+			case height <= account.SyncedHeight:
+				key := fmt.Sprintf("%v-%v", height, txHash.String())
+				ledgerTx, ok := spendingTxs[key]
+				if !ok {
+					ledgerTx = &LedgerTx{
+						TxHash:       txHash.String(),
+						ShardHeight:  height,
+						MetadataType: metadataType,
+					}
+					if value, err := rawdbv2.GetAccountLedgerTx(db, publicKey, height, txHash); err == nil {
+						if err := json.Unmarshal(value, ledgerTx); err != nil {
+							return err
+						}
+					} else if blockHash, err := ledger.blockchain.GetShardBlockHashByView(ledger.blockchain.ShardChain[shardID].GetFinalView(), height); err == nil {
+						ledgerTx.ShardHash = blockHash.String()
+					}
+					spendingTxs[key] = ledgerTx
+					spendingTxKeys = append(spendingTxKeys, key)
+				}
+				addLedgerAmount(&ledgerTx.Spent, ledgerCoin.TokenID, ledgerCoin.Amount)
+				ledgerCoin.IsSpent = true
+				ledgerCoin.SpentTxHash = txHash.String()
+			}
+			// otherwise coin is spent in a block not synced yet, it is marked spent when the block is synced
+		}
+		if err := rawdbv2.StoreAccountLedgerCoin(batch, publicKey, keyImage.CoinPublicKey, ledgerCoin); err != nil {
+			return err
+		}
+	}
+	for _, key := range spendingTxKeys {
+		ledgerTx := spendingTxs[key]
+		txHash, _ := common.Hash{}.NewHashFromStr(ledgerTx.TxHash)
+		if err := rawdbv2.StoreAccountLedgerTx(batch, publicKey, ledgerTx.ShardHeight, *txHash, ledgerTx); err != nil {
+			return err
+		}
+	}
+	return batch.Write()
+}
+
+// findSpendingTx find tx spending the coin of key image, by the index of txs by serial number
+func (ledger *AccountLedger) findSpendingTx(keyImage []byte, tokenID common.Hash, shardID byte) (common.Hash, uint64, int, bool) {
+	db := ledger.blockchain.GetShardChainDatabase(shardID)
+	for _, id := range []common.Hash{tokenID, common.PRVCoinID, common.ConfidentialAssetID} {
+		txHash, err := rawdbv2.GetTxBySerialNumber(db, keyImage, id, shardID)
+		if err != nil {
+			continue
+		}
+		blockHash, _, tx, err := ledger.blockchain.GetTransactionByHashWithShardID(*txHash, shardID)
+		if err != nil {
+			return common.Hash{}, 0, 0, false
+		}
+		block, _, err := ledger.blockchain.GetShardBlockByHashWithShardID(blockHash, shardID)
+		if err != nil {
+			return common.Hash{}, 0, 0, false
+		}
+		return *txHash, block.Header.Height, tx.GetMetadataType(), true
+	}
+	return common.Hash{}, 0, 0, false
+}
+
+func (ledger *AccountLedger) getAccount(accessToken string, publicKey []byte) (*LedgerAccount, error) {
+	account, ok := ledger.accounts[hex.EncodeToString(publicKey)]
+	if !ok {
+		return nil, fmt.Errorf("account is not registered")
+	}
+	if !ledger.accessTokens[accessToken] || account.TokenHash != common.Hash(sha256.Sum256([]byte(accessToken))) {
+		return nil, fmt.Errorf("invalid access token")
+	}
+	return account, nil
+}
+
+func (ledger *AccountLedger) triggerSync() {
+	select {
+	case ledger.syncCh <- struct{}{}:
+	default:
+	}
+}
+
+// syncShard process finalized blocks of shard for accounts behind the final view, one block at a time,
+// so key images can be submitted between blocks
+func (ledger *AccountLedger) syncShard(shardID byte) error {
+	finalView, ok := ledger.blockchain.ShardChain[shardID].GetFinalView().(*ShardBestState)
+	if !ok {
+		return nil
+	}
+	db := ledger.blockchain.GetShardChainDatabase(shardID)
+	for {
+		hasNext, err := ledger.syncNextShardBlock(db, finalView, shardID)
+		if err != nil || !hasNext {
+			return err
+		}
+	}
+}
+
+// syncNextShardBlock process the lowest block not synced by some accounts, return false if all accounts are synced
+func (ledger *AccountLedger) syncNextShardBlock(db incdb.Database, finalView *ShardBestState, shardID byte) (bool, error) {
+	ledger.mtx.Lock()
+	defer ledger.mtx.Unlock()
+	accounts := []*LedgerAccount{}
+	height := finalView.ShardHeight + 1
+	for _, account := range ledger.accounts {
+		if account.ShardID != shardID || account.SyncedHeight >= finalView.ShardHeight {
+			continue
+		}
+		accounts = append(accounts, account)
+		if account.SyncedHeight+1 < height {
+			height = account.SyncedHeight + 1
+		}
+	}
+	if len(accounts) == 0 {
+		return false, nil
+	}
+	block, err := ledger.blockchain.GetShardBlockByView(finalView, height, shardID)
+	if err != nil {
+		return false, err
+	}
+
+	batch := db.NewBatch()
+	syncedAccounts := []*LedgerAccount{}
+	for _, account := range accounts {
+		if account.SyncedHeight >= height {
+			continue
+		}
+		if err := ledger.processShardBlock(batch, db, account, block); err != nil {
+			return false, err
+		}
+		synced := *account
+		synced.SyncedHeight = height
+		if err := rawdbv2.StoreAccountLedgerAccount(batch, account.PublicKey, synced); err != nil {
+			return false, err
+		}
+		syncedAccounts = append(syncedAccounts, account)
+	}
+	if err := batch.Write(); err != nil {
+		return false, err
+	}
+	for _, account := range syncedAccounts {
+		account.SyncedHeight = height
+	}
+	return true, nil
+}
+
+// processShardBlock store coins received by and spent from an account in a shard block, with txs of these coins
+func (ledger *AccountLedger) processShardBlock(batch incdb.KeyValueWriter, db incdb.Database, account *LedgerAccount, block *types.ShardBlock) error {
+	keySet := account.keySet()
+	height := block.Header.Height
+	blockHash := block.Hash().String()
+	for _, tx := range block.Body.Transactions {
+		ledgerTx := &LedgerTx{
+			TxHash:       tx.Hash().String(),
+			ShardHeight:  height,
+			ShardHash:    blockHash,
+			MetadataType: tx.GetMetadataType(),
+		}
+		proofs := []privacy.Proof{tx.GetProof()}
+		if txToken, ok := tx.(transaction.TransactionToken); ok {
+			proofs = []privacy.Proof{txToken.GetTxBase().GetProof(), txToken.GetTxNormal().GetProof()}
+		}
+		for _, proof := range proofs {
+			if proof == nil {
+				continue
+			}
+			for _, inputCoin := range proof.GetInputCoins() {
+				if inputCoin.GetKeyImage() == nil {
+					continue
+				}
+				if err := ledger.spendCoin(batch, db, account, inputCoin.GetKeyImage().ToBytesS(), ledgerTx); err != nil {
+					return err
+				}
+			}
+			for _, outputCoin := range proof.GetOutputCoins() {
+				if err := ledger.receiveCoin(batch, db, account, keySet, outputCoin, ledgerTx); err != nil {
+					return err
+				}
+			}
+		}
+		if err := ledger.storeLedgerTx(batch, account, *tx.Hash(), ledgerTx); err != nil {
+			return err
+		}
+	}
+
+	senderShardIDs := []int{}
+	for senderShardID := range block.Body.CrossTransactions {
+		senderShardIDs = append(senderShardIDs, int(senderShardID))
+	}
+	sort.Ints(senderShardIDs)
+	for _, senderShardID := range senderShardIDs {
+		for _, crossTransaction := range block.Body.CrossTransactions[byte(senderShardID)] {
+			ledgerTx := &LedgerTx{
+				ShardHeight:         height,
+				ShardHash:           blockHash,
+				CrossShardBlockHash: crossTransaction.BlockHash.String(),
+			}
+			outputCoins := crossTransaction.OutputCoin
+			for _, tokenPrivacyData := range crossTransaction.TokenPrivacyData {
+				outputCoins = append(outputCoins, tokenPrivacyData.OutputCoin...)
+			}
+			for _, outputCoin := range outputCoins {
+				if err := ledger.receiveCoin(batch, db, account, keySet, outputCoin, ledgerTx); err != nil {
+					return err
+				}
+			}
+			if err := ledger.storeLedgerTx(batch, account, crossTransaction.BlockHash, ledgerTx); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// receiveCoin store an output coin if it belongs to the account, amount is decrypted with view key of the account
+func (ledger *AccountLedger) receiveCoin(batch incdb.KeyValueWriter, db incdb.Database, account *LedgerAccount, keySet *incognitokey.KeySet, outputCoin privacy.Coin, ledgerTx *LedgerTx) error {
+	coinV2, ok := outputCoin.(*privacy.CoinV2)
+	if !ok {
+		return nil
+	}
+	if belongs, _ := coinV2.DoesCoinBelongToKeySet(keySet); !belongs {
+		return nil
+	}
+	coinPublicKey := coinV2.GetPublicKey().ToBytesS()
+	if has, err := rawdbv2.HasAccountLedgerCoin(db, account.PublicKey, coinPublicKey); err != nil || has {
+		return err
+	}
+	// decrypting changes the coin, which may be shared by cached block
+	clonedCoin := new(privacy.CoinV2)
+	if err := clonedCoin.SetBytes(coinV2.Bytes()); err != nil {
+		return err
+	}
+	tokenID := ledger.getTokenID(clonedCoin, keySet, account.ShardID)
+	plainCoin, err := clonedCoin.Decrypt(keySet)
+	if err != nil {
+		Logger.log.Warnf("Account ledger cannot decrypt coin %v of tx %v: %v", coinV2.GetPublicKey().String(), ledgerTx.TxHash, err)
+		return nil
+	}
+	ledgerCoin := LedgerCoin{
+		PublicKey:   base58.Base58Check{}.Encode(coinPublicKey, common.ZeroByte),
+		TokenID:     tokenID.String(),
+		Amount:      plainCoin.GetValue(),
+		ShardHeight: ledgerTx.ShardHeight,
+		TxHash:      ledgerTx.TxHash,
+	}
+	if err := rawdbv2.StoreAccountLedgerCoin(batch, account.PublicKey, coinPublicKey, ledgerCoin); err != nil {
+		return err
+	}
+	addLedgerAmount(&ledgerTx.Received, ledgerCoin.TokenID, ledgerCoin.Amount)
+	return nil
+}
+
+// spendCoin mark the coin of key image spent if the key image is submitted for a coin of the account
+func (ledger *AccountLedger) spendCoin(batch incdb.KeyValueWriter, db incdb.Database, account *LedgerAccount, keyImage []byte, ledgerTx *LedgerTx) error {
+	has, err := rawdbv2.HasAccountLedgerKeyImage(db, account.PublicKey, keyImage)
+	if err != nil || !has {
+		return err
+	}
+	coinPublicKey, err := rawdbv2.GetAccountLedgerCoinByKeyImage(db, account.PublicKey, keyImage)
+	if err != nil {
+		return err
+	}
+	value, err := rawdbv2.GetAccountLedgerCoin(db, account.PublicKey, coinPublicKey)
+	if err != nil {
+		return err
+	}
+	ledgerCoin := LedgerCoin{}
+	if err := json.Unmarshal(value, &ledgerCoin); err != nil {
+		return err
+	}
+	if ledgerCoin.IsSpent {
+		return nil
+	}
+	ledgerCoin.IsSpent = true
+	ledgerCoin.SpentTxHash = ledgerTx.TxHash
+	if err := rawdbv2.StoreAccountLedgerCoin(batch, account.PublicKey, coinPublicKey, ledgerCoin); err != nil {
+		return err
+	}
+	addLedgerAmount(&ledgerTx.Spent, ledgerCoin.TokenID, ledgerCoin.Amount)
+	return nil
+}
+
+func (ledger *AccountLedger) storeLedgerTx(batch incdb.KeyValueWriter, account *LedgerAccount, hash common.Hash, ledgerTx *LedgerTx) error {
+	if len(ledgerTx.Received) == 0 && len(ledgerTx.Spent) == 0 {
+		return nil
+	}
+	return rawdbv2.StoreAccountLedgerTx(batch, account.PublicKey, ledgerTx.ShardHeight, hash, ledgerTx)
+}
+
+// getTokenID return token id of a coin from its asset tag, asset tags of tokens are reloaded once if not found.
+// ConfidentialAssetID is returned for coin of unknown token
+func (ledger *AccountLedger) getTokenID(c *privacy.CoinV2, keySet *incognitokey.KeySet, shardID byte) common.Hash {
+	tokenID, err := c.GetTokenId(keySet, ledger.rawAssetTags)
+	if err == nil {
+		return *tokenID
+	}
+	tokenIDs, err := ledger.blockchain.ListPrivacyTokenAndBridgeTokenAndPRVByShardID(shardID)
+	if err != nil {
+		return common.ConfidentialAssetID
+	}
+	for _, id := range tokenIDs {
+		clonedTokenID := id
+		ledger.rawAssetTags[privacy.HashToPoint(clonedTokenID[:]).String()] = &clonedTokenID
+	}
+	if tokenID, err = c.GetTokenId(keySet, ledger.rawAssetTags); err != nil {
+		return common.ConfidentialAssetID
+	}
+	return *tokenID
+}
+
+func addLedgerAmount(amounts *map[string]uint64, tokenID string, amount uint64) {
+	if *amounts == nil {
+		*amounts = make(map[string]uint64)
+	}
+	(*amounts)[tokenID] += amount
+}
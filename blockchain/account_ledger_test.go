@@ -0,0 +1,212 @@
+package blockchain
+
+import (
+	"encoding/hex"
+	"io/ioutil"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/config"
+	"github.com/incognitochain/incognito-chain/dataaccessobject/rawdbv2"
+	"github.com/incognitochain/incognito-chain/incdb"
+	_ "github.com/incognitochain/incognito-chain/incdb/lvdb"
+	"github.com/incognitochain/incognito-chain/incognitokey"
+	"github.com/incognitochain/incognito-chain/privacy"
+)
+
+var (
+	testLedgerToken1 = strings.Repeat("a1", 32)
+	testLedgerToken2 = strings.Repeat("b2", 32)
+)
+
+// newTestAccountLedger create account ledger of access tokens on a temporary database shared by all shards
+func newTestAccountLedger(t *testing.T, accessTokens ...string) (*AccountLedger, func()) {
+	config.AbortParam()
+	common.MaxShardNumber = 8
+	dbPath, err := ioutil.TempDir(os.TempDir(), "account_ledger_test_")
+	if err != nil {
+		t.Fatal(err)
+	}
+	db, err := incdb.Open("leveldb", dbPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	dbs := make(map[int]incdb.Database)
+	for shardID := 0; shardID < common.MaxShardNumber; shardID++ {
+		dbs[shardID] = db
+	}
+	ledger := &AccountLedger{
+		blockchain:   &BlockChain{config: Config{DataBase: dbs}},
+		accessTokens: make(map[string]bool),
+		accounts:     make(map[string]*LedgerAccount),
+		rawAssetTags: make(map[string]*common.Hash),
+		syncCh:       make(chan struct{}, 1),
+	}
+	for _, token := range accessTokens {
+		ledger.accessTokens[token] = true
+	}
+	return ledger, func() {
+		db.Close()
+		os.RemoveAll(dbPath)
+	}
+}
+
+func newTestLedgerKeySet(seed string) *incognitokey.KeySet {
+	return new(incognitokey.KeySet).GenerateKey([]byte(seed))
+}
+
+func newTestLedgerCoin(t *testing.T, keySet *incognitokey.KeySet, amount uint64) *privacy.CoinV2 {
+	paymentInfo := &privacy.PaymentInfo{PaymentAddress: keySet.PaymentAddress, Amount: amount}
+	c, err := privacy.NewCoinFromPaymentInfo(privacy.NewCoinParams().FromPaymentInfo(paymentInfo))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := c.ConcealOutputCoin(keySet.PaymentAddress.GetPublicView()); err != nil {
+		t.Fatal(err)
+	}
+	return c
+}
+
+func TestNewAccountLedgerInvalidAccessTokens(t *testing.T) {
+	for _, tokens := range []string{
+		"",
+		" , ",
+		"not a hex token",
+		strings.Repeat("a1", 31),
+		testLedgerToken1 + "," + strings.Repeat("b2", 33),
+	} {
+		if _, err := NewAccountLedger(&BlockChain{}, tokens); err == nil {
+			t.Errorf("access tokens %q should be rejected", tokens)
+		}
+	}
+}
+
+func TestAccountLedgerAccessToken(t *testing.T) {
+	ledger, cleanUp := newTestAccountLedger(t, testLedgerToken1, testLedgerToken2)
+	defer cleanUp()
+	keySet := newTestLedgerKeySet("account ledger user")
+	publicKey := keySet.PaymentAddress.Pk
+
+	if _, err := ledger.RegisterAccount(strings.Repeat("c3", 32), keySet.OTAKey, keySet.ReadonlyKey, 1); err == nil {
+		t.Fatal("account registered with a token not configured")
+	}
+	otherKeySet := newTestLedgerKeySet("another user")
+	if _, err := ledger.RegisterAccount(testLedgerToken1, keySet.OTAKey, otherKeySet.ReadonlyKey, 1); err == nil {
+		t.Fatal("account registered with view key of another account")
+	}
+	account, err := ledger.RegisterAccount(testLedgerToken1, keySet.OTAKey, keySet.ReadonlyKey, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if account.ShardID != common.GetShardIDFromLastByte(publicKey[len(publicKey)-1]) || account.SyncedHeight != 0 {
+		t.Fatalf("wrong registered account %+v", account)
+	}
+	if _, err := ledger.RegisterAccount(testLedgerToken1, keySet.OTAKey, keySet.ReadonlyKey, 100); err != nil {
+		t.Fatalf("register account again with the same token: %v", err)
+	}
+	if _, err := ledger.RegisterAccount(testLedgerToken2, keySet.OTAKey, keySet.ReadonlyKey, 1); err == nil {
+		t.Fatal("account registered again with another token")
+	}
+
+	if _, err := ledger.GetAccount(testLedgerToken1, publicKey); err != nil {
+		t.Fatal(err)
+	}
+	for _, token := range []string{testLedgerToken2, strings.Repeat("c3", 32), ""} {
+		if _, err := ledger.GetAccount(token, publicKey); err == nil {
+			t.Errorf("account is queried with token %q", token)
+		}
+		if _, err := ledger.GetCoins(token, publicKey); err == nil {
+			t.Errorf("coins are queried with token %q", token)
+		}
+	}
+	if _, err := ledger.GetAccount(testLedgerToken1, otherKeySet.PaymentAddress.Pk); err == nil {
+		t.Fatal("account not registered is queried")
+	}
+
+	// the token registering the account is removed from config of node
+	reloaded, cleanUpReloaded := newTestAccountLedger(t, testLedgerToken2)
+	defer cleanUpReloaded()
+	reloaded.accounts = ledger.accounts
+	if _, err := reloaded.GetAccount(testLedgerToken1, publicKey); err == nil {
+		t.Fatal("account is queried with an expired token")
+	}
+	if _, err := reloaded.GetBalances(testLedgerToken2, publicKey); err == nil {
+		t.Fatal("account is queried with a token not registering it")
+	}
+}
+
+func TestAccountLedgerReceiveAndSpendCoin(t *testing.T) {
+	ledger, cleanUp := newTestAccountLedger(t, testLedgerToken1)
+	defer cleanUp()
+	keySet := newTestLedgerKeySet("account ledger user")
+	publicKey := keySet.PaymentAddress.Pk
+	account, err := ledger.RegisterAccount(testLedgerToken1, keySet.OTAKey, keySet.ReadonlyKey, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	account = ledger.accounts[hex.EncodeToString(publicKey)]
+	db := ledger.blockchain.GetShardChainDatabase(account.ShardID)
+
+	receivedCoin := newTestLedgerCoin(t, keySet, 1000)
+	otherCoin := newTestLedgerCoin(t, newTestLedgerKeySet("another user"), 2000)
+	receivingTx := &LedgerTx{TxHash: common.HashH([]byte("receiving tx")).String(), ShardHeight: 10}
+	batch := db.NewBatch()
+	for _, c := range []*privacy.CoinV2{receivedCoin, otherCoin} {
+		if err := ledger.receiveCoin(batch, db, account, account.keySet(), c, receivingTx); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := batch.Write(); err != nil {
+		t.Fatal(err)
+	}
+	if receivingTx.Received[common.PRVIDStr] != 1000 {
+		t.Fatalf("wrong received amounts %+v", receivingTx.Received)
+	}
+	coins, err := ledger.GetCoins(testLedgerToken1, publicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(coins) != 1 || coins[0].Amount != 1000 || coins[0].TokenID != common.PRVIDStr || coins[0].TxHash != receivingTx.TxHash || coins[0].IsSpent {
+		t.Fatalf("wrong received coins %+v", coins)
+	}
+
+	// coin is spent only after its key image is submitted
+	keyImage, err := receivedCoin.ParseKeyImageWithPrivateKey(keySet.PrivateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	spendingTx := &LedgerTx{TxHash: common.HashH([]byte("spending tx")).String(), ShardHeight: 11}
+	if err := ledger.spendCoin(db, db, account, keyImage.ToBytesS(), spendingTx); err != nil {
+		t.Fatal(err)
+	}
+	if spendingTx.IsOutgoing() {
+		t.Fatal("coin is spent without its key image")
+	}
+	if err := rawdbv2.StoreAccountLedgerKeyImage(db, publicKey, keyImage.ToBytesS(), receivedCoin.GetPublicKey().ToBytesS()); err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 2; i++ {
+		if err := ledger.spendCoin(db, db, account, keyImage.ToBytesS(), spendingTx); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if spendingTx.Spent[common.PRVIDStr] != 1000 {
+		t.Fatalf("wrong spent amounts %+v", spendingTx.Spent)
+	}
+	coins, err = ledger.GetCoins(testLedgerToken1, publicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(coins) != 1 || !coins[0].IsSpent || coins[0].SpentTxHash != spendingTx.TxHash {
+		t.Fatalf("wrong spent coins %+v", coins)
+	}
+	balances, err := ledger.GetBalances(testLedgerToken1, publicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(balances) != 0 {
+		t.Fatalf("wrong balances %+v", balances)
+	}
+}
@@ -0,0 +1,321 @@
+package blockchain
+
+import (
+	"encoding/json"
+	"math/big"
+	"sort"
+
+	"github.com/incognitochain/incognito-chain/blockchain/committeestate"
+	"github.com/incognitochain/incognito-chain/blockchain/types"
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/common/base58"
+	"github.com/incognitochain/incognito-chain/config"
+	"github.com/incognitochain/incognito-chain/dataaccessobject/rawdbv2"
+	"github.com/incognitochain/incognito-chain/dataaccessobject/statedb"
+	"github.com/incognitochain/incognito-chain/incdb"
+	"github.com/incognitochain/incognito-chain/instruction"
+)
+
+// types of delegation event kept in delegation index
+const (
+	DelegationEventRedelegate     = "redelegate"
+	DelegationEventUndelegate     = "undelegate"
+	DelegationEventWithdrawReward = "withdrawreward"
+)
+
+// DelegationEvent is a change of delegation of a reward receiver in a beacon block
+type DelegationEvent struct {
+	Type                string
+	BeaconHeight        uint64
+	BeaconHash          string
+	Epoch               uint64
+	AffectEpoch         uint64 `json:",omitempty"` // first epoch delegation reward is counted with new delegate
+	Delegator           string `json:",omitempty"` // committee public key of shard staker
+	Delegate            string `json:",omitempty"`
+	DelegateUID         string `json:",omitempty"`
+	PreviousDelegate    string `json:",omitempty"`
+	PreviousDelegateUID string `json:",omitempty"`
+	RewardAmount        uint64 `json:",omitempty"`
+	TxRequestID         string `json:",omitempty"`
+}
+
+// SharePricePoint is share price of a beacon uid, used from Epoch until next point
+type SharePricePoint struct {
+	Epoch        uint64
+	BeaconHeight uint64
+	BeaconHash   string
+	Price        uint64
+}
+
+// DelegationEpochReward is value and reward accrual of a delegation in an epoch
+type DelegationEpochReward struct {
+	Epoch       uint64
+	Delegator   string // committee public key of shard staker
+	DelegateUID string
+	SharePrice  uint64
+	Value       uint64
+	Reward      uint64
+}
+
+type delegationPeriod struct {
+	startEpoch  uint64
+	delegateUID string // empty if delegation ended
+}
+
+// GetDelegationEpochRewards replay delegation events to find delegate of each delegator in each epoch of [fromEpoch, toEpoch],
+// value in an epoch is amount adjusted by share price since the start of delegation,
+// reward of an epoch is the increase of value at the share price of next epoch
+func GetDelegationEpochRewards(
+	events []DelegationEvent,
+	sharePrices map[string][]SharePricePoint,
+	amount, initSharePrice uint64,
+	fromEpoch, toEpoch uint64,
+) []DelegationEpochReward {
+	res := []DelegationEpochReward{}
+	periods := make(map[string][]delegationPeriod)
+	for _, event := range events {
+		switch event.Type {
+		case DelegationEventRedelegate:
+			periods[event.Delegator] = append(periods[event.Delegator], delegationPeriod{startEpoch: event.AffectEpoch, delegateUID: event.DelegateUID})
+		case DelegationEventUndelegate:
+			periods[event.Delegator] = append(periods[event.Delegator], delegationPeriod{startEpoch: event.AffectEpoch})
+		}
+	}
+	delegators := []string{}
+	for delegator := range periods {
+		delegators = append(delegators, delegator)
+	}
+	sort.Strings(delegators)
+
+	priceAt := func(uid string, epoch uint64) uint64 {
+		price := initSharePrice
+		for _, point := range sharePrices[uid] {
+			if point.Epoch > epoch {
+				break
+			}
+			price = point.Price
+		}
+		return price
+	}
+	for epoch := fromEpoch; epoch <= toEpoch; epoch++ {
+		for _, delegator := range delegators {
+			var current *delegationPeriod
+			for i, period := range periods[delegator] {
+				if period.startEpoch > epoch {
+					break
+				}
+				current = &periods[delegator][i]
+			}
+			if current == nil || current.delegateUID == "" {
+				continue
+			}
+			startPrice := priceAt(current.delegateUID, current.startEpoch)
+			price := priceAt(current.delegateUID, epoch)
+			nextPrice := priceAt(current.delegateUID, epoch+1)
+			value := delegationValue(amount, price, startPrice)
+			reward := uint64(0)
+			if nextValue := delegationValue(amount, nextPrice, startPrice); nextValue > value {
+				reward = nextValue - value
+			}
+			res = append(res, DelegationEpochReward{
+				Epoch:       epoch,
+				Delegator:   delegator,
+				DelegateUID: current.delegateUID,
+				SharePrice:  price,
+				Value:       value,
+				Reward:      reward,
+			})
+		}
+	}
+	return res
+}
+
+// delegationValue return amount adjusted by share price since the start of delegation
+func delegationValue(amount, price, startPrice uint64) uint64 {
+	if startPrice == 0 {
+		return 0
+	}
+	return new(big.Int).Div(
+		new(big.Int).Mul(new(big.Int).SetUint64(amount), new(big.Int).SetUint64(price)),
+		new(big.Int).SetUint64(startPrice),
+	).Uint64()
+}
+
+// storeDelegationIndex index redelegation, end of delegation, delegation reward withdrawal and share price update of beacon block,
+// delegation events are grouped by reward receiver of shard staker
+func (blockchain *BlockChain) storeDelegationIndex(
+	batch incdb.KeyValueWriter,
+	curView *BeaconBestState,
+	newBestState *BeaconBestState,
+	beaconBlock *types.BeaconBlock,
+	committeeChange *committeestate.CommitteeChange,
+) error {
+	if newBestState.CommitteeStateVersion() < committeestate.STAKING_FLOW_V4 {
+		return nil
+	}
+	blockHash := *beaconBlock.Hash()
+	height := beaconBlock.Header.Height
+	epoch := beaconBlock.Header.Epoch
+	prevStateDB := curView.consensusStateDB
+	newStateDB := newBestState.consensusStateDB
+
+	receivers := make(map[string][]byte)
+	events := make(map[string][]DelegationEvent)
+	addEvent := func(receiverPublicKey []byte, event DelegationEvent) {
+		event.BeaconHeight = height
+		event.BeaconHash = blockHash.String()
+		event.Epoch = epoch
+		k := base58.Base58Check{}.Encode(receiverPublicKey, common.Base58Version)
+		receivers[k] = receiverPublicKey
+		events[k] = append(events[k], event)
+	}
+
+	for _, inst := range beaconBlock.Body.Instructions {
+		if len(inst) == 0 {
+			continue
+		}
+		switch inst[0] {
+		case instruction.RE_DELEGATE:
+			reDelegateInst := instruction.ImportReDelegateInstructionFromString(inst)
+			for i, delegator := range reDelegateInst.CommitteePublicKeys {
+				info, has, err := statedb.GetShardStakerInfo(newStateDB, delegator)
+				// redelegation could be skipped by committee state
+				if err != nil || !has || info.GetDelegateUID() != reDelegateInst.DelegateUIDList[i] {
+					continue
+				}
+				event := DelegationEvent{
+					Type:        DelegationEventRedelegate,
+					AffectEpoch: epoch + 1,
+					Delegator:   delegator,
+					Delegate:    info.GetDelegate(),
+					DelegateUID: info.GetDelegateUID(),
+				}
+				if prevInfo, has, err := statedb.GetShardStakerInfo(prevStateDB, delegator); err == nil && has {
+					event.PreviousDelegate = prevInfo.GetDelegate()
+					event.PreviousDelegateUID = prevInfo.GetDelegateUID()
+				}
+				addEvent(info.RewardReceiver().Pk, event)
+			}
+		case instruction.MINT_DREWARD_ACTION:
+			mintDRewardInst, err := instruction.ValidateAndImportMintDelegationRewardInstructionFromString(inst)
+			if err != nil {
+				return err
+			}
+			for i, paymentAddress := range mintDRewardInst.PaymentAddressesStruct {
+				addEvent(paymentAddress.Pk, DelegationEvent{
+					Type:         DelegationEventWithdrawReward,
+					RewardAmount: mintDRewardInst.RewardAmount[i],
+					TxRequestID:  mintDRewardInst.TxRequestIDs[i],
+				})
+			}
+		case instruction.SHARE_PRICE:
+			sharePriceInst, err := instruction.ValidateAndImportSharePriceInstructionFromString(inst)
+			if err != nil {
+				return err
+			}
+			for uid, price := range sharePriceInst.GetValue() {
+				point := SharePricePoint{
+					Epoch:        epoch + 1,
+					BeaconHeight: height,
+					BeaconHash:   blockHash.String(),
+					Price:        price,
+				}
+				if err := rawdbv2.StoreSharePriceHistory(batch, common.HashH([]byte(uid)), height, blockHash, point); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	// delegation of returned staker ends at first block of epoch, same as ProcessDelegateRewardForReturnValidator
+	if committeeChange != nil && height%config.Param().EpochParam.NumberOfBlockInEpoch == 1 {
+		for _, delegator := range committeeChange.RemovedStaker {
+			prevInfo, has, err := statedb.GetShardStakerInfo(prevStateDB, delegator)
+			if err != nil || !has || prevInfo.GetDelegate() == "" {
+				continue
+			}
+			addEvent(prevInfo.RewardReceiver().Pk, DelegationEvent{
+				Type:                DelegationEventUndelegate,
+				AffectEpoch:         epoch,
+				Delegator:           delegator,
+				PreviousDelegate:    prevInfo.GetDelegate(),
+				PreviousDelegateUID: prevInfo.GetDelegateUID(),
+			})
+		}
+	}
+
+	for k, receiverEvents := range events {
+		if err := rawdbv2.StoreDelegationEvents(batch, receivers[k], height, blockHash, receiverEvents); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// GetDelegationEvents return delegation events of reward receiver in canonical beacon blocks, sorted by beacon height
+func (blockchain *BlockChain) GetDelegationEvents(receiverPublicKey []byte) ([]DelegationEvent, error) {
+	values, err := rawdbv2.GetDelegationEvents(blockchain.GetBeaconChainDatabase(), receiverPublicKey)
+	if err != nil {
+		return nil, err
+	}
+	isCanonical := blockchain.newCanonicalBeaconBlockChecker()
+	res := []DelegationEvent{}
+	for _, value := range values {
+		blockEvents := []DelegationEvent{}
+		if err := json.Unmarshal(value, &blockEvents); err != nil {
+			return nil, err
+		}
+		if len(blockEvents) == 0 || !isCanonical(blockEvents[0].BeaconHeight, blockEvents[0].BeaconHash) {
+			continue
+		}
+		res = append(res, blockEvents...)
+	}
+	sort.SliceStable(res, func(i, j int) bool {
+		return res[i].BeaconHeight < res[j].BeaconHeight
+	})
+	return res, nil
+}
+
+// GetSharePriceHistory return share prices of beacon uid updated in canonical beacon blocks, sorted by epoch
+func (blockchain *BlockChain) GetSharePriceHistory(beaconUID string) ([]SharePricePoint, error) {
+	values, err := rawdbv2.GetSharePriceHistory(blockchain.GetBeaconChainDatabase(), common.HashH([]byte(beaconUID)))
+	if err != nil {
+		return nil, err
+	}
+	isCanonical := blockchain.newCanonicalBeaconBlockChecker()
+	res := []SharePricePoint{}
+	for _, value := range values {
+		point := SharePricePoint{}
+		if err := json.Unmarshal(value, &point); err != nil {
+			return nil, err
+		}
+		if !isCanonical(point.BeaconHeight, point.BeaconHash) {
+			continue
+		}
+		res = append(res, point)
+	}
+	sort.Slice(res, func(i, j int) bool {
+		return res[i].Epoch < res[j].Epoch
+	})
+	return res, nil
+}
+
+// newCanonicalBeaconBlockChecker return a function checking if block of height and hash is in current beacon chain,
+// final and best view are taken once so all checks use the same chain
+func (blockchain *BlockChain) newCanonicalBeaconBlockChecker() func(height uint64, hash string) bool {
+	finalView, bestView := blockchain.BeaconChain.GetFinalView(), blockchain.BeaconChain.GetBestView()
+	canonicalHash := make(map[uint64]string)
+	return func(height uint64, hash string) bool {
+		if height > bestView.GetHeight() {
+			return false
+		}
+		if _, ok := canonicalHash[height]; !ok {
+			h, err := blockchain.GetBeaconBlockHashByHeight(finalView, bestView, height)
+			if err != nil {
+				return false
+			}
+			canonicalHash[height] = h.String()
+		}
+		return canonicalHash[height] == hash
+	}
+}
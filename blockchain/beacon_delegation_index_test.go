@@ -0,0 +1,93 @@
+package blockchain
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestGetDelegationEpochRewards(t *testing.T) {
+	events := []DelegationEvent{
+		{Type: DelegationEventRedelegate, BeaconHeight: 10, AffectEpoch: 2, Delegator: "shard1", DelegateUID: "uidA"},
+		{Type: DelegationEventWithdrawReward, BeaconHeight: 15, RewardAmount: 5},
+		{Type: DelegationEventRedelegate, BeaconHeight: 30, AffectEpoch: 4, Delegator: "shard1", DelegateUID: "uidB", PreviousDelegateUID: "uidA"},
+		{Type: DelegationEventUndelegate, BeaconHeight: 41, AffectEpoch: 5, Delegator: "shard1", PreviousDelegateUID: "uidB"},
+		{Type: DelegationEventRedelegate, BeaconHeight: 42, AffectEpoch: 5, Delegator: "shard2", DelegateUID: "uidA"},
+	}
+	sharePrices := map[string][]SharePricePoint{
+		"uidA": {{Epoch: 3, Price: 110}, {Epoch: 4, Price: 120}, {Epoch: 6, Price: 180}},
+		"uidB": {{Epoch: 5, Price: 150}},
+	}
+	tests := []struct {
+		name      string
+		events    []DelegationEvent
+		fromEpoch uint64
+		toEpoch   uint64
+		want      []DelegationEpochReward
+	}{
+		{
+			name:      "no delegation",
+			events:    []DelegationEvent{},
+			fromEpoch: 1,
+			toEpoch:   5,
+			want:      []DelegationEpochReward{},
+		},
+		{
+			name:      "redelegate and undelegate",
+			events:    events[:4],
+			fromEpoch: 1,
+			toEpoch:   5,
+			want: []DelegationEpochReward{
+				{Epoch: 2, Delegator: "shard1", DelegateUID: "uidA", SharePrice: 100, Value: 1000, Reward: 100},
+				{Epoch: 3, Delegator: "shard1", DelegateUID: "uidA", SharePrice: 110, Value: 1100, Reward: 100},
+				{Epoch: 4, Delegator: "shard1", DelegateUID: "uidB", SharePrice: 100, Value: 1000, Reward: 500},
+			},
+		},
+		{
+			name:      "value from share price at start of delegation",
+			events:    events,
+			fromEpoch: 5,
+			toEpoch:   6,
+			want: []DelegationEpochReward{
+				{Epoch: 5, Delegator: "shard2", DelegateUID: "uidA", SharePrice: 120, Value: 1000, Reward: 500},
+				{Epoch: 6, Delegator: "shard2", DelegateUID: "uidA", SharePrice: 180, Value: 1500, Reward: 0},
+			},
+		},
+		{
+			name:      "epochs before delegation",
+			events:    events,
+			fromEpoch: 0,
+			toEpoch:   1,
+			want:      []DelegationEpochReward{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := GetDelegationEpochRewards(tt.events, sharePrices, 1000, 100, tt.fromEpoch, tt.toEpoch)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("GetDelegationEpochRewards() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDelegationValue(t *testing.T) {
+	tests := []struct {
+		name       string
+		amount     uint64
+		price      uint64
+		startPrice uint64
+		want       uint64
+	}{
+		{"unchanged price", 1750000000000, 1000000, 1000000, 1750000000000},
+		{"price increase", 1750000000000, 1000500, 1000000, 1750875000000},
+		{"no overflow", 1 << 62, 1 << 20, 1 << 21, 1 << 61},
+		{"zero start price", 1000, 100, 0, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := delegationValue(tt.amount, tt.price, tt.startPrice); got != tt.want {
+				t.Errorf("delegationValue() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
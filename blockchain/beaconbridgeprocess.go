@@ -87,6 +87,9 @@ func (blockchain *BlockChain) processBridgeInstructions(curView *BeaconBestState
 
 		case strconv.Itoa(metadata.BurningNearConfirmMeta):
 			updatingInfoByTokenID, err = blockchain.processBurningReq(curView, inst, updatingInfoByTokenID, common.NEARPrefix, bridgeAggUnshieldTxIDs)
+
+		case strconv.Itoa(metadata.BurningSOLConfirmMeta):
+			updatingInfoByTokenID, err = blockchain.processBurningReq(curView, inst, updatingInfoByTokenID, common.SOLPrefix, bridgeAggUnshieldTxIDs)
 		}
 		if err != nil {
 			return err
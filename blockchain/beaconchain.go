@@ -41,6 +41,9 @@ func NewBeaconChain(multiView multiview.MultiView, blockGen *BlockGenerator, blo
 	cfg := config.Config()
 	ffPath := path.Join(cfg.DataDir, cfg.DatabaseDir, "beacon", "blockstorage")
 	bs := NewBlockStorage(blockchain.GetBeaconChainDatabase(), ffPath, -1, false)
+	if cfg.FlatFileRecompress {
+		bs.StartRecompressJob(time.Hour)
+	}
 	chain := &BeaconChain{
 		multiView:           multiView,
 		BlockGen:            blockGen,
@@ -431,6 +434,10 @@ func (chain *BeaconChain) ValidatePreSignBlock(block types.BlockInterface, signi
 // 	return chain.Blockchain.InsertBeaconBlock(beaconBlock, false)
 // }
 
+func (chain *BeaconChain) ValidateProducerSig(block types.BlockInterface) error {
+	return chain.Blockchain.config.ConsensusEngine.ValidateProducerSig(block, chain.GetConsensusType())
+}
+
 func (chain *BeaconChain) ValidateBlockSignatures(block types.BlockInterface, committees []incognitokey.CommitteePublicKey, numOfFixNode int) error {
 	if err := chain.Blockchain.config.ConsensusEngine.ValidateProducerSig(block, chain.GetConsensusType()); err != nil {
 		Logger.log.Info("[dcs] err:", err)
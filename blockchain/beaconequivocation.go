@@ -0,0 +1,169 @@
+package blockchain
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/incognitochain/incognito-chain/blockchain/committeestate"
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/config"
+	"github.com/incognitochain/incognito-chain/consensus_v2/consensustypes"
+	"github.com/incognitochain/incognito-chain/consensus_v2/equivocation"
+	"github.com/incognitochain/incognito-chain/dataaccessobject/statedb"
+	"github.com/incognitochain/incognito-chain/incognitokey"
+	"github.com/incognitochain/incognito-chain/instruction"
+)
+
+func (curView *BeaconBestState) isEquivocationSlashingEnabled() bool {
+	return curView.TriggeredFeature[config.EQUIVOCATION_SLASHING] != 0 &&
+		curView.beaconCommitteeState.Version() == committeestate.STAKING_FLOW_V4
+}
+
+// maxEquivocationEvidenceAge is the max number of timeslots from the votes of an evidence
+// to the beacon block that includes it, about two epochs
+func maxEquivocationEvidenceAge() int64 {
+	return 2 * int64(config.Param().EpochParam.NumberOfBlockInEpoch)
+}
+
+// checkEquivocationEvidenceState reject evidence that is expired at timeSlot
+// or has a vote of evidence already processed in beacon state
+func (curView *BeaconBestState) checkEquivocationEvidenceState(evidence *consensustypes.EquivocationEvidence, timeSlot int64) error {
+	if timeSlot-evidence.TimeSlot() > maxEquivocationEvidenceAge() {
+		return fmt.Errorf("equivocation evidence of %+v at timeslot %+v is expired at timeslot %+v",
+			evidence.Validator(), evidence.TimeSlot(), timeSlot)
+	}
+	for _, voteHash := range evidence.VoteHashes() {
+		processed, err := statedb.HasEquivocationVote(curView.consensusStateDB, voteHash)
+		if err != nil {
+			return err
+		}
+		if processed {
+			return fmt.Errorf("vote %+v of %+v is already in processed equivocation evidence", voteHash.String(), evidence.Validator())
+		}
+	}
+	return nil
+}
+
+// getCandidateByBLS find committee public key of a validator (committee, substitute, candidate) from its bls key
+func (curView *BeaconBestState) getCandidateByBLS(blsKey string) (string, *incognitokey.CommitteePublicKey, error) {
+	for _, v := range curView.getAllCommitteeValidatorCandidateFlattenList() {
+		cpk := incognitokey.CommitteePublicKey{}
+		if err := cpk.FromString(v); err != nil {
+			return "", nil, err
+		}
+		if cpk.GetMiningKeyBase58(common.BlsConsensus) == blsKey {
+			return v, &cpk, nil
+		}
+	}
+	return "", nil, fmt.Errorf("validator %+v is not in any committee or candidate list", blsKey)
+}
+
+// VerifyEquivocationEvidence check evidence against the best beacon view,
+// return committee public key of the offender
+func (blockchain *BlockChain) VerifyEquivocationEvidence(evidence *consensustypes.EquivocationEvidence) (string, error) {
+	bestView := blockchain.BeaconChain.GetBestView().(*BeaconBestState)
+	if !bestView.isEquivocationSlashingEnabled() {
+		return "", errors.New("equivocation slashing is not enabled")
+	}
+	if err := evidence.VerifyConflict(); err != nil {
+		return "", err
+	}
+	if err := bestView.checkEquivocationEvidenceState(evidence, bestView.GetCurrentTimeSlot()); err != nil {
+		return "", err
+	}
+	cpkStr, cpk, err := bestView.getCandidateByBLS(evidence.Validator())
+	if err != nil {
+		return "", err
+	}
+	if err := evidence.Verify(*cpk); err != nil {
+		return "", err
+	}
+	return cpkStr, nil
+}
+
+// AddEquivocationEvidence verify evidence from message then add it to evidence pool
+func (blockchain *BlockChain) AddEquivocationEvidence(evidence *consensustypes.EquivocationEvidence) error {
+	cpk, err := blockchain.VerifyEquivocationEvidence(evidence)
+	if err != nil {
+		return err
+	}
+	equivocation.DefaultEvidencePool.AddEvidence(cpk, evidence)
+	return nil
+}
+
+// generateEquivocationInstructions build instructions from the evidence pool for the beacon block at timeSlot,
+// expired and processed evidences are removed from the pool
+func (curView *BeaconBestState) generateEquivocationInstructions(timeSlot int64) [][]string {
+	instructions := [][]string{}
+	if !curView.isEquivocationSlashingEnabled() {
+		return instructions
+	}
+	staleOffenders := []string{}
+	validators := curView.getAllCommitteeValidatorCandidateFlattenList()
+	for _, inst := range equivocation.DefaultEvidencePool.Instructions(validators) {
+		if err := curView.checkEquivocationEvidenceState(inst.Evidence, timeSlot); err != nil {
+			Logger.log.Error(err)
+			staleOffenders = append(staleOffenders, inst.PublicKey)
+			continue
+		}
+		if err := inst.Verify(); err != nil {
+			Logger.log.Error(err)
+			continue
+		}
+		instructions = append(instructions, inst.ToString())
+	}
+	if len(staleOffenders) > 0 {
+		equivocation.DefaultEvidencePool.RemoveValidators(staleOffenders)
+	}
+	return instructions
+}
+
+// filterAndVerifyEquivocationInstruction verify the equivocation instructions of the beacon block at timeSlot
+func (curView *BeaconBestState) filterAndVerifyEquivocationInstruction(instructions [][]string, timeSlot int64) ([][]string, error) {
+	equivocationInstructions := [][]string{}
+	offenders := make(map[string]bool)
+	validators := make(map[string]bool)
+	for _, v := range curView.getAllCommitteeValidatorCandidateFlattenList() {
+		validators[v] = true
+	}
+	for _, v := range instructions {
+		if v[0] != instruction.EQUIVOCATION_ACTION {
+			continue
+		}
+		if !curView.isEquivocationSlashingEnabled() {
+			return nil, errors.New("equivocation instruction is not enabled")
+		}
+		inst, err := instruction.ValidateAndImportEquivocationInstructionFromString(v)
+		if err != nil {
+			return nil, err
+		}
+		if !validators[inst.PublicKey] {
+			return nil, fmt.Errorf("equivocation validator %+v is not in any committee or candidate list", inst.PublicKey)
+		}
+		if offenders[inst.PublicKey] {
+			return nil, fmt.Errorf("duplicate equivocation instruction of validator %+v", inst.PublicKey)
+		}
+		if err := curView.checkEquivocationEvidenceState(inst.Evidence, timeSlot); err != nil {
+			return nil, err
+		}
+		if err := inst.Verify(); err != nil {
+			return nil, err
+		}
+		offenders[inst.PublicKey] = true
+		equivocationInstructions = append(equivocationInstructions, v)
+	}
+	return equivocationInstructions, nil
+}
+
+// removeProcessedEquivocationEvidences remove evidences of offenders that are included in beacon block
+func (beaconBestState *BeaconBestState) removeProcessedEquivocationEvidences(instructions [][]string) {
+	offenders := []string{}
+	for _, v := range instructions {
+		if len(v) > 1 && v[0] == instruction.EQUIVOCATION_ACTION {
+			offenders = append(offenders, v[1])
+		}
+	}
+	if len(offenders) > 0 && equivocation.DefaultEvidencePool != nil {
+		equivocation.DefaultEvidencePool.RemoveValidators(offenders)
+	}
+}
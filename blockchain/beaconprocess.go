@@ -1,7 +1,6 @@
 package blockchain
 
 import (
-	"bytes"
 	"context"
 	"encoding/json"
 	"errors"
@@ -188,6 +187,7 @@ func (blockchain *BlockChain) InsertBeaconBlock(beaconBlock *types.BeaconBlock,
 
 	go blockchain.config.PubSubManager.PublishMessage(pubsub.NewMessage(pubsub.NewBeaconBlockTopic, beaconBlock))
 	go blockchain.config.PubSubManager.PublishMessage(pubsub.NewMessage(pubsub.BeaconBeststateTopic, newBestState))
+	go blockchain.publishFeatureThresholdEvents(newBestState)
 	// For masternode: broadcast new committee to highways
 	beaconInsertBlockTimer.UpdateSince(startTimeStoreBeaconBlock)
 	if newBestState.TriggeredFeature["fixbulletproofv2"] != 0 {
@@ -264,14 +264,8 @@ func (blockchain *BlockChain) verifyPreProcessingBeaconBlock(beaconBlock *types.
 		}
 	}
 	// Check if InstructionMerkleRoot is the root of merkle tree containing all instructions in this block
-	flattenInsts, err := FlattenAndConvertStringInst(beaconBlock.Body.Instructions)
-	if err != nil {
-		return NewBlockChainError(FlattenAndConvertStringInstError, err)
-	}
-	root := types.GetKeccak256MerkleRoot(flattenInsts)
-
-	if !bytes.Equal(root, beaconBlock.Header.InstructionMerkleRoot[:]) {
-		return NewBlockChainError(FlattenAndConvertStringInstError, fmt.Errorf("Expect Instruction Merkle Root in Beacon Block Header to be %+v but get %+v", string(beaconBlock.Header.InstructionMerkleRoot[:]), string(root)))
+	if err := verifyBeaconInstructionMerkleRoot(beaconBlock); err != nil {
+		return err
 	}
 	// if pool does not have one of needed block, fail to verify
 	beaconVerifyPreprocesingTimer.UpdateSince(startTimeVerifyPreProcessingBeaconBlock)
@@ -345,6 +339,13 @@ func (blockchain *BlockChain) verifyPreProcessingBeaconBlockForSigning(curView *
 
 	instructions = addFinishInstruction(instructions, finishSyncInstruction)
 
+	equivocationInstructions, err := curView.filterAndVerifyEquivocationInstruction(
+		beaconBlock.Body.Instructions, curView.CalculateTimeSlot(beaconBlock.Header.Timestamp))
+	if err != nil {
+		return NewBlockChainError(EquivocationInstructionError, err)
+	}
+	instructions = append(instructions, equivocationInstructions...)
+
 	enableFeatureInstructions := filterEnableFeatureInstruction(beaconBlock.Body.Instructions)
 	instructions = append(instructions, enableFeatureInstructions...)
 
@@ -723,6 +724,7 @@ func (curView *BeaconBestState) updateBeaconBestState(
 	}
 
 	beaconBestState.removeFinishedSyncValidators(committeeChange)
+	beaconBestState.removeProcessedEquivocationEvidences(beaconBlock.Body.Instructions)
 	beaconUpdateBestStateTimer.UpdateSince(startTimeUpdateBeaconBestState)
 
 	return beaconBestState, hashes, committeeChange, incurredInstructions, nil
@@ -970,6 +972,9 @@ func (blockchain *BlockChain) processStoreBeaconBlock(
 			return err
 		}
 		Logger.log.Infof("Store Slashing Committee, %+v", committeeChange.SlashingCommittee)
+		if err := blockchain.storeValidatorEpochPerformance(curView, newBestState, beaconBlock.Header.Epoch-1, committeeChange); err != nil {
+			Logger.log.Error(err)
+		}
 	}
 	err = blockchain.addShardRewardRequestToBeacon(beaconBlock, newBestState.rewardStateDB, newBestState)
 	if err != nil {
@@ -1066,6 +1071,7 @@ func (blockchain *BlockChain) processStoreBeaconBlock(
 		strconv.Itoa(metadata.BurningAvaxConfirmForDepositToSCMeta),
 		strconv.Itoa(metadata.BurningNearConfirmMeta),
 		strconv.Itoa(metadata.BurningPRVRequestConfirmMeta),
+		strconv.Itoa(metadata.BurningSOLConfirmMeta),
 	}
 	if err := blockchain.storeBurningConfirm(newBestState.featureStateDB, beaconBlock.Body.Instructions, beaconBlock.Header.Height, metas); err != nil {
 		return NewBlockChainError(StoreBurningConfirmError, err)
@@ -1188,6 +1194,39 @@ func (blockchain *BlockChain) processStoreBeaconBlock(
 		return NewBlockChainError(StoreShardBlockError, err)
 	}
 
+	if isCheckpointViewHeight(beaconBlock.Header.Height) {
+		view, err := json.Marshal(newBestState)
+		if err != nil {
+			return NewBlockChainError(StoreBeaconBlockError, err)
+		}
+		if err := rawdbv2.StoreBeaconCheckpointView(batch, blockHash, view); err != nil {
+			return NewBlockChainError(StoreBeaconBlockError, err)
+		}
+		if viewHash, err := getBeaconCheckpointViewHash(newBestState); err == nil {
+			Logger.log.Infof("Beacon checkpoint view %v %v, view hash %v", beaconBlock.Header.Height, blockHash.String(), viewHash.String())
+		}
+	}
+
+	if config.Config().IndexCommitteeChange {
+		diff := committeestate.NewCommitteeStateDiff(
+			beaconBlock.Header.Height,
+			blockHash.String(),
+			committeestate.NewCommitteePoolSnapshot(curView.beaconCommitteeState),
+			committeestate.NewCommitteePoolSnapshot(newBestState.beaconCommitteeState),
+			beaconBlock.Body.Instructions,
+			committeeChange,
+		)
+		if err := rawdbv2.StoreBeaconCommitteeStateDiff(batch, blockHash, diff); err != nil {
+			return NewBlockChainError(StoreBeaconBlockError, err)
+		}
+	}
+
+	if config.Config().IndexDelegation {
+		if err := blockchain.storeDelegationIndex(batch, curView, newBestState, beaconBlock, committeeChange); err != nil {
+			return NewBlockChainError(StoreBeaconBlockError, err)
+		}
+	}
+
 	if err := blockchain.BeaconChain.BlockStorage.StoreBlock(beaconBlock); err != nil {
 		return NewBlockChainError(StoreBeaconBlockError, err)
 	}
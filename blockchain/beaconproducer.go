@@ -121,6 +121,10 @@ func (blockchain *BlockChain) NewBlockBeacon(
 	finishSyncInstructions := copiedCurView.generateFinishSyncInstruction()
 	instructions = addFinishInstruction(instructions, finishSyncInstructions)
 
+	equivocationInstructions := copiedCurView.generateEquivocationInstructions(
+		copiedCurView.CalculateTimeSlot(newBeaconBlock.Header.Timestamp))
+	instructions = append(instructions, equivocationInstructions...)
+
 	enableFeatureInstructions, _ := copiedCurView.generateEnableFeatureInstructions()
 	instructions = append(instructions, enableFeatureInstructions...)
 
@@ -787,14 +791,14 @@ func (curView *BeaconBestState) generateEnableFeatureInstructions() ([][]string,
 		}
 		beaconProposerSize := len(curView.GetCommittee())
 		//if number of beacon proposer update < 95%, not generate inst
-		if featureStatReport.CommitteeStat[feature][-1] < uint64(math.Ceil(float64(beaconProposerSize)*95/100)) {
+		if featureStatReport.CommitteeStat[feature][-1] < uint64(math.Ceil(float64(beaconProposerSize)*BEACON_FEATURE_COMMITTEE_PERCENT/100)) {
 			continue
 		}
 
 		//if number of each shard committee update < 95%, not generate inst
 		for chainID := 0; chainID < curView.ActiveShards; chainID++ {
 			shardCommitteeSize := len(curView.GetAShardCommittee(byte(chainID)))
-			if featureStatReport.CommitteeStat[feature][chainID] < uint64(math.Ceil(float64(shardCommitteeSize)*89/100)) {
+			if featureStatReport.CommitteeStat[feature][chainID] < uint64(math.Ceil(float64(shardCommitteeSize)*SHARD_FEATURE_COMMITTEE_PERCENT/100)) {
 				invalidCondition = true
 				break
 			}
@@ -65,6 +65,7 @@ func collectStatefulActions(
 			metadata.PortalUnlockOverRateCollateralsMeta,
 			metadata.RelayingBNBHeaderMeta,
 			metadata.RelayingBTCHeaderMeta,
+			metadata.RelayingLTCHeaderMeta,
 			metadata.PortalCustodianWithdrawRequestMeta,
 			metadata.PortalRedeemRequestMeta,
 			metadata.PortalRequestUnlockCollateralMeta,
@@ -143,7 +144,7 @@ func (blockchain *BlockChain) buildStatefulInstructions(
 		Logger.log.Error(err)
 		return utils.EmptyStringMatrix, err
 	}
-	relayingHeaderState, err := portalrelaying.InitRelayingHeaderChainStateFromDB(blockchain.GetBNBHeaderChain(), blockchain.GetBTCHeaderChain())
+	relayingHeaderState, err := portalrelaying.InitRelayingHeaderChainStateFromDB(blockchain.GetBNBHeaderChain(), blockchain.GetBTCHeaderChain(), blockchain.GetLTCHeaderChain())
 	if err != nil {
 		Logger.log.Error(err)
 	}
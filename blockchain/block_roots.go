@@ -0,0 +1,50 @@
+package blockchain
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/incognitochain/incognito-chain/blockchain/types"
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/config"
+)
+
+// verifyBeaconInstructionMerkleRoot check InstructionMerkleRoot is the root of merkle tree containing all instructions in the block
+func verifyBeaconInstructionMerkleRoot(beaconBlock *types.BeaconBlock) error {
+	flattenInsts, err := FlattenAndConvertStringInst(beaconBlock.Body.Instructions)
+	if err != nil {
+		return NewBlockChainError(FlattenAndConvertStringInstError, err)
+	}
+	root := types.GetKeccak256MerkleRoot(flattenInsts)
+
+	if !bytes.Equal(root, beaconBlock.Header.InstructionMerkleRoot[:]) {
+		return NewBlockChainError(FlattenAndConvertStringInstError, fmt.Errorf("Expect Instruction Merkle Root in Beacon Block Header to be %+v but get %+v", string(beaconBlock.Header.InstructionMerkleRoot[:]), string(root)))
+	}
+	return nil
+}
+
+// verifyShardTxRoot check TxRoot is the root of merkle tree containing all transactions in the block
+func verifyShardTxRoot(shardBlock *types.ShardBlock) error {
+	txMerkleTree := types.Merkle{}.BuildMerkleTreeStore(shardBlock.Body.Transactions)
+	txRoot := &common.Hash{}
+	if len(txMerkleTree) > 0 {
+		txRoot = txMerkleTree[len(txMerkleTree)-1]
+	}
+	if !bytes.Equal(shardBlock.Header.TxRoot.GetBytes(), txRoot.GetBytes()) &&
+		(config.Param().Net == config.LocalNet || config.Param().Net != config.TestnetNet || (shardBlock.Header.Height != 487260 && shardBlock.Header.Height != 487261 && shardBlock.Header.Height != 494144)) {
+		return NewBlockChainError(TransactionRootHashError, fmt.Errorf("Expect transaction root hash %+v but get %+v", shardBlock.Header.TxRoot.String(), txRoot.String()))
+	}
+	return nil
+}
+
+// VerifyBlockMerkleRoots check merkle roots of the block header which only depend on the block body,
+// it is used to verify downloaded blocks before they can be inserted
+func VerifyBlockMerkleRoots(block types.BlockInterface) error {
+	switch blk := block.(type) {
+	case *types.BeaconBlock:
+		return verifyBeaconInstructionMerkleRoot(blk)
+	case *types.ShardBlock:
+		return verifyShardTxRoot(blk)
+	}
+	return nil
+}
@@ -24,6 +24,7 @@ import (
 	"github.com/incognitochain/incognito-chain/common"
 	"github.com/incognitochain/incognito-chain/config"
 	configpkg "github.com/incognitochain/incognito-chain/config"
+	"github.com/incognitochain/incognito-chain/consensus_v2/equivocation"
 	"github.com/incognitochain/incognito-chain/consensus_v2/signatureschemes/bridgesig"
 	"github.com/incognitochain/incognito-chain/dataaccessobject/rawdbv2"
 	"github.com/incognitochain/incognito-chain/dataaccessobject/statedb"
@@ -53,11 +54,13 @@ type BlockChain struct {
 	beaconViewCache             *lru.Cache
 	committeeByEpochCache       *lru.Cache
 	committeeByEpochProcessLock sync.Mutex
+	accountLedger               *AccountLedger
 }
 
 // Config is a descriptor which specifies the blockchain instblockchain/beaconstatefulinsts.goance configuration.
 type Config struct {
 	BTCChain      *btcrelaying.BlockChain
+	LTCChain      *btcrelaying.BlockChain
 	BNBChainState *bnbrelaying.BNBChainState
 	DataBase      map[int]incdb.Database
 	MemCache      *memcache.MemoryCache
@@ -150,9 +153,23 @@ func (blockchain *BlockChain) Init(config *Config) error {
 			go outcoinIndexer.Start(cfg)
 		}
 	}
+
+	if configpkg.Config().IndexAccountLedger {
+		accountLedger, err := NewAccountLedger(blockchain, configpkg.Config().AccountLedgerTokens)
+		if err != nil {
+			return err
+		}
+		blockchain.accountLedger = accountLedger
+		go accountLedger.Start()
+	}
 	return nil
 }
 
+// GetAccountLedger return account ledger, nil if the node does not run with account ledger
+func (blockchain *BlockChain) GetAccountLedger() *AccountLedger {
+	return blockchain.accountLedger
+}
+
 // InitChainState attempts to load and initialize the chain state from the
 // database.  When the db does not yet contain any chain state, both it and the
 // chain state are initialized to the genesis block.
@@ -160,7 +177,9 @@ func (blockchain *BlockChain) InitChainState() error {
 	// Determine the state of the chain database. We may need to initialize
 	// everything from scratch or upgrade certain buckets.
 	stats.IsEnableBPV3Stats = config.Param().IsEnableBPV3Stats
-	blockchain.BeaconChain = NewBeaconChain(multiview.NewBeaconMultiView(), blockchain.config.BlockGen, blockchain, common.BeaconChainKey)
+	beaconMultiView := multiview.NewBeaconMultiView()
+	beaconMultiView.SetPubSubManager(common.BeaconChainID, blockchain.config.PubSubManager)
+	blockchain.BeaconChain = NewBeaconChain(beaconMultiView, blockchain.config.BlockGen, blockchain, common.BeaconChainKey)
 
 	var err error
 	blockchain.BeaconChain.hashHistory, err = lru.New(1000)
@@ -199,6 +218,14 @@ func (blockchain *BlockChain) InitChainState() error {
 		}
 	}()
 
+	equivocation.NewDefaultEvidencePool()
+	go func() {
+		for {
+			equivocation.DefaultEvidencePool.Clean(blockchain.BeaconChain.GetBestView().(*BeaconBestState).getAllCommitteeValidatorCandidateFlattenList())
+			time.Sleep(time.Minute * 5)
+		}
+	}()
+
 	wl, err := blockchain.GetWhiteList()
 	if err != nil {
 		Logger.log.Errorf("Can not get whitelist txs, error %v", err)
@@ -220,7 +247,9 @@ func (blockchain *BlockChain) InitChainState() error {
 			nil,
 		)
 		tp.UpdateTxVerifier(tv)
-		blockchain.ShardChain[shardID] = NewShardChain(int(shardID), multiview.NewShardMultiView(), blockchain.config.BlockGen, blockchain, common.GetShardChainKey(shardID), tp, tv)
+		shardMultiView := multiview.NewShardMultiView()
+		shardMultiView.SetPubSubManager(int(shardID), blockchain.config.PubSubManager)
+		blockchain.ShardChain[shardID] = NewShardChain(int(shardID), shardMultiView, blockchain.config.BlockGen, blockchain, common.GetShardChainKey(shardID), tp, tv)
 		blockchain.ShardChain[shardID].hashHistory, err = lru.New(1000)
 		if err != nil {
 			return err
@@ -253,6 +282,14 @@ func (blockchain *BlockChain) InitChainState() error {
 		blockchain.ShardChain[shardID].TxsVerifier.UpdateTransactionStateDB(txDB)
 		blockchain.ShardChain[shardID].PreFetchTx.BestView = blockchain.ShardChain[shardID].GetBestView().(*ShardBestState)
 
+		//restore txs persisted by the tx pool before restart, against the current view
+		bcView, err := blockchain.GetBeaconViewStateDataFromBlockHash(sBestState.GetBeaconHash(), true, false, false)
+		if err != nil {
+			Logger.log.Errorf("Can not get beacon view to restore tx pool of shard %v, err %v", shardID, err)
+		} else {
+			tp.RestoreTxs(blockchain, sBestState, bcView)
+		}
+
 		Logger.log.Infof("Init Shard View shardID %+v, height %+v", shardID, blockchain.ShardChain[shardID].GetFinalViewHeight())
 	}
 
@@ -701,6 +738,8 @@ func (blockchain *BlockChain) RestoreBeaconViews() error {
 			panic("Restart beacon views fail")
 		}
 	}
+	// set after restoring so that restored views are not published as view events
+	newMultiview.SetPubSubManager(common.BeaconChainID, blockchain.config.PubSubManager)
 	blockchain.BeaconChain.multiView = newMultiview
 	for _, beaconState := range allViews {
 		if beaconState.missingSignatureCounter == nil {
@@ -785,6 +824,7 @@ func (blockchain *BlockChain) RestoreShardViews(shardID byte) error {
 		}
 		Logger.log.Info("Restore shard ", shardID, v.GetHeight(), v.GetHash().String())
 	}
+	newMultiView.SetPubSubManager(int(shardID), blockchain.config.PubSubManager)
 	blockchain.ShardChain[shardID].multiView = newMultiView
 	return nil
 }
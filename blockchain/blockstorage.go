@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"github.com/incognitochain/incognito-chain/blockchain/types"
 	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/config"
 	"github.com/incognitochain/incognito-chain/dataaccessobject/flatfile"
 	"github.com/incognitochain/incognito-chain/dataaccessobject/rawdbv2"
 	"github.com/incognitochain/incognito-chain/incdb"
@@ -13,8 +14,13 @@ import (
 	"github.com/pkg/errors"
 	"os"
 	"path"
+	"time"
 )
 
+// UseBinaryBlockStorage store new blocks with the binary block codec instead of json.
+// Reading always accept both, so a node can switch it on without migrating stored blocks
+var UseBinaryBlockStorage = false
+
 type BlockStorage struct {
 	rootDB         incdb.Database
 	blockStorageDB incdb.Database
@@ -27,7 +33,7 @@ func NewBlockStorage(db incdb.Database, ffPath string, cid int, useFF bool) *Blo
 	var ff *flatfile.FlatFileManager
 	var blockStorageDB incdb.Database
 
-	ff, _ = flatfile.NewFlatFile(ffPath, 5000)
+	ff, _ = flatfile.NewFlatFileWithOptions(ffPath, 5000, flatFileOptions())
 	blockStorageDB, _ = incdb.Open("leveldb", path.Join(ffPath, "blockKV"))
 
 	return &BlockStorage{
@@ -40,12 +46,46 @@ func (s *BlockStorage) ChangeMainDir(tmpDir, mainDir string) error {
 	os.Rename(tmpDir, mainDir)
 	blockStorageDB, _ := incdb.Open("leveldb", path.Join(mainDir, "blockstorage", "blockKV"))
 	s.blockStorageDB = blockStorageDB
-	s.flatfile, _ = flatfile.NewFlatFile(path.Join(mainDir, "blockstorage"), 5000)
+	s.flatfile, _ = flatfile.NewFlatFileWithOptions(path.Join(mainDir, "blockstorage"), 5000, flatFileOptions())
 	os.RemoveAll(mainDir + ".bk")
 
 	return nil
 }
 
+// flatFileOptions return the flatfile compression of blocks from config, blocks written before codecs exist are gzip
+func flatFileOptions() flatfile.Options {
+	options := flatfile.Options{Codec: flatfile.CodecGzip}
+	if cfg := config.Config(); cfg != nil && cfg.FlatFileCodec != "" {
+		codec, err := flatfile.ParseCodec(cfg.FlatFileCodec)
+		if err != nil || codec == flatfile.CodecNone {
+			Logger.log.Errorf("Invalid flatfile codec %v, use gzip", cfg.FlatFileCodec)
+		} else if codec == flatfile.CodecZstdDict {
+			options = flatfile.DefaultDictOptions()
+		} else {
+			options.Codec = codec
+		}
+	}
+	options.LegacyCodec = flatfile.CodecGzip
+	return options
+}
+
+// StartRecompressJob recompress old flatfile blocks with the current codec periodically
+func (s *BlockStorage) StartRecompressJob(interval time.Duration) {
+	go func() {
+		for {
+			if s.useFF {
+				count, err := s.flatfile.Recompress()
+				if err != nil {
+					Logger.log.Errorf("Recompress flatfile of chain %v error: %v", s.cid, err)
+				} else if count > 0 {
+					Logger.log.Infof("Recompress %v flatfile files of chain %v", count, s.cid)
+				}
+			}
+			time.Sleep(interval)
+		}
+	}()
+}
+
 func (s *BlockStorage) Truncate() error {
 	return s.flatfile.Truncate(s.flatfile.Size() - 500)
 }
@@ -241,48 +281,20 @@ func (s *BlockStorage) GetBlock(blkHash common.Hash) (types.BlockInterface, int,
 	}
 }
 func (s *BlockStorage) encode(blk types.BlockInterface) []byte {
-	b, _ := json.Marshal(blk)
-	var bb = b
-
-	if s.useFF {
-		//zip
-		var err error
-		bb, err = common.GZipFromBytes(b)
-		if err != nil {
-			panic(err)
-		}
+	if !UseBinaryBlockStorage {
+		b, _ := json.Marshal(blk)
+		return b
 	}
-
-	return bb
+	b, err := types.EncodeBlock(blk)
+	if err != nil {
+		panic(err)
+	}
+	return b
 }
 
+// decode support both binary encoded blocks and legacy json blocks, flatfile records are decompressed by the flatfile
 func (s *BlockStorage) decode(data []byte) (types.BlockInterface, error) {
-	//unzip if using ffstorage
-	var rawData = data
-	if s.useFF {
-		var err error
-		rawData, err = common.GZipToBytes(data)
-		if err != nil {
-			panic(err)
-		}
-	}
-
-	switch s.cid {
-	case -1:
-		beaconBlock := types.NewBeaconBlock()
-		err := json.Unmarshal(rawData, beaconBlock)
-		if err != nil {
-			return nil, err
-		}
-		return beaconBlock, nil
-	default:
-		shardBlock := types.NewShardBlock()
-		err := json.Unmarshal(rawData, shardBlock)
-		if err != nil {
-			return nil, err
-		}
-		return shardBlock, nil
-	}
+	return types.DecodeBlock(data, s.cid)
 }
 
 func (s *BlockStorage) storeBlockUsingFF(blk types.BlockInterface) error {
@@ -320,13 +332,14 @@ func (s *BlockStorage) getFFFileByHeight(blkHeight uint64) (int, error) {
 }
 
 func (s *BlockStorage) storeBlockUsingDB(blk types.BlockInterface) error {
+	dataByte := s.encode(blk)
 	switch s.cid {
 	case -1:
-		if err := rawdbv2.StoreBeaconBlockByHash(s.rootDB, *blk.Hash(), blk); err != nil {
+		if err := rawdbv2.StoreBeaconBlockByHash(s.rootDB, *blk.Hash(), dataByte); err != nil {
 			return NewBlockChainError(StoreBeaconBlockError, err)
 		}
 	default:
-		if err := rawdbv2.StoreShardBlock(s.rootDB, *blk.Hash(), blk); err != nil {
+		if err := rawdbv2.StoreShardBlock(s.rootDB, *blk.Hash(), dataByte); err != nil {
 			return NewBlockChainError(StoreBeaconBlockError, err)
 		}
 	}
@@ -390,7 +390,8 @@ func NewBootstrapManager(hosts []string, bc *BlockChain) *BootstrapManager {
 	return &BootstrapManager{hosts, bc}
 }
 
-func (s *BootstrapManager) BootstrapBeacon() {
+// shuffleHosts return bootstrap hosts in random order, so that nodes do not all download from the first one
+func (s *BootstrapManager) shuffleHosts() []string {
 	rand := mathrand.Int()
 	m := make(map[string]string)
 	hashes := []string{}
@@ -406,6 +407,11 @@ func (s *BootstrapManager) BootstrapBeacon() {
 	for _, h := range hashes {
 		hosts = append(hosts, m[h])
 	}
+	return hosts
+}
+
+func (s *BootstrapManager) BootstrapBeacon() {
+	hosts := s.shuffleHosts()
 
 	for hID, _ := range hosts {
 
@@ -482,21 +488,7 @@ func (s *BootstrapManager) BootstrapBeacon() {
 
 func (s *BootstrapManager) BootstrapShard(sid int, force bool) {
 
-	rand := mathrand.Int()
-	m := make(map[string]string)
-	hashes := []string{}
-	for _, h := range s.hosts {
-		seed := h + strconv.Itoa(int(rand))
-		hash := common.HashB([]byte(seed))
-		hashes = append(hashes, string(hash[:32]))
-		m[string(hash[:32])] = h
-	}
-
-	sort.Strings(hashes)
-	hosts := []string{}
-	for _, h := range hashes {
-		hosts = append(hosts, m[h])
-	}
+	hosts := s.shuffleHosts()
 
 	for hID, _ := range hosts {
 		host := fmt.Sprintf("http://%v", hosts[hID])
@@ -1,6 +1,7 @@
 package blockchain
 
 import (
+	"encoding/binary"
 	"encoding/hex"
 	"fmt"
 	"github.com/incognitochain/incognito-chain/privacy"
@@ -64,6 +65,13 @@ func DecodeInstruction(inst []string) ([]byte, error) {
 			return nil, err
 		}
 
+	case strconv.Itoa(metadata.BurningSOLConfirmMeta):
+		var err error
+		flatten, err = decodeSOLBurningConfirmInst(inst)
+		if err != nil {
+			return nil, err
+		}
+
 	// for portal instructions
 	case strconv.Itoa(metadata.PortalCustodianWithdrawConfirmMetaV3),
 		strconv.Itoa(metadata.PortalRedeemFromLiquidationPoolConfirmMetaV3),
@@ -227,6 +235,53 @@ func decodeNearBurningConfirmInst(inst []string) ([]byte, error) {
 	return flatten, nil
 }
 
+// decodeSOLBurningConfirmInst decodes and flattens a BurningConfirm instruction for Solana vault program
+// integers are encoded in little endian u64 to be decoded natively by the program
+func decodeSOLBurningConfirmInst(inst []string) ([]byte, error) {
+	if len(inst) < 8 {
+		return nil, errors.New("invalid length of BurningConfirm inst")
+	}
+	m, errMeta := strconv.Atoi(inst[0])
+	s, errShard := strconv.Atoi(inst[1])
+	metaType := byte(m)
+	shardID := byte(s)
+	tokenID, _, errToken := base58.Base58Check{}.Decode(inst[2])
+	remoteAddr, errAddr := decodeRemoteAddr(inst[3])
+	amount, _, errAmount := base58.Base58Check{}.Decode(inst[4])
+	txID, errTx := common.Hash{}.NewHashFromStr(inst[5])
+	incTokenID, _, errIncToken := base58.Base58Check{}.Decode(inst[6])
+	height, _, errHeight := base58.Base58Check{}.Decode(inst[7])
+	if err := common.CheckError(errMeta, errShard, errToken, errAddr, errAmount, errTx, errIncToken, errHeight); err != nil {
+		err = errors.Wrapf(err, "inst: %+v", inst)
+		BLogger.log.Error(err)
+		return nil, err
+	}
+	if len(tokenID) != len(common.SOLPrefix)+common.SOLAddressLength || string(tokenID[:len(common.SOLPrefix)]) != common.SOLPrefix {
+		return nil, errors.Errorf("invalid solana token id %x", tokenID)
+	}
+	amountBN := big.NewInt(0).SetBytes(amount)
+	heightBN := big.NewInt(0).SetBytes(height)
+	if !amountBN.IsUint64() || !heightBN.IsUint64() {
+		return nil, errors.Errorf("amount or height is out of range u64, inst: %+v", inst)
+	}
+
+	BLogger.log.Infof("Decoded BurningConfirm inst, amount: %d, remoteAddr: %x, tokenID: %x", amountBN, remoteAddr, tokenID)
+	amountBytes := make([]byte, 8)
+	binary.LittleEndian.PutUint64(amountBytes, amountBN.Uint64())
+	heightBytes := make([]byte, 8)
+	binary.LittleEndian.PutUint64(heightBytes, heightBN.Uint64())
+	flatten := []byte{}
+	flatten = append(flatten, metaType)
+	flatten = append(flatten, shardID)
+	flatten = append(flatten, tokenID[len(common.SOLPrefix):]...)
+	flatten = append(flatten, remoteAddr...)
+	flatten = append(flatten, amountBytes...)
+	flatten = append(flatten, txID[:]...)
+	flatten = append(flatten, incTokenID...)
+	flatten = append(flatten, heightBytes...)
+	return flatten, nil
+}
+
 // decodeRemoteAddr converts address string to 32 bytes slice
 func decodeRemoteAddr(addr string) ([]byte, error) {
 	remoteAddr, err := hex.DecodeString(addr)
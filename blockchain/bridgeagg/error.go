@@ -27,6 +27,8 @@ const (
 	StoreBridgeTokenError
 	InsufficientFundsVaultError
 	NoValidShieldEventError
+	AutoRouteUnshieldError
+	ExceedMaxUnshieldFeeError
 )
 
 var ErrCodeMessage = map[int]struct {
@@ -53,6 +55,8 @@ var ErrCodeMessage = map[int]struct {
 	StoreBridgeTokenError:          {1016, "Store bridge token error"},
 	InsufficientFundsVaultError:    {1017, "Insufficient funds in Vault"},
 	NoValidShieldEventError:        {1018, "Shielding receipt contains no valid shield event"},
+	AutoRouteUnshieldError:         {1019, "Cannot route unshielding request across networks"},
+	ExceedMaxUnshieldFeeError:      {1020, "Unshielding fee exceeds max fee"},
 }
 
 type BridgeAggError struct {
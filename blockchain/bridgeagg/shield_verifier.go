@@ -0,0 +1,169 @@
+package bridgeagg
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"strconv"
+
+	rCommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/dataaccessobject/statedb"
+	"github.com/incognitochain/incognito-chain/metadata"
+	metadataBridge "github.com/incognitochain/incognito-chain/metadata/bridge"
+	"github.com/incognitochain/incognito-chain/wallet"
+)
+
+// ShieldNetworkInfo contains the data of a network used by beacon to validate shielding events
+type ShieldNetworkInfo struct {
+	Prefix            string
+	NativeTokenID     []byte
+	IsTxHashIssued    func(stateDB *statedb.StateDB, uniqTx []byte) (bool, error)
+	ListTxUsedInBlock [][]byte
+}
+
+// ShieldProofVerifier extracts shielding events of a network from the shield action built by shard
+type ShieldProofVerifier interface {
+	// ExtractShieldEvents returns the shielding events in the action data,
+	// the error type is used to build the rejected instruction when error is not nil
+	ExtractShieldEvents(
+		proof []byte, actionData []byte, expectedIncAddrStr string, ac *metadata.AccumulatedValues,
+	) ([]metadataBridge.DepositEventData, int, error)
+	NetworkInfo(ac *metadata.AccumulatedValues) (*ShieldNetworkInfo, error)
+}
+
+func GetShieldProofVerifier(networkID uint8) (ShieldProofVerifier, error) {
+	networkType, err := metadataBridge.GetNetworkTypeByNetworkID(networkID)
+	if err != nil {
+		return nil, err
+	}
+	switch networkType {
+	case common.EVMNetworkType:
+		return &evmShieldProofVerifier{networkID: networkID}, nil
+	case common.AURORANetworkID:
+		return &evmShieldProofVerifier{networkID: networkID, isAurora: true}, nil
+	case common.SOLNetworkType:
+		return &solShieldProofVerifier{}, nil
+	default:
+		return nil, fmt.Errorf("Not found shield proof verifier for networkID %v", networkID)
+	}
+}
+
+type evmShieldProofVerifier struct {
+	networkID uint8
+	isAurora  bool
+}
+
+func (v *evmShieldProofVerifier) ExtractShieldEvents(
+	proof []byte, actionData []byte, expectedIncAddrStr string, ac *metadata.AccumulatedValues,
+) ([]metadataBridge.DepositEventData, int, error) {
+	// unmarshal proof and receipt
+	var uniqTx []byte
+	var txReceipt *types.Receipt
+	var err error
+	if !v.isAurora {
+		var proofData *metadataBridge.EVMProof
+		proofData, txReceipt, err = UnmarshalEVMShieldProof(proof, actionData)
+		if err == nil {
+			uniqTx = append(proofData.BlockHash[:], []byte(strconv.Itoa(int(proofData.TxIndex)))...)
+		}
+	} else {
+		_, txReceipt, err = UnmarshalEVMShieldProof([]byte(`{}`), actionData)
+		uniqTx = proof
+	}
+	if err != nil {
+		return nil, UnmarshalShieldProofError, fmt.Errorf("Can not unmarshal shielding proof - Error %v", err)
+	}
+
+	evmInfo, err := metadataBridge.GetEVMInfoByNetworkID(v.networkID, ac)
+	if err != nil {
+		return nil, InvalidNetworkIDError, fmt.Errorf("Can not get evm info by network id - Error %v", err)
+	}
+
+	// extract info from Receipt
+	extAmount, incAddr, extTokenID, errShield := metadataBridge.ExtractIssueEVMDataFromReceipt(
+		txReceipt, evmInfo.ContractAddress, evmInfo.Prefix, expectedIncAddrStr,
+	)
+	redepositDataLst, errReshield := metadataBridge.ExtractRedepositEVMDataFromReceipt(txReceipt, evmInfo.ContractAddress, evmInfo.Prefix)
+	if errShield == nil {
+		key, _ := wallet.Base58CheckDeserialize(incAddr)
+		shardID, _ := metadataBridge.GetShardIDFromPaymentAddress(key.KeySet.PaymentAddress)
+		redepositDataLst = append(redepositDataLst, metadataBridge.DepositEventData{
+			Amount:          extAmount,
+			ReceiverStr:     incAddr,
+			ExternalTokenID: extTokenID,
+			IncTxID:         uniqTx,
+			ShardID:         shardID,
+			IsOneTime:       false,
+		})
+	} else {
+		Logger.log.Errorf("[BridgeAgg] Can not extract data from Receipt - Error %v", errShield)
+		if errReshield != nil {
+			return nil, ExtractDataFromReceiptError, fmt.Errorf("Extract Redeposit EVM events failed - %v", errReshield)
+		}
+	}
+	return redepositDataLst, 0, nil
+}
+
+func (v *evmShieldProofVerifier) NetworkInfo(ac *metadata.AccumulatedValues) (*ShieldNetworkInfo, error) {
+	evmInfo, err := metadataBridge.GetEVMInfoByNetworkID(v.networkID, ac)
+	if err != nil {
+		return nil, err
+	}
+	return &ShieldNetworkInfo{
+		Prefix:            evmInfo.Prefix,
+		NativeTokenID:     append([]byte(evmInfo.Prefix), rCommon.HexToAddress(common.NativeToken).Bytes()...),
+		IsTxHashIssued:    evmInfo.IsTxHashIssued,
+		ListTxUsedInBlock: evmInfo.ListTxUsedInBlock,
+	}, nil
+}
+
+type solShieldProofVerifier struct{}
+
+func (v *solShieldProofVerifier) ExtractShieldEvents(
+	proof []byte, actionData []byte, expectedIncAddrStr string, ac *metadata.AccumulatedValues,
+) ([]metadataBridge.DepositEventData, int, error) {
+	_, signature, err := metadataBridge.UnmarshalSOLShieldProof(proof)
+	if err != nil {
+		return nil, UnmarshalShieldProofError, fmt.Errorf("Can not unmarshal shielding proof - Error %v", err)
+	}
+	shieldData := metadataBridge.SOLShieldActionData{}
+	err = json.Unmarshal(actionData, &shieldData)
+	if err != nil {
+		return nil, UnmarshalShieldProofError, fmt.Errorf("Can not unmarshal solana shield data - Error %v", err)
+	}
+	if string(signature) != string(shieldData.TxSignature) {
+		return nil, ExtractDataFromReceiptError, errors.New("Solana tx signature is mismatched with the proof")
+	}
+	if expectedIncAddrStr != "" && shieldData.IncAddress != expectedIncAddrStr {
+		return nil, ExtractDataFromReceiptError, errors.New("Different incognito address from solana shield data")
+	}
+	key, err := wallet.Base58CheckDeserialize(shieldData.IncAddress)
+	if err != nil || len(key.KeySet.PaymentAddress.Pk) == 0 {
+		return nil, ExtractDataFromReceiptError, fmt.Errorf("Invalid incognito address %v", shieldData.IncAddress)
+	}
+	if len(shieldData.Mint) != common.SOLAddressLength {
+		return nil, ExtractDataFromReceiptError, fmt.Errorf("Invalid token mint %v", shieldData.Mint)
+	}
+	shardID, _ := metadataBridge.GetShardIDFromPaymentAddress(key.KeySet.PaymentAddress)
+	return []metadataBridge.DepositEventData{
+		{
+			Amount:          new(big.Int).SetUint64(shieldData.Amount),
+			ReceiverStr:     shieldData.IncAddress,
+			ExternalTokenID: append([]byte(common.SOLPrefix), shieldData.Mint...),
+			IncTxID:         shieldData.TxSignature,
+			ShardID:         shardID,
+			IsOneTime:       false,
+		},
+	}, 0, nil
+}
+
+func (v *solShieldProofVerifier) NetworkInfo(ac *metadata.AccumulatedValues) (*ShieldNetworkInfo, error) {
+	return &ShieldNetworkInfo{
+		Prefix:            common.SOLPrefix,
+		IsTxHashIssued:    statedb.IsSOLTxHashIssued,
+		ListTxUsedInBlock: ac.UniqSOLTxsUsed,
+	}, nil
+}
@@ -0,0 +1,106 @@
+package bridgeagg
+
+import (
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/common/base58"
+	"github.com/incognitochain/incognito-chain/metadata"
+	metadataBridge "github.com/incognitochain/incognito-chain/metadata/bridge"
+	"github.com/stretchr/testify/assert"
+)
+
+type solShieldTestCase struct {
+	ProgramID string                              `json:"programID"`
+	Response  metadataBridge.GetSOLTransactionRes `json:"response"`
+	Expected  struct {
+		TxSignature string `json:"TxSignature"`
+		Slot        uint64 `json:"Slot"`
+		Mint        string `json:"Mint"`
+		Amount      uint64 `json:"Amount"`
+		IncAddress  string `json:"IncAddress"`
+	} `json:"expected"`
+}
+
+func readSOLShieldTestCase(t *testing.T) *solShieldTestCase {
+	raw, _ := readTestCases("sol_shield.json")
+	tc := &solShieldTestCase{}
+	err := json.Unmarshal(raw, tc)
+	assert.Nil(t, err)
+	return tc
+}
+
+func TestParseSOLShieldTx(t *testing.T) {
+	tc := readSOLShieldTestCase(t)
+
+	got, err := metadataBridge.ParseSOLShieldTx(tc.Response.Result, tc.ProgramID)
+	assert.Nil(t, err)
+	assert.Equal(t, tc.Expected.TxSignature, base58.Base58{}.Encode(got.TxSignature))
+	assert.Equal(t, tc.Expected.Slot, got.Slot)
+	assert.Equal(t, tc.Expected.Mint, base58.Base58{}.Encode(got.Mint))
+	assert.Equal(t, tc.Expected.Amount, got.Amount)
+	assert.Equal(t, tc.Expected.IncAddress, got.IncAddress)
+
+	// shield instruction of another program
+	_, err = metadataBridge.ParseSOLShieldTx(tc.Response.Result, tc.Response.Result.Transaction.Message.AccountKeys[0])
+	assert.NotNil(t, err)
+
+	// failed transaction
+	failedTx := readSOLShieldTestCase(t).Response.Result
+	failedTx.Meta.Err = map[string]interface{}{"InstructionError": []interface{}{1, "Custom"}}
+	_, err = metadataBridge.ParseSOLShieldTx(failedTx, tc.ProgramID)
+	assert.NotNil(t, err)
+
+	// missing mint account
+	invalidAccountsTx := readSOLShieldTestCase(t).Response.Result
+	invalidAccountsTx.Transaction.Message.Instructions[1].Accounts = []int{1, 2}
+	_, err = metadataBridge.ParseSOLShieldTx(invalidAccountsTx, tc.ProgramID)
+	assert.NotNil(t, err)
+}
+
+func TestSOLShieldProofVerifier(t *testing.T) {
+	tc := readSOLShieldTestCase(t)
+	actionData, err := metadataBridge.ParseSOLShieldTx(tc.Response.Result, tc.ProgramID)
+	assert.Nil(t, err)
+	actionDataBytes, _ := json.Marshal(actionData)
+	proof, _ := json.Marshal(metadataBridge.SOLShieldProof{TxSignature: tc.Expected.TxSignature})
+
+	common.MaxShardNumber = 8
+	verifier, err := GetShieldProofVerifier(common.SOLNetworkID)
+	assert.Nil(t, err)
+	ac := &metadata.AccumulatedValues{UniqSOLTxsUsed: [][]byte{}}
+
+	events, errType, err := verifier.ExtractShieldEvents(proof, actionDataBytes, "", ac)
+	assert.Nil(t, err)
+	assert.Equal(t, 0, errType)
+	assert.Equal(t, 1, len(events))
+	assert.Equal(t, big.NewInt(0).SetUint64(tc.Expected.Amount), events[0].Amount)
+	assert.Equal(t, append([]byte(common.SOLPrefix), actionData.Mint...), events[0].ExternalTokenID)
+	assert.Equal(t, actionData.TxSignature, events[0].IncTxID)
+	assert.Equal(t, tc.Expected.IncAddress, events[0].ReceiverStr)
+	assert.False(t, events[0].IsOneTime)
+
+	// proof of another tx
+	otherProof, _ := json.Marshal(metadataBridge.SOLShieldProof{
+		TxSignature: base58.Base58{}.Encode(make([]byte, metadataBridge.SOLSignatureLength)),
+	})
+	_, errType, err = verifier.ExtractShieldEvents(otherProof, actionDataBytes, "", ac)
+	assert.NotNil(t, err)
+	assert.Equal(t, ExtractDataFromReceiptError, errType)
+
+	// unexpected incognito address
+	_, errType, err = verifier.ExtractShieldEvents(proof, actionDataBytes, "invalid", ac)
+	assert.NotNil(t, err)
+	assert.Equal(t, ExtractDataFromReceiptError, errType)
+
+	// invalid proof
+	_, errType, err = verifier.ExtractShieldEvents([]byte(`{"TxSignature":"abc"}`), actionDataBytes, "", ac)
+	assert.NotNil(t, err)
+	assert.Equal(t, UnmarshalShieldProofError, errType)
+
+	networkInfo, err := verifier.NetworkInfo(ac)
+	assert.Nil(t, err)
+	assert.Equal(t, common.SOLPrefix, networkInfo.Prefix)
+}
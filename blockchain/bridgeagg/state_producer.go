@@ -6,10 +6,8 @@ import (
 	"encoding/json"
 	"fmt"
 	"math/big"
-	"strconv"
 
 	rCommon "github.com/ethereum/go-ethereum/common"
-	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/incognitochain/incognito-chain/common"
 	"github.com/incognitochain/incognito-chain/config"
 	"github.com/incognitochain/incognito-chain/dataaccessobject/statedb"
@@ -175,163 +173,127 @@ func (sp *stateProducer) shield(
 		}
 
 		// validate shielding proof
-		networkType, _ := metadataBridge.GetNetworkTypeByNetworkID(shieldData.NetworkID)
-		switch networkType {
-		case common.EVMNetworkType, common.AURORANetworkID:
-			// unmarshal proof and receipt
-			var uniqTx []byte
-			var txReceipt *types.Receipt
-			if networkType == common.EVMNetworkType {
-				var proofData *metadataBridge.EVMProof
-				proofData, txReceipt, err = UnmarshalEVMShieldProof(shieldData.Proof, action.ExtraData[i])
-				uniqTx = append(proofData.BlockHash[:], []byte(strconv.Itoa(int(proofData.TxIndex)))...)
-			} else {
-				_, txReceipt, err = UnmarshalEVMShieldProof([]byte(`{}`), action.ExtraData[i])
-				uniqTx = shieldData.Proof
+		verifier, err := GetShieldProofVerifier(shieldData.NetworkID)
+		if err != nil {
+			Logger.log.Errorf("[BridgeAgg] Network ID is not matched: %v", shieldData.NetworkID)
+			rejectedInst := buildRejectedInst(
+				metadataCommon.IssuingUnifiedTokenRequestMeta, shardID, action.TxReqID, InvalidNetworkIDError, []byte{})
+			return [][]string{rejectedInst}, state, ac, nil
+		}
+		depositEvents, errType, err := verifier.ExtractShieldEvents(shieldData.Proof, action.ExtraData[i], incAddrStr, clonedAC)
+		if err != nil {
+			Logger.log.Errorf("[BridgeAgg] Can not extract shielding events - Error %v", err)
+			rejectedInst := buildRejectedInst(
+				metadataCommon.IssuingUnifiedTokenRequestMeta, shardID, action.TxReqID, errType, []byte{})
+			return [][]string{rejectedInst}, state, ac, nil
+		}
+		networkInfo, err := verifier.NetworkInfo(clonedAC)
+		if err != nil {
+			Logger.log.Errorf("[BridgeAgg] Can not get network info by network id - Error %v", err)
+			rejectedInst := buildRejectedInst(
+				metadataCommon.IssuingUnifiedTokenRequestMeta, shardID, action.TxReqID, InvalidNetworkIDError, []byte{})
+			return [][]string{rejectedInst}, state, ac, nil
+		}
+		for _, d := range depositEvents {
+			if !d.IsOneTime {
+				incAddrStr = d.ReceiverStr
 			}
+		}
+
+		var validShieldProof bool = false
+		for _, d := range depositEvents {
+			vault, _ := clonedVaults[shieldData.IncTokenID]
+			// check double use shielding proof in current block and previous blocks
+			isValid, _, err := ValidateDoubleShieldProof(d.IncTxID, networkInfo.ListTxUsedInBlock, networkInfo.IsTxHashIssued, stateDBs[common.BeaconChainID])
 			if err != nil {
-				Logger.log.Errorf("[BridgeAgg] Can not unmarshal shielding proof - Error %v", err)
-				rejectedInst := buildRejectedInst(
-					metadataCommon.IssuingUnifiedTokenRequestMeta, shardID, action.TxReqID, UnmarshalShieldProofError, []byte{})
-				return [][]string{rejectedInst}, state, ac, nil
+				Logger.log.Errorf("[BridgeAgg] Cannot validate double shielding proof - Error %v", err)
+				continue
 			}
-
-			evmInfo, err := metadataBridge.GetEVMInfoByNetworkID(shieldData.NetworkID, clonedAC)
+			if !isValid {
+				Logger.log.Errorf("[BridgeAgg] Shielding proof was submitted - Error %v", err)
+				continue
+			}
+			// verify token pair pTokenID - extTokenID
+			err = metadataBridge.VerifyTokenPair(stateDBs, ac, shieldData.IncTokenID, d.ExternalTokenID)
 			if err != nil {
-				Logger.log.Errorf("[BridgeAgg] Can not get evm info by network id - Error %v", err)
-				rejectedInst := buildRejectedInst(
-					metadataCommon.IssuingUnifiedTokenRequestMeta, shardID, action.TxReqID, InvalidNetworkIDError, []byte{})
-				return [][]string{rejectedInst}, state, ac, nil
+				Logger.log.Errorf("[BridgeAgg] Invalid token pair pTokenID and extTokenID - Error %v", err)
+				continue
 			}
 
-			// extract info from Receipt
-			extAmount, incAddr, extTokenID, errShield := metadataBridge.ExtractIssueEVMDataFromReceipt(
-				txReceipt, evmInfo.ContractAddress, evmInfo.Prefix, incAddrStr,
-			)
-			redepositDataLst, errReshield := metadataBridge.ExtractRedepositEVMDataFromReceipt(txReceipt, evmInfo.ContractAddress, evmInfo.Prefix)
-			if errShield == nil {
-				incAddrStr = incAddr
-				key, _ := wallet.Base58CheckDeserialize(incAddr)
-				shardID, _ := metadataBridge.GetShardIDFromPaymentAddress(key.KeySet.PaymentAddress)
-				redepositDataLst = append(redepositDataLst, metadataBridge.DepositEventData{
-					Amount:          extAmount,
-					ReceiverStr:     incAddr,
-					ExternalTokenID: extTokenID,
-					IncTxID:         uniqTx,
-					ShardID:         shardID,
-					IsOneTime:       false,
-				})
-			} else {
-				Logger.log.Errorf("[BridgeAgg] Can not extract data from Receipt - Error %v", errShield)
-				if errReshield != nil {
-					Logger.log.Warnf("[BridgeAgg] Extract Redeposit EVM events failed - %v", errReshield)
-					rejectedInst := buildRejectedInst(
-						metadataCommon.IssuingUnifiedTokenRequestMeta, shardID, action.TxReqID, ExtractDataFromReceiptError, []byte{})
-					return [][]string{rejectedInst}, state, ac, nil
+			// calculate shielding amount (in pDecimal)
+			extDecimal := vault.ExtDecimal()
+			if !bytes.Equal(networkInfo.NativeTokenID, d.ExternalTokenID) {
+				if extDecimal > config.Param().BridgeAggParam.BaseDecimal {
+					extDecimal = config.Param().BridgeAggParam.BaseDecimal
 				}
 			}
+			incAmount, err := ConvertAmountByDecimal(d.Amount, extDecimal, true)
+			if err != nil {
+				Logger.log.Errorf("[BridgeAgg] Cannot convert external amount to incognito amount - Error %v", err)
+				continue
+			}
 
-			var validShieldProof bool = false
-			for _, d := range redepositDataLst {
-				vault, _ := clonedVaults[shieldData.IncTokenID]
-				// check double use shielding proof in current block and previous blocks
-				isValid, _, err := ValidateDoubleShieldProof(d.IncTxID, evmInfo.ListTxUsedInBlock, evmInfo.IsTxHashIssued, stateDBs[common.BeaconChainID])
-				if err != nil {
-					Logger.log.Errorf("[BridgeAgg] Cannot validate double shielding proof - Error %v", err)
-					continue
-				}
-				if !isValid {
-					Logger.log.Errorf("[BridgeAgg] Shielding proof was submitted - Error %v", err)
-					continue
-				}
-				// verify token pair pTokenID - extTokenID
-				err = metadataBridge.VerifyTokenPair(stateDBs, ac, shieldData.IncTokenID, d.ExternalTokenID)
-				if err != nil {
-					Logger.log.Errorf("[BridgeAgg] Invalid token pair pTokenID and extTokenID - Error %v", err)
-					continue
-				}
-
-				// calculate shielding amount (in pDecimal)
-				extDecimal := vault.ExtDecimal()
-				if !bytes.Equal(append([]byte(evmInfo.Prefix), rCommon.HexToAddress(common.NativeToken).Bytes()...), d.ExternalTokenID) {
-					if extDecimal > config.Param().BridgeAggParam.BaseDecimal {
-						extDecimal = config.Param().BridgeAggParam.BaseDecimal
-					}
-				}
-				incAmount, err := ConvertAmountByDecimal(d.Amount, extDecimal, true)
-				if err != nil {
-					Logger.log.Errorf("[BridgeAgg] Cannot convert external amount to incognito amount - Error %v", err)
-					continue
-				}
-
-				// calculate shielding reward (in pDecimal)
-				reward, err := CalRewardForRefillVault(vault, incAmount.Uint64())
-				if err != nil {
-					Logger.log.Errorf("[BridgeAgg] Cannot calculate shielding reward - Error %v", err)
-					continue
-				}
+			// calculate shielding reward (in pDecimal)
+			reward, err := CalRewardForRefillVault(vault, incAmount.Uint64())
+			if err != nil {
+				Logger.log.Errorf("[BridgeAgg] Cannot calculate shielding reward - Error %v", err)
+				continue
+			}
 
-				// update state: list of shielding txs, vault state (waiting unshield amount, waiting reward)
-				evmInfo.ListTxUsedInBlock = append(evmInfo.ListTxUsedInBlock, d.IncTxID)
-				clonedAC, err := clonedAC.UpdateUniqTxsUsed(shieldData.NetworkID, evmInfo.ListTxUsedInBlock)
-				if err != nil {
-					Logger.log.Errorf("[BridgeAgg] Cannot update accumulate values for shield request - Error %v", err)
-					continue
-				}
+			// update state: list of shielding txs, vault state (waiting unshield amount, waiting reward)
+			networkInfo.ListTxUsedInBlock = append(networkInfo.ListTxUsedInBlock, d.IncTxID)
+			clonedAC, err := clonedAC.UpdateUniqTxsUsed(shieldData.NetworkID, networkInfo.ListTxUsedInBlock)
+			if err != nil {
+				Logger.log.Errorf("[BridgeAgg] Cannot update accumulate values for shield request - Error %v", err)
+				continue
+			}
 
-				Logger.log.Infof("Cloned AC after update: %+v\n", clonedAC)
-				clonedVaults[shieldData.IncTokenID], err = updateVaultForRefill(vault, incAmount.Uint64(), reward)
-				if err != nil {
-					Logger.log.Errorf("[BridgeAgg] Cannot update vault state for shield request - Error %v", err)
-					continue
-				}
-				validShieldProof = true
-
-				if d.IsOneTime {
-					var recv privacy.OTAReceiver
-					recv.FromString(d.ReceiverStr)
-					c := metadataBridge.AcceptedReshieldRequest{
-						UnifiedTokenID: &meta.UnifiedTokenID,
-						Receiver:       recv,
-						TxReqID:        action.TxReqID,
-						ReshieldData: metadataBridge.AcceptedShieldRequestData{
-							ShieldAmount:    incAmount.Uint64(),
-							Reward:          reward,
-							UniqTx:          d.IncTxID,
-							ExternalTokenID: d.ExternalTokenID,
-							NetworkID:       shieldData.NetworkID,
-							IncTokenID:      shieldData.IncTokenID,
-						},
-					}
-					contentBytes, _ := json.Marshal(c)
-					inst := metadataCommon.NewInstructionWithValue(
-						metadataCommon.IssuingReshieldResponseMeta,
-						common.AcceptedStatusStr,
-						d.ShardID,
-						base64.StdEncoding.EncodeToString(contentBytes),
-					)
-					acceptedReshieldInstructions = append(acceptedReshieldInstructions, inst.StringSlice())
-				} else {
-					acceptedShieldData = append(acceptedShieldData, metadataBridge.AcceptedShieldRequestData{
+			Logger.log.Infof("Cloned AC after update: %+v\n", clonedAC)
+			clonedVaults[shieldData.IncTokenID], err = updateVaultForRefill(vault, incAmount.Uint64(), reward)
+			if err != nil {
+				Logger.log.Errorf("[BridgeAgg] Cannot update vault state for shield request - Error %v", err)
+				continue
+			}
+			validShieldProof = true
+
+			if d.IsOneTime {
+				var recv privacy.OTAReceiver
+				recv.FromString(d.ReceiverStr)
+				c := metadataBridge.AcceptedReshieldRequest{
+					UnifiedTokenID: &meta.UnifiedTokenID,
+					Receiver:       recv,
+					TxReqID:        action.TxReqID,
+					ReshieldData: metadataBridge.AcceptedShieldRequestData{
 						ShieldAmount:    incAmount.Uint64(),
 						Reward:          reward,
 						UniqTx:          d.IncTxID,
 						ExternalTokenID: d.ExternalTokenID,
 						NetworkID:       shieldData.NetworkID,
 						IncTokenID:      shieldData.IncTokenID,
-					})
+					},
 				}
+				contentBytes, _ := json.Marshal(c)
+				inst := metadataCommon.NewInstructionWithValue(
+					metadataCommon.IssuingReshieldResponseMeta,
+					common.AcceptedStatusStr,
+					d.ShardID,
+					base64.StdEncoding.EncodeToString(contentBytes),
+				)
+				acceptedReshieldInstructions = append(acceptedReshieldInstructions, inst.StringSlice())
+			} else {
+				acceptedShieldData = append(acceptedShieldData, metadataBridge.AcceptedShieldRequestData{
+					ShieldAmount:    incAmount.Uint64(),
+					Reward:          reward,
+					UniqTx:          d.IncTxID,
+					ExternalTokenID: d.ExternalTokenID,
+					NetworkID:       shieldData.NetworkID,
+					IncTokenID:      shieldData.IncTokenID,
+				})
 			}
-			if !validShieldProof {
-				Logger.log.Errorf("[BridgeAgg] no valid shield event found in proof")
-				rejectedInst := buildRejectedInst(metadataCommon.IssuingUnifiedTokenRequestMeta, shardID, action.TxReqID, NoValidShieldEventError, []byte{})
-				return [][]string{rejectedInst}, state, ac, nil
-			}
-
-		default:
-			Logger.log.Errorf("[BridgeAgg] Network ID is not matched: %v", shieldData.NetworkID)
-			rejectedInst := buildRejectedInst(
-				metadataCommon.IssuingUnifiedTokenRequestMeta, shardID, action.TxReqID, InvalidNetworkIDError, []byte{})
+		}
+		if !validShieldProof {
+			Logger.log.Errorf("[BridgeAgg] no valid shield event found in proof")
+			rejectedInst := buildRejectedInst(metadataCommon.IssuingUnifiedTokenRequestMeta, shardID, action.TxReqID, NoValidShieldEventError, []byte{})
 			return [][]string{rejectedInst}, state, ac, nil
 		}
 	}
@@ -433,10 +395,22 @@ func (sp *stateProducer) unshield(
 		return [][]string{rejectedInst}, state, nil
 	}
 
+	// split burning amount across networks for auto-route request
+	unshieldDatas := meta.Data
+	if meta.AutoRoute != nil {
+		unshieldDatas, err = splitAutoRouteUnshieldReq(vaults, meta.AutoRoute)
+		if err != nil {
+			Logger.log.Errorf("[BridgeAgg] Error when routing unshield: %v", err)
+			rejectedInst := buildRejectedUnshieldReqInst(
+				*meta, shardID, txReqID, AutoRouteUnshieldError)
+			return [][]string{rejectedInst}, state, nil
+		}
+	}
+
 	// check vaults
 	isEnoughVault, waitingUnshieldDatas, err := checkVaultForNewUnshieldReq(
 		vaults,
-		meta.Data,
+		unshieldDatas,
 		meta.IsDepositToSC,
 		clonedState.param.PercentFeeWithDec(),
 		stateDB,
@@ -448,6 +422,20 @@ func (sp *stateProducer) unshield(
 		return [][]string{rejectedInst}, state, nil
 	}
 
+	// check max fee for auto-route request
+	if meta.AutoRoute != nil {
+		totalFee := uint64(0)
+		for _, data := range waitingUnshieldDatas {
+			totalFee += data.Fee
+		}
+		if totalFee > meta.AutoRoute.MaxFee {
+			Logger.log.Errorf("[BridgeAgg] Unshield fee %v exceeds max fee %v", totalFee, meta.AutoRoute.MaxFee)
+			rejectedInst := buildRejectedUnshieldReqInst(
+				*meta, shardID, txReqID, ExceedMaxUnshieldFeeError)
+			return [][]string{rejectedInst}, state, nil
+		}
+	}
+
 	waitingUnshieldReq := statedb.NewBridgeAggWaitingUnshieldReqStateWithValue(waitingUnshieldDatas, txReqID, beaconHeightForUnshield)
 	statusStr := common.WaitingStatusStr
 
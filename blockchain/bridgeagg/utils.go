@@ -8,6 +8,7 @@ import (
 	"errors"
 	"fmt"
 	"math/big"
+	"sort"
 	"strconv"
 
 	rCommon "github.com/ethereum/go-ethereum/common"
@@ -91,6 +92,8 @@ func GetInsertTxHashIssuedFuncByNetworkID(networkID uint8) func(*statedb.StateDB
 		return statedb.InsertAURORATxHashIssued
 	case common.AVAXNetworkID:
 		return statedb.InsertAVAXTxHashIssued
+	case common.SOLNetworkID:
+		return statedb.InsertSOLTxHashIssued
 	}
 	return nil
 }
@@ -143,10 +146,8 @@ func buildRejectedConvertReqInst(meta metadataBridge.ConvertTokenToUnifiedTokenR
 }
 
 func buildRejectedUnshieldReqInst(meta metadataBridge.UnshieldRequest, shardID byte, txReqID common.Hash, errorType int) []string {
-	var totalBurnAmt uint64
-	for _, data := range meta.Data {
-		totalBurnAmt += data.BurningAmount
-	}
+	// the burning amount was validated in shard when ValidateSanityData
+	totalBurnAmt, _ := meta.TotalBurningAmount()
 	rejectedUnshieldRequest := metadataBridge.RejectedUnshieldRequest{
 		UnifiedTokenID: meta.UnifiedTokenID,
 		Amount:         totalBurnAmt,
@@ -322,6 +323,11 @@ func getBurningConfirmMetaType(networkID uint8, isDepositToSC bool) (uint, error
 		} else {
 			burningMetaType = metadataCommon.BurningAvaxConfirmMeta
 		}
+	case common.SOLNetworkID:
+		if isDepositToSC {
+			return 0, fmt.Errorf("Deposit to SC is not supported for networkID %v", networkID)
+		}
+		burningMetaType = metadataCommon.BurningSOLConfirmMeta
 	default:
 		return 0, fmt.Errorf("Invalid networkID %v", networkID)
 	}
@@ -367,7 +373,8 @@ func CalculateIncDecimal(decimal, baseDecimal uint8) uint8 {
 func validateConfigVault(sDBs map[int]*statedb.StateDB, tokenID common.Hash, vault config.Vault) error {
 	networkID := vault.NetworkID
 	if networkID != common.BSCNetworkID && networkID != common.ETHNetworkID && networkID != common.PLGNetworkID &&
-		networkID != common.FTMNetworkID && networkID != common.AURORANetworkID && networkID != common.AVAXNetworkID {
+		networkID != common.FTMNetworkID && networkID != common.AURORANetworkID && networkID != common.AVAXNetworkID &&
+		networkID != common.SOLNetworkID {
 		return fmt.Errorf("Cannot find networkID %d", networkID)
 	}
 	if vault.ExternalDecimal == 0 {
@@ -436,6 +443,12 @@ func GetExternalTokenIDByNetworkID(externalTokenID string, networkID uint8) ([]b
 	case common.EVMNetworkType, common.AURORANetworkID:
 		tokenAddr := rCommon.HexToAddress(externalTokenID)
 		res = append([]byte(prefix), tokenAddr.Bytes()...)
+	case common.SOLNetworkType:
+		mint := base58.Base58{}.Decode(externalTokenID)
+		if len(mint) != common.SOLAddressLength {
+			return nil, fmt.Errorf("ExternalTokenID %s is invalid solana mint", externalTokenID)
+		}
+		res = append([]byte(prefix), mint...)
 	}
 	return res, nil
 }
@@ -459,6 +472,8 @@ func getPrefixByNetworkID(networkID uint8) (string, error) {
 		prefix = common.AURORAPrefix
 	case common.AVAXNetworkID:
 		prefix = common.AVAXPrefix
+	case common.SOLNetworkID:
+		prefix = common.SOLPrefix
 	default:
 		return utils.EmptyString, errors.New("Invalid networkID")
 	}
@@ -480,6 +495,8 @@ func GetNetworkIDByPrefix(prefix string) (uint8, error) {
 		networkID = common.AURORANetworkID
 	case common.AVAXPrefix:
 		networkID = common.AVAXNetworkID
+	case common.SOLPrefix:
+		networkID = common.SOLNetworkID
 	default:
 		return 0, fmt.Errorf("Invalid prefix %s for networkID", prefix)
 	}
@@ -786,6 +803,68 @@ func checkVaultForNewUnshieldReq(
 	return isEnoughVault, waitingUnshieldDatas, nil
 }
 
+// splitAutoRouteUnshieldReq splits the burning amount of an auto-route unshield request across the candidate vaults.
+// Vaults with more available amount are filled first, the remaining shortage is charged the same percent fee
+// on any vault so it is put on the vault with the most available amount to keep the total fee minimal.
+func splitAutoRouteUnshieldReq(
+	vaults map[common.Hash]*statedb.BridgeAggVaultState,
+	autoRoute *metadataBridge.AutoRouteUnshieldRequestData,
+) ([]metadataBridge.UnshieldRequestData, error) {
+	type routeCandidate struct {
+		metadataBridge.AutoRouteCandidate
+		availableAmt uint64
+	}
+	candidates := []routeCandidate{}
+	for _, c := range autoRoute.Candidates {
+		v := vaults[c.IncTokenID]
+		if v == nil {
+			return nil, fmt.Errorf("Can not found vault with incTokenID %v", c.IncTokenID)
+		}
+		availableAmt := uint64(0)
+		if v.Amount() > v.LockedAmount() {
+			availableAmt = v.Amount() - v.LockedAmount()
+		}
+		candidates = append(candidates, routeCandidate{AutoRouteCandidate: c, availableAmt: availableAmt})
+	}
+	if len(candidates) == 0 {
+		return nil, errors.New("Candidates can not be empty")
+	}
+	sort.SliceStable(candidates, func(i, j int) bool {
+		if candidates[i].availableAmt != candidates[j].availableAmt {
+			return candidates[i].availableAmt > candidates[j].availableAmt
+		}
+		return bytes.Compare(candidates[i].IncTokenID[:], candidates[j].IncTokenID[:]) < 0
+	})
+
+	remainAmt := autoRoute.BurningAmount
+	burningAmts := make([]uint64, len(candidates))
+	for i, c := range candidates {
+		if remainAmt == 0 {
+			break
+		}
+		amt := c.availableAmt
+		if amt > remainAmt {
+			amt = remainAmt
+		}
+		burningAmts[i] = amt
+		remainAmt -= amt
+	}
+	burningAmts[0] += remainAmt
+
+	res := []metadataBridge.UnshieldRequestData{}
+	for i, c := range candidates {
+		if burningAmts[i] == 0 {
+			continue
+		}
+		res = append(res, metadataBridge.UnshieldRequestData{
+			IncTokenID:    c.IncTokenID,
+			BurningAmount: burningAmts[i],
+			RemoteAddress: c.RemoteAddress,
+		})
+	}
+	return res, nil
+}
+
 func addWaitingUnshieldReq(state *State, waitingUnshieldReq *statedb.BridgeAggWaitingUnshieldReq, unifiedTokenID common.Hash) *State {
 	if state.waitingUnshieldReqs[unifiedTokenID] == nil {
 		state.waitingUnshieldReqs[unifiedTokenID] = []*statedb.BridgeAggWaitingUnshieldReq{}
@@ -10,6 +10,7 @@ import (
 	"os"
 	"path/filepath"
 	"reflect"
+	"strings"
 	"testing"
 
 	rCommon "github.com/ethereum/go-ethereum/common"
@@ -18,6 +19,7 @@ import (
 	"github.com/incognitochain/incognito-chain/dataaccessobject/rawdbv2"
 	"github.com/incognitochain/incognito-chain/dataaccessobject/statedb"
 	"github.com/incognitochain/incognito-chain/metadata"
+	metadataBridge "github.com/incognitochain/incognito-chain/metadata/bridge"
 	metadataCommon "github.com/incognitochain/incognito-chain/metadata/common"
 	"github.com/incognitochain/incognito-chain/metadata/evmcaller"
 	"github.com/incognitochain/incognito-chain/utils"
@@ -179,6 +181,115 @@ func TestCalculateAmountByDecimal(t *testing.T) {
 	}
 }
 
+func TestSplitAutoRouteUnshieldReq(t *testing.T) {
+	tokenID1 := common.Hash{1}
+	tokenID2 := common.Hash{2}
+	tokenID3 := common.Hash{3}
+	vaults := map[common.Hash]*statedb.BridgeAggVaultState{
+		tokenID1: statedb.NewBridgeAggVaultStateWithValue(1000, 800, 0, 0, 9, common.ETHNetworkID, tokenID1),
+		tokenID2: statedb.NewBridgeAggVaultStateWithValue(500, 0, 0, 0, 9, common.BSCNetworkID, tokenID2),
+		tokenID3: statedb.NewBridgeAggVaultStateWithValue(300, 300, 0, 0, 9, common.PLGNetworkID, tokenID3),
+	}
+	candidates := []metadataBridge.AutoRouteCandidate{
+		{IncTokenID: tokenID1, RemoteAddress: "a1"},
+		{IncTokenID: tokenID2, RemoteAddress: "a2"},
+		{IncTokenID: tokenID3, RemoteAddress: "a3"},
+	}
+	tests := []struct {
+		name          string
+		burningAmount uint64
+		candidates    []metadataBridge.AutoRouteCandidate
+		want          []metadataBridge.UnshieldRequestData
+		wantErr       bool
+	}{
+		{
+			name:          "Fit in the vault with most available amount",
+			burningAmount: 400,
+			candidates:    candidates,
+			want: []metadataBridge.UnshieldRequestData{
+				{IncTokenID: tokenID2, BurningAmount: 400, RemoteAddress: "a2"},
+			},
+		},
+		{
+			name:          "Split across vaults",
+			burningAmount: 600,
+			candidates:    candidates,
+			want: []metadataBridge.UnshieldRequestData{
+				{IncTokenID: tokenID2, BurningAmount: 500, RemoteAddress: "a2"},
+				{IncTokenID: tokenID1, BurningAmount: 100, RemoteAddress: "a1"},
+			},
+		},
+		{
+			name:          "Shortage is put on the vault with most available amount",
+			burningAmount: 1000,
+			candidates:    candidates,
+			want: []metadataBridge.UnshieldRequestData{
+				{IncTokenID: tokenID2, BurningAmount: 800, RemoteAddress: "a2"},
+				{IncTokenID: tokenID1, BurningAmount: 200, RemoteAddress: "a1"},
+			},
+		},
+		{
+			name:          "Not found vault",
+			burningAmount: 100,
+			candidates:    []metadataBridge.AutoRouteCandidate{{IncTokenID: common.Hash{4}, RemoteAddress: "a4"}},
+			wantErr:       true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := splitAutoRouteUnshieldReq(vaults, &metadataBridge.AutoRouteUnshieldRequestData{
+				BurningAmount: tt.burningAmount,
+				Candidates:    tt.candidates,
+			})
+			if (err != nil) != tt.wantErr {
+				t.Errorf("splitAutoRouteUnshieldReq() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("splitAutoRouteUnshieldReq() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+type autoRouteTestShardView struct {
+	metadataCommon.ShardViewRetriever
+	triggeredFeature map[string]uint64
+}
+
+func (v autoRouteTestShardView) GetTriggeredFeature() map[string]uint64 {
+	return v.triggeredFeature
+}
+
+func TestAutoRouteUnshieldReqFeature(t *testing.T) {
+	// data is set along with auto-route so a request passing the feature check is rejected right after it
+	req := metadataBridge.NewUnshieldRequest()
+	req.UnifiedTokenID = common.Hash{1}
+	req.Data = []metadataBridge.UnshieldRequestData{{IncTokenID: common.Hash{2}, BurningAmount: 100}}
+	req.AutoRoute = &metadataBridge.AutoRouteUnshieldRequestData{
+		BurningAmount: 100,
+		Candidates:    []metadataBridge.AutoRouteCandidate{{IncTokenID: common.Hash{2}}},
+	}
+	tests := []struct {
+		name             string
+		triggeredFeature map[string]uint64
+		wantErr          string
+	}{
+		{"No triggered feature", nil, "Feature not enabled yet"},
+		{"Auto-route not triggered", map[string]uint64{"pdao": 1}, "Feature not enabled yet"},
+		{"Auto-route triggered", map[string]uint64{"bridgeaggautoroute": 10}, "Data must be empty in auto-route mode"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			shardView := autoRouteTestShardView{triggeredFeature: tt.triggeredFeature}
+			_, _, err := req.ValidateSanityData(nil, shardView, nil, 0, nil)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("ValidateSanityData() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
 func readTestCases(fileName string) ([]byte, error) {
 	raw, err := ioutil.ReadFile("testdata/" + fileName)
 	if err != nil {
@@ -0,0 +1,332 @@
+package blockchain
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+
+	"github.com/incognitochain/incognito-chain/blockchain/types"
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/config"
+	"github.com/incognitochain/incognito-chain/dataaccessobject/rawdbv2"
+	"github.com/incognitochain/incognito-chain/dataaccessobject/statedb"
+	"github.com/incognitochain/incognito-chain/incdb"
+	"github.com/incognitochain/incognito-chain/incognitokey"
+)
+
+const checkpointBlockBatch = 50 //number of beacon blocks requested at once when fast syncing
+
+// BeaconCheckpoint is a trusted beacon block and the roots of beacon state after it.
+// Block is verified with the signature of Committee, state downloaded from peers is verified with the roots
+// and the other fields of the beacon view are verified with ViewHash
+type BeaconCheckpoint struct {
+	Height    uint64
+	Hash      common.Hash
+	RootHash  BeaconRootHash
+	ViewHash  common.Hash
+	Committee []incognitokey.CommitteePublicKey
+}
+
+func NewBeaconCheckpoint(c config.BeaconCheckpoint) (*BeaconCheckpoint, error) {
+	checkpoint := &BeaconCheckpoint{Height: c.Height}
+	for _, v := range []struct {
+		str  string
+		hash *common.Hash
+	}{
+		{c.Hash, &checkpoint.Hash},
+		{c.ConsensusStateRoot, &checkpoint.RootHash.ConsensusStateDBRootHash},
+		{c.FeatureStateRoot, &checkpoint.RootHash.FeatureStateDBRootHash},
+		{c.RewardStateRoot, &checkpoint.RootHash.RewardStateDBRootHash},
+		{c.SlashStateRoot, &checkpoint.RootHash.SlashStateDBRootHash},
+		{c.ViewHash, &checkpoint.ViewHash},
+	} {
+		hash, err := common.Hash{}.NewHashFromStr(v.str)
+		if err != nil {
+			return nil, fmt.Errorf("checkpoint %v: %v", c.Height, err)
+		}
+		*v.hash = *hash
+	}
+	if len(c.Committee) == 0 {
+		return nil, fmt.Errorf("checkpoint %v has no committee", c.Height)
+	}
+	committee, err := incognitokey.CommitteeBase58KeyListToStruct(c.Committee)
+	if err != nil {
+		return nil, fmt.Errorf("checkpoint %v: %v", c.Height, err)
+	}
+	checkpoint.Committee = committee
+	return checkpoint, nil
+}
+
+// LoadBeaconCheckpoint return the checkpoint supplied by the operator, or the latest one of network params.
+// It return nil if there is no checkpoint
+func LoadBeaconCheckpoint() (*BeaconCheckpoint, error) {
+	if file := config.Config().FastSyncCheckpoint; file != "" {
+		data, err := ioutil.ReadFile(file)
+		if err != nil {
+			return nil, err
+		}
+		c := config.BeaconCheckpoint{}
+		if err := json.Unmarshal(data, &c); err != nil {
+			return nil, err
+		}
+		return NewBeaconCheckpoint(c)
+	}
+	var latest *config.BeaconCheckpoint
+	for i, c := range config.Param().BeaconCheckpoints {
+		if latest == nil || c.Height > latest.Height {
+			latest = &config.Param().BeaconCheckpoints[i]
+		}
+	}
+	if latest == nil {
+		return nil, nil
+	}
+	return NewBeaconCheckpoint(*latest)
+}
+
+// isCheckpointViewHeight check if the beacon view of the height is kept to serve fast sync
+func isCheckpointViewHeight(height uint64) bool {
+	interval := config.Param().BeaconCheckpointViewInterval
+	if interval > 0 && height%interval == 0 {
+		return true
+	}
+	for _, c := range config.Param().BeaconCheckpoints {
+		if c.Height == height {
+			return true
+		}
+	}
+	return false
+}
+
+// GetBeaconCheckpointView return the beacon view after the block, for nodes fast syncing from it
+func (blockchain *BlockChain) GetBeaconCheckpointView(hash common.Hash) ([]byte, error) {
+	if view := blockchain.BeaconChain.multiView.GetViewByHash(hash); view != nil {
+		return json.Marshal(view.(*BeaconBestState))
+	}
+	return rawdbv2.GetBeaconCheckpointView(blockchain.GetBeaconChainDatabase(), hash)
+}
+
+// GetBeaconTrieNodes return encoded nodes of beacon state tries by hash
+func (blockchain *BlockChain) GetBeaconTrieNodes(hashes []common.Hash) ([][]byte, error) {
+	return statedb.GetTrieNodes(blockchain.GetBeaconChainDatabase(), hashes)
+}
+
+// verifyCheckpointBlock check the block is the checkpoint and signed by the checkpoint committee
+func (blockchain *BlockChain) verifyCheckpointBlock(checkpoint *BeaconCheckpoint, block *types.BeaconBlock, proposerLength int) error {
+	if block.GetHeight() != checkpoint.Height || *block.Hash() != checkpoint.Hash {
+		return fmt.Errorf("beacon block %v %v does not match checkpoint %v %v", block.GetHeight(), block.Hash().String(), checkpoint.Height, checkpoint.Hash.String())
+	}
+	if err := blockchain.config.ConsensusEngine.ValidateProducerSig(block, block.GetConsensusType()); err != nil {
+		return err
+	}
+	return blockchain.config.ConsensusEngine.ValidateBlockCommitteSig(block, checkpoint.Committee, proposerLength)
+}
+
+// getBeaconCheckpointViewHash return the hash of the json of the view without its best block,
+// it covers all fields restored from the view (shard states, triggered features, random number, timeslot managers...).
+// Best block is verified by the checkpoint hash and the committee signature
+func getBeaconCheckpointViewHash(view *BeaconBestState) (common.Hash, error) {
+	viewWithoutBlock := *view
+	viewWithoutBlock.BestBlock = types.BeaconBlock{}
+	data, err := json.Marshal(&viewWithoutBlock)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	return common.HashH(data), nil
+}
+
+// verifyCheckpointView check the view is the state after the checkpoint block
+func verifyCheckpointView(checkpoint *BeaconCheckpoint, view *BeaconBestState) error {
+	if view.BestBlockHash != checkpoint.Hash || view.BeaconHeight != checkpoint.Height {
+		return fmt.Errorf("beacon view %v %v does not match checkpoint %v %v", view.BeaconHeight, view.BestBlockHash.String(), checkpoint.Height, checkpoint.Hash.String())
+	}
+	rootHash := BeaconRootHash{
+		ConsensusStateDBRootHash: view.ConsensusStateDBRootHash,
+		FeatureStateDBRootHash:   view.FeatureStateDBRootHash,
+		RewardStateDBRootHash:    view.RewardStateDBRootHash,
+		SlashStateDBRootHash:     view.SlashStateDBRootHash,
+	}
+	if rootHash != checkpoint.RootHash {
+		return fmt.Errorf("state roots of beacon view %v do not match checkpoint", view.BeaconHeight)
+	}
+	viewHash, err := getBeaconCheckpointViewHash(view)
+	if err != nil {
+		return err
+	}
+	if viewHash != checkpoint.ViewHash {
+		return fmt.Errorf("beacon view %v hash %v does not match checkpoint %v", view.BeaconHeight, viewHash.String(), checkpoint.ViewHash.String())
+	}
+	return nil
+}
+
+func (r *remoteRPCClient) call(method string, params []interface{}, result interface{}) error {
+	requestBody, err := json.Marshal(map[string]interface{}{
+		"jsonrpc": "1.0",
+		"method":  method,
+		"params":  params,
+		"id":      1,
+	})
+	if err != nil {
+		return err
+	}
+	body, err := r.sendRequest(requestBody)
+	if err != nil {
+		return err
+	}
+	resp := struct {
+		Result json.RawMessage
+		Error  *ErrMsg
+	}{}
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return err
+	}
+	if resp.Error != nil {
+		return fmt.Errorf("%v: %v", method, resp.Error.Message)
+	}
+	return json.Unmarshal(resp.Result, result)
+}
+
+func (r *remoteRPCClient) GetBeaconCheckpointView(hash common.Hash) (*BeaconBestState, error) {
+	view := &BeaconBestState{}
+	if err := r.call("getbeaconcheckpointview", []interface{}{hash.String()}, view); err != nil {
+		return nil, err
+	}
+	return view, nil
+}
+
+func (r *remoteRPCClient) GetBeaconTrieNodes(hashes []common.Hash) ([][]byte, error) {
+	hashStrs := make([]string, 0, len(hashes))
+	for _, hash := range hashes {
+		hashStrs = append(hashStrs, hash.String())
+	}
+	res := [][]byte{}
+	if err := r.call("getbeacontrienodes", []interface{}{hashStrs}, &res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// getBeaconBlocks download beacon blocks [from, to], the last block must be lastHash and blocks must be linked
+func (r *remoteRPCClient) getBeaconBlocks(from uint64, to uint64, lastHash common.Hash) ([]*types.BeaconBlock, error) {
+	blocks := []*types.BeaconBlock{}
+	for height := from; height <= to; {
+		res, err := r.GetBlocksFromHeight(-1, height, checkpointBlockBatch)
+		if err != nil {
+			return nil, err
+		}
+		requestHeight := height
+		for _, blk := range res.([]types.BeaconBlock) {
+			if blk.GetHeight() != height || height > to {
+				continue
+			}
+			blk := blk
+			blocks = append(blocks, &blk)
+			height++
+		}
+		if height == requestHeight {
+			return nil, fmt.Errorf("no beacon block %v", height)
+		}
+	}
+	expectHash := lastHash
+	for i := len(blocks) - 1; i >= 0; i-- {
+		if *blocks[i].Hash() != expectHash {
+			return nil, fmt.Errorf("beacon block %v is %v, expect %v", blocks[i].GetHeight(), blocks[i].Hash().String(), expectHash.String())
+		}
+		expectHash = blocks[i].GetPrevHash()
+	}
+	return blocks, nil
+}
+
+// FastSyncBeacon download the beacon state at the checkpoint from bootstrap hosts trie node by trie node,
+// and start the beacon chain from it. Nothing is done if the chain is already at the checkpoint
+func (s *BootstrapManager) FastSyncBeacon() error {
+	checkpoint, err := LoadBeaconCheckpoint()
+	if err != nil {
+		return err
+	}
+	if checkpoint == nil {
+		return errors.New("no beacon checkpoint to fast sync from")
+	}
+	if s.blockchain.GetBeaconBestState().BeaconHeight >= checkpoint.Height {
+		return nil
+	}
+	if s.blockchain.IsEqualToRandomTime(checkpoint.Height) {
+		return fmt.Errorf("checkpoint %v is at random time, committee state cannot be restored", checkpoint.Height)
+	}
+	for _, host := range s.shuffleHosts() {
+		rpcClient := remoteRPCClient{fmt.Sprintf("http://%v", host)}
+		Logger.log.Infof("Start fast sync beacon from checkpoint %v %v, host %v", checkpoint.Height, checkpoint.Hash.String(), host)
+		if err := s.fastSyncBeacon(&rpcClient, checkpoint); err != nil {
+			Logger.log.Errorf("Fast sync beacon from host %v fail: %v", host, err)
+			continue
+		}
+		Logger.log.Infof("Fast sync beacon finish at checkpoint %v", checkpoint.Height)
+		return nil
+	}
+	return fmt.Errorf("cannot fast sync beacon from checkpoint %v", checkpoint.Height)
+}
+
+func (s *BootstrapManager) fastSyncBeacon(rpcClient *remoteRPCClient, checkpoint *BeaconCheckpoint) error {
+	view, err := rpcClient.GetBeaconCheckpointView(checkpoint.Hash)
+	if err != nil {
+		return err
+	}
+	if err := verifyCheckpointView(checkpoint, view); err != nil {
+		return err
+	}
+	//blocks of the checkpoint epoch are needed to restore the view
+	firstHeight := GetFirstBeaconHeightInEpoch(view.Epoch)
+	if firstHeight < 1 || firstHeight > checkpoint.Height {
+		firstHeight = checkpoint.Height
+	}
+	blocks, err := rpcClient.getBeaconBlocks(firstHeight, checkpoint.Height, checkpoint.Hash)
+	if err != nil {
+		return err
+	}
+	checkpointBlock := blocks[len(blocks)-1]
+	if err := s.blockchain.verifyCheckpointBlock(checkpoint, checkpointBlock, view.GetProposerLength()); err != nil {
+		return err
+	}
+
+	db := s.blockchain.GetBeaconChainDatabase()
+	for _, root := range []common.Hash{
+		checkpoint.RootHash.ConsensusStateDBRootHash,
+		checkpoint.RootHash.FeatureStateDBRootHash,
+		checkpoint.RootHash.RewardStateDBRootHash,
+		checkpoint.RootHash.SlashStateDBRootHash,
+	} {
+		count, err := statedb.SyncTrie(db, root, rpcClient.GetBeaconTrieNodes)
+		if err != nil {
+			return fmt.Errorf("sync state trie %v: %v", root.String(), err)
+		}
+		Logger.log.Infof("Fast sync beacon state trie %v, %v nodes downloaded", root.String(), count)
+	}
+
+	s.blockchain.BeaconChain.insertLock.Lock()
+	defer s.blockchain.BeaconChain.insertLock.Unlock()
+	return s.blockchain.startBeaconFromCheckpoint(db, checkpoint, blocks, view)
+}
+
+// startBeaconFromCheckpoint store blocks of the checkpoint epoch and restart beacon views from the checkpoint view
+func (blockchain *BlockChain) startBeaconFromCheckpoint(db incdb.Database, checkpoint *BeaconCheckpoint, blocks []*types.BeaconBlock, view *BeaconBestState) error {
+	for _, blk := range blocks {
+		if err := blockchain.BeaconChain.BlockStorage.StoreBlock(blk); err != nil {
+			return err
+		}
+		if err := blockchain.BeaconChain.BlockStorage.StoreFinalizedBeaconBlock(blk.GetHeight(), *blk.Hash()); err != nil {
+			return err
+		}
+	}
+	if err := rawdbv2.StoreBeaconRootsHash(db, checkpoint.Hash, checkpoint.RootHash); err != nil {
+		return err
+	}
+	view.BestBlock = *blocks[len(blocks)-1]
+	b, err := json.Marshal([]*BeaconBestState{view})
+	if err != nil {
+		return err
+	}
+	if err := rawdbv2.StoreBeaconViews(db, b); err != nil {
+		return err
+	}
+	return blockchain.RestoreBeaconViews()
+}
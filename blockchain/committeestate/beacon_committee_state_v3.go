@@ -176,6 +176,17 @@ func (b *BeaconCommitteeStateV3) UpdateCommitteeState(env *BeaconCommitteeStateE
 			}
 			committeeChange = b.processStopAutoStakeInstruction(stopAutoStakeInstruction, env, committeeChange)
 
+		case instruction.EQUIVOCATION_ACTION:
+			if env.TriggeredFeature[config.EQUIVOCATION_SLASHING] == 0 {
+				continue
+			}
+			equivocationInstruction, err := instruction.ValidateAndImportEquivocationInstructionFromString(inst)
+			if err != nil {
+				return nil, nil, nil, NewCommitteeStateError(ErrUpdateCommitteeState, err)
+			}
+			// shard validator that double vote is forced to leave at its next swap
+			committeeChange = b.turnOffAutoStake(env.newAllRoles, []string{equivocationInstruction.PublicKey}, committeeChange)
+
 		case instruction.SWAP_SHARD_ACTION:
 			swapShardInstruction, err := instruction.ValidateAndImportSwapShardInstructionFromString(inst)
 			if err != nil {
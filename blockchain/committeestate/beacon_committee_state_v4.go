@@ -402,6 +402,15 @@ func (s *BeaconCommitteeStateV4) GetUnsyncBeaconValidator() []incognitokey.Commi
 	return res
 }
 
+// GetBeaconPerformance return current performance score of a beacon committee, pending or waiting validator
+func (s *BeaconCommitteeStateV4) GetBeaconPerformance(cpk string) (uint64, bool) {
+	stakerInfo := s.getBeaconStakerInfo(cpk)
+	if stakerInfo == nil {
+		return 0, false
+	}
+	return stakerInfo.Performance, true
+}
+
 func (s *BeaconCommitteeStateV4) GetBeaconLocking() []incognitokey.CommitteePublicKey {
 	return GetKeyStructListFromMapLocking(s.beaconLocking)
 }
@@ -620,6 +629,7 @@ func (s *BeaconCommitteeStateV4) UpdateCommitteeState(env *BeaconCommitteeStateE
 		s.ProcessBeaconUnstakeInstruction,
 		s.ProcessDelegateRewardForReturnValidator,
 		s.ProcessBeaconRedelegateInstruction,
+		s.ProcessBeaconEquivocationInstruction,
 		s.ProcessBeaconSwapAndSlash,
 		s.ProcessBeaconFinishSyncInstruction,
 		s.ProcessBeaconWaitingCondition,
@@ -649,15 +659,22 @@ func (s *BeaconCommitteeStateV4) UpdateCommitteeState(env *BeaconCommitteeStateE
 	return stateHash, changes, instructions, nil
 }
 func (s *BeaconCommitteeStateV4) commiteeAndPendingHash() common.Hash {
+	return common.HashH(s.CommitteeAndPendingRootPreimage())
+}
 
-	type Data struct {
-		Config          BeaconCommitteeStateV4Config
-		BeaconCommittee []*StakerInfo
-		BeaconPending   []*StakerInfo
-	}
+// BeaconCommitteeRootData is the data hashed into BeaconCommitteeAndValidatorRoot of beacon header since staking flow v4
+type BeaconCommitteeRootData struct {
+	Config          BeaconCommitteeStateV4Config
+	BeaconCommittee []*StakerInfo
+	BeaconPending   []*StakerInfo
+}
+
+// CommitteeAndPendingRootPreimage return the bytes hashed into BeaconCommitteeAndValidatorRoot,
+// light clients use it to check a beacon committee against a beacon header
+func (s *BeaconCommitteeStateV4) CommitteeAndPendingRootPreimage() []byte {
 	beaconCommittee := s.GetBeaconCommittee()
 	beaconPending := s.GetBeaconSubstitute()
-	data := Data{
+	data := BeaconCommitteeRootData{
 		Config:          s.config,
 		BeaconCommittee: []*StakerInfo{},
 		BeaconPending:   []*StakerInfo{},
@@ -679,8 +696,7 @@ func (s *BeaconCommitteeStateV4) commiteeAndPendingHash() common.Hash {
 		data.BeaconPending = append(data.BeaconPending, stakerInfo)
 	}
 	b, _ := json.Marshal(data)
-	hash := common.HashH(b)
-	return hash
+	return b
 }
 
 func (s *BeaconCommitteeStateV4) waitingAndSlashingHash() common.Hash {
@@ -826,6 +842,11 @@ func (s *BeaconCommitteeStateV4) ProcessBeaconSwapAndSlash(env ProcessContext) (
 	}
 
 	//slash
+	if env.TriggeredFeature[config.EQUIVOCATION_SLASHING] != 0 {
+		if err = s.slashEquivocationOffenders(env); err != nil {
+			return nil, err
+		}
+	}
 	for cpk, stakerInfo := range s.beaconCommittee {
 		if stakerInfo.Performance <= s.config.MIN_PERFORMANCE && !stakerInfo.FixedNode {
 			slashCpk[cpk] = env.Epoch + s.getTotalLockingEpoch(stakerInfo.Performance)
@@ -1108,6 +1129,56 @@ func (s *BeaconCommitteeStateV4) ProcessBeaconUnstakeInstruction(env ProcessCont
 	return nil, nil
 }
 
+// Process equivocation instruction
+// -> lock beacon staker that double vote, with the longest locking period
+// -> remove it from committee, pending and waiting pool
+func (s *BeaconCommitteeStateV4) ProcessBeaconEquivocationInstruction(env ProcessContext) ([][]string, error) {
+	if env.TriggeredFeature[config.EQUIVOCATION_SLASHING] == 0 {
+		return nil, nil
+	}
+	for _, inst := range env.BeaconInstructions {
+		if inst[0] != instruction.EQUIVOCATION_ACTION {
+			continue
+		}
+		equivocationInst, err := instruction.ValidateAndImportEquivocationInstructionFromString(inst)
+		if err != nil {
+			return nil, err
+		}
+		// votes are recorded so the evidence cannot be replayed, the offender is slashed at the end of epoch
+		cpk := equivocationInst.PublicKey
+		Logger.log.Infof("Beacon Height %+v, record equivocation of validator %+v, reason %+v", env.BeaconHeight, cpk, equivocationInst.Evidence.Reason)
+		if err = statedb.StoreEquivocationVotes(s.stateDB, equivocationInst.Evidence.VoteHashes(), cpk, env.Epoch); err != nil {
+			return nil, err
+		}
+	}
+	return nil, nil
+}
+
+// slashEquivocationOffenders lock validators with equivocation evidence processed in this epoch
+// and remove them from all pools
+func (s *BeaconCommitteeStateV4) slashEquivocationOffenders(env ProcessContext) error {
+	for _, cpk := range statedb.GetEquivocationOffenders(s.stateDB, env.Epoch) {
+		stakerInfo := s.getBeaconStakerInfo(cpk)
+		if stakerInfo == nil || stakerInfo.FixedNode {
+			continue
+		}
+		Logger.log.Infof("Beacon Height %+v, lock equivocation validator %+v", env.BeaconHeight, cpk)
+		if err := s.setLocking(cpk, env.Epoch, env.Epoch+s.getTotalLockingEpoch(0), statedb.BY_SLASH); err != nil {
+			return err
+		}
+		if err := s.removeFromPool(COMMITTEE_POOL, cpk); err != nil {
+			return err
+		}
+		if err := s.removeFromPool(PENDING_POOL, cpk); err != nil {
+			return err
+		}
+		if err := s.removeFromPool(WAITING_POOL, cpk); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // Process return staking amount (unlocking)
 func (s *BeaconCommitteeStateV4) ProcessBeaconUnlocking(env ProcessContext) ([][]string, error) {
 	if !lastBlockEpoch(env.BeaconHeight) {
@@ -0,0 +1,171 @@
+package committeestate
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/incognitochain/incognito-chain/incognitokey"
+	"github.com/incognitochain/incognito-chain/instruction"
+)
+
+// pool names used in committee state diff
+const (
+	SHARD_COMMITTEE_POOL_NAME        = "shard_committee"
+	SHARD_SUBSTITUTE_POOL_NAME       = "shard_substitute"
+	SHARD_SYNCING_POOL_NAME          = "shard_syncing"
+	SHARD_WAITING_CURRENT_POOL_NAME  = "shard_waiting_current_random"
+	SHARD_WAITING_NEXT_POOL_NAME     = "shard_waiting_next_random"
+	BEACON_COMMITTEE_POOL_NAME       = "beacon_committee"
+	BEACON_PENDING_POOL_NAME         = "beacon_pending"
+	BEACON_WAITING_POOL_NAME         = "beacon_waiting"
+	BEACON_LOCKING_POOL_NAME         = "beacon_locking"
+	COMMITTEE_MOVE_REASON_SLASH      = "slash"
+	COMMITTEE_MOVE_REASON_STATE_RULE = "state_rule"
+	BEACON_POOL_CHAIN_ID             = -1
+)
+
+// CommitteeMove is one validator entering or leaving a pool in a beacon block
+type CommitteeMove struct {
+	PublicKey   string
+	Pool        string
+	ChainID     int // shard of shard pools, -1 for beacon and candidate pools
+	Entered     bool
+	Reason      string   // action of the instruction causing the move, or a rule of committee state
+	Instruction []string `json:",omitempty"`
+}
+
+// CommitteeStateDiff keeps all moves of validators caused by a beacon block
+type CommitteeStateDiff struct {
+	BeaconHeight uint64
+	BeaconHash   string
+	Moves        []CommitteeMove
+}
+
+type committeePool struct {
+	name    string
+	chainID int
+}
+
+// CommitteePoolSnapshot is members of all pools of a beacon committee state
+type CommitteePoolSnapshot map[committeePool]map[string]bool
+
+func (snapshot CommitteePoolSnapshot) add(name string, chainID int, keys []incognitokey.CommitteePublicKey) {
+	pool := committeePool{name: name, chainID: chainID}
+	if _, ok := snapshot[pool]; !ok {
+		snapshot[pool] = make(map[string]bool)
+	}
+	keyStrs, _ := incognitokey.CommitteeKeyListToString(keys)
+	for _, k := range keyStrs {
+		snapshot[pool][k] = true
+	}
+}
+
+func NewCommitteePoolSnapshot(state BeaconCommitteeState) CommitteePoolSnapshot {
+	snapshot := make(CommitteePoolSnapshot)
+	if state == nil {
+		return snapshot
+	}
+	shardCommittee, shardSubstitute, syncingValidators,
+		beaconCommittee, beaconPending, beaconWaiting, beaconLocking,
+		waitingCurrentRandom, waitingNextRandom := state.GetAllStaker()
+	for shardID, keys := range shardCommittee {
+		snapshot.add(SHARD_COMMITTEE_POOL_NAME, int(shardID), keys)
+	}
+	for shardID, keys := range shardSubstitute {
+		snapshot.add(SHARD_SUBSTITUTE_POOL_NAME, int(shardID), keys)
+	}
+	for shardID, keys := range syncingValidators {
+		snapshot.add(SHARD_SYNCING_POOL_NAME, int(shardID), keys)
+	}
+	snapshot.add(BEACON_COMMITTEE_POOL_NAME, BEACON_POOL_CHAIN_ID, beaconCommittee)
+	snapshot.add(BEACON_PENDING_POOL_NAME, BEACON_POOL_CHAIN_ID, beaconPending)
+	snapshot.add(BEACON_WAITING_POOL_NAME, BEACON_POOL_CHAIN_ID, beaconWaiting)
+	snapshot.add(BEACON_LOCKING_POOL_NAME, BEACON_POOL_CHAIN_ID, beaconLocking)
+	snapshot.add(SHARD_WAITING_CURRENT_POOL_NAME, BEACON_POOL_CHAIN_ID, waitingCurrentRandom)
+	snapshot.add(SHARD_WAITING_NEXT_POOL_NAME, BEACON_POOL_CHAIN_ID, waitingNextRandom)
+	return snapshot
+}
+
+// NewCommitteeStateDiff compare pools before and after processing a beacon block,
+// each move is explained by the first consensus instruction of block mentioning the validator,
+// moves without instruction come from slashing or the rules of committee state
+func NewCommitteeStateDiff(
+	beaconHeight uint64,
+	beaconHash string,
+	before, after CommitteePoolSnapshot,
+	instructions [][]string,
+	committeeChange *CommitteeChange,
+) *CommitteeStateDiff {
+	diff := &CommitteeStateDiff{
+		BeaconHeight: beaconHeight,
+		BeaconHash:   beaconHash,
+		Moves:        []CommitteeMove{},
+	}
+	slashed := make(map[string]bool)
+	if committeeChange != nil {
+		for _, keys := range committeeChange.SlashingCommittee {
+			for _, k := range keys {
+				slashed[k] = true
+			}
+		}
+	}
+	newMove := func(pool committeePool, key string, entered bool) CommitteeMove {
+		move := CommitteeMove{PublicKey: key, Pool: pool.name, ChainID: pool.chainID, Entered: entered}
+		if inst := findConsensusInstruction(instructions, key); inst != nil {
+			move.Reason = inst[0]
+			move.Instruction = inst
+		} else if slashed[key] {
+			move.Reason = COMMITTEE_MOVE_REASON_SLASH
+		} else {
+			move.Reason = COMMITTEE_MOVE_REASON_STATE_RULE
+		}
+		return move
+	}
+	for pool, keys := range after {
+		for k := range keys {
+			if !before[pool][k] {
+				diff.Moves = append(diff.Moves, newMove(pool, k, true))
+			}
+		}
+	}
+	for pool, keys := range before {
+		for k := range keys {
+			if !after[pool][k] {
+				diff.Moves = append(diff.Moves, newMove(pool, k, false))
+			}
+		}
+	}
+	sort.Slice(diff.Moves, func(i, j int) bool {
+		a, b := diff.Moves[i], diff.Moves[j]
+		if a.Pool != b.Pool {
+			return a.Pool < b.Pool
+		}
+		if a.ChainID != b.ChainID {
+			return a.ChainID < b.ChainID
+		}
+		if a.Entered != b.Entered {
+			return a.Entered
+		}
+		return a.PublicKey < b.PublicKey
+	})
+	return diff
+}
+
+func findConsensusInstruction(instructions [][]string, key string) []string {
+	for _, inst := range instructions {
+		if len(inst) < 2 || !instruction.IsConsensusInstruction(inst[0]) {
+			continue
+		}
+		for _, field := range inst[1:] {
+			if !strings.Contains(field, key) {
+				continue
+			}
+			for _, v := range strings.Split(field, instruction.SPLITTER) {
+				if v == key {
+					return inst
+				}
+			}
+		}
+	}
+	return nil
+}
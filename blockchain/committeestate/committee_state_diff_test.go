@@ -0,0 +1,74 @@
+package committeestate
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/incognitochain/incognito-chain/instruction"
+)
+
+func TestNewCommitteeStateDiff(t *testing.T) {
+	shard0Committee := committeePool{name: SHARD_COMMITTEE_POOL_NAME, chainID: 0}
+	shard0Substitute := committeePool{name: SHARD_SUBSTITUTE_POOL_NAME, chainID: 0}
+	beaconWaiting := committeePool{name: BEACON_WAITING_POOL_NAME, chainID: BEACON_POOL_CHAIN_ID}
+
+	before := CommitteePoolSnapshot{
+		shard0Committee:  {"key1": true, "key2": true, "key3": true},
+		shard0Substitute: {"key4": true},
+	}
+	after := CommitteePoolSnapshot{
+		shard0Committee:  {"key1": true, "key4": true},
+		shard0Substitute: {},
+		beaconWaiting:    {"key5": true},
+	}
+	swapInst := []string{instruction.SWAP_SHARD_ACTION, "key4", "key2", "0", "0"}
+	stakeInst := []string{instruction.BEACON_STAKE_ACTION, "key5"}
+	instructions := [][]string{
+		{instruction.RANDOM_ACTION, "123"},
+		swapInst,
+		stakeInst,
+	}
+	committeeChange := NewCommitteeChange()
+	committeeChange.SlashingCommittee[0] = []string{"key3"}
+
+	diff := NewCommitteeStateDiff(10, "hash", before, after, instructions, committeeChange)
+
+	want := []CommitteeMove{
+		{PublicKey: "key5", Pool: BEACON_WAITING_POOL_NAME, ChainID: BEACON_POOL_CHAIN_ID, Entered: true, Reason: instruction.BEACON_STAKE_ACTION, Instruction: stakeInst},
+		{PublicKey: "key4", Pool: SHARD_COMMITTEE_POOL_NAME, ChainID: 0, Entered: true, Reason: instruction.SWAP_SHARD_ACTION, Instruction: swapInst},
+		{PublicKey: "key2", Pool: SHARD_COMMITTEE_POOL_NAME, ChainID: 0, Entered: false, Reason: instruction.SWAP_SHARD_ACTION, Instruction: swapInst},
+		{PublicKey: "key3", Pool: SHARD_COMMITTEE_POOL_NAME, ChainID: 0, Entered: false, Reason: COMMITTEE_MOVE_REASON_SLASH},
+		{PublicKey: "key4", Pool: SHARD_SUBSTITUTE_POOL_NAME, ChainID: 0, Entered: false, Reason: instruction.SWAP_SHARD_ACTION, Instruction: swapInst},
+	}
+	if diff.BeaconHeight != 10 || diff.BeaconHash != "hash" {
+		t.Fatalf("NewCommitteeStateDiff() block = %+v %+v", diff.BeaconHeight, diff.BeaconHash)
+	}
+	if !reflect.DeepEqual(diff.Moves, want) {
+		t.Errorf("NewCommitteeStateDiff() moves = %+v, want %+v", diff.Moves, want)
+	}
+}
+
+func TestFindConsensusInstruction(t *testing.T) {
+	stakeInst := []string{instruction.STAKE_ACTION, "key1,key12", "0,0", "tx1,tx2", "addr1,addr2", "true,true"}
+	instructions := [][]string{
+		{instruction.RANDOM_ACTION, "1234"},
+		{"90", "key1"},
+		stakeInst,
+	}
+	tests := []struct {
+		name string
+		key  string
+		want []string
+	}{
+		{name: "Exact key in list", key: "key12", want: stakeInst},
+		{name: "Prefix of key must not match", key: "key", want: nil},
+		{name: "Non consensus instruction is skipped", key: "key1", want: stakeInst},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := findConsensusInstruction(instructions, tt.key); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("findConsensusInstruction() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
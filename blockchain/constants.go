@@ -32,6 +32,8 @@ const (
 	MaxSubsetCommittees                = 2
 
 	DEQUEUE_THRESHOLD_PERCENT = 0.5
+
+	MAX_COMMITTEE_STATE_DIFF_RANGE = 1000 // max number of beacon blocks of a committee state diff query
 )
 
 // burning addresses
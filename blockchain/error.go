@@ -101,6 +101,7 @@ const (
 	BuildRewardInstructionError
 	BuildBridgeError
 	BuildBridgeAggError
+	EquivocationInstructionError
 	GenerateBeaconCommitteeAndValidatorRootError
 	GenerateShardCommitteeAndValidatorRootError
 	GenerateBeaconCandidateRootError
@@ -375,6 +376,7 @@ var ErrCodeMessage = map[int]struct {
 	FinishSyncInstructionError:                        {-1165, "Checking finish sync instruction error"},
 	BuildBridgeError:                                  {-1166, "Build bridge unshield instruction error"},
 	BuildBridgeAggError:                               {-1167, "Build bridge agg unshield instruction error"},
+	EquivocationInstructionError:                      {-1168, "Checking equivocation instruction error"},
 
 	GetListOutputCoinsByKeysetError:                 {-2000, "Get List Output Coins By Keyset Error"},
 	GetTotalLockedCollateralError:                   {-3000, "Get Total Locked Collateral Error"},
@@ -0,0 +1,233 @@
+package blockchain
+
+import (
+	"math"
+	"sort"
+	"sync"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/config"
+	"github.com/incognitochain/incognito-chain/incognitokey"
+	"github.com/incognitochain/incognito-chain/pubsub"
+)
+
+// percentage of committee must support a feature before beacon proposer generates enable feature instruction
+const (
+	BEACON_FEATURE_COMMITTEE_PERCENT = 95
+	SHARD_FEATURE_COMMITTEE_PERCENT  = 89
+)
+
+// FeatureChainReadiness is support of a feature in beacon (chainID -1) or a shard
+type FeatureChainReadiness struct {
+	ChainID             int
+	CommitteeSize       int
+	CommitteeRequired   uint64
+	CommitteeSupporting uint64
+	ValidatorSize       int
+	ValidatorRequired   uint64
+	ValidatorSupporting uint64
+	MissingValidators   []string // committee and pending validators not reporting the feature
+	Ready               bool
+}
+
+// FeatureReadiness is the readiness of an auto enable feature at a beacon view
+type FeatureReadiness struct {
+	Feature                string
+	MinTriggerHeight       uint64
+	RequiredPercentage     int
+	Triggered              bool
+	TriggeredHeight        uint64 `json:",omitempty"`
+	Ready                  bool
+	ProjectedTriggerHeight uint64 `json:",omitempty"` // height of block including enable feature instruction if feature stays ready
+	Chains                 []*FeatureChainReadiness
+}
+
+// FeatureActivation is the beacon block enabling a feature
+type FeatureActivation struct {
+	Feature      string
+	BeaconHeight uint64
+	Epoch        uint64
+	Timestamp    int64
+}
+
+// FeatureThresholdEvent is published to FeatureThresholdTopic when a feature becomes ready, not ready or triggered
+type FeatureThresholdEvent struct {
+	Feature      string
+	BeaconHeight uint64
+	Ready        bool
+	Triggered    bool
+	Readiness    *FeatureReadiness
+}
+
+// Readiness compute support of each auto enable feature, same threshold as generateEnableFeatureInstructions.
+// Like the enable feature instruction, support is the number of validators and is not weighted by stake
+func (stat *FeatureStat) Readiness(beaconView *BeaconBestState) []*FeatureReadiness {
+	report := stat.Report(beaconView)
+
+	chainValidators := map[int][]string{}
+	chainCommittee := map[int][]string{}
+	chainCommittee[-1], _ = incognitokey.CommitteeKeyListToString(beaconView.GetCommittee())
+	chainValidators[-1] = chainCommittee[-1]
+	for i := 0; i < beaconView.ActiveShards; i++ {
+		chainCommittee[i], _ = incognitokey.CommitteeKeyListToString(beaconView.GetAShardCommittee(byte(i)))
+		pending, _ := incognitokey.CommitteeKeyListToString(beaconView.GetAShardPendingValidator(byte(i)))
+		chainValidators[i] = append(append([]string{}, chainCommittee[i]...), pending...)
+	}
+
+	features := []string{}
+	for feature, info := range config.Param().AutoEnableFeature {
+		if info.MinTriggerBlockHeight == 0 {
+			continue
+		}
+		features = append(features, feature)
+	}
+	sort.Strings(features)
+
+	res := []*FeatureReadiness{}
+	for _, feature := range features {
+		info := config.Param().AutoEnableFeature[feature]
+		readiness := &FeatureReadiness{
+			Feature:            feature,
+			MinTriggerHeight:   uint64(info.MinTriggerBlockHeight),
+			RequiredPercentage: info.RequiredPercentage,
+			Chains:             []*FeatureChainReadiness{},
+		}
+		if height := beaconView.TriggeredFeature[feature]; height != 0 {
+			readiness.Triggered = true
+			readiness.TriggeredHeight = height
+			res = append(res, readiness)
+			continue
+		}
+		readiness.Ready = report.CommitteeStat[feature] != nil
+		for chainID := -1; chainID < beaconView.ActiveShards; chainID++ {
+			chain := newFeatureChainReadiness(chainID, len(chainCommittee[chainID]), report, feature, info.RequiredPercentage)
+			chain.MissingValidators = stat.missingFeatureValidators(feature, chainValidators[chainID])
+			readiness.Ready = readiness.Ready && chain.Ready
+			readiness.Chains = append(readiness.Chains, chain)
+		}
+		if readiness.Ready {
+			readiness.ProjectedTriggerHeight = projectFeatureTriggerHeight(beaconView.BeaconHeight, beaconView.Epoch, readiness.MinTriggerHeight)
+		}
+		res = append(res, readiness)
+	}
+	return res
+}
+
+// newFeatureChainReadiness compare support of a feature in a chain with the thresholds of generateEnableFeatureInstructions,
+// validator threshold is only checked if some validators report the feature
+func newFeatureChainReadiness(chainID int, committeeSize int, report FeatureReportInfo, feature string, requiredPercentage int) *FeatureChainReadiness {
+	percent := SHARD_FEATURE_COMMITTEE_PERCENT
+	if chainID == -1 {
+		percent = BEACON_FEATURE_COMMITTEE_PERCENT
+	}
+	chain := &FeatureChainReadiness{
+		ChainID:             chainID,
+		CommitteeSize:       committeeSize,
+		CommitteeRequired:   uint64(math.Ceil(float64(committeeSize) * float64(percent) / 100)),
+		CommitteeSupporting: report.CommitteeStat[feature][chainID],
+		ValidatorSize:       report.ValidatorSize[chainID],
+		ValidatorRequired:   uint64(math.Ceil(float64(report.ValidatorSize[chainID]*requiredPercentage) / 100)),
+		ValidatorSupporting: report.ValidatorStat[feature][chainID],
+	}
+	chain.Ready = chain.CommitteeSupporting >= chain.CommitteeRequired
+	if report.ValidatorStat[feature] != nil {
+		chain.Ready = chain.Ready && chain.ValidatorSupporting >= chain.ValidatorRequired
+	}
+	return chain
+}
+
+func (stat *FeatureStat) missingFeatureValidators(feature string, validators []string) []string {
+	stat.lock.RLock()
+	defer stat.lock.RUnlock()
+	missing := []string{}
+	for _, v := range validators {
+		if common.IndexOfStr(feature, stat.nodes[v].Features) == -1 {
+			missing = append(missing, v)
+		}
+	}
+	return missing
+}
+
+// projectFeatureTriggerHeight return height of the block including enable feature instruction of a ready feature,
+// the instruction is only generated on top of the first block of an epoch not lower than min trigger height
+func projectFeatureTriggerHeight(beaconHeight, epoch, minTriggerHeight uint64) uint64 {
+	height := GetFirstBeaconHeightInEpoch(epoch)
+	for height < beaconHeight || height < minTriggerHeight {
+		epoch++
+		height = GetFirstBeaconHeightInEpoch(epoch)
+	}
+	return height + 1
+}
+
+// GetFeatureActivationHistory return beacon blocks enabling features of beacon view, sorted by height
+func (blockchain *BlockChain) GetFeatureActivationHistory(beaconView *BeaconBestState) ([]*FeatureActivation, error) {
+	res := []*FeatureActivation{}
+	for feature, height := range beaconView.TriggeredFeature {
+		activation := &FeatureActivation{
+			Feature:      feature,
+			BeaconHeight: height,
+		}
+		if height != 0 && height <= beaconView.BeaconHeight {
+			hash, err := blockchain.GetBeaconBlockHashByView(beaconView, height)
+			if err != nil {
+				return nil, err
+			}
+			block, _, err := blockchain.GetBeaconBlockByHash(*hash)
+			if err != nil {
+				return nil, err
+			}
+			activation.Epoch = block.Header.Epoch
+			activation.Timestamp = block.Header.Timestamp
+		}
+		res = append(res, activation)
+	}
+	sort.Slice(res, func(i, j int) bool {
+		if res[i].BeaconHeight != res[j].BeaconHeight {
+			return res[i].BeaconHeight < res[j].BeaconHeight
+		}
+		return res[i].Feature < res[j].Feature
+	})
+	return res, nil
+}
+
+type featureThresholdTracker struct {
+	ready map[string]bool
+	lock  *sync.Mutex
+}
+
+var defaultFeatureThresholdTracker = &featureThresholdTracker{
+	ready: make(map[string]bool),
+	lock:  new(sync.Mutex),
+}
+
+// publishFeatureThresholdEvents compare readiness of features with previous beacon view,
+// publish an event for each feature crossing its threshold or being triggered
+func (blockchain *BlockChain) publishFeatureThresholdEvents(beaconView *BeaconBestState) {
+	if DefaultFeatureStat == nil || blockchain.config.PubSubManager == nil {
+		return
+	}
+	tracker := defaultFeatureThresholdTracker
+	tracker.lock.Lock()
+	defer tracker.lock.Unlock()
+
+	for _, readiness := range DefaultFeatureStat.Readiness(beaconView) {
+		event := &FeatureThresholdEvent{
+			Feature:      readiness.Feature,
+			BeaconHeight: beaconView.BeaconHeight,
+			Ready:        readiness.Ready,
+			Triggered:    readiness.Triggered,
+			Readiness:    readiness,
+		}
+		if readiness.Triggered {
+			delete(tracker.ready, readiness.Feature)
+			if readiness.TriggeredHeight == beaconView.BeaconHeight {
+				blockchain.config.PubSubManager.PublishMessage(pubsub.NewMessage(pubsub.FeatureThresholdTopic, event))
+			}
+			continue
+		}
+		if tracker.ready[readiness.Feature] != readiness.Ready {
+			tracker.ready[readiness.Feature] = readiness.Ready
+			blockchain.config.PubSubManager.PublishMessage(pubsub.NewMessage(pubsub.FeatureThresholdTopic, event))
+		}
+	}
+}
@@ -0,0 +1,77 @@
+package blockchain
+
+import (
+	"testing"
+
+	"github.com/incognitochain/incognito-chain/config"
+)
+
+func TestNewFeatureChainReadiness(t *testing.T) {
+	const feature = "feature"
+	newReport := func(chainID int, committeeSupporting uint64, validatorSize int, validatorSupporting uint64) FeatureReportInfo {
+		report := FeatureReportInfo{
+			ValidatorStat: map[string]map[int]uint64{},
+			CommitteeStat: map[string]map[int]uint64{feature: {chainID: committeeSupporting}},
+			ValidatorSize: map[int]int{chainID: validatorSize},
+		}
+		if validatorSupporting > 0 {
+			report.ValidatorStat[feature] = map[int]uint64{chainID: validatorSupporting}
+		}
+		return report
+	}
+	tests := []struct {
+		name              string
+		chainID           int
+		committeeSize     int
+		report            FeatureReportInfo
+		committeeRequired uint64
+		validatorRequired uint64
+		ready             bool
+	}{
+		{"beacon below 95%", -1, 20, newReport(-1, 18, 20, 20), 19, 16, false},
+		{"beacon at 95%", -1, 20, newReport(-1, 19, 20, 20), 19, 16, true},
+		{"small beacon committee needs all", -1, 4, newReport(-1, 3, 4, 4), 4, 4, false},
+		{"shard below 89%", 0, 20, newReport(0, 17, 20, 20), 18, 16, false},
+		{"shard at 89%", 0, 20, newReport(0, 18, 20, 20), 18, 16, true},
+		{"shard 89% rounded up", 1, 9, newReport(1, 8, 9, 9), 9, 8, false},
+		{"validators below required percentage", 0, 20, newReport(0, 20, 40, 31), 18, 32, false},
+		{"validators at required percentage", 0, 20, newReport(0, 20, 40, 32), 18, 32, true},
+		{"no validator report", 0, 20, newReport(0, 18, 40, 0), 18, 32, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := newFeatureChainReadiness(tt.chainID, tt.committeeSize, tt.report, feature, 80)
+			if got.CommitteeRequired != tt.committeeRequired || got.ValidatorRequired != tt.validatorRequired || got.Ready != tt.ready {
+				t.Errorf("newFeatureChainReadiness() = %+v, want committee required %v, validator required %v, ready %v",
+					got, tt.committeeRequired, tt.validatorRequired, tt.ready)
+			}
+		})
+	}
+}
+
+func TestProjectFeatureTriggerHeight(t *testing.T) {
+	config.AbortParam()
+	config.Param().EpochParam.NumberOfBlockInEpoch = 10
+	config.Param().EpochParam.EpochV2BreakPoint = 1000
+	tests := []struct {
+		name             string
+		beaconHeight     uint64
+		epoch            uint64
+		minTriggerHeight uint64
+		want             uint64
+	}{
+		{"first block of epoch", 21, 3, 0, 22},
+		{"middle of epoch", 25, 3, 0, 32},
+		{"last block of epoch", 30, 3, 0, 32},
+		{"min trigger height at first block of epoch", 21, 3, 21, 22},
+		{"min trigger height after first block of epoch", 21, 3, 22, 32},
+		{"min trigger height in later epoch", 25, 3, 45, 52},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := projectFeatureTriggerHeight(tt.beaconHeight, tt.epoch, tt.minTriggerHeight); got != tt.want {
+				t.Errorf("projectFeatureTriggerHeight() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
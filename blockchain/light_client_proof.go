@@ -0,0 +1,339 @@
+package blockchain
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+
+	"github.com/incognitochain/incognito-chain/blockchain/committeestate"
+	"github.com/incognitochain/incognito-chain/blockchain/types"
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/config"
+	"github.com/incognitochain/incognito-chain/incognitokey"
+)
+
+// max number of beacon headers returned in one light client request
+const MAX_LIGHT_BEACON_HEADERS = 500
+
+// max number of beacon blocks scanned to find the block confirming a shard block
+const MAX_BEACON_BLOCKS_CONFIRM_SHARD_BLOCK = 100
+
+// BeaconCommitteeProof bind the beacon committee after a beacon block to BeaconCommitteeAndValidatorRoot of its header
+type BeaconCommitteeProof struct {
+	Committee    []string // in signing order of the next beacon block
+	RootPreimage []byte   `json:",omitempty"` // json of committeestate.BeaconCommitteeRootData, only since staking flow v4
+}
+
+// LightBeaconHeader is a beacon header with its validation data,
+// committee proof is set if beacon committee changes after this block
+type LightBeaconHeader struct {
+	Header         types.BeaconHeader
+	ValidationData string
+	CommitteeProof *BeaconCommitteeProof `json:",omitempty"`
+}
+
+// BeaconInstructionProof prove an instruction is in InstructionMerkleRoot of a beacon header
+type BeaconInstructionProof struct {
+	BeaconHeight   uint64
+	BeaconHash     common.Hash
+	Index          int
+	Instruction    []string
+	InstPath       [][]byte
+	InstPathIsLeft []bool
+}
+
+// TxInclusionProof prove a tx is in TxRoot of a shard header and the shard header is confirmed by a beacon header
+type TxInclusionProof struct {
+	TxHash          common.Hash
+	ShardID         byte
+	ShardHeader     types.ShardHeader
+	TxPath          []common.Hash
+	TxPathIsLeft    []bool
+	BeaconHeight    uint64
+	BeaconHash      common.Hash
+	ShardStates     map[byte][]types.ShardState
+	ShardStateHashV int // committee state version used to hash shard states of beacon block
+}
+
+// Verify check committee proof against the header it is attached to, return committee in signing order
+func (proof *BeaconCommitteeProof) Verify(header *types.BeaconHeader) ([]incognitokey.CommitteePublicKey, error) {
+	if len(proof.Committee) == 0 {
+		return nil, errors.New("empty committee")
+	}
+	if len(proof.RootPreimage) == 0 {
+		root, err := common.GenerateHashFromStringArray(proof.Committee)
+		if err != nil {
+			return nil, err
+		}
+		if !root.IsEqual(&header.BeaconCommitteeAndValidatorRoot) {
+			return nil, fmt.Errorf("committee root %v, expect %v", root, header.BeaconCommitteeAndValidatorRoot)
+		}
+	} else {
+		root := common.HashH(proof.RootPreimage)
+		if !root.IsEqual(&header.BeaconCommitteeAndValidatorRoot) {
+			return nil, fmt.Errorf("committee root %v, expect %v", root, header.BeaconCommitteeAndValidatorRoot)
+		}
+		data := committeestate.BeaconCommitteeRootData{}
+		if err := json.Unmarshal(proof.RootPreimage, &data); err != nil {
+			return nil, err
+		}
+		// order of committee is sorted by score, not kept in root, only members are checked
+		members := make(map[string]bool)
+		for _, info := range data.BeaconCommittee {
+			members[info.CPK] = true
+		}
+		if len(members) != len(proof.Committee) {
+			return nil, fmt.Errorf("committee size %v, expect %v", len(proof.Committee), len(members))
+		}
+		for _, k := range proof.Committee {
+			if !members[k] {
+				return nil, fmt.Errorf("%v is not in committee root", k)
+			}
+		}
+	}
+	return incognitokey.CommitteeBase58KeyListToStruct(proof.Committee)
+}
+
+// Verify check instruction proof against the beacon header of proof
+func (proof *BeaconInstructionProof) Verify(header *types.BeaconHeader) error {
+	if header.Height != proof.BeaconHeight || header.Hash() != proof.BeaconHash {
+		return fmt.Errorf("proof of beacon block %v %v, header %v %v", proof.BeaconHeight, proof.BeaconHash, header.Height, header.Hash())
+	}
+	if len(proof.InstPath) != len(proof.InstPathIsLeft) {
+		return errors.New("invalid instruction path")
+	}
+	inst, err := DecodeInstruction(proof.Instruction)
+	if err != nil {
+		return err
+	}
+	node := common.Keccak256(inst)
+	for i, sibling := range proof.InstPath {
+		// nil sibling is the last node of an odd level, hashed with itself
+		if len(sibling) == 0 {
+			sibling = node[:]
+		}
+		if proof.InstPathIsLeft[i] {
+			node = common.Keccak256(append(append([]byte{}, sibling...), node[:]...))
+		} else {
+			node = common.Keccak256(append(append([]byte{}, node[:]...), sibling...))
+		}
+	}
+	if node != header.InstructionMerkleRoot {
+		return fmt.Errorf("instruction root %v, expect %v", node, header.InstructionMerkleRoot)
+	}
+	return nil
+}
+
+// Verify check tx proof against the beacon header of proof
+func (proof *TxInclusionProof) Verify(header *types.BeaconHeader) error {
+	if header.Height != proof.BeaconHeight || header.Hash() != proof.BeaconHash {
+		return fmt.Errorf("proof of beacon block %v %v, header %v %v", proof.BeaconHeight, proof.BeaconHash, header.Height, header.Hash())
+	}
+	if len(proof.TxPath) != len(proof.TxPathIsLeft) {
+		return errors.New("invalid tx path")
+	}
+	node := proof.TxHash
+	for i, sibling := range proof.TxPath {
+		var data [common.HashSize * 2]byte
+		if proof.TxPathIsLeft[i] {
+			copy(data[:common.HashSize], sibling[:])
+			copy(data[common.HashSize:], node[:])
+		} else {
+			copy(data[:common.HashSize], node[:])
+			copy(data[common.HashSize:], sibling[:])
+		}
+		node = common.HashH(data[:])
+	}
+	if node != proof.ShardHeader.TxRoot {
+		return fmt.Errorf("tx root %v, expect %v", node, proof.ShardHeader.TxRoot)
+	}
+	if proof.ShardHeader.ShardID != proof.ShardID {
+		return fmt.Errorf("shard header of shard %v, expect %v", proof.ShardHeader.ShardID, proof.ShardID)
+	}
+	if !verifyHashFromShardState(proof.ShardStates, header.ShardStateHash, proof.ShardStateHashV) {
+		return fmt.Errorf("shard state hash mismatch, expect %v", header.ShardStateHash)
+	}
+	shardHash := proof.ShardHeader.Hash()
+	for _, state := range proof.ShardStates[proof.ShardID] {
+		if state.Hash == shardHash && state.Height == proof.ShardHeader.Height {
+			return nil
+		}
+	}
+	return fmt.Errorf("shard block %v is not confirmed by beacon block %v", shardHash, proof.BeaconHeight)
+}
+
+// GetLightBeaconHeaders return finalized beacon headers from fromHeight, committee proof is attached to the first header if withCommittee,
+// and to every header after which beacon committee changes
+func (blockchain *BlockChain) GetLightBeaconHeaders(fromHeight uint64, count int, withCommittee bool) ([]*LightBeaconHeader, error) {
+	if count > MAX_LIGHT_BEACON_HEADERS {
+		count = MAX_LIGHT_BEACON_HEADERS
+	}
+	finalView, bestView := blockchain.BeaconChain.GetFinalView(), blockchain.BeaconChain.GetBestView()
+	res := []*LightBeaconHeader{}
+	var prevCommittee []string
+	if fromHeight > 1 && !withCommittee {
+		prevHash, err := blockchain.GetBeaconBlockHashByHeight(finalView, bestView, fromHeight-1)
+		if err != nil {
+			return nil, err
+		}
+		committee, err := blockchain.BeaconChain.GetCommitteeByHash(*prevHash, fromHeight-1)
+		if err != nil {
+			return nil, err
+		}
+		if prevCommittee, err = incognitokey.CommitteeKeyListToString(committee); err != nil {
+			return nil, err
+		}
+	}
+	for height := fromHeight; height < fromHeight+uint64(count) && height <= finalView.GetHeight(); height++ {
+		hash, err := blockchain.GetBeaconBlockHashByHeight(finalView, bestView, height)
+		if err != nil {
+			return nil, err
+		}
+		block, _, err := blockchain.GetBeaconBlockByHashWithLatestValidationData(*hash)
+		if err != nil {
+			return nil, err
+		}
+		committee, err := blockchain.BeaconChain.GetCommitteeByHash(*hash, height)
+		if err != nil {
+			return nil, err
+		}
+		committeeStr, err := incognitokey.CommitteeKeyListToString(committee)
+		if err != nil {
+			return nil, err
+		}
+		header := &LightBeaconHeader{
+			Header:         block.Header,
+			ValidationData: block.ValidationData,
+		}
+		if (withCommittee && height == fromHeight) || !common.CompareStringArray(prevCommittee, committeeStr) {
+			header.CommitteeProof, err = blockchain.getBeaconCommitteeProof(block, committeeStr)
+			if err != nil {
+				return nil, err
+			}
+		}
+		prevCommittee = committeeStr
+		res = append(res, header)
+	}
+	return res, nil
+}
+
+func (blockchain *BlockChain) getBeaconCommitteeProof(block *types.BeaconBlock, committee []string) (*BeaconCommitteeProof, error) {
+	proof := &BeaconCommitteeProof{Committee: committee}
+	v4Version := config.Param().FeatureVersion[BEACON_STAKING_FLOW_V4]
+	if v4Version == 0 || block.GetVersion() < int(v4Version) {
+		return proof, nil
+	}
+	var view *BeaconBestState
+	if v := blockchain.BeaconChain.multiView.GetViewByHash(*block.Hash()); v != nil {
+		view = v.(*BeaconBestState)
+	} else {
+		var err error
+		view, err = blockchain.GetBeaconViewStateDataFromBlockHash(*block.Hash(), true, false, false)
+		if err != nil {
+			return nil, err
+		}
+	}
+	state, ok := view.beaconCommitteeState.(*committeestate.BeaconCommitteeStateV4)
+	if !ok {
+		return nil, fmt.Errorf("committee state of beacon block %v is not v4", block.GetHeight())
+	}
+	proof.RootPreimage = state.CommitteeAndPendingRootPreimage()
+	return proof, nil
+}
+
+// GetBeaconInstructionProof return merkle proof of an instruction of canonical beacon block
+func (blockchain *BlockChain) GetBeaconInstructionProof(height uint64, index int) (*BeaconInstructionProof, error) {
+	hash, err := blockchain.GetBeaconBlockHashByHeight(blockchain.BeaconChain.GetFinalView(), blockchain.BeaconChain.GetBestView(), height)
+	if err != nil {
+		return nil, err
+	}
+	block, _, err := blockchain.GetBeaconBlockByHash(*hash)
+	if err != nil {
+		return nil, err
+	}
+	if index < 0 || index >= len(block.Body.Instructions) {
+		return nil, fmt.Errorf("beacon block %v has %v instructions", height, len(block.Body.Instructions))
+	}
+	flattenInsts, err := FlattenAndConvertStringInst(block.Body.Instructions)
+	if err != nil {
+		return nil, err
+	}
+	path, left := types.GetKeccak256MerkleProofFromTree(types.BuildKeccak256MerkleTree(flattenInsts), index)
+	return &BeaconInstructionProof{
+		BeaconHeight:   height,
+		BeaconHash:     *hash,
+		Index:          index,
+		Instruction:    block.Body.Instructions[index],
+		InstPath:       path,
+		InstPathIsLeft: left,
+	}, nil
+}
+
+// GetTxInclusionProof return merkle proof of a tx and the canonical beacon block confirming its shard block
+func (blockchain *BlockChain) GetTxInclusionProof(txHash common.Hash) (*TxInclusionProof, error) {
+	shardID, shardHash, _, index, _, err := blockchain.GetTransactionByHash(txHash)
+	if err != nil {
+		return nil, err
+	}
+	shardBlock, _, err := blockchain.GetShardBlockByHashWithShardID(shardHash, shardID)
+	if err != nil {
+		return nil, err
+	}
+	txPath, txPathIsLeft := getTxMerkleProof(types.Merkle{}.BuildMerkleTreeStore(shardBlock.Body.Transactions), index)
+	proof := &TxInclusionProof{
+		TxHash:       txHash,
+		ShardID:      shardID,
+		ShardHeader:  shardBlock.Header,
+		TxPath:       txPath,
+		TxPathIsLeft: txPathIsLeft,
+	}
+
+	finalView, bestView := blockchain.BeaconChain.GetFinalView(), blockchain.BeaconChain.GetBestView()
+	for height := shardBlock.Header.BeaconHeight + 1; height <= shardBlock.Header.BeaconHeight+MAX_BEACON_BLOCKS_CONFIRM_SHARD_BLOCK && height <= bestView.GetHeight(); height++ {
+		hash, err := blockchain.GetBeaconBlockHashByHeight(finalView, bestView, height)
+		if err != nil {
+			return nil, err
+		}
+		beaconBlock, _, err := blockchain.GetBeaconBlockByHash(*hash)
+		if err != nil {
+			return nil, err
+		}
+		for _, state := range beaconBlock.Body.ShardState[shardID] {
+			if state.Hash != shardHash {
+				continue
+			}
+			proof.BeaconHeight = height
+			proof.BeaconHash = *hash
+			proof.ShardStates = beaconBlock.Body.ShardState
+			proof.ShardStateHashV = committeestate.SELF_SWAP_SHARD_VERSION
+			if !verifyHashFromShardState(proof.ShardStates, beaconBlock.Header.ShardStateHash, proof.ShardStateHashV) {
+				proof.ShardStateHashV = committeestate.STAKING_FLOW_V4
+			}
+			return proof, nil
+		}
+	}
+	return nil, errors.New("shard block " + shardHash.String() + " of shard " + strconv.Itoa(int(shardID)) + " is not confirmed by beacon yet")
+}
+
+// getTxMerkleProof return path of a leaf in tree built by Merkle.BuildMerkleTreeStore,
+// nil sibling of the last node of an odd level is replaced by the node itself
+func getTxMerkleProof(merkles []*common.Hash, id int) ([]common.Hash, []bool) {
+	path := []common.Hash{}
+	left := []bool{}
+	height := uint(math.Log2(float64(len(merkles) + 1)))
+	start := 0
+	for i := height; i > 1; i-- {
+		sibling := id ^ 1
+		if merkles[sibling] == nil {
+			path = append(path, *merkles[id])
+		} else {
+			path = append(path, *merkles[sibling])
+		}
+		left = append(left, sibling < id)
+		id = (id-start)/2 + start + (1 << (i - 1))
+		start += 1 << (i - 1)
+	}
+	return path, left
+}
@@ -57,7 +57,7 @@ func (blockchain *BlockChain) processPortalInstructions(
 	lastPortalV4State := clonedBeaconBestState.portalStateV4
 	lastPortalV3State := clonedBeaconBestState.portalStateV3
 	beaconHeight := block.Header.Height - 1
-	relayingState, err := portalrelaying.InitRelayingHeaderChainStateFromDB(blockchain.GetBNBHeaderChain(), blockchain.GetBTCHeaderChain())
+	relayingState, err := portalrelaying.InitRelayingHeaderChainStateFromDB(blockchain.GetBNBHeaderChain(), blockchain.GetBTCHeaderChain(), blockchain.GetLTCHeaderChain())
 	if err != nil {
 		Logger.log.Error(err)
 		return lastPortalV3State, lastPortalV4State, nil
@@ -642,7 +642,7 @@ func (s *PortalTestSuiteV4) TestShieldingProof() {
 	minShieldAmt := uint64(10000)
 
 	isValid, utxos, err := s.portalParams.PortalTokens[portal.TestnetPortalV4BTCID].ParseAndVerifyShieldProof(proof,
-		bc, expectedReceivedAddress, chainCodeSeed, minShieldAmt)
+		bc, []string{expectedReceivedAddress}, chainCodeSeed, minShieldAmt)
 
 	fmt.Printf("isValid %v\n", isValid)
 	fmt.Printf("err %v\n", err)
@@ -145,6 +145,10 @@ func (blockchain *BlockChain) GetBTCChainParams() *chaincfg.Params {
 	return blockchain.GetBTCHeaderChain().GetChainParams()
 }
 
+func (blockchain *BlockChain) GetLTCHeaderChain() *btcrelaying.BlockChain {
+	return blockchain.GetConfig().LTCChain
+}
+
 func (blockchain *BlockChain) GetPortalFeederAddress(beaconHeight uint64) string {
 	portalParams := blockchain.GetPortalParamsV3(beaconHeight)
 	return portalParams.PortalFeederAddress
@@ -58,6 +58,9 @@ func NewShardChain(
 	cfg := config.Config()
 	ffPath := path.Join(cfg.DataDir, cfg.DatabaseDir, fmt.Sprintf("shard%v", shardID), "blockstorage")
 	bs := NewBlockStorage(blockchain.GetShardChainDatabase(byte(shardID)), ffPath, shardID, false)
+	if cfg.FlatFileRecompress {
+		bs.StartRecompressJob(time.Hour)
+	}
 
 	chain := &ShardChain{
 		shardID:      shardID,
@@ -358,6 +361,10 @@ func (chain *ShardChain) CreateNewBlockFromOldBlock(oldBlock types.BlockInterfac
 // 	return chain.Blockchain.InsertShardBlock(shardBlock, false)
 // }
 
+func (chain *ShardChain) ValidateProducerSig(block types.BlockInterface) error {
+	return chain.Blockchain.config.ConsensusEngine.ValidateProducerSig(block, chain.GetConsensusType())
+}
+
 func (chain *ShardChain) ValidateBlockSignatures(block types.BlockInterface, committees []incognitokey.CommitteePublicKey, numOfFixNode int) error {
 	if err := chain.Blockchain.config.ConsensusEngine.ValidateProducerSig(block, chain.GetConsensusType()); err != nil {
 		return err
@@ -331,14 +331,8 @@ func (blockchain *BlockChain) verifyPreProcessingShardBlock(curView *ShardBestSt
 	}
 
 	// Verify transaction root
-	txMerkleTree := types.Merkle{}.BuildMerkleTreeStore(shardBlock.Body.Transactions)
-	txRoot := &common.Hash{}
-	if len(txMerkleTree) > 0 {
-		txRoot = txMerkleTree[len(txMerkleTree)-1]
-	}
-	if !bytes.Equal(shardBlock.Header.TxRoot.GetBytes(), txRoot.GetBytes()) &&
-		(config.Param().Net == config.LocalNet || config.Param().Net != config.TestnetNet || (shardBlock.Header.Height != 487260 && shardBlock.Header.Height != 487261 && shardBlock.Header.Height != 494144)) {
-		return NewBlockChainError(TransactionRootHashError, fmt.Errorf("Expect transaction root hash %+v but get %+v", shardBlock.Header.TxRoot.String(), txRoot.String()))
+	if err := verifyShardTxRoot(shardBlock); err != nil {
+		return err
 	}
 
 	// Verify ShardTx Root
@@ -0,0 +1,77 @@
+package signaturecounter
+
+// SlashingProjection is the forecast of one committee member's missing signature
+// penalty at the end of the current epoch.
+type SlashingProjection struct {
+	Signed                  uint
+	ExpectedTotal           uint
+	ProjectedSigned         uint
+	ProjectedMissing        uint
+	ProjectedExpectedTotal  uint
+	ProjectedPenalty        Penalty
+	RemainingMissingAllowed uint
+}
+
+// GetSlashingPenalty return penalty of a committee member missing numberOfMissingSig blocks out of total
+func GetSlashingPenalty(numberOfMissingSig uint, total uint, penalties []Penalty) Penalty {
+	return getSlashingPenalty(numberOfMissingSig, total, penalties)
+}
+
+// ProjectSlashingPenalty extrapolates the current signing rate of a committee member
+// to the expected number of blocks at the end of the epoch.
+// expectedTotal is the number of blocks expected so far in this epoch,
+// expectedTotalAtEpochEnd is the estimated number of expected blocks when the epoch ends.
+// A member which has not been asked to sign any block yet is assumed to sign all remaining blocks.
+func ProjectSlashingPenalty(missingSignature MissingSignature, expectedTotal uint, expectedTotalAtEpochEnd uint, penalties []Penalty) SlashingProjection {
+	signed := missingSignature.ActualTotal - missingSignature.Missing
+	if expectedTotalAtEpochEnd < expectedTotal {
+		expectedTotalAtEpochEnd = expectedTotal
+	}
+	if signed > expectedTotalAtEpochEnd {
+		signed = expectedTotalAtEpochEnd
+	}
+	projection := SlashingProjection{
+		Signed:                 signed,
+		ExpectedTotal:          expectedTotal,
+		ProjectedExpectedTotal: expectedTotalAtEpochEnd,
+	}
+
+	remaining := expectedTotalAtEpochEnd - expectedTotal
+	projectedSigned := signed + remaining
+	if missingSignature.ActualTotal != 0 {
+		projectedSigned = signed + remaining*signed/missingSignature.ActualTotal
+	}
+	if projectedSigned > expectedTotalAtEpochEnd {
+		projectedSigned = expectedTotalAtEpochEnd
+	}
+	projection.ProjectedSigned = projectedSigned
+	projection.ProjectedMissing = expectedTotalAtEpochEnd - projectedSigned
+	projection.ProjectedPenalty = getSlashingPenalty(projection.ProjectedMissing, expectedTotalAtEpochEnd, penalties)
+
+	// number of blocks this member can still miss before reaching the lowest penalty level
+	if len(penalties) == 0 || expectedTotalAtEpochEnd == 0 {
+		projection.RemainingMissingAllowed = remaining
+		return projection
+	}
+	minPercent := penalties[0].MinPercent
+	for _, penalty := range penalties {
+		if penalty.MinPercent < minPercent {
+			minPercent = penalty.MinPercent
+		}
+	}
+	maxMissing := uint(0)
+	if threshold := minPercent * expectedTotalAtEpochEnd; threshold > 0 {
+		maxMissing = (threshold+99)/100 - 1
+	}
+	missingSoFar := uint(0)
+	if expectedTotal > signed {
+		missingSoFar = expectedTotal - signed
+	}
+	if maxMissing > missingSoFar {
+		projection.RemainingMissingAllowed = maxMissing - missingSoFar
+		if projection.RemainingMissingAllowed > remaining {
+			projection.RemainingMissingAllowed = remaining
+		}
+	}
+	return projection
+}
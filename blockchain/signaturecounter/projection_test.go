@@ -0,0 +1,96 @@
+package signaturecounter
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestProjectSlashingPenalty(t *testing.T) {
+	type args struct {
+		missingSignature        MissingSignature
+		expectedTotal           uint
+		expectedTotalAtEpochEnd uint
+	}
+	tests := []struct {
+		name string
+		args args
+		want SlashingProjection
+	}{
+		{
+			name: "all signed, half epoch",
+			args: args{
+				missingSignature:        MissingSignature{ActualTotal: 50, Missing: 0},
+				expectedTotal:           50,
+				expectedTotalAtEpochEnd: 100,
+			},
+			want: SlashingProjection{
+				Signed:                  50,
+				ExpectedTotal:           50,
+				ProjectedSigned:         100,
+				ProjectedMissing:        0,
+				ProjectedExpectedTotal:  100,
+				ProjectedPenalty:        NewPenalty(),
+				RemainingMissingAllowed: 49,
+			},
+		},
+		{
+			name: "missing more than half, force unstake",
+			args: args{
+				missingSignature:        MissingSignature{ActualTotal: 50, Missing: 30},
+				expectedTotal:           50,
+				expectedTotalAtEpochEnd: 100,
+			},
+			want: SlashingProjection{
+				Signed:                  20,
+				ExpectedTotal:           50,
+				ProjectedSigned:         40,
+				ProjectedMissing:        60,
+				ProjectedExpectedTotal:  100,
+				ProjectedPenalty:        defaultRule[0],
+				RemainingMissingAllowed: 19,
+			},
+		},
+		{
+			name: "already unavoidable",
+			args: args{
+				missingSignature:        MissingSignature{ActualTotal: 80, Missing: 60},
+				expectedTotal:           80,
+				expectedTotalAtEpochEnd: 100,
+			},
+			want: SlashingProjection{
+				Signed:                  20,
+				ExpectedTotal:           80,
+				ProjectedSigned:         25,
+				ProjectedMissing:        75,
+				ProjectedExpectedTotal:  100,
+				ProjectedPenalty:        defaultRule[0],
+				RemainingMissingAllowed: 0,
+			},
+		},
+		{
+			name: "not asked to sign yet",
+			args: args{
+				missingSignature:        MissingSignature{ActualTotal: 0, Missing: 0},
+				expectedTotal:           10,
+				expectedTotalAtEpochEnd: 100,
+			},
+			want: SlashingProjection{
+				Signed:                  0,
+				ExpectedTotal:           10,
+				ProjectedSigned:         90,
+				ProjectedMissing:        10,
+				ProjectedExpectedTotal:  100,
+				ProjectedPenalty:        NewPenalty(),
+				RemainingMissingAllowed: 39,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ProjectSlashingPenalty(tt.args.missingSignature, tt.args.expectedTotal, tt.args.expectedTotalAtEpochEnd, defaultRule)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ProjectSlashingPenalty() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,416 @@
+package types
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/transaction"
+)
+
+// binary encoded blocks start with blockCodecMagic followed by the codec version,
+// json encoded blocks start with '{' so both can be stored side by side
+const blockCodecMagic byte = 0xBC
+
+const (
+	BlockCodecJSON     byte = 0
+	BlockCodecBinaryV1 byte = 1
+)
+
+// BlockCodec encode and decode a block of a chain, chainID is -1 for beacon chain
+type BlockCodec interface {
+	Encode(blk BlockInterface) ([]byte, error)
+	Decode(data []byte, chainID int) (BlockInterface, error)
+}
+
+var (
+	blockCodecLock    = new(sync.RWMutex)
+	blockCodecs       = map[byte]BlockCodec{BlockCodecBinaryV1: binaryBlockCodecV1{}}
+	defaultBlockCodec = BlockCodecBinaryV1
+)
+
+// RegisterBlockCodec add a codec version for decoding, existing version is replaced
+func RegisterBlockCodec(version byte, codec BlockCodec) {
+	blockCodecLock.Lock()
+	defer blockCodecLock.Unlock()
+	blockCodecs[version] = codec
+}
+
+// SetDefaultBlockCodec set the codec version used by EncodeBlock, BlockCodecJSON for legacy json
+func SetDefaultBlockCodec(version byte) error {
+	blockCodecLock.Lock()
+	defer blockCodecLock.Unlock()
+	if _, ok := blockCodecs[version]; !ok && version != BlockCodecJSON {
+		return fmt.Errorf("block codec version %v is not registered", version)
+	}
+	defaultBlockCodec = version
+	return nil
+}
+
+// EncodeBlock encode block with the default codec
+func EncodeBlock(blk BlockInterface) ([]byte, error) {
+	blockCodecLock.RLock()
+	version := defaultBlockCodec
+	codec := blockCodecs[version]
+	blockCodecLock.RUnlock()
+	return EncodeBlockWithCodec(blk, version, codec)
+}
+
+func EncodeBlockWithCodec(blk BlockInterface, version byte, codec BlockCodec) ([]byte, error) {
+	if version == BlockCodecJSON {
+		return json.Marshal(blk)
+	}
+	data, err := codec.Encode(blk)
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte{blockCodecMagic, version}, data...), nil
+}
+
+// DecodeBlock decode block encoded by any registered codec or legacy json
+func DecodeBlock(data []byte, chainID int) (BlockInterface, error) {
+	if len(data) == 0 {
+		return nil, errors.New("cannot decode block from empty data")
+	}
+	if data[0] != blockCodecMagic {
+		return decodeJSONBlock(data, chainID)
+	}
+	if len(data) < 2 {
+		return nil, errors.New("block codec version is missing")
+	}
+	blockCodecLock.RLock()
+	codec, ok := blockCodecs[data[1]]
+	blockCodecLock.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("unknown block codec version %v", data[1])
+	}
+	return codec.Decode(data[2:], chainID)
+}
+
+// IsBinaryEncodedBlock check if data is encoded by a binary codec
+func IsBinaryEncodedBlock(data []byte) bool {
+	return len(data) > 1 && data[0] == blockCodecMagic
+}
+
+func decodeJSONBlock(data []byte, chainID int) (BlockInterface, error) {
+	if chainID == common.BeaconChainID {
+		beaconBlock := NewBeaconBlock()
+		if err := json.Unmarshal(data, beaconBlock); err != nil {
+			return nil, err
+		}
+		return beaconBlock, nil
+	}
+	shardBlock := NewShardBlock()
+	if err := json.Unmarshal(data, shardBlock); err != nil {
+		return nil, err
+	}
+	return shardBlock, nil
+}
+
+// binaryBlockCodecV1 encode header fields and instructions in binary, txs with transaction.EncodeTxBinary
+// and cross transactions in json
+type binaryBlockCodecV1 struct{}
+
+func (binaryBlockCodecV1) Encode(blk BlockInterface) ([]byte, error) {
+	w := common.NewBinaryWriter()
+	switch b := blk.(type) {
+	case *ShardBlock:
+		w.WriteString(b.ValidationData)
+		writeShardHeader(w, &b.Header)
+		if err := writeShardBody(w, &b.Body); err != nil {
+			return nil, err
+		}
+	case *BeaconBlock:
+		w.WriteString(b.ValidationData)
+		writeBeaconHeader(w, &b.Header)
+		writeBeaconBody(w, &b.Body)
+	default:
+		return nil, fmt.Errorf("cannot encode block type %T", blk)
+	}
+	return w.Bytes(), nil
+}
+
+func (binaryBlockCodecV1) Decode(data []byte, chainID int) (BlockInterface, error) {
+	r := common.NewBinaryReader(data)
+	if chainID == common.BeaconChainID {
+		beaconBlock := NewBeaconBlock()
+		beaconBlock.ValidationData = r.ReadString()
+		readBeaconHeader(r, &beaconBlock.Header)
+		readBeaconBody(r, &beaconBlock.Body)
+		if err := checkReaderDone(r); err != nil {
+			return nil, err
+		}
+		return beaconBlock, nil
+	}
+	shardBlock := NewShardBlock()
+	shardBlock.ValidationData = r.ReadString()
+	readShardHeader(r, &shardBlock.Header)
+	if err := readShardBody(r, &shardBlock.Body); err != nil {
+		return nil, err
+	}
+	if err := checkReaderDone(r); err != nil {
+		return nil, err
+	}
+	if err := shardBlock.initAfterDecode(); err != nil {
+		return nil, err
+	}
+	return shardBlock, nil
+}
+
+func checkReaderDone(r *common.BinaryReader) error {
+	if r.Err() != nil {
+		return r.Err()
+	}
+	if r.Remaining() != 0 {
+		return fmt.Errorf("%v unexpected bytes after block", r.Remaining())
+	}
+	return nil
+}
+
+func writeInstructions(w *common.BinaryWriter, insts [][]string) {
+	w.WriteUvarint(uint64(len(insts)))
+	for _, inst := range insts {
+		w.WriteStrings(inst)
+	}
+}
+
+func readInstructions(r *common.BinaryReader) [][]string {
+	l := r.ReadLength()
+	insts := [][]string{}
+	for i := 0; i < l && r.Err() == nil; i++ {
+		insts = append(insts, r.ReadStrings())
+	}
+	return insts
+}
+
+func writeShardHeader(w *common.BinaryWriter, h *ShardHeader) {
+	w.WriteString(h.Producer)
+	w.WriteString(h.ProducerPubKeyStr)
+	w.WriteUint8(h.ShardID)
+	w.WriteVarint(int64(h.Version))
+	w.WriteHash(h.PreviousBlockHash)
+	w.WriteUvarint(h.Height)
+	w.WriteVarint(int64(h.Round))
+	w.WriteUvarint(h.Epoch)
+	w.WriteBytes(h.CrossShardBitMap)
+	w.WriteUvarint(h.BeaconHeight)
+	w.WriteHash(h.BeaconHash)
+	tokenIDs := make([]common.Hash, 0, len(h.TotalTxsFee))
+	for tokenID := range h.TotalTxsFee {
+		tokenIDs = append(tokenIDs, tokenID)
+	}
+	sort.Slice(tokenIDs, func(i, j int) bool {
+		return bytes.Compare(tokenIDs[i][:], tokenIDs[j][:]) < 0
+	})
+	w.WriteUvarint(uint64(len(tokenIDs)))
+	for _, tokenID := range tokenIDs {
+		w.WriteHash(tokenID)
+		w.WriteUvarint(h.TotalTxsFee[tokenID])
+	}
+	w.WriteString(h.ConsensusType)
+	w.WriteVarint(h.Timestamp)
+	w.WriteHash(h.TxRoot)
+	w.WriteHash(h.ShardTxRoot)
+	w.WriteHash(h.CrossTransactionRoot)
+	w.WriteHash(h.InstructionsRoot)
+	w.WriteHash(h.CommitteeRoot)
+	w.WriteHash(h.PendingValidatorRoot)
+	w.WriteHash(h.StakingTxRoot)
+	w.WriteHash(h.InstructionMerkleRoot)
+	w.WriteString(h.Proposer)
+	w.WriteVarint(h.ProposeTime)
+	w.WriteHash(h.CommitteeFromBlock)
+	w.WriteUvarint(h.FinalityHeight)
+}
+
+func readShardHeader(r *common.BinaryReader, h *ShardHeader) {
+	h.Producer = r.ReadString()
+	h.ProducerPubKeyStr = r.ReadString()
+	h.ShardID = r.ReadUint8()
+	h.Version = int(r.ReadVarint())
+	h.PreviousBlockHash = r.ReadHash()
+	h.Height = r.ReadUvarint()
+	h.Round = int(r.ReadVarint())
+	h.Epoch = r.ReadUvarint()
+	h.CrossShardBitMap = r.ReadBytes()
+	h.BeaconHeight = r.ReadUvarint()
+	h.BeaconHash = r.ReadHash()
+	l := r.ReadLength()
+	h.TotalTxsFee = make(map[common.Hash]uint64)
+	for i := 0; i < l && r.Err() == nil; i++ {
+		tokenID := r.ReadHash()
+		h.TotalTxsFee[tokenID] = r.ReadUvarint()
+	}
+	h.ConsensusType = r.ReadString()
+	h.Timestamp = r.ReadVarint()
+	h.TxRoot = r.ReadHash()
+	h.ShardTxRoot = r.ReadHash()
+	h.CrossTransactionRoot = r.ReadHash()
+	h.InstructionsRoot = r.ReadHash()
+	h.CommitteeRoot = r.ReadHash()
+	h.PendingValidatorRoot = r.ReadHash()
+	h.StakingTxRoot = r.ReadHash()
+	h.InstructionMerkleRoot = r.ReadHash()
+	h.Proposer = r.ReadString()
+	h.ProposeTime = r.ReadVarint()
+	h.CommitteeFromBlock = r.ReadHash()
+	h.FinalityHeight = r.ReadUvarint()
+}
+
+func writeShardBody(w *common.BinaryWriter, body *ShardBody) error {
+	writeInstructions(w, body.Instructions)
+	if len(body.CrossTransactions) == 0 {
+		w.WriteBytes(nil)
+	} else {
+		crossTxs, err := json.Marshal(body.CrossTransactions)
+		if err != nil {
+			return err
+		}
+		w.WriteBytes(crossTxs)
+	}
+	w.WriteUvarint(uint64(len(body.Transactions)))
+	for _, tx := range body.Transactions {
+		txBytes, err := transaction.EncodeTxBinary(tx)
+		if err != nil {
+			return err
+		}
+		w.WriteBytes(txBytes)
+	}
+	return nil
+}
+
+func readShardBody(r *common.BinaryReader, body *ShardBody) error {
+	body.Instructions = readInstructions(r)
+	crossTxs := r.ReadBytes()
+	if len(crossTxs) > 0 {
+		if err := json.Unmarshal(crossTxs, &body.CrossTransactions); err != nil {
+			return err
+		}
+	}
+	l := r.ReadLength()
+	for i := 0; i < l && r.Err() == nil; i++ {
+		txBytes := r.ReadBytes()
+		if r.Err() != nil {
+			break
+		}
+		tx, err := transaction.DecodeTxBinary(txBytes)
+		if err != nil {
+			return err
+		}
+		body.Transactions = append(body.Transactions, tx)
+	}
+	return r.Err()
+}
+
+func writeBeaconHeader(w *common.BinaryWriter, h *BeaconHeader) {
+	w.WriteVarint(int64(h.Version))
+	w.WriteUvarint(h.Height)
+	w.WriteUvarint(h.Epoch)
+	w.WriteVarint(int64(h.Round))
+	w.WriteVarint(h.Timestamp)
+	w.WriteHash(h.PreviousBlockHash)
+	w.WriteHash(h.InstructionHash)
+	w.WriteHash(h.ShardStateHash)
+	w.WriteHash(h.InstructionMerkleRoot)
+	w.WriteHash(h.BeaconCommitteeAndValidatorRoot)
+	w.WriteHash(h.BeaconCandidateRoot)
+	w.WriteHash(h.ShardCandidateRoot)
+	w.WriteHash(h.ShardCommitteeAndValidatorRoot)
+	w.WriteHash(h.AutoStakingRoot)
+	w.WriteHash(h.ShardSyncValidatorRoot)
+	w.WriteString(h.ConsensusType)
+	w.WriteString(h.Producer)
+	w.WriteString(h.ProducerPubKeyStr)
+	w.WriteString(h.Proposer)
+	w.WriteVarint(h.ProposeTime)
+	w.WriteUvarint(h.FinalityHeight)
+	if h.ProcessBridgeFromBlock == nil {
+		w.WriteBool(false)
+	} else {
+		w.WriteBool(true)
+		w.WriteUvarint(*h.ProcessBridgeFromBlock)
+	}
+	w.WriteString(h.PreviousValidationData)
+}
+
+func readBeaconHeader(r *common.BinaryReader, h *BeaconHeader) {
+	h.Version = int(r.ReadVarint())
+	h.Height = r.ReadUvarint()
+	h.Epoch = r.ReadUvarint()
+	h.Round = int(r.ReadVarint())
+	h.Timestamp = r.ReadVarint()
+	h.PreviousBlockHash = r.ReadHash()
+	h.InstructionHash = r.ReadHash()
+	h.ShardStateHash = r.ReadHash()
+	h.InstructionMerkleRoot = r.ReadHash()
+	h.BeaconCommitteeAndValidatorRoot = r.ReadHash()
+	h.BeaconCandidateRoot = r.ReadHash()
+	h.ShardCandidateRoot = r.ReadHash()
+	h.ShardCommitteeAndValidatorRoot = r.ReadHash()
+	h.AutoStakingRoot = r.ReadHash()
+	h.ShardSyncValidatorRoot = r.ReadHash()
+	h.ConsensusType = r.ReadString()
+	h.Producer = r.ReadString()
+	h.ProducerPubKeyStr = r.ReadString()
+	h.Proposer = r.ReadString()
+	h.ProposeTime = r.ReadVarint()
+	h.FinalityHeight = r.ReadUvarint()
+	if r.ReadBool() {
+		processBridgeFromBlock := r.ReadUvarint()
+		h.ProcessBridgeFromBlock = &processBridgeFromBlock
+	}
+	h.PreviousValidationData = r.ReadString()
+}
+
+func writeBeaconBody(w *common.BinaryWriter, body *BeaconBody) {
+	shardIDs := make([]int, 0, len(body.ShardState))
+	for shardID := range body.ShardState {
+		shardIDs = append(shardIDs, int(shardID))
+	}
+	sort.Ints(shardIDs)
+	w.WriteUvarint(uint64(len(shardIDs)))
+	for _, shardID := range shardIDs {
+		states := body.ShardState[byte(shardID)]
+		w.WriteUint8(byte(shardID))
+		w.WriteUvarint(uint64(len(states)))
+		for _, state := range states {
+			w.WriteString(state.ValidationData)
+			w.WriteString(state.PreviousValidationData)
+			w.WriteHash(state.CommitteeFromBlock)
+			w.WriteUvarint(state.Height)
+			w.WriteHash(state.Hash)
+			w.WriteBytes(state.CrossShard)
+			w.WriteVarint(state.ProposerTime)
+			w.WriteVarint(int64(state.Version))
+		}
+	}
+	writeInstructions(w, body.Instructions)
+}
+
+func readBeaconBody(r *common.BinaryReader, body *BeaconBody) {
+	l := r.ReadLength()
+	body.ShardState = make(map[byte][]ShardState)
+	for i := 0; i < l && r.Err() == nil; i++ {
+		shardID := r.ReadUint8()
+		n := r.ReadLength()
+		states := []ShardState{}
+		for j := 0; j < n && r.Err() == nil; j++ {
+			state := ShardState{}
+			state.ValidationData = r.ReadString()
+			state.PreviousValidationData = r.ReadString()
+			state.CommitteeFromBlock = r.ReadHash()
+			state.Height = r.ReadUvarint()
+			state.Hash = r.ReadHash()
+			state.CrossShard = r.ReadBytes()
+			state.ProposerTime = r.ReadVarint()
+			state.Version = int(r.ReadVarint())
+			states = append(states, state)
+		}
+		body.ShardState[shardID] = states
+	}
+	body.Instructions = readInstructions(r)
+}
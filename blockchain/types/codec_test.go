@@ -0,0 +1,206 @@
+package types
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"testing"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/config"
+	"github.com/incognitochain/incognito-chain/metadata"
+	"github.com/incognitochain/incognito-chain/transaction"
+)
+
+func loadTestTxs(t testing.TB) []metadata.Transaction {
+	config.AbortParam()
+	common.MaxShardNumber = 8
+	data, err := ioutil.ReadFile("testdata/tx_ver1.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	txChoice, err := transaction.DeserializeTransactionJSON(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// tx ver 2 with metadata and without proof
+	reqID := common.HashH([]byte("request"))
+	txVer2 := &transaction.TxVersion2{}
+	txVer2.Version = 2
+	txVer2.Type = common.TxRewardType
+	txVer2.LockTime = 1600000000
+	txVer2.Info = []byte("info")
+	txVer2.SigPubKey = []byte{1, 2, 3}
+	txVer2.Sig = []byte{4, 5, 6}
+	txVer2.Metadata = &metadata.WithDrawRewardResponse{
+		MetadataBase:    metadata.MetadataBase{Type: metadata.WithDrawRewardResponseMeta},
+		TxRequest:       &reqID,
+		TokenID:         common.PRVCoinID,
+		RewardPublicKey: []byte{7, 8, 9},
+		Version:         1,
+	}
+	return []metadata.Transaction{txChoice.ToTx(), txVer2}
+}
+
+func newTestShardBlock(t testing.TB, numTxs int) *ShardBlock {
+	txs := loadTestTxs(t)
+	blk := NewShardBlock()
+	blk.ValidationData = `{"ProducerBLSSig":"abc","ValidatiorsIdx":[0,1,2]}`
+	blk.Header = ShardHeader{
+		Producer:              "producer",
+		ProducerPubKeyStr:     "producerkey",
+		ShardID:               1,
+		Version:               ADJUST_BLOCKTIME_VERSION,
+		PreviousBlockHash:     common.HashH([]byte("prev")),
+		Height:                100,
+		Round:                 2,
+		Epoch:                 3,
+		CrossShardBitMap:      []byte{0, 2},
+		BeaconHeight:          200,
+		BeaconHash:            common.HashH([]byte("beacon")),
+		TotalTxsFee:           map[common.Hash]uint64{common.PRVCoinID: 100, common.HashH([]byte("token")): 5},
+		ConsensusType:         common.BlsConsensus,
+		Timestamp:             1600000000,
+		TxRoot:                common.HashH([]byte("txroot")),
+		CommitteeRoot:         common.HashH([]byte("committee")),
+		InstructionMerkleRoot: common.HashH([]byte("inst")),
+		Proposer:              "proposer",
+		ProposeTime:           1600000001,
+		FinalityHeight:        99,
+	}
+	blk.Body.Instructions = [][]string{{"1", "a"}, {"2", "b", "c"}}
+	for i := 0; i < numTxs; i++ {
+		blk.Body.Transactions = append(blk.Body.Transactions, txs[i%len(txs)])
+	}
+	return blk
+}
+
+func TestBlockCodecShardBlock(t *testing.T) {
+	blk := newTestShardBlock(t, 2)
+	data, err := EncodeBlock(blk)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !IsBinaryEncodedBlock(data) {
+		t.Fatal("EncodeBlock() should use binary codec by default")
+	}
+	decoded, err := DecodeBlock(data, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	shardBlock := decoded.(*ShardBlock)
+	if shardBlock.Hash().String() != blk.Hash().String() || shardBlock.Body.Hash() != blk.Body.Hash() {
+		t.Errorf("decoded block hash = %v, want %v", shardBlock.Hash().String(), blk.Hash().String())
+	}
+	for i, tx := range shardBlock.Body.Transactions {
+		if tx.Hash().String() != blk.Body.Transactions[i].Hash().String() {
+			t.Errorf("decoded tx %v hash = %v, want %v", i, tx.Hash().String(), blk.Body.Transactions[i].Hash().String())
+		}
+	}
+	// decoded block must be identical to the block in json
+	expected, _ := json.Marshal(blk)
+	actual, _ := json.Marshal(shardBlock)
+	if !bytes.Equal(expected, actual) {
+		t.Errorf("decoded block json = %s, want %s", actual, expected)
+	}
+
+	// legacy json block
+	decoded, err = DecodeBlock(expected, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if decoded.GetHeight() != blk.GetHeight() || decoded.(*ShardBlock).Body.Hash() != blk.Body.Hash() {
+		t.Errorf("decoded json block = %+v", decoded)
+	}
+
+	if _, err := DecodeBlock(data[:len(data)-1], 1); err == nil {
+		t.Error("DecodeBlock() of truncated data should fail")
+	}
+	if _, err := DecodeBlock(append([]byte{blockCodecMagic, 99}, data[2:]...), 1); err == nil {
+		t.Error("DecodeBlock() with unknown codec version should fail")
+	}
+}
+
+func TestBlockCodecBeaconBlock(t *testing.T) {
+	config.AbortParam()
+	processBridgeFromBlock := uint64(10)
+	blk := NewBeaconBlock()
+	blk.ValidationData = "validation"
+	blk.Header = BeaconHeader{
+		Version:                ADJUST_BLOCKTIME_VERSION,
+		Height:                 50,
+		Epoch:                  1,
+		Timestamp:              1600000000,
+		PreviousBlockHash:      common.HashH([]byte("prev")),
+		InstructionMerkleRoot:  common.HashH([]byte("inst")),
+		ConsensusType:          common.BlsConsensus,
+		Producer:               "producer",
+		Proposer:               "proposer",
+		ProposeTime:            1600000001,
+		ProcessBridgeFromBlock: &processBridgeFromBlock,
+		PreviousValidationData: "prevvaldata",
+	}
+	blk.Body = BeaconBody{
+		ShardState: map[byte][]ShardState{
+			1: {NewShardState("val1", "", common.Hash{}, 3, common.HashH([]byte("s1")), []byte{0}, 1600000000, 8)},
+			0: {NewShardState("val0", "prev0", common.Hash{}, 2, common.HashH([]byte("s0")), []byte{}, 1600000000, 8)},
+		},
+		Instructions: [][]string{{"stake", "key"}, {}},
+	}
+	data, err := EncodeBlock(blk)
+	if err != nil {
+		t.Fatal(err)
+	}
+	decoded, err := DecodeBlock(data, common.BeaconChainID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	beaconBlock := decoded.(*BeaconBlock)
+	if beaconBlock.Hash().String() != blk.Hash().String() {
+		t.Errorf("decoded block hash = %v, want %v", beaconBlock.Hash().String(), blk.Hash().String())
+	}
+	if *beaconBlock.Header.ProcessBridgeFromBlock != processBridgeFromBlock || beaconBlock.Header.PreviousValidationData != "prevvaldata" {
+		t.Errorf("decoded header = %+v", beaconBlock.Header)
+	}
+	if len(beaconBlock.Body.ShardState[0]) != 1 || beaconBlock.Body.ShardState[0][0].PreviousValidationData != "prev0" || beaconBlock.Body.ShardState[1][0].Height != 3 {
+		t.Errorf("decoded shard states = %+v", beaconBlock.Body.ShardState)
+	}
+	if len(beaconBlock.Body.Instructions) != 2 || beaconBlock.Body.Instructions[0][1] != "key" {
+		t.Errorf("decoded instructions = %v", beaconBlock.Body.Instructions)
+	}
+}
+
+func TestBlockCodecSize(t *testing.T) {
+	blk := newTestShardBlock(t, 20)
+	jsonData, _ := json.Marshal(blk)
+	binaryData, err := EncodeBlock(blk)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Logf("block with 20 txs: json %v bytes, binary %v bytes", len(jsonData), len(binaryData))
+	if len(binaryData) >= len(jsonData) {
+		t.Errorf("binary encoding (%v bytes) should be smaller than json (%v bytes)", len(binaryData), len(jsonData))
+	}
+}
+
+func BenchmarkDecodeShardBlockJSON(b *testing.B) {
+	data, _ := json.Marshal(newTestShardBlock(b, 20))
+	b.SetBytes(int64(len(data)))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := DecodeBlock(data, 1); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkDecodeShardBlockBinary(b *testing.B) {
+	data, _ := EncodeBlock(newTestShardBlock(b, 20))
+	b.SetBytes(int64(len(data)))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := DecodeBlock(data, 1); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
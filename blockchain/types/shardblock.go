@@ -279,6 +279,11 @@ func (shardBlock *ShardBlock) UnmarshalJSON(data []byte) error {
 	}
 	shardBlock.Header = tempShardBlock.Header
 	shardBlock.Body = blkBody
+	return shardBlock.initAfterDecode()
+}
+
+// initAfterDecode set validation env of txs and default values, then check sanity of decoded block
+func (shardBlock *ShardBlock) initAfterDecode() error {
 	if shardBlock.Body.Transactions == nil {
 		shardBlock.Body.Transactions = []metadata.Transaction{}
 	}
@@ -0,0 +1,236 @@
+package blockchain
+
+import (
+	"errors"
+
+	"github.com/incognitochain/incognito-chain/blockchain/committeestate"
+	"github.com/incognitochain/incognito-chain/blockchain/signaturecounter"
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/dataaccessobject/statedb"
+	"github.com/incognitochain/incognito-chain/dataaccessobject/stats"
+	"github.com/incognitochain/incognito-chain/incognitokey"
+)
+
+const (
+	ValidatorSlashEvent   = "slash"
+	ValidatorSwapOutEvent = "swapout"
+)
+
+type ValidatorEvent struct {
+	Epoch   uint64
+	ChainID int
+	Type    string
+}
+
+// ValidatorPerformance is the signing performance of a committee member in current epoch and previous epochs
+type ValidatorPerformance struct {
+	CommitteePublicKey string
+	Role               string
+	ChainID            int
+	Epoch              uint64
+	BeaconHeight       uint64
+	ActualTotal        uint
+	Signed             uint
+	Missing            uint
+	ExpectedTotal      uint
+	CurrentPenalty     signaturecounter.Penalty
+	Projection         signaturecounter.SlashingProjection
+	BeaconPerformance  uint64 `json:"BeaconPerformance,omitempty"`
+	History            []*stats.ValidatorEpochPerformance
+	Events             []ValidatorEvent
+}
+
+// getValidatorRole return role and chain of a committee public key in this view
+func (beaconBestState *BeaconBestState) getValidatorRole(cpk string) (string, int) {
+	contains := func(list []incognitokey.CommitteePublicKey) bool {
+		for _, v := range list {
+			if temp, _ := v.ToBase58(); temp == cpk {
+				return true
+			}
+		}
+		return false
+	}
+	if contains(beaconBestState.GetBeaconCommittee()) {
+		return common.CommitteeRole, common.BeaconChainID
+	}
+	if contains(beaconBestState.GetBeaconPendingValidator()) {
+		return common.PendingRole, common.BeaconChainID
+	}
+	for shardID, committees := range beaconBestState.GetShardCommittee() {
+		if contains(committees) {
+			return common.CommitteeRole, int(shardID)
+		}
+	}
+	if beaconBestState.CommitteeStateVersion() >= committeestate.STAKING_FLOW_V3 {
+		for shardID, validators := range beaconBestState.GetSyncingValidators() {
+			if contains(validators) {
+				return common.SyncingRole, int(shardID)
+			}
+		}
+	}
+	for shardID, validators := range beaconBestState.GetShardPendingValidator() {
+		if contains(validators) {
+			return common.PendingRole, int(shardID)
+		}
+	}
+	if contains(beaconBestState.GetShardCandidate()) {
+		return common.WaitingRole, -2
+	}
+	return "", -2
+}
+
+func (beaconBestState *BeaconBestState) getBeaconPerformance(cpk string) (uint64, bool) {
+	if beaconBestState.CommitteeStateVersion() != committeestate.STAKING_FLOW_V4 {
+		return 0, false
+	}
+	state, ok := beaconBestState.beaconCommitteeState.(*committeestate.BeaconCommitteeStateV4)
+	if !ok {
+		return 0, false
+	}
+	return state.GetBeaconPerformance(cpk)
+}
+
+// storeValidatorEpochPerformance store signing result of all committee members in the epoch that has just finished.
+// curView is the last view of finished epoch, newView is the first view of new epoch
+func (blockchain *BlockChain) storeValidatorEpochPerformance(curView, newView *BeaconBestState, epoch uint64, committeeChange *committeestate.CommitteeChange) error {
+	if curView.missingSignatureCounter == nil {
+		return nil
+	}
+	performances := make(map[string]*stats.ValidatorEpochPerformance)
+	missingSignatures := curView.missingSignatureCounter.MissingSignature()
+	expectedTotalBlocks := curView.GetExpectedTotalBlock(curView.BestBlock.GetVersion())
+	for shardID, committees := range curView.GetShardCommittee() {
+		for _, committee := range committees {
+			cpk, _ := committee.ToBase58()
+			missingSignature := missingSignatures[cpk]
+			performances[cpk] = &stats.ValidatorEpochPerformance{
+				Epoch:         epoch,
+				ChainID:       int(shardID),
+				ActualTotal:   missingSignature.ActualTotal,
+				Signed:        missingSignature.ActualTotal - missingSignature.Missing,
+				Missing:       missingSignature.Missing,
+				ExpectedTotal: expectedTotalBlocks[cpk],
+			}
+		}
+	}
+	for _, committee := range curView.GetBeaconCommittee() {
+		cpk, _ := committee.ToBase58()
+		performance := &stats.ValidatorEpochPerformance{
+			Epoch:   epoch,
+			ChainID: common.BeaconChainID,
+		}
+		performance.BeaconPerformance, _ = newView.getBeaconPerformance(cpk)
+		performances[cpk] = performance
+	}
+
+	for _, slashingCommittees := range committeeChange.SlashingCommittee {
+		for _, cpk := range slashingCommittees {
+			if performance, ok := performances[cpk]; ok {
+				performance.Slashed = true
+			}
+		}
+	}
+	swapOut := append([]incognitokey.CommitteePublicKey{}, committeeChange.BeaconCommitteeRemoved...)
+	for _, removed := range committeeChange.ShardCommitteeRemoved {
+		swapOut = append(swapOut, removed...)
+	}
+	for _, replaced := range committeeChange.ShardCommitteeReplaced {
+		swapOut = append(swapOut, replaced[common.REPLACE_OUT]...)
+	}
+	swapOut = append(swapOut, committeeChange.BeaconCommitteeReplaced[common.REPLACE_OUT]...)
+	for _, committee := range swapOut {
+		cpk, _ := committee.ToBase58()
+		if performance, ok := performances[cpk]; ok {
+			performance.SwappedOut = true
+		}
+	}
+
+	return stats.StoreValidatorEpochPerformance(blockchain.GetBeaconChainDatabase(), performances)
+}
+
+// GetValidatorPerformance return current epoch signing statistic, projected penalty at the end of epoch
+// and performance history of last numberOfEpoch epochs of a committee public key
+func (blockchain *BlockChain) GetValidatorPerformance(beaconBestState *BeaconBestState, cpk string, numberOfEpoch uint64) (*ValidatorPerformance, error) {
+	if _, err := incognitokey.CommitteeBase58KeyListToStruct([]string{cpk}); err != nil {
+		return nil, err
+	}
+	if beaconBestState == nil {
+		return nil, errors.New("beacon best state is not ready")
+	}
+	res := &ValidatorPerformance{
+		CommitteePublicKey: cpk,
+		Epoch:              beaconBestState.Epoch,
+		BeaconHeight:       beaconBestState.BeaconHeight,
+		History:            []*stats.ValidatorEpochPerformance{},
+		Events:             []ValidatorEvent{},
+	}
+	res.Role, res.ChainID = beaconBestState.getValidatorRole(cpk)
+	res.BeaconPerformance, _ = beaconBestState.getBeaconPerformance(cpk)
+
+	if res.Role == common.CommitteeRole && res.ChainID != common.BeaconChainID && beaconBestState.missingSignatureCounter != nil {
+		missingSignature := beaconBestState.GetNumberOfMissingSignature()[cpk]
+		res.ActualTotal = missingSignature.ActualTotal
+		res.Signed = missingSignature.ActualTotal - missingSignature.Missing
+		res.Missing = missingSignature.Missing
+		res.ExpectedTotal = beaconBestState.GetExpectedTotalBlock(beaconBestState.BestBlock.GetVersion())[cpk]
+
+		penalties := beaconBestState.missingSignatureCounter.Penalties()
+		if res.ExpectedTotal > res.Signed {
+			res.CurrentPenalty = signaturecounter.GetSlashingPenalty(res.ExpectedTotal-res.Signed, res.ExpectedTotal, penalties)
+		}
+		res.Projection = signaturecounter.ProjectSlashingPenalty(
+			missingSignature,
+			res.ExpectedTotal,
+			blockchain.estimateExpectedTotalBlockAtEpochEnd(beaconBestState, res.ExpectedTotal),
+			penalties,
+		)
+	}
+
+	fromEpoch := uint64(1)
+	if beaconBestState.Epoch > numberOfEpoch {
+		fromEpoch = beaconBestState.Epoch - numberOfEpoch
+	}
+	history, err := stats.GetValidatorEpochPerformance(blockchain.GetBeaconChainDatabase(), cpk, fromEpoch)
+	if err != nil {
+		return nil, err
+	}
+	res.History = history
+	slashedEpochs := make(map[uint64]struct{})
+	for _, performance := range history {
+		if performance.Slashed {
+			slashedEpochs[performance.Epoch] = struct{}{}
+			res.Events = append(res.Events, ValidatorEvent{Epoch: performance.Epoch, ChainID: performance.ChainID, Type: ValidatorSlashEvent})
+		}
+		if performance.SwappedOut {
+			res.Events = append(res.Events, ValidatorEvent{Epoch: performance.Epoch, ChainID: performance.ChainID, Type: ValidatorSwapOutEvent})
+		}
+	}
+	// slashing committee is kept in beacon state, so slash events from before the history was recorded are still found
+	for epoch := fromEpoch; epoch < beaconBestState.Epoch; epoch++ {
+		if _, ok := slashedEpochs[epoch]; ok {
+			continue
+		}
+		for shardID, slashingCommittees := range statedb.GetSlashingCommittee(beaconBestState.GetBeaconSlashStateDB(), epoch) {
+			if common.IndexOfStr(cpk, slashingCommittees) != -1 {
+				res.Events = append(res.Events, ValidatorEvent{Epoch: epoch, ChainID: int(shardID), Type: ValidatorSlashEvent})
+			}
+		}
+	}
+
+	return res, nil
+}
+
+// estimateExpectedTotalBlockAtEpochEnd scale number of expected blocks so far to the whole epoch
+func (blockchain *BlockChain) estimateExpectedTotalBlockAtEpochEnd(beaconBestState *BeaconBestState, expectedTotal uint) uint {
+	epoch := beaconBestState.Epoch
+	lastHeight := blockchain.GetLastBeaconHeightInEpoch(epoch)
+	firstHeight := uint64(1)
+	if epoch > 1 {
+		firstHeight = blockchain.GetLastBeaconHeightInEpoch(epoch-1) + 1
+	}
+	if beaconBestState.BeaconHeight < firstHeight || lastHeight < beaconBestState.BeaconHeight {
+		return expectedTotal
+	}
+	elapsed := beaconBestState.BeaconHeight - firstHeight + 1
+	return uint(uint64(expectedTotal) * (lastHeight - firstHeight + 1) / elapsed)
+}
@@ -40,14 +40,19 @@ type params struct {
 	OutDataDir   string `long:"outdatadir" description:"Directory of Export Blockchain Data"`
 	FileName     string `long:"filename" description:"Filename of Backup Blockchin Data"`
 	// wallet
-	WalletName        string `long:"wallet" description:"Wallet Database Name file, default is 'wallet'"`
-	WalletPassphrase  string `long:"walletpassphrase" description:"Wallet passphrase"`
-	WalletAccountName string `long:"walletaccountname" description:"Wallet account name"`
-	ShardID           int8   `long:"shardid" description:"Process Shard Chain with ShardID"`
+	WalletName          string `long:"wallet" description:"Wallet Database Name file, default is 'wallet'"`
+	WalletPassphrase    string `long:"walletpassphrase" description:"Wallet passphrase"`
+	WalletAccountName   string `long:"walletaccountname" description:"Wallet account name"`
+	NewWalletPassphrase string `long:"newwalletpassphrase" description:"New wallet passphrase, the wallet is saved as a keystore"`
+	ShardID             int8   `long:"shardid" description:"Process Shard Chain with ShardID"`
 
 	// pToken
 	PNetwork string `long:"pNetwork" description:"Bridge network"`
 	PToken   string `long:"pToken" description:"Bridge token"`
+
+	// offline signer
+	UnsignedTxFile string `long:"unsignedtx" description:"File of the unsigned tx returned by the rpc createunsignedtransactionv2"`
+	ActiveShards   int    `long:"activeshards" description:"Number of shards of the network"`
 }
 
 // newConfigParser returns a new command line flags parser.
@@ -58,8 +63,9 @@ func newConfigParser(cfg *params, options flags.Options) *flags.Parser {
 
 func loadParams() (*params, error) {
 	cfg := params{
-		DataDir: defaultDataDir,
-		TestNet: false,
+		DataDir:      defaultDataDir,
+		TestNet:      false,
+		ActiveShards: 8,
 	}
 
 	preParser := newConfigParser(&cfg, flags.HelpFlag)
@@ -124,6 +124,36 @@ func processCmd() {
 			}
 			log.Println(string(result))
 		}
+	case signTransactionCmd:
+		{
+			if cfg.WalletPassphrase == "" || cfg.WalletName == "" || cfg.WalletAccountName == "" || cfg.UnsignedTxFile == "" {
+				log.Println("Wrong param")
+				return
+			}
+			signedTx, err := signTransaction(cfg.WalletAccountName, cfg.UnsignedTxFile)
+			if err != nil {
+				log.Println(err)
+				return
+			}
+			result, err := parseToJsonString(signedTx)
+			if err != nil {
+				log.Println(err)
+				return
+			}
+			log.Println(string(result))
+		}
+	case changePassphraseCmd:
+		{
+			if cfg.WalletPassphrase == "" || cfg.WalletName == "" || cfg.NewWalletPassphrase == "" {
+				log.Println("Wrong param")
+				return
+			}
+			err := changePassphrase(cfg.NewWalletPassphrase)
+			if err != nil {
+				log.Println(err)
+				return
+			}
+		}
 	case backupChain:
 		{
 			if cfg.Beacon == false && cfg.ShardIDs == "" {
@@ -0,0 +1,51 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"log"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/transaction/offline"
+	"github.com/incognitochain/incognito-chain/transaction/utils"
+)
+
+// signTransaction sign the unsigned tx of createunsignedtransactionv2 saved in unsignedTxFile with the key of a wallet account,
+// the private key never leaves this host
+func signTransaction(accountName string, unsignedTxFile string) (interface{}, error) {
+	utils.Logger.Init(common.NewBackend(nil).Logger("SignerCMD", true))
+	common.MaxShardNumber = cfg.ActiveShards
+
+	data, err := ioutil.ReadFile(unsignedTxFile)
+	if err != nil {
+		return nil, err
+	}
+	unsignedTx := &offline.UnsignedTx{}
+	if err := json.Unmarshal(data, unsignedTx); err != nil {
+		return nil, err
+	}
+
+	walletObj, err := loadWallet()
+	if err != nil {
+		return nil, err
+	}
+	for _, account := range walletObj.ListAccounts() {
+		if accountName != account.Name {
+			continue
+		}
+		tokenID := common.PRVIDStr
+		if unsignedTx.IsToken() {
+			tokenID = unsignedTx.TokenID
+		}
+		log.Printf("Sign tx of %s paying a fee of %d", unsignedTx.SenderPaymentAddress, unsignedTx.Fee)
+		for _, receiver := range unsignedTx.Receivers {
+			log.Printf("Pay %d %s to %s", receiver.Amount, common.PRVIDStr, receiver.PaymentAddress)
+		}
+		for _, receiver := range unsignedTx.TokenReceivers {
+			log.Printf("Pay %d %s to %s", receiver.Amount, tokenID, receiver.PaymentAddress)
+		}
+		return unsignedTx.Sign(&account.Key.KeySet.PrivateKey)
+	}
+	return nil, errors.New("Not found")
+}
@@ -110,3 +110,17 @@ func createAccount(accountName string, shardID *byte) (interface{}, error) {
 	}
 	return nil, errors.New("Can not load wallet")
 }
+
+// changePassphrase re-encrypts the wallet with newPassPhrase, a wallet in the legacy format is saved as a keystore
+func changePassphrase(newPassPhrase string) error {
+	walletObj, err := loadWallet()
+	if err != nil {
+		return err
+	}
+	err = walletObj.ChangePassphrase(cfg.WalletPassphrase, newPassPhrase)
+	if err != nil {
+		return err
+	}
+	log.Printf("Change passphrase of wallet %s successfully", cfg.WalletName)
+	return nil
+}
@@ -11,6 +11,7 @@ const (
 	WARNING_LEVEL = "warning"
 	DEBUG_LEVEL   = "debug"
 	FATAL_LEVEL   = "fatal"
+	TRACE_LEVEL   = "trace"
 )
 
 var SENTRY_DSN = os.Getenv("SENTRY_DSN")
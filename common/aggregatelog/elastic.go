@@ -4,7 +4,6 @@ import (
 	"context"
 	"errors"
 	"log"
-	"os"
 	"time"
 
 	"github.com/olivere/elastic"
@@ -12,14 +11,6 @@ import (
 
 const LOG_AGGREGATION_INDEX = "log_aggregation"
 
-type MessageData struct {
-	Time     time.Time `json:"time"`
-	LogLevel string    `json:"level"`
-	Message  string    `json:"message"`
-	NodeID   string    `json:"nodeid,omitempty"`
-	TestName string    `json:"testname,omitempty"`
-}
-
 var elasticClient *elastic.Client
 var ctx = context.Background()
 
@@ -121,23 +112,23 @@ func SendMessageToElastic(message, level string) error {
 		return validErr
 	}
 
-	messageObject := MessageData{
-		Time:     time.Now(),
-		Message:  message,
-		LogLevel: level,
-		NodeID:   os.Getenv("NodeID"),
-		TestName: os.Getenv("TestName"),
+	return SendElasticEvent(NewEvent(time.Now(), level, "", message, nil))
+}
+
+// SendElasticEvent index a structured log event, its context fields are indexed at top level
+func SendElasticEvent(event *Event) error {
+	validErr := ValidateElasticClient()
+	if validErr != nil {
+		return validErr
 	}
 	// putResult, err := elasticClient.Index().
 	_, err := elasticClient.Index().
 		Index(LOG_AGGREGATION_INDEX).
 		Type("log").
-		BodyJson(messageObject).
+		BodyJson(event).
 		Do(ctx)
 	if err != nil {
 		return err
 	}
-	// log.Printf("Indexed tweet %s to index %s, type %s\n", putResult.Id, putResult.Index, putResult.Type)
-	// log.Printf("Indexed tweet %s to index %s, type %s\n", putResult.Id, putResult.Index, putResult.Type)
 	return nil
 }
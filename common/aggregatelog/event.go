@@ -0,0 +1,78 @@
+package aggregatelog
+
+import (
+	"encoding/json"
+	"os"
+	"time"
+)
+
+// field names of structured log events, shared by the json log format and the aggregation sinks
+const (
+	FieldTime      = "time"
+	FieldLevel     = "level"
+	FieldSubsystem = "subsystem"
+	FieldMessage   = "message"
+	FieldFile      = "file"
+	FieldNodeID    = "nodeid"
+	FieldTestName  = "testname"
+
+	FieldChainID   = "chainID"
+	FieldHeight    = "height"
+	FieldBlockHash = "blockHash"
+	FieldTxHash    = "txHash"
+	FieldPeerID    = "peerID"
+)
+
+// Fields are the context fields attached to a log event, keyed by the Field* names
+type Fields map[string]interface{}
+
+// Event is a structured log event
+type Event struct {
+	Time      time.Time
+	Level     string
+	Subsystem string
+	Message   string
+	File      string
+	NodeID    string
+	TestName  string
+	Fields    Fields
+}
+
+// NewEvent create an event of the current node, NodeID and TestName are read from env like the elastic sink does
+func NewEvent(t time.Time, level, subsystem, message string, fields Fields) *Event {
+	return &Event{
+		Time:      t,
+		Level:     level,
+		Subsystem: subsystem,
+		Message:   message,
+		NodeID:    os.Getenv("NodeID"),
+		TestName:  os.Getenv("TestName"),
+		Fields:    fields,
+	}
+}
+
+// Map flatten the event into one level of key/value, context fields never override the event fields
+func (e *Event) Map() map[string]interface{} {
+	m := make(map[string]interface{}, len(e.Fields)+7)
+	for k, v := range e.Fields {
+		m[k] = v
+	}
+	m[FieldTime] = e.Time
+	m[FieldLevel] = e.Level
+	m[FieldSubsystem] = e.Subsystem
+	m[FieldMessage] = e.Message
+	if e.File != "" {
+		m[FieldFile] = e.File
+	}
+	if e.NodeID != "" {
+		m[FieldNodeID] = e.NodeID
+	}
+	if e.TestName != "" {
+		m[FieldTestName] = e.TestName
+	}
+	return m
+}
+
+func (e *Event) MarshalJSON() ([]byte, error) {
+	return json.Marshal(e.Map())
+}
@@ -2,6 +2,7 @@ package aggregatelog
 
 /*import (
 	"errors"
+	"fmt"
 
 	raven "github.com/getsentry/raven-go"
 )
@@ -96,4 +97,21 @@ func sendMessageToSentry(message string, tags map[string]string) error {
 	}
 	sentryClient.CaptureMessage(message, tags, nil)
 	return nil
+}
+
+func CaptureSentryEvent(event *Event) error {
+	clientErr := ValidateSentryClient()
+	if clientErr != nil {
+		return clientErr
+	}
+	tags := map[string]string{
+		"level":        event.Level,
+		"type":         event.Level,
+		FieldSubsystem: event.Subsystem,
+	}
+	for k, v := range event.Fields {
+		tags[k] = fmt.Sprint(v)
+	}
+	sentryClient.CaptureMessage(event.Message, tags, nil)
+	return nil
 }*/
@@ -11,6 +11,7 @@ type LogService struct {
 	CaptureDebug   CaptureDebug
 	CaptureWarning CaptureWarning
 	CaptureFatal   CaptureFatal
+	CaptureEvent   CaptureEvent
 }
 
 type CaptureMessage func(message string, params ...interface{}) error
@@ -18,6 +19,7 @@ type CaptureError func(err error, params ...interface{}) error
 type CaptureDebug func(message string, params ...interface{}) error
 type CaptureWarning func(message string, params ...interface{}) error
 type CaptureFatal func(message string, params ...interface{}) error
+type CaptureEvent func(event *Event) error
 
 type InitService func(params map[string]interface{}) error
 
@@ -43,6 +45,7 @@ func init() {
 		CaptureSentryDebug,
 		CaptureSentryWarning,
 		CaptureSentryFatal,
+		CaptureSentryEvent,
 	})*/
 
 	RegisterService(ELASTIC_LOG_SERVICENAME, &LogService{
@@ -52,5 +55,6 @@ func init() {
 		SendElasticDebug,
 		SendElasticWarning,
 		SendElasticFatal,
+		SendElasticEvent,
 	})
 }
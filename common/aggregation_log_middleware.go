@@ -4,10 +4,10 @@ import (
 	"log"
 
 	"github.com/incognitochain/incognito-chain/common/aggregatelog"
-	"github.com/pkg/errors"
 )
 
 var LevelMap = map[string]string{
+	"TRC": aggregatelog.TRACE_LEVEL,
 	"DBG": aggregatelog.DEBUG_LEVEL,
 	"INF": aggregatelog.INFO_LEVEL,
 	"WRN": aggregatelog.WARNING_LEVEL,
@@ -51,25 +51,19 @@ func AggregationLogInit() {
 	}
 }
 
-func HandleCaptureMessage(message, level string) error {
-	lvl, ok := LevelMap[level]
-	if !ok {
+// HandleCaptureEvent send a log event to every initialized aggregation log service, trace events are not sent
+func HandleCaptureEvent(event *aggregatelog.Event) error {
+	if event.Level == aggregatelog.TRACE_LEVEL {
 		return nil
 	}
-	if ElasticLogService == nil || SentryLogService == nil {
-		return nil
-	}
-	switch lvl {
-	case aggregatelog.ERROR_LEVEL:
-		return ElasticLogService.CaptureError(errors.New(message))
-	case aggregatelog.INFO_LEVEL:
-		return ElasticLogService.CaptureMessage(message)
-	case aggregatelog.DEBUG_LEVEL:
-		return ElasticLogService.CaptureDebug(message)
-	case aggregatelog.WARNING_LEVEL:
-		return ElasticLogService.CaptureWarning(message)
-	case aggregatelog.FATAL_LEVEL:
-		return ElasticLogService.CaptureFatal(message)
+	var err error
+	for _, service := range []*aggregatelog.LogService{ElasticLogService, SentryLogService} {
+		if service == nil || service.CaptureEvent == nil {
+			continue
+		}
+		if e := service.CaptureEvent(event); e != nil {
+			err = e
+		}
 	}
-	return nil
+	return err
 }
@@ -0,0 +1,196 @@
+package common
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+)
+
+// maxBinaryLength bound the length prefix of bytes, string and list read by BinaryReader
+const maxBinaryLength = 1 << 28
+
+// BinaryWriter builds canonical binary encoding: integers are varints, bytes and strings are length-prefixed
+type BinaryWriter struct {
+	buf []byte
+}
+
+func NewBinaryWriter() *BinaryWriter {
+	return &BinaryWriter{buf: []byte{}}
+}
+
+func (w *BinaryWriter) Bytes() []byte {
+	return w.buf
+}
+
+func (w *BinaryWriter) WriteUint8(b byte) {
+	w.buf = append(w.buf, b)
+}
+
+func (w *BinaryWriter) WriteBool(b bool) {
+	if b {
+		w.WriteUint8(1)
+	} else {
+		w.WriteUint8(0)
+	}
+}
+
+func (w *BinaryWriter) WriteUvarint(v uint64) {
+	var tmp [binary.MaxVarintLen64]byte
+	n := binary.PutUvarint(tmp[:], v)
+	w.buf = append(w.buf, tmp[:n]...)
+}
+
+func (w *BinaryWriter) WriteVarint(v int64) {
+	var tmp [binary.MaxVarintLen64]byte
+	n := binary.PutVarint(tmp[:], v)
+	w.buf = append(w.buf, tmp[:n]...)
+}
+
+func (w *BinaryWriter) WriteHash(h Hash) {
+	w.buf = append(w.buf, h[:]...)
+}
+
+func (w *BinaryWriter) WriteBytes(b []byte) {
+	w.WriteUvarint(uint64(len(b)))
+	w.buf = append(w.buf, b...)
+}
+
+func (w *BinaryWriter) WriteString(s string) {
+	w.WriteUvarint(uint64(len(s)))
+	w.buf = append(w.buf, s...)
+}
+
+func (w *BinaryWriter) WriteStrings(list []string) {
+	w.WriteUvarint(uint64(len(list)))
+	for _, s := range list {
+		w.WriteString(s)
+	}
+}
+
+// BinaryReader reads data written by BinaryWriter, the first error is kept and returned by Err
+type BinaryReader struct {
+	data []byte
+	pos  int
+	err  error
+}
+
+func NewBinaryReader(data []byte) *BinaryReader {
+	return &BinaryReader{data: data}
+}
+
+func (r *BinaryReader) Err() error {
+	return r.err
+}
+
+// Remaining return the number of unread bytes
+func (r *BinaryReader) Remaining() int {
+	return len(r.data) - r.pos
+}
+
+func (r *BinaryReader) fail(err error) {
+	if r.err == nil {
+		r.err = err
+	}
+}
+
+func (r *BinaryReader) next(n int) []byte {
+	if r.err != nil {
+		return nil
+	}
+	if n < 0 || r.Remaining() < n {
+		r.fail(fmt.Errorf("unexpected end of data at %v, need %v bytes", r.pos, n))
+		return nil
+	}
+	res := r.data[r.pos : r.pos+n]
+	r.pos += n
+	return res
+}
+
+func (r *BinaryReader) ReadUint8() byte {
+	b := r.next(1)
+	if b == nil {
+		return 0
+	}
+	return b[0]
+}
+
+func (r *BinaryReader) ReadBool() bool {
+	return r.ReadUint8() == 1
+}
+
+func (r *BinaryReader) ReadUvarint() uint64 {
+	if r.err != nil {
+		return 0
+	}
+	v, n := binary.Uvarint(r.data[r.pos:])
+	if n <= 0 {
+		r.fail(fmt.Errorf("invalid uvarint at %v", r.pos))
+		return 0
+	}
+	r.pos += n
+	return v
+}
+
+func (r *BinaryReader) ReadVarint() int64 {
+	if r.err != nil {
+		return 0
+	}
+	v, n := binary.Varint(r.data[r.pos:])
+	if n <= 0 {
+		r.fail(fmt.Errorf("invalid varint at %v", r.pos))
+		return 0
+	}
+	r.pos += n
+	return v
+}
+
+// ReadLength read a length prefix and check it against the upper bound
+func (r *BinaryReader) ReadLength() int {
+	l := r.ReadUvarint()
+	if l > maxBinaryLength {
+		r.fail(errors.New("length prefix is too large"))
+		return 0
+	}
+	return int(l)
+}
+
+func (r *BinaryReader) ReadHash() Hash {
+	h := Hash{}
+	copy(h[:], r.next(HashSize))
+	return h
+}
+
+// ReadBytes return a copy of length-prefixed bytes, nil if the length is 0
+func (r *BinaryReader) ReadBytes() []byte {
+	l := r.ReadLength()
+	b := r.next(l)
+	if len(b) == 0 {
+		return nil
+	}
+	res := make([]byte, l)
+	copy(res, b)
+	return res
+}
+
+func (r *BinaryReader) ReadString() string {
+	return string(r.next(r.ReadLength()))
+}
+
+func (r *BinaryReader) ReadStrings() []string {
+	l := r.ReadLength()
+	if r.err != nil {
+		return nil
+	}
+	res := make([]string, 0, minInt(l, r.Remaining()))
+	for i := 0; i < l && r.err == nil; i++ {
+		res = append(res, r.ReadString())
+	}
+	return res
+}
+
+func minInt(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
@@ -132,6 +132,8 @@ const (
 	EVMAddressLength          = 40
 	UnifiedTokenPrefix        = "UT"
 	NEARPrefix                = "NER"
+	SOLPrefix                 = "SOL"
+	SOLAddressLength          = 32
 )
 
 // Bridge, PDE & Portal statuses for RPCs
@@ -260,10 +262,12 @@ const (
 	AURORANetworkID
 	AVAXNetworkID
 	NEARNetworkID
+	SOLNetworkID
 )
 
 const (
 	EVMNetworkType = iota
+	SOLNetworkType
 )
 
 const (
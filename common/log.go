@@ -1,19 +1,22 @@
 package common
 
 import (
-	"bytes"
+	"encoding/json"
 	"fmt"
 	"io"
 	"io/ioutil"
 	"log"
 	"os"
 	"runtime"
+	"sort"
+	"strconv"
 	"strings"
 	"sync"
 	"sync/atomic"
 	"time"
 
 	"github.com/0xsirrush/color"
+	"github.com/incognitochain/incognito-chain/common/aggregatelog"
 )
 
 // Logger is an interface which describes a level-based logger.  A default
@@ -73,8 +76,26 @@ type Logger interface {
 
 	// SetLevel changes the logging level to the passed level.
 	SetLevel(level Level)
+
+	// WithFields returns a logger sharing the level of this logger which
+	// attaches the fields to every message.
+	WithFields(fields LogFields) Logger
 }
 
+// LogFields are context fields attached to log messages.  They are output as
+// key=value in the text format and as top level keys in the json format and
+// aggregation log events.
+type LogFields = aggregatelog.Fields
+
+// Field names of LogFields, use these so the log pipeline can index them.
+const (
+	LogFieldChainID   = aggregatelog.FieldChainID
+	LogFieldHeight    = aggregatelog.FieldHeight
+	LogFieldBlockHash = aggregatelog.FieldBlockHash
+	LogFieldTxHash    = aggregatelog.FieldTxHash
+	LogFieldPeerID    = aggregatelog.FieldPeerID
+)
+
 // defaultFlags specifies changes to the default logger behavior.  It is set
 // during package init and configured using the LOGFLAGS environment variable.
 // Zero logger backends can override these default flags using WithFlags.
@@ -165,6 +186,36 @@ func (l Level) String() string {
 	return levelStrs[l]
 }
 
+// LogFormat is the output format of a Backend.
+type LogFormat uint32
+
+// LogFormat constants.
+const (
+	LogFormatText LogFormat = iota
+	LogFormatJSON
+)
+
+// LogFormatFromString returns a log format based on the input string s, an
+// empty string is the text format.
+func LogFormatFromString(s string) (LogFormat, error) {
+	switch strings.ToLower(s) {
+	case "", "text":
+		return LogFormatText, nil
+	case "json":
+		return LogFormatJSON, nil
+	default:
+		return LogFormatText, fmt.Errorf("invalid log format %v, expect text or json", s)
+	}
+}
+
+// String returns the name of the log format.
+func (f LogFormat) String() string {
+	if f == LogFormatJSON {
+		return "json"
+	}
+	return "text"
+}
+
 // NewBackend creates a logger backend from a Writer.
 func NewBackend(w io.Writer, opts ...BackendOption) *Backend {
 	b := &Backend{w: w, flag: defaultFlags}
@@ -178,9 +229,10 @@ func NewBackend(w io.Writer, opts ...BackendOption) *Backend {
 // the backend's Writer.  Backend provides atomic writes to the Writer from all
 // subsystems.
 type Backend struct {
-	w    io.Writer
-	mu   sync.Mutex // ensures atomic writes
-	flag uint32
+	w      io.Writer
+	mu     sync.Mutex // ensures atomic writes
+	flag   uint32
+	format uint32 // atomic
 }
 
 // BackendOption is a function used to modify the behavior of a Backend.
@@ -195,6 +247,24 @@ func WithFlags(flags uint32) BackendOption {
 	}
 }
 
+// WithFormat configures a Backend to output in the specified format.
+func WithFormat(format LogFormat) BackendOption {
+	return func(b *Backend) {
+		b.format = uint32(format)
+	}
+}
+
+// Format returns the current output format of the backend.
+func (b *Backend) Format() LogFormat {
+	return LogFormat(atomic.LoadUint32(&b.format))
+}
+
+// SetFormat changes the output format of the backend, it is safe to call
+// while subsystems are logging.
+func (b *Backend) SetFormat(format LogFormat) {
+	atomic.StoreUint32(&b.format, uint32(format))
+}
+
 // bufferPool defines a concurrent safe free list of byte slices used to provide
 // temporary buffers for formatting log messages prior to outputting them.
 var bufferPool = sync.Pool{
@@ -305,67 +375,88 @@ func callsite(flag uint32) (string, int) {
 // creating a prefix for the given level and tag according to the formatHeader
 // function and formatting the provided arguments using the default formatting
 // rules.
-func (b *Backend) print(lvl, tag string, args ...interface{}) {
+func (b *Backend) print(lvl, tag string, fields LogFields, args ...interface{}) {
 	t := time.Now() // get as early as possible
 
-	bytebuf := buffer()
-
 	var file string
 	var line int
 	if b.flag&(Lshortfile|Llongfile) != 0 {
 		file, line = callsite(b.flag)
 	}
-
-	formatHeader(bytebuf, t, lvl, tag, file, line)
-	buf := bytes.NewBuffer(*bytebuf)
-	fmt.Fprintln(buf, args...)
-	*bytebuf = buf.Bytes()
-
-	b.colorPrint(lvl, *bytebuf)
-	// @hunghd SEND LOG TO AGGREGATION LOG SERVER
-	if isAggregationLogMode() {
-		loggerSeparator := "[" + lvl + "]"
-		mes := bytes.Split(*bytebuf, []byte(loggerSeparator))
-		if len(mes) >= 2 {
-			HandleCaptureMessage(string(mes[1]), lvl)
-		}
-	}
-
-	recycleBuffer(bytebuf)
+	msg := fmt.Sprintln(args...)
+	b.output(t, lvl, tag, file, line, msg[:len(msg)-1], fields)
 }
 
 // printf outputs a log message to the writer associated with the backend after
 // creating a prefix for the given level and tag according to the formatHeader
 // function and formatting the provided arguments according to the given format
 // specifier.
-func (b *Backend) printf(lvl, tag string, format string, args ...interface{}) {
+func (b *Backend) printf(lvl, tag string, fields LogFields, format string, args ...interface{}) {
 	t := time.Now() // get as early as possible
 
-	bytebuf := buffer()
-
 	var file string
 	var line int
 	if b.flag&(Lshortfile|Llongfile) != 0 {
 		file, line = callsite(b.flag)
 	}
-	formatHeader(bytebuf, t, lvl, tag, file, line)
-	buf := bytes.NewBuffer(*bytebuf)
-	fmt.Fprintf(buf, format, args...)
-	*bytebuf = append(buf.Bytes(), '\n')
+	b.output(t, lvl, tag, file, line, fmt.Sprintf(format, args...), fields)
+}
+
+// output writes a formatted message in the format of the backend and sends it
+// to the aggregation log services when enabled.
+func (b *Backend) output(t time.Time, lvl, tag, file string, line int, msg string, fields LogFields) {
+	var event *aggregatelog.Event
+	jsonFormat := b.Format() == LogFormatJSON
+	if jsonFormat || isAggregationLogMode() {
+		event = aggregatelog.NewEvent(t, LevelMap[lvl], strings.TrimSpace(tag), msg, fields)
+		if file != "" {
+			event.File = file + ":" + strconv.Itoa(line)
+		}
+	}
 
-	b.colorPrint(lvl, *bytebuf)
+	bytebuf := buffer()
+	if jsonFormat {
+		data, err := json.Marshal(event)
+		if err != nil {
+			data, _ = json.Marshal(aggregatelog.NewEvent(t, event.Level, event.Subsystem, msg+" (log fields: "+err.Error()+")", nil))
+		}
+		*bytebuf = append(append(*bytebuf, data...), '\n')
+		b.mu.Lock()
+		b.w.Write(*bytebuf)
+		b.mu.Unlock()
+	} else {
+		formatHeader(bytebuf, t, lvl, tag, file, line)
+		*bytebuf = append(*bytebuf, msg...)
+		formatFields(bytebuf, fields)
+		*bytebuf = append(*bytebuf, '\n')
+		b.colorPrint(lvl, *bytebuf)
+	}
 	// @hunghd SEND LOG TO AGGREGATION LOG SERVER
 	if isAggregationLogMode() {
-		loggerSeparator := "[" + lvl + "]"
-		mes := bytes.Split(*bytebuf, []byte(loggerSeparator))
-		if len(mes) >= 2 {
-			HandleCaptureMessage(string(mes[1]), lvl)
-		}
+		HandleCaptureEvent(event)
 	}
 
 	recycleBuffer(bytebuf)
 }
 
+// formatFields appends the fields sorted by name as ' key=value'.
+func formatFields(buf *[]byte, fields LogFields) {
+	if len(fields) == 0 {
+		return
+	}
+	keys := make([]string, 0, len(fields))
+	for k := range fields {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		*buf = append(*buf, ' ')
+		*buf = append(*buf, k...)
+		*buf = append(*buf, '=')
+		*buf = append(*buf, fmt.Sprint(fields[k])...)
+	}
+}
+
 func (b *Backend) colorPrint(lvl string, bytebuf []byte) {
 	b.mu.Lock()
 	switch lvl {
@@ -399,15 +490,18 @@ func (b *Backend) colorPrint(lvl string, bytebuf []byte) {
 // Backend b.  A tag describes the subsystem and is included in all log
 // messages.  The logger uses the info verbosity level by default.
 func (b *Backend) Logger(subsystemTag string, disable bool) Logger {
-	return &slog{LevelInfo, subsystemTag, b, disable}
+	lvl := LevelInfo
+	return &slog{&lvl, subsystemTag, b, disable, nil}
 }
 
 // slog is a subsystem logger for a Backend.  Implements the Logger interface.
+// Loggers created by WithFields share the level of their parent.
 type slog struct {
-	lvl     Level // atomic
+	lvl     *Level // atomic
 	tag     string
 	b       *Backend
 	disable bool
+	fields  LogFields
 }
 
 // Trace formats message using the default formats for its operands, prepends
@@ -421,7 +515,7 @@ func (l *slog) Trace(args ...interface{}) {
 	lvl := l.Level()
 	if lvl <= LevelTrace {
 		if !l.disable {
-			l.b.print("TRC", l.tag, args...)
+			l.b.print("TRC", l.tag, l.fields, args...)
 		}
 	}
 }
@@ -437,7 +531,7 @@ func (l *slog) Tracef(format string, args ...interface{}) {
 	lvl := l.Level()
 	if lvl <= LevelTrace {
 		if !l.disable {
-			l.b.printf("TRC", l.tag, format, args...)
+			l.b.printf("TRC", l.tag, l.fields, format, args...)
 		}
 	}
 }
@@ -453,7 +547,7 @@ func (l *slog) Debug(args ...interface{}) {
 	lvl := l.Level()
 	if lvl <= LevelDebug {
 		if !l.disable {
-			l.b.print("DBG", l.tag, args...)
+			l.b.print("DBG", l.tag, l.fields, args...)
 		}
 	}
 }
@@ -469,7 +563,7 @@ func (l *slog) Debugf(format string, args ...interface{}) {
 	lvl := l.Level()
 	if lvl <= LevelDebug {
 		if !l.disable {
-			l.b.printf("DBG", l.tag, format, args...)
+			l.b.printf("DBG", l.tag, l.fields, format, args...)
 		}
 	}
 }
@@ -485,7 +579,7 @@ func (l *slog) Info(args ...interface{}) {
 	lvl := l.Level()
 	if lvl <= LevelInfo {
 		if !l.disable {
-			l.b.print("INF", l.tag, args...)
+			l.b.print("INF", l.tag, l.fields, args...)
 		}
 	}
 }
@@ -501,7 +595,7 @@ func (l *slog) Infof(format string, args ...interface{}) {
 	lvl := l.Level()
 	if lvl <= LevelInfo {
 		if !l.disable {
-			l.b.printf("INF", l.tag, format, args...)
+			l.b.printf("INF", l.tag, l.fields, format, args...)
 		}
 	}
 }
@@ -517,7 +611,7 @@ func (l *slog) Warn(args ...interface{}) {
 	lvl := l.Level()
 	if lvl <= LevelWarn {
 		if !l.disable {
-			l.b.print("WRN", l.tag, args...)
+			l.b.print("WRN", l.tag, l.fields, args...)
 		}
 	}
 }
@@ -533,7 +627,7 @@ func (l *slog) Warnf(format string, args ...interface{}) {
 	lvl := l.Level()
 	if lvl <= LevelWarn {
 		if !l.disable {
-			l.b.printf("WRN", l.tag, format, args...)
+			l.b.printf("WRN", l.tag, l.fields, format, args...)
 		}
 	}
 }
@@ -549,7 +643,7 @@ func (l *slog) Error(args ...interface{}) {
 	lvl := l.Level()
 	if lvl <= LevelError {
 		if !l.disable {
-			l.b.print("ERR", l.tag, args...)
+			l.b.print("ERR", l.tag, l.fields, args...)
 		}
 	}
 }
@@ -565,7 +659,7 @@ func (l *slog) Errorf(format string, args ...interface{}) {
 	lvl := l.Level()
 	if lvl <= LevelError {
 		if !l.disable {
-			l.b.printf("ERR", l.tag, format, args...)
+			l.b.printf("ERR", l.tag, l.fields, format, args...)
 		}
 	}
 }
@@ -581,7 +675,7 @@ func (l *slog) Critical(args ...interface{}) {
 	lvl := l.Level()
 	if lvl <= LevelCritical {
 		if !l.disable {
-			l.b.print("CRT", l.tag, args...)
+			l.b.print("CRT", l.tag, l.fields, args...)
 		}
 	}
 }
@@ -597,7 +691,7 @@ func (l *slog) Criticalf(format string, args ...interface{}) {
 	lvl := l.Level()
 	if lvl <= LevelCritical {
 		if !l.disable {
-			l.b.printf("CRT", l.tag, format, args...)
+			l.b.printf("CRT", l.tag, l.fields, format, args...)
 		}
 	}
 }
@@ -606,21 +700,37 @@ func (l *slog) Criticalf(format string, args ...interface{}) {
 //
 // This is part of the Logger interface implementation.
 func (l *slog) Level() Level {
-	return Level(atomic.LoadUint32((*uint32)(&l.lvl)))
+	return Level(atomic.LoadUint32((*uint32)(l.lvl)))
 }
 
 // SetLevel changes the logging level to the passed level.
 //
 // This is part of the Logger interface implementation.
 func (l *slog) SetLevel(level Level) {
-	atomic.StoreUint32((*uint32)(&l.lvl), uint32(level))
+	atomic.StoreUint32((*uint32)(l.lvl), uint32(level))
+}
+
+// WithFields returns a logger which attaches the fields, merged with the
+// fields of l, to every message.
+//
+// This is part of the Logger interface implementation.
+func (l *slog) WithFields(fields LogFields) Logger {
+	merged := make(LogFields, len(l.fields)+len(fields))
+	for k, v := range l.fields {
+		merged[k] = v
+	}
+	for k, v := range fields {
+		merged[k] = v
+	}
+	return &slog{l.lvl, l.tag, l.b, l.disable, merged}
 }
 
 // Disabled is a Logger that will never output anything.
 var Disabled Logger
 
 func init() {
-	Disabled = &slog{lvl: LevelOff, b: NewBackend(ioutil.Discard)}
+	lvl := LevelOff
+	Disabled = &slog{lvl: &lvl, b: NewBackend(ioutil.Discard)}
 
 	// @hunghd SEND LOG TO AGGREGATION LOG SERVER
 	if isAggregationLogMode() {
@@ -0,0 +1,69 @@
+package common
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLogJSONFormat(t *testing.T) {
+	buf := &bytes.Buffer{}
+	backend := NewBackend(buf, WithFlags(0), WithFormat(LogFormatJSON))
+	logger := backend.Logger("Syncker log ", false)
+	logger.WithFields(LogFields{LogFieldChainID: 1, LogFieldHeight: 10}).Infof("insert block %v", "abc")
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	assert.Equal(t, 1, len(lines))
+	event := map[string]interface{}{}
+	assert.Equal(t, nil, json.Unmarshal([]byte(lines[0]), &event))
+	assert.Equal(t, "info", event["level"])
+	assert.Equal(t, "Syncker log", event["subsystem"])
+	assert.Equal(t, "insert block abc", event["message"])
+	assert.Equal(t, float64(1), event["chainID"])
+	assert.Equal(t, float64(10), event["height"])
+	assert.NotEmpty(t, event["time"])
+}
+
+func TestLogTextFormatFields(t *testing.T) {
+	buf := &bytes.Buffer{}
+	backend := NewBackend(buf, WithFlags(0))
+	logger := backend.Logger("Peerv2 log", false)
+	logger.WithFields(LogFields{LogFieldPeerID: "QmPeer", LogFieldChainID: -1}).Warn("drop message")
+
+	assert.Contains(t, buf.String(), "[WRN] Peerv2 log: drop message chainID=-1 peerID=QmPeer\n")
+
+	buf.Reset()
+	backend.SetFormat(LogFormatJSON)
+	logger.Warn("drop message")
+	assert.True(t, strings.HasPrefix(buf.String(), "{"))
+}
+
+func TestLogWithFieldsSharesLevel(t *testing.T) {
+	buf := &bytes.Buffer{}
+	logger := NewBackend(buf, WithFlags(0)).Logger("BlockChain log", false)
+	child := logger.WithFields(LogFields{LogFieldTxHash: "abc"})
+	grandChild := child.WithFields(LogFields{LogFieldHeight: 5})
+
+	logger.SetLevel(LevelError)
+	grandChild.Info("filtered")
+	assert.Equal(t, 0, buf.Len())
+
+	child.SetLevel(LevelDebug)
+	assert.Equal(t, LevelDebug, logger.Level())
+	grandChild.Debug("shown")
+	assert.Contains(t, buf.String(), "shown height=5 txHash=abc")
+}
+
+func TestLogFormatFromString(t *testing.T) {
+	format, err := LogFormatFromString("")
+	assert.Equal(t, nil, err)
+	assert.Equal(t, LogFormatText, format)
+	format, err = LogFormatFromString("JSON")
+	assert.Equal(t, nil, err)
+	assert.Equal(t, LogFormatJSON, format)
+	_, err = LogFormatFromString("xml")
+	assert.NotEqual(t, nil, err)
+}
@@ -19,6 +19,7 @@ const (
 	AURORAHostKey       = "AURORA_HOST"
 	AVAXHostKey         = "AVAX_HOST"
 	NEARHostKey         = "NEAR_HOST"
+	SOLHostKey          = "SOL_HOST"
 )
 
 // default config
@@ -26,6 +27,7 @@ const (
 	DefaultDataDirname                 = "data"
 	DefaultDatabaseDirname             = "block"
 	DefaultMempoolDirname              = "mempool"
+	DefaultTxPoolDirname               = "txpool"
 	DefaultLogLevel                    = "info"
 	DefaultLogDirname                  = "logs"
 	DefaultLogFilename                 = "log.log"
@@ -100,4 +102,5 @@ const (
 	DELEGATION_REWARD      = "delegation_reward"
 	SLASH_TO_SYNC          = "slash2sync"
 	DELEGATION             = "delegation"
+	EQUIVOCATION_SLASHING  = "equivocation_slashing"
 )
@@ -33,6 +33,19 @@ type AutoEnableFeature struct {
 	RequiredPercentage    int `mapstructure:"require_percentage"`
 }
 
+// BeaconCheckpoint is a trusted beacon block and the roots of beacon state after it, for nodes fast syncing from it.
+// Committee is the beacon committee signing the block, ViewHash is the hash of the beacon view fields not kept in the state tries
+type BeaconCheckpoint struct {
+	Height             uint64   `mapstructure:"height" json:"Height"`
+	Hash               string   `mapstructure:"hash" json:"Hash"`
+	ConsensusStateRoot string   `mapstructure:"consensus_state_root" json:"ConsensusStateRoot"`
+	FeatureStateRoot   string   `mapstructure:"feature_state_root" json:"FeatureStateRoot"`
+	RewardStateRoot    string   `mapstructure:"reward_state_root" json:"RewardStateRoot"`
+	SlashStateRoot     string   `mapstructure:"slash_state_root" json:"SlashStateRoot"`
+	ViewHash           string   `mapstructure:"view_hash" json:"ViewHash"`
+	Committee          []string `mapstructure:"committee" json:"Committee"`
+}
+
 type TxsPerBlock struct {
 	Lower int `mapstructure:"lowerbound"`
 	Upper int `mapstructure:"upperbound"`
@@ -57,6 +70,7 @@ type param struct {
 	AuroraContractAddressStr         string                       `mapstructure:"aurora_contract_address" description:"smart contract of AUR for bridge"`
 	AvaxContractAddressStr           string                       `mapstructure:"avax_contract_address" description:"smart contract of AVX for bridge"`
 	NearContractAddressStr           string                       `mapstructure:"near_contract_address" description:"smart contract of NEAR for bridge"`
+	SolProgramIDStr                  string                       `mapstructure:"sol_program_id" description:"vault program of Solana for bridge"`
 	IncognitoDAOAddress              string                       `mapstructure:"dao_address"`
 	CentralizedWebsitePaymentAddress string                       `mapstructure:"centralized_website_payment_address" description:"centralized website's pubkey"`
 	SwapCommitteeParam               swapCommitteeParam           `mapstructure:"swap_committee_param"`
@@ -77,6 +91,7 @@ type param struct {
 	AURORAParam                      auroraParam                  `mapstructure:"aurora_param"`
 	AVAXParam                        avaxParam                    `mapstructure:"avax_param"`
 	NEARParam                        nearParam                    `mapstructure:"near_param"`
+	SOLParam                         solParam                     `mapstructure:"sol_param"`
 	PDexParams                       pdexParam                    `mapstructure:"pdex_param"`
 	IsEnableBPV3Stats                bool                         `mapstructure:"is_enable_bpv3_stats"`
 	BridgeAggParam                   bridgeAggParam               `mapstructure:"bridge_agg_param"`
@@ -88,6 +103,8 @@ type param struct {
 	PRVERC20ContractAddressStr       string                       `mapstructure:"prv_erc20_contract_address" description:"smart contract of prv erc20"`
 	PRVBEP20ContractAddressStr       string                       `mapstructure:"prv_bep20_contract_address" description:"smart contract of prv bep20"`
 	BCHeightBreakPointCoinOrigin     uint64                       `mapstructure:"bc_height_break_point_coin_origin"`
+	BeaconCheckpoints                []BeaconCheckpoint           `mapstructure:"beacon_checkpoints" description:"trusted beacon checkpoints for fast sync"`
+	BeaconCheckpointViewInterval     uint64                       `mapstructure:"beacon_checkpoint_view_interval" description:"beacon views of blocks at multiple of this height are kept to serve fast sync, 0 - disabled"`
 }
 
 type genesisParam struct {
@@ -414,6 +431,16 @@ func (nearParam *nearParam) GetFromEnv() {
 	}
 }
 
+type solParam struct {
+	Host []string `mapstructure:"host"`
+}
+
+func (solParam *solParam) GetFromEnv() {
+	if utils.GetEnv(SOLHostKey, utils.EmptyString) != utils.EmptyString {
+		solParam.Host = []string{utils.GetEnv(SOLHostKey, utils.EmptyString)}
+	}
+}
+
 type bridgeAggParam struct {
 	AdminAddress                 string `mapstructure:"admin_address"`
 	BaseDecimal                  uint8  `mapstructure:"base_decimal"`
@@ -0,0 +1,402 @@
+package bftsim
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/incognitochain/incognito-chain/blockchain/types"
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/consensus_v2/blsbft"
+	"github.com/incognitochain/incognito-chain/incdb"
+	"github.com/incognitochain/incognito-chain/incognitokey"
+	"github.com/incognitochain/incognito-chain/multiview"
+	"github.com/incognitochain/incognito-chain/portal/portalv4"
+)
+
+// SimInstruction is added to every simulated block, so blocks proposed by different nodes never have the same hash
+const SimInstruction = "bftsim"
+
+// view is a beacon view with a static committee
+type view struct {
+	block     *types.BeaconBlock
+	hash      common.Hash
+	committee []incognitokey.CommitteePublicKey
+	slot      int64
+}
+
+func newView(block *types.BeaconBlock, committee []incognitokey.CommitteePublicKey, slot int64) *view {
+	return &view{block: block, hash: *block.Hash(), committee: committee, slot: slot}
+}
+
+func (v *view) CalculateTimeSlot(t int64) int64 {
+	return t / v.slot
+}
+
+func (v *view) GetCurrentTimeSlot() int64 {
+	return v.slot
+}
+
+func (v *view) GetHash() *common.Hash {
+	return &v.hash
+}
+
+func (v *view) GetPreviousHash() *common.Hash {
+	return &v.block.Header.PreviousBlockHash
+}
+
+func (v *view) GetHeight() uint64 {
+	return v.block.Header.Height
+}
+
+func (v *view) GetCommittee() []incognitokey.CommitteePublicKey {
+	return append([]incognitokey.CommitteePublicKey{}, v.committee...)
+}
+
+func (v *view) GetPreviousBlockCommittee(db incdb.Database) ([]incognitokey.CommitteePublicKey, error) {
+	return v.GetCommittee(), nil
+}
+
+func (v *view) CommitteeStateVersion() int {
+	return 0
+}
+
+func (v *view) GetBlock() types.BlockInterface {
+	return v.block
+}
+
+func (v *view) ReplaceBlock(blk types.BlockInterface) {
+	v.block = blk.(*types.BeaconBlock)
+}
+
+func (v *view) GetBeaconHeight() uint64 {
+	return v.block.Header.Height
+}
+
+func (v *view) GetProposerByTimeSlot(ts int64, version int) (incognitokey.CommitteePublicKey, int) {
+	id := int(ts % int64(len(v.committee)))
+	return v.committee[id], id
+}
+
+func (v *view) GetProposerLength() int {
+	return len(v.committee)
+}
+
+func (v *view) CompareCommitteeFromBlock(multiview.View) int {
+	return 0
+}
+
+func (v *view) PastHalfTimeslot(t int64) bool {
+	return false
+}
+
+// chain is the beacon chain of a simulated node, blocks only carry the simulation instruction
+// and are accepted when they have valid signatures from the proposer and the committee
+type chain struct {
+	node      *Node
+	multiView *multiview.BeaconMultiView
+	blocks    map[common.Hash]*types.BeaconBlock
+	committee []incognitokey.CommitteePublicKey
+	slot      int64
+}
+
+func newChain(node *Node, genesis *types.BeaconBlock, committee []incognitokey.CommitteePublicKey, slot time.Duration) *chain {
+	c := &chain{
+		node:      node,
+		multiView: multiview.NewBeaconMultiView(),
+		blocks:    make(map[common.Hash]*types.BeaconBlock),
+		committee: committee,
+		slot:      int64(slot / time.Second),
+	}
+	c.blocks[*genesis.Hash()] = genesis
+	c.multiView.AddView(newView(genesis, committee, c.slot))
+	return c
+}
+
+func newGenesisBlock(version int, startTime int64) *types.BeaconBlock {
+	block := types.NewBeaconBlock()
+	block.Header = types.BeaconHeader{
+		Version:        version,
+		Height:         1,
+		Epoch:          1,
+		Timestamp:      startTime,
+		ProposeTime:    startTime,
+		ConsensusType:  common.BlsConsensus,
+		FinalityHeight: 1,
+	}
+	return block
+}
+
+func (c *chain) BestViewCommitteeFromBlock() common.Hash {
+	return common.Hash{}
+}
+
+func (c *chain) GetMultiView() multiview.MultiView {
+	return c.multiView
+}
+
+func (c *chain) GetFinalView() multiview.View {
+	return c.multiView.GetFinalView()
+}
+
+func (c *chain) GetBestView() multiview.View {
+	return c.multiView.GetBestView()
+}
+
+func (c *chain) GetEpoch() uint64 {
+	return 1
+}
+
+func (c *chain) GetChainName() string {
+	return common.BeaconChainKey
+}
+
+func (c *chain) GetConsensusType() string {
+	return common.BlsConsensus
+}
+
+func (c *chain) GetBlockConsensusData() map[int]types.BlockConsensusData {
+	return nil
+}
+
+func (c *chain) GetLastBlockTimeStamp() int64 {
+	return c.GetBestView().GetBlock().GetProduceTime()
+}
+
+func (c *chain) GetMinBlkInterval() time.Duration {
+	return time.Duration(c.slot) * time.Second
+}
+
+func (c *chain) GetMaxBlkCreateTime() time.Duration {
+	return time.Duration(c.slot) * time.Second / 2
+}
+
+func (c *chain) IsReady() bool {
+	return true
+}
+
+func (c *chain) SetReady(bool) {}
+
+func (c *chain) GetActiveShardNumber() int {
+	return 0
+}
+
+func (c *chain) CurrentHeight() uint64 {
+	return c.GetBestView().GetHeight()
+}
+
+func (c *chain) GetCommitteeSize() int {
+	return len(c.committee)
+}
+
+func (c *chain) IsBeaconChain() bool {
+	return true
+}
+
+func (c *chain) GetCommittee() []incognitokey.CommitteePublicKey {
+	return append([]incognitokey.CommitteePublicKey{}, c.committee...)
+}
+
+func (c *chain) GetPendingCommittee() []incognitokey.CommitteePublicKey {
+	return nil
+}
+
+func (c *chain) GetPubKeyCommitteeIndex(pubKey string) int {
+	for i, v := range c.committee {
+		if v.GetMiningKeyBase58(common.BlsConsensus) == pubKey {
+			return i
+		}
+	}
+	return -1
+}
+
+func (c *chain) GetLastProposerIndex() int {
+	return -1
+}
+
+func (c *chain) UnmarshalBlock(blockString []byte) (types.BlockInterface, error) {
+	block := types.NewBeaconBlock()
+	if err := json.Unmarshal(blockString, block); err != nil {
+		return nil, err
+	}
+	return block, nil
+}
+
+func (c *chain) CreateNewBlock(
+	version int,
+	proposer string,
+	round int,
+	startTime int64,
+	committees []incognitokey.CommitteePublicKey,
+	hash common.Hash,
+) (types.BlockInterface, error) {
+	bestView := c.GetBestView()
+	height := bestView.GetHeight() + 1
+	block := types.NewBeaconBlock()
+	block.Header = types.BeaconHeader{
+		Version:           version,
+		Height:            height,
+		Epoch:             1,
+		Round:             round,
+		Timestamp:         startTime,
+		PreviousBlockHash: *bestView.GetHash(),
+		ConsensusType:     common.BlsConsensus,
+		Producer:          proposer,
+		Proposer:          proposer,
+		ProposeTime:       startTime,
+	}
+	block.Body.Instructions = [][]string{{SimInstruction, c.node.Name, strconv.FormatUint(height, 10)}}
+	block.Header.InstructionHash = common.HashH([]byte(fmt.Sprintf("%v", block.Body.Instructions)))
+	return block, nil
+}
+
+func (c *chain) CreateNewBlockFromOldBlock(oldBlock types.BlockInterface, proposer string, startTime int64, isValidRePropose bool) (types.BlockInterface, error) {
+	b, _ := json.Marshal(oldBlock)
+	newBlock := types.NewBeaconBlock()
+	if err := json.Unmarshal(b, newBlock); err != nil {
+		return nil, err
+	}
+	newBlock.Header.Proposer = proposer
+	newBlock.Header.ProposeTime = startTime
+	newBlock.Header.FinalityHeight = 0
+	return newBlock, nil
+}
+
+// InsertBlock adds the block to the multiview after checking the aggregated signature of the committee
+func (c *chain) InsertBlock(block types.BlockInterface, shouldValidate bool) error {
+	beaconBlock, ok := block.(*types.BeaconBlock)
+	if !ok {
+		return errors.New("not a beacon block")
+	}
+	hash := *beaconBlock.Hash()
+	if _, ok := c.blocks[hash]; ok {
+		return nil
+	}
+	if c.multiView.GetViewByHash(beaconBlock.Header.PreviousBlockHash) == nil {
+		return fmt.Errorf("cannot find previous view %v of block %v", beaconBlock.Header.PreviousBlockHash.String(), hash.String())
+	}
+	if shouldValidate {
+		if err := blsbft.ValidateCommitteeSig(beaconBlock, c.committee, len(c.committee)); err != nil {
+			return err
+		}
+	}
+	c.blocks[hash] = beaconBlock
+	if _, err := c.multiView.AddView(newView(beaconBlock, c.committee, c.slot)); err != nil {
+		return err
+	}
+	c.node.updateFinality()
+	return nil
+}
+
+func (c *chain) InsertAndBroadcastBlock(block types.BlockInterface) error {
+	if err := c.InsertBlock(block, true); err != nil {
+		return err
+	}
+	return c.node.PushBlockToAll(block, "", true)
+}
+
+func (c *chain) InsertWithPrevValidationData(block types.BlockInterface, previousValidationData string) error {
+	return c.InsertBlock(block, true)
+}
+
+func (c *chain) InsertAndBroadcastBlockWithPrevValidationData(block types.BlockInterface, previousValidationData string) error {
+	if err := c.InsertBlock(block, true); err != nil {
+		return err
+	}
+	return c.node.PushBlockToAll(block, previousValidationData, true)
+}
+
+// ValidatePreSignBlock checks the block links to a known view, is proposed by the proposer of its timeslot
+// and is signed by this proposer
+func (c *chain) ValidatePreSignBlock(block types.BlockInterface, signingCommittees, committees []incognitokey.CommitteePublicKey) error {
+	previousView := c.GetViewByHash(block.GetPrevHash())
+	if previousView == nil {
+		return errors.New("cannot find previous view")
+	}
+	if block.GetHeight() != previousView.GetHeight()+1 {
+		return fmt.Errorf("expect height %v, got %v", previousView.GetHeight()+1, block.GetHeight())
+	}
+	proposeTimeSlot := previousView.CalculateTimeSlot(block.GetProposeTime())
+	if proposeTimeSlot <= previousView.CalculateTimeSlot(previousView.GetBlock().GetProposeTime()) {
+		return errors.New("propose time slot is not after previous block")
+	}
+	proposer, _ := c.GetProposerByTimeSlotFromCommitteeList(proposeTimeSlot, committees)
+	if b58Str, _ := proposer.ToBase58(); b58Str != block.GetProposer() {
+		return fmt.Errorf("%v is not proposer of time slot %v", block.GetProposer(), proposeTimeSlot)
+	}
+	producer, _ := c.GetProposerByTimeSlotFromCommitteeList(previousView.CalculateTimeSlot(block.GetProduceTime()), committees)
+	if b58Str, _ := producer.ToBase58(); b58Str != block.GetProducer() {
+		return fmt.Errorf("%v is not producer of block", block.GetProducer())
+	}
+	return blsbft.ValidateProducerSigV2(block)
+}
+
+func (c *chain) GetShardID() int {
+	return -1
+}
+
+func (c *chain) GetChainDatabase() incdb.Database {
+	return c.node.db
+}
+
+func (c *chain) GetBestViewHeight() uint64 {
+	return c.GetBestView().GetHeight()
+}
+
+func (c *chain) GetFinalViewHeight() uint64 {
+	return c.GetFinalView().GetHeight()
+}
+
+func (c *chain) GetBestViewHash() string {
+	return c.GetBestView().GetHash().String()
+}
+
+func (c *chain) GetFinalViewHash() string {
+	return c.GetFinalView().GetHash().String()
+}
+
+func (c *chain) GetViewByHash(hash common.Hash) multiview.View {
+	return c.multiView.GetViewByHash(hash)
+}
+
+func (c *chain) CommitteeEngineVersion() int {
+	return 0
+}
+
+func (c *chain) GetProposerByTimeSlotFromCommitteeList(ts int64, committees []incognitokey.CommitteePublicKey) (incognitokey.CommitteePublicKey, int) {
+	id := int(ts % int64(len(committees)))
+	return committees[id], id
+}
+
+func (c *chain) ReplacePreviousValidationData(previousBlockHash common.Hash, previousProposeBlockHash common.Hash, previousCommittee []incognitokey.CommitteePublicKey, newValidationData string) error {
+	return nil
+}
+
+func (c *chain) GetSigningCommittees(proposerIndex int, committees []incognitokey.CommitteePublicKey, blockVersion int) []incognitokey.CommitteePublicKey {
+	return append([]incognitokey.CommitteePublicKey{}, committees...)
+}
+
+func (c *chain) GetPortalParamsV4(beaconHeight uint64) portalv4.PortalParams {
+	return portalv4.PortalParams{}
+}
+
+func (c *chain) GetBlockByHash(hash common.Hash) (types.BlockInterface, error) {
+	block, ok := c.blocks[hash]
+	if !ok {
+		return nil, fmt.Errorf("cannot find block %v", hash.String())
+	}
+	return block, nil
+}
+
+func (c *chain) CollectTxs(view multiview.View) {}
+
+// CommitteesFromViewHashForShard implements blsbft.CommitteeChainHandler, the committee never changes
+func (c *chain) CommitteesFromViewHashForShard(committeeHash common.Hash, shardID byte) ([]incognitokey.CommitteePublicKey, error) {
+	return c.GetCommittee(), nil
+}
+
+func (c *chain) FinalView() multiview.View {
+	return c.GetFinalView()
+}
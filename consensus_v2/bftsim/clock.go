@@ -0,0 +1,32 @@
+package bftsim
+
+import "time"
+
+// Clock is the simulated time shared by all nodes, it only moves when the simulation advances it
+type Clock struct {
+	now time.Time
+}
+
+func NewClock(start time.Time) *Clock {
+	return &Clock{now: start}
+}
+
+func (c *Clock) Now() time.Time {
+	return c.now
+}
+
+func (c *Clock) set(t time.Time) {
+	if t.After(c.now) {
+		c.now = t
+	}
+}
+
+// nodeClock is the clock seen by a node, it can drift from the simulated time by a fixed skew
+type nodeClock struct {
+	clock *Clock
+	skew  time.Duration
+}
+
+func (c nodeClock) Now() time.Time {
+	return c.clock.Now().Add(c.skew)
+}
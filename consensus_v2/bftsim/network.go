@@ -0,0 +1,165 @@
+package bftsim
+
+import (
+	"container/heap"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/incognitochain/incognito-chain/blockchain/types"
+	"github.com/incognitochain/incognito-chain/consensus_v2/blsbft"
+	"github.com/incognitochain/incognito-chain/wire"
+)
+
+// NetworkConfig describes the faults of the simulated network
+type NetworkConfig struct {
+	// every message is delayed by a latency in [MinLatency, MaxLatency]
+	MinLatency time.Duration
+	MaxLatency time.Duration
+	// probability that a message is lost
+	DropRate float64
+	// probability that a message is held back for another MaxLatency, so it arrives after messages sent later
+	ReorderRate float64
+}
+
+const (
+	eventBFTMessage = iota
+	eventBlock
+	eventSync
+)
+
+type event struct {
+	at   time.Time
+	key  string
+	from int
+	to   int
+	kind int
+	msg  *wire.MessageBFT
+	data []byte
+}
+
+// eventQueue orders events by delivery time, then by a key derived from the content,
+// so the delivery order does not depend on the order messages are sent in the same instant
+type eventQueue []*event
+
+func (q eventQueue) Len() int { return len(q) }
+
+func (q eventQueue) Less(i, j int) bool {
+	if !q[i].at.Equal(q[j].at) {
+		return q[i].at.Before(q[j].at)
+	}
+	if q[i].key != q[j].key {
+		return q[i].key < q[j].key
+	}
+	return q[i].to < q[j].to
+}
+
+func (q eventQueue) Swap(i, j int) { q[i], q[j] = q[j], q[i] }
+
+func (q *eventQueue) Push(x interface{}) { *q = append(*q, x.(*event)) }
+
+func (q *eventQueue) Pop() interface{} {
+	old := *q
+	e := old[len(old)-1]
+	*q = old[:len(old)-1]
+	return e
+}
+
+type network struct {
+	seed      int64
+	config    NetworkConfig
+	clock     *Clock
+	queue     eventQueue
+	partition map[int]int // node index -> group, empty when the network is not partitioned
+	delivered uint64
+	dropped   uint64
+}
+
+func newNetwork(seed int64, config NetworkConfig, clock *Clock) *network {
+	return &network{
+		seed:      seed,
+		config:    config,
+		clock:     clock,
+		partition: make(map[int]int),
+	}
+}
+
+// random returns a number in [0, 1) derived from the seed and parts, so the fault applied to a message
+// only depends on the seed and the message
+func (n *network) random(parts ...string) float64 {
+	h := sha256.Sum256([]byte(fmt.Sprintf("%d|%s", n.seed, strings.Join(parts, "|"))))
+	return float64(binary.BigEndian.Uint64(h[:8])>>11) / float64(1<<53)
+}
+
+func (n *network) connected(from, to int) bool {
+	if from == to || len(n.partition) == 0 {
+		return true
+	}
+	return n.partition[from] == n.partition[to]
+}
+
+// send schedules the delivery of an event with the latency, drop and reorder faults of the network
+func (n *network) send(e *event) {
+	e.key = fmt.Sprintf("%s|%d", e.key, e.from)
+	e.at = n.clock.Now()
+	if e.from != e.to {
+		if !n.connected(e.from, e.to) {
+			n.dropped++
+			return
+		}
+		id := fmt.Sprintf("%s|%d|%d", e.key, e.to, e.at.UnixNano())
+		if n.random(id, "drop") < n.config.DropRate {
+			n.dropped++
+			return
+		}
+		latency := n.config.MinLatency
+		if n.config.MaxLatency > n.config.MinLatency {
+			latency += time.Duration(n.random(id, "latency") * float64(n.config.MaxLatency-n.config.MinLatency))
+		}
+		if n.random(id, "reorder") < n.config.ReorderRate {
+			latency += n.config.MaxLatency
+		}
+		e.at = e.at.Add(latency)
+	}
+	heap.Push(&n.queue, e)
+}
+
+// next pops the next event due before or at t, events to nodes that are partitioned at delivery time are dropped
+func (n *network) next(t time.Time) *event {
+	for len(n.queue) > 0 && !n.queue[0].at.After(t) {
+		e := heap.Pop(&n.queue).(*event)
+		if !n.connected(e.from, e.to) {
+			n.dropped++
+			continue
+		}
+		n.clock.set(e.at)
+		n.delivered++
+		return e
+	}
+	return nil
+}
+
+// messageKey identifies a consensus message by its content, signatures of bridge keys are not deterministic
+// and are not part of the key
+func messageKey(msg *wire.MessageBFT) string {
+	switch msg.Type {
+	case blsbft.MSG_PROPOSE:
+		propose := blsbft.BFTPropose{}
+		if err := json.Unmarshal(msg.Content, &propose); err == nil {
+			block := types.NewBeaconBlock()
+			if err := json.Unmarshal(propose.Block, block); err == nil {
+				return fmt.Sprintf("%s|%s", msg.Type, block.ProposeHash().String())
+			}
+		}
+	case blsbft.MSG_VOTE:
+		vote := blsbft.BFTVote{}
+		if err := json.Unmarshal(msg.Content, &vote); err == nil {
+			return fmt.Sprintf("%s|%s|%s|%s", msg.Type, vote.Phase, vote.BlockHash, vote.Validator)
+		}
+	}
+	h := sha256.Sum256(msg.Content)
+	return fmt.Sprintf("%s|%x", msg.Type, h)
+}
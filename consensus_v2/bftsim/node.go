@@ -0,0 +1,181 @@
+package bftsim
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/incognitochain/incognito-chain/blockchain/types"
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/consensus_v2/blsbft"
+	signatureschemes2 "github.com/incognitochain/incognito-chain/consensus_v2/signatureschemes"
+	"github.com/incognitochain/incognito-chain/dataaccessobject/rawdb_consensus"
+	"github.com/incognitochain/incognito-chain/incdb"
+	"github.com/incognitochain/incognito-chain/wire"
+	peer "github.com/libp2p/go-libp2p-peer"
+)
+
+// Node is a simulated beacon node running a blsbft actorV3 with one mining key.
+// It implements blsbft.NodeInterface on top of the simulated network
+type Node struct {
+	Index     int
+	Name      string
+	Validator int // committee index of the mining key
+	Byzantine bool
+
+	sim     *Simulation
+	peerID  peer.ID
+	key     signatureschemes2.MiningKey
+	db      incdb.Database
+	chain   *chain
+	actor   blsbft.SimulatedActor
+	stopped bool
+	logger  common.Logger
+
+	finalized   map[uint64]common.Hash
+	finalHeight uint64
+}
+
+// FinalHeight return the height of the last block finalized by this node
+func (n *Node) FinalHeight() uint64 {
+	return n.finalHeight
+}
+
+// FinalizedHash return the hash of the block finalized by this node at height
+func (n *Node) FinalizedHash(height uint64) (common.Hash, bool) {
+	hash, ok := n.finalized[height]
+	return hash, ok
+}
+
+func (n *Node) IsStopped() bool {
+	return n.stopped
+}
+
+func (n *Node) PushMessageToChain(msg wire.Message, chain common.ChainInterface) error {
+	msgBFT, ok := msg.(*wire.MessageBFT)
+	if !ok {
+		return fmt.Errorf("unexpected message %v", msg.MessageType())
+	}
+	if msgBFT.Type == blsbft.MSG_PROPOSE {
+		propose := blsbft.BFTPropose{}
+		if err := json.Unmarshal(msgBFT.Content, &propose); err == nil {
+			if block, err := n.chain.UnmarshalBlock(propose.Block); err == nil {
+				n.sim.recordProposal(block, n.chain.GetBestView().CalculateTimeSlot(block.GetProposeTime()))
+			}
+		}
+	}
+	n.sim.broadcast(n, &event{kind: eventBFTMessage, key: messageKey(msgBFT), msg: msgBFT})
+	return nil
+}
+
+func (n *Node) PushBlockToAll(block types.BlockInterface, previousValidationData string, isBeacon bool) error {
+	data, err := json.Marshal(block)
+	if err != nil {
+		return err
+	}
+	n.sim.broadcast(n, &event{kind: eventBlock, key: "block|" + block.Hash().String(), data: data})
+	return nil
+}
+
+func (n *Node) IsEnableMining() bool {
+	return true
+}
+
+func (n *Node) GetMiningKeys() string {
+	return ""
+}
+
+func (n *Node) GetPrivateKey() string {
+	return ""
+}
+
+func (n *Node) GetUserMiningState() (role string, chainID int) {
+	return common.CommitteeRole, common.BeaconChainID
+}
+
+// RequestMissingViewViaStream asks the peer to send its chain, the response goes through the network like a message
+func (n *Node) RequestMissingViewViaStream(peerID string, hashes [][]byte, fromCID int, chainName string) error {
+	peerNode := n.sim.nodeByPeerID(peerID)
+	if peerNode == nil {
+		return fmt.Errorf("unknown peer %v", peerID)
+	}
+	n.sim.network.send(&event{kind: eventSync, key: "sync|" + n.Name, from: peerNode.Index, to: n.Index})
+	return nil
+}
+
+func (n *Node) GetSelfPeerID() peer.ID {
+	return n.peerID
+}
+
+// use switches the global consensus database to the database of this node,
+// the actor keeps propose and vote history and slashing protection there
+func (n *Node) use() {
+	rawdb_consensus.SetConsensusDatabase(n.db)
+}
+
+// syncFrom inserts the blocks finalized by peer which this node does not have yet
+func (n *Node) syncFrom(peerNode *Node) {
+	missing := []*types.BeaconBlock{}
+	hash := *peerNode.chain.GetFinalView().GetHash()
+	for {
+		if _, ok := n.chain.blocks[hash]; ok {
+			break
+		}
+		block, ok := peerNode.chain.blocks[hash]
+		if !ok || block.GetHeight() <= n.finalHeight {
+			return
+		}
+		missing = append(missing, block)
+		hash = block.Header.PreviousBlockHash
+	}
+	for i := len(missing) - 1; i >= 0; i-- {
+		block, err := n.chain.UnmarshalBlock(mustMarshal(missing[i]))
+		if err != nil {
+			n.logger.Error(err)
+			return
+		}
+		if err := n.chain.InsertBlock(block, true); err != nil {
+			n.logger.Errorf("sync block %v from %v error %v", block.GetHeight(), peerNode.Name, err)
+			return
+		}
+	}
+}
+
+// updateFinality records the blocks newly finalized by this node and reports conflicts with other nodes
+func (n *Node) updateFinality() {
+	finalView := n.chain.GetFinalView()
+	if finalView.GetHeight() <= n.finalHeight {
+		if hash, ok := n.finalized[finalView.GetHeight()]; ok && hash != *finalView.GetHash() {
+			n.sim.reportViolation(n, finalView.GetHeight(), hash, *finalView.GetHash())
+		}
+		return
+	}
+	newFinalized := []*types.BeaconBlock{}
+	for hash := *finalView.GetHash(); ; {
+		block, ok := n.chain.blocks[hash]
+		if !ok || block.GetHeight() <= n.finalHeight {
+			break
+		}
+		newFinalized = append(newFinalized, block)
+		hash = block.Header.PreviousBlockHash
+	}
+	if len(newFinalized) == 0 {
+		return
+	}
+	if previousHash := newFinalized[len(newFinalized)-1].Header.PreviousBlockHash; previousHash != n.finalized[n.finalHeight] {
+		n.sim.reportViolation(n, n.finalHeight, n.finalized[n.finalHeight], previousHash)
+	}
+	for i := len(newFinalized) - 1; i >= 0; i-- {
+		block := newFinalized[i]
+		n.finalized[block.GetHeight()] = *block.Hash()
+		n.sim.recordFinalized(n, block)
+	}
+	n.finalHeight = finalView.GetHeight()
+}
+
+func mustMarshal(v interface{}) []byte {
+	data, err := json.Marshal(v)
+	if err != nil {
+		panic(err)
+	}
+	return data
+}
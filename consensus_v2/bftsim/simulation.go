@@ -0,0 +1,455 @@
+package bftsim
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/incognitochain/incognito-chain/blockchain/types"
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/consensus_v2/blsbft"
+	signatureschemes2 "github.com/incognitochain/incognito-chain/consensus_v2/signatureschemes"
+	"github.com/incognitochain/incognito-chain/consensus_v2/signatureschemes/blsmultisig"
+	"github.com/incognitochain/incognito-chain/consensus_v2/signatureschemes/bridgesig"
+	"github.com/incognitochain/incognito-chain/incdb"
+	_ "github.com/incognitochain/incognito-chain/incdb/lvdb"
+	"github.com/incognitochain/incognito-chain/incognitokey"
+	peer "github.com/libp2p/go-libp2p-peer"
+)
+
+const (
+	defaultSlotDuration     = 10 * time.Second
+	defaultTickInterval     = 200 * time.Millisecond
+	defaultCleanMemInterval = 10 * time.Second
+	startUnixTime           = 1600000000
+)
+
+// Config of a simulation, the whole run is determined by Seed
+type Config struct {
+	Seed int64
+	// size of the beacon committee, each validator is run by one node
+	Validators int
+	// committee indexes of byzantine validators, their mining key is also run by a second node
+	// with its own slashing protection database, so the validator proposes conflicting blocks and double votes
+	Byzantine    []int
+	SlotDuration time.Duration
+	// interval of the actor loop, as the ticker of actorV3
+	TickInterval time.Duration
+	// interval a node syncs finalized blocks from its best peer, default to SlotDuration
+	SyncInterval time.Duration
+	// the clock of each node drifts from the simulated time by a fixed skew in [-MaxClockSkew, MaxClockSkew]
+	MaxClockSkew time.Duration
+	Network      NetworkConfig
+	// directory of the consensus databases of the nodes, a temporary directory is used when empty
+	DataDir string
+	// consensus logs of all nodes, logs are disabled when nil
+	LogWriter io.Writer
+}
+
+// SafetyViolation is reported when two honest nodes finalize different blocks at the same height,
+// or a node finalizes a block which does not extend its previous final block
+type SafetyViolation struct {
+	Node   string
+	Height uint64
+	Hashes [2]common.Hash
+}
+
+func (v SafetyViolation) Error() string {
+	return fmt.Sprintf("node %v finalized %v at height %v, conflict with %v", v.Node, v.Hashes[1].String(), v.Height, v.Hashes[0].String())
+}
+
+// Simulation runs blsbft actorV3 of a beacon committee in process, over a simulated network with a simulated clock.
+// It requires config.Param() to be loaded.
+type Simulation struct {
+	config   Config
+	clock    *Clock
+	network  *network
+	nodes    []*Node
+	nodeByID map[string]*Node
+	dataDir  string
+	tempDir  bool
+
+	nextTick   time.Time
+	ticks      uint64
+	finalized  map[uint64]common.Hash
+	violations []SafetyViolation
+	// propose hashes sent by each proposer in each timeslot
+	proposals map[string]map[common.Hash]struct{}
+}
+
+func New(config Config) (*Simulation, error) {
+	if config.Validators <= 0 {
+		return nil, errors.New("number of validators must be positive")
+	}
+	if config.SlotDuration == 0 {
+		config.SlotDuration = defaultSlotDuration
+	}
+	if config.SlotDuration < time.Second || config.SlotDuration%time.Second != 0 {
+		return nil, errors.New("slot duration must be a number of seconds")
+	}
+	if config.TickInterval == 0 {
+		config.TickInterval = defaultTickInterval
+	}
+	if config.SyncInterval == 0 {
+		config.SyncInterval = config.SlotDuration
+	}
+	for _, idx := range config.Byzantine {
+		if idx < 0 || idx >= config.Validators {
+			return nil, fmt.Errorf("byzantine validator %v is not in committee", idx)
+		}
+	}
+
+	s := &Simulation{
+		config:    config,
+		nodeByID:  make(map[string]*Node),
+		finalized: make(map[uint64]common.Hash),
+		proposals: make(map[string]map[common.Hash]struct{}),
+		dataDir:   config.DataDir,
+	}
+	if s.dataDir == "" {
+		dir, err := ioutil.TempDir("", "bftsim")
+		if err != nil {
+			return nil, err
+		}
+		s.dataDir = dir
+		s.tempDir = true
+	}
+
+	start := time.Unix(startUnixTime, 0).Truncate(config.SlotDuration)
+	s.clock = NewClock(start.Add(config.SlotDuration))
+	s.nextTick = s.clock.Now()
+	s.network = newNetwork(config.Seed, config.Network, s.clock)
+
+	keys := make([]signatureschemes2.MiningKey, config.Validators)
+	committee := make([]incognitokey.CommitteePublicKey, config.Validators)
+	for i := range keys {
+		keys[i] = newMiningKey(config.Seed, i)
+		committee[i] = *keys[i].GetPublicKey()
+	}
+	genesis := newGenesisBlock(types.INSTANT_FINALITY_VERSION_V2, start.Unix())
+	s.finalized[genesis.GetHeight()] = *genesis.Hash()
+
+	byzantine := make(map[int]bool)
+	for _, idx := range config.Byzantine {
+		byzantine[idx] = true
+	}
+	validators := []int{}
+	for i := 0; i < config.Validators; i++ {
+		validators = append(validators, i)
+	}
+	validators = append(validators, config.Byzantine...)
+	for _, validator := range validators {
+		if _, err := s.addNode(validator, byzantine[validator], keys[validator], genesis, committee); err != nil {
+			s.Close()
+			return nil, err
+		}
+	}
+	return s, nil
+}
+
+func newMiningKey(seed int64, idx int) signatureschemes2.MiningKey {
+	privateSeed := sha256.Sum256([]byte(fmt.Sprintf("bftsim|%d|%d", seed, idx)))
+	miningKey := signatureschemes2.MiningKey{
+		PriKey: map[string][]byte{},
+		PubKey: map[string][]byte{},
+	}
+	blsPriKey, blsPubKey := blsmultisig.KeyGen(privateSeed[:])
+	miningKey.PriKey[common.BlsConsensus] = blsmultisig.SKBytes(blsPriKey)
+	miningKey.PubKey[common.BlsConsensus] = blsmultisig.PKBytes(blsPubKey)
+	bridgePriKey, bridgePubKey := bridgesig.KeyGen(privateSeed[:])
+	miningKey.PriKey[common.BridgeConsensus] = bridgesig.SKBytes(&bridgePriKey)
+	miningKey.PubKey[common.BridgeConsensus] = bridgesig.PKBytes(&bridgePubKey)
+	return miningKey
+}
+
+func (s *Simulation) addNode(
+	validator int, isByzantine bool, key signatureschemes2.MiningKey,
+	genesis *types.BeaconBlock, committee []incognitokey.CommitteePublicKey,
+) (*Node, error) {
+	idx := len(s.nodes)
+	n := &Node{
+		Index:     idx,
+		Name:      fmt.Sprintf("node-%d", idx),
+		Validator: validator,
+		Byzantine: isByzantine,
+		sim:       s,
+		key:       key,
+		finalized: map[uint64]common.Hash{genesis.GetHeight(): *genesis.Hash()},
+	}
+	n.finalHeight = genesis.GetHeight()
+	n.peerID = peer.ID(n.Name)
+	if s.config.LogWriter != nil {
+		n.logger = common.NewBackend(s.config.LogWriter).Logger(n.Name, false)
+	} else {
+		n.logger = common.NewBackend(nil).Logger(n.Name, true)
+	}
+	db, err := incdb.Open("leveldb", filepath.Join(s.dataDir, n.Name))
+	if err != nil {
+		return nil, err
+	}
+	n.db = db
+	n.chain = newChain(n, genesis, committee, s.config.SlotDuration)
+
+	skew := time.Duration(0)
+	if s.config.MaxClockSkew > 0 {
+		skew = time.Duration((s.network.random(n.Name, "skew")*2 - 1) * float64(s.config.MaxClockSkew))
+	}
+	n.use()
+	n.actor = blsbft.NewSimulatedActorV3(
+		n.chain, n.chain, common.BeaconChainKey, types.INSTANT_FINALITY_VERSION_V2, common.BeaconChainID,
+		n, n.logger, nodeClock{clock: s.clock, skew: skew},
+	)
+	n.actor.LoadUserKeys([]signatureschemes2.MiningKey{key})
+	if err := n.actor.Start(); err != nil {
+		return nil, err
+	}
+	s.nodes = append(s.nodes, n)
+	s.nodeByID[n.peerID.String()] = n
+	return n, nil
+}
+
+// Close releases the databases of the nodes
+func (s *Simulation) Close() {
+	for _, n := range s.nodes {
+		n.db.Close()
+	}
+	if s.tempDir {
+		os.RemoveAll(s.dataDir)
+	}
+}
+
+func (s *Simulation) Now() time.Time {
+	return s.clock.Now()
+}
+
+func (s *Simulation) Nodes() []*Node {
+	return s.nodes
+}
+
+func (s *Simulation) nodeByPeerID(peerID string) *Node {
+	return s.nodeByID[peerID]
+}
+
+// SetNetwork changes the faults of the network, messages already sent keep their delivery time
+func (s *Simulation) SetNetwork(config NetworkConfig) {
+	s.network.config = config
+}
+
+// Partition splits nodes into groups which can not reach each other, nodes not in any group are isolated
+func (s *Simulation) Partition(groups ...[]int) {
+	s.network.partition = make(map[int]int)
+	for _, n := range s.nodes {
+		s.network.partition[n.Index] = -1 - n.Index
+	}
+	for i, group := range groups {
+		for _, idx := range group {
+			s.network.partition[idx] = i
+		}
+	}
+}
+
+// Heal removes the partition
+func (s *Simulation) Heal() {
+	s.network.partition = make(map[int]int)
+}
+
+// StopNode crashes a node, it does not run and loses all messages until it is started again
+func (s *Simulation) StopNode(idx int) {
+	s.nodes[idx].stopped = true
+}
+
+func (s *Simulation) StartNode(idx int) {
+	s.nodes[idx].stopped = false
+}
+
+// NetworkStats return number of delivered and dropped messages
+func (s *Simulation) NetworkStats() (delivered, dropped uint64) {
+	return s.network.delivered, s.network.dropped
+}
+
+// Run advances the simulation by d
+func (s *Simulation) Run(d time.Duration) {
+	s.RunUntil(nil, d)
+}
+
+// RunUntil advances the simulation until cond is true or max is elapsed, and return cond
+func (s *Simulation) RunUntil(cond func() bool, max time.Duration) bool {
+	end := s.clock.Now().Add(max)
+	for !s.nextTick.After(end) {
+		if cond != nil && cond() {
+			return true
+		}
+		s.step()
+	}
+	return cond != nil && cond()
+}
+
+// step delivers messages due before the next tick, then runs the actor loop of every node in order
+func (s *Simulation) step() {
+	t := s.nextTick
+	s.deliver(t)
+	s.clock.set(t)
+	cleanMem := s.isDue(defaultCleanMemInterval)
+	sync := s.isDue(s.config.SyncInterval)
+	for _, n := range s.nodes {
+		if n.stopped {
+			continue
+		}
+		n.use()
+		n.actor.Tick()
+		if cleanMem {
+			n.actor.CleanMem()
+		}
+		if sync {
+			if peerNode := s.bestPeer(n); peerNode != nil {
+				n.syncFrom(peerNode)
+			}
+		}
+		s.deliver(t)
+	}
+	s.ticks++
+	s.nextTick = t.Add(s.config.TickInterval)
+}
+
+func (s *Simulation) isDue(interval time.Duration) bool {
+	every := uint64(interval / s.config.TickInterval)
+	return s.ticks > 0 && every > 0 && s.ticks%every == 0
+}
+
+func (s *Simulation) deliver(t time.Time) {
+	for e := s.network.next(t); e != nil; e = s.network.next(t) {
+		n := s.nodes[e.to]
+		if n.stopped {
+			continue
+		}
+		n.use()
+		switch e.kind {
+		case eventBFTMessage:
+			n.actor.ProcessBFTMsg(e.msg)
+		case eventBlock:
+			block, err := n.chain.UnmarshalBlock(e.data)
+			if err != nil {
+				n.logger.Error(err)
+				continue
+			}
+			if err := n.chain.InsertBlock(block, true); err != nil {
+				n.logger.Debugf("insert block from %v error %v", s.nodes[e.from].Name, err)
+			}
+		case eventSync:
+			n.syncFrom(s.nodes[e.from])
+		}
+	}
+}
+
+func (s *Simulation) broadcast(from *Node, e *event) {
+	for _, n := range s.nodes {
+		s.network.send(&event{kind: e.kind, key: e.key, msg: e.msg, data: e.data, from: from.Index, to: n.Index})
+	}
+}
+
+// bestPeer return the reachable running peer with the highest final height above the node
+func (s *Simulation) bestPeer(n *Node) *Node {
+	var best *Node
+	for _, peerNode := range s.nodes {
+		if peerNode == n || peerNode.stopped || !s.network.connected(n.Index, peerNode.Index) {
+			continue
+		}
+		if peerNode.finalHeight > n.finalHeight && (best == nil || peerNode.finalHeight > best.finalHeight) {
+			best = peerNode
+		}
+	}
+	return best
+}
+
+func (s *Simulation) recordProposal(block types.BlockInterface, timeSlot int64) {
+	key := fmt.Sprintf("%v|%v", block.GetProposer(), timeSlot)
+	if s.proposals[key] == nil {
+		s.proposals[key] = make(map[common.Hash]struct{})
+	}
+	s.proposals[key][*block.ProposeHash()] = struct{}{}
+}
+
+// Equivocations return number of timeslots in which a proposer sent more than one block
+func (s *Simulation) Equivocations() int {
+	res := 0
+	for _, hashes := range s.proposals {
+		if len(hashes) > 1 {
+			res++
+		}
+	}
+	return res
+}
+
+func (s *Simulation) recordFinalized(n *Node, block *types.BeaconBlock) {
+	if n.Byzantine {
+		return
+	}
+	hash := *block.Hash()
+	if finalizedHash, ok := s.finalized[block.GetHeight()]; ok {
+		if finalizedHash != hash {
+			s.reportViolation(n, block.GetHeight(), finalizedHash, hash)
+		}
+		return
+	}
+	s.finalized[block.GetHeight()] = hash
+}
+
+func (s *Simulation) reportViolation(n *Node, height uint64, finalized, conflict common.Hash) {
+	if n.Byzantine {
+		return
+	}
+	s.violations = append(s.violations, SafetyViolation{Node: n.Name, Height: height, Hashes: [2]common.Hash{finalized, conflict}})
+}
+
+// CheckSafety return the first violation found, honest nodes must never finalize conflicting blocks
+func (s *Simulation) CheckSafety() error {
+	if len(s.violations) > 0 {
+		return s.violations[0]
+	}
+	return nil
+}
+
+// MinFinalHeight return the lowest final height of honest running nodes
+func (s *Simulation) MinFinalHeight() uint64 {
+	var res uint64
+	first := true
+	for _, n := range s.nodes {
+		if n.Byzantine || n.stopped {
+			continue
+		}
+		if first || n.finalHeight < res {
+			res = n.finalHeight
+			first = false
+		}
+	}
+	return res
+}
+
+// CheckLiveness runs the simulation until every honest running node finalizes height, or return error after max
+func (s *Simulation) CheckLiveness(height uint64, max time.Duration) error {
+	if !s.RunUntil(func() bool { return s.MinFinalHeight() >= height }, max) {
+		return fmt.Errorf("final height %v has not reached %v after %v", s.MinFinalHeight(), height, max)
+	}
+	return nil
+}
+
+// Hash of the finalized chain, two runs with the same config must have the same hash
+func (s *Simulation) Hash() common.Hash {
+	data := []byte{}
+	for height := uint64(1); ; height++ {
+		hash, ok := s.finalized[height]
+		if !ok {
+			break
+		}
+		data = append(data, hash[:]...)
+	}
+	buf := make([]byte, 8)
+	binary.BigEndian.PutUint64(buf, uint64(len(s.finalized)))
+	return common.HashH(append(data, buf...))
+}
@@ -0,0 +1,202 @@
+package bftsim
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/incognitochain/incognito-chain/blockchain/types"
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/config"
+	"github.com/incognitochain/incognito-chain/dataaccessobject"
+)
+
+func TestMain(m *testing.M) {
+	config.AbortParam()
+	dataaccessobject.Logger.Init(common.NewBackend(nil).Logger("test", true))
+	os.Exit(m.Run())
+}
+
+func newTestSimulation(t *testing.T, cfg Config) *Simulation {
+	sim, err := New(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(sim.Close)
+	return sim
+}
+
+func TestSimulationHappyPath(t *testing.T) {
+	sim := newTestSimulation(t, Config{
+		Seed:       1,
+		Validators: 4,
+		Network:    NetworkConfig{MinLatency: 50 * time.Millisecond, MaxLatency: 300 * time.Millisecond},
+	})
+	if err := sim.CheckLiveness(6, 10*sim.config.SlotDuration); err != nil {
+		t.Fatal(err)
+	}
+	if err := sim.CheckSafety(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestSimulationLossyNetwork(t *testing.T) {
+	sim := newTestSimulation(t, Config{
+		Seed:         2,
+		Validators:   4,
+		MaxClockSkew: 300 * time.Millisecond,
+		Network: NetworkConfig{
+			MinLatency:  50 * time.Millisecond,
+			MaxLatency:  time.Second,
+			DropRate:    0.1,
+			ReorderRate: 0.2,
+		},
+	})
+	if err := sim.CheckLiveness(6, 30*sim.config.SlotDuration); err != nil {
+		t.Fatal(err)
+	}
+	if err := sim.CheckSafety(); err != nil {
+		t.Fatal(err)
+	}
+	if _, dropped := sim.NetworkStats(); dropped == 0 {
+		t.Fatal("expect dropped messages")
+	}
+}
+
+func TestSimulationPartition(t *testing.T) {
+	sim := newTestSimulation(t, Config{
+		Seed:       3,
+		Validators: 4,
+		Network:    NetworkConfig{MinLatency: 50 * time.Millisecond, MaxLatency: 300 * time.Millisecond},
+	})
+	if err := sim.CheckLiveness(3, 10*sim.config.SlotDuration); err != nil {
+		t.Fatal(err)
+	}
+
+	// no side has more than 2/3 of the committee
+	sim.Partition([]int{0, 1}, []int{2, 3})
+	sim.Run(sim.config.SlotDuration)
+	height := sim.MinFinalHeight()
+	sim.Run(10 * sim.config.SlotDuration)
+	for _, n := range sim.Nodes() {
+		if n.FinalHeight() > height+1 {
+			t.Fatalf("%v finalized %v during partition, expect at most %v", n.Name, n.FinalHeight(), height+1)
+		}
+	}
+	if err := sim.CheckSafety(); err != nil {
+		t.Fatal(err)
+	}
+
+	sim.Heal()
+	if err := sim.CheckLiveness(sim.MinFinalHeight()+3, 20*sim.config.SlotDuration); err != nil {
+		t.Fatal(err)
+	}
+	if err := sim.CheckSafety(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestSimulationIsolatedNodeCatchUp(t *testing.T) {
+	sim := newTestSimulation(t, Config{
+		Seed:       4,
+		Validators: 4,
+		Network:    NetworkConfig{MinLatency: 50 * time.Millisecond, MaxLatency: 300 * time.Millisecond},
+	})
+	sim.Partition([]int{0, 1, 2}, []int{3})
+	sim.Run(12 * sim.config.SlotDuration)
+	if h := sim.Nodes()[0].FinalHeight(); h < 5 {
+		t.Fatalf("majority final height %v, expect at least 5", h)
+	}
+	if h := sim.Nodes()[3].FinalHeight(); h != 1 {
+		t.Fatalf("isolated node final height %v, expect 1", h)
+	}
+
+	sim.Heal()
+	target := sim.Nodes()[0].FinalHeight() + 2
+	if err := sim.CheckLiveness(target, 10*sim.config.SlotDuration); err != nil {
+		t.Fatal(err)
+	}
+	if err := sim.CheckSafety(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestSimulationCrashedValidator(t *testing.T) {
+	sim := newTestSimulation(t, Config{
+		Seed:       5,
+		Validators: 4,
+		Network:    NetworkConfig{MinLatency: 50 * time.Millisecond, MaxLatency: 300 * time.Millisecond},
+	})
+	sim.StopNode(1)
+	if err := sim.CheckLiveness(5, 20*sim.config.SlotDuration); err != nil {
+		t.Fatal(err)
+	}
+	sim.StartNode(1)
+	if err := sim.CheckLiveness(sim.Nodes()[0].FinalHeight()+1, 10*sim.config.SlotDuration); err != nil {
+		t.Fatal(err)
+	}
+	if err := sim.CheckSafety(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestSimulationByzantineValidator(t *testing.T) {
+	sim := newTestSimulation(t, Config{
+		Seed:       6,
+		Validators: 4,
+		Byzantine:  []int{0},
+		Network: NetworkConfig{
+			MinLatency:  50 * time.Millisecond,
+			MaxLatency:  time.Second,
+			ReorderRate: 0.2,
+		},
+	})
+	if len(sim.Nodes()) != 5 {
+		t.Fatalf("expect 5 nodes, got %v", len(sim.Nodes()))
+	}
+	if err := sim.CheckLiveness(8, 40*sim.config.SlotDuration); err != nil {
+		t.Fatal(err)
+	}
+	if sim.Equivocations() == 0 {
+		t.Fatal("expect byzantine validator to propose conflicting blocks")
+	}
+	if err := sim.CheckSafety(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestSimulationDeterministic(t *testing.T) {
+	run := func() common.Hash {
+		sim := newTestSimulation(t, Config{
+			Seed:       7,
+			Validators: 4,
+			Network: NetworkConfig{
+				MinLatency:  50 * time.Millisecond,
+				MaxLatency:  2 * time.Second,
+				DropRate:    0.2,
+				ReorderRate: 0.2,
+			},
+		})
+		sim.Run(15 * sim.config.SlotDuration)
+		return sim.Hash()
+	}
+	if first, second := run(), run(); first != second {
+		t.Fatalf("runs with the same seed finalized different chains %v %v", first.String(), second.String())
+	}
+}
+
+func TestSimulationReportViolation(t *testing.T) {
+	sim := newTestSimulation(t, Config{Seed: 8, Validators: 4})
+	if err := sim.CheckLiveness(2, 5*sim.config.SlotDuration); err != nil {
+		t.Fatal(err)
+	}
+	finalized, _ := sim.Nodes()[0].FinalizedHash(2)
+	conflict := newGenesisBlock(types.INSTANT_FINALITY_VERSION_V2, sim.Now().Unix())
+	conflict.Header.Height = 2
+	sim.recordFinalized(sim.Nodes()[1], conflict)
+	err := sim.CheckSafety()
+	violation, ok := err.(SafetyViolation)
+	if !ok || violation.Height != 2 || violation.Hashes[0] != finalized {
+		t.Fatalf("expect violation at height 2, got %v", err)
+	}
+}
@@ -960,7 +960,7 @@ func (a *actorV2) voteValidBlock(
 			continue
 		}
 		if common.IndexOfStr(pubKey.GetMiningKeyBase58(a.GetConsensusName()), committeeBLSString) != -1 {
-			err := a.sendVote(&userKey, proposeBlockInfo.block, proposeBlockInfo.SigningCommittees, a.chain.GetPortalParamsV4(0))
+			err := a.sendVote(&userKey, proposeBlockInfo.block, proposeBlockInfo.SigningCommittees, a.chain.GetPortalParamsV4(proposeBlockInfo.block.GetBeaconHeight()))
 			if err != nil {
 				a.logger.Error(err)
 				return NewConsensusError(UnExpectedError, err)
@@ -1099,13 +1099,20 @@ func (a *actorV2) proposeShardBlock(
 func (a *actorV2) addValidationData(userMiningKey signatureschemes2.MiningKey, block types.BlockInterface) (types.BlockInterface, error) {
 
 	var validationData consensustypes.ValidationData
-	portalParam := a.chain.GetPortalParamsV4(0)
-	portalSigs, err := portalprocessv4.CheckAndSignPortalUnshieldExternalTx(userMiningKey.PriKey[common.BridgeConsensus], block.GetInstructions(), portalParam)
+	portalParam := a.chain.GetPortalParamsV4(block.GetBeaconHeight())
+	previousView := a.chain.GetViewByHash(block.GetPrevHash())
+	// the proposal is signed first, the signer only signs portal external txs of a proposed or voted block
+	signingCtx := newSigningContext(signatureschemes2.SigningKindPropose, previousView, block)
+	producerSig, err := briSignWithContext(&userMiningKey, signingCtx, block.ProposeHash().GetBytes())
+	if err != nil {
+		return block, err
+	}
+	validationData.ProducerBLSSig = producerSig
+	portalSigs, err := signPortalExternalTxs(&userMiningKey, previousView, block, portalParam)
 	if err != nil {
 		return block, NewConsensusError(UnExpectedError, err)
 	}
 	validationData.PortalSig = portalSigs
-	validationData.ProducerBLSSig, _ = userMiningKey.BriSignData(block.ProposeHash().GetBytes())
 	validationDataString, _ := consensustypes.EncodeValidationData(validationData)
 	block.(BlockValidation).AddValidationField(validationDataString)
 
@@ -1419,7 +1426,7 @@ func (a *actorV2) processVoteMessage(voteMsg BFTVote) error {
 					if err = a.validateBlock(a.chain.GetBestView().GetHeight(), proposeBlockInfo); err == nil && proposeBlockInfo.IsValid {
 						bestViewHeight := a.chain.GetBestView().GetHeight()
 						if proposeBlockInfo.block.GetHeight() == bestViewHeight+1 { // and if the propose block is still connected to bestview
-							err := a.sendVote(&userKey, proposeBlockInfo.block, proposeBlockInfo.SigningCommittees, a.chain.GetPortalParamsV4(0)) // => send vote
+							err := a.sendVote(&userKey, proposeBlockInfo.block, proposeBlockInfo.SigningCommittees, a.chain.GetPortalParamsV4(proposeBlockInfo.block.GetBeaconHeight())) // => send vote
 							if err != nil {
 								a.logger.Error(err)
 							} else {
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"github.com/incognitochain/incognito-chain/common"
 	"github.com/incognitochain/incognito-chain/config"
+	"github.com/incognitochain/incognito-chain/consensus_v2/consensustypes"
 	"github.com/incognitochain/incognito-chain/dataaccessobject/rawdb_consensus"
 	"github.com/incognitochain/incognito-chain/incdb"
 	"github.com/incognitochain/incognito-chain/incognitokey"
@@ -40,11 +41,21 @@ type ByzantineDetector struct {
 	blackList                    map[string]*rawdb_consensus.BlackListValidator // validator => reason for blacklist
 	voteInTimeSlot               map[string]map[int64]*BFTVote                  // validator => timeslot => vote
 	validRecentVote              map[string]*BFTVote
-	smallestBlockProduceTimeSlot map[string]map[uint64]*BFTVote // validator => height => timeslot
+	smallestBlockProduceTimeSlot map[string]map[uint64]*BFTVote                  // validator => height => timeslot
+	evidences                    map[string]*consensustypes.EquivocationEvidence // validator => latest double vote evidence
+	evidenceBroadcaster          func(evidence *consensustypes.EquivocationEvidence)
 	logger                       common.Logger
 	mu                           *sync.RWMutex
 }
 
+// SetEvidenceBroadcaster set the function used to gossip double vote evidence
+func (b *ByzantineDetector) SetEvidenceBroadcaster(broadcaster func(evidence *consensustypes.EquivocationEvidence)) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.evidenceBroadcaster = broadcaster
+}
+
 func (b *ByzantineDetector) SetFixedNodes(fixedNodes []incognitokey.CommitteePublicKey) {
 	blsKeys := make(map[string]bool)
 	for _, k := range fixedNodes {
@@ -68,6 +79,7 @@ func NewByzantineDetector(logger common.Logger) *ByzantineDetector {
 		voteInTimeSlot:               make(map[string]map[int64]*BFTVote),
 		smallestBlockProduceTimeSlot: make(map[string]map[uint64]*BFTVote),
 		validRecentVote:              make(map[string]*BFTVote),
+		evidences:                    make(map[string]*consensustypes.EquivocationEvidence),
 		mu:                           new(sync.RWMutex),
 	}
 }
@@ -81,6 +93,7 @@ func (b ByzantineDetector) GetByzantineDetectorInfo() map[string]interface{} {
 	voteInTimeSlot := make(map[string]map[int64]*BFTVote)
 	validRecentVote := make(map[string]*BFTVote)
 	smallestBlockProduceTimeSlot := make(map[string]map[uint64]*BFTVote)
+	evidences := make(map[string]*consensustypes.EquivocationEvidence)
 
 	for k, v := range b.blackList {
 		blackList[k] = v
@@ -94,12 +107,16 @@ func (b ByzantineDetector) GetByzantineDetectorInfo() map[string]interface{} {
 	for k, v := range b.validRecentVote {
 		validRecentVote[k] = v
 	}
+	for k, v := range b.evidences {
+		evidences[k] = v
+	}
 
 	m := map[string]interface{}{
 		"BlackList":                 blackList,
 		"VoteInTimeSlot":            voteInTimeSlot,
 		"BlockWithSmallestTimeSlot": smallestBlockProduceTimeSlot,
 		"ValidRecentVote":           validRecentVote,
+		"Evidence":                  evidences,
 	}
 
 	return m
@@ -137,6 +154,12 @@ func (b *ByzantineDetector) Validate(bestViewHeight uint64, vote *BFTVote) error
 		}
 	}
 
+	if err != nil {
+		if evidence := b.buildEvidence(vote); evidence != nil {
+			b.addEvidence(evidence)
+		}
+	}
+
 	b.addNewVote(rawdb_consensus.GetConsensusDatabase(), vote, err)
 
 	if config.Param().ConsensusParam.ByzantineDetectorHeight < bestViewHeight {
@@ -244,7 +267,7 @@ func (b ByzantineDetector) voteMoreThanOneTimesInATimeSlot(newVote *BFTVote) err
 		if !reflect.DeepEqual(vote, newVote) {
 			return fmt.Errorf("error name: %+v,"+
 				"first bftvote: %+v, latter bftvote: %+v",
-				ErrVoteForHigherTimeSlot, vote, newVote)
+				ErrDuplicateVoteInOneTimeSlot, vote, newVote)
 		}
 	}
 
@@ -344,3 +367,34 @@ func (b *ByzantineDetector) addNewVote(database incdb.Database, newVote *BFTVote
 
 	b.validRecentVote[newVote.Validator] = newVote
 }
+
+// buildEvidence find the stored vote which conflicts with newVote,
+// only votes which sign their height and timeslot could be used as evidence
+func (b *ByzantineDetector) buildEvidence(newVote *BFTVote) *consensustypes.EquivocationEvidence {
+	if newVote.BlockHeight < config.Param().ConsensusParam.ByzantineDetectorHeight {
+		return nil
+	}
+	if vote, ok := b.voteInTimeSlot[newVote.Validator][newVote.ProposeTimeSlot]; ok &&
+		vote.BlockHash != newVote.BlockHash && vote.Phase == newVote.Phase &&
+		vote.CommitteeFromBlock == newVote.CommitteeFromBlock && vote.ChainID == newVote.ChainID {
+		return consensustypes.NewEquivocationEvidence(consensustypes.EquivocationSameTimeSlot, vote.toEquivocationVote(), newVote.toEquivocationVote())
+	}
+	if vote, ok := b.smallestBlockProduceTimeSlot[newVote.Validator][newVote.BlockHeight]; ok &&
+		vote.BlockHash != newVote.BlockHash && vote.ProduceTimeSlot < newVote.ProduceTimeSlot &&
+		vote.ProposeTimeSlot < newVote.ProposeTimeSlot && vote.Phase == newVote.Phase &&
+		vote.CommitteeFromBlock == newVote.CommitteeFromBlock && vote.ChainID == newVote.ChainID {
+		return consensustypes.NewEquivocationEvidence(consensustypes.EquivocationSameHeight, vote.toEquivocationVote(), newVote.toEquivocationVote())
+	}
+	return nil
+}
+
+func (b *ByzantineDetector) addEvidence(evidence *consensustypes.EquivocationEvidence) {
+	if b.evidences == nil {
+		b.evidences = make(map[string]*consensustypes.EquivocationEvidence)
+	}
+	b.evidences[evidence.Validator()] = evidence
+	b.logger.Infof("Byzantine Detector found double vote evidence of %+v, reason %+v", evidence.Validator(), evidence.Reason)
+	if b.evidenceBroadcaster != nil {
+		go b.evidenceBroadcaster(evidence)
+	}
+}
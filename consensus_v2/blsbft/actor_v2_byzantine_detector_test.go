@@ -2,6 +2,8 @@ package blsbft
 
 import (
 	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/config"
+	"github.com/incognitochain/incognito-chain/consensus_v2/consensustypes"
 	"github.com/incognitochain/incognito-chain/dataaccessobject/rawdb_consensus"
 	"reflect"
 	"testing"
@@ -659,3 +661,144 @@ func TestByzantineDetector_voteForSmallerBlockHeight(t *testing.T) {
 		})
 	}
 }
+
+func TestByzantineDetector_buildEvidence(t *testing.T) {
+	config.AbortParam()
+	type fields struct {
+		timeslot                     map[string]map[int64]*BFTVote
+		smallestBlockProduceTimeSlot map[string]map[uint64]*BFTVote
+	}
+	type args struct {
+		bftVote *BFTVote
+	}
+	tests := []struct {
+		name       string
+		fields     fields
+		args       args
+		wantReason string
+	}{
+		{
+			name: "vote for different block in the same timeslot",
+			fields: fields{
+				timeslot: map[string]map[int64]*BFTVote{
+					blsKeys[0]: {
+						163394559: &BFTVote{
+							Validator:       blsKeys[0],
+							BlockHash:       "hash1",
+							BlockHeight:     10,
+							ProposeTimeSlot: 163394559,
+						},
+					},
+				},
+			},
+			args: args{
+				&BFTVote{
+					Validator:       blsKeys[0],
+					BlockHash:       "hash2",
+					BlockHeight:     10,
+					ProposeTimeSlot: 163394559,
+				},
+			},
+			wantReason: consensustypes.EquivocationSameTimeSlot,
+		},
+		{
+			name: "vote for block with higher produce timeslot at the same height",
+			fields: fields{
+				timeslot: map[string]map[int64]*BFTVote{},
+				smallestBlockProduceTimeSlot: map[string]map[uint64]*BFTVote{
+					blsKeys[0]: {
+						10: &BFTVote{
+							Validator:       blsKeys[0],
+							BlockHash:       "hash1",
+							BlockHeight:     10,
+							ProduceTimeSlot: 163394559,
+							ProposeTimeSlot: 163394559,
+						},
+					},
+				},
+			},
+			args: args{
+				&BFTVote{
+					Validator:       blsKeys[0],
+					BlockHash:       "hash2",
+					BlockHeight:     10,
+					ProduceTimeSlot: 163394560,
+					ProposeTimeSlot: 163394560,
+				},
+			},
+			wantReason: consensustypes.EquivocationSameHeight,
+		},
+		{
+			name: "vote for block with smaller produce timeslot at the same height",
+			fields: fields{
+				timeslot: map[string]map[int64]*BFTVote{},
+				smallestBlockProduceTimeSlot: map[string]map[uint64]*BFTVote{
+					blsKeys[0]: {
+						10: &BFTVote{
+							Validator:       blsKeys[0],
+							BlockHash:       "hash1",
+							BlockHeight:     10,
+							ProduceTimeSlot: 163394560,
+							ProposeTimeSlot: 163394560,
+						},
+					},
+				},
+			},
+			args: args{
+				&BFTVote{
+					Validator:       blsKeys[0],
+					BlockHash:       "hash2",
+					BlockHeight:     10,
+					ProduceTimeSlot: 163394559,
+					ProposeTimeSlot: 163394561,
+				},
+			},
+			wantReason: "",
+		},
+		{
+			name: "vote for the same block again",
+			fields: fields{
+				timeslot: map[string]map[int64]*BFTVote{
+					blsKeys[0]: {
+						163394559: &BFTVote{
+							Validator:       blsKeys[0],
+							BlockHash:       "hash1",
+							BlockHeight:     10,
+							ProposeTimeSlot: 163394559,
+						},
+					},
+				},
+			},
+			args: args{
+				&BFTVote{
+					Validator:       blsKeys[0],
+					BlockHash:       "hash1",
+					BlockHeight:     10,
+					ProposeTimeSlot: 163394559,
+				},
+			},
+			wantReason: "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := &ByzantineDetector{
+				voteInTimeSlot:               tt.fields.timeslot,
+				smallestBlockProduceTimeSlot: tt.fields.smallestBlockProduceTimeSlot,
+			}
+			got := b.buildEvidence(tt.args.bftVote)
+			if tt.wantReason == "" {
+				if got != nil {
+					t.Errorf("buildEvidence() = %+v, want nil", got)
+				}
+				return
+			}
+			if got == nil || got.Reason != tt.wantReason {
+				t.Fatalf("buildEvidence() = %+v, want reason %+v", got, tt.wantReason)
+			}
+			if err := got.VerifyConflict(); err != nil {
+				t.Errorf("VerifyConflict() error = %v", err)
+			}
+		})
+	}
+}
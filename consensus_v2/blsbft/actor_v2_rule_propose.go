@@ -376,8 +376,7 @@ func GetProposerByTimeSlot(ts int64, committeeLen int) int {
 
 func (p ProposeRuleLemma2) CreateProposeBFTMessage(env *SendProposeBlockEnvironment, block types.BlockInterface) (*BFTPropose, error) {
 
-	reProposeHashSignature, err := createReProposeHashSignature(p.chain,
-		env.userProposeKey.PriKey[common.BridgeConsensus], block)
+	reProposeHashSignature, err := createReProposeHashSignature(p.chain, &env.userProposeKey, block)
 
 	if err != nil {
 		return nil, err
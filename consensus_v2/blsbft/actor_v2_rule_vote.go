@@ -10,7 +10,6 @@ import (
 	"github.com/incognitochain/incognito-chain/incognitokey"
 	"github.com/incognitochain/incognito-chain/metadata"
 	"github.com/incognitochain/incognito-chain/portal/portalv4"
-	portalprocessv4 "github.com/incognitochain/incognito-chain/portal/portalv4/portalprocess"
 )
 
 type VoteMessageEnvironment struct {
@@ -122,26 +121,29 @@ func CreateVote(
 		bytelist = append(bytelist, v.MiningPubKey[common.BlsConsensus])
 	}
 
-	blsSig, err := userKey.BLSSignData(block.ProposeHash().GetBytes(), selfIdx, bytelist)
+	previousView := chain.GetViewByHash(block.GetPrevHash())
+	signingCtx := newSigningContext(signatureschemes2.SigningKindVote, previousView, block)
+	blsSig, err := blsSignVote(userKey, signingCtx, block.ProposeHash().GetBytes(), selfIdx, bytelist)
 	if err != nil {
-		return nil, NewConsensusError(UnExpectedError, err)
+		return nil, err
 	}
 
 	bridgeSig := []byte{}
 	if metadata.HasBridgeInstructions(block.GetInstructions()) {
-		bridgeSig, err = userKey.BriSignData(block.Hash().GetBytes()) //proof is agg sig on block hash (not propose hash)
+		bridgeSigningCtx := newSigningContext(signatureschemes2.SigningKindBridgeVote, previousView, block)
+		bridgeSigningCtx.DataHash = block.Hash().String()
+		bridgeSig, err = briSignWithContext(userKey, bridgeSigningCtx, block.Hash().GetBytes()) //proof is agg sig on block hash (not propose hash)
 		if err != nil {
-			return nil, NewConsensusError(UnExpectedError, err)
+			return nil, err
 		}
 	}
 
 	// check and sign on unshielding external tx for Portal v4
-	portalSigs, err := portalprocessv4.CheckAndSignPortalUnshieldExternalTx(userKey.PriKey[common.BridgeConsensus], block.GetInstructions(), portalParamsV4)
+	portalSigs, err := signPortalExternalTxs(userKey, previousView, block, portalParamsV4)
 	if err != nil {
 		return nil, NewConsensusError(UnExpectedError, err)
 	}
 
-	previousView := chain.GetViewByHash(block.GetPrevHash())
 	vote.BLS = blsSig
 	vote.BRI = bridgeSig
 	vote.PortalSigs = portalSigs
@@ -14,7 +14,6 @@ import (
 	"github.com/incognitochain/incognito-chain/dataaccessobject/rawdb_consensus"
 	"github.com/incognitochain/incognito-chain/incognitokey"
 	"github.com/incognitochain/incognito-chain/metrics/monitor"
-	portalprocessv4 "github.com/incognitochain/incognito-chain/portal/portalv4/portalprocess"
 	"github.com/incognitochain/incognito-chain/wire"
 	"log"
 	"sort"
@@ -50,10 +49,14 @@ type actorV3 struct {
 	blockVersion int
 
 	currentBestViewHeight uint64
+
+	clock Clock
+	// simulated actor is stepped by the caller instead of its own run loop, see NewSimulatedActorV3
+	simulated bool
 }
 
 func NewActorV3() *actorV3 {
-	return &actorV3{}
+	return &actorV3{clock: systemClock{}}
 }
 
 func NewActorV3WithValue(
@@ -283,6 +286,10 @@ func (a *actorV3) ProcessBFTMsg(msgBFT *wire.MessageBFT) {
 			return
 		}
 		msgPropose.PeerID = msgBFT.PeerID
+		if a.simulated {
+			a.processProposeMsg(msgPropose)
+			return
+		}
 		a.proposeMessageCh <- msgPropose
 	case MSG_VOTE:
 		var msgVote BFTVote
@@ -291,6 +298,10 @@ func (a *actorV3) ProcessBFTMsg(msgBFT *wire.MessageBFT) {
 			a.logger.Error(err)
 			return
 		}
+		if a.simulated {
+			a.processVoteMsg(msgVote)
+			return
+		}
 		a.voteMessageCh <- msgVote
 	default:
 		a.logger.Criticalf("Unknown BFT message type %+v", msgBFT)
@@ -432,13 +443,20 @@ func (a *actorV3) createBLSAggregatedSignatures(
 func (a *actorV3) addValidationData(userMiningKey signatureschemes2.MiningKey, block types.BlockInterface) (types.BlockInterface, error) {
 
 	var validationData consensustypes.ValidationData
-	portalParam := a.chain.GetPortalParamsV4(0)
-	portalSigs, err := portalprocessv4.CheckAndSignPortalUnshieldExternalTx(userMiningKey.PriKey[common.BridgeConsensus], block.GetInstructions(), portalParam)
+	portalParam := a.chain.GetPortalParamsV4(block.GetBeaconHeight())
+	previousView := a.chain.GetViewByHash(block.GetPrevHash())
+	// the proposal is signed first, the signer only signs portal external txs of a proposed or voted block
+	signingCtx := newSigningContext(signatureschemes2.SigningKindPropose, previousView, block)
+	producerSig, err := briSignWithContext(&userMiningKey, signingCtx, block.ProposeHash().GetBytes())
+	if err != nil {
+		return block, err
+	}
+	validationData.ProducerBLSSig = producerSig
+	portalSigs, err := signPortalExternalTxs(&userMiningKey, previousView, block, portalParam)
 	if err != nil {
 		return block, NewConsensusError(UnExpectedError, err)
 	}
 	validationData.PortalSig = portalSigs
-	validationData.ProducerBLSSig, _ = userMiningKey.BriSignData(block.ProposeHash().GetBytes())
 	validationDataString, _ := consensustypes.EncodeValidationData(validationData)
 	block.(BlockValidation).AddValidationField(validationDataString)
 
@@ -450,8 +468,8 @@ func (a *actorV3) sendBFTProposeMsg(
 ) error {
 
 	msg, _ := a.makeBFTProposeMsg(bftPropose, a.chainKey, a.currentTimeSlot)
-	go a.ProcessBFTMsg(msg.(*wire.MessageBFT))
-	go a.node.PushMessageToChain(msg, a.chain)
+	a.spawn(func() { a.ProcessBFTMsg(msg.(*wire.MessageBFT)) })
+	a.spawn(func() { a.node.PushMessageToChain(msg, a.chain) })
 
 	return nil
 }
@@ -558,7 +576,7 @@ func (a *actorV3) handleCleanMem() {
 
 	for h, proposeBlk := range a.receiveBlockByHash {
 		if proposeBlk.block == nil ||
-			(time.Now().Sub(proposeBlk.ReceiveTime) > time.Minute && len(proposeBlk.PreVotes) <= 2*len(proposeBlk.SigningCommittees)/3) ||
+			(a.clock.Now().Sub(proposeBlk.ReceiveTime) > time.Minute && len(proposeBlk.PreVotes) <= 2*len(proposeBlk.SigningCommittees)/3) ||
 			proposeBlk.block.GetHeight() < a.chain.GetFinalViewHeight()-2 {
 			if err := a.CleanReceiveBlockByHash(h); err != nil {
 				a.logger.Errorf("clean receive block by hash error %+v", err)
@@ -590,7 +608,7 @@ func (a *actorV3) makeBFTProposeMsg(
 	msg.(*wire.MessageBFT).Content = proposeCtnBytes
 	msg.(*wire.MessageBFT).Type = MSG_PROPOSE
 	msg.(*wire.MessageBFT).TimeSlot = ts
-	msg.(*wire.MessageBFT).Timestamp = time.Now().UnixNano() / int64(time.Millisecond)
+	msg.(*wire.MessageBFT).Timestamp = a.clock.Now().UnixNano() / int64(time.Millisecond)
 	msg.(*wire.MessageBFT).PeerID = proposeCtn.PeerID
 	return msg, nil
 }
@@ -605,7 +623,7 @@ func (a *actorV3) makeBFTVoteMsg(vote *BFTVote, chainKey string, ts int64, heigh
 	msg.(*wire.MessageBFT).Content = voteCtnBytes
 	msg.(*wire.MessageBFT).Type = MSG_VOTE
 	msg.(*wire.MessageBFT).TimeSlot = ts
-	msg.(*wire.MessageBFT).Timestamp = time.Now().UnixNano() / int64(time.Millisecond)
+	msg.(*wire.MessageBFT).Timestamp = a.clock.Now().UnixNano() / int64(time.Millisecond)
 	return msg, nil
 }
 
@@ -646,113 +664,131 @@ func (a *actorV3) run() error {
 				close(a.destroyCh)
 				return
 			case proposeMsg := <-a.proposeMessageCh:
-				if ActorRuleBuilderContext.HandleProposeRule != HANDLE_PROPOSE_MESSAGE_NORMAL {
-					continue
-				}
-				err := a.handleProposeMsg(proposeMsg)
-				if err != nil {
-					a.logger.Error(err)
-					continue
-				}
+				a.processProposeMsg(proposeMsg)
 
 			case voteMsg := <-a.voteMessageCh:
-				if ActorRuleBuilderContext.HandleVoteRule != HANDLE_VOTE_MESSAGE_COLLECT {
-					continue
-				}
-				switch voteMsg.Phase {
-				case "prevote":
-					err := a.handlePreVoteMsg(voteMsg)
-					if err != nil {
-						a.logger.Error(err)
-						continue
-					}
-				case "vote":
-					err := a.handleVoteMsg(voteMsg)
-					if err != nil {
-						a.logger.Error(err)
-						continue
-					}
-				default:
-					a.logger.Error("Cannot find vote type!")
-				}
+				a.processVoteMsg(voteMsg)
 
 			case <-cleanMemTicker:
 				a.handleCleanMem()
 				continue
 
 			case <-ticker:
-				if !a.chain.IsReady() {
-					continue
-				}
-				bestView := a.chain.GetBestView()
-				a.currentTime = time.Now().Unix()
-				currentTimeSlot := bestView.CalculateTimeSlot(a.currentTime)
+				a.tick()
+			}
+		}
+	}()
+	return nil
+}
 
-				newTimeSlot := false
-				if a.currentTimeSlot != currentTimeSlot {
-					newTimeSlot = true
-				}
+func (a *actorV3) processProposeMsg(proposeMsg BFTPropose) {
+	if ActorRuleBuilderContext.HandleProposeRule != HANDLE_PROPOSE_MESSAGE_NORMAL {
+		return
+	}
+	err := a.handleProposeMsg(proposeMsg)
+	if err != nil {
+		a.logger.Error(err)
+	}
+}
 
-				a.currentTimeSlot = currentTimeSlot
+func (a *actorV3) processVoteMsg(voteMsg BFTVote) {
+	if ActorRuleBuilderContext.HandleVoteRule != HANDLE_VOTE_MESSAGE_COLLECT {
+		return
+	}
+	switch voteMsg.Phase {
+	case "prevote":
+		err := a.handlePreVoteMsg(voteMsg)
+		if err != nil {
+			a.logger.Error(err)
+		}
+	case "vote":
+		err := a.handleVoteMsg(voteMsg)
+		if err != nil {
+			a.logger.Error(err)
+		}
+	default:
+		a.logger.Error("Cannot find vote type!")
+	}
+}
 
-				a.currentBestViewHeight = bestView.GetHeight()
+// tick runs one round of the actor loop: propose in new timeslot, validate received data, prevote, vote and commit
+func (a *actorV3) tick() {
+	if !a.chain.IsReady() {
+		return
+	}
+	bestView := a.chain.GetBestView()
+	a.currentTime = a.clock.Now().Unix()
+	currentTimeSlot := bestView.CalculateTimeSlot(a.currentTime)
 
-				//set round for monitor
-				round := a.currentTimeSlot - bestView.CalculateTimeSlot(bestView.GetBlock().GetProposeTime())
-				monitor.SetGlobalParam("RoundKey", fmt.Sprintf("%d_%d", bestView.GetHeight(), round))
+	newTimeSlot := false
+	if a.currentTimeSlot != currentTimeSlot {
+		newTimeSlot = true
+	}
 
-				if newTimeSlot {
-					a.logger.Info("")
-					a.logger.Info("======================================================")
-					if ActorRuleBuilderContext.CreateRule == CREATE_RULE_NORMAL {
-						err := a.maybeProposeBlock()
-						if err != nil {
-							a.logger.Error(err)
-						}
+	a.currentTimeSlot = currentTimeSlot
 
-					}
-				}
+	a.currentBestViewHeight = bestView.GetHeight()
 
-				//validatingreceived data: propose, prevote, vote
-				for _, proposeInfo := range a.receiveBlockByHash {
-					if ActorRuleBuilderContext.ValidatorRule == VALIDATOR_NO_VALIDATE {
-						break
-					}
-
-					//get propose info at current timeslot
-					if proposeInfo.block != nil && bestView.CalculateTimeSlot(proposeInfo.block.GetProposeTime()) == a.currentTimeSlot {
-						//validate the propose block
-						err := a.validateBlock(proposeInfo)
-						if err != nil {
-							a.logger.Errorf("%v", err)
-						}
-						//validate pre vote this current propose block
-						a.validatePreVote(proposeInfo)
-
-						//validate vote this current propose block
-						a.validateVote(proposeInfo)
-					}
-				}
+	//set round for monitor
+	round := a.currentTimeSlot - bestView.CalculateTimeSlot(bestView.GetBlock().GetProposeTime())
+	monitor.SetGlobalParam("RoundKey", fmt.Sprintf("%d_%d", bestView.GetHeight(), round))
 
-				if ActorRuleBuilderContext.PreVoteRule == VOTE_RULE_VOTE {
-					//prevote for this timeslot
-					a.maybePreVoteMsg()
-				}
+	if newTimeSlot {
+		a.logger.Info("")
+		a.logger.Info("======================================================")
+		if ActorRuleBuilderContext.CreateRule == CREATE_RULE_NORMAL {
+			err := a.maybeProposeBlock()
+			if err != nil {
+				a.logger.Error(err)
+			}
 
-				if ActorRuleBuilderContext.VoteRule == VOTE_RULE_VOTE {
-					//vote for this timeslot
-					a.maybeVoteMsg()
-				}
+		}
+	}
 
-				if ActorRuleBuilderContext.InsertRule == INSERT_AND_BROADCAST {
-					//commit for this timeslot
-					a.maybeCommit()
-				}
+	//validatingreceived data: propose, prevote, vote
+	for _, proposeInfo := range a.receiveBlockByHash {
+		if ActorRuleBuilderContext.ValidatorRule == VALIDATOR_NO_VALIDATE {
+			break
+		}
 
+		//get propose info at current timeslot
+		if proposeInfo.block != nil && bestView.CalculateTimeSlot(proposeInfo.block.GetProposeTime()) == a.currentTimeSlot {
+			//validate the propose block
+			err := a.validateBlock(proposeInfo)
+			if err != nil {
+				a.logger.Errorf("%v", err)
 			}
+			//validate pre vote this current propose block
+			a.validatePreVote(proposeInfo)
+
+			//validate vote this current propose block
+			a.validateVote(proposeInfo)
 		}
-	}()
-	return nil
+	}
+
+	if ActorRuleBuilderContext.PreVoteRule == VOTE_RULE_VOTE {
+		//prevote for this timeslot
+		a.maybePreVoteMsg()
+	}
+
+	if ActorRuleBuilderContext.VoteRule == VOTE_RULE_VOTE {
+		//vote for this timeslot
+		a.maybeVoteMsg()
+	}
+
+	if ActorRuleBuilderContext.InsertRule == INSERT_AND_BROADCAST {
+		//commit for this timeslot
+		a.maybeCommit()
+	}
+}
+
+// spawn runs f in a new goroutine, a simulated actor runs it in place to keep the message order deterministic
+func (a *actorV3) spawn(f func()) {
+	if a.simulated {
+		f()
+		return
+	}
+	go f()
 }
 
 // get lock block hash, which is blockhash that we had send vote message
@@ -781,7 +817,7 @@ func (a *actorV3) validateBlock(proposeBlockInfo *ProposeBlockInfo) error {
 	}
 
 	//interval 1s
-	if time.Since(proposeBlockInfo.LastValidateTime).Seconds() < 1 {
+	if a.clock.Now().Sub(proposeBlockInfo.LastValidateTime).Seconds() < 1 {
 		return nil
 	}
 
@@ -798,7 +834,7 @@ func (a *actorV3) validateBlock(proposeBlockInfo *ProposeBlockInfo) error {
 		}
 	}
 
-	proposeBlockInfo.LastValidateTime = time.Now()
+	proposeBlockInfo.LastValidateTime = a.clock.Now()
 	err := a.chain.ValidatePreSignBlock(proposeBlockInfo.block, proposeBlockInfo.SigningCommittees, proposeBlockInfo.Committees)
 	if err != nil {
 		a.logger.Error(err)
@@ -76,7 +76,7 @@ func (a *actorV3) sendVote(
 					return NewConsensusError(UnExpectedError, err)
 				}
 				//send
-				err := a.createAndSendVote(&userKey, proposeBlockInfo.block, proposeBlockInfo.SigningCommittees, a.chain.GetPortalParamsV4(0))
+				err := a.createAndSendVote(&userKey, proposeBlockInfo.block, proposeBlockInfo.SigningCommittees, a.chain.GetPortalParamsV4(proposeBlockInfo.block.GetBeaconHeight()))
 				if err != nil {
 					a.logger.Error(err)
 					return NewConsensusError(UnExpectedError, err)
@@ -109,7 +109,7 @@ func (a *actorV3) createAndSendPreVote(
 
 	a.logger.Info(a.chainKey, "sending pre vote...", block.FullHashString())
 
-	go a.node.PushMessageToChain(msg, a.chain)
+	a.spawn(func() { a.node.PushMessageToChain(msg, a.chain) })
 
 	return nil
 }
@@ -43,7 +43,7 @@ func (a *actorV3) getBlockForPropose(proposeBlockHeight uint64) types.BlockInter
 // check if node should propose in this timeslot
 // if yes, then create and send propose block message
 func (a *actorV3) maybeProposeBlock() error {
-	time1 := time.Now()
+	time1 := a.clock.Now()
 	var err error
 	bestView := a.chain.GetBestView()
 	round := a.currentTimeSlot - bestView.CalculateTimeSlot(bestView.GetBlock().GetProposeTime())
@@ -124,7 +124,7 @@ func (a *actorV3) maybeProposeBlock() error {
 	if block != nil {
 		a.logger.Infof("create block %v hash %v, propose time %v, produce time %v", block.GetHeight(), block.FullHashString(), block.(types.BlockInterface).GetProposeTime(), block.(types.BlockInterface).GetProduceTime())
 	} else {
-		a.logger.Infof("create block fail, time: %v", time.Since(time1).Seconds())
+		a.logger.Infof("create block fail, time: %v", a.clock.Now().Sub(time1).Seconds())
 		return NewConsensusError(BlockCreationError, errors.New("block is nil"))
 	}
 
@@ -264,7 +264,7 @@ func (a *actorV3) handleProposeMsg(proposeMsg BFTPropose) error {
 
 	proposeBlockInfo := &ProposeBlockInfo{
 		block:                   block,
-		ReceiveTime:             time.Now(),
+		ReceiveTime:             a.clock.Now(),
 		Votes:                   make(map[string]*BFTVote),
 		PreVotes:                make(map[string]*BFTVote),
 		Committees:              incognitokey.DeepCopy(committees),
@@ -0,0 +1,62 @@
+package blsbft
+
+import (
+	"time"
+
+	"github.com/incognitochain/incognito-chain/common"
+)
+
+// Clock is the time source of the consensus actor
+type Clock interface {
+	Now() time.Time
+}
+
+type systemClock struct{}
+
+func (systemClock) Now() time.Time {
+	return time.Now()
+}
+
+// SimulatedActor is a consensus actor driven step by step by a simulator:
+// messages are handled when ProcessBFTMsg is called and outgoing messages are pushed to the node before it returns
+type SimulatedActor interface {
+	Actor
+	// Tick runs one iteration of the actor loop (propose, validate, prevote, vote, commit)
+	Tick()
+	// CleanMem removes outdated propose blocks and propose history
+	CleanMem()
+}
+
+// NewSimulatedActorV3 creates an actorV3 which reads time from clock and does not start its own loop.
+// The actor still reads and writes the global consensus database,
+// callers running several actors in one process must switch it before stepping each actor
+func NewSimulatedActorV3(
+	chain Chain,
+	committeeChain CommitteeChainHandler,
+	chainKey string, blockVersion, chainID int,
+	node NodeInterface, logger common.Logger, clock Clock,
+) SimulatedActor {
+	a := newActorV3WithValue(
+		chain,
+		committeeChain,
+		chainKey,
+		blockVersion,
+		chainID,
+		node,
+		logger,
+	)
+	a.clock = clock
+	a.simulated = true
+	return a
+}
+
+func (a *actorV3) Tick() {
+	if !a.isStarted {
+		return
+	}
+	a.tick()
+}
+
+func (a *actorV3) CleanMem() {
+	a.handleCleanMem()
+}
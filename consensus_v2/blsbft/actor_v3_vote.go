@@ -8,7 +8,6 @@ import (
 	"github.com/incognitochain/incognito-chain/incognitokey"
 	"github.com/incognitochain/incognito-chain/metadata"
 	"github.com/incognitochain/incognito-chain/portal/portalv4"
-	portalprocessv4 "github.com/incognitochain/incognito-chain/portal/portalv4/portalprocess"
 )
 
 func (a *actorV3) maybeVoteMsg() {
@@ -87,7 +86,7 @@ func (a *actorV3) createAndSendVote(
 
 	a.logger.Info(a.chainKey, "sending vote...", block.FullHashString())
 
-	go a.node.PushMessageToChain(msg, a.chain)
+	a.spawn(func() { a.node.PushMessageToChain(msg, a.chain) })
 
 	return nil
 }
@@ -170,26 +169,29 @@ func (a actorV3) CreateVote(
 		bytelist = append(bytelist, v.MiningPubKey[common.BlsConsensus])
 	}
 
-	blsSig, err := userKey.BLSSignData(block.ProposeHash().GetBytes(), selfIdx, bytelist)
+	previousView := a.chain.GetViewByHash(block.GetPrevHash())
+	signingCtx := newSigningContext(signatureschemes2.SigningKindVote, previousView, block)
+	blsSig, err := blsSignVote(userKey, signingCtx, block.ProposeHash().GetBytes(), selfIdx, bytelist)
 	if err != nil {
-		return nil, NewConsensusError(UnExpectedError, err)
+		return nil, err
 	}
 
 	bridgeSig := []byte{}
 	if metadata.HasBridgeInstructions(block.GetInstructions()) {
-		bridgeSig, err = userKey.BriSignData(block.Hash().GetBytes()) //proof is agg sig on block hash (not propose hash)
+		bridgeSigningCtx := newSigningContext(signatureschemes2.SigningKindBridgeVote, previousView, block)
+		bridgeSigningCtx.DataHash = block.Hash().String()
+		bridgeSig, err = briSignWithContext(userKey, bridgeSigningCtx, block.Hash().GetBytes()) //proof is agg sig on block hash (not propose hash)
 		if err != nil {
-			return nil, NewConsensusError(UnExpectedError, err)
+			return nil, err
 		}
 	}
 
 	// check and sign on unshielding external tx for Portal v4
-	portalSigs, err := portalprocessv4.CheckAndSignPortalUnshieldExternalTx(userKey.PriKey[common.BridgeConsensus], block.GetInstructions(), portalParamsV4)
+	portalSigs, err := signPortalExternalTxs(userKey, previousView, block, portalParamsV4)
 	if err != nil {
 		return nil, NewConsensusError(UnExpectedError, err)
 	}
 
-	previousView := a.chain.GetViewByHash(block.GetPrevHash())
 	vote.Phase = "vote"
 	vote.BLS = blsSig
 	vote.BRI = bridgeSig
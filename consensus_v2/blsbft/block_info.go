@@ -315,23 +315,6 @@ type ReProposeBlockInfo struct {
 	RootHash          common.Hash
 }
 
-func createReProposeHashSignature(chain Chain, privateKey []byte, block types.BlockInterface) (string, error) {
-	previousView := chain.GetViewByHash(block.GetPrevHash())
-	if previousView == nil {
-		return "", fmt.Errorf("Cannot find previous view")
-	}
-	reProposeBlockInfo := newReProposeBlockInfo(
-		block.GetPrevHash(),
-		block.GetProducer(),
-		previousView.CalculateTimeSlot(block.GetProduceTime()),
-		block.GetProposer(),
-		previousView.CalculateTimeSlot(block.GetProposeTime()),
-		block.GetAggregateRootHash(),
-	)
-
-	return reProposeBlockInfo.Sign(privateKey)
-}
-
 func verifyReProposeHashSignature(
 	sig string,
 	previousBlockHash common.Hash,
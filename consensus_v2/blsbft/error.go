@@ -19,6 +19,7 @@ const (
 	DecodeValidationDataError
 	EncodeValidationDataError
 	BlockCreationError
+	SlashingProtectionError
 )
 
 var ErrCodeMessage = map[int]struct {
@@ -37,6 +38,7 @@ var ErrCodeMessage = map[int]struct {
 	DecodeValidationDataError:    {-1009, "Decode Validation Data error"},
 	EncodeValidationDataError:    {-1010, "Encode Validation Data Error"},
 	BlockCreationError:           {-1011, "Block Creation Error"},
+	SlashingProtectionError:      {-1012, "Slashing Protection Error"},
 }
 
 type ConsensusError struct {
@@ -7,6 +7,7 @@ import (
 	portalprocessv4 "github.com/incognitochain/incognito-chain/portal/portalv4/portalprocess"
 
 	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/consensus_v2/consensustypes"
 	signatureschemes2 "github.com/incognitochain/incognito-chain/consensus_v2/signatureschemes"
 )
 
@@ -66,6 +67,25 @@ func (s *BFTVote) isEmptyDataForByzantineDetector() bool {
 	return false
 }
 
+// toEquivocationVote keep the signed part of vote, used to build double vote evidence
+func (s *BFTVote) toEquivocationVote() consensustypes.EquivocationVote {
+	return consensustypes.EquivocationVote{
+		Phase:              s.Phase,
+		PrevBlockHash:      s.PrevBlockHash,
+		BlockHeight:        s.BlockHeight,
+		BlockHash:          s.BlockHash,
+		Hash:               s.Hash,
+		Validator:          s.Validator,
+		BLS:                s.BLS,
+		BRI:                s.BRI,
+		Confirmation:       s.Confirmation,
+		ProduceTimeSlot:    s.ProduceTimeSlot,
+		ProposeTimeSlot:    s.ProposeTimeSlot,
+		CommitteeFromBlock: s.CommitteeFromBlock,
+		ChainID:            s.ChainID,
+	}
+}
+
 func (s *BFTVote) signVote(key *signatureschemes2.MiningKey) error {
 
 	data := []byte{}
@@ -93,9 +113,16 @@ func (s *BFTVote) signVote(key *signatureschemes2.MiningKey) error {
 		data = append(data, common.Int64ToBytes(int64(s.ChainID))...)
 	}
 
-	data = common.HashB(data)
+	signingCtx := &signatureschemes2.SigningContext{
+		Kind:            signatureschemes2.SigningKindVoteConfirmation,
+		ChainID:         s.ChainID,
+		BlockHeight:     s.BlockHeight,
+		BlockHash:       s.BlockHash,
+		ProposeTimeSlot: s.ProposeTimeSlot,
+		Message:         data,
+	}
 	var err error
-	s.Confirmation, err = key.BriSignData(data)
+	s.Confirmation, err = key.BriSignWithContext(signingCtx, common.HashB(data))
 	return err
 }
 
@@ -0,0 +1,167 @@
+package blsbft
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/incognitochain/incognito-chain/blockchain/types"
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/common/base58"
+	signatureschemes2 "github.com/incognitochain/incognito-chain/consensus_v2/signatureschemes"
+	"github.com/incognitochain/incognito-chain/consensus_v2/signatureschemes/blsmultisig"
+	"github.com/incognitochain/incognito-chain/dataaccessobject/rawdb_consensus"
+	"github.com/incognitochain/incognito-chain/multiview"
+	"github.com/incognitochain/incognito-chain/portal/portalv4"
+	portalprocessv4 "github.com/incognitochain/incognito-chain/portal/portalv4/portalprocess"
+)
+
+// newSigningContext describes the block for the signer of the mining key,
+// timeslots are calculated from the previous view of the block
+func newSigningContext(kind string, previousView multiview.View, block types.BlockInterface) *signatureschemes2.SigningContext {
+	signingCtx := &signatureschemes2.SigningContext{
+		Kind:               kind,
+		ChainID:            block.GetShardID(),
+		BlockHeight:        block.GetHeight(),
+		BlockHash:          block.ProposeHash().String(),
+		CommitteeFromBlock: block.CommitteeFromBlock().String(),
+	}
+	if previousView != nil {
+		signingCtx.ProduceTimeSlot = previousView.CalculateTimeSlot(block.GetProduceTime())
+		signingCtx.ProposeTimeSlot = previousView.CalculateTimeSlot(block.GetProposeTime())
+	}
+	return signingCtx
+}
+
+// blsSignVote signs the vote after checking it against the slashing protection history of the mining key
+func blsSignVote(
+	userKey *signatureschemes2.MiningKey, signingCtx *signatureschemes2.SigningContext,
+	data []byte, selfIdx int, committee []blsmultisig.PublicKey,
+) ([]byte, error) {
+	validator := userKey.GetPublicKey().GetMiningKeyBase58(common.BlsConsensus)
+	if err := checkAndRecordSigning(rawdb_consensus.GetConsensusDatabase(), validator, signingCtx); err != nil {
+		return nil, NewConsensusError(SlashingProtectionError, err)
+	}
+	sig, err := userKey.BLSSignVote(signingCtx, data, selfIdx, committee)
+	if err != nil {
+		return nil, NewConsensusError(UnExpectedError, err)
+	}
+	return sig, nil
+}
+
+// briSignWithContext signs the proposal, the bridge vote or the re-proposal
+// after checking it against the slashing protection history of the mining key
+func briSignWithContext(
+	userKey *signatureschemes2.MiningKey, signingCtx *signatureschemes2.SigningContext, data []byte,
+) ([]byte, error) {
+	validator := userKey.GetPublicKey().GetMiningKeyBase58(common.BlsConsensus)
+	if err := checkAndRecordSigning(rawdb_consensus.GetConsensusDatabase(), validator, signingCtx); err != nil {
+		return nil, NewConsensusError(SlashingProtectionError, err)
+	}
+	sig, err := userKey.BriSignWithContext(signingCtx, data)
+	if err != nil {
+		return nil, NewConsensusError(UnExpectedError, err)
+	}
+	return sig, nil
+}
+
+// signPortalExternalTxs signs the portal v4 unshield external txs in the block instructions
+// and the MuSig2 sessions on the external txs in the previous block,
+// by the bridge key in this process or by the signer of the mining key.
+// The block must be voted or proposed by the mining key before, see checkAndRecordSigning
+func signPortalExternalTxs(
+	userKey *signatureschemes2.MiningKey, previousView multiview.View, block types.BlockInterface, portalParamsV4 portalv4.PortalParams,
+) ([]*portalprocessv4.PortalSig, error) {
+	// the nonces of the MuSig2 sessions are in the portal sigs of the previous block
+	var prevInsts [][]string
+	var prevPortalSigs []*portalprocessv4.PortalSig
+	if previousView != nil && previousView.GetBlock() != nil && portalprocessv4.HasPortalExternalTxs(previousView.GetBlock().GetInstructions()) {
+		prevInsts = previousView.GetBlock().GetInstructions()
+		var err error
+		prevPortalSigs, _, err = ExtractPortalV4ValidationData(previousView.GetBlock())
+		if err != nil {
+			return nil, err
+		}
+	}
+	if len(prevInsts) == 0 && !portalprocessv4.HasPortalExternalTxs(block.GetInstructions()) {
+		return nil, nil
+	}
+
+	signingCtx := newSigningContext(signatureschemes2.SigningKindPortal, previousView, block)
+	validator := userKey.GetPublicKey().GetMiningKeyBase58(common.BlsConsensus)
+	if err := checkAndRecordSigning(rawdb_consensus.GetConsensusDatabase(), validator, signingCtx); err != nil {
+		return nil, err
+	}
+
+	if userKey.Signer == nil {
+		seedKey := userKey.PriKey[common.BridgeConsensus]
+		portalSigs, err := portalprocessv4.CheckAndSignPortalUnshieldExternalTx(seedKey, block.GetInstructions(), portalParamsV4)
+		if err != nil {
+			return nil, err
+		}
+		muSig2Sigs, err := portalprocessv4.SignPortalMuSig2Sessions(seedKey, prevInsts, prevPortalSigs, portalParamsV4)
+		if err != nil {
+			return nil, err
+		}
+		return append(portalSigs, muSig2Sigs...), nil
+	}
+
+	// the signer checks the instructions against the beacon headers
+	header, err := getBeaconHeaderData(block)
+	if err != nil {
+		return nil, err
+	}
+	var prevHeader []byte
+	if len(prevInsts) > 0 {
+		prevHeader, err = getBeaconHeaderData(previousView.GetBlock())
+		if err != nil {
+			return nil, err
+		}
+	}
+	prevPortalSigsData, err := json.Marshal(prevPortalSigs)
+	if err != nil {
+		return nil, err
+	}
+	data, err := userKey.Signer.SignPortalExternalTxs(signingCtx, header, block.GetInstructions(), prevHeader, prevInsts, prevPortalSigsData)
+	if err != nil {
+		return nil, err
+	}
+	var portalSigs []*portalprocessv4.PortalSig
+	if err := json.Unmarshal(data, &portalSigs); err != nil {
+		return nil, fmt.Errorf("Can not unmarshal portal sigs from signer %v", err)
+	}
+	return portalSigs, nil
+}
+
+// getBeaconHeaderData returns the json encoded header of the beacon block,
+// portal external txs are only in beacon blocks
+func getBeaconHeaderData(block types.BlockInterface) ([]byte, error) {
+	beaconBlock, ok := block.(*types.BeaconBlock)
+	if !ok {
+		return nil, fmt.Errorf("Portal external txs are not signed in block %v of chain %v", block.Hash().String(), block.GetShardID())
+	}
+	return json.Marshal(beaconBlock.Header)
+}
+
+func createReProposeHashSignature(chain Chain, userKey *signatureschemes2.MiningKey, block types.BlockInterface) (string, error) {
+	previousView := chain.GetViewByHash(block.GetPrevHash())
+	if previousView == nil {
+		return "", fmt.Errorf("Cannot find previous view")
+	}
+	reProposeBlockInfo := newReProposeBlockInfo(
+		block.GetPrevHash(),
+		block.GetProducer(),
+		previousView.CalculateTimeSlot(block.GetProduceTime()),
+		block.GetProposer(),
+		previousView.CalculateTimeSlot(block.GetProposeTime()),
+		block.GetAggregateRootHash(),
+	)
+
+	hash := reProposeBlockInfo.Hash()
+	signingCtx := newSigningContext(signatureschemes2.SigningKindRePropose, previousView, block)
+	signingCtx.DataHash = hash.String()
+	sig, err := briSignWithContext(userKey, signingCtx, hash.Bytes())
+	if err != nil {
+		return "", err
+	}
+	return base58.Base58Check{}.Encode(sig, common.Base58Version), nil
+}
@@ -0,0 +1,391 @@
+package blsbft
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/incognitochain/incognito-chain/blockchain/types"
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/config"
+	"github.com/incognitochain/incognito-chain/consensus_v2/consensustypes"
+	signatureschemes2 "github.com/incognitochain/incognito-chain/consensus_v2/signatureschemes"
+	"github.com/incognitochain/incognito-chain/dataaccessobject/rawdb_consensus"
+	"github.com/incognitochain/incognito-chain/incdb"
+	"github.com/incognitochain/incognito-chain/incognitokey"
+	"github.com/incognitochain/incognito-chain/multiview"
+)
+
+var (
+	ErrVoteBelowSlashingProtection    = errors.New("vote is below the slashing protection history")
+	ErrProposeBelowSlashingProtection = errors.New("propose is below the slashing protection history")
+	ErrSlashingProtectionBehindChain  = errors.New("slashing protection history is behind the chain")
+	ErrBridgeVoteWithoutVote          = errors.New("bridge vote is not for the last voted block")
+	ErrReProposeWithoutPropose        = errors.New("re-propose is not for the last proposed block")
+	ErrPortalSignWithoutBlock         = errors.New("portal signature is not for the last voted or proposed block")
+	ErrPortalSignBelowProtection      = errors.New("portal signature is below the slashing protection history")
+)
+
+// SlashingProtectionInterchangeVersion is the version of the slashing protection interchange format
+const SlashingProtectionInterchangeVersion = 1
+
+// SlashingProtectionInterchange is the json document used to move the slashing protection history
+// of validators between nodes, e.g.
+//
+//	{
+//	  "metadata": {"interchange_format_version": 1, "network": "mainnet"},
+//	  "data": [{
+//	    "pubkey": "<bls mining public key in base58>", "chain_id": -1,
+//	    "last_voted_height": 100, "last_voted_timeslot": 1000, "last_voted_block_hash": "<propose hash>",
+//	    "last_proposed_height": 99, "last_proposed_timeslot": 990, "last_proposed_block_hash": "<propose hash>",
+//	    "last_portal_signed_height": 99, "last_portal_signed_timeslot": 990, "last_portal_signed_block_hash": "<propose hash>"
+//	  }]
+//	}
+//
+// chain_id is -1 for the beacon chain, timeslots are the propose timeslots of the blocks.
+// Importing never lowers the history that a node already has.
+type SlashingProtectionInterchange struct {
+	Metadata SlashingProtectionMetadata `json:"metadata"`
+	Data     []SlashingProtectionData   `json:"data"`
+}
+
+type SlashingProtectionMetadata struct {
+	InterchangeFormatVersion int    `json:"interchange_format_version"`
+	Network                  string `json:"network"`
+}
+
+type SlashingProtectionData struct {
+	PublicKey             string `json:"pubkey"`
+	ChainID               int    `json:"chain_id"`
+	LastVotedHeight       uint64 `json:"last_voted_height"`
+	LastVotedTimeSlot     int64  `json:"last_voted_timeslot"`
+	LastVotedBlockHash    string `json:"last_voted_block_hash"`
+	LastProposedHeight    uint64 `json:"last_proposed_height"`
+	LastProposedTimeSlot  int64  `json:"last_proposed_timeslot"`
+	LastProposedBlockHash string `json:"last_proposed_block_hash"`
+
+	LastPortalSignedHeight    uint64 `json:"last_portal_signed_height,omitempty"`
+	LastPortalSignedTimeSlot  int64  `json:"last_portal_signed_timeslot,omitempty"`
+	LastPortalSignedBlockHash string `json:"last_portal_signed_block_hash,omitempty"`
+}
+
+// slashingProtectionMu serializes the checks and updates of the slashing protection history
+var slashingProtectionMu sync.Mutex
+
+// SlashingProtection checks the signing requests of validators against the slashing protection history in the database,
+// it is used by the remote signer which keeps its own history
+type SlashingProtection struct {
+	db incdb.Database
+}
+
+func NewSlashingProtection(db incdb.Database) *SlashingProtection {
+	return &SlashingProtection{db: db}
+}
+
+func (p *SlashingProtection) CheckAndRecord(validator string, signingCtx *signatureschemes2.SigningContext) error {
+	return checkAndRecordSigning(p.db, validator, signingCtx)
+}
+
+// checkAndRecordSigning refuses to sign a vote or a proposal below the highest block
+// the validator has signed on the chain, otherwise the block is recorded before signing.
+// Bridge votes and re-proposals are only signed for the last voted and proposed block.
+// Portal external txs are only signed for the last voted or proposed block and never for two blocks
+// of the same timeslot, since the external txs of two blocks could spend the same UTXOs
+func checkAndRecordSigning(db incdb.Database, validator string, signingCtx *signatureschemes2.SigningContext) error {
+	switch signingCtx.Kind {
+	case signatureschemes2.SigningKindVoteConfirmation, signatureschemes2.SigningKindMessage:
+		// not tied to the signed blocks
+		return nil
+	}
+
+	slashingProtectionMu.Lock()
+	defer slashingProtectionMu.Unlock()
+
+	_, record, err := rawdb_consensus.GetSlashingProtectionRecord(db, validator, signingCtx.ChainID)
+	if err != nil {
+		return err
+	}
+	if record == nil {
+		record = rawdb_consensus.NewSlashingProtectionRecord()
+	}
+
+	switch signingCtx.Kind {
+	case signatureschemes2.SigningKindVote:
+		if signingCtx.ProposeTimeSlot == record.LastVotedTimeSlot && signingCtx.BlockHash == record.LastVotedBlockHash {
+			return nil
+		}
+		if signingCtx.ProposeTimeSlot <= record.LastVotedTimeSlot || signingCtx.BlockHeight < record.LastVotedHeight {
+			return fmt.Errorf("%v, chain %v, voted height %v timeslot %v, new height %v timeslot %v",
+				ErrVoteBelowSlashingProtection, signingCtx.ChainID, record.LastVotedHeight, record.LastVotedTimeSlot,
+				signingCtx.BlockHeight, signingCtx.ProposeTimeSlot)
+		}
+		record.LastVotedHeight = signingCtx.BlockHeight
+		record.LastVotedTimeSlot = signingCtx.ProposeTimeSlot
+		record.LastVotedBlockHash = signingCtx.BlockHash
+	case signatureschemes2.SigningKindPropose:
+		if signingCtx.ProposeTimeSlot == record.LastProposedTimeSlot && signingCtx.BlockHash == record.LastProposedBlockHash {
+			return nil
+		}
+		if signingCtx.ProposeTimeSlot <= record.LastProposedTimeSlot || signingCtx.BlockHeight < record.LastProposedHeight {
+			return fmt.Errorf("%v, chain %v, proposed height %v timeslot %v, new height %v timeslot %v",
+				ErrProposeBelowSlashingProtection, signingCtx.ChainID, record.LastProposedHeight, record.LastProposedTimeSlot,
+				signingCtx.BlockHeight, signingCtx.ProposeTimeSlot)
+		}
+		record.LastProposedHeight = signingCtx.BlockHeight
+		record.LastProposedTimeSlot = signingCtx.ProposeTimeSlot
+		record.LastProposedBlockHash = signingCtx.BlockHash
+	case signatureschemes2.SigningKindBridgeVote:
+		if signingCtx.ProposeTimeSlot != record.LastVotedTimeSlot || signingCtx.BlockHash != record.LastVotedBlockHash {
+			return fmt.Errorf("%v, chain %v, voted block %v timeslot %v, new block %v timeslot %v",
+				ErrBridgeVoteWithoutVote, signingCtx.ChainID, record.LastVotedBlockHash, record.LastVotedTimeSlot,
+				signingCtx.BlockHash, signingCtx.ProposeTimeSlot)
+		}
+		return nil
+	case signatureschemes2.SigningKindRePropose:
+		if signingCtx.ProposeTimeSlot != record.LastProposedTimeSlot || signingCtx.BlockHash != record.LastProposedBlockHash {
+			return fmt.Errorf("%v, chain %v, proposed block %v timeslot %v, new block %v timeslot %v",
+				ErrReProposeWithoutPropose, signingCtx.ChainID, record.LastProposedBlockHash, record.LastProposedTimeSlot,
+				signingCtx.BlockHash, signingCtx.ProposeTimeSlot)
+		}
+		return nil
+	case signatureschemes2.SigningKindPortal:
+		isVoted := signingCtx.ProposeTimeSlot == record.LastVotedTimeSlot && signingCtx.BlockHash == record.LastVotedBlockHash
+		isProposed := signingCtx.ProposeTimeSlot == record.LastProposedTimeSlot && signingCtx.BlockHash == record.LastProposedBlockHash
+		if !isVoted && !isProposed {
+			return fmt.Errorf("%v, chain %v, voted block %v, proposed block %v, new block %v timeslot %v",
+				ErrPortalSignWithoutBlock, signingCtx.ChainID, record.LastVotedBlockHash, record.LastProposedBlockHash,
+				signingCtx.BlockHash, signingCtx.ProposeTimeSlot)
+		}
+		if signingCtx.ProposeTimeSlot == record.LastPortalSignedTimeSlot && signingCtx.BlockHash == record.LastPortalSignedBlockHash {
+			return nil
+		}
+		if signingCtx.ProposeTimeSlot <= record.LastPortalSignedTimeSlot || signingCtx.BlockHeight < record.LastPortalSignedHeight {
+			return fmt.Errorf("%v, chain %v, portal signed height %v timeslot %v, new height %v timeslot %v",
+				ErrPortalSignBelowProtection, signingCtx.ChainID, record.LastPortalSignedHeight, record.LastPortalSignedTimeSlot,
+				signingCtx.BlockHeight, signingCtx.ProposeTimeSlot)
+		}
+		record.LastPortalSignedHeight = signingCtx.BlockHeight
+		record.LastPortalSignedTimeSlot = signingCtx.ProposeTimeSlot
+		record.LastPortalSignedBlockHash = signingCtx.BlockHash
+	default:
+		return fmt.Errorf("Signing kind %v is not supported", signingCtx.Kind)
+	}
+
+	return rawdb_consensus.StoreSlashingProtectionRecord(db, validator, signingCtx.ChainID, record)
+}
+
+// ExportSlashingProtection exports the slashing protection history of all validators in the interchange format
+func ExportSlashingProtection(db incdb.Database) (*SlashingProtectionInterchange, error) {
+	slashingProtectionMu.Lock()
+	defer slashingProtectionMu.Unlock()
+
+	records, err := rawdb_consensus.GetAllSlashingProtectionRecords(db)
+	if err != nil {
+		return nil, err
+	}
+
+	interchange := &SlashingProtectionInterchange{
+		Metadata: SlashingProtectionMetadata{
+			InterchangeFormatVersion: SlashingProtectionInterchangeVersion,
+			Network:                  config.Param().Name,
+		},
+		Data: []SlashingProtectionData{},
+	}
+	for validator, chainRecords := range records {
+		for chainID, record := range chainRecords {
+			interchange.Data = append(interchange.Data, SlashingProtectionData{
+				PublicKey:             validator,
+				ChainID:               chainID,
+				LastVotedHeight:       record.LastVotedHeight,
+				LastVotedTimeSlot:     record.LastVotedTimeSlot,
+				LastVotedBlockHash:    record.LastVotedBlockHash,
+				LastProposedHeight:    record.LastProposedHeight,
+				LastProposedTimeSlot:  record.LastProposedTimeSlot,
+				LastProposedBlockHash: record.LastProposedBlockHash,
+
+				LastPortalSignedHeight:    record.LastPortalSignedHeight,
+				LastPortalSignedTimeSlot:  record.LastPortalSignedTimeSlot,
+				LastPortalSignedBlockHash: record.LastPortalSignedBlockHash,
+			})
+		}
+	}
+	sort.Slice(interchange.Data, func(i, j int) bool {
+		if interchange.Data[i].PublicKey != interchange.Data[j].PublicKey {
+			return interchange.Data[i].PublicKey < interchange.Data[j].PublicKey
+		}
+		return interchange.Data[i].ChainID < interchange.Data[j].ChainID
+	})
+
+	return interchange, nil
+}
+
+// ImportSlashingProtection merges the slashing protection history in the interchange format,
+// the history of each validator only moves up to the higher blocks
+func ImportSlashingProtection(db incdb.Database, interchange *SlashingProtectionInterchange) error {
+	if interchange.Metadata.InterchangeFormatVersion != SlashingProtectionInterchangeVersion {
+		return fmt.Errorf("Slashing protection interchange format version %v is not supported",
+			interchange.Metadata.InterchangeFormatVersion)
+	}
+	if interchange.Metadata.Network != "" && interchange.Metadata.Network != config.Param().Name {
+		return fmt.Errorf("Slashing protection history of network %v can not be imported into network %v",
+			interchange.Metadata.Network, config.Param().Name)
+	}
+
+	slashingProtectionMu.Lock()
+	defer slashingProtectionMu.Unlock()
+
+	for _, data := range interchange.Data {
+		if data.PublicKey == "" || data.ChainID < common.BeaconChainID {
+			return fmt.Errorf("Invalid slashing protection data %+v", data)
+		}
+		_, record, err := rawdb_consensus.GetSlashingProtectionRecord(db, data.PublicKey, data.ChainID)
+		if err != nil {
+			return err
+		}
+		if record == nil {
+			record = rawdb_consensus.NewSlashingProtectionRecord()
+		}
+		if data.LastVotedTimeSlot > record.LastVotedTimeSlot {
+			record.LastVotedTimeSlot = data.LastVotedTimeSlot
+			record.LastVotedBlockHash = data.LastVotedBlockHash
+		}
+		if data.LastVotedHeight > record.LastVotedHeight {
+			record.LastVotedHeight = data.LastVotedHeight
+		}
+		if data.LastProposedTimeSlot > record.LastProposedTimeSlot {
+			record.LastProposedTimeSlot = data.LastProposedTimeSlot
+			record.LastProposedBlockHash = data.LastProposedBlockHash
+		}
+		if data.LastProposedHeight > record.LastProposedHeight {
+			record.LastProposedHeight = data.LastProposedHeight
+		}
+		if data.LastPortalSignedTimeSlot > record.LastPortalSignedTimeSlot {
+			record.LastPortalSignedTimeSlot = data.LastPortalSignedTimeSlot
+			record.LastPortalSignedBlockHash = data.LastPortalSignedBlockHash
+		}
+		if data.LastPortalSignedHeight > record.LastPortalSignedHeight {
+			record.LastPortalSignedHeight = data.LastPortalSignedHeight
+		}
+		if err := rawdb_consensus.StoreSlashingProtectionRecord(db, data.PublicKey, data.ChainID, record); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// CheckSlashingProtectionHistory refuses to start the validators if the chain has a block
+// signed by one of the mining keys above their slashing protection history,
+// e.g. the node is restored from an old backup or the keys are moved from another node
+func CheckSlashingProtectionHistory(
+	db incdb.Database, chain Chain, committeeChain CommitteeChainHandler, userKeys []signatureschemes2.MiningKey,
+) error {
+	slashingProtectionMu.Lock()
+	defer slashingProtectionMu.Unlock()
+
+	bestView := chain.GetBestView()
+	if bestView == nil || bestView.GetHeight() <= 1 {
+		return nil
+	}
+	block := bestView.GetBlock()
+	chainID := chain.GetShardID()
+	signers, err := getBlockSigners(chain, committeeChain, bestView, block)
+	if err != nil {
+		return err
+	}
+	proposer := incognitokey.CommitteePublicKey{}
+	if err := proposer.FromString(block.GetProposer()); err != nil {
+		return err
+	}
+
+	for _, userKey := range userKeys {
+		validator := userKey.GetPublicKey().GetMiningKeyBase58(common.BlsConsensus)
+		has, record, err := rawdb_consensus.GetSlashingProtectionRecord(db, validator, chainID)
+		if err != nil {
+			return err
+		}
+		if !has {
+			record, err = initSlashingProtectionRecord(db, validator, chainID)
+			if err != nil {
+				return err
+			}
+		}
+
+		isSigner := proposer.GetMiningKeyBase58(common.BlsConsensus) == validator
+		for _, signer := range signers {
+			if signer.GetMiningKeyBase58(common.BlsConsensus) == validator {
+				isSigner = true
+			}
+		}
+		lastSignedHeight := record.LastVotedHeight
+		if record.LastProposedHeight > lastSignedHeight {
+			lastSignedHeight = record.LastProposedHeight
+		}
+		if isSigner && lastSignedHeight < block.GetHeight() {
+			return fmt.Errorf("%v, validator %v signed block %v at height %v of chain %v, highest signed height in history is %v",
+				ErrSlashingProtectionBehindChain, validator, block.ProposeHash().String(), block.GetHeight(), chainID, lastSignedHeight)
+		}
+	}
+
+	return nil
+}
+
+// initSlashingProtectionRecord starts the slashing protection history of a validator
+// from the vote history of the node
+func initSlashingProtectionRecord(db incdb.Database, validator string, chainID int) (*rawdb_consensus.SlashingProtectionRecord, error) {
+	record := rawdb_consensus.NewSlashingProtectionRecord()
+	voteHistory, err := rawdb_consensus.GetAllVoteHistory(db, chainID)
+	if err != nil {
+		return nil, err
+	}
+	if len(voteHistory) == 0 {
+		return record, nil
+	}
+	for height := range voteHistory {
+		if height > record.LastVotedHeight {
+			record.LastVotedHeight = height
+		}
+	}
+	if err := rawdb_consensus.StoreSlashingProtectionRecord(db, validator, chainID, record); err != nil {
+		return nil, err
+	}
+	return record, nil
+}
+
+// getBlockSigners returns the committee members that voted for the block of the view
+func getBlockSigners(
+	chain Chain, committeeChain CommitteeChainHandler, view multiview.View, block types.BlockInterface,
+) ([]incognitokey.CommitteePublicKey, error) {
+	validationData, err := consensustypes.DecodeValidationData(block.GetValidationField())
+	if err != nil {
+		return nil, NewConsensusError(DecodeValidationDataError, err)
+	}
+
+	// committees are taken from the previous view if it is still kept
+	previousView := chain.GetViewByHash(block.GetPrevHash())
+	if previousView == nil {
+		previousView = view
+	}
+	committees := previousView.GetCommittee()
+	proposerIndex := -1
+	if block.GetVersion() != types.MULTI_VIEW_VERSION && !chain.IsBeaconChain() {
+		committees, err = committeeChain.CommitteesFromViewHashForShard(block.CommitteeFromBlock(), byte(chain.GetShardID()))
+		if err != nil {
+			return nil, err
+		}
+		_, proposerIndex = chain.GetProposerByTimeSlotFromCommitteeList(
+			previousView.CalculateTimeSlot(block.GetProposeTime()),
+			committees,
+		)
+	}
+	signingCommittees := chain.GetSigningCommittees(proposerIndex, committees, block.GetVersion())
+
+	signers := []incognitokey.CommitteePublicKey{}
+	for _, idx := range validationData.ValidatiorsIdx {
+		if idx >= 0 && idx < len(signingCommittees) {
+			signers = append(signers, signingCommittees[idx])
+		}
+	}
+	return signers, nil
+}
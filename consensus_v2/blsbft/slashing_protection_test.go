@@ -0,0 +1,113 @@
+package blsbft
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/config"
+	signatureschemes2 "github.com/incognitochain/incognito-chain/consensus_v2/signatureschemes"
+	"github.com/incognitochain/incognito-chain/incdb"
+	_ "github.com/incognitochain/incognito-chain/incdb/lvdb"
+	"github.com/stretchr/testify/assert"
+)
+
+func newSlashingProtectionTestDB(t *testing.T) (incdb.Database, func()) {
+	dbPath, err := ioutil.TempDir(os.TempDir(), "slashing_protection_")
+	if err != nil {
+		t.Fatal(err)
+	}
+	db, err := incdb.Open("leveldb", dbPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return db, func() {
+		db.Close()
+		os.RemoveAll(dbPath)
+	}
+}
+
+func newSlashingProtectionTestContext(kind string, chainID int, height uint64, timeSlot int64, hash string) *signatureschemes2.SigningContext {
+	return &signatureschemes2.SigningContext{
+		Kind:            kind,
+		ChainID:         chainID,
+		BlockHeight:     height,
+		BlockHash:       hash,
+		ProduceTimeSlot: timeSlot,
+		ProposeTimeSlot: timeSlot,
+	}
+}
+
+func TestCheckAndRecordSigning(t *testing.T) {
+	config.AbortParam()
+	db, cleanup := newSlashingProtectionTestDB(t)
+	defer cleanup()
+	vote, propose := signatureschemes2.SigningKindVote, signatureschemes2.SigningKindPropose
+
+	// first vote
+	assert.Nil(t, checkAndRecordSigning(db, blsKeys[0], newSlashingProtectionTestContext(vote, common.BeaconChainID, 10, 100, "a")))
+	// sign the same vote again
+	assert.Nil(t, checkAndRecordSigning(db, blsKeys[0], newSlashingProtectionTestContext(vote, common.BeaconChainID, 10, 100, "a")))
+	// another block in the same timeslot
+	assert.NotNil(t, checkAndRecordSigning(db, blsKeys[0], newSlashingProtectionTestContext(vote, common.BeaconChainID, 10, 100, "b")))
+	// lower timeslot
+	assert.NotNil(t, checkAndRecordSigning(db, blsKeys[0], newSlashingProtectionTestContext(vote, common.BeaconChainID, 11, 99, "b")))
+	// re-proposed block at the same height
+	assert.Nil(t, checkAndRecordSigning(db, blsKeys[0], newSlashingProtectionTestContext(vote, common.BeaconChainID, 10, 101, "b")))
+	// lower height
+	assert.NotNil(t, checkAndRecordSigning(db, blsKeys[0], newSlashingProtectionTestContext(vote, common.BeaconChainID, 9, 102, "c")))
+	// other chains and validators have their own history
+	assert.Nil(t, checkAndRecordSigning(db, blsKeys[0], newSlashingProtectionTestContext(vote, 0, 5, 50, "d")))
+	assert.Nil(t, checkAndRecordSigning(db, blsKeys[1], newSlashingProtectionTestContext(vote, common.BeaconChainID, 10, 100, "b")))
+
+	// proposals
+	assert.Nil(t, checkAndRecordSigning(db, blsKeys[0], newSlashingProtectionTestContext(propose, common.BeaconChainID, 11, 103, "e")))
+	assert.Nil(t, checkAndRecordSigning(db, blsKeys[0], newSlashingProtectionTestContext(propose, common.BeaconChainID, 11, 103, "e")))
+	assert.NotNil(t, checkAndRecordSigning(db, blsKeys[0], newSlashingProtectionTestContext(propose, common.BeaconChainID, 11, 103, "f")))
+	assert.Nil(t, checkAndRecordSigning(db, blsKeys[0], newSlashingProtectionTestContext(propose, common.BeaconChainID, 11, 104, "f")))
+
+	// the history is kept in the database
+	interchange, err := ExportSlashingProtection(db)
+	assert.Nil(t, err)
+	assert.Equal(t, SlashingProtectionInterchangeVersion, interchange.Metadata.InterchangeFormatVersion)
+	assert.Equal(t, 3, len(interchange.Data))
+	for _, data := range interchange.Data {
+		if data.PublicKey == blsKeys[0] && data.ChainID == common.BeaconChainID {
+			assert.Equal(t, uint64(10), data.LastVotedHeight)
+			assert.Equal(t, int64(101), data.LastVotedTimeSlot)
+			assert.Equal(t, "b", data.LastVotedBlockHash)
+			assert.Equal(t, uint64(11), data.LastProposedHeight)
+			assert.Equal(t, int64(104), data.LastProposedTimeSlot)
+		}
+	}
+}
+
+func TestImportSlashingProtection(t *testing.T) {
+	config.AbortParam()
+	oldDB, cleanupOld := newSlashingProtectionTestDB(t)
+	defer cleanupOld()
+	newDB, cleanupNew := newSlashingProtectionTestDB(t)
+	defer cleanupNew()
+	vote := signatureschemes2.SigningKindVote
+
+	assert.Nil(t, checkAndRecordSigning(oldDB, blsKeys[0], newSlashingProtectionTestContext(vote, 1, 20, 200, "a")))
+	assert.Nil(t, checkAndRecordSigning(newDB, blsKeys[0], newSlashingProtectionTestContext(vote, 1, 10, 100, "b")))
+	assert.Nil(t, checkAndRecordSigning(newDB, blsKeys[1], newSlashingProtectionTestContext(vote, 1, 30, 300, "c")))
+
+	// move the history to the new node
+	interchange, err := ExportSlashingProtection(oldDB)
+	assert.Nil(t, err)
+	assert.Nil(t, ImportSlashingProtection(newDB, interchange))
+	assert.NotNil(t, checkAndRecordSigning(newDB, blsKeys[0], newSlashingProtectionTestContext(vote, 1, 20, 200, "d")))
+	assert.Nil(t, checkAndRecordSigning(newDB, blsKeys[0], newSlashingProtectionTestContext(vote, 1, 21, 201, "d")))
+
+	// importing an older history does not lower the history
+	interchange.Data = append(interchange.Data, SlashingProtectionData{PublicKey: blsKeys[1], ChainID: 1, LastVotedHeight: 1, LastVotedTimeSlot: 1})
+	assert.Nil(t, ImportSlashingProtection(newDB, interchange))
+	assert.NotNil(t, checkAndRecordSigning(newDB, blsKeys[1], newSlashingProtectionTestContext(vote, 1, 29, 299, "e")))
+
+	// unsupported format
+	interchange.Metadata.InterchangeFormatVersion = SlashingProtectionInterchangeVersion + 1
+	assert.NotNil(t, ImportSlashingProtection(newDB, interchange))
+}
@@ -0,0 +1,175 @@
+package consensustypes
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/config"
+	"github.com/incognitochain/incognito-chain/consensus_v2/signatureschemes/bridgesig"
+	"github.com/incognitochain/incognito-chain/incognitokey"
+)
+
+// Equivocation reasons, mirror the double vote rules of the byzantine detector
+const (
+	EquivocationSameTimeSlot = "sametimeslot" // two votes for different blocks in one propose timeslot
+	EquivocationSameHeight   = "sameheight"   // a later vote for a block of one height with higher produce timeslot
+)
+
+var (
+	ErrInvalidEquivocationEvidence = errors.New("invalid equivocation evidence")
+)
+
+// EquivocationVote is the signed part of a BFT vote, field names are kept
+// identical to blsbft.BFTVote so a vote could be decoded directly from its json
+type EquivocationVote struct {
+	Phase              string
+	PrevBlockHash      string
+	BlockHeight        uint64
+	BlockHash          string
+	Hash               string
+	Validator          string
+	BLS                []byte
+	BRI                []byte
+	Confirmation       []byte
+	ProduceTimeSlot    int64
+	ProposeTimeSlot    int64
+	CommitteeFromBlock common.Hash
+	ChainID            int
+}
+
+// signedData rebuild the data that validator sign in vote confirmation
+func (v EquivocationVote) signedData() []byte {
+	data := []byte{}
+	if v.Phase != "" {
+		data = append(data, []byte(v.Phase)...)
+	}
+	if v.Hash != "" {
+		data = append(data, []byte(v.Hash)...)
+	}
+	data = append(data, v.BlockHash...)
+	data = append(data, v.BLS...)
+	data = append(data, v.BRI...)
+	data = append(data, common.Uint64ToBytes(v.BlockHeight)...)
+	data = append(data, common.Int64ToBytes(v.ProduceTimeSlot)...)
+	data = append(data, common.Int64ToBytes(v.ProposeTimeSlot)...)
+	data = append(data, []byte(v.Validator)...)
+	data = append(data, []byte(v.PrevBlockHash)...)
+	data = append(data, v.CommitteeFromBlock[:]...)
+	data = append(data, common.Int64ToBytes(int64(v.ChainID))...)
+	return data
+}
+
+// DataHash is the hash of the signed data of the vote, a vote with another confirmation of the same data has the same hash
+func (v EquivocationVote) DataHash() common.Hash {
+	return common.HashH(v.signedData())
+}
+
+// verifyConfirmation check vote confirmation is signed by bridge key of its validator
+func (v EquivocationVote) verifyConfirmation(briPublicKey []byte) error {
+	// before byzantine detector height, vote height and timeslot are not covered by confirmation
+	if v.BlockHeight < config.Param().ConsensusParam.ByzantineDetectorHeight {
+		return fmt.Errorf("%+v: vote height %+v is lower than byzantine detector height", ErrInvalidEquivocationEvidence, v.BlockHeight)
+	}
+	dataHash := v.DataHash()
+	ok, err := bridgesig.Verify(briPublicKey, dataHash.GetBytes(), v.Confirmation)
+	if err != nil {
+		return fmt.Errorf("%+v: %+v", ErrInvalidEquivocationEvidence, err)
+	}
+	if !ok {
+		return fmt.Errorf("%+v: invalid vote confirmation of block %+v", ErrInvalidEquivocationEvidence, v.BlockHash)
+	}
+	return nil
+}
+
+// EquivocationEvidence holds two conflicting votes signed by the same validator
+type EquivocationEvidence struct {
+	Reason     string
+	FirstVote  EquivocationVote
+	SecondVote EquivocationVote
+}
+
+// NewEquivocationEvidence keeps the votes of EquivocationSameHeight in the order they were cast,
+// the votes in one timeslot are ordered by block hash so the same pair of votes always result in the same evidence
+func NewEquivocationEvidence(reason string, vote1, vote2 EquivocationVote) *EquivocationEvidence {
+	if reason == EquivocationSameTimeSlot && vote2.BlockHash < vote1.BlockHash {
+		vote1, vote2 = vote2, vote1
+	}
+	return &EquivocationEvidence{
+		Reason:     reason,
+		FirstVote:  vote1,
+		SecondVote: vote2,
+	}
+}
+
+// Validator return bls key (base58) of the offender
+func (e EquivocationEvidence) Validator() string {
+	return e.FirstVote.Validator
+}
+
+// VoteHashes return hashes of the two votes, a vote can only be used in one processed evidence
+func (e EquivocationEvidence) VoteHashes() []common.Hash {
+	return []common.Hash{e.FirstVote.DataHash(), e.SecondVote.DataHash()}
+}
+
+// TimeSlot return the latest propose timeslot of the two votes, it is used to check the age of evidence
+func (e EquivocationEvidence) TimeSlot() int64 {
+	if e.SecondVote.ProposeTimeSlot > e.FirstVote.ProposeTimeSlot {
+		return e.SecondVote.ProposeTimeSlot
+	}
+	return e.FirstVote.ProposeTimeSlot
+}
+
+func (e EquivocationEvidence) Hash() common.Hash {
+	data, _ := json.Marshal(e)
+	return common.HashH(data)
+}
+
+// VerifyConflict check two votes are from the same validator and break one of the double vote rules,
+// signatures are not checked
+func (e EquivocationEvidence) VerifyConflict() error {
+	v1, v2 := e.FirstVote, e.SecondVote
+	if v1.Validator == "" || v1.Validator != v2.Validator {
+		return fmt.Errorf("%+v: votes from different validators", ErrInvalidEquivocationEvidence)
+	}
+	if v1.ChainID != v2.ChainID || v1.CommitteeFromBlock != v2.CommitteeFromBlock || v1.Phase != v2.Phase {
+		return fmt.Errorf("%+v: votes in different chain, committee or phase", ErrInvalidEquivocationEvidence)
+	}
+	if v1.BlockHash == v2.BlockHash {
+		return fmt.Errorf("%+v: votes for the same block %+v", ErrInvalidEquivocationEvidence, v1.BlockHash)
+	}
+	switch e.Reason {
+	case EquivocationSameTimeSlot:
+		if v1.ProposeTimeSlot != v2.ProposeTimeSlot {
+			return fmt.Errorf("%+v: votes in different timeslot %+v %+v", ErrInvalidEquivocationEvidence, v1.ProposeTimeSlot, v2.ProposeTimeSlot)
+		}
+	case EquivocationSameHeight:
+		// an honest validator may vote for a block with smaller produce timeslot after voting for a bigger one,
+		// so only a later vote (in a later propose timeslot) for a higher produce timeslot is a double vote
+		if v1.BlockHeight != v2.BlockHeight {
+			return fmt.Errorf("%+v: votes are not for the same height", ErrInvalidEquivocationEvidence)
+		}
+		if v2.ProposeTimeSlot <= v1.ProposeTimeSlot || v2.ProduceTimeSlot <= v1.ProduceTimeSlot {
+			return fmt.Errorf("%+v: later vote is not for a higher produce timeslot", ErrInvalidEquivocationEvidence)
+		}
+	default:
+		return fmt.Errorf("%+v: unknown reason %+v", ErrInvalidEquivocationEvidence, e.Reason)
+	}
+	return nil
+}
+
+// Verify check evidence is conflicting and both votes are signed by committeePublicKey
+func (e EquivocationEvidence) Verify(committeePublicKey incognitokey.CommitteePublicKey) error {
+	if err := e.VerifyConflict(); err != nil {
+		return err
+	}
+	if committeePublicKey.GetMiningKeyBase58(common.BlsConsensus) != e.Validator() {
+		return fmt.Errorf("%+v: validator %+v is not owner of committee public key", ErrInvalidEquivocationEvidence, e.Validator())
+	}
+	briPublicKey := committeePublicKey.MiningPubKey[common.BridgeConsensus]
+	if err := e.FirstVote.verifyConfirmation(briPublicKey); err != nil {
+		return err
+	}
+	return e.SecondVote.verifyConfirmation(briPublicKey)
+}
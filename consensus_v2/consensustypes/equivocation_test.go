@@ -0,0 +1,67 @@
+package consensustypes
+
+import (
+	"testing"
+)
+
+func TestEquivocationEvidence_VerifyConflict(t *testing.T) {
+	vote := func(blockHash string, produceTimeSlot, proposeTimeSlot int64) EquivocationVote {
+		return EquivocationVote{
+			Validator:       "bls1",
+			BlockHash:       blockHash,
+			BlockHeight:     10,
+			ProduceTimeSlot: produceTimeSlot,
+			ProposeTimeSlot: proposeTimeSlot,
+		}
+	}
+	tests := []struct {
+		name     string
+		evidence *EquivocationEvidence
+		wantErr  bool
+	}{
+		{
+			name:     "two votes in one timeslot",
+			evidence: NewEquivocationEvidence(EquivocationSameTimeSlot, vote("b", 1, 2), vote("a", 2, 2)),
+			wantErr:  false,
+		},
+		{
+			name:     "two votes in different timeslots",
+			evidence: NewEquivocationEvidence(EquivocationSameTimeSlot, vote("a", 1, 1), vote("b", 2, 2)),
+			wantErr:  true,
+		},
+		{
+			name:     "later vote for a higher produce timeslot",
+			evidence: NewEquivocationEvidence(EquivocationSameHeight, vote("b", 1, 1), vote("a", 2, 2)),
+			wantErr:  false,
+		},
+		{
+			name:     "later vote for a smaller produce timeslot",
+			evidence: NewEquivocationEvidence(EquivocationSameHeight, vote("a", 2, 2), vote("b", 1, 3)),
+			wantErr:  true,
+		},
+		{
+			name:     "earlier vote for a higher produce timeslot",
+			evidence: NewEquivocationEvidence(EquivocationSameHeight, vote("b", 1, 3), vote("a", 2, 2)),
+			wantErr:  true,
+		},
+		{
+			name:     "later vote for the same produce timeslot",
+			evidence: NewEquivocationEvidence(EquivocationSameHeight, vote("a", 1, 1), vote("b", 1, 2)),
+			wantErr:  true,
+		},
+		{
+			name: "votes from different validators",
+			evidence: NewEquivocationEvidence(EquivocationSameHeight, vote("a", 1, 1), EquivocationVote{
+				Validator: "bls2", BlockHash: "b", BlockHeight: 10, ProduceTimeSlot: 2, ProposeTimeSlot: 2,
+			}),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.evidence.VerifyConflict(); (err != nil) != tt.wantErr {
+				t.Errorf("VerifyConflict() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -1,6 +1,7 @@
 package consensus_v2
 
 import (
+	"encoding/json"
 	"fmt"
 	"strings"
 	"time"
@@ -12,7 +13,10 @@ import (
 	"github.com/incognitochain/incognito-chain/common/consensus"
 	"github.com/incognitochain/incognito-chain/config"
 	"github.com/incognitochain/incognito-chain/consensus_v2/blsbft"
+	"github.com/incognitochain/incognito-chain/consensus_v2/consensustypes"
+	"github.com/incognitochain/incognito-chain/consensus_v2/remotesigner"
 	signatureschemes2 "github.com/incognitochain/incognito-chain/consensus_v2/signatureschemes"
+	"github.com/incognitochain/incognito-chain/dataaccessobject/rawdb_consensus"
 	"github.com/incognitochain/incognito-chain/incognitokey"
 	"github.com/incognitochain/incognito-chain/metrics/monitor"
 	"github.com/incognitochain/incognito-chain/pubsub"
@@ -246,21 +250,68 @@ func (engine *Engine) updateVersion(chainID int) {
 
 func (engine *Engine) Init(config *EngineConfig) {
 	engine.config = config
+	blsbft.ByzantineDetectorObject.SetEvidenceBroadcaster(engine.broadcastEquivocationEvidence)
 	go engine.WatchCommitteeChange()
 }
 
+// broadcastEquivocationEvidence send double vote evidence found by byzantine detector to beacon,
+// evidence is dropped if it could not be verified against the current beacon view
+func (engine *Engine) broadcastEquivocationEvidence(evidence *consensustypes.EquivocationEvidence) {
+	if _, err := engine.config.Blockchain.VerifyEquivocationEvidence(evidence); err != nil {
+		Logger.Log.Debugf("Drop equivocation evidence of %+v, %+v", evidence.Validator(), err)
+		return
+	}
+	data, err := json.Marshal(evidence)
+	if err != nil {
+		Logger.Log.Error(err)
+		return
+	}
+	msg := wire.NewMessageEquivocation(data, byte(evidence.FirstVote.ChainID))
+	if err := engine.config.Node.PushMessageToChain(msg, engine.config.Blockchain.BeaconChain); err != nil {
+		Logger.Log.Error(err)
+	}
+}
+
 func (engine *Engine) Start() error {
 	defer Logger.Log.Infof("CONSENSUS: Start")
 
-	if engine.config.Node.GetPrivateKey() != "" {
+	if engine.config.Node.GetRemoteSigner() != "" {
+		engine.loadKeysFromRemoteSigner()
+	} else if engine.config.Node.GetPrivateKey() != "" {
 		engine.loadKeysFromPrivateKey()
 	} else if engine.config.Node.GetMiningKeys() != "" {
 		engine.loadKeysFromMiningKey()
 	}
+	if err := engine.checkSlashingProtectionHistory(); err != nil {
+		return err
+	}
 	engine.IsEnabled = 1
 	return nil
 }
 
+// checkSlashingProtectionHistory makes sure the mining keys could not double sign
+// a block that they signed before the local history
+func (engine *Engine) checkSlashingProtectionHistory() error {
+	if len(engine.validators) == 0 {
+		return nil
+	}
+	userKeys := []signatureschemes2.MiningKey{}
+	for _, validator := range engine.validators {
+		userKeys = append(userKeys, validator.MiningKey)
+	}
+	db := rawdb_consensus.GetConsensusDatabase()
+	beaconChain := engine.config.Blockchain.BeaconChain
+	if err := blsbft.CheckSlashingProtectionHistory(db, beaconChain, beaconChain, userKeys); err != nil {
+		return err
+	}
+	for _, shardChain := range engine.config.Blockchain.ShardChain {
+		if err := blsbft.CheckSlashingProtectionHistory(db, shardChain, beaconChain, userKeys); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func (engine *Engine) loadKeysFromPrivateKey() {
 	privateSeed, err := GenMiningKeyFromPrivateKey(engine.config.Node.GetPrivateKey())
 	if err != nil {
@@ -298,6 +349,28 @@ func (engine *Engine) loadKeysFromMiningKey() {
 	monitor.SetGlobalParam("MINING_PUBKEY", strings.Join(pubkeys, ","))
 }
 
+// loadKeysFromRemoteSigner loads the mining keys kept by the remote signer,
+// the private keys never leave the signer process
+func (engine *Engine) loadKeysFromRemoteSigner() {
+	miningKeys, err := remotesigner.LoadMiningKeys(engine.config.Node.GetRemoteSigner())
+	if err != nil {
+		panic(err)
+	}
+	engine.validators = []*consensus.Validator{}
+	for _, miningKey := range miningKeys {
+		engine.validators = append(engine.validators, &consensus.Validator{MiningKey: miningKey})
+	}
+	// @NOTICE: hack code, only allow one key
+	engine.validators = engine.validators[:1] //allow only 1 key
+
+	//set monitor pubkey
+	pubkeys := []string{}
+	for _, val := range engine.validators {
+		pubkeys = append(pubkeys, val.MiningKey.GetPublicKey().GetMiningKeyBase58("bls"))
+	}
+	monitor.SetGlobalParam("MINING_PUBKEY", strings.Join(pubkeys, ","))
+}
+
 func (engine *Engine) Stop() error {
 	Logger.Log.Infof("CONSENSUS: Stop")
 	for _, BFTProcess := range engine.bftProcess {
@@ -318,6 +391,11 @@ func (engine *Engine) OnBFTMsg(msg *wire.MessageBFT) {
 func (engine *Engine) GetAllValidatorKeyState() map[string]consensus.MiningState {
 	result := make(map[string]consensus.MiningState)
 	for _, validator := range engine.validators {
+		if validator.PrivateSeed == "" {
+			// the private seed of the remote signer keys is unknown
+			result[validator.MiningKey.GetPublicKeyBase58()] = validator.State
+			continue
+		}
 		result[validator.PrivateSeed] = validator.State
 	}
 	return result
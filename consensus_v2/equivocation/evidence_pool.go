@@ -0,0 +1,107 @@
+package equivocation
+
+import (
+	"sort"
+	"sync"
+
+	"github.com/incognitochain/incognito-chain/consensus_v2/consensustypes"
+	"github.com/incognitochain/incognito-chain/instruction"
+)
+
+var (
+	DefaultEvidencePool *EvidencePool
+)
+
+// EvidencePool keeps verified equivocation evidences received by beacon node, waiting to be included in beacon block
+// only one evidence is kept for each offender, offender is identified by its committee public key
+// EvidencePool could maintain different data in different beacon nodes
+type EvidencePool struct {
+	Evidences map[string]*consensustypes.EquivocationEvidence `json:"Evidences"`
+	mu        *sync.RWMutex
+}
+
+func NewEvidencePool() *EvidencePool {
+	return &EvidencePool{
+		Evidences: make(map[string]*consensustypes.EquivocationEvidence),
+		mu:        &sync.RWMutex{},
+	}
+}
+
+func NewDefaultEvidencePool() {
+	DefaultEvidencePool = NewEvidencePool()
+}
+
+// AddEvidence add a verified evidence of committeePublicKey,
+// return false if pool already has an evidence for this offender
+func (p *EvidencePool) AddEvidence(committeePublicKey string, evidence *consensustypes.EquivocationEvidence) bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if _, ok := p.Evidences[committeePublicKey]; ok {
+		return false
+	}
+	p.Evidences[committeePublicKey] = evidence
+	Logger.Infof("Add equivocation evidence of %+v, reason %+v", committeePublicKey, evidence.Reason)
+	return true
+}
+
+func (p *EvidencePool) GetEvidences() map[string]*consensustypes.EquivocationEvidence {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	res := make(map[string]*consensustypes.EquivocationEvidence)
+	for k, v := range p.Evidences {
+		res[k] = v
+	}
+	return res
+}
+
+// RemoveValidators remove evidences of offenders which are already processed
+func (p *EvidencePool) RemoveValidators(committeePublicKeys []string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for _, v := range committeePublicKeys {
+		delete(p.Evidences, v)
+	}
+}
+
+// Instructions build equivocation instructions of offenders in validators list, sorted by offender key
+func (p *EvidencePool) Instructions(validators []string) []*instruction.EquivocationInstruction {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	keys := []string{}
+	for _, v := range validators {
+		if _, ok := p.Evidences[v]; ok {
+			keys = append(keys, v)
+		}
+	}
+	sort.Strings(keys)
+
+	res := []*instruction.EquivocationInstruction{}
+	for i, k := range keys {
+		if i > 0 && keys[i-1] == k {
+			continue
+		}
+		res = append(res, instruction.NewEquivocationInstructionWithValue(k, p.Evidences[k]))
+	}
+	return res
+}
+
+// Clean remove evidences of offenders not in validators list any more
+func (p *EvidencePool) Clean(validators []string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	validatorMap := make(map[string]bool)
+	for _, v := range validators {
+		validatorMap[v] = true
+	}
+	Logger.Infof("Equivocation Evidence Pool, Length %+v", len(p.Evidences))
+	for k := range p.Evidences {
+		if !validatorMap[k] {
+			delete(p.Evidences, k)
+		}
+	}
+}
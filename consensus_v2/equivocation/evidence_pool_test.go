@@ -0,0 +1,66 @@
+package equivocation
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/consensus_v2/consensustypes"
+)
+
+var _ = func() (_ struct{}) {
+	Logger.Init(common.NewBackend(nil).Logger("test", true))
+	return
+}()
+
+func newTestEvidence(validator string) *consensustypes.EquivocationEvidence {
+	return consensustypes.NewEquivocationEvidence(
+		consensustypes.EquivocationSameTimeSlot,
+		consensustypes.EquivocationVote{BlockHash: "a", Validator: validator, ProposeTimeSlot: 1},
+		consensustypes.EquivocationVote{BlockHash: "b", Validator: validator, ProposeTimeSlot: 1},
+	)
+}
+
+func TestEvidencePool_AddEvidence(t *testing.T) {
+	p := NewEvidencePool()
+	evidence := newTestEvidence("bls1")
+	if !p.AddEvidence("key1", evidence) {
+		t.Fatal("first evidence of offender must be added")
+	}
+	if p.AddEvidence("key1", newTestEvidence("bls2")) {
+		t.Fatal("only one evidence is kept for each offender")
+	}
+	if got := p.GetEvidences(); !reflect.DeepEqual(got, map[string]*consensustypes.EquivocationEvidence{"key1": evidence}) {
+		t.Errorf("GetEvidences() = %+v", got)
+	}
+}
+
+func TestEvidencePool_Instructions(t *testing.T) {
+	p := NewEvidencePool()
+	p.AddEvidence("key3", newTestEvidence("bls3"))
+	p.AddEvidence("key1", newTestEvidence("bls1"))
+	p.AddEvidence("key2", newTestEvidence("bls2"))
+
+	instructions := p.Instructions([]string{"key3", "key1", "key4", "key1"})
+	got := []string{}
+	for _, inst := range instructions {
+		got = append(got, inst.PublicKey)
+	}
+	if want := []string{"key1", "key3"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Instructions() offenders = %+v, want %+v", got, want)
+	}
+}
+
+func TestEvidencePool_RemoveAndClean(t *testing.T) {
+	p := NewEvidencePool()
+	p.AddEvidence("key1", newTestEvidence("bls1"))
+	p.AddEvidence("key2", newTestEvidence("bls2"))
+	p.AddEvidence("key3", newTestEvidence("bls3"))
+
+	p.RemoveValidators([]string{"key1"})
+	p.Clean([]string{"key2"})
+
+	if got := p.GetEvidences(); len(got) != 1 || got["key2"] == nil {
+		t.Errorf("GetEvidences() = %+v, want only key2", got)
+	}
+}
@@ -0,0 +1,14 @@
+package equivocation
+
+import "github.com/incognitochain/incognito-chain/common"
+
+type EquivocationLogger struct {
+	common.Logger
+}
+
+func (self *EquivocationLogger) Init(inst common.Logger) {
+	self.Logger = inst
+}
+
+// Global instant to use
+var Logger = EquivocationLogger{}
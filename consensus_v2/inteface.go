@@ -24,6 +24,7 @@ type NodeInterface interface {
 	IsEnableMining() bool
 	GetMiningKeys() string
 	GetPrivateKey() string
+	GetRemoteSigner() string
 	GetUserMiningState() (role string, chainID int)
 	GetPubkeyMiningState(*incognitokey.CommitteePublicKey) (role string, chainID int)
 	IsBeaconFullnode(*incognitokey.CommitteePublicKey) (bool, string)
@@ -0,0 +1,124 @@
+package remotesigner
+
+import (
+	"context"
+	"errors"
+	"net"
+	"time"
+
+	"github.com/incognitochain/incognito-chain/common"
+	signatureschemes2 "github.com/incognitochain/incognito-chain/consensus_v2/signatureschemes"
+	"github.com/incognitochain/incognito-chain/consensus_v2/signatureschemes/blsmultisig"
+	"google.golang.org/grpc"
+)
+
+const requestTimeout = 5 * time.Second
+
+// Client signs consensus data of a mining key with the remote signer
+type Client struct {
+	conn      *grpc.ClientConn
+	publicKey string
+}
+
+// Dial connects to the remote signer listening on the unix socket
+func Dial(socketPath string) (*grpc.ClientConn, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+	defer cancel()
+	return grpc.DialContext(ctx, socketPath,
+		grpc.WithInsecure(),
+		grpc.WithBlock(),
+		grpc.WithDefaultCallOptions(grpc.CallContentSubtype(codecName)),
+		grpc.WithContextDialer(func(ctx context.Context, addr string) (net.Conn, error) {
+			var d net.Dialer
+			return d.DialContext(ctx, "unix", addr)
+		}),
+	)
+}
+
+// LoadMiningKeys returns the mining keys kept by the remote signer,
+// the keys have no private keys and sign with the remote signer
+func LoadMiningKeys(socketPath string) ([]signatureschemes2.MiningKey, error) {
+	conn, err := Dial(socketPath)
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+	defer cancel()
+	res := &GetPublicKeysResponse{}
+	if err := conn.Invoke(ctx, methodGetPublicKeys, &GetPublicKeysRequest{}, res); err != nil {
+		conn.Close()
+		return nil, err
+	}
+	if len(res.MiningPubKeys) == 0 {
+		conn.Close()
+		return nil, errors.New("Remote signer has no mining key")
+	}
+	miningKeys := []signatureschemes2.MiningKey{}
+	for _, pubKey := range res.MiningPubKeys {
+		miningKey := signatureschemes2.MiningKey{
+			PriKey: map[string][]byte{},
+			PubKey: pubKey,
+		}
+		miningKey.Signer = &Client{
+			conn:      conn,
+			publicKey: miningKey.GetPublicKey().GetMiningKeyBase58(common.BlsConsensus),
+		}
+		miningKeys = append(miningKeys, miningKey)
+	}
+	return miningKeys, nil
+}
+
+func (c *Client) BLSSignData(signingCtx *signatureschemes2.SigningContext, data []byte, selfIdx int, committee []blsmultisig.PublicKey) ([]byte, error) {
+	req := &SignRequest{
+		PublicKey:  c.publicKey,
+		Scheme:     common.BlsConsensus,
+		Data:       data,
+		SelfIdx:    selfIdx,
+		SigningCtx: signingCtx,
+	}
+	for _, pk := range committee {
+		req.Committee = append(req.Committee, pk)
+	}
+	return c.sign(req)
+}
+
+func (c *Client) BriSignData(signingCtx *signatureschemes2.SigningContext, data []byte) ([]byte, error) {
+	return c.sign(&SignRequest{
+		PublicKey:  c.publicKey,
+		Scheme:     common.BridgeConsensus,
+		Data:       data,
+		SigningCtx: signingCtx,
+	})
+}
+
+func (c *Client) SignPortalExternalTxs(
+	signingCtx *signatureschemes2.SigningContext, header []byte, insts [][]string, prevHeader []byte, prevInsts [][]string, prevPortalSigs []byte,
+) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+	defer cancel()
+	res := &SignPortalExternalTxsResponse{}
+	req := &SignPortalExternalTxsRequest{
+		PublicKey:      c.publicKey,
+		SigningCtx:     signingCtx,
+		Header:         header,
+		Insts:          insts,
+		PrevHeader:     prevHeader,
+		PrevInsts:      prevInsts,
+		PrevPortalSigs: prevPortalSigs,
+	}
+	err := c.conn.Invoke(ctx, methodSignPortalExternalTxs, req, res)
+	if err != nil {
+		return nil, err
+	}
+	return res.PortalSigs, nil
+}
+
+func (c *Client) sign(req *SignRequest) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+	defer cancel()
+	res := &SignResponse{}
+	if err := c.conn.Invoke(ctx, methodSign, req, res); err != nil {
+		return nil, err
+	}
+	return res.Signature, nil
+}
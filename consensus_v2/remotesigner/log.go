@@ -0,0 +1,14 @@
+package remotesigner
+
+import "github.com/incognitochain/incognito-chain/common"
+
+type remoteSignerLogger struct {
+	log common.Logger
+}
+
+func (self *remoteSignerLogger) Init(inst common.Logger) {
+	self.log = inst
+}
+
+// Global instant to use
+var Logger = remoteSignerLogger{}
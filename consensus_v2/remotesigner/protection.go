@@ -0,0 +1,12 @@
+package remotesigner
+
+import (
+	signatureschemes2 "github.com/incognitochain/incognito-chain/consensus_v2/signatureschemes"
+)
+
+// SlashingProtection keeps the history of votes and proposals signed by each mining key on each chain,
+// and refuses the signing requests that could get the mining key slashed.
+// The history must survive restarts of the signer, e.g. blsbft.SlashingProtection on the signer database
+type SlashingProtection interface {
+	CheckAndRecord(miningKey string, signingCtx *signatureschemes2.SigningContext) error
+}
@@ -0,0 +1,144 @@
+// Package remotesigner keeps the mining keys of a validator in a separate signer process.
+// The node talks to the signer over gRPC on a local unix socket, messages are json encoded.
+// The signer refuses votes and proposals that could get the validator slashed.
+package remotesigner
+
+import (
+	"context"
+	"encoding/json"
+
+	signatureschemes2 "github.com/incognitochain/incognito-chain/consensus_v2/signatureschemes"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/encoding"
+)
+
+const (
+	serviceName                 = "remotesigner.Signer"
+	methodGetPublicKeys         = "/" + serviceName + "/GetPublicKeys"
+	methodSign                  = "/" + serviceName + "/Sign"
+	methodSignPortalExternalTxs = "/" + serviceName + "/SignPortalExternalTxs"
+	codecName                   = "json"
+)
+
+type GetPublicKeysRequest struct{}
+
+type GetPublicKeysResponse struct {
+	MiningPubKeys []map[string][]byte
+}
+
+type SignRequest struct {
+	PublicKey  string // bls mining public key (base58) of the signing key
+	Scheme     string // common.BlsConsensus or common.BridgeConsensus
+	Data       []byte
+	SelfIdx    int
+	Committee  [][]byte
+	SigningCtx *signatureschemes2.SigningContext
+}
+
+type SignResponse struct {
+	Signature []byte
+}
+
+type SignPortalExternalTxsRequest struct {
+	PublicKey      string
+	SigningCtx     *signatureschemes2.SigningContext // the beacon block, its height gets the portal params
+	Header         []byte                            // json encoded header of the beacon block
+	Insts          [][]string
+	PrevHeader     []byte     // json encoded header of the previous block, if there are previous instructions
+	PrevInsts      [][]string // instructions of the previous block, for the MuSig2 sessions on its external txs
+	PrevPortalSigs []byte     // json encoded portal sigs of the previous block
+}
+
+type SignPortalExternalTxsResponse struct {
+	PortalSigs []byte
+}
+
+type signerServer interface {
+	GetPublicKeys(context.Context, *GetPublicKeysRequest) (*GetPublicKeysResponse, error)
+	Sign(context.Context, *SignRequest) (*SignResponse, error)
+	SignPortalExternalTxs(context.Context, *SignPortalExternalTxsRequest) (*SignPortalExternalTxsResponse, error)
+}
+
+type jsonCodec struct{}
+
+func (jsonCodec) Marshal(v interface{}) ([]byte, error) {
+	return json.Marshal(v)
+}
+
+func (jsonCodec) Unmarshal(data []byte, v interface{}) error {
+	return json.Unmarshal(data, v)
+}
+
+func (jsonCodec) Name() string {
+	return codecName
+}
+
+func init() {
+	encoding.RegisterCodec(jsonCodec{})
+}
+
+var signerServiceDesc = grpc.ServiceDesc{
+	ServiceName: serviceName,
+	HandlerType: (*signerServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetPublicKeys",
+			Handler:    getPublicKeysHandler,
+		},
+		{
+			MethodName: "Sign",
+			Handler:    signHandler,
+		},
+		{
+			MethodName: "SignPortalExternalTxs",
+			Handler:    signPortalExternalTxsHandler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "remotesigner",
+}
+
+func getPublicKeysHandler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetPublicKeysRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(signerServer).GetPublicKeys(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{Server: srv, FullMethod: methodGetPublicKeys}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(signerServer).GetPublicKeys(ctx, req.(*GetPublicKeysRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func signHandler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SignRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(signerServer).Sign(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{Server: srv, FullMethod: methodSign}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(signerServer).Sign(ctx, req.(*SignRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func signPortalExternalTxsHandler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SignPortalExternalTxsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(signerServer).SignPortalExternalTxs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{Server: srv, FullMethod: methodSignPortalExternalTxs}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(signerServer).SignPortalExternalTxs(ctx, req.(*SignPortalExternalTxsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
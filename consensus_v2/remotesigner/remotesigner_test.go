@@ -0,0 +1,262 @@
+package remotesigner
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/incognitochain/incognito-chain/blockchain/types"
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/config"
+	"github.com/incognitochain/incognito-chain/consensus_v2/blsbft"
+	signatureschemes2 "github.com/incognitochain/incognito-chain/consensus_v2/signatureschemes"
+	"github.com/incognitochain/incognito-chain/consensus_v2/signatureschemes/blsmultisig"
+	"github.com/incognitochain/incognito-chain/consensus_v2/signatureschemes/bridgesig"
+	"github.com/incognitochain/incognito-chain/incdb"
+	_ "github.com/incognitochain/incognito-chain/incdb/lvdb"
+	"github.com/incognitochain/incognito-chain/portal/portalv4"
+	"github.com/stretchr/testify/assert"
+)
+
+func init() {
+	Logger.Init(common.NewBackend(nil).Logger("test", true))
+}
+
+func newTestMiningKey(seed []byte) *signatureschemes2.MiningKey {
+	blsPriKey, blsPubKey := blsmultisig.KeyGen(seed)
+	bridgePriKey, bridgePubKey := bridgesig.KeyGen(seed)
+	return &signatureschemes2.MiningKey{
+		PriKey: map[string][]byte{
+			common.BlsConsensus:    blsmultisig.SKBytes(blsPriKey),
+			common.BridgeConsensus: bridgesig.SKBytes(&bridgePriKey),
+		},
+		PubKey: map[string][]byte{
+			common.BlsConsensus:    blsmultisig.PKBytes(blsPubKey),
+			common.BridgeConsensus: bridgesig.PKBytes(&bridgePubKey),
+		},
+	}
+}
+
+func newTestSigningContext(kind string, blockHash common.Hash, height uint64, produceTimeSlot, proposeTimeSlot int64) *signatureschemes2.SigningContext {
+	return &signatureschemes2.SigningContext{
+		Kind:               kind,
+		ChainID:            0,
+		BlockHeight:        height,
+		BlockHash:          blockHash.String(),
+		ProduceTimeSlot:    produceTimeSlot,
+		ProposeTimeSlot:    proposeTimeSlot,
+		CommitteeFromBlock: common.Hash{}.String(),
+	}
+}
+
+// startTestRemoteSigner serves the local keys on a temporary unix socket
+// and returns the keys signing with the remote signer
+func startTestRemoteSigner(t *testing.T, localKeys []*signatureschemes2.MiningKey) ([]signatureschemes2.MiningKey, func()) {
+	dir, err := ioutil.TempDir("", "remotesigner")
+	if err != nil {
+		t.Fatal(err)
+	}
+	socketPath := filepath.Join(dir, "signer.sock")
+	db, err := incdb.Open("leveldb", filepath.Join(dir, "db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	server := NewServer(localKeys, blsbft.NewSlashingProtection(db), func(uint64) portalv4.PortalParams { return portalv4.PortalParams{} })
+	go server.Serve(socketPath)
+	cleanUp := func() {
+		server.Stop()
+		db.Close()
+		os.RemoveAll(dir)
+	}
+
+	for i := 0; i < 50; i++ {
+		if _, err := os.Stat(socketPath); err == nil {
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	remoteKeys, err := LoadMiningKeys(socketPath)
+	if err != nil {
+		cleanUp()
+		t.Fatal(err)
+	}
+	return remoteKeys, cleanUp
+}
+
+func TestRemoteSigner(t *testing.T) {
+	localKeys := []*signatureschemes2.MiningKey{newTestMiningKey([]byte("seed1")), newTestMiningKey([]byte("seed2"))}
+	remoteKeys, cleanUp := startTestRemoteSigner(t, localKeys)
+	defer cleanUp()
+	assert.Equal(t, len(localKeys), len(remoteKeys))
+	for i := range remoteKeys {
+		assert.Equal(t, localKeys[i].GetPublicKeyBase58(), remoteKeys[i].GetPublicKeyBase58())
+		assert.Empty(t, remoteKeys[i].PriKey)
+	}
+
+	committee := []blsmultisig.PublicKey{localKeys[0].PubKey[common.BlsConsensus], localKeys[1].PubKey[common.BlsConsensus]}
+	blockHash := common.HashH([]byte("block"))
+	sig, err := remoteKeys[1].BLSSignVote(newTestSigningContext(signatureschemes2.SigningKindVote, blockHash, 10, 100, 100), blockHash.GetBytes(), 1, committee)
+	if err != nil {
+		t.Fatal(err)
+	}
+	valid, err := blsmultisig.Verify(sig, blockHash.GetBytes(), []int{1}, committee)
+	assert.Nil(t, err)
+	assert.True(t, valid)
+
+	// double vote
+	otherHash := common.HashH([]byte("other block"))
+	_, err = remoteKeys[1].BLSSignVote(newTestSigningContext(signatureschemes2.SigningKindVote, otherHash, 10, 100, 100), otherHash.GetBytes(), 1, committee)
+	assert.NotNil(t, err)
+	// data does not match the signing context
+	_, err = remoteKeys[1].BLSSignVote(newTestSigningContext(signatureschemes2.SigningKindVote, blockHash, 11, 101, 101), otherHash.GetBytes(), 1, committee)
+	assert.NotNil(t, err)
+	// bls signature without signing context
+	_, err = remoteKeys[1].BLSSignData(blockHash.GetBytes(), 1, committee)
+	assert.NotNil(t, err)
+
+	// bridge vote on the block hash of the voted block
+	bridgeHash := common.HashH([]byte("block hash"))
+	bridgeSigningCtx := newTestSigningContext(signatureschemes2.SigningKindBridgeVote, blockHash, 10, 100, 100)
+	bridgeSigningCtx.DataHash = bridgeHash.String()
+	_, err = remoteKeys[1].BriSignWithContext(bridgeSigningCtx, bridgeHash.GetBytes())
+	assert.Nil(t, err)
+	bridgeSigningCtx = newTestSigningContext(signatureschemes2.SigningKindBridgeVote, otherHash, 10, 100, 100)
+	bridgeSigningCtx.DataHash = bridgeHash.String()
+	_, err = remoteKeys[1].BriSignWithContext(bridgeSigningCtx, bridgeHash.GetBytes())
+	assert.NotNil(t, err)
+
+	// proposal signature
+	sig, err = remoteKeys[0].BriSignWithContext(newTestSigningContext(signatureschemes2.SigningKindPropose, blockHash, 10, 100, 100), blockHash.GetBytes())
+	if err != nil {
+		t.Fatal(err)
+	}
+	localSig, err := localKeys[0].BriSignData(blockHash.GetBytes())
+	assert.Nil(t, err)
+	assert.Equal(t, localSig, sig)
+	_, err = remoteKeys[0].BriSignWithContext(newTestSigningContext(signatureschemes2.SigningKindPropose, otherHash, 10, 100, 100), otherHash.GetBytes())
+	assert.NotNil(t, err)
+
+	// re-propose of the proposed block
+	reProposeHash := common.HashH([]byte("re-propose"))
+	reProposeSigningCtx := newTestSigningContext(signatureschemes2.SigningKindRePropose, blockHash, 10, 100, 100)
+	reProposeSigningCtx.DataHash = reProposeHash.String()
+	_, err = remoteKeys[0].BriSignWithContext(reProposeSigningCtx, reProposeHash.GetBytes())
+	assert.Nil(t, err)
+	_, err = remoteKeys[0].BriSignWithContext(reProposeSigningCtx, otherHash.GetBytes())
+	assert.NotNil(t, err)
+
+	// vote confirmation
+	message := append([]byte(blockHash.String()), []byte("vote")...)
+	confirmationSigningCtx := newTestSigningContext(signatureschemes2.SigningKindVoteConfirmation, blockHash, 10, 100, 100)
+	confirmationSigningCtx.Message = message
+	_, err = remoteKeys[0].BriSignWithContext(confirmationSigningCtx, common.HashB(message))
+	assert.Nil(t, err)
+	_, err = remoteKeys[0].BriSignWithContext(confirmationSigningCtx, otherHash.GetBytes())
+	assert.NotNil(t, err)
+
+	// messages are signed, block hashes are not
+	_, err = remoteKeys[0].BriSignData([]byte("finishsync"))
+	assert.Nil(t, err)
+	_, err = remoteKeys[0].BriSignData(otherHash.GetBytes())
+	assert.NotNil(t, err)
+	// bridge signature without signing context
+	_, err = remoteKeys[0].Signer.BriSignData(nil, []byte("finishsync"))
+	assert.NotNil(t, err)
+}
+
+// newTestBeaconHeader returns the json encoded beacon header of the instructions and its hashes
+func newTestBeaconHeader(t *testing.T, height uint64, prevHash common.Hash, insts [][]string) ([]byte, common.Hash, common.Hash) {
+	header := types.BeaconHeader{Version: 1, Height: height, PreviousBlockHash: prevHash}
+	instParts := ""
+	for _, inst := range insts {
+		instParts += strings.Join(inst, "")
+	}
+	if instParts != "" {
+		header.InstructionHash = common.HashH([]byte(instParts))
+	}
+	data, err := json.Marshal(header)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return data, header.Hash(), header.ProposeHash()
+}
+
+func TestRemoteSignerPortalExternalTxs(t *testing.T) {
+	config.AbortParam()
+	localKeys := []*signatureschemes2.MiningKey{newTestMiningKey([]byte("seed1"))}
+	remoteKeys, cleanUp := startTestRemoteSigner(t, localKeys)
+	defer cleanUp()
+	signer := remoteKeys[0].Signer
+	committee := []blsmultisig.PublicKey{localKeys[0].PubKey[common.BlsConsensus]}
+
+	prevInsts := [][]string{{"1", "prev"}}
+	insts := [][]string{{"1", "inst"}, {"2", "inst"}}
+	prevHeader, prevHash, _ := newTestBeaconHeader(t, 9, common.Hash{}, prevInsts)
+	header, _, proposeHash := newTestBeaconHeader(t, 10, prevHash, insts)
+	newPortalSigningCtx := func(blockHash common.Hash, height uint64, timeSlot int64) *signatureschemes2.SigningContext {
+		signingCtx := newTestSigningContext(signatureschemes2.SigningKindPortal, blockHash, height, timeSlot, timeSlot)
+		signingCtx.ChainID = common.BeaconChainID
+		return signingCtx
+	}
+	signingCtx := newPortalSigningCtx(proposeHash, 10, 100)
+
+	// the block is not voted yet
+	_, err := signer.SignPortalExternalTxs(signingCtx, header, insts, prevHeader, prevInsts, nil)
+	assert.NotNil(t, err)
+	voteSigningCtx := newTestSigningContext(signatureschemes2.SigningKindVote, proposeHash, 10, 100, 100)
+	voteSigningCtx.ChainID = common.BeaconChainID
+	_, err = remoteKeys[0].BLSSignVote(voteSigningCtx, proposeHash.GetBytes(), 0, committee)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// signing context without the block, or not for portal signatures
+	_, err = signer.SignPortalExternalTxs(nil, header, insts, prevHeader, prevInsts, nil)
+	assert.NotNil(t, err)
+	_, err = signer.SignPortalExternalTxs(newPortalSigningCtx(common.Hash{}, 0, 100), header, insts, prevHeader, prevInsts, nil)
+	assert.NotNil(t, err)
+	_, err = signer.SignPortalExternalTxs(voteSigningCtx, header, insts, prevHeader, prevInsts, nil)
+	assert.NotNil(t, err)
+	// instructions or headers do not match the block
+	_, err = signer.SignPortalExternalTxs(signingCtx, header, insts[:1], prevHeader, prevInsts, nil)
+	assert.NotNil(t, err)
+	_, err = signer.SignPortalExternalTxs(newPortalSigningCtx(proposeHash, 11, 100), header, insts, prevHeader, prevInsts, nil)
+	assert.NotNil(t, err)
+	_, err = signer.SignPortalExternalTxs(signingCtx, header, insts, prevHeader, [][]string{{"1", "other"}}, nil)
+	assert.NotNil(t, err)
+	otherPrevHeader, _, _ := newTestBeaconHeader(t, 8, common.Hash{}, prevInsts)
+	_, err = signer.SignPortalExternalTxs(signingCtx, header, insts, otherPrevHeader, prevInsts, nil)
+	assert.NotNil(t, err)
+
+	_, err = signer.SignPortalExternalTxs(signingCtx, header, insts, prevHeader, prevInsts, nil)
+	assert.Nil(t, err)
+	// signing again for the same block
+	_, err = signer.SignPortalExternalTxs(signingCtx, header, insts, nil, nil, nil)
+	assert.Nil(t, err)
+
+	// the external txs of another block of the same timeslot could spend the same UTXOs
+	otherInsts := [][]string{{"1", "other"}}
+	otherHeader, _, otherProposeHash := newTestBeaconHeader(t, 10, prevHash, otherInsts)
+	proposeSigningCtx := newTestSigningContext(signatureschemes2.SigningKindPropose, otherProposeHash, 10, 100, 100)
+	proposeSigningCtx.ChainID = common.BeaconChainID
+	_, err = remoteKeys[0].BriSignWithContext(proposeSigningCtx, otherProposeHash.GetBytes())
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = signer.SignPortalExternalTxs(newPortalSigningCtx(otherProposeHash, 10, 100), otherHeader, otherInsts, nil, nil, nil)
+	assert.NotNil(t, err)
+	// the next block
+	nextHeader, _, nextProposeHash := newTestBeaconHeader(t, 11, common.Hash{}, otherInsts)
+	voteSigningCtx = newTestSigningContext(signatureschemes2.SigningKindVote, nextProposeHash, 11, 101, 101)
+	voteSigningCtx.ChainID = common.BeaconChainID
+	_, err = remoteKeys[0].BLSSignVote(voteSigningCtx, nextProposeHash.GetBytes(), 0, committee)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = signer.SignPortalExternalTxs(newPortalSigningCtx(nextProposeHash, 11, 101), nextHeader, otherInsts, nil, nil, nil)
+	assert.Nil(t, err)
+}
@@ -0,0 +1,249 @@
+package remotesigner
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+
+	"github.com/incognitochain/incognito-chain/blockchain/types"
+	"github.com/incognitochain/incognito-chain/common"
+	signatureschemes2 "github.com/incognitochain/incognito-chain/consensus_v2/signatureschemes"
+	"github.com/incognitochain/incognito-chain/consensus_v2/signatureschemes/blsmultisig"
+	"github.com/incognitochain/incognito-chain/portal/portalv4"
+	portalprocessv4 "github.com/incognitochain/incognito-chain/portal/portalv4/portalprocess"
+	"google.golang.org/grpc"
+)
+
+// Server keeps the mining keys of validators and signs consensus data for the nodes
+// connecting to its unix socket
+type Server struct {
+	miningKeys   map[string]*signatureschemes2.MiningKey // bls public key (base58) => mining key
+	keyOrder     []string
+	protection   SlashingProtection
+	portalParams func(beaconHeight uint64) portalv4.PortalParams
+	grpcServer   *grpc.Server
+}
+
+func NewServer(
+	miningKeys []*signatureschemes2.MiningKey,
+	protection SlashingProtection,
+	portalParams func(beaconHeight uint64) portalv4.PortalParams,
+) *Server {
+	s := &Server{
+		miningKeys:   make(map[string]*signatureschemes2.MiningKey),
+		protection:   protection,
+		portalParams: portalParams,
+	}
+	for _, miningKey := range miningKeys {
+		pubKey := miningKey.GetPublicKey().GetMiningKeyBase58(common.BlsConsensus)
+		if _, ok := s.miningKeys[pubKey]; !ok {
+			s.keyOrder = append(s.keyOrder, pubKey)
+		}
+		s.miningKeys[pubKey] = miningKey
+	}
+	return s
+}
+
+// Serve listens on the unix socket and serves the signing requests until the server is stopped
+func (s *Server) Serve(socketPath string) error {
+	if err := os.RemoveAll(socketPath); err != nil {
+		return err
+	}
+	listener, err := net.Listen("unix", socketPath)
+	if err != nil {
+		return err
+	}
+	// only the user running the signer and the node could connect to the socket
+	if err := os.Chmod(socketPath, 0600); err != nil {
+		listener.Close()
+		return err
+	}
+	s.grpcServer = grpc.NewServer()
+	s.grpcServer.RegisterService(&signerServiceDesc, s)
+	Logger.log.Infof("Remote signer is listening on %v with %v mining keys", socketPath, len(s.keyOrder))
+	return s.grpcServer.Serve(listener)
+}
+
+func (s *Server) Stop() {
+	if s.grpcServer != nil {
+		s.grpcServer.Stop()
+	}
+}
+
+func (s *Server) GetPublicKeys(ctx context.Context, req *GetPublicKeysRequest) (*GetPublicKeysResponse, error) {
+	res := &GetPublicKeysResponse{}
+	for _, pubKey := range s.keyOrder {
+		res.MiningPubKeys = append(res.MiningPubKeys, s.miningKeys[pubKey].PubKey)
+	}
+	return res, nil
+}
+
+func (s *Server) Sign(ctx context.Context, req *SignRequest) (*SignResponse, error) {
+	miningKey, ok := s.miningKeys[req.PublicKey]
+	if !ok {
+		return nil, fmt.Errorf("Mining key %v not found", req.PublicKey)
+	}
+	if req.SigningCtx == nil {
+		return nil, errors.New("Signing context is required")
+	}
+	if err := checkSigningData(req.Scheme, req.SigningCtx, req.Data); err != nil {
+		return nil, err
+	}
+	if err := s.protection.CheckAndRecord(req.PublicKey, req.SigningCtx); err != nil {
+		Logger.log.Errorf("Refuse to sign %v of key %v: %v", req.SigningCtx.Kind, req.PublicKey, err)
+		return nil, err
+	}
+
+	switch req.Scheme {
+	case common.BlsConsensus:
+		committee := []blsmultisig.PublicKey{}
+		for _, pk := range req.Committee {
+			committee = append(committee, pk)
+		}
+		sig, err := miningKey.BLSSignData(req.Data, req.SelfIdx, committee)
+		if err != nil {
+			return nil, err
+		}
+		return &SignResponse{Signature: sig}, nil
+	case common.BridgeConsensus:
+		sig, err := miningKey.BriSignData(req.Data)
+		if err != nil {
+			return nil, err
+		}
+		return &SignResponse{Signature: sig}, nil
+	default:
+		return nil, fmt.Errorf("Signature scheme %v is not supported", req.Scheme)
+	}
+}
+
+// checkSigningData checks that the data is what the signing context describes,
+// so the signer never signs a block hash that the node has not shown to be a vote or a proposal
+func checkSigningData(scheme string, signingCtx *signatureschemes2.SigningContext, data []byte) error {
+	if scheme == common.BlsConsensus && signingCtx.Kind != signatureschemes2.SigningKindVote {
+		return fmt.Errorf("Signing kind %v is not supported for bls signatures", signingCtx.Kind)
+	}
+	switch signingCtx.Kind {
+	case signatureschemes2.SigningKindVote, signatureschemes2.SigningKindPropose:
+		// votes and proposals sign the propose hash of the block
+		dataHash, err := common.Hash{}.NewHash(data)
+		if err != nil || dataHash.String() != signingCtx.BlockHash {
+			return fmt.Errorf("Signing data does not match block hash %v", signingCtx.BlockHash)
+		}
+	case signatureschemes2.SigningKindBridgeVote, signatureschemes2.SigningKindRePropose:
+		dataHash, err := common.Hash{}.NewHash(data)
+		if err != nil || dataHash.String() != signingCtx.DataHash {
+			return fmt.Errorf("Signing data does not match data hash %v", signingCtx.DataHash)
+		}
+	case signatureschemes2.SigningKindVoteConfirmation:
+		if !bytes.Equal(common.HashB(signingCtx.Message), data) || !bytes.Contains(signingCtx.Message, []byte(signingCtx.BlockHash)) {
+			return fmt.Errorf("Signing data is not the confirmation of the vote for block %v", signingCtx.BlockHash)
+		}
+	case signatureschemes2.SigningKindMessage:
+		// a message signature could not be used as the signature on a block hash
+		if len(data) == common.HashSize {
+			return errors.New("Signing data of a message must not have the size of a hash")
+		}
+	default:
+		return fmt.Errorf("Signing kind %v is not supported", signingCtx.Kind)
+	}
+	return nil
+}
+
+func (s *Server) SignPortalExternalTxs(ctx context.Context, req *SignPortalExternalTxsRequest) (*SignPortalExternalTxsResponse, error) {
+	miningKey, ok := s.miningKeys[req.PublicKey]
+	if !ok {
+		return nil, fmt.Errorf("Mining key %v not found", req.PublicKey)
+	}
+	if req.SigningCtx == nil {
+		return nil, errors.New("Signing context is required")
+	}
+	if err := checkPortalSigningData(req); err != nil {
+		return nil, err
+	}
+	var prevPortalSigs []*portalprocessv4.PortalSig
+	if len(req.PrevPortalSigs) > 0 {
+		if err := json.Unmarshal(req.PrevPortalSigs, &prevPortalSigs); err != nil {
+			return nil, fmt.Errorf("Can not unmarshal portal sigs of the previous block %v", err)
+		}
+	}
+	if err := s.protection.CheckAndRecord(req.PublicKey, req.SigningCtx); err != nil {
+		Logger.log.Errorf("Refuse to sign %v of key %v: %v", req.SigningCtx.Kind, req.PublicKey, err)
+		return nil, err
+	}
+
+	seedKey := miningKey.PriKey[common.BridgeConsensus]
+	portalParams := s.portalParams(req.SigningCtx.BlockHeight)
+	portalSigs, err := portalprocessv4.CheckAndSignPortalUnshieldExternalTx(seedKey, req.Insts, portalParams)
+	if err != nil {
+		return nil, err
+	}
+	muSig2Sigs, err := portalprocessv4.SignPortalMuSig2Sessions(seedKey, req.PrevInsts, prevPortalSigs, portalParams)
+	if err != nil {
+		return nil, err
+	}
+	portalSigs = append(portalSigs, muSig2Sigs...)
+	data, err := json.Marshal(portalSigs)
+	if err != nil {
+		return nil, err
+	}
+	return &SignPortalExternalTxsResponse{PortalSigs: data}, nil
+}
+
+// checkPortalSigningData checks that the instructions are in the beacon block the signing context describes
+// and the previous instructions are in its previous block, so the signer never signs external txs
+// that the node has not shown to be in a block
+func checkPortalSigningData(req *SignPortalExternalTxsRequest) error {
+	signingCtx := req.SigningCtx
+	if signingCtx.Kind != signatureschemes2.SigningKindPortal || signingCtx.ChainID != common.BeaconChainID {
+		return fmt.Errorf("Signing kind %v of chain %v is not supported for portal signatures", signingCtx.Kind, signingCtx.ChainID)
+	}
+	if signingCtx.BlockHash == "" || signingCtx.BlockHeight == 0 {
+		return errors.New("Signing context of portal signatures must have the block hash and height")
+	}
+	header, err := decodeBeaconHeader(req.Header, req.Insts)
+	if err != nil {
+		return err
+	}
+	if header.Height != signingCtx.BlockHeight || header.ProposeHash().String() != signingCtx.BlockHash {
+		return fmt.Errorf("Block header does not match block %v at height %v", signingCtx.BlockHash, signingCtx.BlockHeight)
+	}
+	if len(req.PrevInsts) == 0 {
+		return nil
+	}
+	prevHeader, err := decodeBeaconHeader(req.PrevHeader, req.PrevInsts)
+	if err != nil {
+		return err
+	}
+	if prevHeader.Height+1 != header.Height || prevHeader.Hash() != header.PreviousBlockHash {
+		return fmt.Errorf("Previous block header does not match the previous block %v of block %v",
+			header.PreviousBlockHash.String(), signingCtx.BlockHash)
+	}
+	return nil
+}
+
+// decodeBeaconHeader decodes the json encoded beacon header and checks the instructions against its instruction hash,
+// which is the hash of all instruction parts, or zero if there is no instruction
+func decodeBeaconHeader(data []byte, insts [][]string) (*types.BeaconHeader, error) {
+	header := &types.BeaconHeader{}
+	if err := json.Unmarshal(data, header); err != nil {
+		return nil, fmt.Errorf("Can not unmarshal beacon header %v", err)
+	}
+	instHash := common.Hash{}
+	var buf bytes.Buffer
+	for _, inst := range insts {
+		for _, part := range inst {
+			buf.WriteString(part)
+		}
+	}
+	if buf.Len() > 0 {
+		instHash = common.HashH(buf.Bytes())
+	}
+	if instHash != header.InstructionHash {
+		return nil, fmt.Errorf("Instructions do not match instruction hash %v of block at height %v", header.InstructionHash.String(), header.Height)
+	}
+	return header, nil
+}
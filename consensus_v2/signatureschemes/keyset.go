@@ -13,6 +13,8 @@ import (
 type MiningKey struct {
 	PriKey map[string][]byte
 	PubKey map[string][]byte
+	// Signer signs on behalf of this mining key when the private keys are not kept in this process
+	Signer Signer `json:"-"`
 }
 
 func (miningKey *MiningKey) GetPublicKey() *incognitokey.CommitteePublicKey {
@@ -40,6 +42,9 @@ func (miningKey *MiningKey) BLSSignData(
 	[]byte,
 	error,
 ) {
+	if miningKey.Signer != nil {
+		return miningKey.Signer.BLSSignData(nil, data, selfIdx, committee)
+	}
 	sigBytes, err := blsmultisig.Sign(data, miningKey.PriKey[common.BlsConsensus], selfIdx, committee)
 	if err != nil {
 		return nil, err
@@ -53,6 +58,9 @@ func (miningKey *MiningKey) BriSignData(
 	[]byte,
 	error,
 ) {
+	if miningKey.Signer != nil {
+		return miningKey.Signer.BriSignData(NewMessageSigningContext(), data)
+	}
 	sig, err := bridgesig.Sign(miningKey.PriKey[common.BridgeConsensus], data)
 	if err != nil {
 		return nil, err
@@ -60,6 +68,38 @@ func (miningKey *MiningKey) BriSignData(
 	return sig, nil
 }
 
+// BLSSignVote signs the propose hash of the voted block,
+// the signing context lets the signer refuse double votes
+func (miningKey *MiningKey) BLSSignVote(
+	signingCtx *SigningContext,
+	data []byte,
+	selfIdx int,
+	committee []blsmultisig.PublicKey,
+) (
+	[]byte,
+	error,
+) {
+	if miningKey.Signer != nil {
+		return miningKey.Signer.BLSSignData(signingCtx, data, selfIdx, committee)
+	}
+	return miningKey.BLSSignData(data, selfIdx, committee)
+}
+
+// BriSignWithContext signs the data of a proposal, a bridge vote, a re-proposal or a vote confirmation,
+// the signing context lets the signer check the data and refuse double proposals
+func (miningKey *MiningKey) BriSignWithContext(
+	signingCtx *SigningContext,
+	data []byte,
+) (
+	[]byte,
+	error,
+) {
+	if miningKey.Signer != nil {
+		return miningKey.Signer.BriSignData(signingCtx, data)
+	}
+	return miningKey.BriSignData(data)
+}
+
 //DeepCopyMiningKeyArray deep coy for an array of mining keys
 func DeepCopyMiningKeyArray(src []MiningKey) []MiningKey {
 	res := make([]MiningKey, len(src))
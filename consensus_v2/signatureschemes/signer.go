@@ -0,0 +1,49 @@
+package signatureschemes
+
+import (
+	"github.com/incognitochain/incognito-chain/consensus_v2/signatureschemes/blsmultisig"
+)
+
+const (
+	SigningKindVote             = "vote"
+	SigningKindPropose          = "propose"
+	SigningKindBridgeVote       = "bridgevote"
+	SigningKindRePropose        = "repropose"
+	SigningKindVoteConfirmation = "voteconfirmation"
+	SigningKindMessage          = "message"
+	SigningKindPortal           = "portal"
+)
+
+// SigningContext describes the block that a consensus signature is created for.
+// Signers use it to enforce slashing protection rules.
+type SigningContext struct {
+	Kind               string // one of the SigningKind values
+	ChainID            int
+	BlockHeight        uint64
+	BlockHash          string // propose hash of the block
+	ProduceTimeSlot    int64
+	ProposeTimeSlot    int64
+	CommitteeFromBlock string
+	DataHash           string // signed hash of bridge votes and re-proposals, i.e. the block hash or the re-propose hash
+	Message            []byte // the vote fields hashed into the signed data of vote confirmations
+}
+
+// NewMessageSigningContext describes a node message that is not tied to a block, e.g. a finish sync message
+func NewMessageSigningContext() *SigningContext {
+	return &SigningContext{Kind: SigningKindMessage}
+}
+
+// Signer signs consensus data with mining keys that are kept outside of the node, e.g. by a remote signer process.
+// Every signing request has a signing context, which tells the signer what the data is.
+type Signer interface {
+	BLSSignData(signingCtx *SigningContext, data []byte, selfIdx int, committee []blsmultisig.PublicKey) ([]byte, error)
+	BriSignData(signingCtx *SigningContext, data []byte) ([]byte, error)
+	// SignPortalExternalTxs signs the portal v4 unshield external txs in the instructions of the beacon block
+	// with the bridge key and the MuSig2 sessions on the external txs in the previous block instructions,
+	// with the json encoded portal signatures of the previous block.
+	// The json encoded headers of the block and the previous block let the signer check the instructions
+	// against the block in the signing context. Returns the json encoded portal signatures
+	SignPortalExternalTxs(
+		signingCtx *SigningContext, header []byte, insts [][]string, prevHeader []byte, prevInsts [][]string, prevPortalSigs []byte,
+	) ([]byte, error)
+}
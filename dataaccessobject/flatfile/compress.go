@@ -0,0 +1,131 @@
+package flatfile
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io/ioutil"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+// codec of a record, stored in the highest byte of the record size
+const (
+	CodecNone     byte = iota // data is stored as given, also records written before codecs exist
+	CodecGzip                 // gzip
+	CodecZstd                 // zstd without dictionary
+	CodecZstdDict             // zstd with a dictionary trained on recent records
+)
+
+const (
+	recordSizeBits = 56
+	recordSizeMask = uint64(1)<<recordSizeBits - 1
+)
+
+// encodeRecordHeader pack the size and the codec of a record into 8 bytes
+func encodeRecordHeader(size uint64, codec byte) []byte {
+	b := make([]byte, 8)
+	binary.LittleEndian.PutUint64(b, size&recordSizeMask|uint64(codec)<<recordSizeBits)
+	return b
+}
+
+// decodeRecordHeader return the size and the codec of a record
+func decodeRecordHeader(b []byte) (uint64, byte) {
+	v := binary.LittleEndian.Uint64(b)
+	return v & recordSizeMask, byte(v >> recordSizeBits)
+}
+
+// ParseCodec return the codec of the name used in config
+func ParseCodec(name string) (byte, error) {
+	switch name {
+	case "", "none":
+		return CodecNone, nil
+	case "gzip":
+		return CodecGzip, nil
+	case "zstd":
+		return CodecZstd, nil
+	case "zstd_dict":
+		return CodecZstdDict, nil
+	}
+	return 0, fmt.Errorf("unknown flatfile codec %v", name)
+}
+
+// compressor compress and decompress records with all codecs, dictionaries have their own encoder and decoder
+type compressor struct {
+	encoder *zstd.Encoder
+	decoder *zstd.Decoder
+}
+
+func newCompressor() (*compressor, error) {
+	encoder, err := zstd.NewWriter(nil, zstd.WithEncoderConcurrency(1))
+	if err != nil {
+		return nil, err
+	}
+	decoder, err := zstd.NewReader(nil)
+	if err != nil {
+		return nil, err
+	}
+	return &compressor{
+		encoder: encoder,
+		decoder: decoder,
+	}, nil
+}
+
+// compress encode data with the codec, dict is only used by CodecZstdDict
+func (c *compressor) compress(codec byte, dict *dictionary, data []byte) ([]byte, error) {
+	switch codec {
+	case CodecNone:
+		return data, nil
+	case CodecGzip:
+		var buf bytes.Buffer
+		w := gzip.NewWriter(&buf)
+		if _, err := w.Write(data); err != nil {
+			return nil, err
+		}
+		if err := w.Close(); err != nil {
+			return nil, err
+		}
+		return buf.Bytes(), nil
+	case CodecZstd:
+		return c.encoder.EncodeAll(data, nil), nil
+	case CodecZstdDict:
+		if dict == nil {
+			return nil, errors.New("zstd dictionary is not available")
+		}
+		//the dictionary id is stored before the frame, so records can be checked without decoding them
+		result := make([]byte, 4)
+		binary.LittleEndian.PutUint32(result, dict.id)
+		return dict.encoder.EncodeAll(data, result), nil
+	}
+	return nil, fmt.Errorf("unknown flatfile codec %v", codec)
+}
+
+// decompress decode data written with the codec, getDict look up dictionaries by id
+func (c *compressor) decompress(codec byte, data []byte, getDict func(uint32) *dictionary) ([]byte, error) {
+	switch codec {
+	case CodecNone:
+		return data, nil
+	case CodecGzip:
+		r, err := gzip.NewReader(bytes.NewReader(data))
+		if err != nil {
+			return nil, err
+		}
+		defer r.Close()
+		return ioutil.ReadAll(r)
+	case CodecZstd:
+		return c.decoder.DecodeAll(data, nil)
+	case CodecZstdDict:
+		if len(data) < 4 {
+			return nil, errors.New("zstd dictionary record is too short")
+		}
+		dictID := binary.LittleEndian.Uint32(data)
+		dict := getDict(dictID)
+		if dict == nil {
+			return nil, fmt.Errorf("zstd dictionary %v not found", dictID)
+		}
+		return dict.decoder.DecodeAll(data[4:], nil)
+	}
+	return nil, fmt.Errorf("unknown flatfile codec %v", codec)
+}
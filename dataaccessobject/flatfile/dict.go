@@ -0,0 +1,172 @@
+package flatfile
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path"
+	"strconv"
+	"strings"
+
+	"github.com/incognitochain/incognito-chain/dataaccessobject"
+	zstddict "github.com/klauspost/compress/dict"
+	"github.com/klauspost/compress/zstd"
+)
+
+const dictFilePrefix = "dict_"
+
+// dictionary is a zstd dictionary, with the encoder and decoder of the records compressed with it
+type dictionary struct {
+	id      uint32
+	encoder *zstd.Encoder
+	decoder *zstd.Decoder
+}
+
+func newDictionary(id uint32, content []byte) (*dictionary, error) {
+	encoder, err := zstd.NewWriter(nil, zstd.WithEncoderConcurrency(1), zstd.WithEncoderDict(content))
+	if err != nil {
+		return nil, err
+	}
+	decoder, err := zstd.NewReader(nil, zstd.WithDecoderConcurrency(1), zstd.WithDecoderDicts(content))
+	if err != nil {
+		return nil, err
+	}
+	return &dictionary{id, encoder, decoder}, nil
+}
+
+// TrainDictionary build a zstd dictionary of at most maxSize bytes of history from samples, with the given id
+func TrainDictionary(id uint32, samples [][]byte, maxSize int) ([]byte, error) {
+	return zstddict.BuildZstdDict(samples, zstddict.Options{
+		MaxDictSize: maxSize,
+		HashBytes:   6,
+		ZstdDictID:  id,
+	})
+}
+
+func (f *FlatFileManager) dictPath(id uint32) string {
+	return path.Join(f.dataDir, dictFilePrefix+strconv.FormatUint(uint64(id), 10))
+}
+
+// loadDictionaries read all dictionaries of the flatfile folder, the latest one is used for new records
+func (f *FlatFileManager) loadDictionaries() error {
+	files, err := ioutil.ReadDir(f.dataDir)
+	if err != nil {
+		return err
+	}
+	for _, file := range files {
+		if !strings.HasPrefix(file.Name(), dictFilePrefix) {
+			continue
+		}
+		id, err := strconv.ParseUint(strings.TrimPrefix(file.Name(), dictFilePrefix), 10, 32)
+		if err != nil {
+			continue
+		}
+		content, err := ioutil.ReadFile(path.Join(f.dataDir, file.Name()))
+		if err != nil {
+			return err
+		}
+		if err := f.addDictionary(uint32(id), content); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (f *FlatFileManager) addDictionary(id uint32, content []byte) error {
+	dict, err := newDictionary(id, content)
+	if err != nil {
+		return err
+	}
+	f.dictLock.Lock()
+	defer f.dictLock.Unlock()
+	f.dicts[id] = dict
+	if f.currentDict == nil || f.currentDict.id < id {
+		f.currentDict = dict
+	}
+	return nil
+}
+
+// getDictionary return the dictionary by id, dictionaries trained by another manager of the folder are loaded from file
+func (f *FlatFileManager) getDictionary(id uint32) *dictionary {
+	f.dictLock.RLock()
+	dict, ok := f.dicts[id]
+	f.dictLock.RUnlock()
+	if ok {
+		return dict
+	}
+	content, err := ioutil.ReadFile(f.dictPath(id))
+	if err != nil {
+		return nil
+	}
+	if err := f.addDictionary(id, content); err != nil {
+		return nil
+	}
+	return f.getDictionary(id)
+}
+
+func (f *FlatFileManager) getCurrentDictionary() *dictionary {
+	f.dictLock.RLock()
+	defer f.dictLock.RUnlock()
+	return f.currentDict
+}
+
+// trainDictionary build a new dictionary from the recent samples, store it in the flatfile folder and use it for new records
+func (f *FlatFileManager) trainDictionary() error {
+	id := uint32(1)
+	if dict := f.getCurrentDictionary(); dict != nil {
+		id = dict.id + 1
+	}
+	content, err := TrainDictionary(id, f.samples, f.options.DictSize)
+	if err != nil {
+		return err
+	}
+	tmpPath := f.dictPath(id) + tmpFileSuffix
+	if err := writeFileSync(tmpPath, content); err != nil {
+		return err
+	}
+	if err := os.Rename(tmpPath, f.dictPath(id)); err != nil {
+		return err
+	}
+	if err := f.addDictionary(id, content); err != nil {
+		return fmt.Errorf("cannot load zstd dictionary %v: %v", id, err)
+	}
+	return nil
+}
+
+// addSample keep data as a sample of recent records, and train a new dictionary every DictTrainInterval records
+func (f *FlatFileManager) addSample(data []byte) {
+	if f.options.Codec != CodecZstdDict {
+		return
+	}
+	f.samples = append(f.samples, data)
+	if len(f.samples) > f.options.DictSamples {
+		f.samples = f.samples[len(f.samples)-f.options.DictSamples:]
+	}
+	f.samplesSinceTrain++
+	if f.samplesSinceTrain < f.options.DictTrainInterval && f.getCurrentDictionary() != nil {
+		return
+	}
+	if len(f.samples) < f.options.DictSamples {
+		return
+	}
+	f.samplesSinceTrain = 0
+	if err := f.trainDictionary(); err != nil {
+		dataaccessobject.Logger.Log.Errorf("cannot train zstd dictionary in %v: %v", f.dataDir, err)
+	}
+}
+
+func writeFileSync(p string, data []byte) error {
+	fd, err := os.OpenFile(p, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0666)
+	if err != nil {
+		return err
+	}
+	if _, err := fd.Write(data); err != nil {
+		fd.Close()
+		return err
+	}
+	if err := fd.Sync(); err != nil {
+		fd.Close()
+		return err
+	}
+	return fd.Close()
+}
@@ -1,8 +1,6 @@
 package flatfile
 
 import (
-	"bytes"
-	"encoding/binary"
 	"errors"
 	"fmt"
 	"io/ioutil"
@@ -16,6 +14,7 @@ import (
 
 	"os"
 	"strconv"
+	"strings"
 )
 
 type FlatFile interface {
@@ -38,6 +37,28 @@ type FlatFile interface {
 	Path() string
 
 	FileSize() uint64
+
+	//recompress closed files with the current codec, return number of rewritten files
+	Recompress() (int, error)
+}
+
+// Options of the compression of flatfile records
+type Options struct {
+	Codec             byte //codec of new records
+	LegacyCodec       byte //codec of records written before codecs exist
+	DictSize          int  //max size of zstd dictionaries
+	DictSamples       int  //number of recent records a dictionary is trained on
+	DictTrainInterval int  //number of records between dictionary trainings
+}
+
+// DefaultDictOptions return the options using zstd dictionaries trained on recent records
+func DefaultDictOptions() Options {
+	return Options{
+		Codec:             CodecZstdDict,
+		DictSize:          64 * 1024,
+		DictSamples:       20,
+		DictTrainInterval: 5000,
+	}
 }
 
 type FlatFileManager struct {
@@ -52,12 +73,21 @@ type FlatFileManager struct {
 	parseCache *lru.Cache
 	itemCache  *lru.Cache
 	lock       *sync.RWMutex
+
+	options           Options
+	compressor        *compressor
+	dictLock          *sync.RWMutex
+	dicts             map[uint32]*dictionary
+	currentDict       *dictionary
+	samples           [][]byte
+	samplesSinceTrain int
 }
 
 type ReadInfo struct {
 	fd     *os.File
 	offset int64
 	size   int64
+	codec  byte
 }
 
 func (ff *FlatFileManager) FileSize() uint64 {
@@ -108,38 +138,53 @@ func (f *FlatFileManager) PasreFile(fileID uint64) (map[uint64]ReadInfo, error)
 	if err != nil {
 		return nil, err
 	}
+	readInfos, err := parseRecords(fd)
+	if err != nil {
+		return nil, err
+	}
+
+	f.parseCache.Add(fileID, readInfos)
+
+	return readInfos, nil
+}
+
+// parseRecords return the position, size and codec of all records of a file
+func parseRecords(fd *os.File) (map[uint64]ReadInfo, error) {
 	readInfos := make(map[uint64]ReadInfo)
 	offset := int64(0)
-	size := 0
 	for {
 		b := make([]byte, 8)
 		n, err := fd.ReadAt(b, offset)
 		if err != nil && err.Error() != "EOF" {
 			return nil, err
 		}
-		var result int64
-		err = binary.Read(bytes.NewBuffer(b), binary.LittleEndian, &result)
-		if err != nil || n == 0 {
+		if n < 8 {
 			break
 		}
+		result, codec := decodeRecordHeader(b)
 
 		readInf := ReadInfo{
 			fd,
 			offset + 8,
-			result,
+			int64(result),
+			codec,
 		}
 		id := len(readInfos)
 		readInfos[uint64(id)] = readInf
 		offset += 8
-		offset += result
-		size++
+		offset += int64(result)
 	}
-
-	f.parseCache.Add(fileID, readInfos)
-
 	return readInfos, nil
 }
 
+// decodeRecord decompress the data of a record
+func (f FlatFileManager) decodeRecord(codec byte, data []byte) ([]byte, error) {
+	if codec == CodecNone {
+		codec = f.options.LegacyCodec
+	}
+	return f.compressor.decompress(codec, data, f.getDictionary)
+}
+
 func (f FlatFileManager) Read(index uint64) ([]byte, error) {
 
 	//get from cache byte firm
@@ -175,10 +220,11 @@ func (f FlatFileManager) Read(index uint64) ([]byte, error) {
 
 	b := make([]byte, readInfo[itemFileIndex].size)
 	readInfo[itemFileIndex].fd.ReadAt(b, int64(readInfo[itemFileIndex].offset))
-	rawB, err := ioutil.ReadAll(bytes.NewBuffer(b))
+	rawB, err := f.decodeRecord(readInfo[itemFileIndex].codec, b)
 	if err != nil {
 		return nil, err
 	}
+	f.itemCache.Add(index, rawB)
 	return rawB, nil
 }
 
@@ -222,8 +268,14 @@ func (f FlatFileManager) ReadFromIndex(index uint64) (chan []byte, chan uint64,
 				startIndex = offset
 			}
 			for j := int(startIndex); j < len(readInfo); j++ {
-				rawB := make([]byte, readInfo[uint64(j)].size)
-				readInfo[uint64(j)].fd.ReadAt(rawB, readInfo[uint64(j)].offset)
+				b := make([]byte, readInfo[uint64(j)].size)
+				readInfo[uint64(j)].fd.ReadAt(b, readInfo[uint64(j)].offset)
+				rawB, err := f.decodeRecord(readInfo[uint64(j)].codec, b)
+				if err != nil {
+					e <- 1
+					cancel()
+					return
+				}
 
 			LOOP:
 				if !closed {
@@ -292,11 +344,10 @@ func (f *FlatFileManager) checkFileSize() (uint64, error) {
 	for {
 		b := make([]byte, 8)
 		n, _ := f.currentFD.ReadAt(b, int64(offset))
-		var result uint64
-		err := binary.Read(bytes.NewBuffer(b), binary.LittleEndian, &result)
-		if err != nil || n == 0 {
+		if n < 8 {
 			break
 		}
+		result, _ := decodeRecordHeader(b)
 
 		offset += 8
 		offset += result
@@ -311,14 +362,22 @@ func (f *FlatFileManager) Append(data []byte) (uint64, error) {
 	f.lock.Lock()
 	defer f.lock.Unlock()
 
+	codec := f.options.Codec
+	dict := f.getCurrentDictionary()
+	if codec == CodecZstdDict && dict == nil {
+		codec = CodecZstd
+	}
+	record, err := f.compressor.compress(codec, dict, data)
+	if err != nil {
+		return 0, err
+	}
+
 	//append size-bytes uint64o current FD, if max -> create new file, update currentFD
-	var result = make([]byte, 8)
-	binary.LittleEndian.PutUint64(result, uint64(len(data)))
-	_, err := f.currentFD.Write(result)
+	_, err = f.currentFD.Write(encodeRecordHeader(uint64(len(record)), codec))
 	if err != nil {
 		return 0, err
 	}
-	_, err = f.currentFD.Write(data)
+	_, err = f.currentFD.Write(record)
 	if err != nil {
 		return 0, err
 	}
@@ -333,10 +392,23 @@ func (f *FlatFileManager) Append(data []byte) (uint64, error) {
 		f.newNextFile()
 	}
 	f.itemCache.Add(addedItemIndex, data)
+	f.addSample(data)
 	return addedItemIndex, err
 }
 
 func NewFlatFile(dir string, fileBound uint64) (*FlatFileManager, error) {
+	return NewFlatFileWithOptions(dir, fileBound, Options{})
+}
+
+// NewFlatFileWithOptions open the flatfile compressing new records with options.Codec
+func NewFlatFileWithOptions(dir string, fileBound uint64, options Options) (*FlatFileManager, error) {
+	if options.Codec == CodecNone && options.LegacyCodec != CodecNone {
+		return nil, errors.New("records without codec cannot be written when legacy codec is set")
+	}
+	compressor, err := newCompressor()
+	if err != nil {
+		return nil, err
+	}
 	cache, _ := lru.New(4)
 	itemCache, _ := lru.New(50)
 	ff := &FlatFileManager{
@@ -346,10 +418,14 @@ func NewFlatFile(dir string, fileBound uint64) (*FlatFileManager, error) {
 		lock:          new(sync.RWMutex),
 		parseCache:    cache,
 		itemCache:     itemCache,
+		options:       options,
+		compressor:    compressor,
+		dictLock:      new(sync.RWMutex),
+		dicts:         make(map[uint32]*dictionary),
 	}
 
 	//read all file has number  in folder -> uint64o folderMap, sortedFolder
-	err := os.MkdirAll(dir, os.ModePerm)
+	err = os.MkdirAll(dir, os.ModePerm)
 	if err != nil {
 		return nil, err
 	}
@@ -357,10 +433,19 @@ func NewFlatFile(dir string, fileBound uint64) (*FlatFileManager, error) {
 	if err != nil {
 		return nil, err
 	}
+	err = ff.loadDictionaries()
+	if err != nil {
+		return nil, err
+	}
 
 	currentFile := -1
 	for _, f := range files {
 		name := filepath.Base(f.Name())
+		//remove files of an interrupted recompression or dictionary training
+		if strings.HasSuffix(name, tmpFileSuffix) {
+			os.Remove(path.Join(dir, name))
+			continue
+		}
 		i, err := strconv.Atoi(name)
 		if err == nil {
 			ff.folderMap[uint64(i)] = true
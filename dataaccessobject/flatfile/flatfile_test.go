@@ -1,7 +1,10 @@
 package flatfile
 
 import (
+	"bytes"
 	"fmt"
+	"io/ioutil"
+	"os"
 	"testing"
 	"time"
 )
@@ -29,3 +32,118 @@ func TestFlatFileManager_ReadFromIndex(t *testing.T) {
 		}
 	}
 }
+
+func testRecord(i int) []byte {
+	return []byte(fmt.Sprintf(`{"Header":{"Height":%v,"Producer":"1Uv2zzR4LgfX8ToQe8ub3bYcCLk3uDU1sm9U9hiu9EKYXoS77UdikKRLK9pVYfDrfMP2Dp6jufMV2zC9fsUFBpnJEfH6dUFiwATJ8ciDs","Round":1},"Body":{"Transactions":[]}}`, i))
+}
+
+func TestFlatFileManager_MixedCodecs(t *testing.T) {
+	dir, err := ioutil.TempDir("", "flatfile")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	//legacy records are gzip data without codec
+	ff, err := NewFlatFile(dir, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c, _ := newCompressor()
+	for i := 0; i < 15; i++ {
+		b, _ := c.compress(CodecGzip, nil, testRecord(i))
+		ff.Append(b)
+	}
+
+	options := DefaultDictOptions()
+	options.LegacyCodec = CodecGzip
+	options.DictSamples = 5
+	options.DictTrainInterval = 10
+	ff, err = NewFlatFileWithOptions(dir, 10, options)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 15; i < 45; i++ {
+		ff.Append(testRecord(i))
+	}
+	if ff.getCurrentDictionary() == nil || ff.getCurrentDictionary().id != 3 {
+		t.Fatalf("dictionary should be trained 3 times, got %+v", ff.getCurrentDictionary())
+	}
+
+	//reopen to read without cache
+	ff, err = NewFlatFileWithOptions(dir, 10, options)
+	if err != nil {
+		t.Fatal(err)
+	}
+	check := func() {
+		for i := 0; i < 45; i++ {
+			data, err := ff.Read(uint64(i))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !bytes.Equal(data, testRecord(i)) {
+				t.Fatalf("record %v = %s", i, data)
+			}
+		}
+	}
+	check()
+	readInfos, _ := ff.PasreFile(1)
+	if readInfos[4].codec != CodecNone || readInfos[5].codec != CodecZstd {
+		t.Errorf("file 1 should have mixed codecs")
+	}
+	if readInfos, _ := ff.PasreFile(2); readInfos[0].codec != CodecZstdDict {
+		t.Errorf("file 2 should use dictionary")
+	}
+
+	count, err := ff.Recompress()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if count != 4 {
+		t.Errorf("Recompress() = %v, want 4", count)
+	}
+	ff.itemCache.Purge()
+	check()
+	if count, _ := ff.Recompress(); count != 0 {
+		t.Errorf("second Recompress() = %v, want 0", count)
+	}
+	for i := 0; i < 10; i++ {
+		readInfos, _ = ff.PasreFile(1)
+		if need, _ := needRecompress(readInfos[uint64(i)], CodecZstdDict, ff.getCurrentDictionary()); need {
+			t.Errorf("record %v is not recompressed", 10+i)
+		}
+	}
+}
+
+func TestTrainDictionary(t *testing.T) {
+	samples := [][]byte{}
+	for i := 0; i < 20; i++ {
+		samples = append(samples, testRecord(i))
+	}
+	content, err := TrainDictionary(7, samples, 4096)
+	if err != nil {
+		t.Fatal(err)
+	}
+	dict, err := newDictionary(7, content)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c, _ := newCompressor()
+	data := testRecord(100)
+	b, err := c.compress(CodecZstdDict, dict, data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if plain, _ := c.compress(CodecZstd, nil, data); len(b) >= len(plain) {
+		t.Errorf("dictionary record is %v bytes, want less than %v", len(b), len(plain))
+	}
+	result, err := c.decompress(CodecZstdDict, b, func(id uint32) *dictionary {
+		if id == 7 {
+			return dict
+		}
+		return nil
+	})
+	if err != nil || !bytes.Equal(result, data) {
+		t.Errorf("decompress() = %s, %v", result, err)
+	}
+}
@@ -0,0 +1,152 @@
+package flatfile
+
+import (
+	"encoding/binary"
+	"os"
+	"path"
+	"sort"
+	"strconv"
+)
+
+const tmpFileSuffix = ".tmp"
+
+// targetCodec return the codec and dictionary of new records
+func (f *FlatFileManager) targetCodec() (byte, *dictionary) {
+	codec := f.options.Codec
+	dict := f.getCurrentDictionary()
+	if codec == CodecZstdDict && dict == nil {
+		codec = CodecZstd
+	}
+	return codec, dict
+}
+
+// needRecompress check if a record is not written with the target codec and dictionary
+func needRecompress(info ReadInfo, codec byte, dict *dictionary) (bool, error) {
+	if info.codec != codec {
+		return true, nil
+	}
+	if codec != CodecZstdDict {
+		return false, nil
+	}
+	b := make([]byte, 4)
+	if _, err := info.fd.ReadAt(b, info.offset); err != nil {
+		return false, err
+	}
+	return binary.LittleEndian.Uint32(b) != dict.id, nil
+}
+
+// RecompressFile rewrite a closed file with the current codec, records keep their index.
+// The file is written next to the old one and renamed in place, so reads are not interrupted
+func (f *FlatFileManager) RecompressFile(fileID uint64) (bool, error) {
+	f.lock.RLock()
+	currentFile := f.currentFile
+	f.lock.RUnlock()
+	if fileID >= currentFile {
+		return false, nil
+	}
+
+	p := path.Join(f.dataDir, strconv.Itoa(int(fileID)))
+	fd, err := os.Open(p)
+	if err != nil {
+		return false, err
+	}
+	defer fd.Close()
+	readInfos, err := parseRecords(fd)
+	if err != nil {
+		return false, err
+	}
+
+	codec, dict := f.targetCodec()
+	changed := false
+	for i := 0; i < len(readInfos) && !changed; i++ {
+		changed, err = needRecompress(readInfos[uint64(i)], codec, dict)
+		if err != nil {
+			return false, err
+		}
+	}
+	if !changed {
+		return false, nil
+	}
+
+	tmpPath := p + tmpFileSuffix
+	tmpFD, err := os.OpenFile(tmpPath, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0666)
+	if err != nil {
+		return false, err
+	}
+	writeErr := func() error {
+		for i := 0; i < len(readInfos); i++ {
+			info := readInfos[uint64(i)]
+			b := make([]byte, info.size)
+			if _, err := fd.ReadAt(b, info.offset); err != nil {
+				return err
+			}
+			recordCodec := info.codec
+			if need, err := needRecompress(info, codec, dict); err != nil {
+				return err
+			} else if need {
+				data, err := f.decodeRecord(info.codec, b)
+				if err != nil {
+					return err
+				}
+				if b, err = f.compressor.compress(codec, dict, data); err != nil {
+					return err
+				}
+				recordCodec = codec
+			}
+			if _, err := tmpFD.Write(encodeRecordHeader(uint64(len(b)), recordCodec)); err != nil {
+				return err
+			}
+			if _, err := tmpFD.Write(b); err != nil {
+				return err
+			}
+		}
+		return tmpFD.Sync()
+	}()
+	tmpFD.Close()
+	if writeErr != nil {
+		os.Remove(tmpPath)
+		return false, writeErr
+	}
+
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	//file could be truncated meanwhile
+	if _, err := os.Stat(p); err != nil {
+		os.Remove(tmpPath)
+		return false, nil
+	}
+	if err := os.Rename(tmpPath, p); err != nil {
+		os.Remove(tmpPath)
+		return false, err
+	}
+	f.parseCache.Remove(fileID)
+	return true, nil
+}
+
+// Recompress rewrite all closed files having records not written with the current codec
+func (f *FlatFileManager) Recompress() (int, error) {
+	f.lock.RLock()
+	fileIDs := []uint64{}
+	for fileID := range f.folderMap {
+		if fileID < f.currentFile {
+			fileIDs = append(fileIDs, fileID)
+		}
+	}
+	f.lock.RUnlock()
+	sort.Slice(fileIDs, func(i, j int) bool { return fileIDs[i] < fileIDs[j] })
+
+	count := 0
+	for _, fileID := range fileIDs {
+		changed, err := f.RecompressFile(fileID)
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return count, err
+		}
+		if changed {
+			count++
+		}
+	}
+	return count, nil
+}
@@ -22,6 +22,7 @@ var voteByBlockHashPrefix = []byte("v-bh" + string(splitter))
 var proposeHistoryPrefix = []byte("p-h" + string(splitter))
 var receiveBlockByHashPrefix = []byte("rb-hash" + string(splitter))
 var voteHistoryPrefix = []byte("v-h" + string(splitter))
+var slashingProtectionPrefix = []byte("sp" + string(splitter))
 
 func GetShardFinalityProofPrefix(shardID byte) []byte {
 	temp := make([]byte, len(shardFinalityProofPrefix))
@@ -100,3 +101,17 @@ func GetVoteHistoryKey(chainID int, height uint64) []byte {
 	key := append(temp, common.Uint64ToBytes(height)...)
 	return key
 }
+
+func GetSlashingProtectionPrefix() []byte {
+	temp := make([]byte, len(slashingProtectionPrefix))
+	copy(temp, slashingProtectionPrefix)
+	return temp
+}
+
+func GetSlashingProtectionKey(validator string, chainID int) []byte {
+	temp := GetSlashingProtectionPrefix()
+	key := append(temp, []byte(validator)...)
+	key = append(key, splitter...)
+	key = append(key, common.Int32ToBytes(int32(chainID))...)
+	return key
+}
@@ -0,0 +1,104 @@
+package rawdb_consensus
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/incdb"
+)
+
+// SlashingProtectionRecord is the highest block that a validator has voted, proposed
+// and signed the portal external txs of on a chain
+type SlashingProtectionRecord struct {
+	LastVotedHeight           uint64
+	LastVotedTimeSlot         int64
+	LastVotedBlockHash        string
+	LastProposedHeight        uint64
+	LastProposedTimeSlot      int64
+	LastProposedBlockHash     string
+	LastPortalSignedHeight    uint64
+	LastPortalSignedTimeSlot  int64
+	LastPortalSignedBlockHash string
+}
+
+func NewSlashingProtectionRecord() *SlashingProtectionRecord {
+	return &SlashingProtectionRecord{}
+}
+
+func StoreSlashingProtectionRecord(db incdb.KeyValueWriter, validator string, chainID int, record *SlashingProtectionRecord) error {
+
+	key := GetSlashingProtectionKey(validator, chainID)
+
+	value, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+
+	if err := db.Put(key, value); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func GetSlashingProtectionRecord(db incdb.KeyValueReader, validator string, chainID int) (bool, *SlashingProtectionRecord, error) {
+
+	key := GetSlashingProtectionKey(validator, chainID)
+	has, err := db.Has(key)
+	if err != nil {
+		return false, nil, err
+	}
+
+	if !has {
+		return false, nil, nil
+	}
+
+	data, err := db.Get(key)
+	if err != nil {
+		return false, nil, err
+	}
+	record := NewSlashingProtectionRecord()
+
+	if err := json.Unmarshal(data, record); err != nil {
+		return false, nil, err
+	}
+
+	return true, record, nil
+}
+
+// GetAllSlashingProtectionRecords returns the records of all validators, validator => chainID => record
+func GetAllSlashingProtectionRecords(db incdb.Database) (map[string]map[int]*SlashingProtectionRecord, error) {
+
+	prefix := GetSlashingProtectionPrefix()
+
+	it := db.NewIteratorWithPrefix(prefix)
+	defer it.Release()
+
+	res := make(map[string]map[int]*SlashingProtectionRecord)
+
+	for it.Next() {
+		key := make([]byte, len(it.Key()))
+		copy(key, it.Key())
+		keys := strings.Split(string(key), string(splitter))
+		validator := keys[1]
+		chainID, err := common.BytesToInt32([]byte(keys[2]))
+		if err != nil {
+			return nil, err
+		}
+
+		data := make([]byte, len(it.Value()))
+		copy(data, it.Value())
+		record := NewSlashingProtectionRecord()
+		if err := json.Unmarshal(data, record); err != nil {
+			return nil, err
+		}
+
+		if _, ok := res[validator]; !ok {
+			res[validator] = make(map[int]*SlashingProtectionRecord)
+		}
+		res[validator][int(chainID)] = record
+	}
+
+	return res, nil
+}
@@ -24,14 +24,146 @@ func GetBeaconRootsHash(db incdb.KeyValueReader, hash common.Hash) ([]byte, erro
 	return data, err
 }
 
-// StoreBeaconBlock store block hash => block value
-func StoreBeaconBlockByHash(db incdb.KeyValueWriter, hash common.Hash, v interface{}) error {
-	keyHash := GetBeaconHashToBlockKey(hash)
-	val, err := json.Marshal(v)
+// StoreBeaconCommitteeStateDiff store block hash => committee state diff of block, only when committee change index is enabled
+func StoreBeaconCommitteeStateDiff(db incdb.KeyValueWriter, hash common.Hash, diff interface{}) error {
+	key := GetBeaconCommitteeStateDiffKey(hash)
+	b, err := json.Marshal(diff)
 	if err != nil {
-		return NewRawdbError(StoreBeaconBlockError, err)
+		return NewRawdbError(StoreBeaconCommitteeStateDiffError, err)
+	}
+	if err := db.Put(key, b); err != nil {
+		return NewRawdbError(StoreBeaconCommitteeStateDiffError, err)
+	}
+	return nil
+}
+
+func GetBeaconCommitteeStateDiff(db incdb.KeyValueReader, hash common.Hash) ([]byte, error) {
+	key := GetBeaconCommitteeStateDiffKey(hash)
+	data, err := db.Get(key)
+	if err != nil {
+		return nil, NewRawdbError(GetBeaconCommitteeStateDiffError, err)
+	}
+	return data, nil
+}
+
+// StoreDelegationEvents store delegation events of a reward receiver in a beacon block, only when delegation index is enabled
+func StoreDelegationEvents(db incdb.KeyValueWriter, receiverPublicKey []byte, beaconHeight uint64, beaconHash common.Hash, events interface{}) error {
+	key := GetDelegationEventKey(receiverPublicKey, beaconHeight, beaconHash)
+	b, err := json.Marshal(events)
+	if err != nil {
+		return NewRawdbError(StoreDelegationEventError, err)
+	}
+	if err := db.Put(key, b); err != nil {
+		return NewRawdbError(StoreDelegationEventError, err)
+	}
+	return nil
+}
+
+// GetDelegationEvents return all stored delegation events of a reward receiver, including events of forked blocks
+func GetDelegationEvents(db incdb.Database, receiverPublicKey []byte) ([][]byte, error) {
+	iterator := db.NewIteratorWithPrefix(GetDelegationEventPrefix(receiverPublicKey))
+	defer iterator.Release()
+	res := [][]byte{}
+	for iterator.Next() {
+		value := make([]byte, len(iterator.Value()))
+		copy(value, iterator.Value())
+		res = append(res, value)
+	}
+	if err := iterator.Error(); err != nil {
+		return nil, NewRawdbError(GetDelegationEventError, err)
+	}
+	return res, nil
+}
+
+// StoreSharePriceHistory store new share price of a beacon uid in a beacon block, only when delegation index is enabled
+func StoreSharePriceHistory(db incdb.KeyValueWriter, beaconUID common.Hash, beaconHeight uint64, beaconHash common.Hash, price interface{}) error {
+	key := GetSharePriceHistoryKey(beaconUID, beaconHeight, beaconHash)
+	b, err := json.Marshal(price)
+	if err != nil {
+		return NewRawdbError(StoreSharePriceHistoryError, err)
+	}
+	if err := db.Put(key, b); err != nil {
+		return NewRawdbError(StoreSharePriceHistoryError, err)
+	}
+	return nil
+}
+
+// GetSharePriceHistory return all stored share prices of a beacon uid, including prices of forked blocks
+func GetSharePriceHistory(db incdb.Database, beaconUID common.Hash) ([][]byte, error) {
+	iterator := db.NewIteratorWithPrefix(GetSharePriceHistoryPrefix(beaconUID))
+	defer iterator.Release()
+	res := [][]byte{}
+	for iterator.Next() {
+		value := make([]byte, len(iterator.Value()))
+		copy(value, iterator.Value())
+		res = append(res, value)
+	}
+	if err := iterator.Error(); err != nil {
+		return nil, NewRawdbError(GetSharePriceHistoryError, err)
 	}
-	if err := db.Put(keyHash, val); err != nil {
+	return res, nil
+}
+
+// StoreLightClientBeaconHeader store a verified beacon header of light client by height
+func StoreLightClientBeaconHeader(db incdb.KeyValueWriter, height uint64, header interface{}) error {
+	b, err := json.Marshal(header)
+	if err != nil {
+		return NewRawdbError(StoreLightClientBeaconHeaderError, err)
+	}
+	if err := db.Put(GetLightClientBeaconHeaderKey(height), b); err != nil {
+		return NewRawdbError(StoreLightClientBeaconHeaderError, err)
+	}
+	return nil
+}
+
+func GetLightClientBeaconHeader(db incdb.KeyValueReader, height uint64) ([]byte, error) {
+	data, err := db.Get(GetLightClientBeaconHeaderKey(height))
+	if err != nil {
+		return nil, NewRawdbError(GetLightClientBeaconHeaderError, err)
+	}
+	return data, nil
+}
+
+// StoreLightClientState store latest verified height and tracked beacon committee of light client
+func StoreLightClientState(db incdb.KeyValueWriter, state interface{}) error {
+	b, err := json.Marshal(state)
+	if err != nil {
+		return NewRawdbError(StoreLightClientStateError, err)
+	}
+	if err := db.Put(GetLightClientStateKey(), b); err != nil {
+		return NewRawdbError(StoreLightClientStateError, err)
+	}
+	return nil
+}
+
+func GetLightClientState(db incdb.KeyValueReader) ([]byte, error) {
+	data, err := db.Get(GetLightClientStateKey())
+	if err != nil {
+		return nil, NewRawdbError(GetLightClientStateError, err)
+	}
+	return data, nil
+}
+
+// StoreBeaconCheckpointView store block hash => beacon view, for nodes fast syncing from the block
+func StoreBeaconCheckpointView(db incdb.KeyValueWriter, hash common.Hash, view []byte) error {
+	if err := db.Put(GetBeaconCheckpointViewKey(hash), view); err != nil {
+		return NewRawdbError(StoreBeaconCheckpointViewError, err)
+	}
+	return nil
+}
+
+func GetBeaconCheckpointView(db incdb.KeyValueReader, hash common.Hash) ([]byte, error) {
+	data, err := db.Get(GetBeaconCheckpointViewKey(hash))
+	if err != nil {
+		return nil, NewRawdbError(GetBeaconCheckpointViewError, err)
+	}
+	return data, nil
+}
+
+// StoreBeaconBlock store block hash => block value
+func StoreBeaconBlockByHash(db incdb.KeyValueWriter, hash common.Hash, blockBytes []byte) error {
+	keyHash := GetBeaconHashToBlockKey(hash)
+	if err := db.Put(keyHash, blockBytes); err != nil {
 		return NewRawdbError(StoreBeaconBlockError, err)
 	}
 	return nil
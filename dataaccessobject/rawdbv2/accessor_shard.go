@@ -38,13 +38,9 @@ func GetBeaconConfirmInstantFinalityShardBlock(db incdb.KeyValueReader, sid byte
 // StoreShardBlock store block hash => block value and block index => block hash
 // record1: prefix-shardid-index-hash => empty
 // record2: prefix-hash => block value
-func StoreShardBlock(db incdb.KeyValueWriter, hash common.Hash, v interface{}) error {
+func StoreShardBlock(db incdb.KeyValueWriter, hash common.Hash, blockBytes []byte) error {
 	keyHash := GetShardHashToBlockKey(hash)
-	val, err := json.Marshal(v)
-	if err != nil {
-		return NewRawdbError(StoreShardBlockError, err)
-	}
-	if err := db.Put(keyHash, val); err != nil {
+	if err := db.Put(keyHash, blockBytes); err != nil {
 		return NewRawdbError(StoreShardBlockError, err)
 	}
 	return nil
@@ -186,3 +182,116 @@ func GetStakingTx(db incdb.KeyValueReader, sid byte, hash common.Hash) ([]byte,
 	}
 	return data, err
 }
+
+// StoreAccountLedgerAccount store an account registered to account ledger by its public spend key
+func StoreAccountLedgerAccount(db incdb.KeyValueWriter, publicKey []byte, account interface{}) error {
+	return putAccountLedgerValue(db, GetAccountLedgerAccountKey(publicKey), account)
+}
+
+// GetAccountLedgerAccounts return all accounts registered to account ledger
+func GetAccountLedgerAccounts(db incdb.Database) ([][]byte, error) {
+	return getAccountLedgerValues(db, GetAccountLedgerAccountPrefix())
+}
+
+// StoreAccountLedgerCoin store a coin received by an account of account ledger
+func StoreAccountLedgerCoin(db incdb.KeyValueWriter, publicKey []byte, coinPublicKey []byte, coin interface{}) error {
+	return putAccountLedgerValue(db, GetAccountLedgerCoinKey(publicKey, coinPublicKey), coin)
+}
+
+func HasAccountLedgerCoin(db incdb.KeyValueReader, publicKey []byte, coinPublicKey []byte) (bool, error) {
+	has, err := db.Has(GetAccountLedgerCoinKey(publicKey, coinPublicKey))
+	if err != nil {
+		return false, NewRawdbError(GetAccountLedgerError, err)
+	}
+	return has, nil
+}
+
+func GetAccountLedgerCoin(db incdb.KeyValueReader, publicKey []byte, coinPublicKey []byte) ([]byte, error) {
+	data, err := db.Get(GetAccountLedgerCoinKey(publicKey, coinPublicKey))
+	if err != nil {
+		return nil, NewRawdbError(GetAccountLedgerError, err)
+	}
+	return data, nil
+}
+
+// GetAccountLedgerCoins return all coins received by an account of account ledger
+func GetAccountLedgerCoins(db incdb.Database, publicKey []byte) ([][]byte, error) {
+	return getAccountLedgerValues(db, GetAccountLedgerCoinPrefix(publicKey))
+}
+
+// StoreAccountLedgerKeyImage store key image => coin public key of a coin of an account of account ledger
+func StoreAccountLedgerKeyImage(db incdb.KeyValueWriter, publicKey []byte, keyImage []byte, coinPublicKey []byte) error {
+	if err := db.Put(GetAccountLedgerKeyImageKey(publicKey, keyImage), coinPublicKey); err != nil {
+		return NewRawdbError(StoreAccountLedgerError, err)
+	}
+	return nil
+}
+
+func HasAccountLedgerKeyImage(db incdb.KeyValueReader, publicKey []byte, keyImage []byte) (bool, error) {
+	has, err := db.Has(GetAccountLedgerKeyImageKey(publicKey, keyImage))
+	if err != nil {
+		return false, NewRawdbError(GetAccountLedgerError, err)
+	}
+	return has, nil
+}
+
+// GetAccountLedgerCoinByKeyImage return public key of the coin of key image
+func GetAccountLedgerCoinByKeyImage(db incdb.KeyValueReader, publicKey []byte, keyImage []byte) ([]byte, error) {
+	data, err := db.Get(GetAccountLedgerKeyImageKey(publicKey, keyImage))
+	if err != nil {
+		return nil, NewRawdbError(GetAccountLedgerError, err)
+	}
+	return data, nil
+}
+
+// StoreAccountLedgerTx store a tx sending to or spending from an account of account ledger
+func StoreAccountLedgerTx(db incdb.KeyValueWriter, publicKey []byte, shardHeight uint64, hash common.Hash, tx interface{}) error {
+	return putAccountLedgerValue(db, GetAccountLedgerTxKey(publicKey, shardHeight, hash), tx)
+}
+
+func HasAccountLedgerTx(db incdb.KeyValueReader, publicKey []byte, shardHeight uint64, hash common.Hash) (bool, error) {
+	has, err := db.Has(GetAccountLedgerTxKey(publicKey, shardHeight, hash))
+	if err != nil {
+		return false, NewRawdbError(GetAccountLedgerError, err)
+	}
+	return has, nil
+}
+
+func GetAccountLedgerTx(db incdb.KeyValueReader, publicKey []byte, shardHeight uint64, hash common.Hash) ([]byte, error) {
+	data, err := db.Get(GetAccountLedgerTxKey(publicKey, shardHeight, hash))
+	if err != nil {
+		return nil, NewRawdbError(GetAccountLedgerError, err)
+	}
+	return data, nil
+}
+
+// GetAccountLedgerTxs return all txs of an account of account ledger, sorted by shard height
+func GetAccountLedgerTxs(db incdb.Database, publicKey []byte) ([][]byte, error) {
+	return getAccountLedgerValues(db, GetAccountLedgerTxPrefix(publicKey))
+}
+
+func putAccountLedgerValue(db incdb.KeyValueWriter, key []byte, v interface{}) error {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return NewRawdbError(StoreAccountLedgerError, err)
+	}
+	if err := db.Put(key, b); err != nil {
+		return NewRawdbError(StoreAccountLedgerError, err)
+	}
+	return nil
+}
+
+func getAccountLedgerValues(db incdb.Database, prefix []byte) ([][]byte, error) {
+	iterator := db.NewIteratorWithPrefix(prefix)
+	defer iterator.Release()
+	res := [][]byte{}
+	for iterator.Next() {
+		value := make([]byte, len(iterator.Value()))
+		copy(value, iterator.Value())
+		res = append(res, value)
+	}
+	if err := iterator.Error(); err != nil {
+		return nil, NewRawdbError(GetAccountLedgerError, err)
+	}
+	return res, nil
+}
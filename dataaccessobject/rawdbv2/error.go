@@ -115,6 +115,30 @@ const (
 	GetFFIndexError
 	StoreShardStakingTx
 	GetShardStakingTx
+
+	//committee state diff index
+	StoreBeaconCommitteeStateDiffError
+	GetBeaconCommitteeStateDiffError
+
+	//delegation index
+	StoreDelegationEventError
+	GetDelegationEventError
+	StoreSharePriceHistoryError
+	GetSharePriceHistoryError
+
+	//light client
+	StoreLightClientBeaconHeaderError
+	GetLightClientBeaconHeaderError
+	StoreLightClientStateError
+	GetLightClientStateError
+
+	//checkpoint
+	StoreBeaconCheckpointViewError
+	GetBeaconCheckpointViewError
+
+	//account ledger
+	StoreAccountLedgerError
+	GetAccountLedgerError
 )
 
 var ErrCodeMessage = map[int]struct {
@@ -216,6 +240,25 @@ var ErrCodeMessage = map[int]struct {
 
 	StoreShardStakingTx: {-7006, "Store shard stakign error"},
 	GetShardStakingTx:   {-7007, "Get shard staking error"},
+
+	StoreBeaconCommitteeStateDiffError: {-7008, "Store beacon committee state diff error"},
+	GetBeaconCommitteeStateDiffError:   {-7009, "Get beacon committee state diff error"},
+
+	StoreDelegationEventError:   {-7010, "Store delegation event error"},
+	GetDelegationEventError:     {-7011, "Get delegation event error"},
+	StoreSharePriceHistoryError: {-7012, "Store share price history error"},
+	GetSharePriceHistoryError:   {-7013, "Get share price history error"},
+
+	StoreLightClientBeaconHeaderError: {-7014, "Store light client beacon header error"},
+	GetLightClientBeaconHeaderError:   {-7015, "Get light client beacon header error"},
+	StoreLightClientStateError:        {-7016, "Store light client state error"},
+	GetLightClientStateError:          {-7017, "Get light client state error"},
+
+	StoreBeaconCheckpointViewError: {-7018, "Store beacon checkpoint view error"},
+	GetBeaconCheckpointViewError:   {-7019, "Get beacon checkpoint view error"},
+
+	StoreAccountLedgerError: {-7020, "Store account ledger error"},
+	GetAccountLedgerError:   {-7021, "Get account ledger error"},
 }
 
 type RawdbError struct {
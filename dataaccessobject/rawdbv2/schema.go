@@ -52,6 +52,26 @@ var (
 	pruneStatusPrefix         = []byte("p-s")
 
 	cacheCommitteeFromBlockPrefix = []byte("c-c-f-b" + string(splitter))
+
+	// committee state diff by beacon block (optional)
+	beaconCommitteeStateDiffPrefix = []byte("b-c-s-d" + string(splitter))
+
+	// delegation events by reward receiver and share price history by beacon uid (optional)
+	delegationEventPrefix   = []byte("d-e-v" + string(splitter))
+	sharePriceHistoryPrefix = []byte("s-p-h" + string(splitter))
+
+	// verified beacon headers and tracked committee of light client
+	lightClientBeaconHeaderPrefix = []byte("l-c-h" + string(splitter))
+	lightClientStateKey           = []byte("l-c-s" + string(splitter))
+
+	// beacon views served to nodes fast syncing from a checkpoint
+	beaconCheckpointViewPrefix = []byte("b-c-v" + string(splitter))
+
+	// registered accounts, received coins, submitted key images and txs of account ledger (optional)
+	accountLedgerAccountPrefix  = []byte("a-l-a" + string(splitter))
+	accountLedgerCoinPrefix     = []byte("a-l-c" + string(splitter))
+	accountLedgerKeyImagePrefix = []byte("a-l-k" + string(splitter))
+	accountLedgerTxPrefix       = []byte("a-l-t" + string(splitter))
 )
 
 func GetLastShardBlockKey(shardID byte) []byte {
@@ -177,6 +197,95 @@ func GetCacheCommitteeFromBlockKey(hash common.Hash, cid int) []byte {
 	return append(temp, buf...)
 }
 
+func GetBeaconCommitteeStateDiffKey(hash common.Hash) []byte {
+	temp := make([]byte, 0, len(beaconCommitteeStateDiffPrefix))
+	temp = append(temp, beaconCommitteeStateDiffPrefix...)
+	return append(temp, hash[:]...)
+}
+
+func GetDelegationEventPrefix(receiverPublicKey []byte) []byte {
+	temp := make([]byte, 0, len(delegationEventPrefix))
+	temp = append(temp, delegationEventPrefix...)
+	return append(temp, receiverPublicKey...)
+}
+
+// GetDelegationEventKey key format: prefix, receiver public key, 8 bytes beacon height, beacon block hash
+// block hash is kept in key so events of different forks do not overwrite each other
+func GetDelegationEventKey(receiverPublicKey []byte, beaconHeight uint64, beaconHash common.Hash) []byte {
+	key := GetDelegationEventPrefix(receiverPublicKey)
+	key = append(key, common.Uint64ToBytes(beaconHeight)...)
+	return append(key, beaconHash[:]...)
+}
+
+func GetSharePriceHistoryPrefix(beaconUID common.Hash) []byte {
+	temp := make([]byte, 0, len(sharePriceHistoryPrefix))
+	temp = append(temp, sharePriceHistoryPrefix...)
+	return append(temp, beaconUID[:]...)
+}
+
+func GetSharePriceHistoryKey(beaconUID common.Hash, beaconHeight uint64, beaconHash common.Hash) []byte {
+	key := GetSharePriceHistoryPrefix(beaconUID)
+	key = append(key, common.Uint64ToBytes(beaconHeight)...)
+	return append(key, beaconHash[:]...)
+}
+
+func GetLightClientBeaconHeaderKey(height uint64) []byte {
+	temp := make([]byte, 0, len(lightClientBeaconHeaderPrefix))
+	temp = append(temp, lightClientBeaconHeaderPrefix...)
+	return append(temp, common.Uint64ToBytes(height)...)
+}
+
+func GetLightClientStateKey() []byte {
+	return lightClientStateKey
+}
+
+func GetBeaconCheckpointViewKey(hash common.Hash) []byte {
+	temp := make([]byte, 0, len(beaconCheckpointViewPrefix))
+	temp = append(temp, beaconCheckpointViewPrefix...)
+	return append(temp, hash[:]...)
+}
+
+func GetAccountLedgerAccountPrefix() []byte {
+	return accountLedgerAccountPrefix
+}
+
+func GetAccountLedgerAccountKey(publicKey []byte) []byte {
+	temp := make([]byte, 0, len(accountLedgerAccountPrefix))
+	temp = append(temp, accountLedgerAccountPrefix...)
+	return append(temp, publicKey...)
+}
+
+func GetAccountLedgerCoinPrefix(publicKey []byte) []byte {
+	temp := make([]byte, 0, len(accountLedgerCoinPrefix))
+	temp = append(temp, accountLedgerCoinPrefix...)
+	return append(temp, publicKey...)
+}
+
+func GetAccountLedgerCoinKey(publicKey []byte, coinPublicKey []byte) []byte {
+	return append(GetAccountLedgerCoinPrefix(publicKey), coinPublicKey...)
+}
+
+func GetAccountLedgerKeyImageKey(publicKey []byte, keyImage []byte) []byte {
+	temp := make([]byte, 0, len(accountLedgerKeyImagePrefix))
+	temp = append(temp, accountLedgerKeyImagePrefix...)
+	temp = append(temp, publicKey...)
+	return append(temp, keyImage...)
+}
+
+func GetAccountLedgerTxPrefix(publicKey []byte) []byte {
+	temp := make([]byte, 0, len(accountLedgerTxPrefix))
+	temp = append(temp, accountLedgerTxPrefix...)
+	return append(temp, publicKey...)
+}
+
+// GetAccountLedgerTxKey key format: prefix, account public key, 8 bytes shard height, tx hash
+// coins received by cross shard are keyed by hash of the cross shard block instead of tx hash
+func GetAccountLedgerTxKey(publicKey []byte, shardHeight uint64, hash common.Hash) []byte {
+	key := GetAccountLedgerTxPrefix(publicKey)
+	key = append(key, common.Uint64ToBytes(shardHeight)...)
+	return append(key, hash[:]...)
+}
+
 // ============================= Transaction =======================================
 func GetTransactionHashKey(hash common.Hash) []byte {
 	temp := make([]byte, 0, len(txHashPrefix))
@@ -1,5 +1,11 @@
 package statedb
 
+import (
+	"sort"
+
+	"github.com/incognitochain/incognito-chain/common"
+)
+
 func GetProducersBlackList(stateDB *StateDB, beaconHeight uint64) map[string]uint8 {
 	return stateDB.getAllProducerBlackList()
 }
@@ -31,3 +37,39 @@ func RemoveAllEmptyProducerBlackList(stateDB *StateDB, blackListProducerKey stri
 		}
 	}
 }
+
+// StoreEquivocationVotes records the votes of processed equivocation evidence of a validator
+func StoreEquivocationVotes(stateDB *StateDB, voteHashes []common.Hash, committeePublicKey string, epoch uint64) error {
+	for _, voteHash := range voteHashes {
+		key := GenerateEquivocationVoteObjectKey(voteHash)
+		value := NewEquivocationVoteStateWithValue(committeePublicKey, epoch)
+		err := stateDB.SetStateObject(EquivocationVoteObjectType, key, value)
+		if err != nil {
+			return NewStatedbError(StoreEquivocationVotesError, err)
+		}
+	}
+	return nil
+}
+
+// HasEquivocationVote checks the vote is already in processed equivocation evidence
+func HasEquivocationVote(stateDB *StateDB, voteHash common.Hash) (bool, error) {
+	key := GenerateEquivocationVoteObjectKey(voteHash)
+	_, has, err := stateDB.getEquivocationVoteState(key)
+	return has, err
+}
+
+// GetEquivocationOffenders returns the sorted committee public keys of validators whose equivocation evidence
+// is processed in the epoch
+func GetEquivocationOffenders(stateDB *StateDB, epoch uint64) []string {
+	offenders := []string{}
+	added := make(map[string]bool)
+	for _, state := range stateDB.getAllEquivocationVoteState() {
+		if state.epoch != epoch || added[state.committeePublicKey] {
+			continue
+		}
+		added[state.committeePublicKey] = true
+		offenders = append(offenders, state.committeePublicKey)
+	}
+	sort.Strings(offenders)
+	return offenders
+}
@@ -124,8 +124,14 @@ const (
 	// Near bridge
 	BridgeNEARTxObjectType = 77
 
+	// Solana bridge
+	BridgeSOLTxObjectType = 88
+
 	InscriptionTokenIDObjectType = 86
 	InscriptionNumberObjectType  = 87
+
+	// equivocation slashing
+	EquivocationVoteObjectType = 89
 )
 
 // Prefix length
@@ -21,6 +21,7 @@ const (
 	ErrInvalidBeaconDelegateStateType         = "invalid beacon delegate state type"
 	ErrInvalidRewardRequestStateType          = "invalid reward request state type"
 	ErrInvalidBlackListProducerStateType      = "invalid black list producer state type"
+	ErrInvalidEquivocationVoteStateType       = "invalid equivocation vote state type"
 	ErrInvalidSerialNumberStateType           = "invalid serial number state type"
 	ErrInvalidCommitmentStateType             = "invalid commitment state type"
 	ErrInvalidSNDerivatorStateType            = "invalid snderivator state type"
@@ -96,6 +97,7 @@ const (
 	ErrInvalidBridgeAggParamStateType          = "invalid bridge agg param state type"
 
 	ErrInvalidBridgeNEARTxStateType = "invalid bridge near tx state type"
+	ErrInvalidBridgeSOLTxStateType  = "invalid bridge sol tx state type"
 )
 const (
 	InvalidByteArrayTypeError = iota
@@ -157,6 +159,7 @@ const (
 	ListCommitteeRewardError
 	RemoveCommitteeRewardError
 	StoreBlackListProducersError
+	StoreEquivocationVotesError
 	StoreSyncingValidatorsError
 	SaveStopAutoStakerInfoError
 
@@ -327,6 +330,10 @@ const (
 	// Near bridge
 	BridgeInsertNEARTxHashIssuedError
 	IsNEARTxHashIssuedError
+
+	// Solana bridge
+	BridgeInsertSOLTxHashIssuedError
+	IsSOLTxHashIssuedError
 )
 
 var ErrCodeMessage = map[int]struct {
@@ -386,6 +393,7 @@ var ErrCodeMessage = map[int]struct {
 	StoreSyncingValidatorsError:            {-3015, "Store Syncing Validators Error"},
 	SaveStopAutoStakerInfoError:            {-3016, "Store Stop Autostake Info Error"},
 	StoreDelegationRewardError:             {-3017, "Store Delegation Reward Error"},
+	StoreEquivocationVotesError:            {-3018, "Store Equivocation Votes Error"},
 	// -4xxx: pdex error
 	StoreWaitingPDEContributionError: {-4000, "Store Waiting PDEX Contribution Error"},
 	StorePDEPoolPairError:            {-4001, "Store PDEX Pool Pair Error"},
@@ -524,6 +532,10 @@ var ErrCodeMessage = map[int]struct {
 	// near bridge
 	BridgeInsertNEARTxHashIssuedError: {-15110, "Insert near shield transaction error"},
 	IsNEARTxHashIssuedError:           {-15111, "Is Near Tx Hash Issued Error"},
+
+	// solana bridge
+	BridgeInsertSOLTxHashIssuedError: {-15114, "Insert solana shield transaction error"},
+	IsSOLTxHashIssuedError:           {-15115, "Is Solana Tx Hash Issued Error"},
 }
 
 type StatedbError struct {
@@ -24,6 +24,7 @@ var (
 	slashingCommitteePrefix            = []byte("slashing-committee-")
 	rewardRequestPrefix                = []byte("reward-request-")
 	blackListProducerPrefix            = []byte("black-list-")
+	equivocationVotePrefix             = []byte("equivocation-vote-")
 	serialNumberPrefix                 = []byte("serial-number-")
 	commitmentPrefix                   = []byte("com-value-")
 	commitmentIndexPrefix              = []byte("com-index-")
@@ -52,6 +53,7 @@ var (
 	bridgeAURORATxPrefix               = []byte("bri-aurora-tx-")
 	bridgeAVAXTxPrefix                 = []byte("bri-avax-tx-")
 	bridgeNEARTxPrefix                 = []byte("bri-near-tx-")
+	bridgeSOLTxPrefix                  = []byte("bri-sol-tx-")
 	bridgePRVEVMPrefix                 = []byte("bri-prv-evm-tx-")
 	bridgeCentralizedTokenInfoPrefix   = []byte("bri-cen-token-info-")
 	bridgeDecentralizedTokenInfoPrefix = []byte("bri-de-token-info-")
@@ -307,6 +309,11 @@ func GetBlackListProducerPrefix() []byte {
 	return h[:][:prefixHashKeyLength]
 }
 
+func GetEquivocationVotePrefix() []byte {
+	h := common.HashH(equivocationVotePrefix)
+	return h[:][:prefixHashKeyLength]
+}
+
 func GetSerialNumberPrefix(tokenID common.Hash, shardID byte) []byte {
 	h := common.HashH(append(serialNumberPrefix, append(tokenID[:], shardID)...))
 	return h[:][:prefixHashKeyLength]
@@ -436,6 +443,11 @@ func GetBridgeNEARTxPrefix() []byte {
 	return h[:][:prefixHashKeyLength]
 }
 
+func GetBridgeSOLTxPrefix() []byte {
+	h := common.HashH(bridgeSOLTxPrefix)
+	return h[:][:prefixHashKeyLength]
+}
+
 func GetBridgeTokenInfoPrefix(isCentralized bool) []byte {
 	if isCentralized {
 		h := common.HashH(bridgeCentralizedTokenInfoPrefix)
@@ -1024,6 +1036,13 @@ var _ = func() (_ struct{}) {
 		panic("black-list-" + " same prefix " + v)
 	}
 	m[string(tempBlackListProducer)] = "black-list-"
+	// equivocation vote
+	tempEquivocationVote := GetEquivocationVotePrefix()
+	prefixs = append(prefixs, tempEquivocationVote)
+	if v, ok := m[string(tempEquivocationVote)]; ok {
+		panic("equivocation-vote-" + " same prefix " + v)
+	}
+	m[string(tempEquivocationVote)] = "equivocation-vote-"
 	for i, v1 := range prefixs {
 		for j, v2 := range prefixs {
 			if i == j {
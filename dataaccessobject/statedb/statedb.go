@@ -1254,6 +1254,37 @@ func (stateDB *StateDB) getAllProducerBlackListState() map[common.Hash]uint8 {
 	return m
 }
 
+// ================================= Equivocation Vote OBJECT =======================================
+func (stateDB *StateDB) getEquivocationVoteState(key common.Hash) (*EquivocationVoteState, bool, error) {
+	equivocationVoteState, err := stateDB.getStateObject(EquivocationVoteObjectType, key)
+	if err != nil {
+		return nil, false, err
+	}
+	if equivocationVoteState != nil {
+		return equivocationVoteState.GetValue().(*EquivocationVoteState), true, nil
+	}
+	return NewEquivocationVoteState(), false, nil
+}
+
+func (stateDB *StateDB) getAllEquivocationVoteState() []*EquivocationVoteState {
+	equivocationVoteStates := []*EquivocationVoteState{}
+	prefix := GetEquivocationVotePrefix()
+	temp := stateDB.trie.NodeIterator(prefix)
+	it := trie.NewIterator(temp)
+	for it.Next(true, false, true) {
+		value := it.Value
+		newValue := make([]byte, len(value))
+		copy(newValue, value)
+		equivocationVoteState := NewEquivocationVoteState()
+		err := json.Unmarshal(newValue, equivocationVoteState)
+		if err != nil {
+			panic("wrong value type")
+		}
+		equivocationVoteStates = append(equivocationVoteStates, equivocationVoteState)
+	}
+	return equivocationVoteStates
+}
+
 // ================================= Serial Number OBJECT =======================================
 func (stateDB *StateDB) getSerialNumberState(key common.Hash) (*SerialNumberState, bool, error) {
 	serialNumberState, err := stateDB.getStateObject(SerialNumberObjectType, key)
@@ -2753,6 +2784,18 @@ func (stateDB *StateDB) getBridgeAVAXTxState(key common.Hash) (*BridgeAVAXTxStat
 	return NewBridgeAVAXTxState(), false, nil
 }
 
+// ================================= Solana bridge OBJECT =======================================
+func (stateDB *StateDB) getBridgeSOLTxState(key common.Hash) (*BridgeSOLTxState, bool, error) {
+	solTxState, err := stateDB.getStateObject(BridgeSOLTxObjectType, key)
+	if err != nil {
+		return nil, false, err
+	}
+	if solTxState != nil {
+		return solTxState.GetValue().(*BridgeSOLTxState), true, nil
+	}
+	return NewBridgeSOLTxState(), false, nil
+}
+
 // ================================= Near bridge OBJECT =======================================
 func (stateDB *StateDB) getBridgeNEARTxState(key common.Hash) (*BridgeNEARTxState, bool, error) {
 	nearTxState, err := stateDB.getStateObject(BridgeNEARTxObjectType, key)
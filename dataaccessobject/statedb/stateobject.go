@@ -35,6 +35,8 @@ func newStateObjectWithValue(db *StateDB, objectType int, hash common.Hash, valu
 		return newRewardRequestMultisetObjectWithValue(db, hash, value)
 	case BlackListProducerObjectType:
 		return newBlackListProducerObjectWithValue(db, hash, value)
+	case EquivocationVoteObjectType:
+		return newEquivocationVoteObjectWithValue(db, hash, value)
 	case TokenObjectType:
 		return newTokenObjectWithValue(db, hash, value)
 	case SerialNumberObjectType:
@@ -73,6 +75,8 @@ func newStateObjectWithValue(db *StateDB, objectType int, hash common.Hash, valu
 		return newBridgeBSCTxObjectWithValue(db, hash, value)
 	case BridgeNEARTxObjectType:
 		return newBridgeNEARTxObjectWithValue(db, hash, value)
+	case BridgeSOLTxObjectType:
+		return newBridgeSOLTxObjectWithValue(db, hash, value)
 	case BridgePRVEVMObjectType:
 		return newBrigePRVEVMObjectWithValue(db, hash, value)
 	case BridgeTokenInfoObjectType:
@@ -213,6 +217,8 @@ func newStateObject(db *StateDB, objectType int, hash common.Hash) StateObject {
 		return newRewardRequestMultisetObject(db, hash)
 	case BlackListProducerObjectType:
 		return newBlackListProducerObject(db, hash)
+	case EquivocationVoteObjectType:
+		return newEquivocationVoteObject(db, hash)
 	case SerialNumberObjectType:
 		return newSerialNumberObject(db, hash)
 	case CommitmentObjectType:
@@ -349,6 +355,8 @@ func newStateObject(db *StateDB, objectType int, hash common.Hash) StateObject {
 		return newBridgeAVAXTxObject(db, hash)
 	case BridgeNEARTxObjectType:
 		return newBridgeNEARTxObject(db, hash)
+	case BridgeSOLTxObjectType:
+		return newBridgeSOLTxObject(db, hash)
 	case BridgeAggUnifiedTokenObjectType:
 		return newBridgeAggUnifiedTokenObject(db, hash)
 	case BridgeAggStatusObjectType:
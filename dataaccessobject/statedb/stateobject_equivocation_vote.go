@@ -0,0 +1,205 @@
+package statedb
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+
+	"github.com/incognitochain/incognito-chain/common"
+)
+
+// EquivocationVoteState records a vote of processed equivocation evidence,
+// so the vote can not be used in other evidence to slash the validator again
+type EquivocationVoteState struct {
+	// base58 string of committee public key
+	committeePublicKey string
+	epoch              uint64
+}
+
+func NewEquivocationVoteStateWithValue(committeePublicKey string, epoch uint64) *EquivocationVoteState {
+	return &EquivocationVoteState{committeePublicKey: committeePublicKey, epoch: epoch}
+}
+
+func NewEquivocationVoteState() *EquivocationVoteState {
+	return &EquivocationVoteState{}
+}
+
+func (e EquivocationVoteState) CommitteePublicKey() string {
+	return e.committeePublicKey
+}
+
+func (e *EquivocationVoteState) SetCommitteePublicKey(committeePublicKey string) {
+	e.committeePublicKey = committeePublicKey
+}
+
+func (e EquivocationVoteState) Epoch() uint64 {
+	return e.epoch
+}
+
+func (e *EquivocationVoteState) SetEpoch(epoch uint64) {
+	e.epoch = epoch
+}
+
+func (e EquivocationVoteState) MarshalJSON() ([]byte, error) {
+	data, err := json.Marshal(struct {
+		CommitteePublicKey string
+		Epoch              uint64
+	}{
+		CommitteePublicKey: e.committeePublicKey,
+		Epoch:              e.epoch,
+	})
+	if err != nil {
+		return []byte{}, err
+	}
+	return data, nil
+}
+
+func (e *EquivocationVoteState) UnmarshalJSON(data []byte) error {
+	temp := struct {
+		CommitteePublicKey string
+		Epoch              uint64
+	}{}
+	err := json.Unmarshal(data, &temp)
+	if err != nil {
+		return err
+	}
+	e.committeePublicKey = temp.CommitteePublicKey
+	e.epoch = temp.Epoch
+	return nil
+}
+
+type EquivocationVoteObject struct {
+	db *StateDB
+	// Write caches.
+	trie Trie // storage trie, which becomes non-nil on first access
+
+	version               int
+	voteHash              common.Hash
+	equivocationVoteState *EquivocationVoteState
+	objectType            int
+	deleted               bool
+
+	// DB error.
+	// State objects are used by the consensus core and VM which are
+	// unable to deal with database-level errors. Any error that occurs
+	// during a database read is memoized here and will eventually be returned
+	// by StateDB.Commit.
+	dbErr error
+}
+
+func newEquivocationVoteObject(db *StateDB, hash common.Hash) *EquivocationVoteObject {
+	return &EquivocationVoteObject{
+		version:               defaultVersion,
+		db:                    db,
+		voteHash:              hash,
+		equivocationVoteState: NewEquivocationVoteState(),
+		objectType:            EquivocationVoteObjectType,
+		deleted:               false,
+	}
+}
+
+func newEquivocationVoteObjectWithValue(db *StateDB, key common.Hash, data interface{}) (*EquivocationVoteObject, error) {
+	var newEquivocationVoteState = NewEquivocationVoteState()
+	var ok bool
+	var dataBytes []byte
+	if dataBytes, ok = data.([]byte); ok {
+		err := json.Unmarshal(dataBytes, newEquivocationVoteState)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		newEquivocationVoteState, ok = data.(*EquivocationVoteState)
+		if !ok {
+			return nil, fmt.Errorf("%+v, got type %+v", ErrInvalidEquivocationVoteStateType, reflect.TypeOf(data))
+		}
+	}
+	return &EquivocationVoteObject{
+		version:               defaultVersion,
+		voteHash:              key,
+		equivocationVoteState: newEquivocationVoteState,
+		db:                    db,
+		objectType:            EquivocationVoteObjectType,
+		deleted:               false,
+	}, nil
+}
+
+func GenerateEquivocationVoteObjectKey(voteHash common.Hash) common.Hash {
+	prefixHash := GetEquivocationVotePrefix()
+	valueHash := common.HashH(voteHash[:])
+	return common.BytesToHash(append(prefixHash, valueHash[:][:prefixKeyLength]...))
+}
+
+func (e EquivocationVoteObject) GetVersion() int {
+	return e.version
+}
+
+// setError remembers the first non-nil error it is called with.
+func (e *EquivocationVoteObject) SetError(err error) {
+	if e.dbErr == nil {
+		e.dbErr = err
+	}
+}
+
+func (e EquivocationVoteObject) GetTrie(db DatabaseAccessWarper) Trie {
+	return e.trie
+}
+
+func (e *EquivocationVoteObject) SetValue(data interface{}) error {
+	var newEquivocationVoteState = NewEquivocationVoteState()
+	var ok bool
+	var dataBytes []byte
+	if dataBytes, ok = data.([]byte); ok {
+		err := json.Unmarshal(dataBytes, newEquivocationVoteState)
+		if err != nil {
+			return err
+		}
+	} else {
+		newEquivocationVoteState, ok = data.(*EquivocationVoteState)
+		if !ok {
+			return fmt.Errorf("%+v, got type %+v", ErrInvalidEquivocationVoteStateType, reflect.TypeOf(data))
+		}
+	}
+	e.equivocationVoteState = newEquivocationVoteState
+	return nil
+}
+
+func (e EquivocationVoteObject) GetValue() interface{} {
+	return e.equivocationVoteState
+}
+
+func (e EquivocationVoteObject) GetValueBytes() []byte {
+	data := e.GetValue()
+	value, err := json.Marshal(data)
+	if err != nil {
+		panic("failed to marshal equivocation vote state")
+	}
+	return []byte(value)
+}
+
+func (e EquivocationVoteObject) GetHash() common.Hash {
+	return e.voteHash
+}
+
+func (e EquivocationVoteObject) GetType() int {
+	return e.objectType
+}
+
+// MarkDelete will delete an object in trie
+func (e *EquivocationVoteObject) MarkDelete() {
+	e.deleted = true
+}
+
+func (e *EquivocationVoteObject) Reset() bool {
+	e.equivocationVoteState = NewEquivocationVoteState()
+	return true
+}
+
+func (e EquivocationVoteObject) IsDeleted() bool {
+	return e.deleted
+}
+
+// value is either default or nil
+func (e EquivocationVoteObject) IsEmpty() bool {
+	temp := NewEquivocationVoteState()
+	return reflect.DeepEqual(temp, e.equivocationVoteState) || e.equivocationVoteState == nil
+}
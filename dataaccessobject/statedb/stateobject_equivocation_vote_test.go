@@ -0,0 +1,55 @@
+package statedb
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/incognitochain/incognito-chain/common"
+)
+
+func TestStateDB_StoreEquivocationVotes(t *testing.T) {
+	sDB, err := NewWithPrefixTrie(emptyRoot, wrarperDB)
+	if err != nil {
+		t.Fatal(err)
+	}
+	vote1, vote2, vote3 := common.HashH([]byte("vote1")), common.HashH([]byte("vote2")), common.HashH([]byte("vote3"))
+	if err := StoreEquivocationVotes(sDB, []common.Hash{vote1, vote2}, committeePublicKeys[1], 2); err != nil {
+		t.Fatal(err)
+	}
+	if err := StoreEquivocationVotes(sDB, []common.Hash{vote3}, committeePublicKeys[0], 2); err != nil {
+		t.Fatal(err)
+	}
+	if err := StoreEquivocationVotes(sDB, []common.Hash{common.HashH([]byte("vote4"))}, committeePublicKeys[1], 3); err != nil {
+		t.Fatal(err)
+	}
+	rootHash, err := sDB.Commit(true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := sDB.Database().TrieDB().Commit(rootHash, false); err != nil {
+		t.Fatal(err)
+	}
+	tempStateDB, err := NewWithPrefixTrie(rootHash, wrarperDB)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, voteHash := range []common.Hash{vote1, vote2, vote3} {
+		has, err := HasEquivocationVote(tempStateDB, voteHash)
+		if err != nil || !has {
+			t.Fatalf("want vote %+v stored, got %+v %+v", voteHash, has, err)
+		}
+	}
+	if has, err := HasEquivocationVote(tempStateDB, common.HashH([]byte("vote5"))); err != nil || has {
+		t.Fatalf("want vote not stored, got %+v %+v", has, err)
+	}
+	want := []string{committeePublicKeys[0], committeePublicKeys[1]}
+	if committeePublicKeys[1] < committeePublicKeys[0] {
+		want = []string{committeePublicKeys[1], committeePublicKeys[0]}
+	}
+	if got := GetEquivocationOffenders(tempStateDB, 2); !reflect.DeepEqual(got, want) {
+		t.Fatalf("want %+v but got %+v", want, got)
+	}
+	if got := GetEquivocationOffenders(tempStateDB, 1); len(got) != 0 {
+		t.Fatalf("want no offender but got %+v", got)
+	}
+}
@@ -0,0 +1,75 @@
+package statedb
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/incdb"
+	"github.com/incognitochain/incognito-chain/trie"
+)
+
+const (
+	MaxTrieNodesPerRequest = 384 // max number of trie nodes served or requested at once
+	trieSyncBloomSize      = 64  // memory of the bloom filter of known trie nodes, in MB
+)
+
+// GetTrieNodes return encoded trie nodes by hash, to serve nodes syncing state trie by trie node
+func GetTrieNodes(db incdb.Database, hashes []common.Hash) ([][]byte, error) {
+	if len(hashes) > MaxTrieNodesPerRequest {
+		return nil, fmt.Errorf("request %v trie nodes, max %v", len(hashes), MaxTrieNodesPerRequest)
+	}
+	trieDB := NewDatabaseAccessWarper(db).TrieDB()
+	res := make([][]byte, 0, len(hashes))
+	for _, hash := range hashes {
+		data, err := trieDB.Node(hash)
+		if err != nil {
+			return nil, fmt.Errorf("trie node %v: %v", hash.String(), err)
+		}
+		res = append(res, data)
+	}
+	return res, nil
+}
+
+// SyncTrie download the state trie of root into db node by node with fetch, nodes already in db are skipped.
+// Each node is checked against its hash, so the synced state is the one committed by root.
+// It return the number of downloaded nodes
+func SyncTrie(db incdb.Database, root common.Hash, fetch func(hashes []common.Hash) ([][]byte, error)) (int, error) {
+	bloom := trie.NewSyncBloom(trieSyncBloomSize, db)
+	defer bloom.Close()
+	sched := trie.NewSync(root, db, nil, bloom)
+
+	count := 0
+	for sched.Pending() > 0 {
+		hashes := sched.Missing(MaxTrieNodesPerRequest)
+		if len(hashes) == 0 {
+			return count, errors.New("trie sync has pending nodes but nothing to request")
+		}
+		data, err := fetch(hashes)
+		if err != nil {
+			return count, err
+		}
+		if len(data) != len(hashes) {
+			return count, fmt.Errorf("request %v trie nodes, receive %v", len(hashes), len(data))
+		}
+		results := make([]trie.SyncResult, 0, len(hashes))
+		for i, hash := range hashes {
+			if common.Keccak256(data[i]) != hash {
+				return count, fmt.Errorf("trie node %v does not match its hash", hash.String())
+			}
+			results = append(results, trie.SyncResult{Hash: hash, Data: data[i]})
+		}
+		if _, index, err := sched.Process(results); err != nil {
+			return count, fmt.Errorf("process trie node %v: %v", hashes[index].String(), err)
+		}
+		batch := db.NewBatch()
+		if err := sched.Commit(batch); err != nil {
+			return count, err
+		}
+		if err := batch.Write(); err != nil {
+			return count, err
+		}
+		count += len(hashes)
+	}
+	return count, nil
+}
@@ -0,0 +1,110 @@
+package statedb
+
+import (
+	"io/ioutil"
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/incdb"
+)
+
+func newTrieSyncTestDB(t *testing.T) (incdb.Database, func()) {
+	dbPath, err := ioutil.TempDir(os.TempDir(), "test_trie_sync_")
+	if err != nil {
+		t.Fatal(err)
+	}
+	db, err := incdb.Open("leveldb", dbPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return db, func() {
+		db.Close()
+		os.RemoveAll(dbPath)
+	}
+}
+
+func TestSyncTrie(t *testing.T) {
+	srcDB, closeSrc := newTrieSyncTestDB(t)
+	defer closeSrc()
+	dstDB, closeDst := newTrieSyncTestDB(t)
+	defer closeDst()
+
+	keys, values := generateKeyValuePairWithPrefix(2000, []byte("abc"))
+	srcState, _ := NewWithPrefixTrie(emptyRoot, NewDatabaseAccessWarper(srcDB))
+	for i := range keys {
+		srcState.SetStateObject(TestObjectType, keys[i], values[i])
+	}
+	root, err := srcState.Commit(true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := srcState.Database().TrieDB().Commit(root, false); err != nil {
+		t.Fatal(err)
+	}
+
+	requests := 0
+	fetch := func(hashes []common.Hash) ([][]byte, error) {
+		requests++
+		return GetTrieNodes(srcDB, hashes)
+	}
+	count, err := SyncTrie(dstDB, root, fetch)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if count == 0 || requests < 2 {
+		t.Fatalf("expect trie synced in several requests, got %v nodes in %v requests", count, requests)
+	}
+
+	dstState, err := NewWithPrefixTrie(root, NewDatabaseAccessWarper(dstDB))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := range keys {
+		v, err := dstState.getTestObject(keys[i])
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(v, values[i]) {
+			t.Fatalf("want value %+v, got %+v", values[i], v)
+		}
+	}
+
+	//synced nodes are not requested again
+	requests = 0
+	if count, err := SyncTrie(dstDB, root, fetch); err != nil || count != 0 || requests != 0 {
+		t.Fatalf("expect nothing to sync, got %v nodes in %v requests, err %v", count, requests, err)
+	}
+}
+
+func TestSyncTrieRejectInvalidNode(t *testing.T) {
+	srcDB, closeSrc := newTrieSyncTestDB(t)
+	defer closeSrc()
+	dstDB, closeDst := newTrieSyncTestDB(t)
+	defer closeDst()
+
+	keys, values := generateKeyValuePairWithPrefix(100, []byte("abc"))
+	srcState, _ := NewWithPrefixTrie(emptyRoot, NewDatabaseAccessWarper(srcDB))
+	for i := range keys {
+		srcState.SetStateObject(TestObjectType, keys[i], values[i])
+	}
+	root, _ := srcState.Commit(true)
+	srcState.Database().TrieDB().Commit(root, false)
+
+	fetch := func(hashes []common.Hash) ([][]byte, error) {
+		data, err := GetTrieNodes(srcDB, hashes)
+		if err != nil {
+			return nil, err
+		}
+		data[0] = append([]byte{}, data[0]...)
+		data[0][len(data[0])-1] ^= 1
+		return data, nil
+	}
+	if _, err := SyncTrie(dstDB, root, fetch); err == nil {
+		t.Fatal("expect error of modified trie node")
+	}
+	if has, _ := dstDB.Has(root[:]); has {
+		t.Fatal("modified trie node is stored")
+	}
+}
@@ -0,0 +1,78 @@
+package stats
+
+import (
+	"encoding/json"
+	"sort"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/incdb"
+)
+
+var (
+	validatorEpochPerformancePrefix = []byte("b-validator-performance-epoch")
+)
+
+// ValidatorEpochPerformance is the signing result of one committee member in a finished epoch
+type ValidatorEpochPerformance struct {
+	Epoch             uint64
+	ChainID           int
+	ActualTotal       uint
+	Signed            uint
+	Missing           uint
+	ExpectedTotal     uint
+	Slashed           bool
+	SwappedOut        bool
+	BeaconPerformance uint64 `json:"BeaconPerformance,omitempty"`
+}
+
+func getValidatorEpochPerformancePrefix(committeePublicKey string) []byte {
+	key := append([]byte{}, validatorEpochPerformancePrefix...)
+	key = append(key, split...)
+	key = append(key, []byte(committeePublicKey)...)
+	key = append(key, split...)
+	return key
+}
+
+func getValidatorEpochPerformanceKey(committeePublicKey string, epoch uint64) []byte {
+	key := getValidatorEpochPerformancePrefix(committeePublicKey)
+	key = append(key, common.Uint64ToBytes(epoch)...)
+	return key
+}
+
+// StoreValidatorEpochPerformance store performance of all committee members in an epoch, key is committee public key in base58
+func StoreValidatorEpochPerformance(db incdb.Database, performances map[string]*ValidatorEpochPerformance) error {
+	batch := db.NewBatch()
+	for committeePublicKey, performance := range performances {
+		value, err := json.Marshal(performance)
+		if err != nil {
+			return err
+		}
+		if err := batch.Put(getValidatorEpochPerformanceKey(committeePublicKey, performance.Epoch), value); err != nil {
+			return err
+		}
+	}
+	return batch.Write()
+}
+
+// GetValidatorEpochPerformance return performance history of a committee member from fromEpoch, sorted by epoch
+func GetValidatorEpochPerformance(db incdb.Database, committeePublicKey string, fromEpoch uint64) ([]*ValidatorEpochPerformance, error) {
+	res := []*ValidatorEpochPerformance{}
+	iterator := db.NewIteratorWithPrefix(getValidatorEpochPerformancePrefix(committeePublicKey))
+	defer iterator.Release()
+	for iterator.Next() {
+		temp := make([]byte, len(iterator.Value()))
+		copy(temp, iterator.Value())
+		performance := &ValidatorEpochPerformance{}
+		if err := json.Unmarshal(temp, performance); err != nil {
+			return res, err
+		}
+		if performance.Epoch < fromEpoch {
+			continue
+		}
+		res = append(res, performance)
+	}
+	sort.Slice(res, func(i, j int) bool {
+		return res[i].Epoch < res[j].Epoch
+	})
+	return res, iterator.Error()
+}
@@ -1,7 +1,9 @@
 package main
 
 import (
+	"encoding/json"
 	"fmt"
+	"io/ioutil"
 	"log"
 	"net/http"
 	_ "net/http/pprof"
@@ -10,14 +12,23 @@ import (
 	"runtime"
 	"runtime/debug"
 	"strconv"
+	"strings"
 
+	"github.com/incognitochain/incognito-chain/common"
 	"github.com/incognitochain/incognito-chain/dataaccessobject/rawdb_consensus"
+	"github.com/incognitochain/incognito-chain/lightclient"
 	"github.com/incognitochain/incognito-chain/metadata/evmcaller"
 	"github.com/incognitochain/incognito-chain/pruner"
 
 	"github.com/incognitochain/incognito-chain/config"
+	consensus "github.com/incognitochain/incognito-chain/consensus_v2"
+	"github.com/incognitochain/incognito-chain/consensus_v2/blsbft"
+	"github.com/incognitochain/incognito-chain/consensus_v2/remotesigner"
+	"github.com/incognitochain/incognito-chain/consensus_v2/signatureschemes"
 	"github.com/incognitochain/incognito-chain/metrics/monitor"
 	"github.com/incognitochain/incognito-chain/portal"
+	"github.com/incognitochain/incognito-chain/portal/portalv4"
+	portaltokensv4 "github.com/incognitochain/incognito-chain/portal/portalv4/portaltokens"
 	bnbrelaying "github.com/incognitochain/incognito-chain/relaying/bnb"
 	"github.com/incognitochain/incognito-chain/utils"
 
@@ -30,6 +41,7 @@ import (
 	"github.com/incognitochain/incognito-chain/limits"
 	btcrelaying "github.com/incognitochain/incognito-chain/relaying/btc"
 	"github.com/incognitochain/incognito-chain/wallet"
+	"github.com/incognitochain/incognito-chain/wire"
 )
 
 //go:generate mockery -dir=incdb/ -name=Database
@@ -38,6 +50,128 @@ import (
 // as a service and reacts accordingly.
 var winServiceMain func() (bool, error)
 
+// runRemoteSigner keeps the mining keys from the config and signs for the node connecting to the unix socket
+func runRemoteSigner(interrupt <-chan struct{}) error {
+	cfg := config.Config()
+	var miningKeys []*signatureschemes.MiningKey
+	if cfg.PrivateKey != "" {
+		privateSeed, err := consensus.GenMiningKeyFromPrivateKey(cfg.PrivateKey)
+		if err != nil {
+			return err
+		}
+		miningKey, err := consensus.GetMiningKeyFromPrivateSeed(privateSeed)
+		if err != nil {
+			return err
+		}
+		miningKeys = append(miningKeys, miningKey)
+	} else {
+		for _, key := range strings.Split(cfg.MiningKeys, ",") {
+			miningKey, err := consensus.GetMiningKeyFromPrivateSeed(key)
+			if err != nil {
+				return err
+			}
+			miningKeys = append(miningKeys, miningKey)
+		}
+	}
+
+	// the signer keeps its own slashing protection history, apart from the database of the node
+	db, err := incdb.Open("leveldb", filepath.Join(cfg.DataDir, "remotesigner"))
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	signer := remotesigner.NewServer(miningKeys, blsbft.NewSlashingProtection(db), func(beaconHeight uint64) portalv4.PortalParams {
+		return portal.GetPortalParams().GetPortalParamsV4(beaconHeight)
+	})
+	errChan := make(chan error, 1)
+	go func() {
+		errChan <- signer.Serve(cfg.RemoteSignerListen)
+	}()
+	select {
+	case err := <-errChan:
+		return err
+	case <-interrupt:
+		signer.Stop()
+		return nil
+	}
+}
+
+// runLightClient syncs beacon headers from the full node and serves verified headers and inclusion proofs
+func runLightClient(interrupt <-chan struct{}) error {
+	cfg := config.Config()
+	checkpoint := strings.Split(cfg.LightClientCheckpoint, ":")
+	if len(checkpoint) != 2 {
+		return fmt.Errorf("light client checkpoint %v is not height:hash", cfg.LightClientCheckpoint)
+	}
+	checkpointHeight, err := strconv.ParseUint(checkpoint[0], 10, 64)
+	if err != nil {
+		return err
+	}
+	checkpointHash, err := common.Hash{}.NewHashFromStr(checkpoint[1])
+	if err != nil {
+		return err
+	}
+	db, err := incdb.Open("leveldb", filepath.Join(cfg.DataDir, "lightclient"))
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	lc, err := lightclient.NewLightClient(db, lightclient.NewRemoteSource(cfg.LightClientSource))
+	if err != nil {
+		return err
+	}
+	if err := lc.Init(checkpointHeight, *checkpointHash); err != nil {
+		return err
+	}
+	if cfg.LightClientListen != "" {
+		server := lightclient.NewServer(lc)
+		go func() {
+			if err := server.Serve(cfg.LightClientListen); err != nil {
+				Logger.log.Error(err)
+			}
+		}()
+		defer server.Stop()
+	}
+	lc.Start(interrupt)
+	return nil
+}
+
+// exportSlashingProtection writes the slashing protection history of the validators to the interchange file
+func exportSlashingProtection(filePath string) error {
+	interchange, err := blsbft.ExportSlashingProtection(rawdb_consensus.GetConsensusDatabase())
+	if err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(interchange, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := ioutil.WriteFile(filePath, data, 0600); err != nil {
+		return err
+	}
+	Logger.log.Infof("Exported slashing protection history of %v records to %v", len(interchange.Data), filePath)
+	return nil
+}
+
+// importSlashingProtection merges the slashing protection history in the interchange file
+func importSlashingProtection(filePath string) error {
+	data, err := ioutil.ReadFile(filePath)
+	if err != nil {
+		return err
+	}
+	interchange := &blsbft.SlashingProtectionInterchange{}
+	if err := json.Unmarshal(data, interchange); err != nil {
+		return err
+	}
+	if err := blsbft.ImportSlashingProtection(rawdb_consensus.GetConsensusDatabase(), interchange); err != nil {
+		return err
+	}
+	Logger.log.Infof("Imported slashing protection history of %v records from %v", len(interchange.Data), filePath)
+	return nil
+}
+
 func getBTCRelayingChain(btcRelayingChainID, btcDataFolderName string) (*btcrelaying.BlockChain, error) {
 	relayingChainParams := map[string]*chaincfg.Params{
 		portal.TestnetBTCChainID:  btcrelaying.GetTestNet3Params(),
@@ -56,6 +190,24 @@ func getBTCRelayingChain(btcRelayingChainID, btcDataFolderName string) (*btcrela
 	)
 }
 
+func getLTCRelayingChain(ltcRelayingChainID, ltcDataFolderName string) (*btcrelaying.BlockChain, error) {
+	relayingChainParams := map[string]*chaincfg.Params{
+		portal.TestnetLTCChainID: btcrelaying.GetLTCTestNet4Params(),
+	}
+	relayingChainGenesisBlkHeight := map[string]int32{
+		portal.TestnetLTCChainID: int32(0),
+	}
+	params, ok := relayingChainParams[ltcRelayingChainID]
+	if !ok {
+		return nil, fmt.Errorf("LTC relaying chain %v is not supported", ltcRelayingChainID)
+	}
+	return btcrelaying.GetChainV2(
+		filepath.Join(config.Config().DataDir, ltcDataFolderName),
+		params,
+		relayingChainGenesisBlkHeight[ltcRelayingChainID],
+	)
+}
+
 func getBNBRelayingChainState(bnbRelayingChainID string) (*bnbrelaying.BNBChainState, error) {
 	bnbChainState := new(bnbrelaying.BNBChainState)
 	err := bnbChainState.LoadBNBChainState(
@@ -87,6 +239,10 @@ func mainMaster(serverChan chan<- *Server) error {
 		fmt.Fprintln(os.Stderr, err)
 		panic(err)
 	}
+	if err := setLogFormat(cfg.LogFormat); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		panic(err)
+	}
 	config.LoadParam()
 
 	portal.SetupParam()
@@ -111,6 +267,16 @@ func mainMaster(serverChan chan<- *Server) error {
 	if interruptRequested(interrupt) {
 		return nil
 	}
+	// run as a remote signer, the node is not started
+	if cfg.RemoteSignerListen != "" {
+		return runRemoteSigner(interrupt)
+	}
+	// run as a light client, the node is not started
+	if cfg.LightClientSource != "" {
+		return runLightClient(interrupt)
+	}
+	wire.UseBinaryBlockEncoding = cfg.BinaryBlockMessage
+	blockchain.UseBinaryBlockStorage = cfg.BinaryBlockStorage
 	db, err := incdb.OpenMultipleDB("leveldb", filepath.Join(cfg.DataDir, cfg.DatabaseDir))
 	// Create db and use it.
 	if err != nil {
@@ -137,6 +303,17 @@ func mainMaster(serverChan chan<- *Server) error {
 		panic(err)
 	}
 	rawdb_consensus.SetConsensusDatabase(consensusDB)
+	//check if slashing protection flags are available
+	if cfg.SlashingProtectionExport != "" {
+		return exportSlashingProtection(cfg.SlashingProtectionExport)
+	}
+	if cfg.SlashingProtectionImport != "" {
+		if err := importSlashingProtection(cfg.SlashingProtectionImport); err != nil {
+			Logger.log.Error("could not import slashing protection history")
+			Logger.log.Error(err)
+			panic(err)
+		}
+	}
 	// Create db for mempool and use it
 	dbmp, err := databasemp.Open("leveldbmempool", filepath.Join(cfg.DataDir, cfg.MempoolDir))
 	if err != nil {
@@ -144,6 +321,16 @@ func mainMaster(serverChan chan<- *Server) error {
 		Logger.log.Error(err)
 		panic(err)
 	}
+	// Create db for the shard tx pools when persisting txs, it has the same format as the mempool db
+	var dbtp databasemp.DatabaseInterface
+	if cfg.IsPersistMempool {
+		dbtp, err = databasemp.Open("leveldbmempool", filepath.Join(cfg.DataDir, config.DefaultTxPoolDirname))
+		if err != nil {
+			Logger.log.Error("could not open connection to leveldb! Clear tx pool DB")
+			Logger.log.Error(err)
+			panic(err)
+		}
+	}
 	// Check wallet and start it
 	var walletObj *wallet.Wallet
 	if cfg.EnableWallet {
@@ -189,6 +376,30 @@ func mainMaster(serverChan chan<- *Server) error {
 		db.Close()
 	}()
 
+	// Create ltcrelaying chain, only for networks supporting pLTC
+	var ltcChain *btcrelaying.BlockChain
+	if portal.GetPortalParams().RelayingParam.LTCRelayingHeaderChainID != "" {
+		ltcChain, err = getLTCRelayingChain(
+			portal.GetPortalParams().RelayingParam.LTCRelayingHeaderChainID,
+			portal.GetPortalParams().RelayingParam.LTCDataFolderName,
+		)
+		if err != nil {
+			Logger.log.Error("could not get or create ltc relaying chain")
+			Logger.log.Error(err)
+			panic(err)
+		}
+		defer func() {
+			Logger.log.Warn("Gracefully shutting down the ltc database...")
+			db := ltcChain.GetDB()
+			db.Close()
+		}()
+	}
+
+	// Use the local fee oracles of portal v4 external chains if any
+	if len(cfg.PortalV4FeeOracles) > 0 {
+		portal.SetPortalV4FeeOracle(portaltokensv4.NewRPCFeeOracle(cfg.PortalV4FeeOracles))
+	}
+
 	// Create bnbrelaying chain state
 	bnbChainState, err := getBNBRelayingChainState(portal.GetPortalParams().RelayingParam.BNBRelayingHeaderChainID)
 	if err != nil {
@@ -210,7 +421,7 @@ func mainMaster(serverChan chan<- *Server) error {
 	// Create server and start it.
 	server := Server{}
 	server.wallet = walletObj
-	err = server.NewServer(cfg.Listener, db, dbmp, outcoinDb, cfg.NumIndexerWorkers, cfg.IndexerAccessTokens, version, btcChain, bnbChainState, p, interrupt)
+	err = server.NewServer(cfg.Listener, db, dbmp, dbtp, outcoinDb, cfg.NumIndexerWorkers, cfg.IndexerAccessTokens, version, btcChain, ltcChain, bnbChainState, p, interrupt)
 	if err != nil {
 		Logger.log.Errorf("Unable to start server on %+v", cfg.Listener)
 		Logger.log.Error(err)
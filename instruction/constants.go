@@ -25,6 +25,7 @@ const (
 	MINT_DREWARD_ACTION            = "mintdreward"
 	SHARE_PRICE                    = "shareprice"
 	ENABLE_FEATURE                 = "enablefeature"
+	EQUIVOCATION_ACTION            = "equivocation"
 	DEQUEUE                        = "dequeue"
 	OUTDATED_DEQUEUE_REASON        = "outdated"
 	ACCEPT_BLOCK_REWARD_V3_ACTION  = "acceptblockrewardv3"
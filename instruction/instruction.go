@@ -48,7 +48,8 @@ func IsConsensusInstruction(action string) bool {
 		action == ADD_STAKING_ACTION ||
 		action == RE_DELEGATE ||
 		action == REQ_DREWARD_ACTION ||
-		action == MINT_DREWARD_ACTION
+		action == MINT_DREWARD_ACTION ||
+		action == EQUIVOCATION_ACTION
 }
 
 // the order of instruction must always be maintain
@@ -0,0 +1,98 @@
+package instruction
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/incognitochain/incognito-chain/consensus_v2/consensustypes"
+	"github.com/incognitochain/incognito-chain/incognitokey"
+)
+
+var (
+	ErrEquivocationInstruction = errors.New("equivocation instruction error")
+)
+
+//EquivocationInstruction :
+// format: "equivocation", "key", "evidence json"
+type EquivocationInstruction struct {
+	PublicKey       string
+	PublicKeyStruct incognitokey.CommitteePublicKey
+	Evidence        *consensustypes.EquivocationEvidence
+}
+
+func NewEquivocationInstructionWithValue(publicKey string, evidence *consensustypes.EquivocationEvidence) *EquivocationInstruction {
+	equivocationInstruction := &EquivocationInstruction{}
+	equivocationInstruction.SetPublicKey(publicKey)
+	equivocationInstruction.Evidence = evidence
+	return equivocationInstruction
+}
+
+func NewEquivocationInstruction() *EquivocationInstruction {
+	return &EquivocationInstruction{}
+}
+
+func (e *EquivocationInstruction) GetType() string {
+	return EQUIVOCATION_ACTION
+}
+
+func (e *EquivocationInstruction) IsEmpty() bool {
+	return e.PublicKey == "" || e.Evidence == nil
+}
+
+func (e *EquivocationInstruction) ToString() []string {
+	evidence, _ := json.Marshal(e.Evidence)
+	return []string{EQUIVOCATION_ACTION, e.PublicKey, string(evidence)}
+}
+
+func (e *EquivocationInstruction) SetPublicKey(publicKey string) *EquivocationInstruction {
+	e.PublicKey = publicKey
+	e.PublicKeyStruct = incognitokey.CommitteePublicKey{}
+	e.PublicKeyStruct.FromString(publicKey)
+	return e
+}
+
+// Verify check evidence of instruction is signed by its public key
+func (e *EquivocationInstruction) Verify() error {
+	return e.Evidence.Verify(e.PublicKeyStruct)
+}
+
+func ValidateAndImportEquivocationInstructionFromString(instruction []string) (*EquivocationInstruction, error) {
+	if err := ValidateEquivocationInstructionSanity(instruction); err != nil {
+		return nil, err
+	}
+	return ImportEquivocationInstructionFromString(instruction)
+}
+
+// ImportEquivocationInstructionFromString is unsafe method
+func ImportEquivocationInstructionFromString(instruction []string) (*EquivocationInstruction, error) {
+	equivocationInstruction := NewEquivocationInstruction()
+	if err := equivocationInstruction.PublicKeyStruct.FromString(instruction[1]); err != nil {
+		return nil, err
+	}
+	equivocationInstruction.PublicKey = instruction[1]
+	evidence := &consensustypes.EquivocationEvidence{}
+	if err := json.Unmarshal([]byte(instruction[2]), evidence); err != nil {
+		return nil, err
+	}
+	equivocationInstruction.Evidence = evidence
+	return equivocationInstruction, nil
+}
+
+//ValidateEquivocationInstructionSanity ...
+func ValidateEquivocationInstructionSanity(instruction []string) error {
+	if len(instruction) != 3 {
+		return fmt.Errorf("%+v: invalid length, %+v", ErrEquivocationInstruction, instruction)
+	}
+	if instruction[0] != EQUIVOCATION_ACTION {
+		return fmt.Errorf("%+v: invalid equivocation action, %+v", ErrEquivocationInstruction, instruction)
+	}
+	if _, err := incognitokey.CommitteeBase58KeyListToStruct([]string{instruction[1]}); err != nil {
+		return fmt.Errorf("%+v: invalid equivocation public key, err %+v, %+v", ErrEquivocationInstruction, err, instruction)
+	}
+	evidence := &consensustypes.EquivocationEvidence{}
+	if err := json.Unmarshal([]byte(instruction[2]), evidence); err != nil {
+		return fmt.Errorf("%+v: invalid equivocation evidence, err %+v, %+v", ErrEquivocationInstruction, err, instruction)
+	}
+	return nil
+}
@@ -0,0 +1,81 @@
+package instruction
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/incognitochain/incognito-chain/consensus_v2/consensustypes"
+)
+
+func TestEquivocationInstruction_ToStringAndImport(t *testing.T) {
+
+	initPublicKey()
+
+	evidence := consensustypes.NewEquivocationEvidence(
+		consensustypes.EquivocationSameTimeSlot,
+		consensustypes.EquivocationVote{BlockHash: "b", Validator: "v", BlockHeight: 10, ProposeTimeSlot: 5},
+		consensustypes.EquivocationVote{BlockHash: "a", Validator: "v", BlockHeight: 10, ProposeTimeSlot: 5},
+	)
+	inst := NewEquivocationInstructionWithValue(key1, evidence)
+	if inst.IsEmpty() {
+		t.Fatal("instruction must not be empty")
+	}
+	if !inst.PublicKeyStruct.IsEqual(*incKey1) {
+		t.Fatalf("public key struct = %+v, want %+v", inst.PublicKeyStruct, *incKey1)
+	}
+
+	got, err := ValidateAndImportEquivocationInstructionFromString(inst.ToString())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.PublicKey != key1 || !reflect.DeepEqual(got.Evidence, evidence) {
+		t.Errorf("ImportEquivocationInstructionFromString() = %+v, want %+v", got, inst)
+	}
+	if got.Evidence.FirstVote.BlockHash != "a" {
+		t.Errorf("votes of evidence must be ordered by block hash, got %+v", got.Evidence.FirstVote.BlockHash)
+	}
+}
+
+func TestValidateEquivocationInstructionSanity(t *testing.T) {
+
+	initPublicKey()
+
+	tests := []struct {
+		name        string
+		instruction []string
+		wantErr     bool
+	}{
+		{
+			name:        "Invalid Length",
+			instruction: []string{EQUIVOCATION_ACTION, key1},
+			wantErr:     true,
+		},
+		{
+			name:        "Invalid Action",
+			instruction: []string{FINISH_SYNC_ACTION, key1, "{}"},
+			wantErr:     true,
+		},
+		{
+			name:        "Invalid Public Key",
+			instruction: []string{EQUIVOCATION_ACTION, "key", "{}"},
+			wantErr:     true,
+		},
+		{
+			name:        "Invalid Evidence",
+			instruction: []string{EQUIVOCATION_ACTION, key1, "evidence"},
+			wantErr:     true,
+		},
+		{
+			name:        "Valid Input",
+			instruction: []string{EQUIVOCATION_ACTION, key1, "{}"},
+			wantErr:     false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := ValidateEquivocationInstructionSanity(tt.instruction); (err != nil) != tt.wantErr {
+				t.Errorf("ValidateEquivocationInstructionSanity() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
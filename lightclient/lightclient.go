@@ -0,0 +1,295 @@
+package lightclient
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/incognitochain/incognito-chain/blockchain"
+	"github.com/incognitochain/incognito-chain/blockchain/types"
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/consensus_v2/blsbft"
+	"github.com/incognitochain/incognito-chain/consensus_v2/consensustypes"
+	"github.com/incognitochain/incognito-chain/consensus_v2/signatureschemes/blsmultisig"
+	"github.com/incognitochain/incognito-chain/dataaccessobject/rawdbv2"
+	"github.com/incognitochain/incognito-chain/incdb"
+	"github.com/incognitochain/incognito-chain/incognitokey"
+)
+
+const (
+	syncInterval    = 10 * time.Second
+	syncBatchLength = 100
+)
+
+// HeaderSource is a full node serving finalized beacon headers and inclusion proofs
+type HeaderSource interface {
+	GetLightBeaconHeaders(fromHeight uint64, count int, withCommittee bool) ([]*blockchain.LightBeaconHeader, error)
+	GetBeaconInstructionProof(height uint64, index int) (*blockchain.BeaconInstructionProof, error)
+	GetTxInclusionProof(txHash common.Hash) (*blockchain.TxInclusionProof, error)
+}
+
+// State is the latest verified beacon block and the committee signing the next one
+type State struct {
+	Height    uint64
+	Hash      common.Hash
+	Committee []string
+}
+
+// LightClient syncs beacon headers only, each header is verified with the BLS aggregate signature of the tracked beacon committee,
+// committee is updated by committee proofs bound to the header roots
+type LightClient struct {
+	db        incdb.Database
+	source    HeaderSource
+	state     *State
+	committee []incognitokey.CommitteePublicKey
+	lock      *sync.RWMutex
+}
+
+func NewLightClient(db incdb.Database, source HeaderSource) (*LightClient, error) {
+	lc := &LightClient{
+		db:     db,
+		source: source,
+		lock:   new(sync.RWMutex),
+	}
+	if has, err := db.Has(rawdbv2.GetLightClientStateKey()); err != nil || !has {
+		return lc, err
+	}
+	data, err := rawdbv2.GetLightClientState(db)
+	if err != nil {
+		return nil, err
+	}
+	state := &State{}
+	if err := json.Unmarshal(data, state); err != nil {
+		return nil, err
+	}
+	committee, err := incognitokey.CommitteeBase58KeyListToStruct(state.Committee)
+	if err != nil {
+		return nil, err
+	}
+	lc.state, lc.committee = state, committee
+	return lc, nil
+}
+
+// Init trust the beacon block of checkpoint, committee after it is taken from its committee proof,
+// checkpoint is only checked against stored header if light client is already initialized
+func (lc *LightClient) Init(checkpointHeight uint64, checkpointHash common.Hash) error {
+	lc.lock.Lock()
+	defer lc.lock.Unlock()
+	if lc.state != nil {
+		if checkpointHeight > lc.state.Height {
+			return nil
+		}
+		header, err := lc.getBeaconHeader(checkpointHeight)
+		if err != nil {
+			return err
+		}
+		if header.Header.Hash() != checkpointHash {
+			return fmt.Errorf("stored beacon block %v is %v, checkpoint %v", checkpointHeight, header.Header.Hash(), checkpointHash)
+		}
+		return nil
+	}
+
+	headers, err := lc.source.GetLightBeaconHeaders(checkpointHeight, 1, true)
+	if err != nil {
+		return err
+	}
+	if len(headers) == 0 || headers[0].CommitteeProof == nil {
+		return fmt.Errorf("no committee proof of checkpoint %v", checkpointHeight)
+	}
+	header := headers[0]
+	if header.Header.Height != checkpointHeight || header.Header.Hash() != checkpointHash {
+		return fmt.Errorf("beacon block %v %v does not match checkpoint %v %v", header.Header.Height, header.Header.Hash(), checkpointHeight, checkpointHash)
+	}
+	committee, err := header.CommitteeProof.Verify(&header.Header)
+	if err != nil {
+		return err
+	}
+	return lc.storeHeader(header, committee, header.CommitteeProof.Committee)
+}
+
+// ProcessHeaders verify and store consecutive beacon headers on top of latest verified one
+func (lc *LightClient) ProcessHeaders(headers []*blockchain.LightBeaconHeader) error {
+	lc.lock.Lock()
+	defer lc.lock.Unlock()
+	if lc.state == nil {
+		return errors.New("light client is not initialized")
+	}
+	for _, header := range headers {
+		if header.Header.Height != lc.state.Height+1 {
+			return fmt.Errorf("expect beacon block %v, get %v", lc.state.Height+1, header.Header.Height)
+		}
+		if header.Header.PreviousBlockHash != lc.state.Hash {
+			return fmt.Errorf("previous hash of beacon block %v is %v, expect %v", header.Header.Height, header.Header.PreviousBlockHash, lc.state.Hash)
+		}
+		if err := verifyCommitteeSig(&header.Header, header.ValidationData, lc.committee); err != nil {
+			return fmt.Errorf("beacon block %v: %v", header.Header.Height, err)
+		}
+		committee, committeeStr := lc.committee, lc.state.Committee
+		if header.CommitteeProof != nil {
+			var err error
+			committee, err = header.CommitteeProof.Verify(&header.Header)
+			if err != nil {
+				return fmt.Errorf("committee proof of beacon block %v: %v", header.Header.Height, err)
+			}
+			committeeStr = header.CommitteeProof.Committee
+			Logger.log.Infof("Beacon committee changes at beacon block %v, size %v", header.Header.Height, len(committee))
+		}
+		if err := lc.storeHeader(header, committee, committeeStr); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (lc *LightClient) storeHeader(header *blockchain.LightBeaconHeader, committee []incognitokey.CommitteePublicKey, committeeStr []string) error {
+	state := &State{
+		Height:    header.Header.Height,
+		Hash:      header.Header.Hash(),
+		Committee: committeeStr,
+	}
+	batch := lc.db.NewBatch()
+	if err := rawdbv2.StoreLightClientBeaconHeader(batch, state.Height, header); err != nil {
+		return err
+	}
+	if err := rawdbv2.StoreLightClientState(batch, state); err != nil {
+		return err
+	}
+	if err := batch.Write(); err != nil {
+		return err
+	}
+	lc.state, lc.committee = state, committee
+	return nil
+}
+
+// verifyCommitteeSig check the aggregate signature of header is signed by more than 2/3 of committee
+func verifyCommitteeSig(header *types.BeaconHeader, validationData string, committee []incognitokey.CommitteePublicKey) error {
+	valData, err := consensustypes.DecodeValidationData(validationData)
+	if err != nil {
+		return err
+	}
+	if !blsbft.CheckValidationDataWithCommittee(valData, committee) {
+		return fmt.Errorf("validator index %v is not valid with committee size %v", valData.ValidatiorsIdx, len(committee))
+	}
+	committeeBLSKeys := []blsmultisig.PublicKey{}
+	for _, member := range committee {
+		committeeBLSKeys = append(committeeBLSKeys, member.MiningPubKey[common.BlsConsensus])
+	}
+	proposeHash := header.ProposeHash()
+	ok, err := blsmultisig.Verify(valData.AggSig, proposeHash.GetBytes(), valData.ValidatiorsIdx, committeeBLSKeys)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return errors.New("invalid aggregate signature")
+	}
+	return nil
+}
+
+// Start sync beacon headers from source until interrupt
+func (lc *LightClient) Start(interrupt <-chan struct{}) {
+	ticker := time.NewTicker(syncInterval)
+	defer ticker.Stop()
+	for {
+		if err := lc.sync(); err != nil {
+			Logger.log.Error(err)
+		}
+		select {
+		case <-interrupt:
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (lc *LightClient) sync() error {
+	for {
+		state := lc.GetState()
+		if state == nil {
+			return errors.New("light client is not initialized")
+		}
+		headers, err := lc.source.GetLightBeaconHeaders(state.Height+1, syncBatchLength, false)
+		if err != nil {
+			return err
+		}
+		if len(headers) == 0 {
+			return nil
+		}
+		if err := lc.ProcessHeaders(headers); err != nil {
+			return err
+		}
+		Logger.log.Infof("Light client synced beacon block %v", headers[len(headers)-1].Header.Height)
+	}
+}
+
+// GetState return latest verified beacon block, nil if light client is not initialized
+func (lc *LightClient) GetState() *State {
+	lc.lock.RLock()
+	defer lc.lock.RUnlock()
+	if lc.state == nil {
+		return nil
+	}
+	state := *lc.state
+	return &state
+}
+
+// GetBeaconHeader return verified beacon header
+func (lc *LightClient) GetBeaconHeader(height uint64) (*blockchain.LightBeaconHeader, error) {
+	lc.lock.RLock()
+	defer lc.lock.RUnlock()
+	return lc.getBeaconHeader(height)
+}
+
+func (lc *LightClient) getBeaconHeader(height uint64) (*blockchain.LightBeaconHeader, error) {
+	if lc.state == nil || height > lc.state.Height {
+		return nil, fmt.Errorf("beacon block %v is not synced yet", height)
+	}
+	data, err := rawdbv2.GetLightClientBeaconHeader(lc.db, height)
+	if err != nil {
+		return nil, err
+	}
+	header := &blockchain.LightBeaconHeader{}
+	if err := json.Unmarshal(data, header); err != nil {
+		return nil, err
+	}
+	return header, nil
+}
+
+// GetBeaconInstructionProof get proof of an instruction from source and verify it against the verified beacon header
+func (lc *LightClient) GetBeaconInstructionProof(height uint64, index int) (*blockchain.BeaconInstructionProof, error) {
+	proof, err := lc.source.GetBeaconInstructionProof(height, index)
+	if err != nil {
+		return nil, err
+	}
+	if proof.BeaconHeight != height || proof.Index != index {
+		return nil, fmt.Errorf("get proof of instruction %v of beacon block %v, expect %v of %v", proof.Index, proof.BeaconHeight, index, height)
+	}
+	header, err := lc.GetBeaconHeader(height)
+	if err != nil {
+		return nil, err
+	}
+	if err := proof.Verify(&header.Header); err != nil {
+		return nil, err
+	}
+	return proof, nil
+}
+
+// GetTxInclusionProof get proof of a tx from source and verify it against the verified beacon header confirming its shard block
+func (lc *LightClient) GetTxInclusionProof(txHash common.Hash) (*blockchain.TxInclusionProof, error) {
+	proof, err := lc.source.GetTxInclusionProof(txHash)
+	if err != nil {
+		return nil, err
+	}
+	if proof.TxHash != txHash {
+		return nil, fmt.Errorf("get proof of tx %v, expect %v", proof.TxHash, txHash)
+	}
+	header, err := lc.GetBeaconHeader(proof.BeaconHeight)
+	if err != nil {
+		return nil, err
+	}
+	if err := proof.Verify(&header.Header); err != nil {
+		return nil, err
+	}
+	return proof, nil
+}
@@ -0,0 +1,252 @@
+package lightclient
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/incognitochain/incognito-chain/blockchain"
+	"github.com/incognitochain/incognito-chain/blockchain/types"
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/config"
+	"github.com/incognitochain/incognito-chain/consensus_v2/consensustypes"
+	"github.com/incognitochain/incognito-chain/consensus_v2/signatureschemes/blsmultisig"
+	"github.com/incognitochain/incognito-chain/incdb"
+	_ "github.com/incognitochain/incognito-chain/incdb/lvdb"
+	"github.com/incognitochain/incognito-chain/incognitokey"
+)
+
+func init() {
+	Logger.Init(common.NewBackend(nil).Logger("test", true))
+}
+
+type testCommittee struct {
+	keys    []incognitokey.CommitteePublicKey
+	keysStr []string
+	sks     [][]byte
+}
+
+func newTestCommittee(t *testing.T, seed byte, size int) *testCommittee {
+	c := &testCommittee{}
+	for i := 0; i < size; i++ {
+		s := []byte{seed, byte(i)}
+		key, err := incognitokey.NewCommitteeKeyFromSeed(s, common.HashB(s))
+		if err != nil {
+			t.Fatal(err)
+		}
+		sk, _ := blsmultisig.KeyGen(s)
+		c.keys = append(c.keys, key)
+		c.sks = append(c.sks, blsmultisig.SKBytes(sk))
+	}
+	var err error
+	if c.keysStr, err = incognitokey.CommitteeKeyListToString(c.keys); err != nil {
+		t.Fatal(err)
+	}
+	return c
+}
+
+func (c *testCommittee) sign(t *testing.T, header *types.BeaconHeader, signers []int) string {
+	blsKeys := []blsmultisig.PublicKey{}
+	for _, key := range c.keys {
+		blsKeys = append(blsKeys, key.MiningPubKey[common.BlsConsensus])
+	}
+	proposeHash := header.ProposeHash()
+	sigs := [][]byte{}
+	for _, i := range signers {
+		sig, err := blsmultisig.Sign(proposeHash.GetBytes(), c.sks[i], i, blsKeys)
+		if err != nil {
+			t.Fatal(err)
+		}
+		sigs = append(sigs, sig)
+	}
+	aggSig, err := blsmultisig.Combine(sigs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	valData, err := consensustypes.EncodeValidationData(consensustypes.ValidationData{AggSig: aggSig, ValidatiorsIdx: signers})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return valData
+}
+
+func (c *testCommittee) root(t *testing.T) common.Hash {
+	root, err := common.GenerateHashFromStringArray(c.keysStr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return root
+}
+
+type testSource struct {
+	headers map[uint64]*blockchain.LightBeaconHeader
+	proof   *blockchain.BeaconInstructionProof
+}
+
+func (s *testSource) GetLightBeaconHeaders(fromHeight uint64, count int, withCommittee bool) ([]*blockchain.LightBeaconHeader, error) {
+	res := []*blockchain.LightBeaconHeader{}
+	for h := fromHeight; h < fromHeight+uint64(count); h++ {
+		header, ok := s.headers[h]
+		if !ok {
+			break
+		}
+		res = append(res, header)
+	}
+	return res, nil
+}
+
+func (s *testSource) GetBeaconInstructionProof(height uint64, index int) (*blockchain.BeaconInstructionProof, error) {
+	return s.proof, nil
+}
+
+func (s *testSource) GetTxInclusionProof(txHash common.Hash) (*blockchain.TxInclusionProof, error) {
+	return nil, errors.New("not supported")
+}
+
+func newTestLightClient(t *testing.T, source HeaderSource) (*LightClient, func()) {
+	dir, err := ioutil.TempDir("", "lightclient")
+	if err != nil {
+		t.Fatal(err)
+	}
+	db, err := incdb.Open("leveldb", dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	lc, err := NewLightClient(db, source)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return lc, func() {
+		db.Close()
+		os.RemoveAll(dir)
+	}
+}
+
+func TestLightClientSync(t *testing.T) {
+	config.AbortParam()
+	oldCommittee := newTestCommittee(t, 1, 4)
+	newCommittee := newTestCommittee(t, 2, 4)
+
+	insts := [][]string{{"1", "a"}, {"2", "b"}, {"3", "c"}}
+	flattenInsts, err := blockchain.FlattenAndConvertStringInst(insts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	instTree := types.BuildKeccak256MerkleTree(flattenInsts)
+
+	checkpoint := &blockchain.LightBeaconHeader{
+		Header:         types.BeaconHeader{Version: 1, Height: 10, BeaconCommitteeAndValidatorRoot: oldCommittee.root(t)},
+		CommitteeProof: &blockchain.BeaconCommitteeProof{Committee: oldCommittee.keysStr},
+	}
+	header11 := &blockchain.LightBeaconHeader{
+		Header: types.BeaconHeader{Version: 1, Height: 11, PreviousBlockHash: checkpoint.Header.Hash(), BeaconCommitteeAndValidatorRoot: oldCommittee.root(t)},
+	}
+	copy(header11.Header.InstructionMerkleRoot[:], instTree[len(instTree)-1])
+	header11.ValidationData = oldCommittee.sign(t, &header11.Header, []int{0, 1, 3})
+	// committee changes after block 12, still signed by old committee
+	header12 := &blockchain.LightBeaconHeader{
+		Header:         types.BeaconHeader{Version: 1, Height: 12, PreviousBlockHash: header11.Header.Hash(), BeaconCommitteeAndValidatorRoot: newCommittee.root(t)},
+		CommitteeProof: &blockchain.BeaconCommitteeProof{Committee: newCommittee.keysStr},
+	}
+	header12.ValidationData = oldCommittee.sign(t, &header12.Header, []int{0, 1, 2, 3})
+	header13 := &blockchain.LightBeaconHeader{
+		Header: types.BeaconHeader{Version: 1, Height: 13, PreviousBlockHash: header12.Header.Hash(), BeaconCommitteeAndValidatorRoot: newCommittee.root(t)},
+	}
+	header13.ValidationData = newCommittee.sign(t, &header13.Header, []int{1, 2, 3})
+
+	path, left := types.GetKeccak256MerkleProofFromTree(instTree, 2)
+	source := &testSource{
+		headers: map[uint64]*blockchain.LightBeaconHeader{10: checkpoint, 11: header11, 12: header12, 13: header13},
+		proof: &blockchain.BeaconInstructionProof{
+			BeaconHeight:   11,
+			BeaconHash:     header11.Header.Hash(),
+			Index:          2,
+			Instruction:    insts[2],
+			InstPath:       path,
+			InstPathIsLeft: left,
+		},
+	}
+	lc, closeDB := newTestLightClient(t, source)
+	defer closeDB()
+
+	if err := lc.Init(10, common.Hash{}); err == nil {
+		t.Fatal("Init() with wrong checkpoint hash should fail")
+	}
+	if err := lc.Init(10, checkpoint.Header.Hash()); err != nil {
+		t.Fatal(err)
+	}
+	if err := lc.sync(); err != nil {
+		t.Fatal(err)
+	}
+	state := lc.GetState()
+	if state.Height != 13 || state.Hash != header13.Header.Hash() || !common.CompareStringArray(state.Committee, newCommittee.keysStr) {
+		t.Fatalf("state = %+v", state)
+	}
+
+	proof, err := lc.GetBeaconInstructionProof(11, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if proof.Instruction[1] != "c" {
+		t.Errorf("GetBeaconInstructionProof() instruction = %v", proof.Instruction)
+	}
+	source.proof.Instruction = []string{"3", "d"}
+	if _, err := lc.GetBeaconInstructionProof(11, 2); err == nil {
+		t.Error("GetBeaconInstructionProof() of tampered instruction should fail")
+	}
+
+	// block signed by the old committee after committee change
+	header14 := &blockchain.LightBeaconHeader{
+		Header: types.BeaconHeader{Version: 1, Height: 14, PreviousBlockHash: header13.Header.Hash(), BeaconCommitteeAndValidatorRoot: newCommittee.root(t)},
+	}
+	header14.ValidationData = oldCommittee.sign(t, &header14.Header, []int{0, 1, 2})
+	if err := lc.ProcessHeaders([]*blockchain.LightBeaconHeader{header14}); err == nil {
+		t.Error("ProcessHeaders() of block signed by old committee should fail")
+	}
+	// not enough signers
+	header14.ValidationData = newCommittee.sign(t, &header14.Header, []int{0, 1})
+	if err := lc.ProcessHeaders([]*blockchain.LightBeaconHeader{header14}); err == nil {
+		t.Error("ProcessHeaders() of block signed by 2/4 committee should fail")
+	}
+	header14.ValidationData = newCommittee.sign(t, &header14.Header, []int{0, 1, 2})
+	if err := lc.ProcessHeaders([]*blockchain.LightBeaconHeader{header14}); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestProcessHeadersCommitteeProof(t *testing.T) {
+	config.AbortParam()
+	committee := newTestCommittee(t, 1, 4)
+	otherCommittee := newTestCommittee(t, 3, 4)
+	checkpoint := &blockchain.LightBeaconHeader{
+		Header:         types.BeaconHeader{Version: 1, Height: 1, BeaconCommitteeAndValidatorRoot: committee.root(t)},
+		CommitteeProof: &blockchain.BeaconCommitteeProof{Committee: committee.keysStr},
+	}
+	lc, closeDB := newTestLightClient(t, &testSource{headers: map[uint64]*blockchain.LightBeaconHeader{1: checkpoint}})
+	defer closeDB()
+	if err := lc.Init(1, checkpoint.Header.Hash()); err != nil {
+		t.Fatal(err)
+	}
+
+	// committee proof not matching the committee root of header
+	header := &blockchain.LightBeaconHeader{
+		Header:         types.BeaconHeader{Version: 1, Height: 2, PreviousBlockHash: checkpoint.Header.Hash(), BeaconCommitteeAndValidatorRoot: committee.root(t)},
+		CommitteeProof: &blockchain.BeaconCommitteeProof{Committee: otherCommittee.keysStr},
+	}
+	header.ValidationData = committee.sign(t, &header.Header, []int{0, 1, 2, 3})
+	if err := lc.ProcessHeaders([]*blockchain.LightBeaconHeader{header}); err == nil {
+		t.Error("ProcessHeaders() with committee proof not matching header should fail")
+	}
+	if lc.GetState().Height != 1 {
+		t.Errorf("state height = %v, want 1", lc.GetState().Height)
+	}
+
+	// header not linked to latest verified block
+	header.CommitteeProof = nil
+	header.Header.PreviousBlockHash = common.Hash{}
+	header.ValidationData = committee.sign(t, &header.Header, []int{0, 1, 2, 3})
+	if err := lc.ProcessHeaders([]*blockchain.LightBeaconHeader{header}); err == nil {
+		t.Error("ProcessHeaders() of header not linked to latest block should fail")
+	}
+}
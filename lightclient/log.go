@@ -0,0 +1,14 @@
+package lightclient
+
+import "github.com/incognitochain/incognito-chain/common"
+
+type lightClientLogger struct {
+	log common.Logger
+}
+
+func (self *lightClientLogger) Init(inst common.Logger) {
+	self.log = inst
+}
+
+// Global instant to use
+var Logger = lightClientLogger{}
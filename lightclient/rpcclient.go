@@ -0,0 +1,86 @@
+package lightclient
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"time"
+
+	"github.com/incognitochain/incognito-chain/blockchain"
+	"github.com/incognitochain/incognito-chain/common"
+)
+
+// RemoteSource is a full node serving light client rpcs
+type RemoteSource struct {
+	Endpoint string
+	client   *http.Client
+}
+
+type errMsg struct {
+	Code       int
+	Message    string
+	StackTrace string
+}
+
+func NewRemoteSource(endpoint string) *RemoteSource {
+	return &RemoteSource{
+		Endpoint: endpoint,
+		client:   &http.Client{Timeout: 60 * time.Second},
+	}
+}
+
+func (r *RemoteSource) call(method string, params []interface{}, result interface{}) error {
+	requestBody, err := json.Marshal(map[string]interface{}{
+		"jsonrpc": "1.0",
+		"method":  method,
+		"params":  params,
+		"id":      1,
+	})
+	if err != nil {
+		return err
+	}
+	resp, err := r.client.Post(r.Endpoint, "application/json", bytes.NewBuffer(requestBody))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	res := struct {
+		Result json.RawMessage
+		Error  *errMsg
+	}{}
+	if err := json.Unmarshal(body, &res); err != nil {
+		return err
+	}
+	if res.Error != nil {
+		if res.Error.StackTrace != "" {
+			return errors.New(res.Error.StackTrace)
+		}
+		return errors.New(res.Error.Message)
+	}
+	return json.Unmarshal(res.Result, result)
+}
+
+func (r *RemoteSource) GetLightBeaconHeaders(fromHeight uint64, count int, withCommittee bool) ([]*blockchain.LightBeaconHeader, error) {
+	res := []*blockchain.LightBeaconHeader{}
+	err := r.call("getlightbeaconheaders", []interface{}{fromHeight, count, withCommittee}, &res)
+	return res, err
+}
+
+func (r *RemoteSource) GetBeaconInstructionProof(height uint64, index int) (*blockchain.BeaconInstructionProof, error) {
+	res := &blockchain.BeaconInstructionProof{}
+	err := r.call("getbeaconinstructionproof", []interface{}{height, index}, res)
+	return res, err
+}
+
+func (r *RemoteSource) GetTxInclusionProof(txHash common.Hash) (*blockchain.TxInclusionProof, error) {
+	res := &blockchain.TxInclusionProof{}
+	err := r.call("gettxinclusionproof", []interface{}{txHash.String()}, res)
+	return res, err
+}
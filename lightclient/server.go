@@ -0,0 +1,125 @@
+package lightclient
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/incognitochain/incognito-chain/common"
+)
+
+type jsonRequest struct {
+	Method string        `json:"Method"`
+	Params []interface{} `json:"Params"`
+	Id     interface{}   `json:"Id"`
+}
+
+type jsonError struct {
+	Code    int    `json:"Code"`
+	Message string `json:"Message"`
+}
+
+type jsonResponse struct {
+	Id     interface{} `json:"Id"`
+	Result interface{} `json:"Result"`
+	Error  *jsonError  `json:"Error"`
+}
+
+// Server serves verified beacon headers and inclusion proofs of light client to wallets and bridge relayers
+type Server struct {
+	lc         *LightClient
+	httpServer *http.Server
+}
+
+func NewServer(lc *LightClient) *Server {
+	return &Server{lc: lc}
+}
+
+// Serve listens on address and serves json rpc until the server is stopped
+func (s *Server) Serve(listen string) error {
+	s.httpServer = &http.Server{
+		Addr:    listen,
+		Handler: http.HandlerFunc(s.handleRequest),
+	}
+	Logger.log.Infof("Light client rpc is listening on %v", listen)
+	err := s.httpServer.ListenAndServe()
+	if err == http.ErrServerClosed {
+		return nil
+	}
+	return err
+}
+
+func (s *Server) Stop() {
+	if s.httpServer != nil {
+		s.httpServer.Close()
+	}
+}
+
+func (s *Server) handleRequest(w http.ResponseWriter, r *http.Request) {
+	request := jsonRequest{}
+	response := jsonResponse{}
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		response.Error = &jsonError{Code: -1, Message: err.Error()}
+	} else {
+		response.Id = request.Id
+		result, err := s.handle(request.Method, request.Params)
+		if err != nil {
+			response.Error = &jsonError{Code: -1, Message: err.Error()}
+		} else {
+			response.Result = result
+		}
+	}
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		Logger.log.Error(err)
+	}
+}
+
+func (s *Server) handle(method string, params []interface{}) (interface{}, error) {
+	switch method {
+	case "getlightclientstate":
+		state := s.lc.GetState()
+		if state == nil {
+			return nil, errors.New("light client is not initialized")
+		}
+		return state, nil
+	case "getbeaconheader":
+		if len(params) < 1 {
+			return nil, errors.New("param must be an array at least 1 element")
+		}
+		height, ok := params[0].(float64)
+		if !ok {
+			return nil, errors.New("height is invalid")
+		}
+		return s.lc.GetBeaconHeader(uint64(height))
+	case "getbeaconinstructionproof":
+		if len(params) < 2 {
+			return nil, errors.New("param must be an array at least 2 elements")
+		}
+		height, ok := params[0].(float64)
+		if !ok {
+			return nil, errors.New("height is invalid")
+		}
+		index, ok := params[1].(float64)
+		if !ok {
+			return nil, errors.New("instruction index is invalid")
+		}
+		return s.lc.GetBeaconInstructionProof(uint64(height), int(index))
+	case "gettxinclusionproof":
+		if len(params) < 1 {
+			return nil, errors.New("param must be an array at least 1 element")
+		}
+		txHashStr, ok := params[0].(string)
+		if !ok {
+			return nil, errors.New("tx hash is invalid")
+		}
+		txHash, err := common.Hash{}.NewHashFromStr(txHashStr)
+		if err != nil {
+			return nil, err
+		}
+		return s.lc.GetTxInclusionProof(*txHash)
+	default:
+		return nil, fmt.Errorf("method %v is not supported by light client", method)
+	}
+}
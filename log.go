@@ -18,10 +18,13 @@ import (
 	"github.com/incognitochain/incognito-chain/common"
 	"github.com/incognitochain/incognito-chain/connmanager"
 	consensus "github.com/incognitochain/incognito-chain/consensus_v2"
+	"github.com/incognitochain/incognito-chain/consensus_v2/equivocation"
+	"github.com/incognitochain/incognito-chain/consensus_v2/remotesigner"
 	"github.com/incognitochain/incognito-chain/dataaccessobject"
 	"github.com/incognitochain/incognito-chain/databasemp"
 	"github.com/incognitochain/incognito-chain/incdb"
 	"github.com/incognitochain/incognito-chain/instruction"
+	"github.com/incognitochain/incognito-chain/lightclient"
 	"github.com/incognitochain/incognito-chain/mempool"
 	"github.com/incognitochain/incognito-chain/metadata"
 	"github.com/incognitochain/incognito-chain/netsync"
@@ -71,6 +74,8 @@ var (
 	walletLogger           = backendLog.Logger("Wallet log", false)
 	blockchainLogger       = backendLog.Logger("BlockChain log", false)
 	consensusLogger        = backendLog.Logger("Consensus log", false)
+	remoteSignerLogger     = backendLog.Logger("Remote signer log", false)
+	lightClientLogger      = backendLog.Logger("Light client log", false)
 	mempoolLogger          = backendLog.Logger("Mempool log", false)
 	transactionLogger      = backendLog.Logger("Transaction log", false)
 	privacyLogger          = backendLog.Logger("Privacy log", false)
@@ -91,6 +96,7 @@ var (
 	pdexLogger             = backendLog.Logger("Pdex log ", false)
 	bridgeAggLogger        = backendLog.Logger("BridgeAgg log ", false)
 	finishSyncLogger       = backendLog.Logger("Finish Sync log ", false)
+	equivocationLogger     = backendLog.Logger("Equivocation log ", false)
 	prunerLogger           = backendLog.Logger("Pruner log ", false)
 
 	portalLogger          = backendLog.Logger("Portal log ", false)
@@ -132,6 +138,8 @@ func init() {
 	wallet.Logger.Init(walletLogger)
 	blockchain.Logger.Init(blockchainLogger)
 	consensus.Logger.Init(consensusLogger)
+	remotesigner.Logger.Init(remoteSignerLogger)
+	lightclient.Logger.Init(lightClientLogger)
 	mempool.Logger.Init(mempoolLogger)
 	transaction.Logger.Init(transactionLogger)
 	//privacy.Logger.Init(privacyLogger)
@@ -147,6 +155,7 @@ func init() {
 	btcRelaying.Logger.Init(btcRelayingLogger)
 	syncker.Logger.Init(synckerLogger)
 	finishsync.Logger.Init(finishSyncLogger)
+	equivocation.Logger.Init(equivocationLogger)
 	privacy.LoggerV1.Init(privacyV1Logger)
 	privacy.LoggerV2.Init(privacyV2Logger)
 	instruction.Logger.Init(instructionLogger)
@@ -183,6 +192,8 @@ var subsystemLoggers = map[string]common.Logger{
 	"WALL":              walletLogger,
 	"BLOC":              blockchainLogger,
 	"CONS":              consensusLogger,
+	"RSIG":              remoteSignerLogger,
+	"LCLI":              lightClientLogger,
 	"MEMP":              mempoolLogger,
 	"RAND":              randomLogger,
 	"TRAN":              transactionLogger,
@@ -198,6 +209,7 @@ var subsystemLoggers = map[string]common.Logger{
 	"INST":              instructionLogger,
 	"COMS":              committeeStateLogger,
 	"FINS":              finishSyncLogger,
+	"EQUI":              equivocationLogger,
 	"PORTAL":            portalLogger,
 	"PORTALRELAYING":    portalRelayingLogger,
 	"PORTALV3COMMON":    portalV3CommonLogger,
@@ -206,6 +218,10 @@ var subsystemLoggers = map[string]common.Logger{
 	"PORTALV4PROCESS":   portalV4ProcessLogger,
 	"PORTALV4TOKENS":    portalV4TokenLogger,
 	"EVMCALLER":         evmCallerLogger,
+	"PDEX":              pdexLogger,
+	"BRIDGEAGG":         bridgeAggLogger,
+	"PRUNER":            prunerLogger,
+	"TXPOOL":            txPoolLogger,
 }
 
 // initLogRotator initializes the logging rotater to write logs to logFile and
@@ -345,3 +361,45 @@ func parseAndSetDebugLevels(debugLevel string) error {
 	}
 	return nil
 }
+
+// setLogFormat sets the output format of all subsystem loggers, text or json.
+func setLogFormat(logFormat string) error {
+	format, err := common.LogFormatFromString(logFormat)
+	if err != nil {
+		return err
+	}
+	backendLog.SetFormat(format)
+	return nil
+}
+
+// logLevelController gets and sets the logging level of subsystems while the
+// node is running, it is used by the rpc server.
+type logLevelController struct{}
+
+// GetLogLevels returns the current logging level of every subsystem.
+func (logLevelController) GetLogLevels() map[string]string {
+	levels := make(map[string]string, len(subsystemLoggers))
+	for subsysID, logger := range subsystemLoggers {
+		levels[subsysID] = logger.Level().String()
+	}
+	return levels
+}
+
+// SetLogLevel sets the logging level of the subsystem, or of all subsystems
+// when subsysID is "all".
+func (logLevelController) SetLogLevel(subsysID string, logLevel string) error {
+	if !validLogLevel(logLevel) {
+		str := "the specified debug level [%v] is invalid"
+		return fmt.Errorf(str, logLevel)
+	}
+	if subsysID == "all" {
+		setLogLevels(logLevel)
+		return nil
+	}
+	if _, exists := subsystemLoggers[subsysID]; !exists {
+		str := "the specified subsystem [%v] is invalid -- supported subsytems %v"
+		return fmt.Errorf(str, subsysID, supportedSubsystems())
+	}
+	setLogLevel(subsysID, logLevel)
+	return nil
+}
@@ -90,7 +90,8 @@ func (request *ShieldRequest) ValidateSanityData(chainRetriever metadataCommon.C
 	}
 	for _, data := range request.Data {
 		if (data.NetworkID == common.AVAXNetworkID && shardViewRetriever.GetTriggeredFeature()["auroraavaxbridge"] == 0) ||
-			(data.NetworkID == common.AURORANetworkID && shardViewRetriever.GetTriggeredFeature()["aurorahotfix"] == 0) {
+			(data.NetworkID == common.AURORANetworkID && shardViewRetriever.GetTriggeredFeature()["aurorahotfix"] == 0) ||
+			(data.NetworkID == common.SOLNetworkID && shardViewRetriever.GetTriggeredFeature()["solbridge"] == 0) {
 			return false, false, metadataCommon.NewMetadataTxError(metadataCommon.UnexpectedError, errors.New("Feature not enabled yet"))
 		}
 
@@ -134,6 +135,13 @@ func (request *ShieldRequest) ValidateMetadataByItself() bool {
 				return false
 			}
 			return auroraShieldRequest.ValidateMetadataByItself()
+		case common.SOLNetworkID:
+			_, _, err := UnmarshalSOLShieldProof(data.Proof)
+			if err != nil {
+				metadataCommon.Logger.Log.Errorf("Can not unmarshal solana proof: %v\n", err)
+				return false
+			}
+			return true
 		case common.DefaultNetworkID:
 			return false
 		default:
@@ -202,6 +210,12 @@ func (request *ShieldRequest) BuildReqActions(tx metadataCommon.Transaction, cha
 				return [][]string{}, err
 			}
 			extraData = append(extraData, content)
+		case common.SOLNetworkType:
+			content, err := VerifySOLShieldProof(data.Proof)
+			if err != nil {
+				return [][]string{}, err
+			}
+			extraData = append(extraData, content)
 		default:
 			return [][]string{}, errors.New("Invalid networkID")
 		}
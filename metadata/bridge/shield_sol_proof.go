@@ -0,0 +1,181 @@
+package bridge
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/common/base58"
+	"github.com/incognitochain/incognito-chain/config"
+	metadataCommon "github.com/incognitochain/incognito-chain/metadata/common"
+	"github.com/incognitochain/incognito-chain/metadata/rpccaller"
+	"github.com/incognitochain/incognito-chain/wallet"
+)
+
+// SOLShieldInstructionTag is the first byte of the shield instruction data of the Solana vault program.
+// The instruction data layout is: tag (1 byte) | amount (8 bytes, little endian) | incognito payment address.
+// The instruction accounts are: depositor token account | vault token account | token mint.
+const (
+	SOLShieldInstructionTag    = 1
+	SOLShieldInstructionMinLen = 1 + 8
+	SOLShieldMintAccountIndex  = 2
+	SOLSignatureLength         = 64
+)
+
+// SOLShieldProof is the shielding proof of Solana network submitted by users
+type SOLShieldProof struct {
+	TxSignature string `json:"TxSignature"`
+}
+
+// SOLShieldActionData is the shielding event extracted by shard from a finalized Solana transaction
+type SOLShieldActionData struct {
+	TxSignature []byte `json:"TxSignature"`
+	Slot        uint64 `json:"Slot"`
+	Mint        []byte `json:"Mint"`
+	Amount      uint64 `json:"Amount"`
+	IncAddress  string `json:"IncAddress"`
+}
+
+type SOLInstruction struct {
+	ProgramIDIndex int    `json:"programIdIndex"`
+	Accounts       []int  `json:"accounts"`
+	Data           string `json:"data"`
+}
+
+type SOLTransaction struct {
+	Slot uint64 `json:"slot"`
+	Meta *struct {
+		Err interface{} `json:"err"`
+	} `json:"meta"`
+	Transaction struct {
+		Signatures []string `json:"signatures"`
+		Message    struct {
+			AccountKeys  []string         `json:"accountKeys"`
+			Instructions []SOLInstruction `json:"instructions"`
+		} `json:"message"`
+	} `json:"transaction"`
+}
+
+type GetSOLTransactionRes struct {
+	rpccaller.RPCBaseRes
+	Result *SOLTransaction `json:"result"`
+}
+
+func UnmarshalSOLShieldProof(proofBytes []byte) (*SOLShieldProof, []byte, error) {
+	proof := &SOLShieldProof{}
+	err := json.Unmarshal(proofBytes, proof)
+	if err != nil {
+		return nil, nil, err
+	}
+	signature := base58.Base58{}.Decode(proof.TxSignature)
+	if len(signature) != SOLSignatureLength {
+		return nil, nil, fmt.Errorf("Invalid solana tx signature %v", proof.TxSignature)
+	}
+	return proof, signature, nil
+}
+
+// VerifySOLShieldProof fetches the finalized shielding transaction from Solana hosts
+// and returns the action data for beacon
+func VerifySOLShieldProof(proofBytes []byte) ([]byte, error) {
+	proof, _, err := UnmarshalSOLShieldProof(proofBytes)
+	if err != nil {
+		return nil, err
+	}
+	solParam := config.Param().SOLParam
+	solParam.GetFromEnv()
+	programID := config.Param().SolProgramIDStr
+	if programID == "" {
+		return nil, errors.New("Solana vault program is not configured")
+	}
+
+	var tx *SOLTransaction
+	rpcClient := rpccaller.NewRPCClient()
+	for _, host := range solParam.Host {
+		res := GetSOLTransactionRes{}
+		err = rpcClient.RPCCall(
+			"", host, "",
+			"getTransaction",
+			[]interface{}{proof.TxSignature, map[string]string{"encoding": "json", "commitment": "finalized"}},
+			&res,
+		)
+		if err != nil {
+			metadataCommon.Logger.Log.Warnf("Can not get solana tx from host %v - Error %v", host, err)
+			continue
+		}
+		if res.RPCError != nil {
+			metadataCommon.Logger.Log.Warnf("Can not get solana tx from host %v - Error %v", host, res.RPCError.Message)
+			continue
+		}
+		if res.Result == nil {
+			return nil, errors.New("The shield transaction is not finalized")
+		}
+		tx = res.Result
+		break
+	}
+	if tx == nil {
+		return nil, errors.New("Can not get solana tx from all hosts")
+	}
+
+	actionData, err := ParseSOLShieldTx(tx, programID)
+	if err != nil {
+		return nil, err
+	}
+	if (base58.Base58{}).Encode(actionData.TxSignature) != proof.TxSignature {
+		return nil, errors.New("Solana tx signature is mismatched with the proof")
+	}
+	return json.Marshal(actionData)
+}
+
+// ParseSOLShieldTx extracts the shielding event of the vault program from a Solana transaction
+func ParseSOLShieldTx(tx *SOLTransaction, programID string) (*SOLShieldActionData, error) {
+	if tx.Meta == nil || tx.Meta.Err != nil {
+		return nil, errors.New("Solana shield transaction is failed")
+	}
+	if len(tx.Transaction.Signatures) == 0 {
+		return nil, errors.New("Solana shield transaction has no signature")
+	}
+	signature := base58.Base58{}.Decode(tx.Transaction.Signatures[0])
+	if len(signature) != SOLSignatureLength {
+		return nil, fmt.Errorf("Invalid solana tx signature %v", tx.Transaction.Signatures[0])
+	}
+	accountKeys := tx.Transaction.Message.AccountKeys
+	for _, inst := range tx.Transaction.Message.Instructions {
+		if inst.ProgramIDIndex < 0 || inst.ProgramIDIndex >= len(accountKeys) || accountKeys[inst.ProgramIDIndex] != programID {
+			continue
+		}
+		data := base58.Base58{}.Decode(inst.Data)
+		if len(data) <= SOLShieldInstructionMinLen || data[0] != SOLShieldInstructionTag {
+			continue
+		}
+		if len(inst.Accounts) <= SOLShieldMintAccountIndex {
+			return nil, errors.New("Invalid accounts of solana shield instruction")
+		}
+		mintIndex := inst.Accounts[SOLShieldMintAccountIndex]
+		if mintIndex < 0 || mintIndex >= len(accountKeys) {
+			return nil, errors.New("Invalid token mint of solana shield instruction")
+		}
+		mint := base58.Base58{}.Decode(accountKeys[mintIndex])
+		if len(mint) != common.SOLAddressLength {
+			return nil, fmt.Errorf("Invalid token mint %v", accountKeys[mintIndex])
+		}
+		amount := binary.LittleEndian.Uint64(data[1:SOLShieldInstructionMinLen])
+		if amount == 0 {
+			return nil, errors.New("Solana shield amount is zero")
+		}
+		incAddrStr := string(data[SOLShieldInstructionMinLen:])
+		key, err := wallet.Base58CheckDeserialize(incAddrStr)
+		if err != nil || len(key.KeySet.PaymentAddress.Pk) == 0 {
+			return nil, fmt.Errorf("Invalid incognito address %v", incAddrStr)
+		}
+		return &SOLShieldActionData{
+			TxSignature: signature,
+			Slot:        tx.Slot,
+			Mint:        mint,
+			Amount:      amount,
+			IncAddress:  incAddrStr,
+		}, nil
+	}
+	return nil, errors.New("Not found shield instruction of solana vault program")
+}
@@ -14,10 +14,11 @@ import (
 )
 
 type UnshieldRequest struct {
-	UnifiedTokenID common.Hash           `json:"UnifiedTokenID"`
-	Data           []UnshieldRequestData `json:"Data"`
-	Receiver       privacy.OTAReceiver   `json:"Receiver"`
-	IsDepositToSC  bool                  `json:"IsDepositToSC"`
+	UnifiedTokenID common.Hash                   `json:"UnifiedTokenID"`
+	Data           []UnshieldRequestData         `json:"Data"`
+	AutoRoute      *AutoRouteUnshieldRequestData `json:"AutoRoute,omitempty"`
+	Receiver       privacy.OTAReceiver           `json:"Receiver"`
+	IsDepositToSC  bool                          `json:"IsDepositToSC"`
 	metadataCommon.MetadataBase
 }
 
@@ -28,6 +29,19 @@ type UnshieldRequestData struct {
 	RemoteAddress     string      `json:"RemoteAddress"`
 }
 
+// AutoRouteUnshieldRequestData lets the beacon producer split BurningAmount across the candidate networks
+// with the most available liquidity. The request is rejected if the total unshield fee is greater than MaxFee.
+type AutoRouteUnshieldRequestData struct {
+	BurningAmount uint64               `json:"BurningAmount"`
+	MaxFee        uint64               `json:"MaxFee"`
+	Candidates    []AutoRouteCandidate `json:"Candidates"`
+}
+
+type AutoRouteCandidate struct {
+	IncTokenID    common.Hash `json:"IncTokenID"`
+	RemoteAddress string      `json:"RemoteAddress"`
+}
+
 type RejectedUnshieldRequest struct {
 	UnifiedTokenID common.Hash         `json:"UnifiedTokenID"`
 	Amount         uint64              `json:"Amount"`
@@ -62,6 +76,21 @@ func NewUnshieldRequestWithValue(
 	}
 }
 
+// TotalBurningAmount returns the amount of unified token burned by the request
+func (request *UnshieldRequest) TotalBurningAmount() (uint64, error) {
+	if request.AutoRoute != nil {
+		return request.AutoRoute.BurningAmount, nil
+	}
+	var totalBurningAmount uint64 = 0
+	for _, data := range request.Data {
+		totalBurningAmount += data.BurningAmount
+		if totalBurningAmount < data.BurningAmount {
+			return 0, fmt.Errorf("out of range uint64")
+		}
+	}
+	return totalBurningAmount, nil
+}
+
 func (request *UnshieldRequest) ValidateTxWithBlockChain(tx metadataCommon.Transaction, chainRetriever metadataCommon.ChainRetriever, shardViewRetriever metadataCommon.ShardViewRetriever, beaconViewRetriever metadataCommon.BeaconViewRetriever, shardID byte, transactionStateDB *statedb.StateDB) (bool, error) {
 	return true, nil
 }
@@ -71,7 +100,14 @@ func (request *UnshieldRequest) ValidateSanityData(chainRetriever metadataCommon
 	if request.UnifiedTokenID.IsZeroValue() {
 		return false, false, metadataCommon.NewMetadataTxError(metadataCommon.BridgeAggConvertRequestValidateSanityDataError, fmt.Errorf("UnifiedTokenID can not be empty"))
 	}
-	if len(request.Data) <= 0 || len(request.Data) > int(config.Param().BridgeAggParam.MaxLenOfPath) {
+	if request.AutoRoute != nil {
+		if shardViewRetriever.GetTriggeredFeature()["bridgeaggautoroute"] == 0 {
+			return false, false, metadataCommon.NewMetadataTxError(metadataCommon.UnexpectedError, fmt.Errorf("Feature not enabled yet"))
+		}
+		if len(request.Data) != 0 {
+			return false, false, metadataCommon.NewMetadataTxError(metadataCommon.BridgeAggUnshieldValidateSanityDataError, fmt.Errorf("Data must be empty in auto-route mode"))
+		}
+	} else if len(request.Data) <= 0 || len(request.Data) > int(config.Param().BridgeAggParam.MaxLenOfPath) {
 		return false, false, metadataCommon.NewMetadataTxError(metadataCommon.BridgeAggUnshieldValidateSanityDataError, fmt.Errorf("Length of data %d need to be in [1..%d]", len(request.Data), config.Param().BridgeAggParam.MaxLenOfPath))
 	}
 	if !request.Receiver.IsValid() {
@@ -81,6 +117,9 @@ func (request *UnshieldRequest) ValidateSanityData(chainRetriever metadataCommon
 		return false, false, metadataCommon.NewMetadataTxError(metadataCommon.BridgeAggUnshieldValidateSanityDataError, fmt.Errorf("otaReceiver shardID is different from txShardID"))
 	}
 	usedTokenIDs[request.UnifiedTokenID] = true
+	if request.AutoRoute != nil {
+		return request.validateAutoRouteSanityData(tx, usedTokenIDs)
+	}
 	totalBurningAmount := uint64(0)
 	for _, data := range request.Data {
 		if _, err := hex.DecodeString(data.RemoteAddress); err != nil {
@@ -104,6 +143,36 @@ func (request *UnshieldRequest) ValidateSanityData(chainRetriever metadataCommon
 			return false, false, metadataCommon.NewMetadataTxError(metadataCommon.BridgeAggUnshieldValidateSanityDataError, fmt.Errorf("Out of range uint64"))
 		}
 	}
+	return request.validateBurnTx(tx, totalBurningAmount, usedTokenIDs)
+}
+
+func (request *UnshieldRequest) validateAutoRouteSanityData(tx metadataCommon.Transaction, usedTokenIDs map[common.Hash]bool) (bool, bool, error) {
+	autoRoute := request.AutoRoute
+	if len(autoRoute.Candidates) <= 0 || len(autoRoute.Candidates) > int(config.Param().BridgeAggParam.MaxLenOfPath) {
+		return false, false, metadataCommon.NewMetadataTxError(metadataCommon.BridgeAggUnshieldValidateSanityDataError, fmt.Errorf("Length of candidates %d need to be in [1..%d]", len(autoRoute.Candidates), config.Param().BridgeAggParam.MaxLenOfPath))
+	}
+	if autoRoute.BurningAmount == 0 {
+		return false, false, metadataCommon.NewMetadataTxError(metadataCommon.BridgeAggUnshieldValidateSanityDataError, fmt.Errorf("wrong request info's burned amount"))
+	}
+	if autoRoute.MaxFee >= autoRoute.BurningAmount {
+		return false, false, metadataCommon.NewMetadataTxError(metadataCommon.BridgeAggUnshieldValidateSanityDataError, fmt.Errorf("maxFee %v must be less than burningAmount %v", autoRoute.MaxFee, autoRoute.BurningAmount))
+	}
+	for _, candidate := range autoRoute.Candidates {
+		if _, err := hex.DecodeString(candidate.RemoteAddress); err != nil {
+			return false, false, metadataCommon.NewMetadataTxError(metadataCommon.BridgeAggUnshieldValidateSanityDataError, err)
+		}
+		if candidate.IncTokenID.IsZeroValue() {
+			return false, false, metadataCommon.NewMetadataTxError(metadataCommon.BridgeAggUnshieldValidateSanityDataError, fmt.Errorf("IncTokenID cannot be empty"))
+		}
+		if usedTokenIDs[candidate.IncTokenID] {
+			return false, false, metadataCommon.NewMetadataTxError(metadataCommon.BridgeAggUnshieldValidateSanityDataError, fmt.Errorf("IncTokenID duplicate with tokenID %s", candidate.IncTokenID.String()))
+		}
+		usedTokenIDs[candidate.IncTokenID] = true
+	}
+	return request.validateBurnTx(tx, autoRoute.BurningAmount, usedTokenIDs)
+}
+
+func (request *UnshieldRequest) validateBurnTx(tx metadataCommon.Transaction, totalBurningAmount uint64, usedTokenIDs map[common.Hash]bool) (bool, bool, error) {
 	isBurned, burnCoin, burnedTokenID, err := tx.GetTxBurnData()
 	if err != nil || !isBurned {
 		return false, false, metadataCommon.NewMetadataTxError(metadataCommon.BridgeAggUnshieldValidateSanityDataError, fmt.Errorf("it is not transaction burn. Error %v", err))
@@ -828,6 +828,8 @@ func GetNetworkTypeByNetworkID(networkID uint8) (uint, error) {
 		return common.EVMNetworkType, nil
 	case common.AURORANetworkID:
 		return common.AURORANetworkID, nil
+	case common.SOLNetworkID:
+		return common.SOLNetworkType, nil
 	default:
 		return 0, errors.New("Not found networkID")
 	}
@@ -857,6 +859,8 @@ func IsBurningConfirmMetaType(metaType int) bool {
 		return true
 	case metadataCommon.BurningPRVRequestConfirmMeta:
 		return true
+	case metadataCommon.BurningSOLConfirmMeta:
+		return true
 	default:
 		return false
 	}
@@ -16,6 +16,7 @@ type AccumulatedValues struct {
 	UniqNEARTxsUsed   [][]byte
 	UniqAURORATxsUsed [][]byte
 	UniqAVAXTxsUsed   [][]byte
+	UniqSOLTxsUsed    [][]byte
 	DBridgeTokenPair  map[string][]byte
 	CBridgeTokens     []*common.Hash
 	InitTokens        []*common.Hash
@@ -37,6 +38,8 @@ func (ac *AccumulatedValues) UpdateUniqTxsUsed(networkID uint8, uniqTxsUsed [][]
 		ac.UniqAVAXTxsUsed = uniqTxsUsed
 	case common.NEARNetworkID:
 		ac.UniqNEARTxsUsed = uniqTxsUsed
+	case common.SOLNetworkID:
+		ac.UniqSOLTxsUsed = uniqTxsUsed
 	default:
 		return nil, errors.New("Invalid networkID")
 	}
@@ -125,6 +128,11 @@ func (ac *AccumulatedValues) Clone() *AccumulatedValues {
 		res.UniqFTMTxsUsed[i] = make([]byte, len(v))
 		copy(res.UniqFTMTxsUsed[i], v)
 	}
+	res.UniqSOLTxsUsed = make([][]byte, len(ac.UniqSOLTxsUsed))
+	for i, v := range ac.UniqSOLTxsUsed {
+		res.UniqSOLTxsUsed[i] = make([]byte, len(v))
+		copy(res.UniqSOLTxsUsed[i], v)
+	}
 	res.CBridgeTokens = make([]*common.Hash, len(ac.CBridgeTokens))
 	for _, v := range ac.CBridgeTokens {
 		tokenID := &common.Hash{}
@@ -116,6 +116,7 @@ const (
 	// relaying
 	RelayingBNBHeaderMeta = 200
 	RelayingBTCHeaderMeta = 201
+	RelayingLTCHeaderMeta = 211
 
 	PortalTopUpWaitingPortingRequestMeta  = 202
 	PortalTopUpWaitingPortingResponseMeta = 203
@@ -257,6 +258,9 @@ const (
 
 	InscribeRequestMeta  = 363
 	InscribeResponseMeta = 364
+
+	// unshield to Solana
+	BurningSOLConfirmMeta = 165
 )
 
 var minerCreatedMetaTypes = []int{
@@ -400,6 +404,7 @@ var portalMetaTypesV3 = []int{
 var portalRelayingMetaTypes = []int{
 	RelayingBNBHeaderMeta,
 	RelayingBTCHeaderMeta,
+	RelayingLTCHeaderMeta,
 }
 
 var bridgeMetas = []string{
@@ -424,6 +429,7 @@ var bridgeMetas = []string{
 	strconv.Itoa(BurningAvaxConfirmForDepositToSCMeta),
 	strconv.Itoa(BurningNearConfirmMeta),
 	strconv.Itoa(BurningPRVRequestConfirmMeta),
+	strconv.Itoa(BurningSOLConfirmMeta),
 }
 
 var portalV4MetaTypes = []int{
@@ -74,6 +74,7 @@ type ChainRetriever interface {
 	GetBTCChainID() string
 	GetBTCHeaderChain() *btcrelaying.BlockChain
 	GetBTCChainParams() *chaincfg.Params
+	GetLTCHeaderChain() *btcrelaying.BlockChain
 	GetShardStakingTx(shardID byte, beaconHeight uint64) (map[string]string, error)
 	IsAfterNewZKPCheckPoint(beaconHeight uint64) bool
 	IsAfterPrivacyV2CheckPoint(beaconHeight uint64) bool
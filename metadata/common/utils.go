@@ -240,6 +240,7 @@ func buildMetaInfo() {
 		PortalUnlockOverRateCollateralsMeta,
 		RelayingBNBHeaderMeta,
 		RelayingBTCHeaderMeta,
+		RelayingLTCHeaderMeta,
 		PortalTopUpWaitingPortingRequestMeta,
 
 		IssuingRequestMeta,
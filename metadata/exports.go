@@ -167,6 +167,7 @@ const (
 	// relaying
 	RelayingBNBHeaderMeta                 = metadataCommon.RelayingBNBHeaderMeta
 	RelayingBTCHeaderMeta                 = metadataCommon.RelayingBTCHeaderMeta
+	RelayingLTCHeaderMeta                 = metadataCommon.RelayingLTCHeaderMeta
 	PortalTopUpWaitingPortingRequestMeta  = metadataCommon.PortalTopUpWaitingPortingRequestMeta
 	PortalTopUpWaitingPortingResponseMeta = metadataCommon.PortalTopUpWaitingPortingResponseMeta
 	// incognito mode for smart contract
@@ -247,6 +248,8 @@ const (
 
 	BurningPRVRequestMeta        = metadataCommon.BurningPRVRequestMeta
 	BurningPRVRequestConfirmMeta = metadataCommon.BurningPRVRequestConfirmMeta
+
+	BurningSOLConfirmMeta = metadataCommon.BurningSOLConfirmMeta
 )
 
 // export error codes
@@ -181,6 +181,8 @@ func ParseMetadata(meta interface{}) (Metadata, error) {
 		md = &RelayingHeader{}
 	case RelayingBTCHeaderMeta:
 		md = &RelayingHeader{}
+	case RelayingLTCHeaderMeta:
+		md = &RelayingHeader{}
 	case PortalCustodianWithdrawRequestMeta:
 		md = &PortalCustodianWithdrawRequest{}
 	case PortalCustodianWithdrawResponseMeta:
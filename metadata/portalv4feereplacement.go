@@ -4,6 +4,7 @@ import (
 	"encoding/base64"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"strconv"
 
 	"github.com/incognitochain/incognito-chain/common"
@@ -114,8 +115,9 @@ func (repl PortalReplacementFeeRequest) ValidateSanityData(chainRetriever ChainR
 	}
 
 	// validate amount of pToken is divisible by the decimal difference between nano pToken and nano Token
-	if uint64(repl.Fee)%chainRetriever.GetPortalV4MultipleTokenAmount(repl.TokenID, beaconHeight) != 0 {
-		return false, false, errors.New("pBTC amount has to be divisible by 10")
+	multipleTokenAmount := chainRetriever.GetPortalV4MultipleTokenAmount(repl.TokenID, beaconHeight)
+	if uint64(repl.Fee)%multipleTokenAmount != 0 {
+		return false, false, fmt.Errorf("Fee amount has to be divisible by %v", multipleTokenAmount)
 	}
 
 	if repl.BatchID == "" || repl.Fee < 1 {
@@ -0,0 +1,108 @@
+package multiview
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/metrics"
+	"github.com/incognitochain/incognito-chain/pubsub"
+)
+
+const (
+	NewBestViewEvent  = "newbestview"
+	BranchSwitchEvent = "branchswitch"
+	FinalizeViewEvent = "finalizeview"
+)
+
+// ViewEvent is published to ViewEventTopic when the best view or the final view of a chain changes
+type ViewEvent struct {
+	ChainID   int
+	Type      string
+	Height    uint64
+	Hash      common.Hash
+	Timestamp int64
+
+	// for branch switch: previous best view, the common ancestor of both branches
+	// and the number of blocks reverted from previous best branch
+	PreviousBestHeight   uint64       `json:",omitempty"`
+	PreviousBestHash     *common.Hash `json:",omitempty"`
+	CommonAncestorHeight uint64       `json:",omitempty"`
+	CommonAncestorHash   *common.Hash `json:",omitempty"`
+	ReorgDepth           uint64       `json:",omitempty"`
+}
+
+type Pubsub interface {
+	PublishMessage(message *pubsub.Message)
+}
+
+// SetPubSubManager enable publishing view events of the chain to pubsub
+func (s *multiView) SetPubSubManager(chainID int, pubSubManager Pubsub) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.chainID = chainID
+	s.pubSubManager = pubSubManager
+}
+
+func chainMetricName(chainID int) string {
+	if chainID == common.BeaconChainID {
+		return "beacon"
+	}
+	return fmt.Sprintf("shard%v", chainID)
+}
+
+func (s *multiView) publishEvent(event *ViewEvent) {
+	if s.pubSubManager == nil {
+		return
+	}
+	event.ChainID = s.chainID
+	event.Timestamp = time.Now().Unix()
+	if event.Type == BranchSwitchEvent {
+		name := chainMetricName(s.chainID)
+		metrics.GetOrRegisterCounter(name+"/reorg", nil).Inc(1)
+		metrics.GetOrRegisterHistogram(name+"/reorgdepth", nil, metrics.NewExpDecaySample(1028, 0.015)).Update(int64(event.ReorgDepth))
+	}
+	s.pubSubManager.PublishMessage(pubsub.NewMessage(pubsub.ViewEventTopic, event))
+}
+
+// notifyBestViewChange publish new best view or branch switch event if best view is changed from prevBestView
+func (s *multiView) notifyBestViewChange(prevBestView View) {
+	if s.pubSubManager == nil || s.bestView == nil || prevBestView == nil || s.bestView == prevBestView {
+		return
+	}
+	if *s.bestView.GetHash() == *prevBestView.GetHash() {
+		return
+	}
+	event := &ViewEvent{
+		Type:   NewBestViewEvent,
+		Height: s.bestView.GetHeight(),
+		Hash:   *s.bestView.GetHash(),
+	}
+	ancestor := s.findCommonAncestor(s.bestView, prevBestView)
+	if ancestor == nil || *ancestor.GetHash() != *prevBestView.GetHash() {
+		event.Type = BranchSwitchEvent
+		event.PreviousBestHeight = prevBestView.GetHeight()
+		event.PreviousBestHash = prevBestView.GetHash()
+		if ancestor != nil {
+			event.CommonAncestorHeight = ancestor.GetHeight()
+			event.CommonAncestorHash = ancestor.GetHash()
+			event.ReorgDepth = prevBestView.GetHeight() - ancestor.GetHeight()
+		}
+	}
+	s.publishEvent(event)
+}
+
+// findCommonAncestor traverse backward from both views until they meet, return nil if the ancestor is not in memory
+func (s *multiView) findCommonAncestor(v1, v2 View) View {
+	for v1 != nil && v2 != nil {
+		if *v1.GetHash() == *v2.GetHash() {
+			return v1
+		}
+		if v1.GetHeight() >= v2.GetHeight() {
+			v1 = s.viewByHash[*v1.GetPreviousHash()]
+		} else {
+			v2 = s.viewByHash[*v2.GetPreviousHash()]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,156 @@
+package multiview
+
+import (
+	"testing"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/pubsub"
+)
+
+// reorgTestView is a view of a block in a test branch, only hash, previous hash and height are used
+type reorgTestView struct {
+	View
+	hash     common.Hash
+	prevHash common.Hash
+	height   uint64
+}
+
+func (v *reorgTestView) GetHash() *common.Hash         { return &v.hash }
+func (v *reorgTestView) GetPreviousHash() *common.Hash { return &v.prevHash }
+func (v *reorgTestView) GetHeight() uint64             { return v.height }
+
+type reorgTestPubsub struct {
+	events []*ViewEvent
+}
+
+func (p *reorgTestPubsub) PublishMessage(message *pubsub.Message) {
+	p.events = append(p.events, message.Value.(*ViewEvent))
+}
+
+// newReorgTestMultiView build the views of branches in memory, each branch is a list of block ids from the root,
+// a block id is the first byte of its hash and the root is at height 1
+func newReorgTestMultiView(branches ...[]byte) (*multiView, map[byte]View) {
+	s := NewMultiView()
+	views := make(map[byte]View)
+	for _, branch := range branches {
+		for i, id := range branch {
+			if _, ok := views[id]; ok {
+				continue
+			}
+			view := &reorgTestView{hash: common.Hash{id}, height: uint64(i + 1)}
+			if i > 0 {
+				view.prevHash = common.Hash{branch[i-1]}
+			}
+			views[id] = view
+			s.viewByHash[view.hash] = view
+		}
+	}
+	return s, views
+}
+
+// addReorgTestOrphanBranch add views 10 and 11 at height 5 and 6, parent of 10 is not in memory
+func addReorgTestOrphanBranch(s *multiView, views map[byte]View) {
+	views[10] = &reorgTestView{hash: common.Hash{10}, prevHash: common.Hash{100}, height: 5}
+	views[11] = &reorgTestView{hash: common.Hash{11}, prevHash: common.Hash{10}, height: 6}
+	s.viewByHash[common.Hash{10}] = views[10]
+	s.viewByHash[common.Hash{11}] = views[11]
+}
+
+func TestMultiView_findCommonAncestor(t *testing.T) {
+	// 1 - 2 - 3 - 4 - 5
+	//      \- 6 - 7 - 8 - 9
+	// 10 - 11 (parent of 10 is not in memory)
+	s, views := newReorgTestMultiView([]byte{1, 2, 3, 4, 5}, []byte{1, 2, 6, 7, 8, 9})
+	addReorgTestOrphanBranch(s, views)
+	tests := []struct {
+		name   string
+		v1, v2 byte
+		want   byte // 0 if no common ancestor
+	}{
+		{"same view", 5, 5, 5},
+		{"extend by 1", 5, 4, 4},
+		{"extend by many", 5, 2, 2},
+		{"sibling", 3, 6, 2},
+		{"deep fork", 9, 5, 2},
+		{"deep fork reversed", 5, 9, 2},
+		{"no common ancestor", 11, 5, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := s.findCommonAncestor(views[tt.v1], views[tt.v2])
+			if tt.want == 0 {
+				if got != nil {
+					t.Fatalf("findCommonAncestor() = %v, want nil", got.GetHash().String())
+				}
+				return
+			}
+			if got == nil || *got.GetHash() != (common.Hash{tt.want}) {
+				t.Fatalf("findCommonAncestor() = %v, want view %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMultiView_notifyBestViewChange(t *testing.T) {
+	// 1 - 2 - 3 - 4 - 5
+	//  \   \- 6 - 7 - 8 - 9
+	//   \- 12 - 13
+	// 10 - 11 (parent of 10 is not in memory)
+	s, views := newReorgTestMultiView([]byte{1, 2, 3, 4, 5}, []byte{1, 2, 6, 7, 8, 9}, []byte{1, 12, 13})
+	addReorgTestOrphanBranch(s, views)
+	tests := []struct {
+		name           string
+		prevBest       byte
+		best           byte
+		wantEvent      bool
+		wantType       string
+		wantAncestor   byte
+		wantReorgDepth uint64
+	}{
+		{"best view unchanged", 5, 5, false, "", 0, 0},
+		{"reorg depth 0", 4, 5, true, NewBestViewEvent, 0, 0},
+		{"extend best view by many blocks", 2, 9, true, NewBestViewEvent, 0, 0},
+		{"reorg depth 1", 3, 6, true, BranchSwitchEvent, 2, 1},
+		{"deep reorg", 5, 9, true, BranchSwitchEvent, 2, 3},
+		{"reorg to a shorter branch", 9, 13, true, BranchSwitchEvent, 1, 5},
+		{"no common ancestor", 5, 11, true, BranchSwitchEvent, 0, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := &reorgTestPubsub{}
+			s.SetPubSubManager(1, p)
+			s.bestView = views[tt.best]
+			s.notifyBestViewChange(views[tt.prevBest])
+			if !tt.wantEvent {
+				if len(p.events) != 0 {
+					t.Fatalf("want no event, got %+v", p.events[0])
+				}
+				return
+			}
+			if len(p.events) != 1 {
+				t.Fatalf("want 1 event, got %v", len(p.events))
+			}
+			event := p.events[0]
+			if event.ChainID != 1 || event.Type != tt.wantType || event.Hash != (common.Hash{tt.best}) || event.Height != views[tt.best].GetHeight() {
+				t.Fatalf("wrong event %+v", event)
+			}
+			if tt.wantType == NewBestViewEvent {
+				return
+			}
+			if event.PreviousBestHash == nil || *event.PreviousBestHash != (common.Hash{tt.prevBest}) ||
+				event.PreviousBestHeight != views[tt.prevBest].GetHeight() || event.ReorgDepth != tt.wantReorgDepth {
+				t.Fatalf("wrong branch switch event %+v", event)
+			}
+			if tt.wantAncestor == 0 {
+				if event.CommonAncestorHash != nil || event.CommonAncestorHeight != 0 {
+					t.Fatalf("want no common ancestor, got %+v", event)
+				}
+				return
+			}
+			if event.CommonAncestorHash == nil || *event.CommonAncestorHash != (common.Hash{tt.wantAncestor}) ||
+				event.CommonAncestorHeight != views[tt.wantAncestor].GetHeight() {
+				t.Fatalf("wrong common ancestor %+v", event)
+			}
+		})
+	}
+}
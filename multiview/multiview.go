@@ -57,6 +57,10 @@ type multiView struct {
 	finalView         View //view that must not be revert
 	expectedFinalView View //view at this time seen as final view (shardchain could revert from beacon view)
 	bestView          View // best view from final view
+
+	//view events
+	chainID       int
+	pubSubManager Pubsub
 }
 
 func NewMultiView() *multiView {
@@ -175,6 +179,11 @@ func (s *multiView) FinalizeView(hashToFinalize common.Hash) error {
 		//if current final view is less than the specified view
 		if s.finalView.GetHeight() < viewToFinalize.GetHeight() {
 			s.finalView = viewToFinalize
+			s.publishEvent(&ViewEvent{
+				Type:   FinalizeViewEvent,
+				Height: viewToFinalize.GetHeight(),
+				Hash:   *viewToFinalize.GetHash(),
+			})
 		}
 
 	}
@@ -199,7 +208,9 @@ func (s *multiView) addView(view View) bool {
 		if _, ok := s.viewByHash[*view.GetPreviousHash()]; ok { // view must point to previous valid view
 			s.viewByHash[*view.GetHash()] = view
 			s.viewByPrevHash[*view.GetPreviousHash()] = append(s.viewByPrevHash[*view.GetPreviousHash()], view)
+			prevBestView := s.bestView
 			s.updateViewState(view)
+			s.notifyBestViewChange(prevBestView)
 			return true
 		}
 	}
@@ -441,8 +452,10 @@ func (s *multiView) ReplaceBlockIfImproveFinality(b types.BlockInterface) (bool,
 			}
 			tmp.addView(v)
 		}
+		prevBestView := s.bestView
 		s.expectedFinalView = tmp.expectedFinalView
 		s.bestView = tmp.bestView
+		s.notifyBestViewChange(prevBestView)
 		return true, nil
 	}
 
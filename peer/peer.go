@@ -106,6 +106,7 @@ type MessageListeners struct {
 	OnPeerState          func(p *PeerConn, msg *wire.MessagePeerState)
 	OnFinishSync         func(p *PeerConn, msg *wire.MessageFinishSync)
 	OnFeatureMsg         func(p *PeerConn, msg *wire.MessageFeature)
+	OnEquivocation       func(p *PeerConn, msg *wire.MessageEquivocation)
 	PushRawBytesToShard  func(p *PeerConn, msgBytes *[]byte, shard byte) error
 	PushRawBytesToBeacon func(p *PeerConn, msgBytes *[]byte) error
 	GetCurrentRoleShard  func() (string, *byte)
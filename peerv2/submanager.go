@@ -148,7 +148,7 @@ func (sub *SubManager) Subscribe(forced bool) error {
 
 		// Registering mining
 		for chainID, validator := range newRole {
-			if validator.PrivateSeed != "" {
+			if validator.PrivateSeed != "" || validator.MiningKey.Signer != nil {
 				topics, _, err := sub.registerToProxy(
 					validator.MiningKey.GetPublicKeyBase58(),
 					validator.State.Layer,
@@ -403,6 +403,7 @@ func getMessagesForLayer(layer, role string, shardID []byte) []string {
 				wire.CmdTx,
 				wire.CmdPrivacyCustomToken,
 				wire.CmdMsgFeatureStat,
+				wire.CmdMsgEquivocation,
 			}
 
 		case common.SyncingRole:
@@ -434,6 +435,7 @@ func getMessagesForLayer(layer, role string, shardID []byte) []string {
 			wire.CmdBlockShard,
 			wire.CmdMsgFinishSync,
 			wire.CmdMsgFeatureStat,
+			wire.CmdMsgEquivocation,
 		}
 	default:
 		containShard := false
@@ -12,6 +12,7 @@ import (
 	portaltokensv3 "github.com/incognitochain/incognito-chain/portal/portalv3/portaltokens"
 	"github.com/incognitochain/incognito-chain/portal/portalv4"
 	portaltokensv4 "github.com/incognitochain/incognito-chain/portal/portalv4/portaltokens"
+	btcrelaying "github.com/incognitochain/incognito-chain/relaying/btc"
 )
 
 type PortalParams struct {
@@ -133,52 +134,113 @@ var localPortalParam = PortalParams{
 		BNBRelayingHeaderChainID: TestnetBNBChainID,
 		BTCRelayingHeaderChainID: TestnetBTCChainID,
 		BTCDataFolderName:        TestnetBTCDataFolderName,
+		LTCRelayingHeaderChainID: TestnetLTCChainID,
+		LTCDataFolderName:        TestnetLTCDataFolderName,
 		BNBFullNodeProtocol:      TestnetBNBFullNodeProtocol,
 		BNBFullNodeHost:          TestnetBNBFullNodeHost,
 		BNBFullNodePort:          TestnetBNBFullNodePort,
 	},
 	PortalParamsV4: map[uint64]portalv4.PortalParams{
-		0: {
-			MasterPubKeys: map[string][][]byte{
-				LocalPortalV4BTCID: [][]byte{
-					[]byte{0x3, 0xb2, 0xd3, 0x16, 0x7d, 0x94, 0x9c, 0x25, 0x3, 0xe6, 0x9c, 0x9f, 0x29, 0x78, 0x7d, 0x9c, 0x8, 0x8d, 0x39, 0x17, 0x8d, 0xb4, 0x75, 0x40, 0x35, 0xf5, 0xae, 0x6a, 0xf0, 0x17, 0x12, 0x11, 0x0},
-					[]byte{0x3, 0x98, 0x7a, 0x87, 0xd1, 0x99, 0x13, 0xbd, 0xe3, 0xef, 0xf0, 0x55, 0x79, 0x2, 0xb4, 0x90, 0x57, 0xed, 0x1c, 0x9c, 0x8b, 0x32, 0xf9, 0x2, 0xbb, 0xbb, 0x85, 0x71, 0x3a, 0x99, 0x1f, 0xdc, 0x41},
-					[]byte{0x3, 0x73, 0x23, 0x5e, 0xb1, 0xc8, 0xf1, 0x84, 0xe7, 0x59, 0x17, 0x6c, 0xe3, 0x87, 0x37, 0xb7, 0x91, 0x19, 0x47, 0x1b, 0xba, 0x63, 0x56, 0xbc, 0xab, 0x8d, 0xcc, 0x14, 0x4b, 0x42, 0x99, 0x86, 0x1},
-					[]byte{0x3, 0x29, 0xe7, 0x59, 0x31, 0x89, 0xca, 0x7a, 0xf6, 0x1, 0xb6, 0x35, 0x67, 0x3d, 0xb1, 0x53, 0xd4, 0x19, 0xd7, 0x6, 0x19, 0x3, 0x2a, 0x32, 0x94, 0x57, 0x76, 0xb2, 0xb3, 0x80, 0x65, 0xe1, 0x5d},
+		0:                               localPortalParamsV4,
+		LocalPortalV4LTCHeight:          addPortalV4Tokens(localPortalParamsV4, localPortalLTCParamsV4),
+		LocalPortalV4TaprootVaultHeight: enablePortalTaprootVault(addPortalV4Tokens(localPortalParamsV4, localPortalLTCParamsV4)),
+	},
+}
+
+var localPortalParamsV4 = portalv4.PortalParams{
+	MasterPubKeys: map[string][][]byte{
+		LocalPortalV4BTCID: [][]byte{
+			[]byte{0x3, 0xb2, 0xd3, 0x16, 0x7d, 0x94, 0x9c, 0x25, 0x3, 0xe6, 0x9c, 0x9f, 0x29, 0x78, 0x7d, 0x9c, 0x8, 0x8d, 0x39, 0x17, 0x8d, 0xb4, 0x75, 0x40, 0x35, 0xf5, 0xae, 0x6a, 0xf0, 0x17, 0x12, 0x11, 0x0},
+			[]byte{0x3, 0x98, 0x7a, 0x87, 0xd1, 0x99, 0x13, 0xbd, 0xe3, 0xef, 0xf0, 0x55, 0x79, 0x2, 0xb4, 0x90, 0x57, 0xed, 0x1c, 0x9c, 0x8b, 0x32, 0xf9, 0x2, 0xbb, 0xbb, 0x85, 0x71, 0x3a, 0x99, 0x1f, 0xdc, 0x41},
+			[]byte{0x3, 0x73, 0x23, 0x5e, 0xb1, 0xc8, 0xf1, 0x84, 0xe7, 0x59, 0x17, 0x6c, 0xe3, 0x87, 0x37, 0xb7, 0x91, 0x19, 0x47, 0x1b, 0xba, 0x63, 0x56, 0xbc, 0xab, 0x8d, 0xcc, 0x14, 0x4b, 0x42, 0x99, 0x86, 0x1},
+			[]byte{0x3, 0x29, 0xe7, 0x59, 0x31, 0x89, 0xca, 0x7a, 0xf6, 0x1, 0xb6, 0x35, 0x67, 0x3d, 0xb1, 0x53, 0xd4, 0x19, 0xd7, 0x6, 0x19, 0x3, 0x2a, 0x32, 0x94, 0x57, 0x76, 0xb2, 0xb3, 0x80, 0x65, 0xe1, 0x5d},
+		},
+	},
+	NumRequiredSigs: 3,
+	GeneralMultiSigAddresses: map[string]string{
+		LocalPortalV4BTCID: "tb1qfgzhddwenekk573slpmqdutrd568ej89k37lmjr43tm9nhhulu0scjyajz",
+	},
+	PortalTokens: initPortalTokensV4ForLocal(),
+	DefaultFeeUnshields: map[string]uint64{
+		LocalPortalV4BTCID: 50000, // 50000 nano pbtc = 5000 satoshi
+	},
+	MinShieldAmts: map[string]uint64{
+		LocalPortalV4BTCID: 5000, // 5000 nano pbtc = 500 satoshi
+	},
+	MinUnshieldAmts: map[string]uint64{
+		LocalPortalV4BTCID: 500000, // 500000 nano pbtc = 50000 satoshi
+	},
+	DustValueThreshold: map[string]uint64{
+		LocalPortalV4BTCID: 10000000, // 1000000 nano pbtc = 0.01 BTC
+	},
+	MinUTXOsInVault: map[string]uint64{
+		LocalPortalV4BTCID: 50,
+	},
+	BatchNumBlks:                15, // ~ 2.5 mins
+	PortalReplacementAddress:    "12svfkP6w5UDJDSCwqH978PvqiqBxKmUnA9em9yAYWYJVRv7wuXY1qhhYpPAm4BDz2mLbFrRmdK3yRhnTqJCZXKHUmoi7NV83HCH2YFpctHNaDdkSiQshsjw2UFUuwdEvcidgaKmF3VJpY5f8RdN",
+	MaxFeePercentageForEachStep: 20, // ~ 20% from previous fee
+	TimeSpaceForFeeReplacement:  5 * time.Minute,
+	MaxUnshieldFees: map[string]uint64{
+		LocalPortalV4BTCID: 1000000, // 1000000 nano pbtc = 100000 satoshi
+	},
+	PortalV4TokenIDs: []string{
+		LocalPortalV4BTCID,
+	},
+}
+
+// localPortalLTCParamsV4 adds pLTC to the portal v4 params from the beacon height that pLTC is triggered
+var localPortalLTCParamsV4 = portalv4.PortalParams{
+	MasterPubKeys: map[string][][]byte{
+		LocalPortalV4LTCID: [][]byte{
+			[]byte{0x3, 0xc7, 0x88, 0x29, 0xaf, 0x53, 0xa2, 0x69, 0x1c, 0xec, 0x58, 0xd6, 0x9a, 0x78, 0x29, 0x89, 0xb0, 0xcd, 0x79, 0xf4, 0xbe, 0xc6, 0xe2, 0xc5, 0x4d, 0x89, 0x12, 0x39, 0xaa, 0xe1, 0xce, 0x2f, 0xd},
+			[]byte{0x3, 0xed, 0xf6, 0xa9, 0xdf, 0xf8, 0x72, 0x3e, 0x17, 0xfd, 0x28, 0xf0, 0x89, 0x93, 0x19, 0xd0, 0x90, 0x65, 0xda, 0xdb, 0xed, 0x26, 0x2, 0x8d, 0xb8, 0xd9, 0x95, 0xa7, 0x7b, 0xd0, 0x3c, 0x4, 0x22},
+			[]byte{0x3, 0x33, 0x27, 0xf3, 0x93, 0xfa, 0xf3, 0x55, 0xc5, 0x14, 0x85, 0x52, 0xcd, 0x33, 0x52, 0x24, 0x30, 0x93, 0x86, 0x89, 0x6e, 0x4c, 0x48, 0xe5, 0x16, 0x23, 0x11, 0x5e, 0xf0, 0xfd, 0xf2, 0x82, 0x14},
+			[]byte{0x2, 0x11, 0x51, 0xd5, 0x26, 0x5a, 0x11, 0xf0, 0x29, 0xdb, 0x84, 0xac, 0x12, 0x3e, 0xbe, 0x5b, 0x1a, 0x49, 0xb1, 0x8d, 0xfa, 0xfc, 0x33, 0x7, 0x9c, 0x7c, 0xe1, 0xc5, 0x7, 0xeb, 0xf3, 0x6d, 0x4a},
+		},
+	},
+	GeneralMultiSigAddresses: map[string]string{
+		LocalPortalV4LTCID: "tltc1qyzyu7gunkkt5rkwy2675nw0674j620dx0geh9jk8efyjwl7rlupqg6fcfj",
+	},
+	PortalTokens: map[string]portaltokensv4.PortalTokenProcessor{
+		LocalPortalV4LTCID: portaltokensv4.PortalLTCTokenProcessor{
+			PortalBTCTokenProcessor: portaltokensv4.PortalBTCTokenProcessor{
+				PortalToken: &portaltokensv4.PortalToken{
+					ChainID:             TestnetLTCChainID,
+					MinTokenAmount:      10,
+					MultipleTokenAmount: 10,
+					ExternalInputSize:   130,
+					ExternalOutputSize:  43,
+					ExternalTxMaxSize:   5120,
+
+					ExternalTaprootInputSize: 58,
 				},
-			},
-			NumRequiredSigs: 3,
-			GeneralMultiSigAddresses: map[string]string{
-				LocalPortalV4BTCID: "tb1qfgzhddwenekk573slpmqdutrd568ej89k37lmjr43tm9nhhulu0scjyajz",
-			},
-			PortalTokens: initPortalTokensV4ForLocal(),
-			DefaultFeeUnshields: map[string]uint64{
-				LocalPortalV4BTCID: 50000, // 50000 nano pbtc = 5000 satoshi
-			},
-			MinShieldAmts: map[string]uint64{
-				LocalPortalV4BTCID: 5000, // 5000 nano pbtc = 500 satoshi
-			},
-			MinUnshieldAmts: map[string]uint64{
-				LocalPortalV4BTCID: 500000, // 500000 nano pbtc = 50000 satoshi
-			},
-			DustValueThreshold: map[string]uint64{
-				LocalPortalV4BTCID: 10000000, // 1000000 nano pbtc = 0.01 BTC
-			},
-			MinUTXOsInVault: map[string]uint64{
-				LocalPortalV4BTCID: 50,
-			},
-			BatchNumBlks:                15, // ~ 2.5 mins
-			PortalReplacementAddress:    "12svfkP6w5UDJDSCwqH978PvqiqBxKmUnA9em9yAYWYJVRv7wuXY1qhhYpPAm4BDz2mLbFrRmdK3yRhnTqJCZXKHUmoi7NV83HCH2YFpctHNaDdkSiQshsjw2UFUuwdEvcidgaKmF3VJpY5f8RdN",
-			MaxFeePercentageForEachStep: 20, // ~ 20% from previous fee
-			TimeSpaceForFeeReplacement:  5 * time.Minute,
-			MaxUnshieldFees: map[string]uint64{
-				LocalPortalV4BTCID: 1000000, // 1000000 nano pbtc = 100000 satoshi
-			},
-			PortalV4TokenIDs: []string{
-				LocalPortalV4BTCID,
+				ChainParam:             btcrelaying.GetLTCTestNet4Params(),
+				PortalTokenID:          LocalPortalV4LTCID,
+				MasterKeyChainCodeSeed: PortalV4LTCMasterKeyChainCodeSeed,
 			},
 		},
 	},
+	DefaultFeeUnshields: map[string]uint64{
+		LocalPortalV4LTCID: 50000, // 50000 nano pltc = 5000 litoshi
+	},
+	MinShieldAmts: map[string]uint64{
+		LocalPortalV4LTCID: 5000, // 5000 nano pltc = 500 litoshi
+	},
+	MinUnshieldAmts: map[string]uint64{
+		LocalPortalV4LTCID: 500000, // 500000 nano pltc = 50000 litoshi
+	},
+	DustValueThreshold: map[string]uint64{
+		LocalPortalV4LTCID: 10000000, // 10000000 nano pltc = 0.01 LTC
+	},
+	MinUTXOsInVault: map[string]uint64{
+		LocalPortalV4LTCID: 50,
+	},
+	MaxUnshieldFees: map[string]uint64{
+		LocalPortalV4LTCID: 1000000, // 1000000 nano pltc = 100000 litoshi
+	},
+	PortalV4TokenIDs: []string{
+		LocalPortalV4LTCID,
+	},
 }
 
 var testnet1PortalParams = PortalParams{
@@ -208,56 +270,121 @@ var testnet1PortalParams = PortalParams{
 		BNBRelayingHeaderChainID: TestnetBNBChainID,
 		BTCRelayingHeaderChainID: TestnetBTCChainID,
 		BTCDataFolderName:        TestnetBTCDataFolderName,
+		LTCRelayingHeaderChainID: TestnetLTCChainID,
+		LTCDataFolderName:        TestnetLTCDataFolderName,
 		BNBFullNodeProtocol:      TestnetBNBFullNodeProtocol,
 		BNBFullNodeHost:          TestnetBNBFullNodeHost,
 		BNBFullNodePort:          TestnetBNBFullNodePort,
 	},
 	PortalParamsV4: map[uint64]portalv4.PortalParams{
-		0: {
-			MasterPubKeys: map[string][][]byte{
-				TestnetPortalV4BTCID: [][]byte{
-					[]byte{0x2, 0x30, 0x34, 0xcb, 0x1a, 0x50, 0xf6, 0x7f, 0x5e, 0xb2, 0x53, 0x9e, 0x68, 0x3b, 0xd4,
-						0x80, 0x73, 0x71, 0x2a, 0xdf, 0xf3, 0x25, 0x94, 0x34, 0x72, 0x6d, 0x62, 0x80, 0x83, 0xd2, 0x6f, 0x4c, 0xdd},
-					[]byte{0x2, 0x74, 0x61, 0x32, 0x93, 0xe7, 0x93, 0x85, 0x94, 0xd2, 0x58, 0xfb, 0xcf, 0xc5, 0x33,
-						0x78, 0xdc, 0x82, 0xcd, 0x64, 0xd1, 0xc0, 0x33, 0x1, 0x71, 0x2f, 0x90, 0x85, 0x72, 0xb9, 0x17, 0xab, 0xc7},
-					[]byte{0x3, 0x67, 0x7a, 0x81, 0xfc, 0x9c, 0x4c, 0x9c, 0x6, 0x28, 0xd2, 0xf6, 0xd0, 0x1e, 0x27,
-						0x15, 0xbb, 0x54, 0x11, 0x75, 0xe9, 0x62, 0xae, 0x78, 0x8f, 0xff, 0x26, 0x75, 0x1e, 0xb5, 0x24, 0xe0, 0xeb},
-					[]byte{0x3, 0x2, 0xdb, 0xd4, 0xd4, 0x6b, 0x4e, 0xef, 0xe9, 0xa6, 0xe8, 0x64, 0xce, 0xeb, 0xb5,
-						0x11, 0x25, 0x71, 0x28, 0x8a, 0xc4, 0xce, 0xca, 0xf4, 0x10, 0xd4, 0x16, 0x5f, 0x4c, 0x4c, 0xeb, 0x27, 0xe3},
+		0:                                 testnet1PortalParamsV4,
+		TestnetPortalV4LTCHeight:          addPortalV4Tokens(testnet1PortalParamsV4, testnet1PortalLTCParamsV4),
+		TestnetPortalV4TaprootVaultHeight: enablePortalTaprootVault(addPortalV4Tokens(testnet1PortalParamsV4, testnet1PortalLTCParamsV4)),
+	},
+}
+
+var testnet1PortalParamsV4 = portalv4.PortalParams{
+	MasterPubKeys: map[string][][]byte{
+		TestnetPortalV4BTCID: [][]byte{
+			[]byte{0x2, 0x30, 0x34, 0xcb, 0x1a, 0x50, 0xf6, 0x7f, 0x5e, 0xb2, 0x53, 0x9e, 0x68, 0x3b, 0xd4,
+				0x80, 0x73, 0x71, 0x2a, 0xdf, 0xf3, 0x25, 0x94, 0x34, 0x72, 0x6d, 0x62, 0x80, 0x83, 0xd2, 0x6f, 0x4c, 0xdd},
+			[]byte{0x2, 0x74, 0x61, 0x32, 0x93, 0xe7, 0x93, 0x85, 0x94, 0xd2, 0x58, 0xfb, 0xcf, 0xc5, 0x33,
+				0x78, 0xdc, 0x82, 0xcd, 0x64, 0xd1, 0xc0, 0x33, 0x1, 0x71, 0x2f, 0x90, 0x85, 0x72, 0xb9, 0x17, 0xab, 0xc7},
+			[]byte{0x3, 0x67, 0x7a, 0x81, 0xfc, 0x9c, 0x4c, 0x9c, 0x6, 0x28, 0xd2, 0xf6, 0xd0, 0x1e, 0x27,
+				0x15, 0xbb, 0x54, 0x11, 0x75, 0xe9, 0x62, 0xae, 0x78, 0x8f, 0xff, 0x26, 0x75, 0x1e, 0xb5, 0x24, 0xe0, 0xeb},
+			[]byte{0x3, 0x2, 0xdb, 0xd4, 0xd4, 0x6b, 0x4e, 0xef, 0xe9, 0xa6, 0xe8, 0x64, 0xce, 0xeb, 0xb5,
+				0x11, 0x25, 0x71, 0x28, 0x8a, 0xc4, 0xce, 0xca, 0xf4, 0x10, 0xd4, 0x16, 0x5f, 0x4c, 0x4c, 0xeb, 0x27, 0xe3},
+		},
+	},
+	NumRequiredSigs: 3,
+	GeneralMultiSigAddresses: map[string]string{
+		TestnetPortalV4BTCID: "tb1qjjy5aqpf86979y6jdkvy8nwh2z3r3qtt7tr9ux0wj4lk8vydffnq7azu84",
+	},
+	PortalTokens: initPortalTokensV4ForTestNet(),
+	DefaultFeeUnshields: map[string]uint64{
+		TestnetPortalV4BTCID: 50000, // nano pbtc
+	},
+	MinShieldAmts: map[string]uint64{
+		TestnetPortalV4BTCID: 100000, // nano pbtc
+	},
+	MinUnshieldAmts: map[string]uint64{
+		TestnetPortalV4BTCID: 100000, // nano pbtc
+	},
+	DustValueThreshold: map[string]uint64{
+		TestnetPortalV4BTCID: 10000000, // nano pbtc
+	},
+	MinUTXOsInVault: map[string]uint64{
+		TestnetPortalV4BTCID: 50,
+	},
+	BatchNumBlks:                20,
+	PortalReplacementAddress:    "12sv8WUvkvFfD5SW3aaXDSPs8yx2SxPdbv6a2LAU6FJb2kBKqmLcCuQ6ZQst4fg7THBTBtERaqMpJ7KBgsnRYobmysFEM2pbMwLE2kGzwyxgSijnZT7VQGeuUxBryC1Z6ebd8EWqDUkxwpW7Gqt8",
+	MaxFeePercentageForEachStep: 10, // ~ 10% from previous fee
+	TimeSpaceForFeeReplacement:  5 * time.Minute,
+	MaxUnshieldFees: map[string]uint64{
+		TestnetPortalV4BTCID: 100000, // pbtc
+	},
+	PortalV4TokenIDs: []string{
+		TestnetPortalV4BTCID,
+	},
+}
+
+// testnet1PortalLTCParamsV4 adds pLTC to the portal v4 params from the beacon height that pLTC is triggered
+var testnet1PortalLTCParamsV4 = portalv4.PortalParams{
+	MasterPubKeys: map[string][][]byte{
+		TestnetPortalV4LTCID: [][]byte{
+			[]byte{0x3, 0x84, 0x8b, 0x35, 0xdc, 0x29, 0x20, 0xb1, 0xb6, 0x4f, 0x4c, 0x8d, 0x7d, 0x8c, 0x87,
+				0xcd, 0x22, 0x88, 0x81, 0x75, 0x56, 0x98, 0xe4, 0xb0, 0x11, 0x70, 0x28, 0xdc, 0x6a, 0x25, 0xe9, 0x25, 0x9f},
+			[]byte{0x3, 0x2a, 0xaa, 0xba, 0xb2, 0xbc, 0x66, 0x3d, 0xf6, 0x95, 0x25, 0xda, 0x16, 0x66, 0x2a,
+				0xee, 0xdd, 0x58, 0x29, 0x44, 0x51, 0xeb, 0x6e, 0x85, 0x31, 0x12, 0x7d, 0x7e, 0x80, 0x81, 0x18, 0x73, 0x91},
+			[]byte{0x3, 0xd9, 0xfa, 0x5e, 0x26, 0x52, 0x43, 0xa1, 0x75, 0x7, 0x3c, 0x23, 0xf6, 0x63, 0xc3,
+				0x9c, 0xb4, 0xaa, 0xdf, 0x21, 0xc0, 0xda, 0x41, 0xa, 0x36, 0x9f, 0x79, 0x23, 0x5c, 0xb8, 0x74, 0x73, 0x96},
+			[]byte{0x2, 0x41, 0x9d, 0xd4, 0xef, 0xd2, 0x92, 0xbd, 0xa4, 0x25, 0x35, 0x1e, 0x66, 0x39, 0x7c,
+				0x97, 0xfc, 0x6, 0xc9, 0x9e, 0xf5, 0x1a, 0x27, 0x3e, 0x78, 0x63, 0x88, 0x1, 0xbb, 0x5d, 0x76, 0x5c, 0xdb},
+		},
+	},
+	GeneralMultiSigAddresses: map[string]string{
+		TestnetPortalV4LTCID: "tltc1qn4y9lcy4jgcee44ty34uheywwnws39ed9m27lesaws9u86ekg3cstcgjn8",
+	},
+	PortalTokens: map[string]portaltokensv4.PortalTokenProcessor{
+		TestnetPortalV4LTCID: portaltokensv4.PortalLTCTokenProcessor{
+			PortalBTCTokenProcessor: portaltokensv4.PortalBTCTokenProcessor{
+				PortalToken: &portaltokensv4.PortalToken{
+					ChainID:             TestnetLTCChainID,
+					MinTokenAmount:      10,
+					MultipleTokenAmount: 10,
+					ExternalInputSize:   130,
+					ExternalOutputSize:  43,
+					ExternalTxMaxSize:   5120,
+
+					ExternalTaprootInputSize: 58,
 				},
-			},
-			NumRequiredSigs: 3,
-			GeneralMultiSigAddresses: map[string]string{
-				TestnetPortalV4BTCID: "tb1qjjy5aqpf86979y6jdkvy8nwh2z3r3qtt7tr9ux0wj4lk8vydffnq7azu84",
-			},
-			PortalTokens: initPortalTokensV4ForTestNet(),
-			DefaultFeeUnshields: map[string]uint64{
-				TestnetPortalV4BTCID: 50000, // nano pbtc
-			},
-			MinShieldAmts: map[string]uint64{
-				TestnetPortalV4BTCID: 100000, // nano pbtc
-			},
-			MinUnshieldAmts: map[string]uint64{
-				TestnetPortalV4BTCID: 100000, // nano pbtc
-			},
-			DustValueThreshold: map[string]uint64{
-				TestnetPortalV4BTCID: 10000000, // nano pbtc
-			},
-			MinUTXOsInVault: map[string]uint64{
-				TestnetPortalV4BTCID: 50,
-			},
-			BatchNumBlks:                20,
-			PortalReplacementAddress:    "12sv8WUvkvFfD5SW3aaXDSPs8yx2SxPdbv6a2LAU6FJb2kBKqmLcCuQ6ZQst4fg7THBTBtERaqMpJ7KBgsnRYobmysFEM2pbMwLE2kGzwyxgSijnZT7VQGeuUxBryC1Z6ebd8EWqDUkxwpW7Gqt8",
-			MaxFeePercentageForEachStep: 10, // ~ 10% from previous fee
-			TimeSpaceForFeeReplacement:  5 * time.Minute,
-			MaxUnshieldFees: map[string]uint64{
-				TestnetPortalV4BTCID: 100000, // pbtc
-			},
-			PortalV4TokenIDs: []string{
-				TestnetPortalV4BTCID,
+				ChainParam:             btcrelaying.GetLTCTestNet4Params(),
+				PortalTokenID:          TestnetPortalV4LTCID,
+				MasterKeyChainCodeSeed: PortalV4LTCMasterKeyChainCodeSeed,
 			},
 		},
 	},
+	DefaultFeeUnshields: map[string]uint64{
+		TestnetPortalV4LTCID: 50000, // nano pltc
+	},
+	MinShieldAmts: map[string]uint64{
+		TestnetPortalV4LTCID: 100000, // nano pltc
+	},
+	MinUnshieldAmts: map[string]uint64{
+		TestnetPortalV4LTCID: 100000, // nano pltc
+	},
+	DustValueThreshold: map[string]uint64{
+		TestnetPortalV4LTCID: 10000000, // nano pltc
+	},
+	MinUTXOsInVault: map[string]uint64{
+		TestnetPortalV4LTCID: 50,
+	},
+	MaxUnshieldFees: map[string]uint64{
+		TestnetPortalV4LTCID: 100000, // pltc
+	},
+	PortalV4TokenIDs: []string{
+		TestnetPortalV4LTCID,
+	},
 }
 
 var testnet2PortalParams = PortalParams{
@@ -510,6 +637,8 @@ func initPortalTokensV4ForLocal() map[string]portaltokensv4.PortalTokenProcessor
 				ExternalInputSize:   130,
 				ExternalOutputSize:  43,
 				ExternalTxMaxSize:   5120,
+
+				ExternalTaprootInputSize: 58,
 			},
 			ChainParam:    &chaincfg.TestNet3Params,
 			PortalTokenID: LocalPortalV4BTCID,
@@ -527,6 +656,8 @@ func initPortalTokensV4ForTestNet() map[string]portaltokensv4.PortalTokenProcess
 				ExternalInputSize:   130,
 				ExternalOutputSize:  43,
 				ExternalTxMaxSize:   5120,
+
+				ExternalTaprootInputSize: 58,
 			},
 			ChainParam:    &chaincfg.TestNet3Params,
 			PortalTokenID: TestnetPortalV4BTCID,
@@ -568,11 +699,96 @@ func initPortalTokensV4ForMainNet() map[string]portaltokensv4.PortalTokenProcess
 	}
 }
 
+// addPortalV4Tokens returns the portal v4 params with the tokens in the token params added
+func addPortalV4Tokens(portalParams portalv4.PortalParams, tokenParams portalv4.PortalParams) portalv4.PortalParams {
+	masterPubKeys := map[string][][]byte{}
+	generalMultiSigAddresses := map[string]string{}
+	portalTokens := map[string]portaltokensv4.PortalTokenProcessor{}
+	for _, params := range []portalv4.PortalParams{portalParams, tokenParams} {
+		for tokenID, pubKeys := range params.MasterPubKeys {
+			masterPubKeys[tokenID] = pubKeys
+		}
+		for tokenID, address := range params.GeneralMultiSigAddresses {
+			generalMultiSigAddresses[tokenID] = address
+		}
+		for tokenID, portalToken := range params.PortalTokens {
+			portalTokens[tokenID] = portalToken
+		}
+	}
+	portalParams.MasterPubKeys = masterPubKeys
+	portalParams.GeneralMultiSigAddresses = generalMultiSigAddresses
+	portalParams.PortalTokens = portalTokens
+	portalParams.DefaultFeeUnshields = mergePortalV4Amounts(portalParams.DefaultFeeUnshields, tokenParams.DefaultFeeUnshields)
+	portalParams.MinShieldAmts = mergePortalV4Amounts(portalParams.MinShieldAmts, tokenParams.MinShieldAmts)
+	portalParams.MinUnshieldAmts = mergePortalV4Amounts(portalParams.MinUnshieldAmts, tokenParams.MinUnshieldAmts)
+	portalParams.DustValueThreshold = mergePortalV4Amounts(portalParams.DustValueThreshold, tokenParams.DustValueThreshold)
+	portalParams.MaxUnshieldFees = mergePortalV4Amounts(portalParams.MaxUnshieldFees, tokenParams.MaxUnshieldFees)
+	portalParams.MinUTXOsInVault = mergePortalV4Amounts(portalParams.MinUTXOsInVault, tokenParams.MinUTXOsInVault)
+	portalParams.PortalV4TokenIDs = append(append([]string{}, portalParams.PortalV4TokenIDs...), tokenParams.PortalV4TokenIDs...)
+	return portalParams
+}
+
+func mergePortalV4Amounts(amounts map[string]uint64, tokenAmounts map[string]uint64) map[string]uint64 {
+	res := map[string]uint64{}
+	for tokenID, amount := range amounts {
+		res[tokenID] = amount
+	}
+	for tokenID, amount := range tokenAmounts {
+		res[tokenID] = amount
+	}
+	return res
+}
+
+// enablePortalTaprootVault returns the portal v4 params with shielding coins deposited to the taproot vault
+func enablePortalTaprootVault(portalParams portalv4.PortalParams) portalv4.PortalParams {
+	portalTokens := map[string]portaltokensv4.PortalTokenProcessor{}
+	for tokenID, portalToken := range portalParams.PortalTokens {
+		switch processor := portalToken.(type) {
+		case portaltokensv4.PortalBTCTokenProcessor:
+			processor.TaprootVault = true
+			portalTokens[tokenID] = processor
+		case portaltokensv4.PortalLTCTokenProcessor:
+			processor.TaprootVault = true
+			portalTokens[tokenID] = processor
+		default:
+			portalTokens[tokenID] = portalToken
+		}
+	}
+	portalParams.PortalTokens = portalTokens
+	return portalParams
+}
+
+// SetPortalV4FeeOracle injects the fee oracle into the processors of the portal v4 tokens,
+// it is used to estimate fees of unshield batches
+func SetPortalV4FeeOracle(oracle portaltokensv4.FeeOracle) {
+	portalParamsV4 := map[uint64]portalv4.PortalParams{}
+	for height, portalParams := range p.PortalParamsV4 {
+		portalTokens := map[string]portaltokensv4.PortalTokenProcessor{}
+		for tokenID, portalToken := range portalParams.PortalTokens {
+			switch processor := portalToken.(type) {
+			case portaltokensv4.PortalBTCTokenProcessor:
+				processor.FeeOracle = oracle
+				portalTokens[tokenID] = processor
+			case portaltokensv4.PortalLTCTokenProcessor:
+				processor.FeeOracle = oracle
+				portalTokens[tokenID] = processor
+			default:
+				portalTokens[tokenID] = portalToken
+			}
+		}
+		portalParams.PortalTokens = portalTokens
+		portalParamsV4[height] = portalParams
+	}
+	p.PortalParamsV4 = portalParamsV4
+}
+
 const (
 	// relaying header chain
 	TestnetBNBChainID        = "Binance-Chain-Ganges"
 	TestnetBTCChainID        = "Bitcoin-Testnet"
 	TestnetBTCDataFolderName = "btcrelayingv15"
+	TestnetLTCChainID        = "Litecoin-Testnet"
+	TestnetLTCDataFolderName = "ltcrelayingv1"
 
 	// BNB fullnode
 	TestnetBNBFullNodeHost     = "data-seed-pre-0-s3.binance.org"
@@ -607,4 +823,17 @@ const (
 	TestnetPortalV4BTCID  = "4584d5e9b2fc0337dfb17f4b5bb025e5b82c38cfa4f54e8a3d4fcdd03954ff82"
 	Testnet2PortalV4BTCID = "4584d5e9b2fc0337dfb17f4b5bb025e5b82c38cfa4f54e8a3d4fcdd03954ff82"
 	MainnetPortalV4BTCID  = "b832e5d3b1f01a4f0623f7fe91d6673461e1f5d37d91fe78c5c2e6183ff39696"
+	LocalPortalV4LTCID    = "2c68fbee1786b83261f13dd68602fbffdb6796f8a6ba5c7cb6f34c972f48d104"
+	TestnetPortalV4LTCID  = "66b09bfa68c34fe897282ccb3e469f80232632c121c5dac47298b8b656087537"
+
+	// beacon heights to trigger pLTC, before the taproot vault heights
+	LocalPortalV4LTCHeight   = 5
+	TestnetPortalV4LTCHeight = 3900000 // todo: need to be updated before deploying
+
+	// pLTC master keys are derived from the btc master keys of the beacon validators with this chain code seed
+	PortalV4LTCMasterKeyChainCodeSeed = "pltc"
+
+	// beacon heights to deposit shielding coins to the taproot vault
+	LocalPortalV4TaprootVaultHeight   = 10
+	TestnetPortalV4TaprootVaultHeight = 4000000 // todo: need to be updated before deploying
 )
@@ -25,10 +25,16 @@ func NewPortalManager() *PortalManager {
 			Actions: [][]string{},
 		},
 	}
+	rltcChain := &portalrelaying.RelayingLTCChain{
+		RelayingChain: &portalrelaying.RelayingChain{
+			Actions: [][]string{},
+		},
+	}
 
 	relayingChainProcessor := map[int]portalrelaying.RelayingProcessor{
 		metadata.RelayingBNBHeaderMeta: rbnbChain,
 		metadata.RelayingBTCHeaderMeta: rbtcChain,
+		metadata.RelayingLTCHeaderMeta: rltcChain,
 	}
 
 	portalInstProcessorV3 := map[int]portalprocessv3.PortalInstructionProcessorV3{
@@ -8,10 +8,15 @@ import (
 	bnbTypes "github.com/tendermint/tendermint/types"
 )
 
-func InitRelayingHeaderChainStateFromDB(bnbChain *bnbrelaying.BNBChainState, btcChain *btcrelaying.BlockChain) (*RelayingHeaderChainState, error) {
+func InitRelayingHeaderChainStateFromDB(
+	bnbChain *bnbrelaying.BNBChainState,
+	btcChain *btcrelaying.BlockChain,
+	ltcChain *btcrelaying.BlockChain,
+) (*RelayingHeaderChainState, error) {
 	return &RelayingHeaderChainState{
 		BNBHeaderChain: bnbChain,
 		BTCHeaderChain: btcChain,
+		LTCHeaderChain: ltcChain,
 	}, nil
 }
 
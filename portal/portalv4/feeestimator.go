@@ -0,0 +1,102 @@
+package portalv4
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/incognitochain/incognito-chain/dataaccessobject/statedb"
+	"github.com/incognitochain/incognito-chain/metadata"
+)
+
+const (
+	FeeRateSourceOracle   = "oracle"
+	FeeRateSourceFallback = "headers-fallback"
+)
+
+// UnshieldBatchFeeEstimation is the fee suggestion for replacing the external tx of an unshield batch.
+// Fees are per unshield output in nano ptokens, fee rates are in external unit per byte.
+type UnshieldBatchFeeEstimation struct {
+	EstimatedTxSize uint
+	CurrentFee      uint
+	CurrentFeeRate  uint64
+	TargetFeeRate   uint64
+	FeeRateSource   string
+	TargetFee       uint // fee needed to reach the target fee rate
+	MaxReplaceFee   uint // max fee accepted by the next replacement
+	SuggestedFee    uint // fee for the next replacement request
+}
+
+// EstimateUnshieldBatchFee suggests the fee to replace the external tx of an unshield batch
+// spending the UTXOs with numUnshields unshield outputs (and a change output).
+// The target fee rate comes from the fee oracle of the token if any, otherwise from the fallback heuristic
+// on the relayed headers of the external chain.
+// The suggested fee follows the replacement rules: greater than the current fee,
+// at most MaxFeePercentageForEachStep percent higher and not greater than MaxUnshieldFees.
+func (p PortalParams) EstimateUnshieldBatchFee(
+	tokenID string,
+	utxos []*statedb.UTXO,
+	numUnshields int,
+	currentFee uint,
+	bc metadata.ChainRetriever,
+) (*UnshieldBatchFeeEstimation, error) {
+	portalTokenProcessor, ok := p.PortalTokens[tokenID]
+	if !ok {
+		return nil, fmt.Errorf("TokenID %v is not a portal token", tokenID)
+	}
+	if len(utxos) == 0 || numUnshields <= 0 {
+		return nil, errors.New("Unshield batch must have at least one UTXO and one unshield request")
+	}
+	multipleAmt := portalTokenProcessor.GetMultipleTokenAmount()
+	if multipleAmt == 0 {
+		multipleAmt = 1
+	}
+
+	txSize := portalTokenProcessor.EstimateTxSize(utxos, numUnshields+1)
+	currentTotalFee := portalTokenProcessor.ConvertIncToExternalAmount(uint64(currentFee)) * uint64(numUnshields)
+	currentFeeRate := currentTotalFee / uint64(txSize)
+
+	res := &UnshieldBatchFeeEstimation{
+		EstimatedTxSize: txSize,
+		CurrentFee:      currentFee,
+		CurrentFeeRate:  currentFeeRate,
+	}
+	if oracle := portalTokenProcessor.GetFeeOracle(); oracle != nil {
+		feeRate, err := oracle.GetFeeRate(portalTokenProcessor.GetChainID())
+		if err == nil {
+			res.TargetFeeRate = feeRate
+			res.FeeRateSource = FeeRateSourceOracle
+		}
+	}
+	if res.FeeRateSource == "" {
+		feeRate, err := portalTokenProcessor.EstimateFallbackFeeRate(bc, currentFeeRate)
+		if err != nil {
+			return nil, err
+		}
+		res.TargetFeeRate = feeRate
+		res.FeeRateSource = FeeRateSourceFallback
+	}
+
+	// fee per output in external unit to reach the target fee rate, rounded up to the multiple token amount
+	targetTotalFee := res.TargetFeeRate * uint64(txSize)
+	targetFeeInExternal := (targetTotalFee + uint64(numUnshields) - 1) / uint64(numUnshields)
+	targetFee := portalTokenProcessor.ConvertExternalToIncAmount(targetFeeInExternal)
+	res.TargetFee = uint((targetFee + multipleAmt - 1) / multipleAmt * multipleAmt)
+
+	maxReplaceFee := uint64(currentFee) + uint64(currentFee)*uint64(p.MaxFeePercentageForEachStep)/100
+	if maxUnshieldFee, ok := p.MaxUnshieldFees[tokenID]; ok && maxReplaceFee > maxUnshieldFee {
+		maxReplaceFee = maxUnshieldFee
+	}
+	res.MaxReplaceFee = uint(maxReplaceFee / multipleAmt * multipleAmt)
+	if res.MaxReplaceFee < currentFee {
+		res.MaxReplaceFee = currentFee
+	}
+
+	res.SuggestedFee = res.TargetFee
+	if res.SuggestedFee > res.MaxReplaceFee {
+		res.SuggestedFee = res.MaxReplaceFee
+	}
+	if res.SuggestedFee < currentFee {
+		res.SuggestedFee = currentFee
+	}
+	return res, nil
+}
@@ -0,0 +1,104 @@
+package portalv4
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/incognitochain/incognito-chain/dataaccessobject/statedb"
+	"github.com/incognitochain/incognito-chain/metadata"
+	portaltokensv4 "github.com/incognitochain/incognito-chain/portal/portalv4/portaltokens"
+	btcrelaying "github.com/incognitochain/incognito-chain/relaying/btc"
+)
+
+const testBTCTokenID = "4584d5e9b2fc0337dfb17f4b5bb025e5b82c38cfa4f54e8a3d4fcdd03954ff82"
+
+type testFeeOracle struct {
+	feeRate uint64
+	err     error
+}
+
+func (o testFeeOracle) GetFeeRate(chainID string) (uint64, error) {
+	return o.feeRate, o.err
+}
+
+type testChainRetriever struct {
+	metadata.ChainRetriever
+}
+
+func (bc testChainRetriever) GetBTCHeaderChain() *btcrelaying.BlockChain {
+	return nil
+}
+
+func TestEstimateUnshieldBatchFee(t *testing.T) {
+	portalParams := PortalParams{
+		PortalTokens: map[string]portaltokensv4.PortalTokenProcessor{
+			testBTCTokenID: &portaltokensv4.PortalBTCTokenProcessor{
+				PortalToken: &portaltokensv4.PortalToken{
+					ChainID:             "Bitcoin-Testnet",
+					MinTokenAmount:      10,
+					MultipleTokenAmount: 10,
+					ExternalInputSize:   130,
+					ExternalOutputSize:  43,
+					ExternalTxMaxSize:   5120,
+				},
+				ChainParam:    &chaincfg.TestNet3Params,
+				PortalTokenID: testBTCTokenID,
+			},
+		},
+		MaxUnshieldFees:             map[string]uint64{testBTCTokenID: 1000000},
+		MaxFeePercentageForEachStep: 25,
+	}
+
+	utxos := []*statedb.UTXO{
+		statedb.NewUTXOWithValue("tb1qfgzhddwenekk573slpmqdutrd568ej89k37lmjr43tm9nhhulu0scjyajz", "bb6d3db9b0bd3f8e26e1af1c3d3e6cb2b4bd7d2a80d9e57d8a36b9a4e4c7f0a1", 0, 50000000, ""),
+		statedb.NewUTXOWithValue("tb1qfgzhddwenekk573slpmqdutrd568ej89k37lmjr43tm9nhhulu0scjyajz", "3a1f4d1c2b0e9f8d7c6b5a4938271605f4e3d2c1b0a99887766554433221100f", 1, 30000000, ""),
+	}
+
+	// batch of 2 UTXOs and 3 unshield requests: 432 bytes, 30000 nano pBTC ~ 3000 satoshi per output
+	testcases := []struct {
+		name            string
+		oracle          portaltokensv4.FeeOracle
+		maxUnshieldFee  uint64
+		expectedRate    uint64
+		expectedTarget  uint
+		expectedMax     uint
+		expectedSuggest uint
+	}{
+		{"target fee is greater than max step", testFeeOracle{feeRate: 40}, 1000000, 40, 57600, 37500, 37500},
+		{"target fee is in max step", testFeeOracle{feeRate: 22}, 1000000, 22, 31680, 37500, 31680},
+		{"target fee is less than current fee", testFeeOracle{feeRate: 10}, 1000000, 10, 14400, 37500, 30000},
+		{"max unshield fee", testFeeOracle{feeRate: 40}, 35000, 40, 57600, 35000, 35000},
+	}
+	btcProcessor := portalParams.PortalTokens[testBTCTokenID].(*portaltokensv4.PortalBTCTokenProcessor)
+	for _, tc := range testcases {
+		portalParams.MaxUnshieldFees[testBTCTokenID] = tc.maxUnshieldFee
+		btcProcessor.FeeOracle = tc.oracle
+		res, err := portalParams.EstimateUnshieldBatchFee(testBTCTokenID, utxos, 3, 30000, testChainRetriever{})
+		if err != nil {
+			t.Fatalf("%v: %v", tc.name, err)
+		}
+		if res.EstimatedTxSize != 432 || res.CurrentFeeRate != 20 || res.FeeRateSource != FeeRateSourceOracle ||
+			res.TargetFeeRate != tc.expectedRate || res.TargetFee != tc.expectedTarget ||
+			res.MaxReplaceFee != tc.expectedMax || res.SuggestedFee != tc.expectedSuggest {
+			t.Errorf("%v: wrong estimation %+v", tc.name, res)
+		}
+	}
+
+	// fall back to the relaying header chain when the oracle fails or the token has no oracle
+	for _, oracle := range []portaltokensv4.FeeOracle{testFeeOracle{err: errors.New("oracle is down")}, nil} {
+		btcProcessor.FeeOracle = oracle
+		_, err := portalParams.EstimateUnshieldBatchFee(testBTCTokenID, utxos, 3, 30000, testChainRetriever{})
+		if err == nil {
+			t.Error("Expected error without the relaying header chain")
+		}
+	}
+	_, err := portalParams.EstimateUnshieldBatchFee("invalid token", utxos, 3, 30000, testChainRetriever{})
+	if err == nil {
+		t.Error("Expected error with invalid token")
+	}
+	_, err = portalParams.EstimateUnshieldBatchFee(testBTCTokenID, nil, 3, 30000, testChainRetriever{})
+	if err == nil {
+		t.Error("Expected error with empty batch")
+	}
+}
@@ -178,8 +178,8 @@ func (p *PortalConvertVaultRequestProcessor) BuildNewInsts(
 		return [][]string{rejectInst}, nil
 	}
 
-	// generate expected multisig address from master pubkeys and user payment address
-	_, expectedReceivedMultisigAddress, err := portalTokenProcessor.GenerateOTMultisigAddress(
+	// generate expected multisig addresses (P2WSH and taproot vaults) from master pubkeys and user payment address
+	expectedReceivedMultisigAddresses, err := portalTokenProcessor.GenerateOTDepositAddresses(
 		portalParams.MasterPubKeys[meta.TokenID], int(portalParams.NumRequiredSigs), portalcommonv4.PortalConvertVaultChainCode)
 	if err != nil {
 		Logger.log.Error("Converting Request: Could not generate multisig address - Error: %v", err)
@@ -188,7 +188,7 @@ func (p *PortalConvertVaultRequestProcessor) BuildNewInsts(
 
 	// verify shielding proof
 	isValid, listUTXO, err := portalTokenProcessor.ParseAndVerifyShieldProof(
-		meta.ConvertProof, bc, expectedReceivedMultisigAddress, portalcommonv4.PortalConvertVaultChainCode, 0)
+		meta.ConvertProof, bc, expectedReceivedMultisigAddresses, portalcommonv4.PortalConvertVaultChainCode, 0)
 	if !isValid || err != nil {
 		Logger.log.Error("Converting Request: Parse proof and verify converting proof failed - Error: %v", err)
 		return [][]string{rejectInst}, nil
@@ -1,6 +1,7 @@
 package portalprocess
 
 import (
+	"bytes"
 	"encoding/hex"
 	"encoding/json"
 	"fmt"
@@ -18,48 +19,67 @@ type PortalSig struct {
 	TokenID   string
 	RawTxHash string
 	Sigs      [][]byte // array of sigs for all TxIn
+
+	// MuSig2 signing on the key path of the taproot vault TxIns
+	Signer            []byte   `json:",omitempty"` // master public key of the beacon validator
+	MuSig2Nonces      [][]byte `json:",omitempty"` // nonces for all TxIn, sent with Sigs in the votes on the block of the unshield tx
+	MuSig2PartialSigs [][]byte `json:",omitempty"` // partial sigs for all TxIn, sent without Sigs in the votes on the next block
+}
+
+// getPortalExternalTx returns the unshield external tx in the portal instruction
+func getPortalExternalTx(inst []string) (tokenID string, hexRawExternalTx string, utxos []*statedb.UTXO, ok bool, err error) {
+	metaType := inst[0]
+	switch metaType {
+	case strconv.Itoa(metadataCommon.PortalV4UnshieldBatchingMeta):
+		{
+			// unmarshal instructions content
+			var actionData metadata.PortalUnshieldRequestBatchContent
+			err := json.Unmarshal([]byte(inst[3]), &actionData)
+			if err != nil {
+				return "", "", nil, false, fmt.Errorf("[checkAndSignPortalV4] Can not unmarshal instruction content %v - Error %v\n", inst[3], err)
+			}
+			return actionData.TokenID, actionData.RawExternalTx, actionData.UTXOs, true, nil
+		}
+	case strconv.Itoa(metadataCommon.PortalV4FeeReplacementRequestMeta):
+		{
+			reqStatus := inst[2]
+			if reqStatus == portalcommonv4.PortalV4RequestRejectedChainStatus {
+				return "", "", nil, false, nil
+			}
+			// unmarshal instructions content
+			var actionData metadata.PortalReplacementFeeRequestContent
+			err := json.Unmarshal([]byte(inst[3]), &actionData)
+			if err != nil {
+				return "", "", nil, false, fmt.Errorf("[checkAndSignPortalV4] Can not unmarshal instruction content %v - Error %v\n", inst[3], err)
+			}
+			return actionData.TokenID, actionData.ExternalRawTx, actionData.UTXOs, true, nil
+		}
+	// other cases
+	default:
+		return "", "", nil, false, nil
+	}
+}
+
+// HasPortalExternalTxs checks whether the instructions have unshield external txs for beacons to sign on
+func HasPortalExternalTxs(insts [][]string) bool {
+	for _, inst := range insts {
+		if len(inst) > 0 && (inst[0] == strconv.Itoa(metadataCommon.PortalV4UnshieldBatchingMeta) ||
+			inst[0] == strconv.Itoa(metadataCommon.PortalV4FeeReplacementRequestMeta)) {
+			return true
+		}
+	}
+	return false
 }
 
 // CheckAndSignPortalUnshieldExternalTx checks portal instructions need beacons sign on
 func CheckAndSignPortalUnshieldExternalTx(seedKey []byte, insts [][]string, portalParam portalv4.PortalParams) ([]*PortalSig, error) {
 	var pSigs []*PortalSig
-	var tokenID string
-	var hexRawExternalTx string
-	var utxos []*statedb.UTXO
-
 	for _, inst := range insts {
-		metaType := inst[0]
-		switch metaType {
-		case strconv.Itoa(metadataCommon.PortalV4UnshieldBatchingMeta):
-			{
-				// unmarshal instructions content
-				var actionData metadata.PortalUnshieldRequestBatchContent
-				err := json.Unmarshal([]byte(inst[3]), &actionData)
-				if err != nil {
-					return nil, fmt.Errorf("[checkAndSignPortalV4] Can not unmarshal instruction content %v - Error %v\n", inst[3], err)
-				}
-				tokenID = actionData.TokenID
-				hexRawExternalTx = actionData.RawExternalTx
-				utxos = actionData.UTXOs
-			}
-		case strconv.Itoa(metadataCommon.PortalV4FeeReplacementRequestMeta):
-			{
-				reqStatus := inst[2]
-				if reqStatus == portalcommonv4.PortalV4RequestRejectedChainStatus {
-					continue
-				}
-				// unmarshal instructions content
-				var actionData metadata.PortalReplacementFeeRequestContent
-				err := json.Unmarshal([]byte(inst[3]), &actionData)
-				if err != nil {
-					return nil, fmt.Errorf("[checkAndSignPortalV4] Can not unmarshal instruction content %v - Error %v\n", inst[3], err)
-				}
-				tokenID = actionData.TokenID
-				hexRawExternalTx = actionData.ExternalRawTx
-				utxos = actionData.UTXOs
-			}
-		// other cases
-		default:
+		tokenID, hexRawExternalTx, utxos, ok, err := getPortalExternalTx(inst)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
 			continue
 		}
 
@@ -76,12 +96,130 @@ func CheckAndSignPortalUnshieldExternalTx(seedKey []byte, insts [][]string, port
 		if err != nil {
 			return nil, fmt.Errorf("[checkAndSignPortalV4] Error when signing raw tx bytes: %v", err)
 		}
-		pSigs = append(pSigs, &PortalSig{
+		pSig := &PortalSig{
 			TokenID:   tokenID,
 			RawTxHash: txHash,
 			Sigs:      sigs,
+		}
+
+		// start the MuSig2 sessions on the taproot vault TxIns, the partial sigs are sent in the next block
+		nonces, err := portalTokenProcessor.GenerateMuSig2Nonces(seedKey, portalParam.MasterPubKeys[tokenID], int(portalParam.NumRequiredSigs), rawTxBytes, utxos)
+		if err != nil {
+			return nil, fmt.Errorf("[checkAndSignPortalV4] Error when generating MuSig2 nonces: %v", err)
+		}
+		if hasNonNilItem(nonces) {
+			pSig.Signer = portalTokenProcessor.GenerateMasterPubKey(seedKey)
+			pSig.MuSig2Nonces = nonces
+		}
+		pSigs = append(pSigs, pSig)
+	}
+
+	return pSigs, nil
+}
+
+// SignPortalMuSig2Sessions signs the MuSig2 sessions on the taproot vault TxIns of the unshield external txs
+// in the previous block, with the nonces of beacon validators in the portal sigs of the previous block
+func SignPortalMuSig2Sessions(seedKey []byte, prevInsts [][]string, prevPortalSigs []*PortalSig, portalParam portalv4.PortalParams) ([]*PortalSig, error) {
+	var pSigs []*PortalSig
+	for _, inst := range prevInsts {
+		tokenID, hexRawExternalTx, utxos, ok, err := getPortalExternalTx(inst)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			continue
+		}
+
+		rawTxBytes, err := hex.DecodeString(hexRawExternalTx)
+		if err != nil {
+			return nil, fmt.Errorf("[signPortalMuSig2Sessions] Error when decoding raw tx string: %v", err)
+		}
+		portalTokenProcessor := portalParam.PortalTokens[tokenID]
+		if portalTokenProcessor == nil {
+			return nil, fmt.Errorf("[signPortalMuSig2Sessions] portalTokenProcessor is nil")
+		}
+		txHash, err := portalTokenProcessor.GetTxHashFromRawTx(hexRawExternalTx)
+		if err != nil {
+			return nil, fmt.Errorf("[signPortalMuSig2Sessions] Error when getting hash of raw tx: %v", err)
+		}
+		masterPubKeys := portalParam.MasterPubKeys[tokenID]
+		nonces := GetMuSig2Nonces(prevPortalSigs, txHash, masterPubKeys, len(utxos))
+		hasNonces := false
+		for _, txInNonces := range nonces {
+			hasNonces = hasNonces || txInNonces != nil
+		}
+		if !hasNonces {
+			continue
+		}
+		partialSigs, err := portalTokenProcessor.PartSignMuSig2(seedKey, masterPubKeys, int(portalParam.NumRequiredSigs), rawTxBytes, utxos, nonces)
+		if err != nil {
+			return nil, fmt.Errorf("[signPortalMuSig2Sessions] Error when signing MuSig2 sessions: %v", err)
+		}
+		if !hasNonNilItem(partialSigs) {
+			continue
+		}
+		pSigs = append(pSigs, &PortalSig{
+			TokenID:           tokenID,
+			RawTxHash:         txHash,
+			Signer:            portalTokenProcessor.GenerateMasterPubKey(seedKey),
+			MuSig2PartialSigs: partialSigs,
 		})
 	}
 
 	return pSigs, nil
 }
+
+// GetMuSig2Nonces returns the MuSig2 nonces of all beacon validators on each TxIn of the external tx
+// in order of the master public keys, the nonces of a TxIn are nil if some validators did not send them
+func GetMuSig2Nonces(portalSigs []*PortalSig, rawTxHash string, masterPubKeys [][]byte, numTxIns int) [][][]byte {
+	return collectMuSig2Data(portalSigs, rawTxHash, masterPubKeys, numTxIns, func(pSig *PortalSig) [][]byte {
+		return pSig.MuSig2Nonces
+	})
+}
+
+// GetMuSig2PartialSigs returns the MuSig2 partial sigs of all beacon validators on each TxIn of the external tx
+// in order of the master public keys, the partial sigs of a TxIn are nil if some validators did not send them
+func GetMuSig2PartialSigs(portalSigs []*PortalSig, rawTxHash string, masterPubKeys [][]byte, numTxIns int) [][][]byte {
+	return collectMuSig2Data(portalSigs, rawTxHash, masterPubKeys, numTxIns, func(pSig *PortalSig) [][]byte {
+		return pSig.MuSig2PartialSigs
+	})
+}
+
+func collectMuSig2Data(
+	portalSigs []*PortalSig, rawTxHash string, masterPubKeys [][]byte, numTxIns int, getData func(pSig *PortalSig) [][]byte,
+) [][][]byte {
+	dataBySigner := make([][][]byte, len(masterPubKeys))
+	for _, pSig := range portalSigs {
+		data := getData(pSig)
+		if pSig.RawTxHash != rawTxHash || len(data) != numTxIns {
+			continue
+		}
+		for i, masterPubKey := range masterPubKeys {
+			if dataBySigner[i] == nil && bytes.Equal(pSig.Signer, masterPubKey) {
+				dataBySigner[i] = data
+				break
+			}
+		}
+	}
+
+	res := make([][][]byte, numTxIns)
+	for txInIdx := range res {
+		for _, data := range dataBySigner {
+			if data == nil || len(data[txInIdx]) == 0 {
+				res[txInIdx] = nil
+				break
+			}
+			res[txInIdx] = append(res[txInIdx], data[txInIdx])
+		}
+	}
+	return res
+}
+
+func hasNonNilItem(items [][]byte) bool {
+	for _, item := range items {
+		if item != nil {
+			return true
+		}
+	}
+	return false
+}
@@ -200,8 +200,8 @@ func (p *PortalShieldingRequestProcessor) BuildNewInsts(
 		return [][]string{rejectInst}, nil
 	}
 
-	// generate expected multisig address from master pubkeys and user payment address
-	_, expectedReceivedMultisigAddress, err := portalTokenProcessor.GenerateOTMultisigAddress(
+	// generate expected multisig addresses (P2WSH and taproot vaults) from master pubkeys and user payment address
+	expectedReceivedMultisigAddresses, err := portalTokenProcessor.GenerateOTDepositAddresses(
 		portalParams.MasterPubKeys[meta.TokenID], int(portalParams.NumRequiredSigs), meta.IncogAddressStr)
 	if err != nil {
 		Logger.log.Error("Shielding Request: Could not generate multisig address - Error: %v", err)
@@ -210,7 +210,7 @@ func (p *PortalShieldingRequestProcessor) BuildNewInsts(
 
 	// verify shielding proof
 	isValid, listUTXO, err := portalTokenProcessor.ParseAndVerifyShieldProof(
-		meta.ShieldingProof, bc, expectedReceivedMultisigAddress, meta.IncogAddressStr, portalParams.MinShieldAmts[meta.TokenID])
+		meta.ShieldingProof, bc, expectedReceivedMultisigAddresses, meta.IncogAddressStr, portalParams.MinShieldAmts[meta.TokenID])
 	if !isValid || err != nil {
 		Logger.log.Error("Shielding Request: Parse proof and verify shielding proof failed - Error: %v", err)
 		return [][]string{rejectInst}, nil
@@ -0,0 +1,114 @@
+package portaltokens
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/incognitochain/incognito-chain/metadata"
+	"github.com/incognitochain/incognito-chain/metadata/rpccaller"
+	btcrelaying "github.com/incognitochain/incognito-chain/relaying/btc"
+)
+
+const (
+	// number of latest relayed blocks used to measure the congestion of the external chain
+	FeeEstimationNumBlocks = 6
+	// confirmation target (in blocks) requested from the fee oracles
+	FeeOracleConfTarget = 2
+)
+
+// FeeOracle returns the recommended fee rate (in external unit per byte) of an external chain
+type FeeOracle interface {
+	GetFeeRate(chainID string) (uint64, error)
+}
+
+// RPCFeeOracle gets fee rates from the estimatesmartfee RPC of local full nodes of the external chains
+// (bitcoind, litecoind, ...). Credentials of the node can be put into its endpoint url.
+type RPCFeeOracle struct {
+	client    *rpccaller.RPCClient
+	endpoints map[string]string // chainID => rpc endpoint
+}
+
+type estimateSmartFeeRes struct {
+	rpccaller.RPCBaseRes
+	Result *struct {
+		FeeRate float64  `json:"feerate"` // in coins per kilobyte
+		Errors  []string `json:"errors"`
+	} `json:"result"`
+}
+
+func NewRPCFeeOracle(endpoints map[string]string) *RPCFeeOracle {
+	return &RPCFeeOracle{
+		client:    rpccaller.NewRPCClient(),
+		endpoints: endpoints,
+	}
+}
+
+func (o *RPCFeeOracle) GetFeeRate(chainID string) (uint64, error) {
+	endpoint, ok := o.endpoints[chainID]
+	if !ok {
+		return 0, fmt.Errorf("There is no fee oracle for chain %v", chainID)
+	}
+	var res estimateSmartFeeRes
+	err := o.client.RPCCall("", endpoint, "", "estimatesmartfee", []interface{}{FeeOracleConfTarget}, &res)
+	if err != nil {
+		return 0, err
+	}
+	if res.RPCError != nil {
+		return 0, errors.New(res.RPCError.Message)
+	}
+	if res.Result == nil || res.Result.FeeRate <= 0 {
+		return 0, fmt.Errorf("Fee oracle of chain %v has no fee rate: %+v", chainID, res.Result)
+	}
+	// convert coins per kilobyte to the smallest unit per byte
+	return uint64(math.Ceil(res.Result.FeeRate * 1e8 / 1000)), nil
+}
+
+// GetFeeOracle returns the fee oracle of the token, nil if there is no fee oracle
+func (p PortalBTCTokenProcessor) GetFeeOracle() FeeOracle {
+	return p.FeeOracle
+}
+
+// EstimateFallbackFeeRate is a fallback heuristic used only when the token has no fee oracle
+// or its fee oracle fails, see estimateFallbackFeeRateFromHeaderChain.
+func (p PortalBTCTokenProcessor) EstimateFallbackFeeRate(bc metadata.ChainRetriever, currentFeeRate uint64) (uint64, error) {
+	return p.estimateFallbackFeeRateFromHeaderChain(bc.GetBTCHeaderChain(), currentFeeRate)
+}
+
+// estimateFallbackFeeRateFromHeaderChain is a heuristic, not a fee market estimation: it scales the current fee rate
+// by the average interval of the latest relayed blocks over the target time per block, since slow blocks
+// usually mean a congested mempool. It never returns less than the current fee rate
+// and does not see the mempool, so the fee oracle must be preferred whenever it is available.
+func (p PortalBTCTokenProcessor) estimateFallbackFeeRateFromHeaderChain(headerChain *btcrelaying.BlockChain, currentFeeRate uint64) (uint64, error) {
+	if headerChain == nil {
+		return 0, errors.New("Relaying header chain should not be null")
+	}
+	bestState := headerChain.BestSnapshot()
+	numBlocks := int32(FeeEstimationNumBlocks)
+	if bestState.Height < numBlocks {
+		numBlocks = bestState.Height
+	}
+	if numBlocks <= 0 {
+		return currentFeeRate, nil
+	}
+	tipHeader, err := headerChain.HeaderByHash(&bestState.Hash)
+	if err != nil {
+		return 0, err
+	}
+	firstHash, err := headerChain.BlockHashByHeight(bestState.Height - numBlocks)
+	if err != nil {
+		return 0, err
+	}
+	firstHeader, err := headerChain.HeaderByHash(firstHash)
+	if err != nil {
+		return 0, err
+	}
+
+	avgInterval := tipHeader.Timestamp.Sub(firstHeader.Timestamp) / time.Duration(numBlocks)
+	targetInterval := headerChain.GetChainParams().TargetTimePerBlock
+	if avgInterval <= targetInterval || targetInterval <= 0 {
+		return currentFeeRate, nil
+	}
+	return uint64(math.Ceil(float64(currentFeeRate) * float64(avgInterval) / float64(targetInterval))), nil
+}
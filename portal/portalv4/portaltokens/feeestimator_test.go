@@ -0,0 +1,84 @@
+package portaltokens
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	btcrelaying "github.com/incognitochain/incognito-chain/relaying/btc"
+)
+
+func TestEstimateFallbackFeeRate(t *testing.T) {
+	p := PortalLTCTokenProcessor{
+		PortalBTCTokenProcessor{
+			PortalToken: &PortalToken{ChainID: "Litecoin-Testnet"},
+			ChainParam:  btcrelaying.GetLTCTestNet4Params(),
+		},
+	}
+	relayingChain := newLTCTestRelayingChain(t)
+	defer relayingChain.cleanUp()
+	bc := &ltcTestChainRetriever{ltcChain: relayingChain.chain}
+
+	// not enough relayed blocks
+	feeRate, err := p.EstimateFallbackFeeRate(bc, 20)
+	if err != nil || feeRate != 20 {
+		t.Fatalf("Expected fee rate 20 without relayed blocks, got %v (err %v)", feeRate, err)
+	}
+
+	// blocks are mined on time
+	relayingChain.mineEmptyBlocks(FeeEstimationNumBlocks)
+	feeRate, err = p.EstimateFallbackFeeRate(bc, 20)
+	if err != nil || feeRate != 20 {
+		t.Fatalf("Expected fee rate 20 when blocks are on time, got %v (err %v)", feeRate, err)
+	}
+
+	// blocks are mined faster than the target, the fee rate is kept
+	relayingChain.blockInterval = relayingChain.params.TargetTimePerBlock / 2
+	relayingChain.mineEmptyBlocks(FeeEstimationNumBlocks)
+	feeRate, err = p.EstimateFallbackFeeRate(bc, 20)
+	if err != nil || feeRate != 20 {
+		t.Fatalf("Expected fee rate 20 when blocks are fast, got %v (err %v)", feeRate, err)
+	}
+
+	// blocks are mined 2.5 times slower than the target
+	relayingChain.blockInterval = relayingChain.params.TargetTimePerBlock * 5 / 2
+	relayingChain.mineEmptyBlocks(FeeEstimationNumBlocks)
+	feeRate, err = p.EstimateFallbackFeeRate(bc, 20)
+	if err != nil || feeRate != 50 {
+		t.Fatalf("Expected fee rate 50 when blocks are slow, got %v (err %v)", feeRate, err)
+	}
+
+	// the btc relaying chain is not available
+	_, err = p.PortalBTCTokenProcessor.EstimateFallbackFeeRate(bc, 20)
+	if err == nil {
+		t.Fatal("Expected error without the relaying chain")
+	}
+}
+
+func TestRPCFeeOracle(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		var req struct {
+			Method string        `json:"method"`
+			Params []interface{} `json:"params"`
+		}
+		if err := json.Unmarshal(body, &req); err != nil || req.Method != "estimatesmartfee" || len(req.Params) != 1 {
+			w.Write([]byte(`{"id":1,"result":null,"error":{"code":-32601,"message":"Method not found"}}`))
+			return
+		}
+		w.Write([]byte(`{"id":1,"result":{"feerate":0.00012345,"blocks":2},"error":null}`))
+	}))
+	defer server.Close()
+
+	oracle := NewRPCFeeOracle(map[string]string{"Bitcoin-Testnet": server.URL})
+	feeRate, err := oracle.GetFeeRate("Bitcoin-Testnet")
+	if err != nil || feeRate != 13 {
+		t.Fatalf("Expected fee rate 13, got %v (err %v)", feeRate, err)
+	}
+	_, err = oracle.GetFeeRate("Litecoin-Testnet")
+	if err == nil {
+		t.Fatal("Expected error for the chain without fee oracle")
+	}
+}
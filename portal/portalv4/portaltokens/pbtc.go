@@ -26,6 +26,12 @@ type PortalBTCTokenProcessor struct {
 	*PortalToken
 	ChainParam    *chaincfg.Params
 	PortalTokenID string
+	TaprootVault  bool // shielding coins can be deposited to the taproot vault addresses
+	// MasterKeyChainCodeSeed derives the master keys of the token from the btc master keys of the validators,
+	// so the tokens sharing the btc processor do not share the keys. It is empty for btc
+	MasterKeyChainCodeSeed string
+	// FeeOracle is the local fee oracle of the external chain used to estimate fees of unshield batches, it is optional
+	FeeOracle FeeOracle
 }
 
 func (p PortalBTCTokenProcessor) GetPortalTokenID() string {
@@ -54,7 +60,7 @@ func (p PortalBTCTokenProcessor) GetTxHashFromProof(proof string) (string, error
 }
 
 func (p PortalBTCTokenProcessor) parseAndVerifyProofBTCChain(
-	proof string, btcChain *btcrelaying.BlockChain, expectedMultisigAddress string, chainCodeSeed string, minShieldAmt uint64) (bool, []*statedb.UTXO, error) {
+	proof string, btcChain *btcrelaying.BlockChain, expectedMultisigAddresses []string, chainCodeSeed string, minShieldAmt uint64) (bool, []*statedb.UTXO, error) {
 	if btcChain == nil {
 		Logger.log.Error("BTC relaying chain should not be null")
 		return false, nil, errors.New("BTC relaying chain should not be null")
@@ -86,7 +92,7 @@ func (p PortalBTCTokenProcessor) parseAndVerifyProofBTCChain(
 			Logger.log.Errorf("[portal] ExtractPaymentAddrStrFromPkScript: could not extract payment address string from pkscript with err: %v\n", err)
 			continue
 		}
-		if strings.ToLower(addrStr) != strings.ToLower(expectedMultisigAddress) {
+		if !isExpectedAddress(addrStr, expectedMultisigAddresses) {
 			continue
 		}
 
@@ -114,11 +120,20 @@ func (p PortalBTCTokenProcessor) parseAndVerifyProofBTCChain(
 	return true, listUTXO, nil
 }
 
+func isExpectedAddress(addrStr string, expectedAddresses []string) bool {
+	for _, expectedAddress := range expectedAddresses {
+		if strings.ToLower(addrStr) == strings.ToLower(expectedAddress) {
+			return true
+		}
+	}
+	return false
+}
+
 func (p PortalBTCTokenProcessor) ParseAndVerifyShieldProof(
-	proof string, bc metadata.ChainRetriever, expectedReceivedMultisigAddress string, chainCodeSeed string, minShieldAmt uint64,
+	proof string, bc metadata.ChainRetriever, expectedReceivedMultisigAddresses []string, chainCodeSeed string, minShieldAmt uint64,
 ) (bool, []*statedb.UTXO, error) {
 	btcChain := bc.GetBTCHeaderChain()
-	return p.parseAndVerifyProofBTCChain(proof, btcChain, expectedReceivedMultisigAddress, chainCodeSeed, minShieldAmt)
+	return p.parseAndVerifyProofBTCChain(proof, btcChain, expectedReceivedMultisigAddresses, chainCodeSeed, minShieldAmt)
 }
 
 func (p PortalBTCTokenProcessor) ParseAndVerifyUnshieldProof(
@@ -130,6 +145,17 @@ func (p PortalBTCTokenProcessor) ParseAndVerifyUnshieldProof(
 	utxos []*statedb.UTXO,
 ) (bool, []*statedb.UTXO, string, uint64, error) {
 	btcChain := bc.GetBTCHeaderChain()
+	return p.parseAndVerifyUnshieldProofBTCChain(proof, btcChain, expectedReceivedMultisigAddress, chainCodeSeed, expectPaymentInfo, utxos)
+}
+
+func (p PortalBTCTokenProcessor) parseAndVerifyUnshieldProofBTCChain(
+	proof string,
+	btcChain *btcrelaying.BlockChain,
+	expectedReceivedMultisigAddress string,
+	chainCodeSeed string,
+	expectPaymentInfo []*OutputTx,
+	utxos []*statedb.UTXO,
+) (bool, []*statedb.UTXO, string, uint64, error) {
 	if btcChain == nil {
 		Logger.log.Error("BTC relaying chain should not be null")
 		return false, nil, "", 0, errors.New("BTC relaying chain should not be null")
@@ -246,31 +272,75 @@ func (p PortalBTCTokenProcessor) generatePublicKeyFromPrivateKey(privateKey []by
 }
 
 func (p PortalBTCTokenProcessor) generatePublicKeyFromSeed(seed []byte) []byte {
-	// generate BTC master account
+	masterPrivateKey, err := p.generateMasterPrivateKey(seed)
+	if err != nil {
+		Logger.log.Errorf("Could not generate master private key: %v", err)
+		return nil
+	}
+	return p.generatePublicKeyFromPrivateKey(masterPrivateKey)
+}
+
+// GenerateMasterPubKey returns the master public key of the beacon validator with the seed key
+func (p PortalBTCTokenProcessor) GenerateMasterPubKey(seedKey []byte) []byte {
+	return p.generatePublicKeyFromSeed(seedKey)
+}
+
+// generateMasterPrivateKey returns the master private key of the beacon validator for the token
+func (p PortalBTCTokenProcessor) generateMasterPrivateKey(seed []byte) ([]byte, error) {
 	BTCPrivateKeyMaster := chainhash.HashB(seed) // private mining key => private key btc
-	return p.generatePublicKeyFromPrivateKey(BTCPrivateKeyMaster)
+	if p.MasterKeyChainCodeSeed == "" {
+		return BTCPrivateKeyMaster, nil
+	}
+	return p.generateChildPrivateKey(BTCPrivateKeyMaster, p.MasterKeyChainCodeSeed)
 }
 
 func (p PortalBTCTokenProcessor) generateOTPrivateKey(seed []byte, chainCodeSeed string) ([]byte, error) {
-	BTCPrivateKeyMaster := chainhash.HashB(seed) // private mining key => private key btc
+	masterPrivateKey, err := p.generateMasterPrivateKey(seed)
+	if err != nil {
+		return []byte{}, err
+	}
 
 	// this Incognito address is marked for the address that received change UTXOs
 	if chainCodeSeed == "" {
-		return BTCPrivateKeyMaster, nil
-	} else {
-		chainCode := chainhash.HashB([]byte(chainCodeSeed))
-		extendedBTCPrivateKey := hdkeychain.NewExtendedKey(p.ChainParam.HDPrivateKeyID[:], BTCPrivateKeyMaster, chainCode, []byte{}, 0, 0, true)
-		extendedBTCChildPrivateKey, err := extendedBTCPrivateKey.Child(0)
-		if err != nil {
-			return []byte{}, fmt.Errorf("Could not generate child private key for incognito address: %v", chainCodeSeed)
-		}
-		btcChildPrivateKey, err := extendedBTCChildPrivateKey.ECPrivKey()
+		return masterPrivateKey, nil
+	}
+	return p.generateChildPrivateKey(masterPrivateKey, chainCodeSeed)
+}
+
+func (p PortalBTCTokenProcessor) generateChildPrivateKey(privateKey []byte, chainCodeSeed string) ([]byte, error) {
+	chainCode := chainhash.HashB([]byte(chainCodeSeed))
+	extendedBTCPrivateKey := hdkeychain.NewExtendedKey(p.ChainParam.HDPrivateKeyID[:], privateKey, chainCode, []byte{}, 0, 0, true)
+	extendedBTCChildPrivateKey, err := extendedBTCPrivateKey.Derive(0)
+	if err != nil {
+		return []byte{}, fmt.Errorf("Could not generate child private key for incognito address: %v", chainCodeSeed)
+	}
+	btcChildPrivateKey, err := extendedBTCChildPrivateKey.ECPrivKey()
+	if err != nil {
+		return []byte{}, fmt.Errorf("Could not get private key from extended private key")
+	}
+	btcChildPrivateKeyBytes := btcChildPrivateKey.Serialize()
+	return btcChildPrivateKeyBytes, nil
+}
+
+// generateOTPubKeys returns the public keys of beacon validators for each Incognito address
+func (p PortalBTCTokenProcessor) generateOTPubKeys(masterPubKeys [][]byte, chainCodeSeed string) ([][]byte, error) {
+	// this Incognito address is marked for the address that received change UTXOs
+	if chainCodeSeed == "" {
+		return masterPubKeys[:], nil
+	}
+	pubKeys := [][]byte{}
+	chainCode := chainhash.HashB([]byte(chainCodeSeed))
+	for idx, masterPubKey := range masterPubKeys {
+		// generate BTC child public key for this Incognito address
+		extendedBTCPublicKey := hdkeychain.NewExtendedKey(p.ChainParam.HDPublicKeyID[:], masterPubKey, chainCode, []byte{}, 0, 0, false)
+		extendedBTCChildPubKey, _ := extendedBTCPublicKey.Derive(0)
+		childPubKey, err := extendedBTCChildPubKey.ECPubKey()
 		if err != nil {
-			return []byte{}, fmt.Errorf("Could not get private key from extended private key")
+			return nil, fmt.Errorf("Master BTC Public Key (#%v) %v is invalid - Error %v", idx, masterPubKey, err)
 		}
-		btcChildPrivateKeyBytes := btcChildPrivateKey.Serialize()
-		return btcChildPrivateKeyBytes, nil
+		pubKeys = append(pubKeys, childPubKey.SerializeCompressed())
 	}
+	return pubKeys, nil
 }
 
 // Generate Bech32 P2WSH multisig address for each Incognito address
@@ -280,22 +350,9 @@ func (p PortalBTCTokenProcessor) GenerateOTMultisigAddress(masterPubKeys [][]byt
 		return []byte{}, "", fmt.Errorf("Invalid signature requirement")
 	}
 
-	pubKeys := [][]byte{}
-	// this Incognito address is marked for the address that received change UTXOs
-	if chainCodeSeed == "" {
-		pubKeys = masterPubKeys[:]
-	} else {
-		chainCode := chainhash.HashB([]byte(chainCodeSeed))
-		for idx, masterPubKey := range masterPubKeys {
-			// generate BTC child public key for this Incognito address
-			extendedBTCPublicKey := hdkeychain.NewExtendedKey(p.ChainParam.HDPublicKeyID[:], masterPubKey, chainCode, []byte{}, 0, 0, false)
-			extendedBTCChildPubKey, _ := extendedBTCPublicKey.Child(0)
-			childPubKey, err := extendedBTCChildPubKey.ECPubKey()
-			if err != nil {
-				return []byte{}, "", fmt.Errorf("Master BTC Public Key (#%v) %v is invalid - Error %v", idx, masterPubKey, err)
-			}
-			pubKeys = append(pubKeys, childPubKey.SerializeCompressed())
-		}
+	pubKeys, err := p.generateOTPubKeys(masterPubKeys, chainCodeSeed)
+	if err != nil {
+		return []byte{}, "", err
 	}
 
 	// create redeem script for m of n multi-sig
@@ -359,12 +416,7 @@ func (p PortalBTCTokenProcessor) CreateRawExternalTx(inputs []*statedb.UTXO, out
 	totalOutputAmount := uint64(0)
 	for _, out := range outputs {
 		// adding the output to tx
-		decodedAddr, err := btcutil.DecodeAddress(out.ReceiverAddress, p.ChainParam)
-		if err != nil {
-			Logger.log.Errorf("[CreateRawExternalTx-BTC] Error when decoding receiver address: %v", err)
-			return "", "", err
-		}
-		destinationAddrByte, err := txscript.PayToAddrScript(decodedAddr)
+		destinationAddrByte, err := btcrelaying.PayToAddrStrScript(out.ReceiverAddress, p.ChainParam)
 		if err != nil {
 			Logger.log.Errorf("[CreateRawExternalTx-BTC] Error when new Address Script: %v", err)
 			return "", "", err
@@ -391,12 +443,7 @@ func (p PortalBTCTokenProcessor) CreateRawExternalTx(inputs []*statedb.UTXO, out
 	if totalInputAmount > totalOutputAmount {
 		// adding the output to tx
 		multiSigAddress := bc.GetPortalV4GeneralMultiSigAddress(p.GetPortalTokenID(), beaconHeight)
-		decodedAddr, err := btcutil.DecodeAddress(multiSigAddress, p.ChainParam)
-		if err != nil {
-			Logger.log.Errorf("[CreateRawExternalTx-BTC] Error when decoding multisig address: %v", err)
-			return "", "", err
-		}
-		destinationAddrByte, err := txscript.PayToAddrScript(decodedAddr)
+		destinationAddrByte, err := btcrelaying.PayToAddrStrScript(multiSigAddress, p.ChainParam)
 		if err != nil {
 			Logger.log.Errorf("[CreateRawExternalTx-BTC] Error when new multisig Address Script: %v", err)
 			return "", "", err
@@ -437,6 +484,15 @@ func (p PortalBTCTokenProcessor) PartSignOnRawExternalTx(seedKey []byte, masterP
 		if err != nil {
 			return nil, "", fmt.Errorf("[PartSignOnRawExternalTx] Error when generate btc private key from seed: %v", err)
 		}
+		// sign the taproot vault input with schnorr signature
+		if p.isTaprootUTXO(inputs[i]) {
+			sig, err := p.partSignTaprootInput(btcPrivateKeyBytes, masterPubKeys, numSigsRequired, msgTx, inputs, i)
+			if err != nil {
+				return nil, "", fmt.Errorf("[PartSignOnRawExternalTx] Error when signing on taproot input: %v", err)
+			}
+			sigs = append(sigs, sig)
+			continue
+		}
 		btcPrivateKey, _ := btcec.PrivKeyFromBytes(btcec.S256(), btcPrivateKeyBytes)
 		multiSigScript, _, err := p.GenerateOTMultisigAddress(masterPubKeys, numSigsRequired, inputs[i].GetChainCodeSeed())
 		if err != nil {
@@ -452,8 +508,31 @@ func (p PortalBTCTokenProcessor) PartSignOnRawExternalTx(seedKey []byte, masterP
 	return sigs, msgTx.TxHash().String(), nil
 }
 
-func (p PortalBTCTokenProcessor) IsAcceptableTxSize(numInputs int, numOutputs int) bool {
-	return p.ExternalInputSize*uint(numInputs)+p.ExternalOutputSize*uint(numOutputs) <= p.ExternalTxMaxSize
+// GenerateWitness builds the witness of the input idx of the external tx from the signatures of beacon validators
+func (p PortalBTCTokenProcessor) GenerateWitness(
+	masterPubKeys [][]byte, numSigsRequired int, msgTx *wire.MsgTx, inputs []*statedb.UTXO, idx int, sigs [][]byte,
+) (wire.TxWitness, error) {
+	if idx < 0 || idx >= len(inputs) {
+		return nil, fmt.Errorf("Input index %v is out of range", idx)
+	}
+	if p.isTaprootUTXO(inputs[idx]) {
+		return p.buildTaprootWitness(masterPubKeys, numSigsRequired, msgTx, inputs, idx, sigs)
+	}
+	multisigScript, _, err := p.GenerateOTMultisigAddress(masterPubKeys, numSigsRequired, inputs[idx].GetChainCodeSeed())
+	if err != nil {
+		return nil, err
+	}
+	// the first item is dummy for the bug of OP_CHECKMULTISIG
+	witness := wire.TxWitness{nil}
+	witness = append(witness, sigs...)
+	witness = append(witness, multisigScript)
+	return witness, nil
+}
+
+// IsAcceptableTxSize checks the size limit of the external tx that spends the inputs,
+// the size is estimated the same way as EstimateTxSize
+func (p PortalBTCTokenProcessor) IsAcceptableTxSize(inputs []*statedb.UTXO, numOutputs int) bool {
+	return p.EstimateTxSize(inputs, numOutputs) <= p.ExternalTxMaxSize
 }
 
 func (p PortalBTCTokenProcessor) MatchUTXOsAndUnshieldIDsNew(
@@ -590,7 +669,7 @@ func (p PortalBTCTokenProcessor) MatchUTXOsAndUnshieldIDs(
 		cnt := 0
 		if utxosArr[utxoIdx].value.GetOutputAmount() >= wReqsArr[unshieldIdx].value.GetAmount() {
 			// find the last unshield idx that the cumulative sum of unshield amount <= current utxo amount
-			for unshieldIdx < len(wReqsArr) && curSumAmount+wReqsArr[unshieldIdx].value.GetAmount() <= utxosArr[utxoIdx].value.GetOutputAmount() && p.IsAcceptableTxSize(1, cnt+1) {
+			for unshieldIdx < len(wReqsArr) && curSumAmount+wReqsArr[unshieldIdx].value.GetAmount() <= utxosArr[utxoIdx].value.GetOutputAmount() && p.IsAcceptableTxSize([]*statedb.UTXO{utxosArr[utxoIdx].value}, cnt+1) {
 				curSumAmount += wReqsArr[unshieldIdx].value.GetAmount()
 				chosenUnshieldIDs = append(chosenUnshieldIDs, wReqsArr[unshieldIdx].value.GetUnshieldID())
 				unshieldIdx += 1
@@ -606,7 +685,7 @@ func (p PortalBTCTokenProcessor) MatchUTXOsAndUnshieldIDs(
 				utxoIdx += 1 // utxoIdx increases
 				cnt += 1
 			}
-			if utxoIdx < len(utxos) && p.IsAcceptableTxSize(cnt+1, 1) {
+			if utxoIdx < len(utxos) && p.IsAcceptableTxSize(withUTXO(chosenUTXOs, utxosArr[utxoIdx].value), 1) {
 				curSumAmount += utxosArr[utxoIdx].value.GetOutputAmount()
 				chosenUTXOs = append(chosenUTXOs, utxosArr[utxoIdx].value)
 				utxoIdx += 1
@@ -617,7 +696,7 @@ func (p PortalBTCTokenProcessor) MatchUTXOsAndUnshieldIDs(
 				curSumAmount = 0
 
 				// insert new unshield IDs if the current utxos still has enough amount
-				for unshieldIdx < len(wReqsArr) && curSumAmount+wReqsArr[unshieldIdx].value.GetAmount() <= target && p.IsAcceptableTxSize(cnt, newCnt+1) {
+				for unshieldIdx < len(wReqsArr) && curSumAmount+wReqsArr[unshieldIdx].value.GetAmount() <= target && p.IsAcceptableTxSize(chosenUTXOs, newCnt+1) {
 					curSumAmount += wReqsArr[unshieldIdx].value.GetAmount()
 					chosenUnshieldIDs = append(chosenUnshieldIDs, wReqsArr[unshieldIdx].value.GetUnshieldID())
 					unshieldIdx += 1
@@ -646,11 +725,11 @@ func (p PortalBTCTokenProcessor) MatchUTXOsAndUnshieldIDs(
 			prevUTXOs, prevRequests := mergedBatches[mergedIdx].UTXOs, mergedBatches[mergedIdx].UnshieldIDs
 			curUTXOs, curRequests := broadcastTxs[idx].UTXOs, broadcastTxs[idx].UnshieldIDs
 
-			lenUTXOs := len(prevUTXOs) + len(curUTXOs)
+			mergedUTXOs := append(append([]*statedb.UTXO{}, prevUTXOs...), curUTXOs...)
 			lenRequests := len(prevRequests) + len(curRequests)
-			if p.IsAcceptableTxSize(lenUTXOs, lenRequests) {
+			if p.IsAcceptableTxSize(mergedUTXOs, lenRequests) {
 				mergedBatches[mergedIdx] = &BroadcastTx{
-					UTXOs:       append(prevUTXOs, curUTXOs...),
+					UTXOs:       mergedUTXOs,
 					UnshieldIDs: append(prevRequests, curRequests...),
 				}
 			} else {
@@ -667,7 +746,7 @@ func (p PortalBTCTokenProcessor) MatchUTXOsAndUnshieldIDs(
 	for idx := 0; idx < len(mergedBatches); idx++ {
 		if utxoIdx < len(utxos)-dustUTXOUsed &&
 			utxosArr[len(utxos)-dustUTXOUsed-1].value.GetOutputAmount() <= dustValueThreshold &&
-			p.IsAcceptableTxSize(len(mergedBatches[idx].UTXOs)+1, len(mergedBatches[idx].UnshieldIDs)) {
+			p.IsAcceptableTxSize(withUTXO(mergedBatches[idx].UTXOs, utxosArr[len(utxos)-dustUTXOUsed-1].value), len(mergedBatches[idx].UnshieldIDs)) {
 			dustUTXOUsed += 1
 			mergedBatches[idx].UTXOs = append(mergedBatches[idx].UTXOs, utxosArr[len(utxos)-dustUTXOUsed].value)
 		}
@@ -687,7 +766,7 @@ func (p PortalBTCTokenProcessor) MatchUTXOsAndUnshieldIDs(
 			sumRequestAmount += unshieldAmountMap[val]
 		}
 		for unshieldIdx < len(wReqsArr) && sumRequestAmount+wReqsArr[unshieldIdx].value.GetAmount() <= sumUTXOAmount &&
-			p.IsAcceptableTxSize(len(mergedBatches[idx].UTXOs), len(mergedBatches[idx].UnshieldIDs)+1) {
+			p.IsAcceptableTxSize(mergedBatches[idx].UTXOs, len(mergedBatches[idx].UnshieldIDs)+1) {
 			sumRequestAmount += wReqsArr[unshieldIdx].value.GetAmount()
 			mergedBatches[idx].UnshieldIDs = append(mergedBatches[idx].UnshieldIDs, wReqsArr[unshieldIdx].value.GetUnshieldID())
 			unshieldIdx += 1
@@ -0,0 +1,513 @@
+package portaltokens
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	btcecv2 "github.com/btcsuite/btcd/btcec/v2"
+	"github.com/btcsuite/btcd/btcec/v2/schnorr"
+	"github.com/btcsuite/btcd/btcec/v2/schnorr/musig2"
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/incognitochain/incognito-chain/dataaccessobject/statedb"
+	btcrelaying "github.com/incognitochain/incognito-chain/relaying/btc"
+)
+
+// Taproot vault
+// The taproot vault is a P2TR output whose internal key is the MuSig2 aggregated key (BIP327) of beacon validators,
+// with a single tapscript leaf <pk_1> OP_CHECKSIG <pk_2> OP_CHECKSIGADD ... <pk_n> OP_CHECKSIGADD <m> OP_NUMEQUAL (BIP342).
+// Unshield txs spend it by the key path with one aggregated schnorr signature, so the multisig policy of the vault
+// is not revealed on the external chain: beacon validators send their MuSig2 nonces in the votes on the block
+// that has the unshield tx, and their partial signatures in the votes on the next block.
+// When some validators of the vault do not take part in the key path, the schnorr signatures in the votes
+// on the block that has the unshield tx spend it by the tapscript leaf.
+
+const (
+	tapscriptLeafVersion = 0xc0
+	opCheckSigAdd        = 0xba
+	sigHashDefault       = 0x00
+	xOnlyPubKeySize      = 32
+
+	// muSig2PartialSigSize is the size of an encoded partial signature: s and the final nonce R of the signing session
+	muSig2PartialSigSize = 32 + btcecv2.PubKeyBytesLenCompressed
+	// muSig2SessionTimeout is the lifetime of the signing sessions, the partial signatures are sent in the next block
+	muSig2SessionTimeout = time.Hour
+)
+
+// taprootVault is the taproot vault of an Incognito address
+type taprootVault struct {
+	pubKeys    []*btcecv2.PublicKey // public keys of beacon validators in order of the master public keys
+	aggKey     *musig2.AggregateKey
+	tapscript  []byte
+	merkleRoot []byte
+}
+
+// controlBlock returns the control block to spend the vault by the tapscript leaf
+func (v *taprootVault) controlBlock() []byte {
+	parity := byte(0)
+	if v.aggKey.FinalKey.SerializeCompressed()[0] == 0x03 {
+		parity = 1
+	}
+	return append([]byte{tapscriptLeafVersion | parity}, schnorr.SerializePubKey(v.aggKey.PreTweakedKey)...)
+}
+
+// tapLeafHash returns the hash of the tapscript leaf
+func tapLeafHash(script []byte) []byte {
+	var buf bytes.Buffer
+	buf.WriteByte(tapscriptLeafVersion)
+	wire.WriteVarBytes(&buf, 0, script)
+	return chainhash.TaggedHash([]byte("TapLeaf"), buf.Bytes())[:]
+}
+
+func (p PortalBTCTokenProcessor) generateTaprootVault(masterPubKeys [][]byte, numSigsRequired int, chainCodeSeed string) (*taprootVault, error) {
+	if len(masterPubKeys) < numSigsRequired || numSigsRequired <= 0 {
+		return nil, fmt.Errorf("Invalid signature requirement")
+	}
+	otPubKeys, err := p.generateOTPubKeys(masterPubKeys, chainCodeSeed)
+	if err != nil {
+		return nil, err
+	}
+
+	// create tapscript for m of n multi-sig
+	vault := &taprootVault{}
+	builder := txscript.NewScriptBuilder()
+	for idx, otPubKey := range otPubKeys {
+		pubKey, err := btcecv2.ParsePubKey(otPubKey)
+		if err != nil {
+			return nil, fmt.Errorf("Public key (#%v) %x is invalid - Error %v", idx, otPubKey, err)
+		}
+		vault.pubKeys = append(vault.pubKeys, pubKey)
+		builder.AddData(schnorr.SerializePubKey(pubKey))
+		if idx == 0 {
+			builder.AddOp(txscript.OP_CHECKSIG)
+		} else {
+			builder.AddOp(opCheckSigAdd)
+		}
+	}
+	builder.AddInt64(int64(numSigsRequired))
+	builder.AddOp(txscript.OP_NUMEQUAL)
+	vault.tapscript, err = builder.Script()
+	if err != nil {
+		return nil, fmt.Errorf("Could not build tapscript - Error %v", err)
+	}
+
+	// the script tree only has one leaf, so its merkle root is the leaf hash
+	vault.merkleRoot = tapLeafHash(vault.tapscript)
+	vault.aggKey, _, _, err = musig2.AggregateKeys(vault.pubKeys, false, musig2.WithTaprootKeyTweak(vault.merkleRoot))
+	if err != nil {
+		return nil, fmt.Errorf("Could not aggregate public keys - Error %v", err)
+	}
+	return vault, nil
+}
+
+// GenerateOTTaprootAddress generates the P2TR taproot vault address for each Incognito address
+// Return tapscript, control block, OTTaprootAddress
+func (p PortalBTCTokenProcessor) GenerateOTTaprootAddress(masterPubKeys [][]byte, numSigsRequired int, chainCodeSeed string) ([]byte, []byte, string, error) {
+	vault, err := p.generateTaprootVault(masterPubKeys, numSigsRequired, chainCodeSeed)
+	if err != nil {
+		return nil, nil, "", err
+	}
+	addrStr, err := btcrelaying.EncodeTaprootAddress(schnorr.SerializePubKey(vault.aggKey.FinalKey), p.ChainParam)
+	if err != nil {
+		return nil, nil, "", fmt.Errorf("Could not generate taproot address - Error %v", err)
+	}
+	return vault.tapscript, vault.controlBlock(), addrStr, nil
+}
+
+// GenerateOTDepositAddresses returns the vault addresses that receive shielding coins of the Incognito address:
+// the P2WSH multisig address and the taproot vault address if it is enabled
+func (p PortalBTCTokenProcessor) GenerateOTDepositAddresses(masterPubKeys [][]byte, numSigsRequired int, chainCodeSeed string) ([]string, error) {
+	_, multisigAddress, err := p.GenerateOTMultisigAddress(masterPubKeys, numSigsRequired, chainCodeSeed)
+	if err != nil {
+		return nil, err
+	}
+	addresses := []string{multisigAddress}
+	if p.TaprootVault {
+		_, _, taprootAddress, err := p.GenerateOTTaprootAddress(masterPubKeys, numSigsRequired, chainCodeSeed)
+		if err != nil {
+			return nil, err
+		}
+		addresses = append(addresses, taprootAddress)
+	}
+	return addresses, nil
+}
+
+func (p PortalBTCTokenProcessor) isTaprootUTXO(utxo *statedb.UTXO) bool {
+	return btcrelaying.IsTaprootAddress(utxo.GetWalletAddress(), p.ChainParam)
+}
+
+// calcTaprootSigHash returns the BIP341 signature hash (SIGHASH_DEFAULT) of the taproot input,
+// spent by the tapscript leaf or by the key path if tapscript is nil
+func (p PortalBTCTokenProcessor) calcTaprootSigHash(msgTx *wire.MsgTx, inputs []*statedb.UTXO, idx int, tapscript []byte) ([32]byte, error) {
+	var sigHash [32]byte
+	if len(inputs) != len(msgTx.TxIn) || idx < 0 || idx >= len(inputs) {
+		return sigHash, errors.New("Invalid inputs for taproot signature hash")
+	}
+	var prevOuts, amounts, pkScripts, sequences, outputs bytes.Buffer
+	for i, txIn := range msgTx.TxIn {
+		prevOuts.Write(txIn.PreviousOutPoint.Hash[:])
+		binary.Write(&prevOuts, binary.LittleEndian, txIn.PreviousOutPoint.Index)
+		binary.Write(&amounts, binary.LittleEndian, int64(inputs[i].GetOutputAmount()))
+		pkScript, err := btcrelaying.PayToAddrStrScript(inputs[i].GetWalletAddress(), p.ChainParam)
+		if err != nil {
+			return sigHash, fmt.Errorf("Could not build pkscript of input %v: %v", i, err)
+		}
+		wire.WriteVarBytes(&pkScripts, 0, pkScript)
+		binary.Write(&sequences, binary.LittleEndian, txIn.Sequence)
+	}
+	for _, txOut := range msgTx.TxOut {
+		binary.Write(&outputs, binary.LittleEndian, txOut.Value)
+		wire.WriteVarBytes(&outputs, 0, txOut.PkScript)
+	}
+	sha := func(buf bytes.Buffer) []byte {
+		h := sha256.Sum256(buf.Bytes())
+		return h[:]
+	}
+
+	var sigMsg bytes.Buffer
+	sigMsg.WriteByte(0x00) // epoch
+	sigMsg.WriteByte(sigHashDefault)
+	binary.Write(&sigMsg, binary.LittleEndian, msgTx.Version)
+	binary.Write(&sigMsg, binary.LittleEndian, msgTx.LockTime)
+	sigMsg.Write(sha(prevOuts))
+	sigMsg.Write(sha(amounts))
+	sigMsg.Write(sha(pkScripts))
+	sigMsg.Write(sha(sequences))
+	sigMsg.Write(sha(outputs))
+	if tapscript == nil {
+		sigMsg.WriteByte(0x00) // spend type: key path without annex
+		binary.Write(&sigMsg, binary.LittleEndian, uint32(idx))
+	} else {
+		sigMsg.WriteByte(0x02) // spend type: script path without annex
+		binary.Write(&sigMsg, binary.LittleEndian, uint32(idx))
+		sigMsg.Write(tapLeafHash(tapscript))
+		sigMsg.WriteByte(0x00) // key version
+		binary.Write(&sigMsg, binary.LittleEndian, uint32(0xffffffff))
+	}
+	copy(sigHash[:], chainhash.TaggedHash([]byte("TapSighash"), sigMsg.Bytes())[:])
+	return sigHash, nil
+}
+
+// partSignTaprootInput signs the tapscript leaf of the taproot vault input by the beacon validator
+func (p PortalBTCTokenProcessor) partSignTaprootInput(
+	privateKey []byte, masterPubKeys [][]byte, numSigsRequired int, msgTx *wire.MsgTx, inputs []*statedb.UTXO, idx int,
+) ([]byte, error) {
+	vault, err := p.generateTaprootVault(masterPubKeys, numSigsRequired, inputs[idx].GetChainCodeSeed())
+	if err != nil {
+		return nil, err
+	}
+	sigHash, err := p.calcTaprootSigHash(msgTx, inputs, idx, vault.tapscript)
+	if err != nil {
+		return nil, err
+	}
+	privKey, _ := btcecv2.PrivKeyFromBytes(privateKey)
+	sig, err := schnorr.Sign(privKey, sigHash[:])
+	if err != nil {
+		return nil, err
+	}
+	return sig.Serialize(), nil
+}
+
+// buildTaprootWitness builds the witness of the taproot vault input spent by the tapscript leaf
+// from the signatures of beacon validators. The signatures are matched to the keys of the tapscript,
+// the witness has one item for each key in reverse order: a signature for the first numSigsRequired matched keys,
+// and empty for the others.
+func (p PortalBTCTokenProcessor) buildTaprootWitness(
+	masterPubKeys [][]byte, numSigsRequired int, msgTx *wire.MsgTx, inputs []*statedb.UTXO, idx int, sigs [][]byte,
+) (wire.TxWitness, error) {
+	vault, err := p.generateTaprootVault(masterPubKeys, numSigsRequired, inputs[idx].GetChainCodeSeed())
+	if err != nil {
+		return nil, err
+	}
+	sigHash, err := p.calcTaprootSigHash(msgTx, inputs, idx, vault.tapscript)
+	if err != nil {
+		return nil, err
+	}
+
+	witness := make(wire.TxWitness, len(vault.pubKeys), len(vault.pubKeys)+2)
+	numSigs := 0
+	for i, pubKey := range vault.pubKeys {
+		witness[len(vault.pubKeys)-1-i] = []byte{}
+		if numSigs == numSigsRequired {
+			continue
+		}
+		for _, sigBytes := range sigs {
+			sig, err := schnorr.ParseSignature(sigBytes)
+			if err == nil && sig.Verify(sigHash[:], pubKey) {
+				witness[len(vault.pubKeys)-1-i] = sigBytes
+				numSigs++
+				break
+			}
+		}
+	}
+	if numSigs < numSigsRequired {
+		return nil, fmt.Errorf("Not enough signatures for taproot input %v: %v/%v", idx, numSigs, numSigsRequired)
+	}
+	return append(witness, vault.tapscript, vault.controlBlock()), nil
+}
+
+// muSig2Session is the signing session of a beacon validator on the key path of a taproot vault input
+type muSig2Session struct {
+	secNonce   [musig2.SecNonceSize]byte
+	sigHash    [32]byte
+	createdAt  time.Time
+	aggNonce   [musig2.PubNonceSize]byte
+	partialSig []byte // the secret nonce is erased once the session is signed
+}
+
+// muSig2Sessions keeps the signing sessions by public nonce, a secret nonce must never sign two aggregated nonces.
+// The sessions are only kept in memory on purpose and are lost when the process restarts: a restored secret nonce
+// (e.g. from a copied data dir) could sign two messages and leak the bridge key. A validator that lost its sessions
+// does not send partial sigs for them, so the key path is not complete and the unshield tx falls back to
+// the tapscript leaf with the schnorr sigs already sent in the votes on the block of the unshield tx.
+var muSig2Sessions = struct {
+	sync.Mutex
+	sessions map[[musig2.PubNonceSize]byte]*muSig2Session
+}{sessions: map[[musig2.PubNonceSize]byte]*muSig2Session{}}
+
+func newMuSig2Session(nonces *musig2.Nonces, sigHash [32]byte) {
+	muSig2Sessions.Lock()
+	defer muSig2Sessions.Unlock()
+	for pubNonce, session := range muSig2Sessions.sessions {
+		if time.Since(session.createdAt) > muSig2SessionTimeout {
+			delete(muSig2Sessions.sessions, pubNonce)
+		}
+	}
+	muSig2Sessions.sessions[nonces.PubNonce] = &muSig2Session{
+		secNonce:  nonces.SecNonce,
+		sigHash:   sigHash,
+		createdAt: time.Now(),
+	}
+}
+
+// signMuSig2Session signs the session of the public nonce once, returns nil if the session is not started by this process
+func signMuSig2Session(
+	pubNonce [musig2.PubNonceSize]byte, aggNonce [musig2.PubNonceSize]byte, sigHash [32]byte,
+	sign func(secNonce [musig2.SecNonceSize]byte) (*musig2.PartialSignature, error),
+) ([]byte, error) {
+	muSig2Sessions.Lock()
+	defer muSig2Sessions.Unlock()
+	session, ok := muSig2Sessions.sessions[pubNonce]
+	if !ok {
+		return nil, nil
+	}
+	if session.sigHash != sigHash {
+		return nil, errors.New("MuSig2 nonce was generated for another message")
+	}
+	if session.partialSig != nil {
+		if session.aggNonce != aggNonce {
+			return nil, errors.New("MuSig2 nonce was already signed with other nonces")
+		}
+		return session.partialSig, nil
+	}
+	partialSig, err := sign(session.secNonce)
+	if err != nil {
+		return nil, err
+	}
+	session.secNonce = [musig2.SecNonceSize]byte{}
+	session.aggNonce = aggNonce
+	session.partialSig = encodeMuSig2PartialSig(partialSig)
+	return session.partialSig, nil
+}
+
+func encodeMuSig2PartialSig(partialSig *musig2.PartialSignature) []byte {
+	s := partialSig.S.Bytes()
+	return append(s[:], partialSig.R.SerializeCompressed()...)
+}
+
+func decodeMuSig2PartialSig(data []byte) (*musig2.PartialSignature, error) {
+	if len(data) != muSig2PartialSigSize {
+		return nil, fmt.Errorf("Invalid length of MuSig2 partial signature %v", len(data))
+	}
+	s := new(btcecv2.ModNScalar)
+	if overflow := s.SetByteSlice(data[:32]); overflow {
+		return nil, errors.New("MuSig2 partial signature is out of range")
+	}
+	r, err := btcecv2.ParsePubKey(data[32:])
+	if err != nil {
+		return nil, err
+	}
+	partialSig := musig2.NewPartialSignature(s, r)
+	return &partialSig, nil
+}
+
+func decodeMuSig2Nonces(nonces [][]byte) ([][musig2.PubNonceSize]byte, error) {
+	pubNonces := make([][musig2.PubNonceSize]byte, len(nonces))
+	for i, nonce := range nonces {
+		if len(nonce) != musig2.PubNonceSize {
+			return nil, fmt.Errorf("Invalid length of MuSig2 nonce #%v: %v", i, len(nonce))
+		}
+		copy(pubNonces[i][:], nonce)
+	}
+	return pubNonces, nil
+}
+
+func decodeExternalTx(rawTxBytes []byte, inputs []*statedb.UTXO) (*wire.MsgTx, error) {
+	msgTx := new(wire.MsgTx)
+	err := msgTx.Deserialize(bytes.NewBuffer(rawTxBytes))
+	if err != nil {
+		return nil, fmt.Errorf("Error when deserializing raw tx bytes: %v", err)
+	}
+	if len(inputs) != len(msgTx.TxIn) {
+		return nil, fmt.Errorf("Len of inputs %v and len of TxIn %v are not correct", len(inputs), len(msgTx.TxIn))
+	}
+	return msgTx, nil
+}
+
+// GenerateMuSig2Nonces starts the signing sessions of the beacon validator on the key path of the taproot vault inputs,
+// returns the public nonces for all TxIn (nil for the other inputs)
+func (p PortalBTCTokenProcessor) GenerateMuSig2Nonces(
+	seedKey []byte, masterPubKeys [][]byte, numSigsRequired int, rawTxBytes []byte, inputs []*statedb.UTXO,
+) ([][]byte, error) {
+	msgTx, err := decodeExternalTx(rawTxBytes, inputs)
+	if err != nil {
+		return nil, fmt.Errorf("[GenerateMuSig2Nonces] %v", err)
+	}
+	nonces := make([][]byte, len(inputs))
+	for i := range msgTx.TxIn {
+		if !p.isTaprootUTXO(inputs[i]) {
+			continue
+		}
+		vault, err := p.generateTaprootVault(masterPubKeys, numSigsRequired, inputs[i].GetChainCodeSeed())
+		if err != nil {
+			return nil, fmt.Errorf("[GenerateMuSig2Nonces] Error when generate taproot vault: %v", err)
+		}
+		sigHash, err := p.calcTaprootSigHash(msgTx, inputs, i, nil)
+		if err != nil {
+			return nil, fmt.Errorf("[GenerateMuSig2Nonces] %v", err)
+		}
+		privKeyBytes, err := p.generateOTPrivateKey(seedKey, inputs[i].GetChainCodeSeed())
+		if err != nil {
+			return nil, fmt.Errorf("[GenerateMuSig2Nonces] Error when generate btc private key from seed: %v", err)
+		}
+		privKey, pubKey := btcecv2.PrivKeyFromBytes(privKeyBytes)
+		muSig2Nonces, err := musig2.GenNonces(
+			musig2.WithPublicKey(pubKey),
+			musig2.WithNonceSecretKeyAux(privKey),
+			musig2.WithNonceCombinedKeyAux(vault.aggKey.FinalKey),
+			musig2.WithNonceMessageAux(sigHash),
+		)
+		if err != nil {
+			return nil, fmt.Errorf("[GenerateMuSig2Nonces] Error when generate nonces: %v", err)
+		}
+		newMuSig2Session(muSig2Nonces, sigHash)
+		nonces[i] = muSig2Nonces.PubNonce[:]
+	}
+	return nonces, nil
+}
+
+// PartSignMuSig2 signs the sessions of the beacon validator on the key path of the taproot vault inputs.
+// nonces[i] are the public nonces of all beacon validators on the TxIn i in order of the master public keys.
+// The partial signature of a TxIn is nil if its nonces are not complete or its session was not started by this process.
+func (p PortalBTCTokenProcessor) PartSignMuSig2(
+	seedKey []byte, masterPubKeys [][]byte, numSigsRequired int, rawTxBytes []byte, inputs []*statedb.UTXO, nonces [][][]byte,
+) ([][]byte, error) {
+	msgTx, err := decodeExternalTx(rawTxBytes, inputs)
+	if err != nil {
+		return nil, fmt.Errorf("[PartSignMuSig2] %v", err)
+	}
+	if len(nonces) != len(inputs) {
+		return nil, fmt.Errorf("[PartSignMuSig2] Len of nonces %v and len of TxIn %v are not correct", len(nonces), len(inputs))
+	}
+	signerIdx := -1
+	masterPubKey := p.generatePublicKeyFromSeed(seedKey)
+	for i, pubKey := range masterPubKeys {
+		if bytes.Equal(pubKey, masterPubKey) {
+			signerIdx = i
+			break
+		}
+	}
+	if signerIdx < 0 {
+		return nil, errors.New("[PartSignMuSig2] Seed key is not a key of the vault")
+	}
+
+	partialSigs := make([][]byte, len(inputs))
+	for i := range msgTx.TxIn {
+		if !p.isTaprootUTXO(inputs[i]) || len(nonces[i]) != len(masterPubKeys) {
+			continue
+		}
+		vault, err := p.generateTaprootVault(masterPubKeys, numSigsRequired, inputs[i].GetChainCodeSeed())
+		if err != nil {
+			return nil, fmt.Errorf("[PartSignMuSig2] Error when generate taproot vault: %v", err)
+		}
+		sigHash, err := p.calcTaprootSigHash(msgTx, inputs, i, nil)
+		if err != nil {
+			return nil, fmt.Errorf("[PartSignMuSig2] %v", err)
+		}
+		privKeyBytes, err := p.generateOTPrivateKey(seedKey, inputs[i].GetChainCodeSeed())
+		if err != nil {
+			return nil, fmt.Errorf("[PartSignMuSig2] Error when generate btc private key from seed: %v", err)
+		}
+		privKey, _ := btcecv2.PrivKeyFromBytes(privKeyBytes)
+
+		// the nonces come from the votes of other validators, invalid ones only skip the key path of the input
+		pubNonces, err := decodeMuSig2Nonces(nonces[i])
+		if err != nil {
+			Logger.log.Warnf("[PartSignMuSig2] Skip the key path of input %v: %v", i, err)
+			continue
+		}
+		aggNonce, err := musig2.AggregateNonces(pubNonces)
+		if err != nil {
+			Logger.log.Warnf("[PartSignMuSig2] Skip the key path of input %v: %v", i, err)
+			continue
+		}
+		partialSigs[i], err = signMuSig2Session(pubNonces[signerIdx], aggNonce, sigHash,
+			func(secNonce [musig2.SecNonceSize]byte) (*musig2.PartialSignature, error) {
+				return musig2.Sign(secNonce, privKey, aggNonce, vault.pubKeys, sigHash, musig2.WithTaprootSignTweak(vault.merkleRoot))
+			})
+		if err != nil {
+			Logger.log.Warnf("[PartSignMuSig2] Skip the key path of input %v: %v", i, err)
+		}
+	}
+	return partialSigs, nil
+}
+
+// CombineMuSig2Sigs builds the witness of the taproot vault input spent by the key path
+// from the public nonces and the partial signatures of all beacon validators in order of the master public keys
+func (p PortalBTCTokenProcessor) CombineMuSig2Sigs(
+	masterPubKeys [][]byte, numSigsRequired int, msgTx *wire.MsgTx, inputs []*statedb.UTXO, idx int, nonces [][]byte, partialSigs [][]byte,
+) (wire.TxWitness, error) {
+	if idx < 0 || idx >= len(inputs) || !p.isTaprootUTXO(inputs[idx]) {
+		return nil, fmt.Errorf("Input %v is not a taproot vault input", idx)
+	}
+	if len(nonces) != len(masterPubKeys) || len(partialSigs) != len(masterPubKeys) {
+		return nil, fmt.Errorf("Not enough MuSig2 partial signatures for taproot input %v", idx)
+	}
+	vault, err := p.generateTaprootVault(masterPubKeys, numSigsRequired, inputs[idx].GetChainCodeSeed())
+	if err != nil {
+		return nil, err
+	}
+	sigHash, err := p.calcTaprootSigHash(msgTx, inputs, idx, nil)
+	if err != nil {
+		return nil, err
+	}
+	pubNonces, err := decodeMuSig2Nonces(nonces)
+	if err != nil {
+		return nil, err
+	}
+	aggNonce, err := musig2.AggregateNonces(pubNonces)
+	if err != nil {
+		return nil, err
+	}
+	sigs := make([]*musig2.PartialSignature, len(partialSigs))
+	for i, data := range partialSigs {
+		sigs[i], err = decodeMuSig2PartialSig(data)
+		if err != nil {
+			return nil, fmt.Errorf("Invalid MuSig2 partial signature of key #%v: %v", i, err)
+		}
+		if !sigs[i].Verify(pubNonces[i], aggNonce, vault.pubKeys, vault.pubKeys[i], sigHash, musig2.WithTaprootSignTweak(vault.merkleRoot)) {
+			return nil, fmt.Errorf("Invalid MuSig2 partial signature of key #%v", i)
+		}
+	}
+	sig := musig2.CombineSigs(sigs[0].R, sigs, musig2.WithTaprootTweakedCombine(sigHash, vault.pubKeys, vault.merkleRoot, false))
+	if !sig.Verify(sigHash[:], vault.aggKey.FinalKey) {
+		return nil, fmt.Errorf("Invalid MuSig2 signature for taproot input %v", idx)
+	}
+	return wire.TxWitness{sig.Serialize()}, nil
+}
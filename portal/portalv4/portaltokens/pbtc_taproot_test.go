@@ -0,0 +1,323 @@
+package portaltokens
+
+import (
+	"encoding/hex"
+	"testing"
+
+	btcecv2 "github.com/btcsuite/btcd/btcec/v2"
+	"github.com/btcsuite/btcd/btcec/v2/schnorr"
+	"github.com/btcsuite/btcd/btcec/v2/schnorr/musig2"
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/incognitochain/incognito-chain/dataaccessobject/statedb"
+	btcrelaying "github.com/incognitochain/incognito-chain/relaying/btc"
+)
+
+func newTaprootTestProcessor() *PortalBTCTokenProcessor {
+	return &PortalBTCTokenProcessor{
+		PortalToken: &PortalToken{
+			ChainID:             "Bitcoin-Testnet",
+			MinTokenAmount:      10,
+			MultipleTokenAmount: 10,
+			ExternalInputSize:   130,
+			ExternalOutputSize:  43,
+			ExternalTxMaxSize:   5120,
+		},
+		ChainParam:    &chaincfg.TestNet3Params,
+		PortalTokenID: "4584d5e9b2fc0337dfb17f4b5bb025e5b82c38cfa4f54e8a3d4fcdd03954ff82",
+	}
+}
+
+func TestTaprootVault(t *testing.T) {
+	p := newTaprootTestProcessor()
+	numSigsRequired := 3
+	masterPubKeys := [][]byte{}
+	for _, seed := range ltcTestSeeds {
+		masterPubKeys = append(masterPubKeys, p.generatePublicKeyFromSeed(seed))
+	}
+
+	// deposit addresses
+	depositAddresses, err := p.GenerateOTDepositAddresses(masterPubKeys, numSigsRequired, ltcTestIncAddress)
+	if err != nil || len(depositAddresses) != 1 {
+		t.Fatalf("Expected only P2WSH deposit address without taproot vault, got %v (err %v)", depositAddresses, err)
+	}
+	p.TaprootVault = true
+	depositAddresses, err = p.GenerateOTDepositAddresses(masterPubKeys, numSigsRequired, ltcTestIncAddress)
+	if err != nil || len(depositAddresses) != 2 {
+		t.Fatalf("Expected P2WSH and taproot deposit addresses, got %v (err %v)", depositAddresses, err)
+	}
+	multisigAddress, taprootAddress := depositAddresses[0], depositAddresses[1]
+	if btcrelaying.IsTaprootAddress(multisigAddress, p.ChainParam) || !btcrelaying.IsTaprootAddress(taprootAddress, p.ChainParam) {
+		t.Fatalf("Wrong deposit addresses %v", depositAddresses)
+	}
+
+	// the output key of the taproot address is the aggregated key of validators tweaked with the tapscript
+	tapscript, controlBlock, addr, err := p.GenerateOTTaprootAddress(masterPubKeys, numSigsRequired, ltcTestIncAddress)
+	if err != nil || addr != taprootAddress {
+		t.Fatalf("Wrong taproot address %v (err %v)", addr, err)
+	}
+	otPubKeys, _ := p.generateOTPubKeys(masterPubKeys, ltcTestIncAddress)
+	pubKeys := []*btcecv2.PublicKey{}
+	for _, otPubKey := range otPubKeys {
+		pubKey, _ := btcecv2.ParsePubKey(otPubKey)
+		pubKeys = append(pubKeys, pubKey)
+	}
+	internalKey, _, _, err := musig2.AggregateKeys(pubKeys, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tweak := chainhash.TaggedHash([]byte("TapTweak"), schnorr.SerializePubKey(internalKey.FinalKey), tapLeafHash(tapscript)[:])
+	var tweakScalar btcecv2.ModNScalar
+	tweakScalar.SetBytes((*[32]byte)(tweak))
+	var internalPoint, tweakPoint, outputPoint btcecv2.JacobianPoint
+	evenInternalKey, _ := schnorr.ParsePubKey(schnorr.SerializePubKey(internalKey.FinalKey))
+	evenInternalKey.AsJacobian(&internalPoint)
+	btcecv2.ScalarBaseMultNonConst(&tweakScalar, &tweakPoint)
+	btcecv2.AddNonConst(&internalPoint, &tweakPoint, &outputPoint)
+	outputPoint.ToAffine()
+	outputKey := btcecv2.NewPublicKey(&outputPoint.X, &outputPoint.Y)
+	program, _ := btcrelaying.DecodeTaprootAddress(taprootAddress, p.ChainParam)
+	parity := outputKey.SerializeCompressed()[0] - 0x02
+	if hex.EncodeToString(program) != hex.EncodeToString(schnorr.SerializePubKey(outputKey)) ||
+		hex.EncodeToString(controlBlock[1:]) != hex.EncodeToString(schnorr.SerializePubKey(internalKey.FinalKey)) ||
+		controlBlock[0] != tapscriptLeafVersion|parity {
+		t.Fatal("Taproot output key does not commit to the aggregated key and the tapscript")
+	}
+	if len(tapscript) != len(masterPubKeys)*(xOnlyPubKeySize+2)+2 ||
+		tapscript[len(tapscript)-2] != txscript.OP_3 || tapscript[len(tapscript)-1] != txscript.OP_NUMEQUAL {
+		t.Fatalf("Wrong tapscript %x", tapscript)
+	}
+
+	// spend a taproot vault UTXO and a P2WSH vault UTXO in the same unshield tx
+	_, generalAddress, err := p.GenerateOTMultisigAddress(masterPubKeys, numSigsRequired, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, _, generalTaprootAddress, err := p.GenerateOTTaprootAddress(masterPubKeys, numSigsRequired, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	bc := &ltcTestChainRetriever{generalAddress: generalAddress}
+	inputs := []*statedb.UTXO{
+		statedb.NewUTXOWithValue(taprootAddress, "bb6d3db9b0bd3f8e26e1af1c3d3e6cb2b4bd7d2a80d9e57d8a36b9a4e4c7f0a1", 0, 50000000, ltcTestIncAddress),
+		statedb.NewUTXOWithValue(multisigAddress, "3a1f4d1c2b0e9f8d7c6b5a4938271605f4e3d2c1b0a99887766554433221100f", 1, 30000000, ltcTestIncAddress),
+	}
+	outputs := []*OutputTx{
+		{ReceiverAddress: "tb1qy97qqcvavz3g38yge3nsunah5584tndm8mgv5d", Amount: 2e8},
+		{ReceiverAddress: generalTaprootAddress, Amount: 3e8},
+	}
+	rawTxHex, txHash, err := p.CreateRawExternalTx(inputs, outputs, 1e5, bc, 100)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rawTxBytes, _ := hex.DecodeString(rawTxHex)
+	msgTx := decodeLTCTestTx(t, rawTxHex)
+	if len(msgTx.TxOut) != 3 || len(msgTx.TxOut[1].PkScript) != btcrelaying.TaprootProgramSize+2 {
+		t.Fatalf("Wrong outputs of the unshield tx %+v", msgTx.TxOut)
+	}
+
+	// sign by all beacon validators, the signatures of the taproot input are not in order
+	sigs := make([][][]byte, len(inputs))
+	for _, idx := range []int{2, 0, 3, 1} {
+		inputSigs, signedTxHash, err := p.PartSignOnRawExternalTx(ltcTestSeeds[idx], masterPubKeys, numSigsRequired, rawTxBytes, inputs)
+		if err != nil || signedTxHash != txHash || len(inputSigs) != len(inputs) {
+			t.Fatalf("Sign raw tx failed: %v", err)
+		}
+		if len(inputSigs[0]) != schnorr.SignatureSize {
+			t.Fatalf("Expected schnorr signature for the taproot input, got %x", inputSigs[0])
+		}
+		sigs[0] = append(sigs[0], inputSigs[0])
+		if idx < numSigsRequired {
+			sigs[1] = append(sigs[1], inputSigs[1])
+		}
+	}
+	// P2WSH multisig requires the signatures in order of the public keys (signed by validators #2, #0, #1)
+	sigs[1] = [][]byte{sigs[1][1], sigs[1][2], sigs[1][0]}
+
+	// taproot input
+	witness, err := p.GenerateWitness(masterPubKeys, numSigsRequired, msgTx, inputs, 0, sigs[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(witness) != len(masterPubKeys)+2 || hex.EncodeToString(witness[len(witness)-2]) != hex.EncodeToString(tapscript) ||
+		hex.EncodeToString(witness[len(witness)-1]) != hex.EncodeToString(controlBlock) {
+		t.Fatalf("Wrong taproot witness %x", witness)
+	}
+	sigHash, err := p.calcTaprootSigHash(msgTx, inputs, 0, tapscript)
+	if err != nil {
+		t.Fatal(err)
+	}
+	numSigs := 0
+	for i, pubKey := range pubKeys {
+		item := witness[len(pubKeys)-1-i]
+		if len(item) == 0 {
+			continue
+		}
+		sig, err := schnorr.ParseSignature(item)
+		if err != nil || !sig.Verify(sigHash[:], pubKey) {
+			t.Fatalf("Invalid signature of key #%v in the taproot witness", i)
+		}
+		numSigs++
+	}
+	if numSigs != numSigsRequired {
+		t.Fatalf("Expected %v signatures in the taproot witness, got %v", numSigsRequired, numSigs)
+	}
+	_, err = p.GenerateWitness(masterPubKeys, numSigsRequired, msgTx, inputs, 0, sigs[0][:2])
+	if err == nil {
+		t.Fatal("Taproot witness without enough signatures should be rejected")
+	}
+
+	// P2WSH input
+	witness, err = p.GenerateWitness(masterPubKeys, numSigsRequired, msgTx, inputs, 1, sigs[1])
+	if err != nil {
+		t.Fatal(err)
+	}
+	msgTx.TxIn[1].Witness = witness
+	pkScript, err := btcrelaying.PayToAddrStrScript(multisigAddress, p.ChainParam)
+	if err != nil {
+		t.Fatal(err)
+	}
+	vm, err := txscript.NewEngine(pkScript, msgTx, 1, txscript.StandardVerifyFlags, nil,
+		txscript.NewTxSigHashes(msgTx), int64(inputs[1].GetOutputAmount()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := vm.Execute(); err != nil {
+		t.Fatalf("Signed P2WSH input is invalid: %v", err)
+	}
+
+	// the signatures of the taproot input commit to the amounts of all inputs
+	inputs[1] = statedb.NewUTXOWithValue(multisigAddress, inputs[1].GetTxHash(), 1, 30000001, ltcTestIncAddress)
+	_, err = p.GenerateWitness(masterPubKeys, numSigsRequired, msgTx, inputs, 0, sigs[0])
+	if err == nil {
+		t.Fatal("Taproot signatures for other input amounts should be rejected")
+	}
+}
+
+func TestTaprootVaultMuSig2(t *testing.T) {
+	p := newTaprootTestProcessor()
+	p.TaprootVault = true
+	p.ExternalTaprootInputSize = 58
+	numSigsRequired := 3
+	masterPubKeys := [][]byte{}
+	for _, seed := range ltcTestSeeds {
+		masterPubKeys = append(masterPubKeys, p.GenerateMasterPubKey(seed))
+	}
+	multisigScript, generalAddress, err := p.GenerateOTMultisigAddress(masterPubKeys, numSigsRequired, "")
+	if err != nil || len(multisigScript) == 0 {
+		t.Fatal(err)
+	}
+	_, _, taprootAddress, err := p.GenerateOTTaprootAddress(masterPubKeys, numSigsRequired, ltcTestIncAddress)
+	if err != nil {
+		t.Fatal(err)
+	}
+	inputs := []*statedb.UTXO{
+		statedb.NewUTXOWithValue(taprootAddress, "bb6d3db9b0bd3f8e26e1af1c3d3e6cb2b4bd7d2a80d9e57d8a36b9a4e4c7f0a1", 0, 50000000, ltcTestIncAddress),
+		statedb.NewUTXOWithValue(generalAddress, "3a1f4d1c2b0e9f8d7c6b5a4938271605f4e3d2c1b0a99887766554433221100f", 1, 30000000, ""),
+	}
+	outputs := []*OutputTx{{ReceiverAddress: "tb1qy97qqcvavz3g38yge3nsunah5584tndm8mgv5d", Amount: 5e8}}
+	if txSize := p.EstimateTxSize(inputs, len(outputs)+1); txSize != 58+130+2*43 {
+		t.Fatalf("Wrong estimated size %v of the tx spending a taproot input by the key path", txSize)
+	}
+	p.ExternalTxMaxSize = 58 + 130 + 2*43
+	if !p.IsAcceptableTxSize(inputs, len(outputs)+1) {
+		t.Fatal("The size limit should use the taproot input size of the key path")
+	}
+	if p.IsAcceptableTxSize(append(inputs, inputs[0]), len(outputs)+1) {
+		t.Fatal("The tx over the size limit should not be accepted")
+	}
+	rawTxHex, _, err := p.CreateRawExternalTx(inputs, outputs, 1e5, &ltcTestChainRetriever{generalAddress: generalAddress}, 100)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rawTxBytes, _ := hex.DecodeString(rawTxHex)
+	msgTx := decodeLTCTestTx(t, rawTxHex)
+
+	// round 1: nonces of all validators in the votes on the block of the unshield tx
+	txInNonces := [][]byte{}
+	for _, seed := range ltcTestSeeds {
+		nonces, err := p.GenerateMuSig2Nonces(seed, masterPubKeys, numSigsRequired, rawTxBytes, inputs)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(nonces) != len(inputs) || len(nonces[0]) != musig2.PubNonceSize || nonces[1] != nil {
+			t.Fatalf("Expected a nonce for the taproot input only, got %x", nonces)
+		}
+		txInNonces = append(txInNonces, nonces[0])
+	}
+
+	// round 2: partial sigs of all validators in the votes on the next block
+	partialSigs := [][]byte{}
+	for _, seed := range ltcTestSeeds {
+		sigs, err := p.PartSignMuSig2(seed, masterPubKeys, numSigsRequired, rawTxBytes, inputs, [][][]byte{txInNonces, nil})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(sigs[0]) != muSig2PartialSigSize || sigs[1] != nil {
+			t.Fatalf("Expected a partial sig for the taproot input only, got %x", sigs)
+		}
+		// the same session is signed again with the same nonces
+		sigsAgain, err := p.PartSignMuSig2(seed, masterPubKeys, numSigsRequired, rawTxBytes, inputs, [][][]byte{txInNonces, nil})
+		if err != nil || hex.EncodeToString(sigsAgain[0]) != hex.EncodeToString(sigs[0]) {
+			t.Fatalf("Signing the session again should return the same partial sig (err %v)", err)
+		}
+		partialSigs = append(partialSigs, sigs[0])
+	}
+
+	// a signed nonce never signs other nonces
+	otherNonces, err := p.GenerateMuSig2Nonces(ltcTestSeeds[1], masterPubKeys, numSigsRequired, rawTxBytes, inputs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	changedNonces := [][]byte{txInNonces[0], otherNonces[0], txInNonces[2], txInNonces[3]}
+	sigs, err := p.PartSignMuSig2(ltcTestSeeds[0], masterPubKeys, numSigsRequired, rawTxBytes, inputs, [][][]byte{changedNonces, nil})
+	if err != nil || sigs[0] != nil {
+		t.Fatalf("Signed nonce should not sign other nonces (err %v)", err)
+	}
+	// sessions that are not started by this process are not signed
+	sigs, err = p.PartSignMuSig2(ltcTestSeeds[2], masterPubKeys, numSigsRequired, rawTxBytes, inputs, [][][]byte{changedNonces[1:2], nil})
+	if err != nil || sigs[0] != nil {
+		t.Fatalf("Incomplete nonces should not be signed (err %v)", err)
+	}
+	_, err = p.PartSignMuSig2([]byte("not a validator"), masterPubKeys, numSigsRequired, rawTxBytes, inputs, [][][]byte{txInNonces, nil})
+	if err == nil {
+		t.Fatal("Seed key out of the vault should be rejected")
+	}
+
+	// the key path witness is one schnorr signature of the output key
+	witness, err := p.CombineMuSig2Sigs(masterPubKeys, numSigsRequired, msgTx, inputs, 0, txInNonces, partialSigs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(witness) != 1 || len(witness[0]) != schnorr.SignatureSize {
+		t.Fatalf("Wrong key path witness %x", witness)
+	}
+	program, _ := btcrelaying.DecodeTaprootAddress(taprootAddress, p.ChainParam)
+	outputKey, err := schnorr.ParsePubKey(program)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sigHash, err := p.calcTaprootSigHash(msgTx, inputs, 0, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sig, err := schnorr.ParseSignature(witness[0])
+	if err != nil || !sig.Verify(sigHash[:], outputKey) {
+		t.Fatal("Key path signature is invalid for the output key")
+	}
+
+	_, err = p.CombineMuSig2Sigs(masterPubKeys, numSigsRequired, msgTx, inputs, 0, txInNonces, partialSigs[:3])
+	if err == nil {
+		t.Fatal("Key path witness without all partial sigs should be rejected")
+	}
+	_, err = p.CombineMuSig2Sigs(masterPubKeys, numSigsRequired, msgTx, inputs, 0, txInNonces,
+		[][]byte{partialSigs[1], partialSigs[0], partialSigs[2], partialSigs[3]})
+	if err == nil {
+		t.Fatal("Partial sigs out of order of the master public keys should be rejected")
+	}
+	_, err = p.CombineMuSig2Sigs(masterPubKeys, numSigsRequired, msgTx, inputs, 1, txInNonces, partialSigs)
+	if err == nil {
+		t.Fatal("Key path witness of a P2WSH input should be rejected")
+	}
+}
@@ -217,11 +217,11 @@ func TestMultiSigAddressDerivation(t *testing.T) {
 		extendedBTCPublicKey := hdkeychain.NewExtendedKey(test.net.HDPublicKeyID[:], BTCPublicKeyMaster, chainCode, parentFP, 0, 0, false)
 
 		// generate child account - it is multisig wallet corresponding user inc address
-		childPub, _ := extendedBTCPublicKey.Child(0)
+		childPub, _ := extendedBTCPublicKey.Derive(0)
 		childPubKeyAddr, _ := childPub.Address(test.net)
 
 		// re-generate private key of child account - used to sign on spent uxto
-		childPrv, _ := extendedBTCPrivateKey.Child(0)
+		childPrv, _ := extendedBTCPrivateKey.Derive(0)
 		childPrvAddrr, _ := childPrv.Address(test.net)
 
 		fmt.Println(childPubKeyAddr.String())
@@ -140,7 +140,7 @@ func (p PortalBTCTokenProcessor) ChooseUTXOsForUnshieldReq(
 			chosenIndex += i
 		}
 
-		isValid := p.IsAcceptableTxSize(len(chosenUTXOs)+1, 2)
+		isValid := p.IsAcceptableTxSize(withUTXO(getUTXOValues(chosenUTXOs), chosenUTXO.value), 2)
 		if !isValid {
 			return []utxoItem{}, []int{}, fmt.Errorf("Number of utxos for unshielding amount %v is exceeded\n",
 				unshieldAmount)
@@ -171,10 +171,11 @@ func (p PortalBTCTokenProcessor) MergeBatches(batchTxs []*BroadcastTx) []*Broadc
 	tmpBatchUnshieldIDs := batchTxs[0].UnshieldIDs
 	for j := 1; j < len(batchTxs); j++ {
 		nextBatch := batchTxs[j]
-		isValid := p.IsAcceptableTxSize(len(tmpBatchUTXOs)+len(nextBatch.UTXOs),
+		mergedUTXOs := append(append([]*statedb.UTXO{}, tmpBatchUTXOs...), nextBatch.UTXOs...)
+		isValid := p.IsAcceptableTxSize(mergedUTXOs,
 			len(tmpBatchUnshieldIDs)+len(nextBatch.UnshieldIDs)+1) // 1 for output change
 		if isValid {
-			tmpBatchUTXOs = append(tmpBatchUTXOs, nextBatch.UTXOs...)
+			tmpBatchUTXOs = mergedUTXOs
 			tmpBatchUnshieldIDs = append(tmpBatchUnshieldIDs, nextBatch.UnshieldIDs...)
 		} else {
 			mergedBatches = append(mergedBatches, &BroadcastTx{
@@ -196,12 +197,35 @@ func (p PortalBTCTokenProcessor) MergeBatches(batchTxs []*BroadcastTx) []*Broadc
 	return mergedBatches
 }
 
-func (p PortalBTCTokenProcessor) EstimateTxSize(numInputs int, numOutputs int) uint {
-	return p.ExternalInputSize*uint(numInputs) + p.ExternalOutputSize*uint(numOutputs)
+// EstimateTxSize estimates the size of the external tx that spends the inputs,
+// the taproot vault inputs are expected to be spent by the key path
+func (p PortalBTCTokenProcessor) EstimateTxSize(inputs []*statedb.UTXO, numOutputs int) uint {
+	txSize := p.ExternalOutputSize * uint(numOutputs)
+	for _, input := range inputs {
+		if p.ExternalTaprootInputSize > 0 && p.isTaprootUTXO(input) {
+			txSize += p.ExternalTaprootInputSize
+		} else {
+			txSize += p.ExternalInputSize
+		}
+	}
+	return txSize
+}
+
+// withUTXO returns a new list of the utxos and the given utxo, the utxos are not changed
+func withUTXO(utxos []*statedb.UTXO, utxo *statedb.UTXO) []*statedb.UTXO {
+	return append(append([]*statedb.UTXO{}, utxos...), utxo)
+}
+
+func getUTXOValues(utxos []utxoItem) []*statedb.UTXO {
+	res := make([]*statedb.UTXO, 0, len(utxos))
+	for _, u := range utxos {
+		res = append(res, u.value)
+	}
+	return res
 }
 
 func (p PortalBTCTokenProcessor) CalculateTinyUTXONumber(batchTx *BroadcastTx, maxUTXOsCanPick int) int {
-	estimatedTxSize := p.EstimateTxSize(len(batchTx.UTXOs), len(batchTx.UnshieldIDs)+1)
+	estimatedTxSize := p.EstimateTxSize(batchTx.UTXOs, len(batchTx.UnshieldIDs)+1)
 	remainTxSize := p.ExternalTxMaxSize - estimatedTxSize
 	maxTinyUTXOs := int(remainTxSize / p.ExternalInputSize)
 	if maxTinyUTXOs > maxUTXOsCanPick {
@@ -266,7 +290,7 @@ func (p PortalBTCTokenProcessor) AppendWaitingUnshieldRequests(
 		nextIndexRemainUnshieldReq := indexRemainUnshieldReq
 		for i := indexRemainUnshieldReq; i < len(remainUnshieldReqs); i++ {
 			rUnshield := remainUnshieldReqs[i].value
-			if p.IsAcceptableTxSize(len(batch.UTXOs), len(batch.UnshieldIDs)+2) &&
+			if p.IsAcceptableTxSize(batch.UTXOs, len(batch.UnshieldIDs)+2) &&
 				rUnshield.GetAmount() <= remainUTXOAmt {
 				batch.UnshieldIDs = append(batch.UnshieldIDs, rUnshield.GetUnshieldID())
 				remainUTXOAmt -= rUnshield.GetAmount()
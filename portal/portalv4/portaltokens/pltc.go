@@ -0,0 +1,45 @@
+package portaltokens
+
+import (
+	"github.com/incognitochain/incognito-chain/dataaccessobject/statedb"
+	"github.com/incognitochain/incognito-chain/metadata"
+)
+
+// PortalLTCTokenProcessor processes pLTC on portal v4.
+// Litecoin uses the same UTXO model, script system and segwit multisig as bitcoin,
+// so it reuses the btc processor with litecoin chain params (address prefixes, HD key ids)
+// and verifies proofs against the litecoin relaying header chain.
+type PortalLTCTokenProcessor struct {
+	PortalBTCTokenProcessor
+}
+
+func (p PortalLTCTokenProcessor) ParseAndVerifyShieldProof(
+	proof string, bc metadata.ChainRetriever, expectedReceivedMultisigAddresses []string, chainCodeSeed string, minShieldAmt uint64,
+) (bool, []*statedb.UTXO, error) {
+	ltcChain := bc.GetLTCHeaderChain()
+	return p.parseAndVerifyProofBTCChain(proof, ltcChain, expectedReceivedMultisigAddresses, chainCodeSeed, minShieldAmt)
+}
+
+func (p PortalLTCTokenProcessor) ParseAndVerifyUnshieldProof(
+	proof string,
+	bc metadata.ChainRetriever,
+	expectedReceivedMultisigAddress string,
+	chainCodeSeed string,
+	expectPaymentInfo []*OutputTx,
+	utxos []*statedb.UTXO,
+) (bool, []*statedb.UTXO, string, uint64, error) {
+	ltcChain := bc.GetLTCHeaderChain()
+	return p.parseAndVerifyUnshieldProofBTCChain(proof, ltcChain, expectedReceivedMultisigAddress, chainCodeSeed, expectPaymentInfo, utxos)
+}
+
+func (p PortalLTCTokenProcessor) IsValidRemoteAddress(address string, bcr metadata.ChainRetriever) (bool, error) {
+	ltcHeaderChain := bcr.GetLTCHeaderChain()
+	if ltcHeaderChain == nil {
+		return false, nil
+	}
+	return ltcHeaderChain.IsBTCAddressValid(address), nil
+}
+
+func (p PortalLTCTokenProcessor) EstimateFallbackFeeRate(bc metadata.ChainRetriever, currentFeeRate uint64) (uint64, error) {
+	return p.estimateFallbackFeeRateFromHeaderChain(bc.GetLTCHeaderChain(), currentFeeRate)
+}
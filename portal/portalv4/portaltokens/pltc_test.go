@@ -0,0 +1,404 @@
+package portaltokens
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"io/ioutil"
+	"math/big"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/btcsuite/btcd/btcec"
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/btcsuite/btcutil"
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/dataaccessobject/statedb"
+	"github.com/incognitochain/incognito-chain/metadata"
+	btcrelaying "github.com/incognitochain/incognito-chain/relaying/btc"
+)
+
+const (
+	ltcTestTokenID     = "2c68fbee1786b83261f13dd68602fbffdb6796f8a6ba5c7cb6f34c972f48d104"
+	ltcTestIncAddress  = "12S5Lrs1XeQLbqN4ySyKtjAjd2d7sBP2tjFijzmp6avrrkQCNFMpkXm3FPzj2Wcu2ZNqJEmh9JriVuRErVwhuQnLmWSaggobEWsBEci"
+	ltcTestUserAddress = "tltc1qy97qqcvavz3g38yge3nsunah5584tndm7n2jyy"
+	// private key of the user sending the shield tx
+	ltcTestUserPrivKey = "5f9e0d8c4b7a6e3d2c1b0a9f8e7d6c5b4a3f2e1d0c9b8a7f6e5d4c3b2a1f0e9d"
+)
+
+var ltcTestSeeds = [][]byte{
+	{0xf1, 0x29, 0xb7, 0xa, 0x46, 0xac, 0x35, 0xc4, 0x17, 0x94, 0x10, 0xf3, 0x52, 0xd7, 0xf5, 0x5c, 0xc5, 0x47, 0xe1, 0xa9, 0x26, 0x1f, 0xe8, 0xed, 0xe7, 0x72, 0x34, 0x4, 0x71, 0xeb, 0xc6, 0x9},
+	{0xca, 0xa8, 0xaa, 0xdf, 0x1e, 0xdb, 0xc5, 0x72, 0x80, 0x8f, 0x8, 0x65, 0x1d, 0x41, 0x85, 0xde, 0xd1, 0x21, 0x5a, 0xd4, 0x7, 0xe6, 0x3c, 0xb4, 0x6f, 0x11, 0xc5, 0x5, 0xc6, 0x16, 0x7e, 0xfe},
+	{0x64, 0x3b, 0x2d, 0xb2, 0x89, 0x5c, 0x53, 0x11, 0x5a, 0xb1, 0x53, 0xd, 0xfd, 0xb3, 0x32, 0xee, 0x1b, 0xe0, 0x7d, 0xcc, 0xd4, 0x3a, 0xd9, 0xf5, 0x62, 0x9b, 0x4c, 0x50, 0x88, 0xa8, 0xad, 0x1a},
+	{0x0, 0xa, 0x43, 0x51, 0xdf, 0x7b, 0x2b, 0x86, 0xc3, 0x40, 0x58, 0xe6, 0x42, 0xa6, 0xc2, 0x5d, 0xb6, 0x6c, 0x30, 0x88, 0x8d, 0xb5, 0x8e, 0xe1, 0x44, 0xce, 0xc0, 0x45, 0xc, 0xf5, 0xa0, 0xeb},
+}
+
+// ltcTestRelayingChain mines litecoin blocks with the lowest difficulty on top of a custom genesis block
+// and relays their headers into a litecoin relaying chain
+type ltcTestRelayingChain struct {
+	t             *testing.T
+	chain         *btcrelaying.BlockChain
+	params        *chaincfg.Params
+	tipBlk        *wire.MsgBlock
+	blockInterval time.Duration
+	cleanUp       func()
+}
+
+func init() {
+	Logger.Init(common.NewBackend(nil).Logger("test", true))
+	btcrelaying.Logger.Init(common.NewBackend(nil).Logger("test", true))
+}
+
+// ltcTestChainRetriever serves the litecoin relaying chain and the general multisig address to the processor
+type ltcTestChainRetriever struct {
+	metadata.ChainRetriever
+	ltcChain       *btcrelaying.BlockChain
+	generalAddress string
+}
+
+func (bc *ltcTestChainRetriever) GetBTCHeaderChain() *btcrelaying.BlockChain {
+	return nil
+}
+
+func (bc *ltcTestChainRetriever) GetLTCHeaderChain() *btcrelaying.BlockChain {
+	return bc.ltcChain
+}
+
+func (bc *ltcTestChainRetriever) GetPortalV4GeneralMultiSigAddress(tokenIDStr string, beaconHeight uint64) string {
+	return bc.generalAddress
+}
+
+func hasValidScryptPoW(header *wire.BlockHeader) bool {
+	powHash, err := btcrelaying.ScryptPoWHash(header)
+	if err != nil {
+		return false
+	}
+	return btcrelaying.HashToBig(powHash).Cmp(btcrelaying.CompactToBig(header.Bits)) <= 0
+}
+
+func newLTCTestRelayingChain(t *testing.T) *ltcTestRelayingChain {
+	params := *btcrelaying.GetLTCTestNet4Params()
+	params.PowLimit = new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 255), big.NewInt(1))
+	params.PowLimitBits = 0x207fffff
+	params.ReduceMinDifficulty = false
+	genesisBlk := &wire.MsgBlock{Header: params.GenesisBlock.Header, Transactions: params.GenesisBlock.Transactions}
+	genesisBlk.Header.Bits = params.PowLimitBits
+	for !hasValidScryptPoW(&genesisBlk.Header) {
+		genesisBlk.Header.Nonce++
+	}
+	genesisHash := genesisBlk.BlockHash()
+	params.GenesisBlock = genesisBlk
+	params.GenesisHash = &genesisHash
+
+	dbPath, err := ioutil.TempDir("", "ltcrelaying")
+	if err != nil {
+		t.Fatal(err)
+	}
+	chain, err := btcrelaying.GetChainV2(dbPath, &params, 0)
+	if err != nil {
+		os.RemoveAll(dbPath)
+		t.Fatal(err)
+	}
+	return &ltcTestRelayingChain{
+		t:             t,
+		chain:         chain,
+		params:        &params,
+		tipBlk:        genesisBlk,
+		blockInterval: params.TargetTimePerBlock,
+		cleanUp: func() {
+			chain.GetDB().Close()
+			os.RemoveAll(dbPath)
+		},
+	}
+}
+
+// mineBlock mines a block including txs on the tip of the chain and relays it
+func (c *ltcTestRelayingChain) mineBlock(txs ...*wire.MsgTx) *wire.MsgBlock {
+	coinbaseTx := wire.NewMsgTx(1)
+	coinbaseTx.AddTxIn(&wire.TxIn{
+		PreviousOutPoint: wire.OutPoint{Hash: chainhash.Hash{}, Index: wire.MaxPrevOutIndex},
+		SignatureScript:  []byte(c.tipBlk.BlockHash().String()),
+		Sequence:         wire.MaxTxInSequenceNum,
+	})
+	coinbaseTx.AddTxOut(wire.NewTxOut(25*1e8, []byte{txscript.OP_TRUE}))
+	msgBlk := &wire.MsgBlock{
+		Header: wire.BlockHeader{
+			Version:   4,
+			PrevBlock: c.tipBlk.BlockHash(),
+			Timestamp: c.tipBlk.Header.Timestamp.Add(c.blockInterval),
+			Bits:      c.params.PowLimitBits,
+		},
+		Transactions: append([]*wire.MsgTx{coinbaseTx}, txs...),
+	}
+	merkles := btcrelaying.BuildMerkleTreeStore(btcutil.NewBlock(msgBlk).Transactions(), false)
+	msgBlk.Header.MerkleRoot = *merkles[len(merkles)-1]
+	for !hasValidScryptPoW(&msgBlk.Header) {
+		msgBlk.Header.Nonce++
+	}
+
+	isMainChain, isOrphan, err := c.chain.ProcessBlockV2(btcutil.NewBlock(msgBlk), btcrelaying.BFNone)
+	if err != nil || !isMainChain || isOrphan {
+		c.t.Fatalf("Relay litecoin block failed: isMainChain %v, isOrphan %v, err %v", isMainChain, isOrphan, err)
+	}
+	c.tipBlk = msgBlk
+	return msgBlk
+}
+
+func (c *ltcTestRelayingChain) mineEmptyBlocks(num int) {
+	for i := 0; i < num; i++ {
+		c.mineBlock()
+	}
+}
+
+func buildLTCTestProof(t *testing.T, msgBlk *wire.MsgBlock, txIndex int) string {
+	proof, err := btcrelaying.BuildBTCProof(msgBlk, txIndex)
+	if err != nil {
+		t.Fatal(err)
+	}
+	proofBytes, err := json.Marshal(proof)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return base64.StdEncoding.EncodeToString(proofBytes)
+}
+
+// buildLTCTestShieldTx build and sign a litecoin tx of the user that spends a P2WPKH output of 1 LTC,
+// sends 0.5 LTC to depositAddress (output 0) and the change back to the user (output 1)
+func buildLTCTestShieldTx(t *testing.T, params *chaincfg.Params, depositAddress string) *wire.MsgTx {
+	privKeyBytes, err := hex.DecodeString(ltcTestUserPrivKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	privKey, pubKey := btcec.PrivKeyFromBytes(btcec.S256(), privKeyBytes)
+	userAddress, err := btcutil.NewAddressWitnessPubKeyHash(btcutil.Hash160(pubKey.SerializeCompressed()), params)
+	if err != nil {
+		t.Fatal(err)
+	}
+	userScript, err := txscript.PayToAddrScript(userAddress)
+	if err != nil {
+		t.Fatal(err)
+	}
+	depositAddr, err := btcutil.DecodeAddress(depositAddress, params)
+	if err != nil {
+		t.Fatal(err)
+	}
+	depositScript, err := txscript.PayToAddrScript(depositAddr)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	prevAmount := int64(100000000)
+	msgTx := wire.NewMsgTx(wire.TxVersion)
+	msgTx.AddTxIn(wire.NewTxIn(wire.NewOutPoint(&chainhash.Hash{0x01}, 1), nil, nil))
+	msgTx.AddTxOut(wire.NewTxOut(50000000, depositScript))
+	msgTx.AddTxOut(wire.NewTxOut(prevAmount-50000000-20000, userScript))
+	sigHashes := txscript.NewTxSigHashes(msgTx)
+	msgTx.TxIn[0].Witness, err = txscript.WitnessSignature(msgTx, sigHashes, 0, prevAmount, userScript, txscript.SigHashAll, privKey, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	vm, err := txscript.NewEngine(userScript, msgTx, 0, txscript.StandardVerifyFlags, nil, sigHashes, prevAmount)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := vm.Execute(); err != nil {
+		t.Fatalf("Invalid signature of shield tx: %v", err)
+	}
+	return msgTx
+}
+
+func decodeLTCTestTx(t *testing.T, txHex string) *wire.MsgTx {
+	txBytes, err := hex.DecodeString(txHex)
+	if err != nil {
+		t.Fatal(err)
+	}
+	msgTx := wire.NewMsgTx(wire.TxVersion)
+	if err := msgTx.Deserialize(bytes.NewReader(txBytes)); err != nil {
+		t.Fatal(err)
+	}
+	return msgTx
+}
+
+func TestPortalLTCShieldAndUnshield(t *testing.T) {
+	p := PortalLTCTokenProcessor{
+		PortalBTCTokenProcessor{
+			PortalToken: &PortalToken{
+				ChainID:             "Litecoin-Testnet",
+				MinTokenAmount:      10,
+				MultipleTokenAmount: 10,
+				ExternalInputSize:   130,
+				ExternalOutputSize:  43,
+				ExternalTxMaxSize:   5120,
+			},
+			ChainParam:             btcrelaying.GetLTCTestNet4Params(),
+			PortalTokenID:          ltcTestTokenID,
+			MasterKeyChainCodeSeed: "pltc",
+		},
+	}
+	numSigsRequired := 3
+	masterPubKeys := [][]byte{}
+	for _, seed := range ltcTestSeeds {
+		masterPubKeys = append(masterPubKeys, p.generatePublicKeyFromSeed(seed))
+	}
+	// the ltc master keys are derived from the btc master keys of the validators
+	btcMasterPubKeys := [][]byte{}
+	for _, seed := range ltcTestSeeds {
+		btcMasterPubKeys = append(btcMasterPubKeys, PortalBTCTokenProcessor{}.generatePublicKeyFromSeed(seed))
+	}
+	derivedPubKeys, err := p.generateOTPubKeys(btcMasterPubKeys, p.MasterKeyChainCodeSeed)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := range masterPubKeys {
+		if bytes.Equal(masterPubKeys[i], btcMasterPubKeys[i]) || !bytes.Equal(masterPubKeys[i], derivedPubKeys[i]) {
+			t.Fatalf("Wrong ltc master public key %#v", masterPubKeys[i])
+		}
+	}
+	_, depositAddress, err := p.GenerateOTMultisigAddress(masterPubKeys, numSigsRequired, ltcTestIncAddress)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, generalAddress, err := p.GenerateOTMultisigAddress(masterPubKeys, numSigsRequired, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if depositAddress != "tltc1qk4utqc8lquajs973usvdz9ygtp3xyv8rm43enmy047c0jnaxdqtq3yhhmd" {
+		t.Fatalf("Wrong deposit address %v", depositAddress)
+	}
+	if generalAddress != "tltc1qyzyu7gunkkt5rkwy2675nw0674j620dx0geh9jk8efyjwl7rlupqg6fcfj" {
+		t.Fatalf("Wrong general multisig address %v", generalAddress)
+	}
+
+	relayingChain := newLTCTestRelayingChain(t)
+	defer relayingChain.cleanUp()
+	beaconHeight := uint64(100)
+	bc := &ltcTestChainRetriever{ltcChain: relayingChain.chain, generalAddress: generalAddress}
+
+	// remote addresses
+	for _, tc := range []struct {
+		address string
+		isValid bool
+	}{
+		{ltcTestUserAddress, true},
+		{depositAddress, true},
+		{"tb1qy97qqcvavz3g38yge3nsunah5584tndmhrr97f", false},
+		{"ltc1qy97qqcvavz3g38yge3nsunah5584tndmxm7pj2", false},
+		{"invalid address", false},
+	} {
+		isValid, err := p.IsValidRemoteAddress(tc.address, bc)
+		if err != nil || isValid != tc.isValid {
+			t.Errorf("Remote address %v: expected valid %v, got %v (err %v)", tc.address, tc.isValid, isValid, err)
+		}
+	}
+
+	// shield
+	shieldTx := buildLTCTestShieldTx(t, p.ChainParam, depositAddress)
+	shieldBlk := relayingChain.mineBlock(shieldTx)
+	shieldProof := buildLTCTestProof(t, shieldBlk, 1)
+	minShieldAmt := uint64(1e5)
+
+	isValid, _, err := p.ParseAndVerifyShieldProof(shieldProof, bc, []string{depositAddress}, ltcTestIncAddress, minShieldAmt)
+	if isValid || err == nil {
+		t.Fatal("Shield proof without enough confirmations should be rejected")
+	}
+	relayingChain.mineEmptyBlocks(5)
+	isValid, shieldUTXOs, err := p.ParseAndVerifyShieldProof(shieldProof, bc, []string{depositAddress}, ltcTestIncAddress, minShieldAmt)
+	if !isValid || err != nil {
+		t.Fatalf("Verify shield proof failed: %v", err)
+	}
+	if len(shieldUTXOs) != 1 || shieldUTXOs[0].GetOutputAmount() != 50000000 || shieldUTXOs[0].GetOutputIndex() != 0 ||
+		shieldUTXOs[0].GetTxHash() != shieldTx.TxHash().String() || shieldUTXOs[0].GetChainCodeSeed() != ltcTestIncAddress {
+		t.Fatalf("Wrong shield UTXOs %+v", shieldUTXOs)
+	}
+	isValid, _, err = p.ParseAndVerifyShieldProof(shieldProof, bc, []string{depositAddress}, ltcTestIncAddress, uint64(1e10))
+	if isValid || err == nil {
+		t.Fatal("Shield proof with the amount less than the minimum should be rejected")
+	}
+	isValid, _, err = p.PortalBTCTokenProcessor.ParseAndVerifyShieldProof(shieldProof, bc, []string{depositAddress}, ltcTestIncAddress, minShieldAmt)
+	if isValid || err == nil {
+		t.Fatal("Litecoin shield proof should not be verified by the btc relaying chain")
+	}
+
+	// batch the waiting unshield request
+	utxos := map[string]*statedb.UTXO{}
+	for _, utxo := range shieldUTXOs {
+		key := statedb.GenerateUTXOObjectKey(ltcTestTokenID, depositAddress, utxo.GetTxHash(), utxo.GetOutputIndex()).String()
+		utxos[key] = utxo
+	}
+	unshieldAmt := uint64(2e8)
+	waitingUnshieldReqs := map[string]*statedb.WaitingUnshieldRequest{}
+	insertUnshieldIDIntoStateDB(waitingUnshieldReqs, ltcTestTokenID, ltcTestUserAddress, "unshield-ltc-1", unshieldAmt, beaconHeight)
+	batchTxs, err := p.MatchUTXOsAndUnshieldIDsNew(utxos, waitingUnshieldReqs, 1e6, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(batchTxs) != 1 || len(batchTxs[0].UTXOs) != 1 || len(batchTxs[0].UnshieldIDs) != 1 || batchTxs[0].UnshieldIDs[0] != "unshield-ltc-1" {
+		printBroadcastTxs(t, batchTxs)
+		t.Fatal("Wrong batch txs")
+	}
+
+	feePerOutput := uint64(1e5)
+	outputs := []*OutputTx{{ReceiverAddress: ltcTestUserAddress, Amount: unshieldAmt}}
+	rawTxHex, txHash, err := p.CreateRawExternalTx(batchTxs[0].UTXOs, outputs, feePerOutput, bc, beaconHeight)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// sign by the beacon validators
+	rawTxBytes, _ := hex.DecodeString(rawTxHex)
+	witness := wire.TxWitness{[]byte{}}
+	for _, seed := range ltcTestSeeds[:numSigsRequired] {
+		sigs, signedTxHash, err := p.PartSignOnRawExternalTx(seed, masterPubKeys, numSigsRequired, rawTxBytes, batchTxs[0].UTXOs)
+		if err != nil || signedTxHash != txHash || len(sigs) != 1 {
+			t.Fatalf("Sign raw tx failed: %v", err)
+		}
+		witness = append(witness, sigs[0])
+	}
+	multisigScript, _, err := p.GenerateOTMultisigAddress(masterPubKeys, numSigsRequired, batchTxs[0].UTXOs[0].GetChainCodeSeed())
+	if err != nil {
+		t.Fatal(err)
+	}
+	unshieldTx := decodeLTCTestTx(t, rawTxHex)
+	unshieldTx.TxIn[0].Witness = append(witness, multisigScript)
+	vm, err := txscript.NewEngine(shieldTx.TxOut[0].PkScript, unshieldTx, 0, txscript.StandardVerifyFlags, nil,
+		txscript.NewTxSigHashes(unshieldTx), shieldTx.TxOut[0].Value)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := vm.Execute(); err != nil {
+		t.Fatalf("Signed unshield tx is invalid: %v", err)
+	}
+
+	// submit the unshield proof
+	unshieldBlk := relayingChain.mineBlock(unshieldTx)
+	relayingChain.mineEmptyBlocks(5)
+	unshieldProof := buildLTCTestProof(t, unshieldBlk, 1)
+	isValid, changeUTXOs, externalTxID, externalFee, err := p.ParseAndVerifyUnshieldProof(
+		unshieldProof, bc, generalAddress, "", outputs, batchTxs[0].UTXOs)
+	if !isValid || err != nil {
+		t.Fatalf("Verify unshield proof failed: %v", err)
+	}
+	if externalTxID != txHash || externalFee != feePerOutput {
+		t.Errorf("Wrong external tx id %v or fee %v", externalTxID, externalFee)
+	}
+	if len(changeUTXOs) != 1 || changeUTXOs[0].GetOutputAmount() != 30000000 || changeUTXOs[0].GetOutputIndex() != 1 ||
+		!strings.EqualFold(changeUTXOs[0].GetWalletAddress(), generalAddress) {
+		t.Errorf("Wrong change UTXOs %+v", changeUTXOs)
+	}
+	if uint64(unshieldTx.TxOut[0].Value) != p.ConvertIncToExternalAmount(unshieldAmt-feePerOutput) {
+		t.Errorf("Wrong unshield output amount %v", unshieldTx.TxOut[0].Value)
+	}
+
+	// the proof does not match the other utxos
+	otherUTXOs := []*statedb.UTXO{statedb.NewUTXOWithValue(depositAddress, shieldTx.TxHash().String(), 1, 50000000, ltcTestIncAddress)}
+	isValid, _, _, _, err = p.ParseAndVerifyUnshieldProof(unshieldProof, bc, generalAddress, "", outputs, otherUTXOs)
+	if isValid || err == nil {
+		t.Error("Unshield proof spending other utxos should be rejected")
+	}
+}
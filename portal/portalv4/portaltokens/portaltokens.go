@@ -1,6 +1,7 @@
 package portaltokens
 
 import (
+	"github.com/btcsuite/btcd/wire"
 	"github.com/incognitochain/incognito-chain/dataaccessobject/statedb"
 	"github.com/incognitochain/incognito-chain/metadata"
 )
@@ -16,7 +17,7 @@ type PortalTokenProcessor interface {
 
 	GetTxHashFromProof(proof string) (string, error)
 	ParseAndVerifyShieldProof(
-		proof string, bc metadata.ChainRetriever, expectedReceivedMultisigAddress string, chainCodeSeed string, minShieldAmt uint64) (bool, []*statedb.UTXO, error)
+		proof string, bc metadata.ChainRetriever, expectedReceivedMultisigAddresses []string, chainCodeSeed string, minShieldAmt uint64) (bool, []*statedb.UTXO, error)
 	ParseAndVerifyUnshieldProof(
 		proof string, bc metadata.ChainRetriever, expectedReceivedMultisigAddress string, chainCodeSeed string, expectPaymentInfo []*OutputTx, utxos []*statedb.UTXO) (bool, []*statedb.UTXO, string, uint64, error)
 	MatchUTXOsAndUnshieldIDs(utxos map[string]*statedb.UTXO, waitingUnshieldReqs map[string]*statedb.WaitingUnshieldRequest, tinyAmount uint64) []*BroadcastTx
@@ -30,7 +31,17 @@ type PortalTokenProcessor interface {
 		bc metadata.ChainRetriever, beaconHeight uint64) (string, string, error)
 	PartSignOnRawExternalTx(seedKey []byte, masterPubKeys [][]byte, numSigsRequired int, rawTxBytes []byte, inputs []*statedb.UTXO) ([][]byte, string, error)
 	GenerateOTMultisigAddress(masterPubKeys [][]byte, numSigsRequired int, chainCodeSeed string) ([]byte, string, error)
+	GenerateOTDepositAddresses(masterPubKeys [][]byte, numSigsRequired int, chainCodeSeed string) ([]string, error)
+	GenerateWitness(masterPubKeys [][]byte, numSigsRequired int, msgTx *wire.MsgTx, inputs []*statedb.UTXO, idx int, sigs [][]byte) (wire.TxWitness, error)
+	GenerateMasterPubKey(seedKey []byte) []byte
+	GenerateMuSig2Nonces(seedKey []byte, masterPubKeys [][]byte, numSigsRequired int, rawTxBytes []byte, inputs []*statedb.UTXO) ([][]byte, error)
+	PartSignMuSig2(seedKey []byte, masterPubKeys [][]byte, numSigsRequired int, rawTxBytes []byte, inputs []*statedb.UTXO, nonces [][][]byte) ([][]byte, error)
+	CombineMuSig2Sigs(masterPubKeys [][]byte, numSigsRequired int, msgTx *wire.MsgTx, inputs []*statedb.UTXO, idx int, nonces [][]byte, partialSigs [][]byte) (wire.TxWitness, error)
 	GetPortalTokenID() string
+
+	EstimateTxSize(inputs []*statedb.UTXO, numOutputs int) uint
+	GetFeeOracle() FeeOracle
+	EstimateFallbackFeeRate(bc metadata.ChainRetriever, currentFeeRate uint64) (uint64, error)
 }
 
 type PortalToken struct {
@@ -40,6 +51,10 @@ type PortalToken struct {
 	ExternalInputSize   uint   // they are used to estimate size of external txs (in byte)
 	ExternalOutputSize  uint
 	ExternalTxMaxSize   uint
+
+	// ExternalTaprootInputSize is the size of a taproot vault input spent by the key path,
+	// the inputs of the other vaults use ExternalInputSize
+	ExternalTaprootInputSize uint
 }
 
 type BroadcastTx struct {
@@ -17,6 +17,8 @@ const (
 	RequestShardBlockByHeightTopic  = "requestshardblockbyheighttopic"
 	RequestBeaconBlockByHeightTopic = "requestbeaconblockbyheighttopic"
 	RequestBeaconBlockByHashTopic   = "requestbeaconblockbyhashtopic"
+	FeatureThresholdTopic           = "featurethresholdtopic"
+	ViewEventTopic                  = "vieweventtopic"
 	TestTopic                       = "testtopic"
 )
 
@@ -35,6 +37,8 @@ var Topics = []string{
 	RequestShardBlockByHeightTopic,
 	RequestShardBlockByHashTopic,
 	ShardBeststateTopic,
+	FeatureThresholdTopic,
+	ViewEventTopic,
 }
 
 type NodeRole struct {
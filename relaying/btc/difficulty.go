@@ -257,9 +257,15 @@ func (b *BlockChain) calcNextRequiredDifficulty(lastNode *blockNode, header *wir
 
 	// Get the block node at the previous retarget (targetTimespan days
 	// worth of blocks).
-	firstNode := lastNode.RelativeAncestor(b.blocksPerRetarget - 1)
+	// Litecoin goes back the full period except for the first retarget
+	// to fix the time warp bug of bitcoin.
+	blocksToGoBack := b.blocksPerRetarget - 1
+	if IsLitecoinNet(b.chainParams) && lastNode.height+1 != b.blocksPerRetarget {
+		blocksToGoBack = b.blocksPerRetarget
+	}
+	firstNode := lastNode.RelativeAncestor(blocksToGoBack)
 	if firstNode == nil {
-		if b.genesisBlkHeight > lastNode.height+1-b.blocksPerRetarget {
+		if b.genesisBlkHeight > lastNode.height+1-blocksToGoBack-1 {
 			return header.Bits, nil
 		}
 		return 0, AssertError("unable to obtain previous retarget block")
@@ -0,0 +1,191 @@
+package btcrelaying
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/wire"
+	"golang.org/x/crypto/scrypt"
+)
+
+// Litecoin networks share the block and transaction formats of bitcoin,
+// so the relaying chain can keep track of them with litecoin chain params.
+// The differences are handled here: block hashes are still double sha256
+// but the proof of work is checked against the scrypt hash of the header.
+const (
+	LTCMainNet  wire.BitcoinNet = 0xdbb6c0fb
+	LTCTestNet4 wire.BitcoinNet = 0xf1c8d2fd
+)
+
+var (
+	ltcPowLimit = new(big.Int).Sub(new(big.Int).Lsh(bigOne, 236), bigOne)
+
+	ltcGenesisCoinbaseScript, _ = hex.DecodeString("04ffff001d0104404e592054696d65732030352f4f63742f32303131205374657665204a6f62732c204170706c65e280997320566973696f6e6172792c2044696573206174203536")
+	ltcGenesisOutputScript, _   = hex.DecodeString("41040184710fa689ad5023690c80f3a49c8f13f8d45b8c857fbcbc8bc4a8e4d3eb4b10f4d4604fa08dce601aaf0f470216fe1b51850b4acf21b179c45070ac7b03a9ac")
+)
+
+// LTCMainNetParams and LTCTestNet4Params are the litecoin chain params
+// with the genesis block of the corresponding network
+var (
+	LTCMainNetParams  = newLTCParams(chaincfg.MainNetParams, "mainnet", LTCMainNet, 1317972665, 2084524493)
+	LTCTestNet4Params = newLTCParams(chaincfg.TestNet3Params, "testnet4", LTCTestNet4, 1486949366, 293345)
+)
+
+func init() {
+	for _, params := range []*chaincfg.Params{&LTCMainNetParams, &LTCTestNet4Params} {
+		if err := chaincfg.Register(params); err != nil {
+			panic(fmt.Sprintf("failed to register litecoin network %v: %v", params.Name, err))
+		}
+	}
+}
+
+func newLTCGenesisBlock(timestamp int64, nonce uint32) *wire.MsgBlock {
+	coinbaseTx := wire.NewMsgTx(1)
+	coinbaseTx.AddTxIn(&wire.TxIn{
+		PreviousOutPoint: wire.OutPoint{Hash: chainhash.Hash{}, Index: wire.MaxPrevOutIndex},
+		SignatureScript:  ltcGenesisCoinbaseScript,
+		Sequence:         wire.MaxTxInSequenceNum,
+	})
+	coinbaseTx.AddTxOut(&wire.TxOut{
+		Value:    50 * 1e8,
+		PkScript: ltcGenesisOutputScript,
+	})
+	return &wire.MsgBlock{
+		Header: wire.BlockHeader{
+			Version:    1,
+			PrevBlock:  chainhash.Hash{},
+			MerkleRoot: coinbaseTx.TxHash(),
+			Timestamp:  time.Unix(timestamp, 0),
+			Bits:       0x1e0ffff0,
+			Nonce:      nonce,
+		},
+		Transactions: []*wire.MsgTx{coinbaseTx},
+	}
+}
+
+func newLTCParams(
+	base chaincfg.Params,
+	network string,
+	net wire.BitcoinNet,
+	genesisTime int64,
+	genesisNonce uint32,
+) chaincfg.Params {
+	params := base
+	params.Name = "litecoin-" + network
+	params.Net = net
+	params.DNSSeeds = nil
+	params.Checkpoints = nil
+	params.GenesisBlock = newLTCGenesisBlock(genesisTime, genesisNonce)
+	genesisHash := params.GenesisBlock.BlockHash()
+	params.GenesisHash = &genesisHash
+	params.TargetTimespan = time.Hour * 84 // 3.5 days
+	params.TargetTimePerBlock = time.Second * 150
+	params.RetargetAdjustmentFactor = 4
+
+	params.PowLimit = ltcPowLimit
+	params.PowLimitBits = 0x1e0fffff
+
+	switch net {
+	case LTCMainNet:
+		params.DefaultPort = "9333"
+		params.BIP0034Height = 710000
+		params.BIP0065Height = 918684
+		params.BIP0066Height = 811879
+		params.ReduceMinDifficulty = false
+		params.MinDiffReductionTime = 0
+		params.Bech32HRPSegwit = "ltc"
+		params.PubKeyHashAddrID = 0x30
+		params.ScriptHashAddrID = 0x32
+		params.PrivateKeyID = 0xb0
+		params.HDPrivateKeyID = [4]byte{0x04, 0x88, 0xad, 0xe4} // xprv
+		params.HDPublicKeyID = [4]byte{0x04, 0x88, 0xb2, 0x1e}  // xpub
+		params.HDCoinType = 2
+	default:
+		params.DefaultPort = "19335"
+		params.BIP0034Height = 76
+		params.BIP0065Height = 76
+		params.BIP0066Height = 76
+		params.ReduceMinDifficulty = true
+		params.MinDiffReductionTime = params.TargetTimePerBlock * 2
+		params.Bech32HRPSegwit = "tltc"
+		params.PubKeyHashAddrID = 0x6f
+		params.ScriptHashAddrID = 0x3a
+		params.PrivateKeyID = 0xef
+		params.HDPrivateKeyID = [4]byte{0x04, 0x35, 0x83, 0x94} // tprv
+		params.HDPublicKeyID = [4]byte{0x04, 0x35, 0x87, 0xcf}  // tpub
+		params.HDCoinType = 1
+	}
+	return params
+}
+
+// IsLitecoinNet returns true if the chain params belong to a litecoin network
+func IsLitecoinNet(params *chaincfg.Params) bool {
+	if params == nil {
+		return false
+	}
+	switch params.Net {
+	case LTCMainNet, LTCTestNet4:
+		return true
+	default:
+		return false
+	}
+}
+
+// ScryptPoWHash returns the scrypt hash of the block header that litecoin uses for proof of work
+func ScryptPoWHash(header *wire.BlockHeader) (*chainhash.Hash, error) {
+	var buf bytes.Buffer
+	err := header.Serialize(&buf)
+	if err != nil {
+		return nil, err
+	}
+	headerBytes := buf.Bytes()
+	powHash, err := scrypt.Key(headerBytes, headerBytes, 1024, 1, 1, chainhash.HashSize)
+	if err != nil {
+		return nil, err
+	}
+	return chainhash.NewHash(powHash)
+}
+
+// checkScryptProofOfWork ensures the scrypt hash of the header is not higher than the claimed target.
+// The range of the target is still checked by checkProofOfWork.
+func checkScryptProofOfWork(header *wire.BlockHeader) error {
+	powHash, err := ScryptPoWHash(header)
+	if err != nil {
+		return err
+	}
+	target := CompactToBig(header.Bits)
+	hashNum := HashToBig(powHash)
+	if hashNum.Cmp(target) > 0 {
+		str := fmt.Sprintf("block scrypt hash of %064x is higher than "+
+			"expected max of %064x", hashNum, target)
+		return ruleError(ErrHighHash, str)
+	}
+	return nil
+}
+
+func getHardcodedLTCMainNetGenesisBlock() (*wire.MsgBlock, *chainhash.Hash) {
+	// Block 0 from litecoin mainnet
+	genesisHash, _ := chainhash.NewHashFromStr("12a765e31ffd4059bada1e25190f6e98c99d9714d334efa41a195a7e7e04bfe2")
+	return LTCMainNetParams.GenesisBlock, genesisHash
+}
+
+func getHardcodedLTCTestNet4GenesisBlock() (*wire.MsgBlock, *chainhash.Hash) {
+	// Block 0 from litecoin testnet4
+	genesisHash, _ := chainhash.NewHashFromStr("4966625a4b2851d9fdee139e56211a0d88575f59ed816ff5e6a63deb4e3e29a0")
+	return LTCTestNet4Params.GenesisBlock, genesisHash
+}
+
+func GetLTCMainNetParams() *chaincfg.Params {
+	genesisBlock, genesisHash := getHardcodedLTCMainNetGenesisBlock()
+	return putGenesisBlockIntoChainParams(genesisHash, genesisBlock, LTCMainNetParams)
+}
+
+func GetLTCTestNet4Params() *chaincfg.Params {
+	genesisBlock, genesisHash := getHardcodedLTCTestNet4GenesisBlock()
+	return putGenesisBlockIntoChainParams(genesisHash, genesisBlock, LTCTestNet4Params)
+}
@@ -0,0 +1,124 @@
+package btcrelaying
+
+import (
+	"io/ioutil"
+	"math/big"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/btcsuite/btcutil"
+)
+
+// getLTCTestParams returns the testnet4 params with the lowest difficulty
+// so that blocks can be mined quickly in tests
+func getLTCTestParams() *chaincfg.Params {
+	params := *GetLTCTestNet4Params()
+	params.PowLimit = new(big.Int).Sub(new(big.Int).Lsh(bigOne, 255), bigOne)
+	params.PowLimitBits = 0x207fffff
+	params.ReduceMinDifficulty = false
+	genesisBlk := newLTCGenesisBlock(params.GenesisBlock.Header.Timestamp.Unix(), 0)
+	genesisBlk.Header.Bits = params.PowLimitBits
+	for checkScryptProofOfWork(&genesisBlk.Header) != nil {
+		genesisBlk.Header.Nonce++
+	}
+	genesisHash := genesisBlk.BlockHash()
+	params.GenesisBlock = genesisBlk
+	params.GenesisHash = &genesisHash
+	return &params
+}
+
+func mineLTCTestBlock(t *testing.T, prevBlk *wire.MsgBlock, extraTxs []*wire.MsgTx, validPoW bool) *wire.MsgBlock {
+	coinbaseTx := wire.NewMsgTx(1)
+	coinbaseTx.AddTxIn(&wire.TxIn{
+		PreviousOutPoint: wire.OutPoint{Hash: chainhash.Hash{}, Index: wire.MaxPrevOutIndex},
+		SignatureScript:  []byte(prevBlk.BlockHash().String()),
+		Sequence:         wire.MaxTxInSequenceNum,
+	})
+	coinbaseTx.AddTxOut(wire.NewTxOut(50*1e8, []byte{0x51}))
+	msgBlk := &wire.MsgBlock{
+		Header: wire.BlockHeader{
+			Version:   4,
+			PrevBlock: prevBlk.BlockHash(),
+			Timestamp: prevBlk.Header.Timestamp.Add(150 * time.Second),
+			Bits:      0x207fffff,
+		},
+		Transactions: append([]*wire.MsgTx{coinbaseTx}, extraTxs...),
+	}
+	merkles := BuildMerkleTreeStore(btcutil.NewBlock(msgBlk).Transactions(), false)
+	msgBlk.Header.MerkleRoot = *merkles[len(merkles)-1]
+	for {
+		if (checkScryptProofOfWork(&msgBlk.Header) == nil) == validPoW {
+			return msgBlk
+		}
+		msgBlk.Header.Nonce++
+	}
+}
+
+func TestLTCGenesisBlocks(t *testing.T) {
+	for _, params := range []struct {
+		name         string
+		genesis      *wire.MsgBlock
+		expectedHash string
+	}{
+		{"mainnet", GetLTCMainNetParams().GenesisBlock, "12a765e31ffd4059bada1e25190f6e98c99d9714d334efa41a195a7e7e04bfe2"},
+		{"testnet4", GetLTCTestNet4Params().GenesisBlock, "4966625a4b2851d9fdee139e56211a0d88575f59ed816ff5e6a63deb4e3e29a0"},
+	} {
+		if params.genesis.BlockHash().String() != params.expectedHash {
+			t.Errorf("%v: wrong genesis hash %v", params.name, params.genesis.BlockHash())
+		}
+		if params.genesis.Header.MerkleRoot.String() != "97ddfbbae6be97fd6cdf3e7ca13232a3afff2353e29badfab7f73011edd4ced9" {
+			t.Errorf("%v: wrong genesis merkle root %v", params.name, params.genesis.Header.MerkleRoot)
+		}
+		if err := checkScryptProofOfWork(&params.genesis.Header); err != nil {
+			t.Errorf("%v: genesis scrypt pow should be valid: %v", params.name, err)
+		}
+	}
+
+	if !IsLitecoinNet(GetLTCMainNetParams()) || !IsLitecoinNet(GetLTCTestNet4Params()) || IsLitecoinNet(GetTestNet3Params()) {
+		t.Error("wrong litecoin network check")
+	}
+
+	invalidHeader := GetLTCMainNetParams().GenesisBlock.Header
+	invalidHeader.Nonce++
+	if err := checkScryptProofOfWork(&invalidHeader); err == nil {
+		t.Error("scrypt pow of the modified genesis header should be invalid")
+	}
+}
+
+func TestProcessLTCBlocks(t *testing.T) {
+	dbPath, err := ioutil.TempDir("", "ltcrelaying")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dbPath)
+	params := getLTCTestParams()
+	chain, err := GetChainV2(dbPath, params, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer chain.db.Close()
+
+	// a block with a valid sha256d hash but an invalid scrypt hash is rejected
+	prevBlk := params.GenesisBlock
+	invalidBlk := mineLTCTestBlock(t, prevBlk, nil, false)
+	_, _, err = chain.ProcessBlockV2(btcutil.NewBlock(invalidBlk), BFNone)
+	if ruleErr, ok := err.(RuleError); !ok || ruleErr.ErrorCode != ErrHighHash {
+		t.Fatalf("expected high hash error, got %v", err)
+	}
+
+	for i := 0; i < 3; i++ {
+		blk := mineLTCTestBlock(t, prevBlk, nil, true)
+		isMainChain, isOrphan, err := chain.ProcessBlockV2(btcutil.NewBlock(blk), BFNone)
+		if err != nil || !isMainChain || isOrphan {
+			t.Fatalf("process block %v failed: %v %v %v", i+1, isMainChain, isOrphan, err)
+		}
+		prevBlk = blk
+	}
+	if chain.BestSnapshot().Height != 3 || chain.BestSnapshot().Hash != prevBlk.BlockHash() {
+		t.Errorf("wrong best state %+v", chain.BestSnapshot())
+	}
+}
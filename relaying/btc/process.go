@@ -150,8 +150,21 @@ func (b *BlockChain) ProcessBlockV2(block *btcutil.Block, flags BehaviorFlags) (
 	// 	return false, false, ruleError(ErrDuplicateBlock, str)
 	// }
 
+	// Litecoin blocks are hashed with double sha256 but their proof of work
+	// is the scrypt hash of the header, so check it here instead.
+	sanityFlags := flags
+	if IsLitecoinNet(b.chainParams) {
+		if flags&BFNoPoWCheck != BFNoPoWCheck {
+			err = checkScryptProofOfWork(&block.MsgBlock().Header)
+			if err != nil {
+				return false, false, err
+			}
+		}
+		sanityFlags |= BFNoPoWCheck
+	}
+
 	// Perform preliminary sanity checks on the block and its transactions.
-	err = checkBlockSanityV2(block, b.chainParams.PowLimit, b.timeSource, flags)
+	err = checkBlockSanityV2(block, b.chainParams.PowLimit, b.timeSource, sanityFlags)
 	if err != nil {
 		return false, false, err
 	}
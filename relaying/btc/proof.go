@@ -164,6 +164,25 @@ func buildMerkleProof(txHashes []*chainhash.Hash, targetedTxHash *chainhash.Hash
 	return merkleProofs
 }
 
+// BuildBTCProof builds the proof of the tx at txIndex in the block
+func BuildBTCProof(msgBlk *wire.MsgBlock, txIndex int) (*BTCProof, error) {
+	if msgBlk == nil || txIndex < 0 || txIndex >= len(msgBlk.Transactions) {
+		return nil, errors.New("BuildBTCProof tx index is out of range")
+	}
+	txHashes := make([]*chainhash.Hash, len(msgBlk.Transactions))
+	for i, tx := range msgBlk.Transactions {
+		txHash := tx.TxHash()
+		txHashes[i] = &txHash
+	}
+	blockHash := msgBlk.BlockHash()
+	merkleProofs := buildMerkleProof(txHashes, txHashes[txIndex])
+	return &BTCProof{
+		MerkleProofs: merkleProofs,
+		BTCTx:        msgBlk.Transactions[txIndex],
+		BlockHash:    &blockHash,
+	}, nil
+}
+
 // verify verifies that a tx is present in a block or not
 func verify(
 	merkleRoot *chainhash.Hash,
@@ -237,6 +256,9 @@ func ExtractAttachedMsgFromTx(msgTx *wire.MsgTx) (string, error) {
 // ExtractPaymentAddrStrFromPkScript extracts payment address string from pkscript
 func (b *BlockChain) ExtractPaymentAddrStrFromPkScript(pkScript []byte) (string, error) {
 	chainParams := b.GetChainParams()
+	if program, ok := extractTaprootProgram(pkScript); ok {
+		return EncodeTaprootAddress(program, chainParams)
+	}
 	_, addrs, _, err := txscript.ExtractPkScriptAddrs(pkScript, chainParams)
 	if err != nil {
 		return "", err
@@ -250,6 +272,9 @@ func (b *BlockChain) ExtractPaymentAddrStrFromPkScript(pkScript []byte) (string,
 // IsBTCAddressValid checks whether the passed btc address string is valid or not
 func (btcChain *BlockChain) IsBTCAddressValid(addrStr string) bool {
 	params := btcChain.GetChainParams()
+	if IsTaprootAddress(addrStr, params) {
+		return true
+	}
 	btcAddress, err := btcutil.DecodeAddress(addrStr, params)
 	if err != nil {
 		Logger.log.Warnf("IsBTCAddressValid - Failed to decode btc address with error: %v\n", err)
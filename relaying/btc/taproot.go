@@ -0,0 +1,162 @@
+package btcrelaying
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/btcsuite/btcutil"
+	"github.com/btcsuite/btcutil/bech32"
+)
+
+// Taproot (segwit v1) outputs are encoded with bech32m (BIP350) which is not supported by btcutil,
+// so the encoding and decoding of taproot addresses are handled here.
+const (
+	TaprootWitnessVersion = 1
+	TaprootProgramSize    = 32
+
+	bech32mConst      = 0x2bc830a3
+	bech32Charset     = "qpzry9x8gf2tvdw0s3jn54khce6mua7l"
+	bech32MaxLen      = 90
+	bech32ChecksumLen = 6
+)
+
+var bech32Generator = [5]uint32{0x3b6a57b2, 0x26508e6d, 0x1ea119fa, 0x3d4233dd, 0x2a1462b3}
+
+func bech32mPolymod(values []byte) uint32 {
+	chk := uint32(1)
+	for _, v := range values {
+		top := chk >> 25
+		chk = (chk&0x1ffffff)<<5 ^ uint32(v)
+		for i := 0; i < 5; i++ {
+			if (top>>uint(i))&1 == 1 {
+				chk ^= bech32Generator[i]
+			}
+		}
+	}
+	return chk
+}
+
+func bech32mHrpExpand(hrp string) []byte {
+	res := make([]byte, 0, len(hrp)*2+1)
+	for i := 0; i < len(hrp); i++ {
+		res = append(res, hrp[i]>>5)
+	}
+	res = append(res, 0)
+	for i := 0; i < len(hrp); i++ {
+		res = append(res, hrp[i]&31)
+	}
+	return res
+}
+
+func encodeBech32m(hrp string, data []byte) string {
+	values := append(bech32mHrpExpand(hrp), data...)
+	polymod := bech32mPolymod(append(values, make([]byte, bech32ChecksumLen)...)) ^ bech32mConst
+	var sb strings.Builder
+	sb.WriteString(hrp)
+	sb.WriteByte('1')
+	for _, d := range data {
+		sb.WriteByte(bech32Charset[d])
+	}
+	for i := 0; i < bech32ChecksumLen; i++ {
+		sb.WriteByte(bech32Charset[(polymod>>uint(5*(5-i)))&31])
+	}
+	return sb.String()
+}
+
+func decodeBech32m(addr string) (string, []byte, error) {
+	if len(addr) > bech32MaxLen {
+		return "", nil, errors.New("bech32m string is too long")
+	}
+	if strings.ToLower(addr) != addr && strings.ToUpper(addr) != addr {
+		return "", nil, errors.New("bech32m string has mixed case")
+	}
+	addr = strings.ToLower(addr)
+	sepIdx := strings.LastIndexByte(addr, '1')
+	if sepIdx < 1 || sepIdx+bech32ChecksumLen+1 > len(addr) {
+		return "", nil, errors.New("invalid bech32m separator position")
+	}
+	hrp := addr[:sepIdx]
+	data := make([]byte, 0, len(addr)-sepIdx-1)
+	for i := sepIdx + 1; i < len(addr); i++ {
+		idx := strings.IndexByte(bech32Charset, addr[i])
+		if idx < 0 {
+			return "", nil, fmt.Errorf("invalid bech32m character %q", addr[i])
+		}
+		data = append(data, byte(idx))
+	}
+	if bech32mPolymod(append(bech32mHrpExpand(hrp), data...)) != bech32mConst {
+		return "", nil, errors.New("invalid bech32m checksum")
+	}
+	return hrp, data[:len(data)-bech32ChecksumLen], nil
+}
+
+// EncodeTaprootAddress encodes the witness program (x-only output key) of a taproot output to a bech32m address
+func EncodeTaprootAddress(witnessProgram []byte, params *chaincfg.Params) (string, error) {
+	if len(witnessProgram) != TaprootProgramSize {
+		return "", fmt.Errorf("invalid taproot witness program length %v", len(witnessProgram))
+	}
+	converted, err := bech32.ConvertBits(witnessProgram, 8, 5, true)
+	if err != nil {
+		return "", err
+	}
+	return encodeBech32m(params.Bech32HRPSegwit, append([]byte{TaprootWitnessVersion}, converted...)), nil
+}
+
+// DecodeTaprootAddress returns the witness program of a taproot address of the network
+func DecodeTaprootAddress(addrStr string, params *chaincfg.Params) ([]byte, error) {
+	hrp, data, err := decodeBech32m(addrStr)
+	if err != nil {
+		return nil, err
+	}
+	if hrp != params.Bech32HRPSegwit {
+		return nil, fmt.Errorf("address %v is not for network %v", addrStr, params.Name)
+	}
+	if len(data) < 1 || data[0] != TaprootWitnessVersion {
+		return nil, fmt.Errorf("address %v is not a taproot address", addrStr)
+	}
+	program, err := bech32.ConvertBits(data[1:], 5, 8, false)
+	if err != nil {
+		return nil, err
+	}
+	if len(program) != TaprootProgramSize {
+		return nil, fmt.Errorf("invalid taproot witness program length %v", len(program))
+	}
+	return program, nil
+}
+
+// IsTaprootAddress checks whether the address is a taproot address of the network
+func IsTaprootAddress(addrStr string, params *chaincfg.Params) bool {
+	_, err := DecodeTaprootAddress(addrStr, params)
+	return err == nil
+}
+
+// PayToTaprootScript returns the pkscript paying to the taproot witness program
+func PayToTaprootScript(witnessProgram []byte) ([]byte, error) {
+	return txscript.NewScriptBuilder().AddOp(txscript.OP_1).AddData(witnessProgram).Script()
+}
+
+// extractTaprootProgram returns the witness program if the pkscript is a taproot output
+func extractTaprootProgram(pkScript []byte) ([]byte, bool) {
+	if len(pkScript) != TaprootProgramSize+2 || pkScript[0] != txscript.OP_1 || pkScript[1] != txscript.OP_DATA_32 {
+		return nil, false
+	}
+	return pkScript[2:], true
+}
+
+// PayToAddrStrScript returns the pkscript paying to the address of the network, including taproot addresses
+func PayToAddrStrScript(addrStr string, params *chaincfg.Params) ([]byte, error) {
+	if program, err := DecodeTaprootAddress(addrStr, params); err == nil {
+		return PayToTaprootScript(program)
+	}
+	addr, err := btcutil.DecodeAddress(addrStr, params)
+	if err != nil {
+		return nil, err
+	}
+	if !addr.IsForNet(params) {
+		return nil, fmt.Errorf("address %v is not for network %v", addrStr, params.Name)
+	}
+	return txscript.PayToAddrScript(addr)
+}
@@ -0,0 +1,41 @@
+package btcrelaying
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTaprootAddress(t *testing.T) {
+	// BIP350 test vector
+	addrStr := "bc1p0xlxvlhemja6c4dqv22uapctqupfhlxm9h8z3k2e72q4k9hcz7vqzk5jj0"
+	pkScriptHex := "512079be667ef9dcbbac55a06295ce870b07029bfcdb2dce28d959f2815b16f81798"
+
+	program, err := DecodeTaprootAddress(addrStr, &chaincfg.MainNetParams)
+	assert.Nil(t, err)
+	pkScript, err := PayToTaprootScript(program)
+	assert.Nil(t, err)
+	assert.Equal(t, pkScriptHex, hex.EncodeToString(pkScript))
+
+	encoded, err := EncodeTaprootAddress(program, &chaincfg.MainNetParams)
+	assert.Nil(t, err)
+	assert.Equal(t, addrStr, encoded)
+
+	pkScript, err = PayToAddrStrScript(addrStr, &chaincfg.MainNetParams)
+	assert.Nil(t, err)
+	assert.Equal(t, pkScriptHex, hex.EncodeToString(pkScript))
+
+	btcChain := &BlockChain{chainParams: &chaincfg.MainNetParams}
+	extractedAddr, err := btcChain.ExtractPaymentAddrStrFromPkScript(pkScript)
+	assert.Nil(t, err)
+	assert.Equal(t, addrStr, extractedAddr)
+
+	assert.True(t, IsTaprootAddress(addrStr, &chaincfg.MainNetParams))
+	assert.False(t, IsTaprootAddress(addrStr, &chaincfg.TestNet3Params))
+	// segwit v0 address (bech32) is not a taproot address
+	assert.False(t, IsTaprootAddress("bc1qw508d6qejxtdg4y5r3zarvary0c5xw7kv8f3t4", &chaincfg.MainNetParams))
+	// invalid checksum
+	assert.False(t, IsTaprootAddress("bc1p0xlxvlhemja6c4dqv22uapctqupfhlxm9h8z3k2e72q4k9hcz7vqzk5jj1", &chaincfg.MainNetParams))
+}
@@ -9,6 +9,8 @@ const (
 	startProfiling = "startprofiling"
 	stopProfiling  = "stopprofiling"
 	exportMetrics  = "exportmetrics"
+	getLogLevels   = "getloglevels"
+	setLogLevel    = "setloglevel"
 
 	getNetworkInfo       = "getnetworkinfo"
 	getConnectionCount   = "getconnectioncount"
@@ -29,6 +31,7 @@ const (
 	getRawMempool                 = "getrawmempool"
 	getSyncPoolValidator          = "getsyncpoolvalidator"
 	getSyncPoolValidatorDetail    = "getsyncpoolvalidatordetail"
+	getEquivocationEvidencePool   = "getequivocationevidencepool"
 	getMempoolInfoDetails         = "getmempoolinfodetails"
 	getNumberOfTxsInMempool       = "getnumberoftxsinmempool"
 	getMempoolEntry               = "getmempoolentry"
@@ -104,6 +107,7 @@ const (
 	createAndSendTokenInitTransaction          = "createandsendtokeninittransaction"
 	decryptoutputcoinbykeyoftransaction        = "decryptoutputcoinbykeyoftransaction"
 	randomCommitmentsAndPublicKeys             = "randomcommitmentsandpublickeys"
+	createUnsignedTransactionV2                = "createunsignedtransactionv2"
 
 	createAndSendTransactionV2                   = "createandsendtransactionv2"
 	createAndSendPrivacyCustomTokenTransactionV2 = "createandsendprivacycustomtokentransactionv2"
@@ -138,6 +142,14 @@ const (
 	getCommitteeState                  = "getcommitteestate"
 	getDelegationDetail                = "getdelegationdetail"
 	getDelegationRewardDetail          = "getdelegationrewarddetail"
+	getDelegatorPortfolio              = "getdelegatorportfolio"
+	getDelegationHistory               = "getdelegationhistory"
+	getDelegationRewardHistory         = "getdelegationrewardhistory"
+	registerAccountLedger              = "registeraccountledger"
+	submitAccountKeyImages             = "submitaccountkeyimages"
+	getAccountLedgerBalance            = "getaccountledgerbalance"
+	getAccountIncomingTxs              = "getaccountincomingtxs"
+	getAccountOutgoingTxs              = "getaccountoutgoingtxs"
 	convertPaymentAddress              = "convertpaymentaddress"
 	getTotalBlockInEpoch               = "gettotalblockinepoch"
 	getDetailBlocksOfEpoch             = "getdetailblocksofepoch"
@@ -145,6 +157,7 @@ const (
 	getShardCommitteeStateByBeaconHash = "getshardcommitteestatebybeaconhash"
 	getSlashingCommittee               = "getslashingcommittee"
 	getSlashingCommitteeDetail         = "getslashingcommitteedetail"
+	getValidatorPerformance            = "getvalidatorperformance"
 	getFinalityProof                   = "getfinalityproof"
 	setConsensusRule                   = "setconsensusrule"
 	getConsensusRule                   = "getconsensusrule"
@@ -172,6 +185,8 @@ const (
 	dumpPrivkey                     = "dumpprivkey"
 	importAccount                   = "importaccount"
 	removeAccount                   = "removeaccount"
+	changeWalletPassphrase          = "changewalletpassphrase"
+	restoreWallet                   = "restorewallet"
 	listUnspentOutputCoins          = "listunspentoutputcoins"
 	listUnspentOutputCoinsFromCache = "listunspentoutputcoinsfromcache"
 	getBalance                      = "getbalance"
@@ -246,6 +261,7 @@ const (
 	getAVAXBurnProof         = "getavaxburnproof"
 	getNearBurnProof         = "getnearburnproof"
 	getPrvBurnProof          = "getprvburnproof"
+	getSOLBurnProof          = "getsolburnproof"
 
 	// reward
 	CreateRawWithDrawTransaction                 = "withdrawreward"
@@ -395,10 +411,12 @@ const (
 	// relaying
 	createAndSendTxWithRelayingBNBHeader = "createandsendtxwithrelayingbnbheader"
 	createAndSendTxWithRelayingBTCHeader = "createandsendtxwithrelayingbtcheader"
+	createAndSendTxWithRelayingLTCHeader = "createandsendtxwithrelayingltcheader"
 	getRelayingBNBHeaderState            = "getrelayingbnbheaderstate"
 	getRelayingBNBHeaderByBlockHeight    = "getrelayingbnbheaderbyblockheight"
 	getBTCRelayingBestState              = "getbtcrelayingbeststate"
 	getBTCBlockByHash                    = "getbtcblockbyhash"
+	getLTCRelayingBestState              = "getltcrelayingbeststate"
 	getLatestBNBHeaderBlockHeight        = "getlatestbnbheaderblockheight"
 
 	// incognito mode for sc
@@ -417,6 +435,7 @@ const (
 	getBurnAVAXProofForDepositToSC                  = "getburnavaxprooffordeposittosc"
 
 	getFeatureStats       = "getfeaturestats"
+	getFeatureDashboard   = "getfeaturedashboard"
 	getSyncStats          = "getsyncstats"
 	getBeaconPoolInfo     = "getbeaconpoolinfo"
 	getShardPoolInfo      = "getshardpoolinfo"
@@ -433,6 +452,10 @@ const (
 	// validator state
 	getValKeyState = "getvalkeystate"
 
+	// slashing protection
+	exportSlashingProtection = "exportslashingprotection"
+	importSlashingProtection = "importslashingprotection"
+
 	// portal v4
 	getPortalV4State                           = "getportalv4state"
 	getPortalV4Params                          = "getportalv4params"
@@ -450,6 +473,7 @@ const (
 	createAndSendTxPortalConvertVaultRequest   = "createandsendtxportalconvertvault"
 	getPortalConvertVaultTxStatus              = "getportalconvertvaultstatus"
 	generatePortalShieldMultisigAddress        = "generateportalshieldmultisigaddress"
+	getPortalV4PendingUnshieldBatches          = "getportalv4pendingunshieldbatches"
 
 	// stake
 	unstake = "createunstaketransaction"
@@ -459,6 +483,15 @@ const (
 	getShardStaker          = "getshardstaker"
 	getBeaconCommitteeState = "getbeaconcommitteestate"
 	getBeaconCandidateUID   = "getbeaconcandidateuid"
+	getCommitteeStateDiff   = "getcommitteestatediff"
+
+	// light client
+	getLightBeaconHeaders     = "getlightbeaconheaders"
+	getBeaconInstructionProof = "getbeaconinstructionproof"
+	getTxInclusionProof       = "gettxinclusionproof"
+	// checkpoint fast sync
+	getBeaconCheckpointView = "getbeaconcheckpointview"
+	getBeaconTrieNodes      = "getbeacontrienodes"
 	// prune
 	prune          = "pruneState"
 	getPruneState  = "getPruneState"
@@ -476,6 +509,7 @@ const (
 	subcribeBeaconCandidateByPublickey          = "subcribebeaconcandidatebypublickey"
 	subcribeBeaconPendingValidatorByPublickey   = "subcribebeaconpendingvalidatorbypublickey"
 	subcribeBeaconCommitteeByPublickey          = "subcribebeaconcommitteebypublickey"
+	subcribeValidatorPerformance                = "subcribevalidatorperformance"
 	subcribeCrossOutputCoinByPrivateKey         = "subcribecrossoutputcoinbyprivatekey"
 	subcribeCrossCustomTokenByPrivateKey        = "subcribecrosscustomtokenbyprivatekey"
 	subcribeCrossCustomTokenPrivacyByPrivateKey = "subcribecrosscustomtokenprivacybyprivatekey"
@@ -485,6 +519,8 @@ const (
 	subcribeBeaconBestStateFromMem              = "subcribebeaconbeststatefrommem"
 	subcribeBeaconPoolBeststate                 = "subcribebeaconpoolbeststate"
 	subcribeShardPoolBeststate                  = "subcribeshardpoolbeststate"
+	subcribeFeatureThreshold                    = "subcribefeaturethreshold"
+	subcribeViewEvent                           = "subcribeviewevent"
 )
 
 // add method names when add new feature flags
@@ -492,10 +528,12 @@ var FeatureFlagWithMethodNames = map[string][]string{
 	common.PortalRelayingFlag: {
 		createAndSendTxWithRelayingBNBHeader,
 		createAndSendTxWithRelayingBTCHeader,
+		createAndSendTxWithRelayingLTCHeader,
 		getRelayingBNBHeaderState,
 		getRelayingBNBHeaderByBlockHeight,
 		getBTCRelayingBestState,
 		getBTCBlockByHash,
+		getLTCRelayingBestState,
 		getLatestBNBHeaderBlockHeight,
 	},
 	common.PortalV3Flag: {
@@ -564,5 +602,6 @@ var FeatureFlagWithMethodNames = map[string][]string{
 		getPortalConvertVaultTxStatus,
 		getPortalV4Params,
 		generatePortalShieldMultisigAddress,
+		getPortalV4PendingUnshieldBatches,
 	},
 }
@@ -0,0 +1,215 @@
+package rpcserver
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/incognitochain/incognito-chain/blockchain"
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/common/base58"
+	"github.com/incognitochain/incognito-chain/rpcserver/jsonresult"
+	"github.com/incognitochain/incognito-chain/rpcserver/rpcservice"
+	"github.com/incognitochain/incognito-chain/wallet"
+)
+
+const (
+	defaultAccountLedgerTxLimit = 20
+	maxAccountLedgerTxLimit     = 100
+)
+
+// handleRegisterAccountLedger register a privacy v2 account to account ledger of the node,
+// params: access token, OTA key, read only key, from height (optional)
+// node must run with account ledger enabled
+func (httpServer *HttpServer) handleRegisterAccountLedger(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
+	arrayParams := common.InterfaceSlice(params)
+	if arrayParams == nil || len(arrayParams) < 3 {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("param must be an array at least 3 elements"))
+	}
+	ledger, accessToken, rpcErr := httpServer.parseAccountLedgerAccessToken(arrayParams[0])
+	if rpcErr != nil {
+		return nil, rpcErr
+	}
+	otaKeyWallet, rpcErr := parseAccountLedgerKey(arrayParams[1], "OTA key")
+	if rpcErr != nil {
+		return nil, rpcErr
+	}
+	readonlyKeyWallet, rpcErr := parseAccountLedgerKey(arrayParams[2], "read only key")
+	if rpcErr != nil {
+		return nil, rpcErr
+	}
+	fromHeight := uint64(0)
+	if len(arrayParams) > 3 {
+		fromHeightParam, ok := arrayParams[3].(float64)
+		if !ok {
+			return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("from height is invalid"))
+		}
+		fromHeight = uint64(fromHeightParam)
+	}
+
+	account, err := ledger.RegisterAccount(accessToken, otaKeyWallet.KeySet.OTAKey, readonlyKeyWallet.KeySet.ReadonlyKey, fromHeight)
+	if err != nil {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, err)
+	}
+	return jsonresult.NewAccountLedgerInfo(account), nil
+}
+
+// handleSubmitAccountKeyImages submit key images of coins of an account so account ledger can detect spent coins,
+// params: access token, OTA key, map of coin public key => key image (base58)
+func (httpServer *HttpServer) handleSubmitAccountKeyImages(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
+	arrayParams := common.InterfaceSlice(params)
+	if arrayParams == nil || len(arrayParams) < 3 {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("param must be an array at least 3 elements"))
+	}
+	ledger, accessToken, rpcErr := httpServer.parseAccountLedgerAccessToken(arrayParams[0])
+	if rpcErr != nil {
+		return nil, rpcErr
+	}
+	publicKey, rpcErr := parseAccountLedgerPublicKey(arrayParams[1])
+	if rpcErr != nil {
+		return nil, rpcErr
+	}
+	keyImagesParam, ok := arrayParams[2].(map[string]interface{})
+	if !ok || len(keyImagesParam) == 0 {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("key images must be a non empty map of coin public key to key image"))
+	}
+	keyImages := []blockchain.LedgerKeyImage{}
+	for coinPublicKeyStr, keyImageParam := range keyImagesParam {
+		coinPublicKey, _, err := base58.Base58Check{}.Decode(coinPublicKeyStr)
+		if err != nil {
+			return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, fmt.Errorf("coin public key %v is invalid", coinPublicKeyStr))
+		}
+		keyImageStr, ok := keyImageParam.(string)
+		if !ok {
+			return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, fmt.Errorf("key image of coin %v is invalid", coinPublicKeyStr))
+		}
+		keyImage, _, err := base58.Base58Check{}.Decode(keyImageStr)
+		if err != nil {
+			return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, fmt.Errorf("key image of coin %v is invalid", coinPublicKeyStr))
+		}
+		keyImages = append(keyImages, blockchain.LedgerKeyImage{CoinPublicKey: coinPublicKey, KeyImage: keyImage})
+	}
+
+	if err := ledger.SubmitKeyImages(accessToken, publicKey, keyImages); err != nil {
+		return nil, rpcservice.NewRPCError(rpcservice.UnexpectedError, err)
+	}
+	return true, nil
+}
+
+// handleGetAccountLedgerBalance return unspent amount by token of an account of account ledger,
+// params: access token, OTA key
+func (httpServer *HttpServer) handleGetAccountLedgerBalance(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
+	arrayParams := common.InterfaceSlice(params)
+	if arrayParams == nil || len(arrayParams) < 2 {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("param must be an array at least 2 elements"))
+	}
+	ledger, accessToken, rpcErr := httpServer.parseAccountLedgerAccessToken(arrayParams[0])
+	if rpcErr != nil {
+		return nil, rpcErr
+	}
+	publicKey, rpcErr := parseAccountLedgerPublicKey(arrayParams[1])
+	if rpcErr != nil {
+		return nil, rpcErr
+	}
+	account, err := ledger.GetAccount(accessToken, publicKey)
+	if err != nil {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, err)
+	}
+	balances, err := ledger.GetBalances(accessToken, publicKey)
+	if err != nil {
+		return nil, rpcservice.NewRPCError(rpcservice.UnexpectedError, err)
+	}
+	return &jsonresult.AccountLedgerBalance{
+		AccountLedgerInfo: jsonresult.NewAccountLedgerInfo(account),
+		Balances:          balances,
+	}, nil
+}
+
+// handleGetAccountIncomingTxs return txs sending coins to an account of account ledger, newest first,
+// params: access token, OTA key, page (optional, from 0), limit (optional)
+func (httpServer *HttpServer) handleGetAccountIncomingTxs(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
+	return httpServer.getAccountLedgerTxs(params, false)
+}
+
+// handleGetAccountOutgoingTxs return txs spending coins of an account of account ledger, newest first,
+// only coins with submitted key images are detected as spent,
+// params: access token, OTA key, page (optional, from 0), limit (optional)
+func (httpServer *HttpServer) handleGetAccountOutgoingTxs(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
+	return httpServer.getAccountLedgerTxs(params, true)
+}
+
+func (httpServer *HttpServer) getAccountLedgerTxs(params interface{}, isOutgoing bool) (interface{}, *rpcservice.RPCError) {
+	arrayParams := common.InterfaceSlice(params)
+	if arrayParams == nil || len(arrayParams) < 2 {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("param must be an array at least 2 elements"))
+	}
+	ledger, accessToken, rpcErr := httpServer.parseAccountLedgerAccessToken(arrayParams[0])
+	if rpcErr != nil {
+		return nil, rpcErr
+	}
+	publicKey, rpcErr := parseAccountLedgerPublicKey(arrayParams[1])
+	if rpcErr != nil {
+		return nil, rpcErr
+	}
+	page, limit := 0, defaultAccountLedgerTxLimit
+	if len(arrayParams) > 2 {
+		pageParam, ok := arrayParams[2].(float64)
+		if !ok || pageParam < 0 {
+			return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("page is invalid"))
+		}
+		page = int(pageParam)
+	}
+	if len(arrayParams) > 3 {
+		limitParam, ok := arrayParams[3].(float64)
+		if !ok || limitParam <= 0 || limitParam > maxAccountLedgerTxLimit {
+			return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, fmt.Errorf("limit must be in [1, %v]", maxAccountLedgerTxLimit))
+		}
+		limit = int(limitParam)
+	}
+
+	account, err := ledger.GetAccount(accessToken, publicKey)
+	if err != nil {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, err)
+	}
+	txs, err := ledger.GetTxs(accessToken, publicKey)
+	if err != nil {
+		return nil, rpcservice.NewRPCError(rpcservice.UnexpectedError, err)
+	}
+	return jsonresult.NewAccountLedgerTxs(account, txs, isOutgoing, page, limit), nil
+}
+
+func (httpServer *HttpServer) parseAccountLedgerAccessToken(param interface{}) (*blockchain.AccountLedger, string, *rpcservice.RPCError) {
+	ledger := httpServer.config.BlockChain.GetAccountLedger()
+	if ledger == nil {
+		return nil, "", rpcservice.NewRPCError(rpcservice.UnexpectedError, errors.New("account ledger is disabled, node must run with --indexaccountledger"))
+	}
+	accessToken, ok := param.(string)
+	if !ok || accessToken == "" {
+		return nil, "", rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("access token is invalid"))
+	}
+	return ledger, accessToken, nil
+}
+
+func parseAccountLedgerKey(param interface{}, name string) (*wallet.KeyWallet, *rpcservice.RPCError) {
+	keyStr, ok := param.(string)
+	if !ok {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, fmt.Errorf("%v is invalid", name))
+	}
+	keyWallet, err := wallet.Base58CheckDeserialize(keyStr)
+	if err != nil {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, fmt.Errorf("%v is invalid: %v", name, err))
+	}
+	return keyWallet, nil
+}
+
+// parseAccountLedgerPublicKey return public spend key of the account of an OTA key
+func parseAccountLedgerPublicKey(param interface{}) ([]byte, *rpcservice.RPCError) {
+	keyWallet, rpcErr := parseAccountLedgerKey(param, "OTA key")
+	if rpcErr != nil {
+		return nil, rpcErr
+	}
+	publicSpend := keyWallet.KeySet.OTAKey.GetPublicSpend()
+	if publicSpend == nil {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("OTA key is invalid"))
+	}
+	return publicSpend.ToBytesS(), nil
+}
@@ -83,11 +83,9 @@ func (httpServer *HttpServer) handleGetBootstrapStateDB(conn net.Conn, params in
 			continue
 		}
 		if dbType == "block" {
+			//other files (zstd dictionaries) are always sent
 			fileNameID, err := strconv.ParseInt(file.Name(), 10, 64)
-			if err != nil {
-				log.Println(err)
-			}
-			if ff_fileId > uint64(fileNameID) {
+			if err == nil && ff_fileId > uint64(fileNameID) {
 				continue
 			}
 		}
@@ -235,6 +235,35 @@ func (httpServer *HttpServer) handleGetBeaconCandidateUID(params interface{}, cl
 
 }
 
+// handleGetCommitteeStateDiff return validators entering and leaving each pool between two beacon heights,
+// params: fromHeight, toHeight, committee public key (optional)
+func (httpServer *HttpServer) handleGetCommitteeStateDiff(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
+	arrayParams := common.InterfaceSlice(params)
+	if arrayParams == nil || len(arrayParams) < 2 {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("Not enough param, expect fromHeight and toHeight"))
+	}
+	fromHeight, ok := arrayParams[0].(float64)
+	if !ok {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("From height is invalid"))
+	}
+	toHeight, ok := arrayParams[1].(float64)
+	if !ok {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("To height is invalid"))
+	}
+	publicKey := ""
+	if len(arrayParams) > 2 {
+		publicKey, ok = arrayParams[2].(string)
+		if !ok {
+			return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("Public key is invalid"))
+		}
+	}
+	diffs, err := httpServer.config.BlockChain.GetBeaconCommitteeStateDiff(uint64(fromHeight), uint64(toHeight))
+	if err != nil {
+		return nil, rpcservice.NewRPCError(rpcservice.UnexpectedError, err)
+	}
+	return jsonresult.NewCommitteeStateDiffResult(uint64(fromHeight), uint64(toHeight), diffs, publicKey), nil
+}
+
 func (httpServer *HttpServer) handleGetShardStakerInfo(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
 	arrayParams := common.InterfaceSlice(params)
 	height := uint64(arrayParams[0].(float64))
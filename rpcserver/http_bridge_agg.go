@@ -454,6 +454,7 @@ func (httpServer *HttpServer) createBridgeAggUnshieldTransaction(params interfac
 	}
 
 	md := metadataBridge.NewUnshieldRequestWithValue(mdReader.UnifiedTokenID, mdReader.Data, recv, mdReader.IsDepositToSC)
+	md.AutoRoute = mdReader.AutoRoute
 	if err != nil {
 		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, err)
 	}
@@ -469,9 +470,9 @@ func (httpServer *HttpServer) createBridgeAggUnshieldTransaction(params interfac
 	temp := bc.GetBurningAddress(bestState.BeaconHeight)
 	w, _ := wallet.Base58CheckDeserialize(temp)
 	burnAddr := w.KeySet.PaymentAddress
-	burningAmount := uint64(0)
-	for _, data := range md.Data {
-		burningAmount += data.BurningAmount
+	burningAmount, err := md.TotalBurningAmount()
+	if err != nil {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, err)
 	}
 
 	burnPayments := []*privacy.PaymentInfo{
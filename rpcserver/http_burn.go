@@ -233,6 +233,19 @@ func (httpServer *HttpServer) handleGetPRVBurnProof(
 	return retrieveBurnProof(confirmMeta, onBeacon, height, txID, httpServer, true)
 }
 
+// handleGetSOLBurnProof returns a proof of a tx burning pSOL ( Solana )
+func (httpServer *HttpServer) handleGetSOLBurnProof(
+	params interface{},
+	closeChan <-chan struct{},
+) (interface{}, *rpcservice.RPCError) {
+	onBeacon, height, txID, err := parseGetBurnProofParams(params, httpServer)
+	if err != nil {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, err)
+	}
+	confirmMeta := metadata.BurningSOLConfirmMeta
+	return retrieveBurnProof(confirmMeta, onBeacon, height, txID, httpServer, true)
+}
+
 func parseGetBurnProofParams(params interface{}, httpServer *HttpServer) (bool, uint64, *common.Hash, error) {
 	listParams, ok := params.([]interface{})
 	if !ok || len(listParams) < 1 {
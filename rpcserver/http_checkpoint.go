@@ -0,0 +1,61 @@
+package rpcserver
+
+import (
+	"encoding/json"
+	"errors"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/rpcserver/rpcservice"
+)
+
+// handleGetBeaconCheckpointView return the beacon view after a beacon block, for nodes fast syncing from the block
+// params: beaconHash
+func (httpServer *HttpServer) handleGetBeaconCheckpointView(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
+	arrayParams := common.InterfaceSlice(params)
+	if len(arrayParams) < 1 {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("param must be an array at least 1 element"))
+	}
+	hashStr, ok := arrayParams[0].(string)
+	if !ok {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("beaconHash is invalid"))
+	}
+	hash, err := common.Hash{}.NewHashFromStr(hashStr)
+	if err != nil {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, err)
+	}
+	res, err := httpServer.config.BlockChain.GetBeaconCheckpointView(*hash)
+	if err != nil {
+		return nil, rpcservice.NewRPCError(rpcservice.UnexpectedError, err)
+	}
+	return json.RawMessage(res), nil
+}
+
+// handleGetBeaconTrieNodes return encoded nodes of beacon state tries by hash
+// params: list of trie node hashes
+func (httpServer *HttpServer) handleGetBeaconTrieNodes(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
+	arrayParams := common.InterfaceSlice(params)
+	if len(arrayParams) < 1 {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("param must be an array at least 1 element"))
+	}
+	hashStrs := common.InterfaceSlice(arrayParams[0])
+	if hashStrs == nil {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("hashes are invalid"))
+	}
+	hashes := make([]common.Hash, 0, len(hashStrs))
+	for _, v := range hashStrs {
+		hashStr, ok := v.(string)
+		if !ok {
+			return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("hash is invalid"))
+		}
+		hash, err := common.Hash{}.NewHashFromStr(hashStr)
+		if err != nil {
+			return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, err)
+		}
+		hashes = append(hashes, *hash)
+	}
+	res, err := httpServer.config.BlockChain.GetBeaconTrieNodes(hashes)
+	if err != nil {
+		return nil, rpcservice.NewRPCError(rpcservice.UnexpectedError, err)
+	}
+	return res, nil
+}
@@ -1,12 +1,23 @@
 package rpcserver
 
 import (
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+
+	"github.com/incognitochain/incognito-chain/blockchain"
+	"github.com/incognitochain/incognito-chain/blockchain/committeestate"
 	"github.com/incognitochain/incognito-chain/common"
 	"github.com/incognitochain/incognito-chain/config"
 	"github.com/incognitochain/incognito-chain/dataaccessobject/statedb"
+	"github.com/incognitochain/incognito-chain/rpcserver/jsonresult"
 	"github.com/incognitochain/incognito-chain/rpcserver/rpcservice"
+	"github.com/incognitochain/incognito-chain/wallet"
 )
 
+const maxDelegationRewardHistoryEpochs = 1000
+
 func (httpServer *HttpServer) handleGetDelegationDetail(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
 	shardIDs := []int{}
 	for i := 0; i < config.Param().ActiveShards; i++ {
@@ -58,3 +69,179 @@ func (httpServer *HttpServer) handleGetDelegationRewardDetail(params interface{}
 	}
 	return delegationReward, nil
 }
+
+// handleGetDelegatorPortfolio return current delegations of shard stakers rewarded to a payment address,
+// value of each delegation is adjusted by share price since the last delegation reward withdrawal
+func (httpServer *HttpServer) handleGetDelegatorPortfolio(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
+	arrayParams := common.InterfaceSlice(params)
+	if arrayParams == nil || len(arrayParams) < 1 {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("param must be an array at least 1 element"))
+	}
+	paymentAddress, ok := arrayParams[0].(string)
+	if !ok {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("payment address is invalid"))
+	}
+	keyWallet, err := wallet.Base58CheckDeserialize(paymentAddress)
+	if err != nil {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, err)
+	}
+	receiver := keyWallet.KeySet.PaymentAddress
+
+	bc := httpServer.config.BlockChain
+	beaconBestState := bc.GetBeaconBestState()
+	stateDB := beaconBestState.GetBeaconConsensusStateDB()
+	res := &jsonresult.DelegatorPortfolio{
+		PaymentAddress: paymentAddress,
+		Delegations:    []*jsonresult.DelegationPosition{},
+	}
+	rewardState, has, err := statedb.GetDelegationReward(stateDB, receiver.Pk)
+	if err != nil {
+		return nil, rpcservice.NewRPCError(rpcservice.UnexpectedError, err)
+	}
+	if has {
+		delegators := []string{}
+		for delegator := range rewardState.Reward {
+			delegators = append(delegators, delegator)
+		}
+		sort.Strings(delegators)
+		for _, delegator := range delegators {
+			info, has, err := statedb.GetShardStakerInfo(stateDB, delegator)
+			if err != nil || !has || info.GetDelegateUID() == "" {
+				continue
+			}
+			uid := info.GetDelegateUID()
+			epochs := []int{}
+			for epoch := range rewardState.Reward[delegator] {
+				epochs = append(epochs, epoch)
+			}
+			sort.Ints(epochs)
+			startEpoch := uint64(0)
+			for _, epoch := range epochs {
+				if rewardState.Reward[delegator][epoch].BeaconUID != uid {
+					startEpoch = 0
+					continue
+				}
+				if startEpoch == 0 {
+					startEpoch = uint64(epoch)
+				}
+			}
+			sharePrice, has, err := statedb.GetBeaconSharePrice(stateDB, uid)
+			if err != nil || !has {
+				continue
+			}
+			position := &jsonresult.DelegationPosition{
+				Delegator:       delegator,
+				Delegate:        info.GetDelegate(),
+				DelegateUID:     uid,
+				StartEpoch:      startEpoch,
+				Amount:          config.Param().StakingAmountShard,
+				StartSharePrice: sharePrice.GetPrice(),
+				SharePrice:      sharePrice.GetPrice(),
+				Value:           config.Param().StakingAmountShard,
+			}
+			// delegation affecting next epoch has not earned anything yet
+			if startEpoch != 0 && startEpoch <= beaconBestState.Epoch {
+				startPrice, err := bc.GetBeaconSharePriceByEpoch(startEpoch, uid)
+				if err != nil {
+					return nil, rpcservice.NewRPCError(rpcservice.UnexpectedError, err)
+				}
+				if startPrice != 0 {
+					position.StartSharePrice = startPrice
+					position.Value = new(big.Int).Div(
+						new(big.Int).Mul(new(big.Int).SetUint64(position.Amount), new(big.Int).SetUint64(position.SharePrice)),
+						new(big.Int).SetUint64(startPrice),
+					).Uint64()
+				}
+			}
+			res.Delegations = append(res.Delegations, position)
+		}
+	}
+	res.PendingReward, err = bc.GetDelegationRewardAmount(stateDB, receiver.Pk)
+	if err != nil {
+		return nil, rpcservice.NewRPCError(rpcservice.GetDelegationRewardAmountError, err)
+	}
+	return res, nil
+}
+
+// handleGetDelegationHistory return redelegations, ends of delegation and reward withdrawals of a payment address,
+// node must run with delegation index enabled
+func (httpServer *HttpServer) handleGetDelegationHistory(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
+	arrayParams := common.InterfaceSlice(params)
+	if arrayParams == nil || len(arrayParams) < 1 {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("param must be an array at least 1 element"))
+	}
+	receiver, rpcErr := parseDelegationReceiver(arrayParams[0])
+	if rpcErr != nil {
+		return nil, rpcErr
+	}
+	events, err := httpServer.config.BlockChain.GetDelegationEvents(receiver)
+	if err != nil {
+		return nil, rpcservice.NewRPCError(rpcservice.UnexpectedError, err)
+	}
+	return events, nil
+}
+
+// handleGetDelegationRewardHistory return value and reward accrual of each delegation of a payment address per epoch,
+// params: payment address, from epoch, to epoch (optional, default current epoch)
+// node must run with delegation index enabled
+func (httpServer *HttpServer) handleGetDelegationRewardHistory(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
+	arrayParams := common.InterfaceSlice(params)
+	if arrayParams == nil || len(arrayParams) < 2 {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("param must be an array at least 2 elements"))
+	}
+	receiver, rpcErr := parseDelegationReceiver(arrayParams[0])
+	if rpcErr != nil {
+		return nil, rpcErr
+	}
+	fromEpochParam, ok := arrayParams[1].(float64)
+	if !ok {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("from epoch is invalid"))
+	}
+	bc := httpServer.config.BlockChain
+	fromEpoch, toEpoch := uint64(fromEpochParam), bc.GetBeaconBestState().Epoch
+	if len(arrayParams) > 2 {
+		toEpochParam, ok := arrayParams[2].(float64)
+		if !ok {
+			return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("to epoch is invalid"))
+		}
+		toEpoch = uint64(toEpochParam)
+	}
+	if fromEpoch > toEpoch || toEpoch-fromEpoch > maxDelegationRewardHistoryEpochs {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, fmt.Errorf("epoch range must be valid and not exceed %v epochs", maxDelegationRewardHistoryEpochs))
+	}
+
+	events, err := bc.GetDelegationEvents(receiver)
+	if err != nil {
+		return nil, rpcservice.NewRPCError(rpcservice.UnexpectedError, err)
+	}
+	sharePrices := make(map[string][]blockchain.SharePricePoint)
+	for _, event := range events {
+		if event.DelegateUID == "" {
+			continue
+		}
+		if _, ok := sharePrices[event.DelegateUID]; ok {
+			continue
+		}
+		sharePrices[event.DelegateUID], err = bc.GetSharePriceHistory(event.DelegateUID)
+		if err != nil {
+			return nil, rpcservice.NewRPCError(rpcservice.UnexpectedError, err)
+		}
+	}
+	rewards := blockchain.GetDelegationEpochRewards(events, sharePrices, config.Param().StakingAmountShard, committeestate.INIT_SHARE_PRICE, fromEpoch, toEpoch)
+	return jsonresult.NewDelegationRewardHistory(fromEpoch, toEpoch, rewards), nil
+}
+
+func parseDelegationReceiver(param interface{}) ([]byte, *rpcservice.RPCError) {
+	if !config.Config().IndexDelegation {
+		return nil, rpcservice.NewRPCError(rpcservice.UnexpectedError, errors.New("delegation index is disabled, node must run with --indexdelegation"))
+	}
+	paymentAddress, ok := param.(string)
+	if !ok {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("payment address is invalid"))
+	}
+	keyWallet, err := wallet.Base58CheckDeserialize(paymentAddress)
+	if err != nil {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, err)
+	}
+	return keyWallet.KeySet.PaymentAddress.Pk, nil
+}
@@ -0,0 +1,81 @@
+package rpcserver
+
+import (
+	"errors"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/rpcserver/rpcservice"
+)
+
+// handleGetLightBeaconHeaders return finalized beacon headers with validation data and committee proofs for light clients
+// params: fromHeight, count, withCommittee (optional, attach committee proof to the first header)
+func (httpServer *HttpServer) handleGetLightBeaconHeaders(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
+	arrayParams := common.InterfaceSlice(params)
+	if len(arrayParams) < 2 {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("param must be an array at least 2 elements"))
+	}
+	fromHeight, ok := arrayParams[0].(float64)
+	if !ok || fromHeight < 1 {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("fromHeight is invalid"))
+	}
+	count, ok := arrayParams[1].(float64)
+	if !ok || count < 1 {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("count is invalid"))
+	}
+	withCommittee := false
+	if len(arrayParams) > 2 {
+		withCommittee, ok = arrayParams[2].(bool)
+		if !ok {
+			return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("withCommittee is invalid"))
+		}
+	}
+	res, err := httpServer.config.BlockChain.GetLightBeaconHeaders(uint64(fromHeight), int(count), withCommittee)
+	if err != nil {
+		return nil, rpcservice.NewRPCError(rpcservice.UnexpectedError, err)
+	}
+	return res, nil
+}
+
+// handleGetBeaconInstructionProof return merkle proof of an instruction in InstructionMerkleRoot of a beacon block
+// params: beaconHeight, instruction index
+func (httpServer *HttpServer) handleGetBeaconInstructionProof(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
+	arrayParams := common.InterfaceSlice(params)
+	if len(arrayParams) < 2 {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("param must be an array at least 2 elements"))
+	}
+	height, ok := arrayParams[0].(float64)
+	if !ok {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("beaconHeight is invalid"))
+	}
+	index, ok := arrayParams[1].(float64)
+	if !ok {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("instruction index is invalid"))
+	}
+	res, err := httpServer.config.BlockChain.GetBeaconInstructionProof(uint64(height), int(index))
+	if err != nil {
+		return nil, rpcservice.NewRPCError(rpcservice.UnexpectedError, err)
+	}
+	return res, nil
+}
+
+// handleGetTxInclusionProof return merkle proof of a tx in its shard block and the beacon block confirming the shard block
+// params: txHash
+func (httpServer *HttpServer) handleGetTxInclusionProof(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
+	arrayParams := common.InterfaceSlice(params)
+	if len(arrayParams) < 1 {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("param must be an array at least 1 element"))
+	}
+	txHashStr, ok := arrayParams[0].(string)
+	if !ok {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("txHash is invalid"))
+	}
+	txHash, err := common.Hash{}.NewHashFromStr(txHashStr)
+	if err != nil {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, err)
+	}
+	res, err := httpServer.config.BlockChain.GetTxInclusionProof(*txHash)
+	if err != nil {
+		return nil, rpcservice.NewRPCError(rpcservice.UnexpectedError, err)
+	}
+	return res, nil
+}
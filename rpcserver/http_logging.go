@@ -0,0 +1,40 @@
+package rpcserver
+
+import (
+	"errors"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/rpcserver/rpcservice"
+)
+
+// handleGetLogLevels return the current logging level of every subsystem
+func (httpServer *HttpServer) handleGetLogLevels(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
+	if httpServer.config.LogLevels == nil {
+		return nil, rpcservice.NewRPCError(rpcservice.UnexpectedError, errors.New("log levels are not available"))
+	}
+	return httpServer.config.LogLevels.GetLogLevels(), nil
+}
+
+// handleSetLogLevel change the logging level of a subsystem at runtime, use subsystem "all" for every subsystem
+// params: subsystem, level {trace, debug, info, warn, error, critical}
+func (httpServer *HttpServer) handleSetLogLevel(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
+	arrayParams := common.InterfaceSlice(params)
+	if len(arrayParams) < 2 {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("param must be an array at least 2 elements"))
+	}
+	subsysID, ok := arrayParams[0].(string)
+	if !ok {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("subsystem is invalid"))
+	}
+	logLevel, ok := arrayParams[1].(string)
+	if !ok {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("level is invalid"))
+	}
+	if httpServer.config.LogLevels == nil {
+		return nil, rpcservice.NewRPCError(rpcservice.UnexpectedError, errors.New("log levels are not available"))
+	}
+	if err := httpServer.config.LogLevels.SetLogLevel(subsysID, logLevel); err != nil {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, err)
+	}
+	return httpServer.config.LogLevels.GetLogLevels(), nil
+}
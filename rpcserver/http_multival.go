@@ -1,8 +1,12 @@
 package rpcserver
 
 import (
+	"encoding/json"
 	"errors"
 
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/consensus_v2/blsbft"
+	"github.com/incognitochain/incognito-chain/dataaccessobject/rawdb_consensus"
 	"github.com/incognitochain/incognito-chain/rpcserver/rpcservice"
 )
 
@@ -13,3 +17,32 @@ func (httpServer *HttpServer) handleGetValKeyState(params interface{}, closeChan
 	states := httpServer.config.ConsensusEngine.GetAllValidatorKeyState()
 	return states, nil
 }
+
+// handleExportSlashingProtection returns the slashing protection history of the validators in the interchange format
+func (httpServer *HttpServer) handleExportSlashingProtection(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
+	interchange, err := blsbft.ExportSlashingProtection(rawdb_consensus.GetConsensusDatabase())
+	if err != nil {
+		return nil, rpcservice.NewRPCError(rpcservice.UnexpectedError, err)
+	}
+	return interchange, nil
+}
+
+// handleImportSlashingProtection merges the slashing protection history in the interchange format
+func (httpServer *HttpServer) handleImportSlashingProtection(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
+	arrayParams := common.InterfaceSlice(params)
+	if len(arrayParams) != 1 {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("param must be an array of 1 element"))
+	}
+	data, err := json.Marshal(arrayParams[0])
+	if err != nil {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, err)
+	}
+	interchange := &blsbft.SlashingProtectionInterchange{}
+	if err := json.Unmarshal(data, interchange); err != nil {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, err)
+	}
+	if err := blsbft.ImportSlashingProtection(rawdb_consensus.GetConsensusDatabase(), interchange); err != nil {
+		return nil, rpcservice.NewRPCError(rpcservice.UnexpectedError, err)
+	}
+	return true, nil
+}
@@ -16,6 +16,24 @@ func (httpServer *HttpServer) hanldeGetFeatureStats(params interface{}, closeCha
 	return result, nil
 }
 
+// handleGetFeatureDashboard return readiness of each auto enable feature and the history of triggered features
+func (httpServer *HttpServer) handleGetFeatureDashboard(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
+	if blockchain.DefaultFeatureStat == nil {
+		return nil, rpcservice.NewRPCError(rpcservice.UnexpectedError, errors.New("feature stat is not initialized"))
+	}
+	beaconView := httpServer.config.BlockChain.GetBeaconBestState()
+	history, err := httpServer.config.BlockChain.GetFeatureActivationHistory(beaconView)
+	if err != nil {
+		return nil, rpcservice.NewRPCError(rpcservice.UnexpectedError, err)
+	}
+	return &jsonresult.FeatureDashboard{
+		BeaconHeight: beaconView.BeaconHeight,
+		Epoch:        beaconView.Epoch,
+		Features:     blockchain.DefaultFeatureStat.Readiness(beaconView),
+		History:      history,
+	}, nil
+}
+
 func (httpServer *HttpServer) hanldeGetSyncStats(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
 	stats := httpServer.synkerService.GetSyncStats()
 	result := jsonresult.NewSyncStats(stats)
@@ -6,6 +6,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"sort"
 
 	"github.com/btcsuite/btcd/wire"
 	"github.com/incognitochain/incognito-chain/common"
@@ -410,12 +411,10 @@ func getRawSignedTxByHeight(
 	var tokenID string
 	numSig := uint(0)
 	sigs := make([][][]byte, len(externalTx.TxIn))
-	for i := range sigs {
-		sigs[i] = make([][]byte, 1)
-	}
 
 	for _, v := range portalV4Sig {
-		if v.RawTxHash != externalTxHash {
+		// skip the MuSig2 partial sigs on the external txs of the previous block
+		if v.RawTxHash != externalTxHash || len(v.Sigs) == 0 {
 			continue
 		}
 
@@ -437,16 +436,36 @@ func getRawSignedTxByHeight(
 		return nil, rpcservice.NewRPCError(rpcservice.UnexpectedError, errors.New("Not found portal sigs for batchID"))
 	}
 
-	// append multisig script hex into each sigs
-	// then, attach sig into TxIn in externalTx
+	// build the witness (P2WSH multisig or taproot vault) from sigs
+	// then, attach witness into TxIn in externalTx
 	portalParams := httpServer.blockService.BlockChain.GetPortalParamsV4(height)
+	if len(utxos) != len(externalTx.TxIn) {
+		return nil, rpcservice.NewRPCError(rpcservice.UnexpectedError, errors.New("Invalid length of UTXOs"))
+	}
+	// the taproot vault inputs are spent by the key path if all beacon validators sent their MuSig2 partial sigs
+	// in the next block, otherwise by the tapscript leaf
+	masterPubKeys := portalParams.MasterPubKeys[tokenID]
+	muSig2Nonces := portalprocessv4.GetMuSig2Nonces(portalV4Sig, externalTxHash, masterPubKeys, len(utxos))
+	var muSig2PartialSigs [][][]byte
 	for i, v := range sigs {
-		multisigScriptHex, _, err := portalParams.PortalTokens[tokenID].GenerateOTMultisigAddress(portalParams.MasterPubKeys[tokenID], int(portalParams.NumRequiredSigs), utxos[i].GetChainCodeSeed())
+		if muSig2Nonces[i] != nil && muSig2PartialSigs == nil {
+			muSig2PartialSigs = getMuSig2PartialSigsByHeight(httpServer, height+1, externalTxHash, masterPubKeys, len(utxos))
+		}
+		if muSig2Nonces[i] != nil && muSig2PartialSigs[i] != nil {
+			witness, err := portalParams.PortalTokens[tokenID].CombineMuSig2Sigs(
+				masterPubKeys, int(portalParams.NumRequiredSigs), externalTx, utxos, i, muSig2Nonces[i], muSig2PartialSigs[i])
+			if err == nil {
+				externalTx.TxIn[i].Witness = witness
+				continue
+			}
+			Logger.log.Warnf("Combine MuSig2 sigs of input %v of external tx %v error: %v", i, externalTxHash, err)
+		}
+		witness, err := portalParams.PortalTokens[tokenID].GenerateWitness(
+			masterPubKeys, int(portalParams.NumRequiredSigs), externalTx, utxos, i, v)
 		if err != nil {
 			return nil, rpcservice.NewRPCError(rpcservice.UnexpectedError, err)
 		}
-		v = append(v, multisigScriptHex)
-		externalTx.TxIn[i].Witness = v
+		externalTx.TxIn[i].Witness = witness
 	}
 
 	// hex-encoding signed external tx
@@ -463,6 +482,23 @@ func getRawSignedTxByHeight(
 	}, nil
 }
 
+// getMuSig2PartialSigsByHeight returns the MuSig2 partial sigs on the external tx in the beacon block,
+// the partial sigs of all TxIns are nil if the block is not available yet
+func getMuSig2PartialSigsByHeight(
+	httpServer *HttpServer, height uint64, externalTxHash string, masterPubKeys [][]byte, numTxIns int,
+) [][][]byte {
+	beaconBlockQueried, err := getSingleBeaconBlockByHeight(httpServer.GetBlockchain(), height)
+	if err != nil {
+		return make([][][]byte, numTxIns)
+	}
+	block := &beaconBlock{BeaconBlock: beaconBlockQueried}
+	portalV4Sig, err := block.PortalV4Sigs(httpServer.config.ConsensusEngine)
+	if err != nil {
+		return make([][][]byte, numTxIns)
+	}
+	return portalprocessv4.GetMuSig2PartialSigs(portalV4Sig, externalTxHash, masterPubKeys, numTxIns)
+}
+
 /*
  ====== Replace-By-Fee for Unshield Requests
 */
@@ -556,6 +592,113 @@ func (httpServer *HttpServer) handleGetPortalReplacementFeeRequestStatus(params
 	return status, nil
 }
 
+func (httpServer *HttpServer) handleGetPortalV4PendingUnshieldBatches(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
+	// parse params
+	tokenIDParam := ""
+	arrayParams := common.InterfaceSlice(params)
+	if len(arrayParams) >= 1 {
+		data, ok := arrayParams[0].(map[string]interface{})
+		if !ok {
+			return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("Payload data is invalid"))
+		}
+		if data["TokenID"] != nil {
+			tokenIDParam, ok = data["TokenID"].(string)
+			if !ok {
+				return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("Param TokenID is invalid"))
+			}
+		}
+	}
+
+	bc := httpServer.config.BlockChain
+	beaconBestState := bc.GetBeaconBestState()
+	beaconHeight := beaconBestState.BeaconHeight
+	portalParamsV4 := bc.GetPortalParamsV4(beaconHeight)
+	if tokenIDParam != "" && !portalParamsV4.IsPortalToken(tokenIDParam) {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, fmt.Errorf("TokenID %v is not a portal token", tokenIDParam))
+	}
+	portalState, err := portalprocessv4.InitCurrentPortalStateV4FromDB(beaconBestState.GetBeaconFeatureStateDB(), nil, portalParamsV4)
+	if err != nil {
+		return nil, rpcservice.NewRPCError(rpcservice.GetPortalV4PendingUnshieldBatchesError, err)
+	}
+
+	result := []jsonresult.PortalV4PendingUnshieldBatch{}
+	for tokenID, batches := range portalState.ProcessedUnshieldRequests {
+		if tokenIDParam != "" && tokenID != tokenIDParam {
+			continue
+		}
+		portalTokenProcessor, ok := portalParamsV4.PortalTokens[tokenID]
+		if !ok {
+			continue
+		}
+		for _, batch := range batches {
+			batchStatus, err := httpServer.blockService.GetPortalBatchUnshieldingRequestStatus(batch.GetBatchID())
+			if err != nil {
+				return nil, rpcservice.NewRPCError(rpcservice.GetPortalV4PendingUnshieldBatchesError, err)
+			}
+			pendingBatch := jsonresult.PortalV4PendingUnshieldBatch{
+				BatchID:         batch.GetBatchID(),
+				TokenID:         tokenID,
+				UnshieldIDs:     batch.GetUnshieldRequests(),
+				UTXOs:           batch.GetUTXOs(),
+				BeaconHeight:    batchStatus.BeaconHeight,
+				RawExternalTx:   batchStatus.RawExternalTx,
+				FeeReplacements: []jsonresult.PortalV4FeeReplacement{},
+			}
+			if beaconHeight > batchStatus.BeaconHeight {
+				pendingBatch.PendingBeaconBlocks = beaconHeight - batchStatus.BeaconHeight
+			}
+
+			// list the external fees by beacon height, the latest one is the current fee
+			feeHeights := []uint64{}
+			for height := range batch.GetExternalFees() {
+				feeHeights = append(feeHeights, height)
+			}
+			sort.Slice(feeHeights, func(i, j int) bool {
+				return feeHeights[i] < feeHeights[j]
+			})
+			for _, height := range feeHeights {
+				feeInfo := batch.GetExternalFees()[height]
+				replacement := jsonresult.PortalV4FeeReplacement{
+					BeaconHeight:  height,
+					NetworkFee:    feeInfo.NetworkFee,
+					RBFReqIncTxID: feeInfo.RBFReqIncTxID,
+					RawExternalTx: batchStatus.RawExternalTx,
+				}
+				if feeInfo.RBFReqIncTxID != "" {
+					replacementStatus, err := httpServer.blockService.GetPortalReqReplacementFeeStatus(feeInfo.RBFReqIncTxID)
+					if err != nil {
+						return nil, rpcservice.NewRPCError(rpcservice.GetPortalV4PendingUnshieldBatchesError, err)
+					}
+					replacement.RawExternalTx = replacementStatus.ExternalRawTx
+				}
+				replacement.ExternalTxID, _ = portalTokenProcessor.GetTxHashFromRawTx(replacement.RawExternalTx)
+				pendingBatch.FeeReplacements = append(pendingBatch.FeeReplacements, replacement)
+				pendingBatch.RawExternalTx = replacement.RawExternalTx
+				pendingBatch.ExternalTxID = replacement.ExternalTxID
+				pendingBatch.CurrentFee = replacement.NetworkFee
+			}
+			if len(feeHeights) > 0 {
+				pendingBatch.CanReplaceFee = bc.CheckBlockTimeIsReachedByBeaconHeight(
+					beaconHeight+1, feeHeights[len(feeHeights)-1], portalParamsV4.TimeSpaceForFeeReplacement)
+			}
+
+			pendingBatch.FeeEstimation, err = portalParamsV4.EstimateUnshieldBatchFee(
+				tokenID, pendingBatch.UTXOs, len(pendingBatch.UnshieldIDs), pendingBatch.CurrentFee, bc)
+			if err != nil {
+				Logger.log.Warnf("Estimate fee of unshield batch %v error: %v", pendingBatch.BatchID, err)
+			}
+			result = append(result, pendingBatch)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].BeaconHeight != result[j].BeaconHeight {
+			return result[i].BeaconHeight < result[j].BeaconHeight
+		}
+		return result[i].BatchID < result[j].BatchID
+	})
+	return result, nil
+}
+
 /*
  ====== Submit confirmed external tx
 */
@@ -812,13 +955,22 @@ func (httpServer *HttpServer) handleGenerateShieldingMultisigAddress(
 			errors.New("TokenID is not a portal token"))
 	}
 
-	// generate shielding multisig address
-	_, shieldingAddress, err := portalParamV4.PortalTokens[tokenID].GenerateOTMultisigAddress(
+	// generate shielding multisig address, the first one is P2WSH vault address and the second one is taproot vault address if enabled
+	shieldingAddresses, err := portalParamV4.PortalTokens[tokenID].GenerateOTDepositAddresses(
 		portalParamV4.MasterPubKeys[tokenID], int(portalParamV4.NumRequiredSigs), incAddressStr)
 	if err != nil {
 		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError,
 			fmt.Errorf("Error when generating multisig address %v\n", err))
 	}
 
-	return shieldingAddress, nil
+	// optional param: Taproot
+	if isTaproot, ok := data["Taproot"].(bool); ok && isTaproot {
+		if len(shieldingAddresses) < 2 {
+			return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError,
+				errors.New("Taproot vault is not enabled for this token"))
+		}
+		return shieldingAddresses[1], nil
+	}
+
+	return shieldingAddresses[0], nil
 }
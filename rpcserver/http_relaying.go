@@ -25,6 +25,14 @@ func (httpServer *HttpServer) handleCreateRawTxWithRelayingBTCHeader(params inte
 	)
 }
 
+func (httpServer *HttpServer) handleCreateRawTxWithRelayingLTCHeader(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
+	return httpServer.handleCreateRawTxWithRelayingHeader(
+		metadata.RelayingLTCHeaderMeta,
+		params,
+		closeChan,
+	)
+}
+
 func (httpServer *HttpServer) handleCreateRawTxWithRelayingBNBHeader(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
 	return httpServer.handleCreateRawTxWithRelayingHeader(
 		metadata.RelayingBNBHeaderMeta,
@@ -130,9 +138,26 @@ func (httpServer *HttpServer) handleCreateAndSendTxWithRelayingBTCHeader(params
 	return result, nil
 }
 
+func (httpServer *HttpServer) handleCreateAndSendTxWithRelayingLTCHeader(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
+	data, err := httpServer.handleCreateRawTxWithRelayingLTCHeader(params, closeChan)
+	if err != nil {
+		return nil, rpcservice.NewRPCError(rpcservice.UnexpectedError, err)
+	}
+	tx := data.(jsonresult.CreateTransactionResult)
+	base58CheckData := tx.Base58CheckData
+	newParam := make([]interface{}, 0)
+	newParam = append(newParam, base58CheckData)
+	sendResult, err := httpServer.handleSendRawTransaction(newParam, closeChan)
+	if err != nil {
+		return nil, rpcservice.NewRPCError(rpcservice.UnexpectedError, err)
+	}
+	result := jsonresult.NewCreateTransactionResult(nil, sendResult.(jsonresult.CreateTransactionResult).TxID, nil, sendResult.(jsonresult.CreateTransactionResult).ShardID)
+	return result, nil
+}
+
 func (httpServer *HttpServer) handleGetRelayingBNBHeaderState(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
 	bc := httpServer.config.BlockChain
-	relayingState, err := portalrelaying.InitRelayingHeaderChainStateFromDB(bc.GetBNBHeaderChain(), bc.GetBTCHeaderChain())
+	relayingState, err := portalrelaying.InitRelayingHeaderChainStateFromDB(bc.GetBNBHeaderChain(), bc.GetBTCHeaderChain(), bc.GetLTCHeaderChain())
 	if err != nil {
 		return nil, rpcservice.NewRPCError(rpcservice.GetRelayingBNBHeaderError, err)
 	}
@@ -182,6 +207,16 @@ func (httpServer *HttpServer) handleGetBTCRelayingBestState(params interface{},
 	return bestState, nil
 }
 
+func (httpServer *HttpServer) handleGetLTCRelayingBestState(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
+	bc := httpServer.config.BlockChain
+	ltcChain := bc.GetLTCHeaderChain()
+	if ltcChain == nil {
+		return nil, rpcservice.NewRPCError(rpcservice.GetLTCRelayingBestState, errors.New("LTC relaying chain should not be null"))
+	}
+	bestState := ltcChain.BestSnapshot()
+	return bestState, nil
+}
+
 func (httpServer *HttpServer) handleGetLatestBNBHeaderBlockHeight(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
 	bc := httpServer.config.BlockChain
 	result, err := portalrelaying.GetLatestBNBBlockHeight(bc.GetBNBHeaderChain())
@@ -18,6 +18,7 @@ import (
 	"github.com/incognitochain/incognito-chain/rpcserver/bean"
 	"github.com/incognitochain/incognito-chain/rpcserver/jsonresult"
 	"github.com/incognitochain/incognito-chain/rpcserver/rpcservice"
+	"github.com/incognitochain/incognito-chain/transaction/offline"
 	"github.com/incognitochain/incognito-chain/utils"
 	"github.com/incognitochain/incognito-chain/wallet"
 	"github.com/incognitochain/incognito-chain/wire"
@@ -814,6 +815,96 @@ func (httpServer *HttpServer) handleRandomCommitmentsAndPublicKeys(params interf
 	return result, nil
 }
 
+// handleCreateUnsignedTransactionV2 - assemble a tx ver 2 without the private key of the sender, to be signed by an offline signer
+// then sent with sendtransaction, or sendrawprivacycustomtokentransaction for a token tx.
+// The coins of the txs signed but not confirmed yet must be in ExcludedCoins, the node can not tell if they are spent
+func (httpServer *HttpServer) handleCreateUnsignedTransactionV2(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
+	arrayParams := common.InterfaceSlice(params)
+	if len(arrayParams) < 1 {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("param must be an array at least 1 element"))
+	}
+	reader := &struct {
+		PaymentAddress string
+		OTAKey         string
+		ReadonlyKey    string
+		Fee            interface{}
+		Info           string
+		Receivers      map[string]interface{}
+		TokenID        string
+		TokenReceivers map[string]interface{}
+		ExcludedCoins  []string
+	}{}
+	rawData, err := json.Marshal(arrayParams[0])
+	if err != nil {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, err)
+	}
+	if err := json.Unmarshal(rawData, reader); err != nil {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, err)
+	}
+
+	keySet := &incognitokey.KeySet{}
+	for _, keyStr := range []string{reader.PaymentAddress, reader.OTAKey, reader.ReadonlyKey} {
+		keyWallet, err := wallet.Base58CheckDeserialize(keyStr)
+		if err != nil {
+			return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, fmt.Errorf("cannot deserialize key %v: %v", keyStr, err))
+		}
+		if len(keyWallet.KeySet.PrivateKey) > 0 {
+			return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("private key must not be sent to the node"))
+		}
+		if len(keyWallet.KeySet.PaymentAddress.Pk) > 0 {
+			keySet.PaymentAddress = keyWallet.KeySet.PaymentAddress
+		}
+		if keyWallet.KeySet.OTAKey.GetOTASecretKey() != nil {
+			keySet.OTAKey = keyWallet.KeySet.OTAKey
+		}
+		if len(keyWallet.KeySet.ReadonlyKey.Rk) > 0 {
+			keySet.ReadonlyKey = keyWallet.KeySet.ReadonlyKey
+		}
+	}
+	if keySet.PaymentAddress.GetOTAPublicKey() == nil || keySet.OTAKey.GetOTASecretKey() == nil || keySet.ReadonlyKey.GetPrivateView() == nil {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("PaymentAddress, OTAKey and ReadonlyKey of the sender are required"))
+	}
+	fee, err := common.AssertAndConvertNumber(reader.Fee)
+	if err != nil {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, fmt.Errorf("fee is invalid: %v", err))
+	}
+	if fee == 0 {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("fee must be greater than zero"))
+	}
+	receivers, err := newOfflineTxReceivers(reader.Receivers)
+	if err != nil {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, err)
+	}
+	tokenID := &common.PRVCoinID
+	if reader.TokenID != "" {
+		if tokenID, err = new(common.Hash).NewHashFromStr(reader.TokenID); err != nil {
+			return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, fmt.Errorf("tokenID %v is invalid", reader.TokenID))
+		}
+	}
+	tokenReceivers, err := newOfflineTxReceivers(reader.TokenReceivers)
+	if err != nil {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, err)
+	}
+	excludedCoins := make(map[string]bool)
+	for _, publicKey := range reader.ExcludedCoins {
+		excludedCoins[publicKey] = true
+	}
+
+	return httpServer.txService.BuildUnsignedTransactionV2(keySet, receivers, fee, []byte(reader.Info), tokenID, tokenReceivers, excludedCoins)
+}
+
+func newOfflineTxReceivers(receiversParam map[string]interface{}) ([]offline.Receiver, error) {
+	receivers := make([]offline.Receiver, 0, len(receiversParam))
+	for paymentAddress, amountParam := range receiversParam {
+		amount, err := common.AssertAndConvertNumber(amountParam)
+		if err != nil {
+			return nil, fmt.Errorf("amount of receiver %v is invalid: %v", paymentAddress, err)
+		}
+		receivers = append(receivers, offline.Receiver{PaymentAddress: paymentAddress, Amount: amount})
+	}
+	return receivers, nil
+}
+
 // handleListSerialNumbers - return list all serialnumber in shard for token ID
 func (httpServer *HttpServer) handleListSerialNumbers(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
 	arrayParams := common.InterfaceSlice(params)
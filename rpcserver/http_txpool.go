@@ -3,6 +3,7 @@ package rpcserver
 import (
 	"errors"
 	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/consensus_v2/equivocation"
 	"github.com/incognitochain/incognito-chain/incognitokey"
 	"github.com/incognitochain/incognito-chain/metadata"
 	"github.com/incognitochain/incognito-chain/rpcserver/jsonresult"
@@ -163,3 +164,11 @@ func (httpServer *HttpServer) handleGetSyncPoolValidatorDetail(params interface{
 
 	return validatorsDetail, nil
 }
+
+// handleGetEquivocationEvidencePool - list double vote evidences waiting to be included in beacon block
+func (httpServer *HttpServer) handleGetEquivocationEvidencePool(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
+	if equivocation.DefaultEvidencePool == nil {
+		return nil, rpcservice.NewRPCError(rpcservice.UnexpectedError, errors.New("equivocation evidence pool is not initialized"))
+	}
+	return equivocation.DefaultEvidencePool.GetEvidences(), nil
+}
@@ -0,0 +1,46 @@
+package rpcserver
+
+import (
+	"errors"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/rpcserver/rpcservice"
+)
+
+const defaultValidatorPerformanceEpochs = 10
+
+// parseValidatorPerformanceParams read committee public key and optional number of history epochs
+func parseValidatorPerformanceParams(params interface{}) (string, uint64, error) {
+	arrayParams := common.InterfaceSlice(params)
+	if len(arrayParams) < 1 || len(arrayParams) > 2 {
+		return "", 0, errors.New("Methods should contain committee public key and optional number of epochs")
+	}
+	committeePublicKey, ok := arrayParams[0].(string)
+	if !ok || committeePublicKey == "" {
+		return "", 0, errors.New("Invalid committee public key")
+	}
+	numberOfEpoch := uint64(defaultValidatorPerformanceEpochs)
+	if len(arrayParams) == 2 {
+		temp, ok := arrayParams[1].(float64)
+		if !ok || temp < 0 {
+			return "", 0, errors.New("Invalid number of epochs")
+		}
+		numberOfEpoch = uint64(temp)
+	}
+	return committeePublicKey, numberOfEpoch, nil
+}
+
+// handleGetValidatorPerformance return signed and expected blocks of current epoch, projected slashing penalty
+// and per epoch performance history of a committee public key
+func (httpServer *HttpServer) handleGetValidatorPerformance(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
+	committeePublicKey, numberOfEpoch, err := parseValidatorPerformanceParams(params)
+	if err != nil {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, err)
+	}
+	bc := httpServer.config.BlockChain
+	res, err := bc.GetValidatorPerformance(bc.GetBeaconBestState(), committeePublicKey, numberOfEpoch)
+	if err != nil {
+		return nil, rpcservice.NewRPCError(rpcservice.UnexpectedError, err)
+	}
+	return res, nil
+}
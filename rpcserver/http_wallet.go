@@ -253,6 +253,86 @@ func (httpServer *HttpServer) handleRemoveAccount(params interface{}, closeChan
 	return httpServer.walletService.RemoveAccount(privateKey, passPhrase)
 }
 
+/*
+changewalletpassphrase RPC re-encrypts the wallet of the node, a wallet in the legacy format is saved as a keystore.
+
+- Param #1: passPhrase of wallet
+- Param #2: new passPhrase of wallet
+*/
+func (httpServer *HttpServer) handleChangeWalletPassphrase(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
+	arrayParams := common.InterfaceSlice(params)
+	if arrayParams == nil || len(arrayParams) < 2 {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("param must be an array at least 2 elements"))
+	}
+
+	passPhrase, ok := arrayParams[0].(string)
+	if !ok {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("passPhrase is invalid"))
+	}
+
+	newPassPhrase, ok := arrayParams[1].(string)
+	if !ok || newPassPhrase == "" {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("new passPhrase is invalid"))
+	}
+
+	return httpServer.walletService.ChangePassphrase(passPhrase, newPassPhrase)
+}
+
+/*
+restorewallet RPC restores the accounts of a mnemonic into the wallet of the node by scanning the chain,
+child keys are derived until a gap limit of accounts without any coin is found.
+
+- Param #1: passPhrase of wallet
+- Param #2: mnemonic
+- Param #3: passPhrase the mnemonic was created with
+- Param #4: gap limit (optional, default is 20)
+- Param #5: shard height to scan coins ver 2 from (optional, default is the first height of coins ver 2)
+*/
+func (httpServer *HttpServer) handleRestoreWallet(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
+	if httpServer.config.Wallet == nil {
+		return nil, rpcservice.NewRPCError(rpcservice.UnexpectedError, errors.New("wallet is not existed"))
+	}
+	arrayParams := common.InterfaceSlice(params)
+	if arrayParams == nil || len(arrayParams) < 3 {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("param must be an array at least 3 elements"))
+	}
+
+	passPhrase, ok := arrayParams[0].(string)
+	if !ok {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("passPhrase is invalid"))
+	}
+
+	mnemonic, ok := arrayParams[1].(string)
+	if !ok || mnemonic == "" {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("mnemonic is invalid"))
+	}
+
+	mnemonicPassPhrase, ok := arrayParams[2].(string)
+	if !ok {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("mnemonic passPhrase is invalid"))
+	}
+
+	gapLimit := uint64(0)
+	if len(arrayParams) > 3 {
+		gapLimitParam, err := common.AssertAndConvertNumber(arrayParams[3])
+		if err != nil {
+			return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, fmt.Errorf("gap limit is invalid: %v", err))
+		}
+		gapLimit = gapLimitParam
+	}
+
+	fromHeight := uint64(0)
+	if len(arrayParams) > 4 {
+		fromHeightParam, err := common.AssertAndConvertNumber(arrayParams[4])
+		if err != nil {
+			return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, fmt.Errorf("from height is invalid: %v", err))
+		}
+		fromHeight = fromHeightParam
+	}
+
+	return httpServer.walletService.RestoreWallet(passPhrase, mnemonic, mnemonicPassPhrase, uint32(gapLimit), fromHeight)
+}
+
 // handleGetBalanceByPrivatekey -  return balance of private key
 func (httpServer *HttpServer) handleGetBalanceByPrivatekey(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
 	// all component
@@ -0,0 +1,54 @@
+package jsonresult
+
+import (
+	"github.com/incognitochain/incognito-chain/blockchain"
+)
+
+type AccountLedgerInfo struct {
+	ShardID      byte   `json:"ShardID"`
+	FromHeight   uint64 `json:"FromHeight"`
+	SyncedHeight uint64 `json:"SyncedHeight"`
+}
+
+type AccountLedgerBalance struct {
+	AccountLedgerInfo
+	Balances map[string]uint64 `json:"Balances"` // unspent amount by token id
+}
+
+type AccountLedgerTxs struct {
+	AccountLedgerInfo
+	Page  int                   `json:"Page"`
+	Limit int                   `json:"Limit"`
+	Total int                   `json:"Total"`
+	Txs   []blockchain.LedgerTx `json:"Txs"`
+}
+
+func NewAccountLedgerInfo(account *blockchain.LedgerAccount) AccountLedgerInfo {
+	return AccountLedgerInfo{
+		ShardID:      account.ShardID,
+		FromHeight:   account.FromHeight,
+		SyncedHeight: account.SyncedHeight,
+	}
+}
+
+// NewAccountLedgerTxs return a page of incoming or outgoing txs of an account, newest first.
+// txs must be sorted by shard height, page starts from 0
+func NewAccountLedgerTxs(account *blockchain.LedgerAccount, txs []blockchain.LedgerTx, isOutgoing bool, page, limit int) *AccountLedgerTxs {
+	res := &AccountLedgerTxs{
+		AccountLedgerInfo: NewAccountLedgerInfo(account),
+		Page:              page,
+		Limit:             limit,
+		Txs:               []blockchain.LedgerTx{},
+	}
+	start := page * limit
+	for i := len(txs) - 1; i >= 0; i-- {
+		if txs[i].IsOutgoing() != isOutgoing {
+			continue
+		}
+		if res.Total >= start && len(res.Txs) < limit {
+			res.Txs = append(res.Txs, txs[i])
+		}
+		res.Total++
+	}
+	return res
+}
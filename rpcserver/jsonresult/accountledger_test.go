@@ -0,0 +1,39 @@
+package jsonresult
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/incognitochain/incognito-chain/blockchain"
+)
+
+func TestNewAccountLedgerTxs(t *testing.T) {
+	account := &blockchain.LedgerAccount{ShardID: 1, FromHeight: 10, SyncedHeight: 50}
+	txs := []blockchain.LedgerTx{
+		{TxHash: "a", ShardHeight: 11, Received: map[string]uint64{"prv": 100}},
+		{TxHash: "b", ShardHeight: 12, Received: map[string]uint64{"prv": 40}, Spent: map[string]uint64{"prv": 100}},
+		{CrossShardBlockHash: "c", ShardHeight: 15, Received: map[string]uint64{"token": 7}},
+		{TxHash: "d", ShardHeight: 20, Spent: map[string]uint64{"token": 7}},
+		{TxHash: "e", ShardHeight: 21, Received: map[string]uint64{"prv": 5}},
+	}
+
+	got := NewAccountLedgerTxs(account, txs, false, 0, 2)
+	if got.Total != 3 || !reflect.DeepEqual(got.Txs, []blockchain.LedgerTx{txs[4], txs[2]}) {
+		t.Fatalf("NewAccountLedgerTxs() incoming page 0 = %+v", got)
+	}
+	got = NewAccountLedgerTxs(account, txs, false, 1, 2)
+	if got.Total != 3 || !reflect.DeepEqual(got.Txs, []blockchain.LedgerTx{txs[0]}) {
+		t.Fatalf("NewAccountLedgerTxs() incoming page 1 = %+v", got)
+	}
+	got = NewAccountLedgerTxs(account, txs, true, 0, 10)
+	if got.Total != 2 || !reflect.DeepEqual(got.Txs, []blockchain.LedgerTx{txs[3], txs[1]}) {
+		t.Fatalf("NewAccountLedgerTxs() outgoing = %+v", got)
+	}
+	if got.SyncedHeight != 50 || got.ShardID != 1 {
+		t.Errorf("NewAccountLedgerTxs() account info = %+v", got.AccountLedgerInfo)
+	}
+	got = NewAccountLedgerTxs(account, txs, true, 1, 10)
+	if got.Total != 2 || len(got.Txs) != 0 {
+		t.Errorf("NewAccountLedgerTxs() outgoing page 1 = %+v", got)
+	}
+}
@@ -0,0 +1,81 @@
+package jsonresult
+
+import (
+	"fmt"
+
+	"github.com/incognitochain/incognito-chain/blockchain/committeestate"
+)
+
+type CommitteePoolChange struct {
+	Entered []string `json:"Entered"`
+	Left    []string `json:"Left"`
+}
+
+type CommitteeStateDiffResult struct {
+	FromHeight uint64                               `json:"FromHeight"`
+	ToHeight   uint64                               `json:"ToHeight"`
+	Blocks     []*committeestate.CommitteeStateDiff `json:"Blocks"`
+	Summary    map[string]*CommitteePoolChange      `json:"Summary"` // net change of each pool, key is "pool" or "pool-shardID"
+}
+
+// NewCommitteeStateDiffResult keep moves of publicKey only if publicKey is not empty,
+// blocks without any move are dropped
+func NewCommitteeStateDiffResult(fromHeight, toHeight uint64, diffs []*committeestate.CommitteeStateDiff, publicKey string) *CommitteeStateDiffResult {
+	res := &CommitteeStateDiffResult{
+		FromHeight: fromHeight,
+		ToHeight:   toHeight,
+		Blocks:     []*committeestate.CommitteeStateDiff{},
+		Summary:    make(map[string]*CommitteePoolChange),
+	}
+	// net state of each key in each pool: 1 entered, -1 left, 0 unchanged
+	net := make(map[string]map[string]int)
+	order := make(map[string][]string)
+	for _, diff := range diffs {
+		moves := []committeestate.CommitteeMove{}
+		for _, move := range diff.Moves {
+			if publicKey != "" && move.PublicKey != publicKey {
+				continue
+			}
+			moves = append(moves, move)
+			pool := move.Pool
+			if move.ChainID != committeestate.BEACON_POOL_CHAIN_ID {
+				pool = fmt.Sprintf("%v-%v", move.Pool, move.ChainID)
+			}
+			if _, ok := net[pool]; !ok {
+				net[pool] = make(map[string]int)
+			}
+			if _, ok := net[pool][move.PublicKey]; !ok {
+				order[pool] = append(order[pool], move.PublicKey)
+			}
+			if move.Entered {
+				net[pool][move.PublicKey]++
+			} else {
+				net[pool][move.PublicKey]--
+			}
+		}
+		if len(moves) == 0 {
+			continue
+		}
+		res.Blocks = append(res.Blocks, &committeestate.CommitteeStateDiff{
+			BeaconHeight: diff.BeaconHeight,
+			BeaconHash:   diff.BeaconHash,
+			Moves:        moves,
+		})
+	}
+	for pool, keys := range order {
+		change := &CommitteePoolChange{Entered: []string{}, Left: []string{}}
+		for _, k := range keys {
+			switch {
+			case net[pool][k] > 0:
+				change.Entered = append(change.Entered, k)
+			case net[pool][k] < 0:
+				change.Left = append(change.Left, k)
+			}
+		}
+		if len(change.Entered) == 0 && len(change.Left) == 0 {
+			continue
+		}
+		res.Summary[pool] = change
+	}
+	return res
+}
@@ -0,0 +1,41 @@
+package jsonresult
+
+import (
+	"github.com/incognitochain/incognito-chain/blockchain"
+)
+
+type DelegationPosition struct {
+	Delegator       string `json:"Delegator"` // committee public key of shard staker
+	Delegate        string `json:"Delegate"`
+	DelegateUID     string `json:"DelegateUID"`
+	StartEpoch      uint64 `json:"StartEpoch"`
+	Amount          uint64 `json:"Amount"`
+	StartSharePrice uint64 `json:"StartSharePrice"`
+	SharePrice      uint64 `json:"SharePrice"`
+	Value           uint64 `json:"Value"` // amount adjusted by share price since start epoch
+}
+
+type DelegatorPortfolio struct {
+	PaymentAddress string                `json:"PaymentAddress"`
+	Delegations    []*DelegationPosition `json:"Delegations"`
+	PendingReward  uint64                `json:"PendingReward"`
+}
+
+type DelegationRewardHistory struct {
+	FromEpoch   uint64                             `json:"FromEpoch"`
+	ToEpoch     uint64                             `json:"ToEpoch"`
+	Rewards     []blockchain.DelegationEpochReward `json:"Rewards"`
+	TotalReward uint64                             `json:"TotalReward"`
+}
+
+func NewDelegationRewardHistory(fromEpoch, toEpoch uint64, rewards []blockchain.DelegationEpochReward) *DelegationRewardHistory {
+	res := &DelegationRewardHistory{
+		FromEpoch: fromEpoch,
+		ToEpoch:   toEpoch,
+		Rewards:   rewards,
+	}
+	for _, reward := range rewards {
+		res.TotalReward += reward.Reward
+	}
+	return res
+}
@@ -0,0 +1,10 @@
+package jsonresult
+
+import "github.com/incognitochain/incognito-chain/blockchain"
+
+type FeatureDashboard struct {
+	BeaconHeight uint64                          `json:"BeaconHeight"`
+	Epoch        uint64                          `json:"Epoch"`
+	Features     []*blockchain.FeatureReadiness  `json:"Features"`
+	History      []*blockchain.FeatureActivation `json:"History"`
+}
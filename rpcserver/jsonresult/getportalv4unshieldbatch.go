@@ -0,0 +1,29 @@
+package jsonresult
+
+import (
+	"github.com/incognitochain/incognito-chain/dataaccessobject/statedb"
+	"github.com/incognitochain/incognito-chain/portal/portalv4"
+)
+
+type PortalV4FeeReplacement struct {
+	BeaconHeight  uint64
+	NetworkFee    uint
+	RBFReqIncTxID string
+	ExternalTxID  string
+	RawExternalTx string
+}
+
+type PortalV4PendingUnshieldBatch struct {
+	BatchID             string
+	TokenID             string
+	UnshieldIDs         []string
+	UTXOs               []*statedb.UTXO
+	BeaconHeight        uint64
+	PendingBeaconBlocks uint64
+	RawExternalTx       string
+	ExternalTxID        string
+	CurrentFee          uint
+	CanReplaceFee       bool
+	FeeReplacements     []PortalV4FeeReplacement
+	FeeEstimation       *portalv4.UnshieldBatchFeeEstimation
+}
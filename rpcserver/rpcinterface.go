@@ -15,6 +15,10 @@ var HttpHandler = map[string]httpHandler{
 	stopProfiling:  (*HttpServer).handleStopProfiling,
 	exportMetrics:  (*HttpServer).handleExportMetrics,
 
+	// logging
+	getLogLevels: (*HttpServer).handleGetLogLevels,
+	setLogLevel:  (*HttpServer).handleSetLogLevel,
+
 	// node
 	getNodeRole:              (*HttpServer).handleGetNodeRole,
 	getNetworkInfo:           (*HttpServer).handleGetNetWorkInfo,
@@ -29,14 +33,15 @@ var HttpHandler = map[string]httpHandler{
 	getMaxShardsNumber:       (*HttpServer).handleGetMaxShardsNumber,
 
 	//tx pool
-	getRawMempool:              (*HttpServer).handleGetRawMempool,
-	getSyncPoolValidator:       (*HttpServer).handleGetSyncPoolValidator,
-	getSyncPoolValidatorDetail: (*HttpServer).handleGetSyncPoolValidatorDetail,
-	getNumberOfTxsInMempool:    (*HttpServer).handleGetNumberOfTxsInMempool,
-	getMempoolEntry:            (*HttpServer).handleMempoolEntry,
-	removeTxInMempool:          (*HttpServer).handleRemoveTxInMempool,
-	getMempoolInfo:             (*HttpServer).handleGetMempoolInfo,
-	getPendingTxsInBlockgen:    (*HttpServer).handleGetPendingTxsInBlockgen,
+	getRawMempool:               (*HttpServer).handleGetRawMempool,
+	getSyncPoolValidator:        (*HttpServer).handleGetSyncPoolValidator,
+	getSyncPoolValidatorDetail:  (*HttpServer).handleGetSyncPoolValidatorDetail,
+	getEquivocationEvidencePool: (*HttpServer).handleGetEquivocationEvidencePool,
+	getNumberOfTxsInMempool:     (*HttpServer).handleGetNumberOfTxsInMempool,
+	getMempoolEntry:             (*HttpServer).handleMempoolEntry,
+	removeTxInMempool:           (*HttpServer).handleRemoveTxInMempool,
+	getMempoolInfo:              (*HttpServer).handleGetMempoolInfo,
+	getPendingTxsInBlockgen:     (*HttpServer).handleGetPendingTxsInBlockgen,
 
 	// block pool ver.2
 	// getCrossShardPoolStateV2:    (*HttpServer).handleGetCrossShardPoolStateV2,
@@ -93,6 +98,7 @@ var HttpHandler = map[string]httpHandler{
 	listCommitmentIndices:                   (*HttpServer).handleListCommitmentIndices,
 	decryptoutputcoinbykeyoftransaction:     (*HttpServer).handleDecryptOutputCoinByKeyOfTransaction,
 	randomCommitmentsAndPublicKeys:          (*HttpServer).handleRandomCommitmentsAndPublicKeys,
+	createUnsignedTransactionV2:             (*HttpServer).handleCreateUnsignedTransactionV2,
 
 	createAndSendTransactionV2:                (*HttpServer).handleCreateAndSendTxV2,
 	createAndSendStakingTransactionV2:         (*HttpServer).handleCreateAndSendStakingTxV2,
@@ -111,6 +117,14 @@ var HttpHandler = map[string]httpHandler{
 	getCommitteeState:                  (*HttpServer).handleGetCommitteeState,
 	getDelegationDetail:                (*HttpServer).handleGetDelegationDetail,
 	getDelegationRewardDetail:          (*HttpServer).handleGetDelegationRewardDetail,
+	getDelegatorPortfolio:              (*HttpServer).handleGetDelegatorPortfolio,
+	getDelegationHistory:               (*HttpServer).handleGetDelegationHistory,
+	getDelegationRewardHistory:         (*HttpServer).handleGetDelegationRewardHistory,
+	registerAccountLedger:              (*HttpServer).handleRegisterAccountLedger,
+	submitAccountKeyImages:             (*HttpServer).handleSubmitAccountKeyImages,
+	getAccountLedgerBalance:            (*HttpServer).handleGetAccountLedgerBalance,
+	getAccountIncomingTxs:              (*HttpServer).handleGetAccountIncomingTxs,
+	getAccountOutgoingTxs:              (*HttpServer).handleGetAccountOutgoingTxs,
 	convertPaymentAddress:              (*HttpServer).handleConvertPaymentAddress,
 	getTotalBlockInEpoch:               (*HttpServer).handleGetTotalBlockInEpoch,
 	getDetailBlocksOfEpoch:             (*HttpServer).handleGetDetailBlocksOfEpoch,
@@ -119,6 +133,7 @@ var HttpHandler = map[string]httpHandler{
 
 	getSlashingCommittee:       (*HttpServer).handleGetSlashingCommittee,
 	getSlashingCommitteeDetail: (*HttpServer).handleGetSlashingCommitteeDetail,
+	getValidatorPerformance:    (*HttpServer).handleGetValidatorPerformance,
 	getFinalityProof:           (*HttpServer).handleGetFinalityProof,
 	setConsensusRule:           (*HttpServer).handleSetConsensusRule,
 	getConsensusRule:           (*HttpServer).handleGetConsensusRule,
@@ -223,6 +238,7 @@ var HttpHandler = map[string]httpHandler{
 	getAVAXBurnProof:         (*HttpServer).handleGetAVAXBurnProof,
 	getNearBurnProof:         (*HttpServer).handleGetNearBurnProof,
 	getPrvBurnProof:          (*HttpServer).handleGetPRVBurnProof,
+	getSOLBurnProof:          (*HttpServer).handleGetSOLBurnProof,
 
 	//reward
 	CreateRawWithDrawTransaction:                 (*HttpServer).handleCreateAndSendWithDrawTransaction,
@@ -365,10 +381,12 @@ var HttpHandler = map[string]httpHandler{
 	// relaying
 	createAndSendTxWithRelayingBNBHeader: (*HttpServer).handleCreateAndSendTxWithRelayingBNBHeader,
 	createAndSendTxWithRelayingBTCHeader: (*HttpServer).handleCreateAndSendTxWithRelayingBTCHeader,
+	createAndSendTxWithRelayingLTCHeader: (*HttpServer).handleCreateAndSendTxWithRelayingLTCHeader,
 	getRelayingBNBHeaderState:            (*HttpServer).handleGetRelayingBNBHeaderState,
 	getRelayingBNBHeaderByBlockHeight:    (*HttpServer).handleGetRelayingBNBHeaderByBlockHeight,
 	getBTCRelayingBestState:              (*HttpServer).handleGetBTCRelayingBestState,
 	getBTCBlockByHash:                    (*HttpServer).handleGetBTCBlockByHash,
+	getLTCRelayingBestState:              (*HttpServer).handleGetLTCRelayingBestState,
 	getLatestBNBHeaderBlockHeight:        (*HttpServer).handleGetLatestBNBHeaderBlockHeight,
 
 	// incognnito mode for sc
@@ -387,7 +405,8 @@ var HttpHandler = map[string]httpHandler{
 	getBurnAVAXProofForDepositToSC:                  (*HttpServer).handleGetBurnAVAXProofForDepositToSC,
 
 	//feature stat
-	getFeatureStats: (*HttpServer).hanldeGetFeatureStats,
+	getFeatureStats:     (*HttpServer).hanldeGetFeatureStats,
+	getFeatureDashboard: (*HttpServer).handleGetFeatureDashboard,
 
 	//new pool info
 	getSyncStats:          (*HttpServer).hanldeGetSyncStats,
@@ -424,6 +443,7 @@ var HttpHandler = map[string]httpHandler{
 	getPortalConvertVaultTxStatus:              (*HttpServer).handleGetPortalConvertVaultTxStatus,
 	getPortalV4Params:                          (*HttpServer).handleGetPortalV4Params,
 	generatePortalShieldMultisigAddress:        (*HttpServer).handleGenerateShieldingMultisigAddress,
+	getPortalV4PendingUnshieldBatches:          (*HttpServer).handleGetPortalV4PendingUnshieldBatches,
 
 	// unstake
 	unstake: (*HttpServer).handleCreateUnstakeTransaction,
@@ -433,6 +453,16 @@ var HttpHandler = map[string]httpHandler{
 	getShardStaker:          (*HttpServer).handleGetShardStakerInfo,
 	getBeaconCommitteeState: (*HttpServer).handleGetBeaconCommitteeState,
 	getBeaconCandidateUID:   (*HttpServer).handleGetBeaconCandidateUID,
+	getCommitteeStateDiff:   (*HttpServer).handleGetCommitteeStateDiff,
+
+	// light client
+	getLightBeaconHeaders:     (*HttpServer).handleGetLightBeaconHeaders,
+	getBeaconInstructionProof: (*HttpServer).handleGetBeaconInstructionProof,
+	getTxInclusionProof:       (*HttpServer).handleGetTxInclusionProof,
+
+	// checkpoint fast sync
+	getBeaconCheckpointView: (*HttpServer).handleGetBeaconCheckpointView,
+	getBeaconTrieNodes:      (*HttpServer).handleGetBeaconTrieNodes,
 
 	// prune
 	prune:          (*HttpServer).handlePrune,
@@ -450,6 +480,8 @@ var LimitedHttpHandler = map[string]httpHandler{
 	dumpPrivkey:                      (*HttpServer).handleDumpPrivkey,
 	importAccount:                    (*HttpServer).handleImportAccount,
 	removeAccount:                    (*HttpServer).handleRemoveAccount,
+	changeWalletPassphrase:           (*HttpServer).handleChangeWalletPassphrase,
+	restoreWallet:                    (*HttpServer).handleRestoreWallet,
 	listUnspentOutputCoins:           (*HttpServer).handleListUnspentOutputCoins,
 	listUnspentOutputCoinsFromCache:  (*HttpServer).handleListUnspentOutputCoinsFromCache,
 	getBalance:                       (*HttpServer).handleGetBalance,
@@ -462,6 +494,10 @@ var LimitedHttpHandler = map[string]httpHandler{
 	submitKey:                        (*HttpServer).handleSubmitKey,
 	authorizedSubmitKey:              (*HttpServer).handleAuthorizedSubmitKey,
 	getKeySubmissionInfo:             (*HttpServer).handleGetKeySubmissionInfo,
+
+	// slashing protection
+	exportSlashingProtection: (*HttpServer).handleExportSlashingProtection,
+	importSlashingProtection: (*HttpServer).handleImportSlashingProtection,
 }
 
 var WsHandler = map[string]wsHandler{
@@ -475,6 +511,7 @@ var WsHandler = map[string]wsHandler{
 	subcribeBeaconCandidateByPublickey:          (*WsServer).handleSubcribeBeaconCandidateByPublickey,
 	subcribeBeaconPendingValidatorByPublickey:   (*WsServer).handleSubcribeBeaconPendingValidatorByPublickey,
 	subcribeBeaconCommitteeByPublickey:          (*WsServer).handleSubcribeBeaconCommitteeByPublickey,
+	subcribeValidatorPerformance:                (*WsServer).handleSubcribeValidatorPerformance,
 	subcribeMempoolInfo:                         (*WsServer).handleSubcribeMempoolInfo,
 	subcribeCrossOutputCoinByPrivateKey:         (*WsServer).handleSubcribeCrossOutputCoinByPrivateKey,
 	subcribeCrossCustomTokenPrivacyByPrivateKey: (*WsServer).handleSubcribeCrossCustomTokenPrivacyByPrivateKey,
@@ -483,4 +520,6 @@ var WsHandler = map[string]wsHandler{
 	subcribeBeaconBestStateFromMem:              (*WsServer).handleSubscribeBeaconBestStateFromMem,
 	subcribeBeaconPoolBeststate:                 (*WsServer).handleSubscribeBeaconPoolBestState,
 	subcribeShardPoolBeststate:                  (*WsServer).handleSubscribeShardPoolBeststate,
+	subcribeFeatureThreshold:                    (*WsServer).handleSubscribeFeatureThreshold,
+	subcribeViewEvent:                           (*WsServer).handleSubscribeViewEvent,
 }
@@ -111,6 +111,11 @@ type RpcServerConfig struct {
 	MiningKeys    string // encode of mining key
 	PubSubManager *pubsub.PubSubManager
 	Pruner        *pruner.PrunerManager
+	// get and set the logging level of subsystems at runtime
+	LogLevels interface {
+		GetLogLevels() map[string]string
+		SetLogLevel(subsysID string, logLevel string) error
+	}
 }
 
 func (rpcServer *RpcServer) Init(config *RpcServerConfig) {
@@ -113,6 +113,7 @@ const (
 	GetBTCBlockByHash
 	GetRelayingBNBHeaderError
 	GetLatestBNBHeaderBlockHeightError
+	GetLTCRelayingBestState
 
 	// feature reward
 	GetRewardFeatureByFeatureNameError
@@ -143,6 +144,7 @@ const (
 	GetPortalV4FeeReplacementReqStatusError
 	GetPortalV4SubmitConfirmedTxStatusError
 	GetPortalV4ConvertVaultTxStatusError
+	GetPortalV4PendingUnshieldBatchesError
 
 	CacheQueueError
 
@@ -277,6 +279,7 @@ var ErrCodeMessage = map[int]struct {
 	GetBTCRelayingBestState:                {-10003, "Get BTC relaying best state error"},
 	GetLatestBNBHeaderBlockHeightError:     {-10004, "Get latest bnb header block height error"},
 	GetBTCBlockByHash:                      {-10005, "Get BTC block by hash error"},
+	GetLTCRelayingBestState:                {-10006, "Get LTC relaying best state error"},
 
 	// feature reward
 	GetRewardFeatureByFeatureNameError: {-11001, "Get feature reward by feature name error"},
@@ -309,6 +312,7 @@ var ErrCodeMessage = map[int]struct {
 	GetPortalV4FeeReplacementReqStatusError: {-12504, "Get portal v4 fee replacement request status error"},
 	GetPortalV4SubmitConfirmedTxStatusError: {-12505, "Get portal v4 submit external confirmed tx request status error"},
 	GetPortalV4ConvertVaultTxStatusError:    {-12506, "Get portal v4 convert vault tx request status error"},
+	GetPortalV4PendingUnshieldBatchesError:  {-12507, "Get portal v4 pending unshield batches error"},
 	// bridgeagg
 	GetBridgeAggStateError:                    {-13000, "Get bridge agg state error"},
 	BridgeAggEstimateFeeByBurntAmountError:    {-13001, "Bridge agg estimate fee by burnt amount error"},
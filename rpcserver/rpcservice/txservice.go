@@ -27,6 +27,7 @@ import (
 	"github.com/incognitochain/incognito-chain/rpcserver/bean"
 	"github.com/incognitochain/incognito-chain/rpcserver/jsonresult"
 	"github.com/incognitochain/incognito-chain/transaction"
+	"github.com/incognitochain/incognito-chain/transaction/offline"
 	"github.com/incognitochain/incognito-chain/wallet"
 	"github.com/incognitochain/incognito-chain/wire"
 )
@@ -2622,3 +2623,130 @@ func isTxRelateCommittee(tx metadata.Transaction) bool {
 	}
 	return false
 }
+
+// BuildUnsignedTransactionV2 assemble the skeleton of a tx ver 2 for an offline signer.
+// keySet only holds the view-only keys of the sender: its payment address, OTA key and readonly key.
+// The node can not tell if a coin is spent without the private key, the coins in excludedCoins (base58 public keys) are not chosen
+func (txService TxService) BuildUnsignedTransactionV2(
+	keySet *incognitokey.KeySet, receivers []offline.Receiver, fee uint64, info []byte,
+	tokenID *common.Hash, tokenReceivers []offline.Receiver, excludedCoins map[string]bool,
+) (*offline.UnsignedTx, *RPCError) {
+	pk := keySet.PaymentAddress.Pk
+	if len(pk) == 0 {
+		return nil, NewRPCError(RPCInvalidParamsError, errors.New("payment address is invalid"))
+	}
+	shardID := common.GetShardIDFromLastByte(pk[len(pk)-1])
+	if int(shardID) >= common.MaxShardNumber {
+		return nil, NewRPCError(RPCInvalidParamsError, fmt.Errorf("shardID %v is out of range", shardID))
+	}
+	senderAddress := wallet.KeyWallet{KeySet: *keySet}
+	res := &offline.UnsignedTx{
+		SenderPaymentAddress: senderAddress.Base58CheckSerialize(wallet.PaymentAddressType),
+		Fee:                  fee,
+		Info:                 info,
+	}
+	var err *RPCError
+	res.Receivers, res.InputCoins, res.Decoys, err = txService.chooseOfflineTxCoins(keySet, shardID, &common.PRVCoinID, receivers, fee, excludedCoins)
+	if err != nil {
+		return nil, err
+	}
+	if tokenID != nil && *tokenID != common.PRVCoinID {
+		res.TokenID = tokenID.String()
+		res.TokenReceivers, res.TokenInputCoins, res.TokenDecoys, err = txService.chooseOfflineTxCoins(keySet, shardID, tokenID, tokenReceivers, 0, excludedCoins)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return res, nil
+}
+
+// chooseOfflineTxCoins choose the input coins of tokenID paying the receivers and the fee, add the change to the receivers and pick the decoys of the inputs
+func (txService TxService) chooseOfflineTxCoins(
+	keySet *incognitokey.KeySet, shardID byte, tokenID *common.Hash, receivers []offline.Receiver, fee uint64, excludedCoins map[string]bool,
+) ([]offline.Receiver, []offline.RingCoin, []offline.RingCoin, *RPCError) {
+	totalAmount := fee
+	for _, receiver := range receivers {
+		totalAmount += receiver.Amount
+	}
+	if totalAmount == 0 {
+		return nil, nil, nil, NewRPCError(RPCInvalidParamsError, fmt.Errorf("nothing to pay in token %v", tokenID.String()))
+	}
+	plainCoins, _, err := txService.BlockChain.GetAllOutputCoinsByKeyset(keySet, shardID, tokenID, false)
+	if err != nil {
+		return nil, nil, nil, NewRPCError(GetOutputCoinError, err)
+	}
+	candidateCoins := make([]coin.PlainCoin, 0, len(plainCoins))
+	for _, c := range plainCoins {
+		if c.GetVersion() != 2 || excludedCoins[base58.Base58Check{}.Encode(c.GetPublicKey().ToBytesS(), common.ZeroByte)] {
+			continue
+		}
+		candidateCoins = append(candidateCoins, c)
+	}
+	chosenCoins, _, chosenAmount, err := txService.chooseBestOutCoinsToSpent(candidateCoins, totalAmount)
+	if err != nil {
+		return nil, nil, nil, NewRPCError(GetOutputCoinError, fmt.Errorf("not enough coins of token %v: %v", tokenID.String(), err))
+	}
+	if chosenAmount > totalAmount {
+		senderAddress := wallet.KeyWallet{KeySet: *keySet}
+		receivers = append(receivers, offline.Receiver{
+			PaymentAddress: senderAddress.Base58CheckSerialize(wallet.PaymentAddressType),
+			Amount:         chosenAmount - totalAmount,
+		})
+	}
+
+	// coins of tokens are all stored under the confidential asset ID
+	dbTokenID := common.ConfidentialAssetID
+	if *tokenID == common.PRVCoinID {
+		dbTokenID = common.PRVCoinID
+	}
+	db := txService.BlockChain.GetBestStateShard(shardID).GetCopiedTransactionStateDB()
+	inputCoins := make([]offline.RingCoin, 0, len(chosenCoins))
+	for _, c := range chosenCoins {
+		index, err := statedb.GetOTACoinIndex(db, dbTokenID, c.GetPublicKey().ToBytesS())
+		if err != nil {
+			return nil, nil, nil, NewRPCError(GetOutputCoinError, err)
+		}
+		// the decrypted coin is not the on-chain one, read it again
+		coinBytes, err := statedb.GetOTACoinByIndex(db, dbTokenID, index.Uint64(), shardID)
+		if err != nil {
+			return nil, nil, nil, NewRPCError(GetOutputCoinError, err)
+		}
+		inputCoins = append(inputCoins, offline.RingCoin{Coin: coinBytes, Index: index.Uint64()})
+	}
+	decoys, err := randomOfflineTxDecoys(db, dbTokenID, shardID, len(inputCoins)*(privacy.RingSize-1))
+	if err != nil {
+		return nil, nil, nil, NewRPCError(GetOutputCoinError, err)
+	}
+	return receivers, inputCoins, decoys, nil
+}
+
+// randomOfflineTxDecoys pick num random coins of the shard, burned coins are skipped since they reduce the privacy level of the tx
+func randomOfflineTxDecoys(db *statedb.StateDB, dbTokenID common.Hash, shardID byte, num int) ([]offline.RingCoin, error) {
+	lenOTA, err := statedb.GetOTACoinLength(db, dbTokenID, shardID)
+	if err != nil || lenOTA == nil || lenOTA.Sign() == 0 {
+		return nil, fmt.Errorf("no coin of %v in shard %v to form the ring, error = %v", dbTokenID.String(), shardID, err)
+	}
+	res := make([]offline.RingCoin, 0, num)
+	for attempts := 0; len(res) < num && attempts < privacy.MaxPrivacyAttempts; attempts++ {
+		index, err := common.RandBigIntMaxRange(lenOTA)
+		if err != nil {
+			return nil, err
+		}
+		coinBytes, err := statedb.GetOTACoinByIndex(db, dbTokenID, index.Uint64(), shardID)
+		if err != nil {
+			return nil, err
+		}
+		c := new(coin.CoinV2)
+		if err := c.SetBytes(coinBytes); err != nil {
+			return nil, err
+		}
+		if common.IsPublicKeyBurningAddress(c.GetPublicKey().ToBytesS()) {
+			continue
+		}
+		res = append(res, offline.RingCoin{Coin: coinBytes, Index: index.Uint64()})
+	}
+	if len(res) < num {
+		return nil, fmt.Errorf("cannot form decoys")
+	}
+	return res, nil
+}
@@ -133,6 +133,52 @@ func (walletService *WalletService) RemoveAccount(privateKey string, passPhrase
 	return true, nil
 }
 
+func (walletService *WalletService) ChangePassphrase(oldPassPhrase string, newPassPhrase string) (bool, *RPCError) {
+	err := walletService.Wallet.ChangePassphrase(oldPassPhrase, newPassPhrase)
+	if err != nil {
+		return false, NewRPCError(UnexpectedError, err)
+	}
+	return true, nil
+}
+
+// RestoreWallet replaces the seed and the derived accounts of the wallet by the ones of mnemonic, the imported accounts are kept.
+// Accounts are derived until gapLimit accounts in a row have never received coins, coins ver 2 are scanned from fromHeight
+func (walletService *WalletService) RestoreWallet(passPhrase string, mnemonic string, mnemonicPassPhrase string, gapLimit uint32, fromHeight uint64) ([]wallet.KeySerializedData, *RPCError) {
+	if passPhrase != walletService.Wallet.PassPhrase {
+		return nil, NewRPCError(UnexpectedError, wallet.NewWalletError(wallet.WrongPassphraseErr, nil))
+	}
+	restored := new(wallet.Wallet)
+	err := restored.Restore(mnemonic, mnemonicPassPhrase, walletService.Wallet.Name, gapLimit, func(key *wallet.KeyWallet) (bool, error) {
+		return walletService.BlockChain.HasOutputCoinsByKeyset(&key.KeySet, fromHeight)
+	})
+	if err != nil {
+		return nil, NewRPCError(UnexpectedError, err)
+	}
+
+	result := make([]wallet.KeySerializedData, 0, len(restored.MasterAccount.Child))
+	for _, account := range restored.MasterAccount.Child {
+		result = append(result, wallet.KeySerializedData{
+			PaymentAddress: account.Key.Base58CheckSerialize(wallet.PaymentAddressType),
+			Pubkey:         hex.EncodeToString(account.Key.KeySet.PaymentAddress.Pk),
+			ReadonlyKey:    account.Key.Base58CheckSerialize(wallet.ReadonlyKeyType),
+		})
+	}
+	for _, account := range walletService.Wallet.MasterAccount.Child {
+		if account.IsImported {
+			restored.MasterAccount.Child = append(restored.MasterAccount.Child, account)
+		}
+	}
+
+	walletService.Wallet.Seed = restored.Seed
+	walletService.Wallet.Entropy = restored.Entropy
+	walletService.Wallet.Mnemonic = restored.Mnemonic
+	walletService.Wallet.MasterAccount = restored.MasterAccount
+	if err := walletService.Wallet.Save(passPhrase); err != nil {
+		return nil, NewRPCError(UnexpectedError, err)
+	}
+	return result, nil
+}
+
 func (walletService WalletService) GetBalanceByPrivateKey(privateKey string) (uint64, *RPCError) {
 	keySet, shardIDSender, err := GetKeySetFromPrivateKeyParams(privateKey)
 	if err != nil {
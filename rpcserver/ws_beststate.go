@@ -9,6 +9,7 @@ import (
 	"github.com/incognitochain/incognito-chain/blockchain"
 	"github.com/incognitochain/incognito-chain/common"
 	"github.com/incognitochain/incognito-chain/dataaccessobject/rawdbv2"
+	"github.com/incognitochain/incognito-chain/multiview"
 	"github.com/incognitochain/incognito-chain/pubsub"
 	"github.com/incognitochain/incognito-chain/rpcserver/jsonresult"
 	"github.com/incognitochain/incognito-chain/rpcserver/rpcservice"
@@ -175,3 +176,86 @@ func (wsServer *WsServer) handleSubscribeBeaconBestStateFromMem(params interface
 		}
 	}
 }
+
+// handleSubscribeFeatureThreshold push an event when an auto enable feature becomes ready, not ready or is triggered
+func (wsServer *WsServer) handleSubscribeFeatureThreshold(params interface{}, subcription string, cResult chan RpcSubResult, closeChan <-chan struct{}) {
+	subId, subChan, err := wsServer.config.PubSubManager.RegisterNewSubscriber(pubsub.FeatureThresholdTopic)
+	if err != nil {
+		err := rpcservice.NewRPCError(rpcservice.SubcribeError, err)
+		cResult <- RpcSubResult{Error: err}
+		return
+	}
+	defer func() {
+		Logger.log.Info("Finish Subscribe Feature Threshold")
+		wsServer.config.PubSubManager.Unsubscribe(pubsub.FeatureThresholdTopic, subId)
+		close(cResult)
+	}()
+	for {
+		select {
+		case msg := <-subChan:
+			{
+				event, ok := msg.Value.(*blockchain.FeatureThresholdEvent)
+				if !ok {
+					Logger.log.Errorf("Wrong Message Type from Pubsub Manager, wanted *blockchain.FeatureThresholdEvent, have %+v", reflect.TypeOf(msg.Value))
+					continue
+				}
+				cResult <- RpcSubResult{Result: event, Error: nil}
+			}
+		case <-closeChan:
+			{
+				cResult <- RpcSubResult{Result: jsonresult.UnsubcribeResult{Message: "Unsubscribe Feature Threshold"}}
+				return
+			}
+		}
+	}
+}
+
+// handleSubscribeViewEvent push new best view, branch switch and finalize view events of beacon and shard chains
+// params: chainID (optional, -1 for beacon chain, push events of all chains if omitted)
+func (wsServer *WsServer) handleSubscribeViewEvent(params interface{}, subcription string, cResult chan RpcSubResult, closeChan <-chan struct{}) {
+	arrayParams := common.InterfaceSlice(params)
+	filterChain := false
+	chainID := 0
+	if len(arrayParams) > 0 {
+		chainIDParam, ok := arrayParams[0].(float64)
+		if !ok {
+			err := rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("chainID is invalid"))
+			cResult <- RpcSubResult{Error: err}
+			return
+		}
+		filterChain = true
+		chainID = int(chainIDParam)
+	}
+	subId, subChan, err := wsServer.config.PubSubManager.RegisterNewSubscriber(pubsub.ViewEventTopic)
+	if err != nil {
+		err := rpcservice.NewRPCError(rpcservice.SubcribeError, err)
+		cResult <- RpcSubResult{Error: err}
+		return
+	}
+	defer func() {
+		Logger.log.Info("Finish Subscribe View Event")
+		wsServer.config.PubSubManager.Unsubscribe(pubsub.ViewEventTopic, subId)
+		close(cResult)
+	}()
+	for {
+		select {
+		case msg := <-subChan:
+			{
+				event, ok := msg.Value.(*multiview.ViewEvent)
+				if !ok {
+					Logger.log.Errorf("Wrong Message Type from Pubsub Manager, wanted *multiview.ViewEvent, have %+v", reflect.TypeOf(msg.Value))
+					continue
+				}
+				if filterChain && event.ChainID != chainID {
+					continue
+				}
+				cResult <- RpcSubResult{Result: event, Error: nil}
+			}
+		case <-closeChan:
+			{
+				cResult <- RpcSubResult{Result: jsonresult.UnsubcribeResult{Message: "Unsubscribe View Event"}}
+				return
+			}
+		}
+	}
+}
@@ -398,3 +398,49 @@ func (wsServer *WsServer) handleSubcribeBeaconCommitteeByPublickey(params interf
 		}
 	}
 }
+
+// handleSubcribeValidatorPerformance push validator performance of a committee public key on every new beacon block
+func (wsServer *WsServer) handleSubcribeValidatorPerformance(params interface{}, subcription string, cResult chan RpcSubResult, closeChan <-chan struct{}) {
+	committeePublicKey, numberOfEpoch, err := parseValidatorPerformanceParams(params)
+	if err != nil {
+		err := rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, err)
+		cResult <- RpcSubResult{Error: err}
+		return
+	}
+	subId, subChan, err := wsServer.config.PubSubManager.RegisterNewSubscriber(pubsub.NewBeaconBlockTopic)
+	if err != nil {
+		err := rpcservice.NewRPCError(rpcservice.SubcribeError, err)
+		cResult <- RpcSubResult{Error: err}
+		return
+	}
+	defer func() {
+		Logger.log.Info("Finish Subscribe Validator Performance", committeePublicKey)
+		wsServer.config.PubSubManager.Unsubscribe(pubsub.NewBeaconBlockTopic, subId)
+		close(cResult)
+	}()
+	bc := wsServer.config.BlockChain
+	for {
+		select {
+		case msg := <-subChan:
+			{
+				_, ok := msg.Value.(*types.BeaconBlock)
+				if !ok {
+					Logger.log.Errorf("Wrong Message Type from Pubsub Manager, wanted *blockchain.BeaconBlock, have %+v", reflect.TypeOf(msg.Value))
+					continue
+				}
+				res, err := bc.GetValidatorPerformance(bc.GetBeaconBestState(), committeePublicKey, numberOfEpoch)
+				if err != nil {
+					err := rpcservice.NewRPCError(rpcservice.SubcribeError, err)
+					cResult <- RpcSubResult{Error: err}
+					return
+				}
+				cResult <- RpcSubResult{Result: res, Error: nil}
+			}
+		case <-closeChan:
+			{
+				cResult <- RpcSubResult{Result: jsonresult.UnsubcribeResult{Message: "Unsubscribe Validator Performance"}}
+				return
+			}
+		}
+	}
+}
@@ -3,6 +3,7 @@ package main
 import (
 	"context"
 	"crypto/tls"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"log"
@@ -17,6 +18,7 @@ import (
 	"time"
 
 	"github.com/incognitochain/incognito-chain/consensus_v2/blsbft"
+	"github.com/incognitochain/incognito-chain/consensus_v2/consensustypes"
 	"github.com/incognitochain/incognito-chain/pruner"
 
 	p2ppubsub "github.com/incognitochain/go-libp2p-pubsub"
@@ -75,6 +77,7 @@ type Server struct {
 	// userKeySet        *incognitokey.KeySet
 	miningKeys      string
 	privateKey      string
+	remoteSigner    string
 	wallet          *wallet.Wallet
 	consensusEngine *consensus.Engine
 	blockgen        *blockchain.BlockGenerator
@@ -196,11 +199,13 @@ func (serverObj *Server) NewServer(
 	listenAddrs string,
 	db map[int]incdb.Database,
 	dbmp databasemp.DatabaseInterface,
+	dbtp databasemp.DatabaseInterface,
 	dboc *incdb.Database,
 	indexerWorkers int64,
 	indexerToken string,
 	protocolVer string,
 	btcChain *btcrelaying.BlockChain,
+	ltcChain *btcrelaying.BlockChain,
 	bnbChainState *bnbrelaying.BNBChainState,
 	p *pruner.PrunerManager,
 	interrupt <-chan struct{},
@@ -225,7 +230,7 @@ func (serverObj *Server) NewServer(
 
 	serverObj.miningKeys = cfg.MiningKeys
 	serverObj.privateKey = cfg.PrivateKey
-	serverObj.miningKeys = cfg.MiningKeys
+	serverObj.remoteSigner = cfg.RemoteSigner
 
 	// if serverObj.miningKeys == "" && serverObj.privateKey == "" {
 	// 	if cfg.NodeMode == common.NodeModeAuto || cfg.NodeMode == common.NodeModeBeacon || cfg.NodeMode == common.NodeModeShard {
@@ -285,10 +290,11 @@ func (serverObj *Server) NewServer(
 			OnAddr:           serverObj.OnAddr,
 
 			//mubft
-			OnBFTMsg:     serverObj.OnBFTMsg,
-			OnPeerState:  serverObj.OnPeerState,
-			OnFinishSync: serverObj.OnFinishSync,
-			OnFeatureMsg: serverObj.OnFeatureMsg,
+			OnBFTMsg:       serverObj.OnBFTMsg,
+			OnPeerState:    serverObj.OnPeerState,
+			OnFinishSync:   serverObj.OnFinishSync,
+			OnFeatureMsg:   serverObj.OnFeatureMsg,
+			OnEquivocation: serverObj.OnEquivocation,
 		},
 		BC: serverObj.blockChain,
 	}
@@ -312,8 +318,14 @@ func (serverObj *Server) NewServer(
 		serverObj.pusubManager,
 		time.Duration(cfg.TxPoolTTL)*time.Second,
 	)
+	if dbtp != nil {
+		if err := poolManager.LoadOrResetDatabase(dbtp, cfg.IsLoadFromMempool); err != nil {
+			Logger.log.Errorf("Fail to load tx pool database, error: %+v \n", err)
+		}
+	}
 	err = serverObj.blockChain.Init(&blockchain.Config{
 		BTCChain:      btcChain,
+		LTCChain:      ltcChain,
 		BNBChainState: bnbChainState,
 		DataBase:      serverObj.dataBase,
 		MemCache:      serverObj.memCache,
@@ -552,6 +564,7 @@ func (serverObj *Server) NewServer(
 			MemCache:        serverObj.memCache,
 			Syncker:         serverObj.syncker,
 			Pruner:          p,
+			LogLevels:       logLevelController{},
 		}
 		serverObj.rpcServer = &rpcserver.RpcServer{}
 		serverObj.rpcServer.Init(&rpcConfig)
@@ -740,7 +753,7 @@ func (serverObj Server) Start() {
 		serverObj.rpcServer.Start()
 	}
 
-	if cfg.MiningKeys != "" || cfg.PrivateKey != "" {
+	if cfg.MiningKeys != "" || cfg.PrivateKey != "" || cfg.RemoteSigner != "" {
 		serverObj.memPool.IsBlockGenStarted = true
 		serverObj.blockChain.SetIsBlockGenStarted(true)
 	}
@@ -967,6 +980,7 @@ func (serverObj *Server) NewPeerConfig() *peer.Config {
 			//
 			OnFinishSync:         serverObj.OnFinishSync,
 			OnFeatureMsg:         serverObj.OnFeatureMsg,
+			OnEquivocation:       serverObj.OnEquivocation,
 			PushRawBytesToShard:  serverObj.PushRawBytesToShard,
 			PushRawBytesToBeacon: serverObj.PushRawBytesToBeacon,
 			GetCurrentRoleShard:  serverObj.GetCurrentRoleShard,
@@ -993,6 +1007,22 @@ func (serverObj *Server) OnFinishSync(p *peer.PeerConn, msg *wire.MessageFinishS
 	}
 }
 
+var equivocationMessageHistory = metrics.NewRegisteredCounter("message/equivocation", nil)
+
+// OnEquivocation handle double vote evidence message
+func (serverObj *Server) OnEquivocation(p *peer.PeerConn, msg *wire.MessageEquivocation) {
+	equivocationMessageHistory.Inc(1)
+	evidence := &consensustypes.EquivocationEvidence{}
+	if err := json.Unmarshal(msg.Evidence, evidence); err != nil {
+		Logger.log.Error("Receive an invalid MsgEquivocation", err)
+		return
+	}
+	Logger.log.Info("Receive a MsgEquivocation of validator", evidence.Validator())
+	if err := serverObj.blockChain.AddEquivocationEvidence(evidence); err != nil {
+		Logger.log.Error(err)
+	}
+}
+
 // OnFeatureMsg handle feature message
 func (serverObj *Server) OnFeatureMsg(p *peer.PeerConn, msg *wire.MessageFeature) {
 	blockchain.DefaultFeatureStat.ReceiveMsg(msg)
@@ -1250,7 +1280,7 @@ func (serverObj *Server) GetPeerIDsFromPublicKey(pubKey string) []libp2p.ID {
 }
 
 func (serverObj *Server) GetNodeRole() string {
-	if serverObj.miningKeys == "" && serverObj.privateKey == "" {
+	if serverObj.miningKeys == "" && serverObj.privateKey == "" && serverObj.remoteSigner == "" {
 		return "RELAY"
 	}
 	role, shardID := serverObj.GetUserMiningState()
@@ -1665,7 +1695,7 @@ func (serverObj *Server) GetChainMiningStatus(chain int) string {
 	if chain >= common.MaxShardNumber || chain < -1 {
 		return notmining
 	}
-	if config.Config().MiningKeys != "" || config.Config().PrivateKey != "" {
+	if config.Config().MiningKeys != "" || config.Config().PrivateKey != "" || config.Config().RemoteSigner != "" {
 		//Beacon: chain = -1
 		role, chainID := serverObj.GetUserMiningState()
 		layer := ""
@@ -1729,6 +1759,10 @@ func (serverObj *Server) GetPrivateKey() string {
 	return serverObj.privateKey
 }
 
+func (serverObj *Server) GetRemoteSigner() string {
+	return serverObj.remoteSigner
+}
+
 func (serverObj *Server) PushMessageToChain(msg wire.Message, chain common.ChainInterface) error {
 	chainID := chain.GetShardID()
 	if chainID == -1 {
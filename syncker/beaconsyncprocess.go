@@ -40,6 +40,7 @@ type BeaconSyncProcess struct {
 	lastCrossShardState map[byte]map[byte]uint64
 	lastInsert          string
 	consensus           peerv2.ConsensusData
+	downloader          *blockDownloader
 }
 
 func NewBeaconSyncProcess(network Network, consensus peerv2.ConsensusData, bc *blockchain.BlockChain, chain BeaconChainInterface) *BeaconSyncProcess {
@@ -63,6 +64,9 @@ func NewBeaconSyncProcess(network Network, consensus peerv2.ConsensusData, bc *b
 		lastCrossShardState: make(map[byte]map[byte]uint64),
 		consensus:           consensus,
 	}
+	s.downloader = newBlockDownloader("beacon", network.RequestBeaconBlocksViaStream, func(blk types.BlockInterface) error {
+		return preVerifyBlock(chain, blk)
+	})
 	go s.syncBeacon()
 	go s.insertBeaconBlockFromPool()
 	go s.updateConfirmCrossShard()
@@ -267,6 +271,7 @@ func (s *BeaconSyncProcess) syncBeacon() {
 			continue
 		}
 
+		requestCnt += s.downloadInParallel()
 		for peerID, pState := range s.getBeaconPeerStates() {
 			requestCnt += s.streamFromPeer(peerID, pState)
 		}
@@ -283,6 +288,26 @@ func (s *BeaconSyncProcess) syncBeacon() {
 	}
 }
 
+// downloadInParallel download blocks from all peers in parallel when the chain is far behind, return number of inserted blocks
+func (s *BeaconSyncProcess) downloadInParallel() int {
+	peerHeights := make(map[string]uint64)
+	for peerID, pState := range s.getBeaconPeerStates() {
+		peerHeights[peerID] = pState.BestViewHeight
+	}
+	from, to, peers := parallelSyncRange(s.chain.GetBestViewHeight(), peerHeights)
+	if len(peers) == 0 {
+		return 0
+	}
+	Logger.Infof("Syncker download beacon block from %v to %v from %v peers", from, to, len(peers))
+	inserted, err := s.downloader.download(context.Background(), peers, from, to, func(blocks []types.BlockInterface) (int, error) {
+		return insertBlocks(s.chain, blocks)
+	})
+	if err != nil {
+		Logger.Errorf("Syncker download beacon block fail, inserted %v blocks: %v", inserted, err)
+	}
+	return inserted
+}
+
 func (s *BeaconSyncProcess) streamFromPeer(peerID string, pState BeaconPeerState) (requestCnt int) {
 	if pState.processed {
 		return
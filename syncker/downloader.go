@@ -0,0 +1,358 @@
+package syncker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"runtime"
+	"sort"
+	"time"
+
+	"github.com/incognitochain/incognito-chain/blockchain"
+	"github.com/incognitochain/incognito-chain/blockchain/types"
+)
+
+const (
+	DownloadChunkSize     = 100                   //number of blocks requested from a peer at once
+	DownloadMaxParallel   = 8                     //max number of chunks downloaded at the same time
+	DownloadMaxAttempts   = 3                     //max number of peers a chunk is requested from
+	DownloadChunkTimeout  = time.Minute           //timeout of a chunk request
+	ParallelSyncThreshold = 5 * DownloadChunkSize //use parallel download when the chain is behind more than this number of blocks
+	ParallelSyncTipMargin = DownloadChunkSize     //blocks near the tip are synced by stream, as they could be forked
+)
+
+// blockDownloader download a height range in chunks from several peers in parallel, blocks are pre-verified
+// in parallel and given to the insert function chunk by chunk in height order
+type blockDownloader struct {
+	name        string
+	chunkSize   uint64
+	maxParallel int
+	maxAttempts int
+	timeout     time.Duration
+	request     func(ctx context.Context, peerID string, from uint64, to uint64) (chan types.BlockInterface, error)
+	preVerify   func(blk types.BlockInterface) error
+	verifySem   chan struct{}
+}
+
+type downloadChunk struct {
+	from     uint64
+	to       uint64
+	attempts int
+	tried    map[string]bool
+}
+
+type downloadResult struct {
+	index  int
+	peerID string
+	blocks []types.BlockInterface
+	err    error
+}
+
+type insertResult struct {
+	count int
+	err   error
+}
+
+func newBlockDownloader(
+	name string,
+	request func(ctx context.Context, peerID string, from uint64, to uint64) (chan types.BlockInterface, error),
+	preVerify func(blk types.BlockInterface) error,
+) *blockDownloader {
+	return &blockDownloader{
+		name:        name,
+		chunkSize:   DownloadChunkSize,
+		maxParallel: DownloadMaxParallel,
+		maxAttempts: DownloadMaxAttempts,
+		timeout:     DownloadChunkTimeout,
+		request:     request,
+		preVerify:   preVerify,
+		verifySem:   make(chan struct{}, runtime.NumCPU()),
+	}
+}
+
+func splitChunks(from uint64, to uint64, chunkSize uint64) []*downloadChunk {
+	chunks := []*downloadChunk{}
+	for h := from; h <= to; h += chunkSize {
+		end := h + chunkSize - 1
+		if end > to {
+			end = to
+		}
+		chunks = append(chunks, &downloadChunk{from: h, to: end, tried: make(map[string]bool)})
+	}
+	return chunks
+}
+
+// download fetch blocks [from, to] from peers (peerID -> advertised best height) and insert them in height order.
+// Failed chunks are retried from other peers, it return the number of inserted blocks, which include the blocks
+// of a chunk inserted before its insert error
+func (d *blockDownloader) download(ctx context.Context, peers map[string]uint64, from uint64, to uint64, insert func([]types.BlockInterface) (int, error)) (int, error) {
+	if from > to || len(peers) == 0 {
+		return 0, nil
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	peerIDs := []string{}
+	for peerID := range peers {
+		peerIDs = append(peerIDs, peerID)
+	}
+	sort.Strings(peerIDs)
+
+	chunks := splitChunks(from, to, d.chunkSize)
+	queue := []int{}
+	for i := range chunks {
+		queue = append(queue, i)
+	}
+	results := make(chan downloadResult, len(chunks))
+	insertCh := make(chan []types.BlockInterface, len(chunks))
+	insertedCh := make(chan insertResult, len(chunks))
+	go func() {
+		//once an insert fail, the chunks already queued must not be inserted on top of the failed height
+		var failed error
+		for blocks := range insertCh {
+			if failed == nil {
+				failed = ctx.Err()
+			}
+			if failed != nil {
+				insertedCh <- insertResult{0, failed}
+				continue
+			}
+			count, err := insert(blocks)
+			failed = err
+			insertedCh <- insertResult{count, err}
+		}
+	}()
+	defer close(insertCh)
+
+	busy := make(map[string]bool)
+	failures := make(map[string]int)
+	ready := make(map[int][]types.BlockInterface)
+	//doneChunks count the chunks answered by the insert goroutine (inserted or not), insertedBlocks count the blocks really inserted
+	inflight, pushed, doneChunks, insertedBlocks := 0, 0, 0, 0
+	//a chunk which cannot be downloaded end the range, as blocks after it cannot be inserted
+	end := len(chunks)
+	var endErr, failErr error
+	endAt := func(index int, err error) {
+		if index < end {
+			end = index
+			endErr = err
+		}
+	}
+
+	//pick the free peer having the chunk with the least failures, which is not tried for this chunk.
+	//Between them, the peer with the lowest height is preferred, so peers behind serve the early chunks
+	//and peers at the tip are kept for the chunks only them can serve
+	pickPeer := func(chunk *downloadChunk) string {
+		picked := ""
+		for _, peerID := range peerIDs {
+			if busy[peerID] || chunk.tried[peerID] || peers[peerID] < chunk.to {
+				continue
+			}
+			if picked == "" || failures[peerID] < failures[picked] ||
+				(failures[peerID] == failures[picked] && peers[peerID] < peers[picked]) {
+				picked = peerID
+			}
+		}
+		return picked
+	}
+	//check if any peer could serve the chunk later
+	hasCandidate := func(chunk *downloadChunk) bool {
+		for _, peerID := range peerIDs {
+			if !chunk.tried[peerID] && peers[peerID] >= chunk.to {
+				return true
+			}
+		}
+		return false
+	}
+
+	for {
+		if failErr == nil {
+			//dispatch chunks in height order, at most 2*maxParallel chunks ahead of insertion to bound memory
+			remain := []int{}
+			for _, index := range queue {
+				chunk := chunks[index]
+				if index >= end {
+					continue
+				}
+				if inflight >= d.maxParallel || index >= doneChunks+2*d.maxParallel {
+					remain = append(remain, index)
+					continue
+				}
+				peerID := pickPeer(chunk)
+				if peerID == "" {
+					if !hasCandidate(chunk) {
+						endAt(index, fmt.Errorf("%v: no peer to download blocks %v-%v after %v attempts", d.name, chunk.from, chunk.to, chunk.attempts))
+						continue
+					}
+					remain = append(remain, index)
+					continue
+				}
+				busy[peerID] = true
+				chunk.tried[peerID] = true
+				chunk.attempts++
+				inflight++
+				go func(index int, peerID string, chunk downloadChunk) {
+					blocks, err := d.fetchChunk(ctx, peerID, chunk.from, chunk.to)
+					results <- downloadResult{index, peerID, blocks, err}
+				}(index, peerID, *chunk)
+			}
+			queue = remain
+		}
+
+		//push downloaded chunks to insert in height order
+		for pushed < end {
+			blocks, ok := ready[pushed]
+			if !ok {
+				break
+			}
+			delete(ready, pushed)
+			insertCh <- blocks
+			pushed++
+		}
+
+		if doneChunks == end && failErr == nil {
+			return insertedBlocks, endErr
+		}
+		if inflight == 0 && doneChunks == pushed && failErr != nil {
+			return insertedBlocks, failErr
+		}
+
+		select {
+		case r := <-results:
+			inflight--
+			busy[r.peerID] = false
+			if r.err != nil {
+				failures[r.peerID]++
+				Logger.Infof("%v: download blocks %v-%v from peer %v fail: %v", d.name, chunks[r.index].from, chunks[r.index].to, r.peerID, r.err)
+				if chunks[r.index].attempts >= d.maxAttempts {
+					endAt(r.index, fmt.Errorf("%v: download blocks %v-%v fail after %v attempts: %v", d.name, chunks[r.index].from, chunks[r.index].to, chunks[r.index].attempts, r.err))
+					continue
+				}
+				queue = append([]int{r.index}, queue...)
+				continue
+			}
+			ready[r.index] = r.blocks
+		case r := <-insertedCh:
+			doneChunks++
+			insertedBlocks += r.count
+			if r.err != nil && failErr == nil {
+				failErr = r.err
+				cancel()
+			}
+		}
+	}
+}
+
+// fetchChunk request blocks [from, to] from the peer, and verify they are complete and linked
+func (d *blockDownloader) fetchChunk(ctx context.Context, peerID string, from uint64, to uint64) ([]types.BlockInterface, error) {
+	ctx, cancel := context.WithTimeout(ctx, d.timeout)
+	defer cancel()
+	ch, err := d.request(ctx, peerID, from, to)
+	if err != nil {
+		return nil, err
+	}
+	if ch == nil {
+		return nil, errors.New("cannot create stream")
+	}
+
+	blocks := make([]types.BlockInterface, 0, to-from+1)
+	for len(blocks) < int(to-from+1) {
+		select {
+		case blk, ok := <-ch:
+			if !ok || isNil(blk) {
+				return nil, fmt.Errorf("stream closed after %v of %v blocks", len(blocks), to-from+1)
+			}
+			if blk.GetHeight() < from+uint64(len(blocks)) {
+				continue
+			}
+			if blk.GetHeight() != from+uint64(len(blocks)) {
+				return nil, fmt.Errorf("receive block %v, expect %v", blk.GetHeight(), from+uint64(len(blocks)))
+			}
+			if len(blocks) > 0 && blk.GetPrevHash() != *blocks[len(blocks)-1].Hash() {
+				return nil, fmt.Errorf("block %v is not linked to previous block", blk.GetHeight())
+			}
+			blocks = append(blocks, blk)
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+	if err := d.verifyBlocks(blocks); err != nil {
+		return nil, err
+	}
+	return blocks, nil
+}
+
+// verifyBlocks run preVerify of blocks in parallel
+func (d *blockDownloader) verifyBlocks(blocks []types.BlockInterface) error {
+	if d.preVerify == nil {
+		return nil
+	}
+	errCh := make(chan error, len(blocks))
+	for _, blk := range blocks {
+		d.verifySem <- struct{}{}
+		go func(blk types.BlockInterface) {
+			defer func() { <-d.verifySem }()
+			if err := d.preVerify(blk); err != nil {
+				errCh <- fmt.Errorf("block %v %v: %v", blk.GetHeight(), blk.Hash().String(), err)
+				return
+			}
+			errCh <- nil
+		}(blk)
+	}
+	for range blocks {
+		if err := <-errCh; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// preVerifyBlock check what do not depend on the chain state: merkle roots and producer signature
+func preVerifyBlock(chain Chain, blk types.BlockInterface) error {
+	if err := blockchain.VerifyBlockMerkleRoots(blk); err != nil {
+		return err
+	}
+	return chain.ValidateProducerSig(blk)
+}
+
+// insertBlocks insert blocks in batch until all of them are inserted
+func insertBlocks(chain Chain, blocks []types.BlockInterface) (int, error) {
+	inserted := 0
+	for len(blocks) > 0 {
+		time1 := time.Now()
+		successBlk, err := InsertBatchBlock(chain, blocks)
+		if err != nil {
+			return inserted, err
+		}
+		if successBlk == 0 {
+			return inserted, fmt.Errorf("cannot insert block %v", blocks[0].GetHeight())
+		}
+		Logger.Infof("Syncker Insert %d block (from %d to %d) elaspse %f", successBlk, blocks[0].GetHeight(), blocks[successBlk-1].GetHeight(), time.Since(time1).Seconds())
+		inserted += successBlk
+		blocks = blocks[successBlk:]
+	}
+	return inserted, nil
+}
+
+// parallelSyncRange return the range to download in parallel and the peers having it,
+// if the chain is not far enough behind the peers, nothing is returned
+func parallelSyncRange(bestHeight uint64, peerHeights map[string]uint64) (uint64, uint64, map[string]uint64) {
+	maxHeight := uint64(0)
+	for _, height := range peerHeights {
+		if height > maxHeight {
+			maxHeight = height
+		}
+	}
+	if maxHeight < bestHeight+ParallelSyncThreshold {
+		return 0, 0, nil
+	}
+	from := bestHeight + 1
+	to := maxHeight - ParallelSyncTipMargin
+	peers := make(map[string]uint64)
+	for peerID, height := range peerHeights {
+		if height > bestHeight {
+			peers[peerID] = height
+		}
+	}
+	return from, to, peers
+}
@@ -0,0 +1,148 @@
+package syncker
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/incognitochain/incognito-chain/blockchain/types"
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/config"
+)
+
+func init() {
+	Logger.Init(common.NewBackend(nil).Logger("test", true))
+}
+
+func newTestChainBlocks(n int) []types.BlockInterface {
+	config.AbortParam()
+	blocks := []types.BlockInterface{}
+	prevHash := common.Hash{}
+	for i := 1; i <= n; i++ {
+		blk := types.NewBeaconBlock()
+		blk.Header.Height = uint64(i)
+		blk.Header.PreviousBlockHash = prevHash
+		prevHash = *blk.Hash()
+		blocks = append(blocks, blk)
+	}
+	return blocks
+}
+
+func newTestDownloader(blocks []types.BlockInterface, badPeers map[string]bool, requests *sync.Map) *blockDownloader {
+	d := newBlockDownloader("test", func(ctx context.Context, peerID string, from uint64, to uint64) (chan types.BlockInterface, error) {
+		requests.Store(peerID, true)
+		if badPeers[peerID] {
+			return nil, errors.New("bad peer")
+		}
+		ch := make(chan types.BlockInterface, to-from+1)
+		for h := from; h <= to; h++ {
+			ch <- blocks[h-1]
+		}
+		close(ch)
+		return ch, nil
+	}, func(blk types.BlockInterface) error {
+		return nil
+	})
+	d.chunkSize = 10
+	d.maxParallel = 3
+	d.timeout = time.Second
+	return d
+}
+
+func TestBlockDownloader(t *testing.T) {
+	blocks := newTestChainBlocks(95)
+	requests := &sync.Map{}
+	d := newTestDownloader(blocks, map[string]bool{"bad": true}, requests)
+
+	inserted := []types.BlockInterface{}
+	peers := map[string]uint64{"bad": 95, "p1": 95, "p2": 95, "short": 20}
+	count, err := d.download(context.Background(), peers, 5, 95, func(blks []types.BlockInterface) (int, error) {
+		inserted = append(inserted, blks...)
+		return len(blks), nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if count != 91 || len(inserted) != 91 {
+		t.Fatalf("download() inserted %v blocks, want 91", count)
+	}
+	for i, blk := range inserted {
+		if blk.GetHeight() != uint64(5+i) {
+			t.Fatalf("inserted block %v has height %v, want %v", i, blk.GetHeight(), 5+i)
+		}
+	}
+	for _, peerID := range []string{"bad", "p1", "p2", "short"} {
+		if _, ok := requests.Load(peerID); !ok {
+			t.Errorf("peer %v is not requested", peerID)
+		}
+	}
+}
+
+func TestBlockDownloaderFail(t *testing.T) {
+	blocks := newTestChainBlocks(50)
+	d := newTestDownloader(blocks, map[string]bool{"bad": true}, &sync.Map{})
+	insert := func(blks []types.BlockInterface) (int, error) {
+		return len(blks), nil
+	}
+
+	//no peer has blocks above 30
+	count, err := d.download(context.Background(), map[string]uint64{"p1": 30}, 1, 50, insert)
+	if err == nil || count != 30 {
+		t.Errorf("download() = %v, %v, want 30 blocks and error", count, err)
+	}
+
+	//only a bad peer
+	count, err = d.download(context.Background(), map[string]uint64{"bad": 50}, 1, 50, insert)
+	if err == nil || count != 0 {
+		t.Errorf("download() = %v, %v, want 0 blocks and error", count, err)
+	}
+
+	//insert error stop the download
+	count, err = d.download(context.Background(), map[string]uint64{"p1": 50, "p2": 50}, 1, 50, func(blks []types.BlockInterface) (int, error) {
+		if blks[0].GetHeight() == 21 {
+			return 0, errors.New("invalid block")
+		}
+		return len(blks), nil
+	})
+	if err == nil || count != 20 {
+		t.Errorf("download() = %v, %v, want 20 blocks and error", count, err)
+	}
+}
+
+func TestBlockDownloaderUnlinkedBlock(t *testing.T) {
+	blocks := newTestChainBlocks(20)
+	forked := newTestChainBlocks(20)
+	forked[14].(*types.BeaconBlock).Header.Round = 2
+	requests := &sync.Map{}
+	d := newTestDownloader(blocks, nil, requests)
+	good := d.request
+	d.request = func(ctx context.Context, peerID string, from uint64, to uint64) (chan types.BlockInterface, error) {
+		if peerID != "forked" {
+			return good(ctx, peerID, from, to)
+		}
+		ch := make(chan types.BlockInterface, to-from+1)
+		for h := from; h <= to; h++ {
+			ch <- forked[h-1]
+		}
+		close(ch)
+		return ch, nil
+	}
+	count, err := d.download(context.Background(), map[string]uint64{"forked": 20, "p1": 20}, 1, 20, func(blks []types.BlockInterface) (int, error) {
+		return len(blks), nil
+	})
+	if err != nil || count != 20 {
+		t.Errorf("download() = %v, %v, want 20 blocks", count, err)
+	}
+}
+
+func TestParallelSyncRange(t *testing.T) {
+	if _, _, peers := parallelSyncRange(1000, map[string]uint64{"p1": 1000 + ParallelSyncThreshold - 1}); peers != nil {
+		t.Errorf("parallelSyncRange() should not sync when the chain is near the tip")
+	}
+	from, to, peers := parallelSyncRange(1000, map[string]uint64{"p1": 5000, "p2": 3000, "p3": 900})
+	if from != 1001 || to != 5000-ParallelSyncTipMargin || len(peers) != 2 {
+		t.Errorf("parallelSyncRange() = %v, %v, %v", from, to, peers)
+	}
+}
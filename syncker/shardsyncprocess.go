@@ -43,6 +43,8 @@ type ShardSyncProcess struct {
 	shardPool             *BlkPool
 	actionCh              chan func()
 
+	downloader *blockDownloader
+
 	lock          *sync.RWMutex
 	lastInsert    string
 	startSyncTime time.Time
@@ -79,6 +81,11 @@ func NewShardSyncProcess(
 		actionCh: make(chan func()),
 	}
 	s.crossShardSyncProcess = NewCrossShardSyncProcess(network, bc, s, beaconChain)
+	s.downloader = newBlockDownloader(fmt.Sprintf("shard %v", shardID), func(ctx context.Context, peerID string, from uint64, to uint64) (chan types.BlockInterface, error) {
+		return network.RequestShardBlocksViaStream(ctx, peerID, shardID, from, to)
+	}, func(blk types.BlockInterface) error {
+		return preVerifyBlock(chain, blk)
+	})
 
 	go s.syncShardProcess()
 	go s.insertShardBlockFromPool()
@@ -262,6 +269,7 @@ func (s *ShardSyncProcess) syncShardProcess() {
 			}
 		}
 
+		requestCnt += s.downloadInParallel()
 		for peerID, pState := range s.getShardPeerStates() {
 			requestCnt += s.streamFromPeer(peerID, pState)
 		}
@@ -324,6 +332,52 @@ func (s *ShardSyncProcess) syncFinishSyncMessage() {
 
 }
 
+// downloadInParallel download blocks from all peers in parallel when the chain is far behind, return number of inserted blocks
+func (s *ShardSyncProcess) downloadInParallel() int {
+	if s.testStall {
+		return 0
+	}
+	peerHeights := make(map[string]uint64)
+	for peerID, pState := range s.getShardPeerStates() {
+		peerHeights[peerID] = pState.BestViewHeight
+	}
+	from, to, peers := parallelSyncRange(s.Chain.GetBestViewHeight(), peerHeights)
+	if len(peers) == 0 {
+		return 0
+	}
+	Logger.Infof("Syncker download shard %v block from %v to %v from %v peers", s.shardID, from, to, len(peers))
+	inserted, err := s.downloader.download(context.Background(), peers, from, to, s.insertDownloadedBlocks)
+	if err != nil {
+		Logger.Errorf("Syncker download shard %v block fail, inserted %v blocks: %v", s.shardID, inserted, err)
+	}
+	return inserted
+}
+
+// insertDownloadedBlocks insert blocks which beacon block is already inserted
+func (s *ShardSyncProcess) insertDownloadedBlocks(blocks []types.BlockInterface) (int, error) {
+	insertable := func() int {
+		for i, blk := range blocks {
+			if blk.(*types.ShardBlock).Header.BeaconHeight > s.beaconChain.GetBestViewHeight() {
+				return i
+			}
+		}
+		return len(blocks)
+	}
+	n := insertable()
+	if n < len(blocks) {
+		time.Sleep(30 * time.Second)
+		n = insertable()
+	}
+	inserted, err := insertBlocks(s.Chain, blocks[:n])
+	if err != nil {
+		return inserted, err
+	}
+	if n < len(blocks) {
+		return inserted, fmt.Errorf("beacon block %v of shard block %v is not inserted", blocks[n].(*types.ShardBlock).Header.BeaconHeight, blocks[n].GetHeight())
+	}
+	return inserted, nil
+}
+
 func (s *ShardSyncProcess) streamFromPeer(peerID string, pState ShardPeerState) (requestCnt int) {
 	if s.testStall {
 		return 0
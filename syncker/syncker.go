@@ -64,6 +64,11 @@ func (synckerManager *SynckerManager) Init(config *SynckerManagerConfig) {
 	if bootstrapAddrs != "" {
 		bootstrapServers := strings.Split(bootstrapAddrs, ",")
 		bootstrap := blockchain.NewBootstrapManager(bootstrapServers, synckerManager.Blockchain)
+		if configpkg.Config().FastSync {
+			if err := bootstrap.FastSyncBeacon(); err != nil {
+				Logger.Errorf("Fast sync beacon fail: %v", err)
+			}
+		}
 		bootstrap.BootstrapBeacon()
 	}
 
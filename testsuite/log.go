@@ -11,6 +11,7 @@ import (
 	"github.com/incognitochain/incognito-chain/blockchain/pdex"
 	"github.com/incognitochain/incognito-chain/pruner"
 
+	"github.com/incognitochain/incognito-chain/consensus_v2/equivocation"
 	"github.com/incognitochain/incognito-chain/metadata/evmcaller"
 	"github.com/incognitochain/incognito-chain/syncker/finishsync"
 
@@ -92,6 +93,7 @@ var (
 	pdexLogger             = backendLog.Logger("Pdex log ", false)
 	bridgeAggLogger        = backendLog.Logger("BridgeAgg log ", false)
 	finishSyncLogger       = backendLog.Logger("Finish Sync log ", false)
+	equivocationLogger     = backendLog.Logger("Equivocation log ", false)
 	prunerLogger           = backendLog.Logger("Pruner log ", false)
 
 	portalLogger          = backendLog.Logger("Portal log ", false)
@@ -148,6 +150,7 @@ func init() {
 	btcRelaying.Logger.Init(btcRelayingLogger)
 	syncker.Logger.Init(synckerLogger)
 	finishsync.Logger.Init(finishSyncLogger)
+	equivocation.Logger.Init(equivocationLogger)
 	privacy.LoggerV1.Init(privacyV1Logger)
 	privacy.LoggerV2.Init(privacyV2Logger)
 	instruction.Logger.Init(instructionLogger)
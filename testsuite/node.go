@@ -614,7 +614,7 @@ func (s *NodeEngine) SignBlockWithCommittee(block types.BlockInterface, committe
 			c = s.bc.ShardChain[block.GetShardID()]
 		}
 		for _, committeeID := range committeeIndex {
-			vote, _ := blsbft.CreateVote(c, miningKeys[committeeID], block, committeePubKey, s.bc.GetChain(-1).(*blockchain.BeaconChain).GetPortalParamsV4(0))
+			vote, _ := blsbft.CreateVote(c, miningKeys[committeeID], block, committeePubKey, s.bc.GetChain(-1).(*blockchain.BeaconChain).GetPortalParamsV4(block.GetBeaconHeight()))
 			vote.IsValid = 1
 			votes[vote.Validator] = vote
 		}
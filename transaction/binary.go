@@ -0,0 +1,71 @@
+package transaction
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/incognitochain/incognito-chain/metadata"
+)
+
+// kinds of binary encoded tx, the first byte of the encoding
+const (
+	txBinaryJSON     byte = iota // token txs are kept in json
+	txBinaryVersion1             // TxVersion1 using TxBase.ToBinary
+	txBinaryVersion2             // TxVersion2 using TxBase.ToBinary
+)
+
+// EncodeTxBinary encode a transaction of any type to binary
+func EncodeTxBinary(tx metadata.Transaction) ([]byte, error) {
+	var kind byte
+	var data []byte
+	var err error
+	switch t := tx.(type) {
+	case *TxVersion1:
+		kind = txBinaryVersion1
+		data, err = t.ToBinary()
+	case *TxVersion2:
+		kind = txBinaryVersion2
+		data, err = t.ToBinary()
+	default:
+		kind = txBinaryJSON
+		data, err = json.Marshal(tx)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte{kind}, data...), nil
+}
+
+// DecodeTxBinary decode a transaction from the output of EncodeTxBinary
+func DecodeTxBinary(data []byte) (metadata.Transaction, error) {
+	if len(data) == 0 {
+		return nil, errors.New("cannot decode tx from empty data")
+	}
+	switch data[0] {
+	case txBinaryVersion1:
+		tx := &TxVersion1{}
+		if err := tx.FromBinary(data[1:]); err != nil {
+			return nil, err
+		}
+		return tx, nil
+	case txBinaryVersion2:
+		tx := &TxVersion2{}
+		if err := tx.FromBinary(data[1:]); err != nil {
+			return nil, err
+		}
+		return tx, nil
+	case txBinaryJSON:
+		txChoice, err := DeserializeTransactionJSON(data[1:])
+		if err != nil {
+			return nil, err
+		}
+		tx := txChoice.ToTx()
+		if tx == nil {
+			return nil, errors.New("cannot decode tx, corrupted json")
+		}
+		return tx, nil
+	default:
+		return nil, fmt.Errorf("unknown binary tx kind %v", data[0])
+	}
+}
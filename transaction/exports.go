@@ -25,12 +25,14 @@ const (
 	MaxSizeInfo                      = utils.MaxSizeInfo
 	MaxSizeUint32                    = utils.MaxSizeUint32
 	MaxSizeByte                      = utils.MaxSizeByte
+	KvargsRingCoinGetter             = tx_generic.KvargsRingCoinGetter
 )
 
 type EstimateTxSizeParam = tx_generic.EstimateTxSizeParam
 type TxConvertVer1ToVer2InitParams = tx_ver2.TxConvertVer1ToVer2InitParams
 type TxTokenConvertVer1ToVer2InitParams = tx_ver2.TxTokenConvertVer1ToVer2InitParams
 type TxPrivacyInitParams = tx_generic.TxPrivacyInitParams
+type RingCoinCache = tx_generic.RingCoinCache
 
 func NewRandomCommitmentsProcessParam(usableInputCoins []privacy.PlainCoin, randNum int, stateDB *statedb.StateDB, shardID byte, tokenID *common.Hash) *tx_generic.RandomCommitmentsProcessParam {
 	return tx_generic.NewRandomCommitmentsProcessParam(usableInputCoins, randNum, stateDB, shardID, tokenID)
@@ -104,6 +106,10 @@ func NewTxPrivacyInitParams(senderSK *privacy.PrivateKey,
 	return tx_generic.NewTxPrivacyInitParams(senderSK, paymentInfo, inputCoins, fee, hasPrivacy, stateDB, tokenID, metaData, info)
 }
 
+func NewRingCoinCache() *RingCoinCache {
+	return tx_generic.NewRingCoinCache()
+}
+
 func GetTxVersionFromCoins(coins []privacy.PlainCoin) (int8, error) {
 	return tx_generic.GetTxVersionFromCoins(coins)
 }
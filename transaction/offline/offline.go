@@ -0,0 +1,176 @@
+// Package offline lets a tx ver 2 be assembled by a full node and signed on another host, so the private key never leaves the signer.
+//
+// The node picks the input coins of the sender with its view-only keys and random decoys of its shard, the signer
+// decrypts the inputs, derives the one-time addresses of the receivers, proves and signs the tx.
+package offline
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math/big"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/common/base58"
+	"github.com/incognitochain/incognito-chain/incognitokey"
+	"github.com/incognitochain/incognito-chain/metadata"
+	"github.com/incognitochain/incognito-chain/privacy"
+	"github.com/incognitochain/incognito-chain/privacy/coin"
+	"github.com/incognitochain/incognito-chain/transaction"
+	"github.com/incognitochain/incognito-chain/wallet"
+)
+
+// RingCoin is an on-chain coin ver 2 and its index in the list of OTA coins of its shard
+type RingCoin struct {
+	Coin  []byte
+	Index uint64
+}
+
+// Receiver is an output of an unsigned tx, the signer derives its one-time address
+type Receiver struct {
+	PaymentAddress string
+	Amount         uint64
+	Message        []byte
+}
+
+// UnsignedTx is the skeleton of a tx ver 2, it holds no secret.
+// Receivers are paid in PRV with InputCoins, the fee included. TokenReceivers are paid in TokenID with TokenInputCoins.
+// The change goes back to the sender as a receiver like any other
+type UnsignedTx struct {
+	SenderPaymentAddress string
+	Fee                  uint64
+	Info                 []byte
+	Receivers            []Receiver
+	InputCoins           []RingCoin
+	Decoys               []RingCoin
+	TokenID              string `json:",omitempty"`
+	TokenReceivers       []Receiver
+	TokenInputCoins      []RingCoin
+	TokenDecoys          []RingCoin
+}
+
+// SignedTx is a signed tx ready to be sent with sendrawtransaction, or sendrawprivacycustomtokentransaction if IsToken
+type SignedTx struct {
+	TxID            string
+	Base58CheckData string
+	IsToken         bool
+}
+
+// IsToken return whether the tx transfers a token other than PRV
+func (u *UnsignedTx) IsToken() bool {
+	return u.TokenID != "" && u.TokenID != common.PRVIDStr
+}
+
+// Sign check the skeleton against the key set of privateKey, then prove and sign the tx
+func (u *UnsignedTx) Sign(privateKey *privacy.PrivateKey) (*SignedTx, error) {
+	keySet := new(incognitokey.KeySet)
+	if err := keySet.InitFromPrivateKey(privateKey); err != nil {
+		return nil, err
+	}
+	sender, err := wallet.Base58CheckDeserialize(u.SenderPaymentAddress)
+	if err != nil {
+		return nil, fmt.Errorf("invalid sender payment address: %v", err)
+	}
+	if !bytes.Equal(sender.KeySet.PaymentAddress.Pk, keySet.PaymentAddress.Pk) {
+		return nil, fmt.Errorf("the tx is not sent by the private key owner")
+	}
+	pk := keySet.PaymentAddress.Pk
+	shardID := common.GetShardIDFromLastByte(pk[len(pk)-1])
+
+	cache := transaction.NewRingCoinCache()
+	paymentInfos, inputCoins, err := u.prepare(keySet, u.Receivers, u.InputCoins, u.Decoys, u.Fee, common.PRVCoinID, cache)
+	if err != nil {
+		return nil, err
+	}
+	kvargs := map[string]interface{}{transaction.KvargsRingCoinGetter: cache}
+
+	var tx metadata.Transaction
+	if !u.IsToken() {
+		params := transaction.NewTxPrivacyInitParams(privateKey, paymentInfos, inputCoins, u.Fee, true, nil, &common.PRVCoinID, nil, u.Info)
+		params.Kvargs = kvargs
+		txNormal := new(transaction.TxVersion2)
+		if err := txNormal.Init(params); err != nil {
+			return nil, err
+		}
+		tx = txNormal
+	} else {
+		tokenID, err := common.Hash{}.NewHashFromStr(u.TokenID)
+		if err != nil {
+			return nil, fmt.Errorf("invalid token id %v: %v", u.TokenID, err)
+		}
+		tokenPaymentInfos, tokenInputCoins, err := u.prepare(keySet, u.TokenReceivers, u.TokenInputCoins, u.TokenDecoys, 0, common.ConfidentialAssetID, cache)
+		if err != nil {
+			return nil, err
+		}
+		tokenAmount := uint64(0)
+		for _, paymentInfo := range tokenPaymentInfos {
+			tokenAmount += paymentInfo.Amount
+		}
+		tokenParams := &transaction.TokenParam{
+			PropertyID:  tokenID.String(),
+			Amount:      tokenAmount,
+			TokenTxType: transaction.CustomTokenTransfer,
+			Receiver:    tokenPaymentInfos,
+			TokenInput:  tokenInputCoins,
+		}
+		params := transaction.NewTxTokenParams(privateKey, paymentInfos, inputCoins, u.Fee, tokenParams, nil, nil, true, true, shardID, u.Info, nil)
+		params.Kvargs = kvargs
+		txToken := new(transaction.TxTokenVersion2)
+		if err := txToken.Init(params); err != nil {
+			return nil, err
+		}
+		tx = txToken
+	}
+
+	txJson, err := json.Marshal(tx)
+	if err != nil {
+		return nil, err
+	}
+	return &SignedTx{
+		TxID:            tx.Hash().String(),
+		Base58CheckData: base58.Base58Check{}.Encode(txJson, common.ZeroByte),
+		IsToken:         u.IsToken(),
+	}, nil
+}
+
+// prepare decrypt the input coins of one token, add them and the decoys to cache under dbTokenID
+// and check the inputs exactly pay the receivers and the fee, a node could not steal the change
+func (u *UnsignedTx) prepare(keySet *incognitokey.KeySet, receivers []Receiver, inputs, decoys []RingCoin, fee uint64, dbTokenID common.Hash, cache *transaction.RingCoinCache) ([]*privacy.PaymentInfo, []privacy.PlainCoin, error) {
+	paymentInfos := make([]*privacy.PaymentInfo, 0, len(receivers))
+	totalOut := fee
+	for _, receiver := range receivers {
+		keyWallet, err := wallet.Base58CheckDeserialize(receiver.PaymentAddress)
+		if err != nil {
+			return nil, nil, fmt.Errorf("invalid payment address %v: %v", receiver.PaymentAddress, err)
+		}
+		paymentInfos = append(paymentInfos, &privacy.PaymentInfo{
+			PaymentAddress: keyWallet.KeySet.PaymentAddress,
+			Amount:         receiver.Amount,
+			Message:        receiver.Message,
+		})
+		totalOut += receiver.Amount
+	}
+
+	inputCoins := make([]privacy.PlainCoin, 0, len(inputs))
+	totalIn := uint64(0)
+	for _, input := range inputs {
+		c := new(coin.CoinV2)
+		if err := c.SetBytes(input.Coin); err != nil {
+			return nil, nil, fmt.Errorf("invalid input coin: %v", err)
+		}
+		plainCoin, err := c.Decrypt(keySet)
+		if err != nil {
+			return nil, nil, fmt.Errorf("cannot decrypt input coin %v: %v", input.Index, err)
+		}
+		cache.AddInputCoin(dbTokenID, c.GetPublicKey().ToBytesS(), new(big.Int).SetUint64(input.Index))
+		inputCoins = append(inputCoins, plainCoin)
+		totalIn += plainCoin.GetValue()
+	}
+	if totalIn != totalOut {
+		return nil, nil, fmt.Errorf("input coins of %v have %v in total, expect %v", dbTokenID.String(), totalIn, totalOut)
+	}
+	for _, decoy := range decoys {
+		cache.AddDecoy(dbTokenID, new(big.Int).SetUint64(decoy.Index), decoy.Coin)
+	}
+	return paymentInfos, inputCoins, nil
+}
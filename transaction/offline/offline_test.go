@@ -0,0 +1,125 @@
+package offline
+
+import (
+	"testing"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/common/base58"
+	"github.com/incognitochain/incognito-chain/incognitokey"
+	"github.com/incognitochain/incognito-chain/privacy"
+	"github.com/incognitochain/incognito-chain/privacy/coin"
+	"github.com/incognitochain/incognito-chain/privacy/key"
+	"github.com/incognitochain/incognito-chain/transaction"
+	"github.com/incognitochain/incognito-chain/transaction/utils"
+	"github.com/incognitochain/incognito-chain/wallet"
+	"github.com/stretchr/testify/assert"
+)
+
+func init() {
+	common.MaxShardNumber = 1
+	utils.Logger.Init(common.NewBackend(nil).Logger("test", true))
+}
+
+func newTestAccount(t *testing.T) (*privacy.PrivateKey, *incognitokey.KeySet, string) {
+	privateKey := key.GeneratePrivateKey(common.RandBytes(32))
+	keySet := new(incognitokey.KeySet)
+	assert.Nil(t, keySet.InitFromPrivateKey(&privateKey))
+	keyWallet := &wallet.KeyWallet{KeySet: *keySet}
+	return &privateKey, keySet, keyWallet.Base58CheckSerialize(wallet.PaymentAddressType)
+}
+
+func newTestRingCoins(t *testing.T, keySet *incognitokey.KeySet, amount uint64, num int, firstIndex uint64, tokenID *common.Hash) []RingCoin {
+	res := []RingCoin{}
+	for i := 0; i < num; i++ {
+		p := privacy.NewCoinParams().FromPaymentInfo(key.InitPaymentInfo(keySet.PaymentAddress, amount, []byte{}))
+		var c *coin.CoinV2
+		var err error
+		if tokenID == nil {
+			c, err = coin.NewCoinFromPaymentInfo(p)
+		} else {
+			c, _, err = coin.NewCoinCA(p, tokenID)
+		}
+		assert.Nil(t, err)
+		assert.Nil(t, c.ConcealOutputCoin(keySet.PaymentAddress.GetPublicView()))
+		res = append(res, RingCoin{Coin: c.Bytes(), Index: firstIndex + uint64(i)})
+	}
+	return res
+}
+
+func TestSignUnsignedTx(t *testing.T) {
+	senderSK, senderKeySet, senderAddr := newTestAccount(t)
+	_, otherKeySet, receiverAddr := newTestAccount(t)
+
+	u := &UnsignedTx{
+		SenderPaymentAddress: senderAddr,
+		Fee:                  100,
+		Info:                 []byte("offline"),
+		Receivers: []Receiver{
+			{PaymentAddress: receiverAddr, Amount: 700},
+			{PaymentAddress: senderAddr, Amount: 200},
+		},
+		InputCoins: newTestRingCoins(t, senderKeySet, 1000, 1, 0, nil),
+		Decoys:     newTestRingCoins(t, otherKeySet, 50, 2*(privacy.RingSize-1), 1, nil),
+	}
+	signedTx, err := u.Sign(senderSK)
+	assert.Nil(t, err)
+	assert.False(t, signedTx.IsToken)
+
+	txJson, _, err := base58.Base58Check{}.Decode(signedTx.Base58CheckData)
+	assert.Nil(t, err)
+	tx, err := transaction.NewTransactionFromJsonBytes(txJson)
+	assert.Nil(t, err)
+	assert.Equal(t, int8(2), tx.GetVersion())
+	assert.Equal(t, uint64(100), tx.GetTxFee())
+	assert.Equal(t, signedTx.TxID, tx.Hash().String())
+	assert.Equal(t, 2, len(tx.GetProof().GetOutputCoins()))
+
+	// a skeleton not paying exactly the receivers and the fee is refused
+	u.Receivers[1].Amount = 100
+	_, err = u.Sign(senderSK)
+	assert.NotNil(t, err)
+	u.Receivers[1].Amount = 200
+
+	// the skeleton of another sender is refused
+	otherSK, _, _ := newTestAccount(t)
+	_, err = u.Sign(otherSK)
+	assert.NotNil(t, err)
+
+	// the ring cannot be formed without decoys
+	u.Decoys = nil
+	_, err = u.Sign(senderSK)
+	assert.NotNil(t, err)
+}
+
+func TestSignUnsignedTokenTx(t *testing.T) {
+	senderSK, senderKeySet, senderAddr := newTestAccount(t)
+	_, otherKeySet, receiverAddr := newTestAccount(t)
+	tokenID := common.HashH([]byte("offline token"))
+
+	u := &UnsignedTx{
+		SenderPaymentAddress: senderAddr,
+		Fee:                  100,
+		Receivers:            []Receiver{{PaymentAddress: senderAddr, Amount: 400}},
+		InputCoins:           newTestRingCoins(t, senderKeySet, 500, 1, 0, nil),
+		Decoys:               newTestRingCoins(t, otherKeySet, 50, privacy.RingSize-1, 1, nil),
+		TokenID:              tokenID.String(),
+		TokenReceivers:       []Receiver{{PaymentAddress: receiverAddr, Amount: 2000}},
+		TokenInputCoins:      newTestRingCoins(t, senderKeySet, 1000, 2, 0, &tokenID),
+		TokenDecoys:          newTestRingCoins(t, otherKeySet, 50, 2*(privacy.RingSize-1), 2, &tokenID),
+	}
+	signedTx, err := u.Sign(senderSK)
+	assert.Nil(t, err)
+	assert.True(t, signedTx.IsToken)
+
+	txJson, _, err := base58.Base58Check{}.Decode(signedTx.Base58CheckData)
+	assert.Nil(t, err)
+	tx, err := transaction.NewTransactionTokenFromJsonBytes(txJson)
+	assert.Nil(t, err)
+	assert.Equal(t, signedTx.TxID, tx.Hash().String())
+	assert.Equal(t, uint64(100), tx.GetTxFee())
+
+	// the token inputs must pay exactly the token receivers
+	u.TokenReceivers[0].Amount = 1500
+	_, err = u.Sign(senderSK)
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,133 @@
+package tx_generic //nolint:revive
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/dataaccessobject/statedb"
+)
+
+// KvargsRingCoinGetter is the key of TxPrivacyInitParams.Kvargs holding the RingCoinGetter used to form the mlsag ring of a tx ver 2
+const KvargsRingCoinGetter = "ringCoinGetter"
+
+// RingCoinGetter provide the on-chain coins a tx ver 2 needs to form its mlsag ring:
+// the index of each real input coin and random decoy coins of the same token and shard
+type RingCoinGetter interface {
+	GetOTACoinIndex(tokenID common.Hash, publicKey []byte) (*big.Int, error)
+	RandomOTACoin(tokenID common.Hash, shardID byte) (*big.Int, []byte, error)
+}
+
+// GetRingCoinGetter return the RingCoinGetter set in params.Kvargs, or read the coins from params.StateDB if there is none
+func GetRingCoinGetter(params *TxPrivacyInitParams) (RingCoinGetter, error) {
+	if getter, ok := params.Kvargs[KvargsRingCoinGetter]; ok {
+		res, ok := getter.(RingCoinGetter)
+		if !ok {
+			return nil, fmt.Errorf("invalid ring coin getter %T", getter)
+		}
+		return res, nil
+	}
+	if params.StateDB == nil {
+		return nil, fmt.Errorf("cannot form ring without state db or ring coin getter")
+	}
+	return stateDBRingCoinGetter{params.StateDB}, nil
+}
+
+// SkipOTAUniquenessCheck tell whether the OTAs of the output coins are not checked against the chain when the tx is created,
+// it is only the case of a tx created by a wallet with a RingCoinGetter and without state db, the uniqueness is checked again when the tx is validated
+func SkipOTAUniquenessCheck(params *TxPrivacyInitParams) bool {
+	_, ok := params.Kvargs[KvargsRingCoinGetter]
+	return ok && params.StateDB == nil
+}
+
+type stateDBRingCoinGetter struct {
+	stateDB *statedb.StateDB
+}
+
+func (g stateDBRingCoinGetter) GetOTACoinIndex(tokenID common.Hash, publicKey []byte) (*big.Int, error) {
+	return statedb.GetOTACoinIndex(g.stateDB, tokenID, publicKey)
+}
+
+func (g stateDBRingCoinGetter) RandomOTACoin(tokenID common.Hash, shardID byte) (*big.Int, []byte, error) {
+	lenOTA, err := statedb.GetOTACoinLength(g.stateDB, tokenID, shardID)
+	if err != nil || lenOTA == nil {
+		return nil, nil, fmt.Errorf("either database length ota is empty or has error, error = %v", err)
+	}
+	index, err := common.RandBigIntMaxRange(lenOTA)
+	if err != nil {
+		return nil, nil, err
+	}
+	coinBytes, err := statedb.GetOTACoinByIndex(g.stateDB, tokenID, index.Uint64(), shardID)
+	if err != nil {
+		return nil, nil, err
+	}
+	return index, coinBytes, nil
+}
+
+type ringCoin struct {
+	index     *big.Int
+	coinBytes []byte
+}
+
+// RingCoinCache is a RingCoinGetter holding coins fetched from a full node,
+// it lets a wallet without any chain data create a tx ver 2.
+// Decoys are sampled without replacement so a cache is used to create only one tx
+type RingCoinCache struct {
+	indexes      map[common.Hash]map[string]*big.Int
+	decoys       map[common.Hash][]ringCoin
+	decoyIndexes map[common.Hash]map[string]bool
+}
+
+func NewRingCoinCache() *RingCoinCache {
+	return &RingCoinCache{
+		indexes:      make(map[common.Hash]map[string]*big.Int),
+		decoys:       make(map[common.Hash][]ringCoin),
+		decoyIndexes: make(map[common.Hash]map[string]bool),
+	}
+}
+
+// AddInputCoin add the on-chain index of an input coin, tokenID is PRV or the confidential asset ID
+func (c *RingCoinCache) AddInputCoin(tokenID common.Hash, publicKey []byte, index *big.Int) {
+	if _, ok := c.indexes[tokenID]; !ok {
+		c.indexes[tokenID] = make(map[string]*big.Int)
+	}
+	c.indexes[tokenID][string(publicKey)] = index
+}
+
+// AddDecoy add a coin which can be used as decoy, the coin must be in the shard of the sender.
+// A coin added twice is kept once
+func (c *RingCoinCache) AddDecoy(tokenID common.Hash, index *big.Int, coinBytes []byte) {
+	if _, ok := c.decoyIndexes[tokenID]; !ok {
+		c.decoyIndexes[tokenID] = make(map[string]bool)
+	}
+	if c.decoyIndexes[tokenID][index.String()] {
+		return
+	}
+	c.decoyIndexes[tokenID][index.String()] = true
+	c.decoys[tokenID] = append(c.decoys[tokenID], ringCoin{index, coinBytes})
+}
+
+func (c *RingCoinCache) GetOTACoinIndex(tokenID common.Hash, publicKey []byte) (*big.Int, error) {
+	index, ok := c.indexes[tokenID][string(publicKey)]
+	if !ok {
+		return nil, fmt.Errorf("index of input coin %x not found", publicKey)
+	}
+	return index, nil
+}
+
+// RandomOTACoin return a decoy not returned before, so a decoy is never used twice in the ring
+func (c *RingCoinCache) RandomOTACoin(tokenID common.Hash, shardID byte) (*big.Int, []byte, error) {
+	decoys := c.decoys[tokenID]
+	if len(decoys) == 0 {
+		return nil, nil, fmt.Errorf("no decoy of token %v left", tokenID.String())
+	}
+	i, err := common.RandBigIntMaxRange(big.NewInt(int64(len(decoys))))
+	if err != nil {
+		return nil, nil, err
+	}
+	last := len(decoys) - 1
+	decoy := decoys[i.Int64()]
+	decoys[i.Int64()] = decoys[last]
+	c.decoys[tokenID] = decoys[:last]
+	return decoy.index, decoy.coinBytes, nil
+}
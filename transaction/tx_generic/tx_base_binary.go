@@ -0,0 +1,88 @@
+package tx_generic
+
+import (
+	"encoding/json"
+	"reflect"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/metadata"
+	"github.com/incognitochain/incognito-chain/transaction/utils"
+)
+
+// =================== PARSING BINARY FUNCTIONS ===================
+
+// ToBinary encode the tx to binary: proof is stored as raw bytes instead of base64 and metadata is kept in json
+func (tx TxBase) ToBinary() ([]byte, error) {
+	w := common.NewBinaryWriter()
+	w.WriteUint8(byte(tx.Version))
+	w.WriteString(tx.Type)
+	w.WriteVarint(tx.LockTime)
+	w.WriteUvarint(tx.Fee)
+	w.WriteBytes(tx.Info)
+	w.WriteBytes(tx.SigPubKey)
+	w.WriteBytes(tx.Sig)
+	w.WriteUint8(tx.PubKeyLastByteSender)
+	if tx.Proof == nil || reflect.ValueOf(tx.Proof).IsNil() {
+		w.WriteBool(false)
+	} else {
+		w.WriteBool(true)
+		w.WriteBytes(tx.Proof.Bytes())
+	}
+	if tx.Metadata == nil || reflect.ValueOf(tx.Metadata).IsNil() {
+		w.WriteBytes(nil)
+	} else {
+		metaBytes, err := json.Marshal(tx.Metadata)
+		if err != nil {
+			return nil, utils.NewTransactionErr(utils.UnexpectedError, err)
+		}
+		w.WriteBytes(metaBytes)
+	}
+	return w.Bytes(), nil
+}
+
+// FromBinary decode the tx from the output of ToBinary
+func (tx *TxBase) FromBinary(data []byte) error {
+	r := common.NewBinaryReader(data)
+	tx.Version = int8(r.ReadUint8())
+	tx.Type = r.ReadString()
+	tx.LockTime = r.ReadVarint()
+	tx.Fee = r.ReadUvarint()
+	tx.Info = r.ReadBytes()
+	tx.SigPubKey = r.ReadBytes()
+	tx.Sig = r.ReadBytes()
+	tx.PubKeyLastByteSender = r.ReadUint8()
+	hasProof := r.ReadBool()
+	var proofBytes []byte
+	if hasProof {
+		proofBytes = r.ReadBytes()
+	}
+	metaBytes := r.ReadBytes()
+	if r.Err() != nil {
+		return utils.NewTransactionErr(utils.UnexpectedError, r.Err())
+	}
+
+	tx.SetMetadata(nil)
+	if len(metaBytes) > 0 {
+		raw := json.RawMessage(metaBytes)
+		meta, parseErr := metadata.ParseMetadata(&raw)
+		if parseErr != nil {
+			return parseErr
+		}
+		tx.SetMetadata(meta)
+	}
+
+	tx.SetProof(nil)
+	if hasProof {
+		proofType := tx.Type
+		if proofType == common.TxTokenConversionType {
+			proofType = common.TxNormalType
+		}
+		proof, err := utils.ParseProofBytes(proofBytes, tx.Version, proofType)
+		if err != nil {
+			return err
+		}
+		tx.SetProof(proof)
+	}
+	tx.initEnv()
+	return nil
+}
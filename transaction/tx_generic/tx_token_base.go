@@ -34,6 +34,7 @@ type TxTokenParams struct {
 	HasPrivacyToken    bool
 	ShardID            byte
 	Info               []byte
+	Kvargs             map[string]interface{}
 }
 
 // CustomTokenParamTx - use for rpc request json body
@@ -322,6 +322,7 @@ func (txToken *TxToken) initToken(txNormal *Tx, params *tx_generic.TxTokenParams
 				nil,
 				nil,
 			)
+			txParams.Kvargs = params.Kvargs
 			isBurning, err := txNormal.proveToken(txParams)
 			if err != nil {
 				return utils.NewTransactionErr(utils.PrivacyTokenInitTokenDataError, err)
@@ -348,7 +349,7 @@ func (tx *Tx) provePRV(params *tx_generic.TxPrivacyInitParams) ([]privacy.PlainC
 	_ = senderKeySet.InitFromPrivateKey(params.SenderSK)
 	b := senderKeySet.PaymentAddress.Pk[len(senderKeySet.PaymentAddress.Pk)-1]
 
-	outputCoins, err := utils.NewCoinV2ArrayFromPaymentInfoArray(params.PaymentInfo, int(common.GetShardIDFromLastByte(b)), params.TokenID, params.StateDB)
+	outputCoins, err := utils.NewCoinV2ArrayFromPaymentInfoArray(params.PaymentInfo, int(common.GetShardIDFromLastByte(b)), params.TokenID, params.StateDB, tx_generic.SkipOTAUniquenessCheck(params))
 	if err != nil {
 		utils.Logger.Log.Errorf("Cannot parse outputCoinV2 to outputCoins, error %v ", err)
 		return nil, nil, err
@@ -416,6 +417,7 @@ func (txToken *TxToken) Init(paramsInterface interface{}) error {
 		params.MetaData,
 		params.Info,
 	)
+	txPrivacyParams.Kvargs = params.Kvargs
 	jsb, _ := json.Marshal(params.TokenParams)
 	utils.Logger.Log.Infof("Create TX token v2 with token params %s", string(jsb))
 	if err := tx_generic.ValidateTxParams(txPrivacyParams); err != nil {
@@ -235,7 +235,7 @@ func (tx *Tx) prove(params *tx_generic.TxPrivacyInitParams) error {
 	var senderKeySet incognitokey.KeySet
 	_ = senderKeySet.InitFromPrivateKey(params.SenderSK)
 	b := senderKeySet.PaymentAddress.Pk[len(senderKeySet.PaymentAddress.Pk)-1]
-	outputCoins, err := utils.NewCoinV2ArrayFromPaymentInfoArray(params.PaymentInfo, int(common.GetShardIDFromLastByte(b)), params.TokenID, params.StateDB)
+	outputCoins, err := utils.NewCoinV2ArrayFromPaymentInfoArray(params.PaymentInfo, int(common.GetShardIDFromLastByte(b)), params.TokenID, params.StateDB, tx_generic.SkipOTAUniquenessCheck(params))
 	if err != nil {
 		utils.Logger.Log.Errorf("Cannot parse outputCoinV2 to outputCoins, error %v ", err)
 		return err
@@ -264,9 +264,8 @@ func (tx *Tx) prove(params *tx_generic.TxPrivacyInitParams) error {
 // ========== NORMAL VERIFY FUNCTIONS ==========
 
 func generateMlsagRingWithIndexes(inputCoins []privacy.PlainCoin, outputCoins []*privacy.CoinV2, params *tx_generic.TxPrivacyInitParams, pi int, shardID byte, ringSize int) (*mlsag.Ring, [][]*big.Int, *privacy.Point, error) {
-	lenOTA, err := statedb.GetOTACoinLength(params.StateDB, *params.TokenID, shardID)
-	if err != nil || lenOTA == nil {
-		utils.Logger.Log.Errorf("Getting length of commitment error, either database length ota is empty or has error, error = %v", err)
+	ringCoinGetter, err := tx_generic.GetRingCoinGetter(params)
+	if err != nil {
 		return nil, nil, nil, err
 	}
 	outputCoinsAsGeneric := make([]privacy.Coin, len(outputCoins))
@@ -288,7 +287,7 @@ func generateMlsagRingWithIndexes(inputCoins []privacy.PlainCoin, outputCoins []
 			for j := 0; j < len(inputCoins); j++ {
 				row[j] = inputCoins[j].GetPublicKey()
 				publicKeyBytes := inputCoins[j].GetPublicKey().ToBytesS()
-				if rowIndexes[j], err = statedb.GetOTACoinIndex(params.StateDB, *params.TokenID, publicKeyBytes); err != nil {
+				if rowIndexes[j], err = ringCoinGetter.GetOTACoinIndex(*params.TokenID, publicKeyBytes); err != nil {
 					utils.Logger.Log.Errorf("Getting commitment index error %v ", err)
 					return nil, nil, nil, err
 				}
@@ -298,8 +297,8 @@ func generateMlsagRingWithIndexes(inputCoins []privacy.PlainCoin, outputCoins []
 			for j := 0; j < len(inputCoins); j++ {
 				coinDB := new(privacy.CoinV2)
 				for attempts < privacy.MaxPrivacyAttempts { // The chance of infinite loop is negligible
-					rowIndexes[j], _ = common.RandBigIntMaxRange(lenOTA)
-					coinBytes, err := statedb.GetOTACoinByIndex(params.StateDB, *params.TokenID, rowIndexes[j].Uint64(), shardID)
+					var coinBytes []byte
+					rowIndexes[j], coinBytes, err = ringCoinGetter.RandomOTACoin(*params.TokenID, shardID)
 					if err != nil {
 						utils.Logger.Log.Errorf("Get coinv2 by index error %v ", err)
 						return nil, nil, nil, err
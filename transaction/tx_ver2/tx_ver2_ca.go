@@ -130,9 +130,8 @@ func createPrivKeyMlsagCA(inputCoins []privacy.PlainCoin, outputCoins []*privacy
 
 func generateMlsagRingWithIndexesCA(inputCoins []privacy.PlainCoin, outputCoins []*privacy.CoinV2, params *tx_generic.TxPrivacyInitParams, pi int, shardID byte, ringSize int) (*mlsag.Ring, [][]*big.Int, []*privacy.Point, error) {
 
-	lenOTA, err := statedb.GetOTACoinLength(params.StateDB, common.ConfidentialAssetID, shardID)
-	if err != nil || lenOTA == nil {
-		utils.Logger.Log.Errorf("Getting length of commitment error, either database length ota is empty or has error, error = %v", err)
+	ringCoinGetter, err := tx_generic.GetRingCoinGetter(params)
+	if err != nil {
 		return nil, nil, nil, err
 	}
 	outputCoinsAsGeneric := make([]privacy.Coin, len(outputCoins))
@@ -169,7 +168,7 @@ func generateMlsagRingWithIndexesCA(inputCoins []privacy.PlainCoin, outputCoins
 			for j := 0; j < len(inputCoins); j++ {
 				row[j] = inputCoins[j].GetPublicKey()
 				publicKeyBytes := inputCoins[j].GetPublicKey().ToBytesS()
-				if rowIndexes[j], err = statedb.GetOTACoinIndex(params.StateDB, common.ConfidentialAssetID, publicKeyBytes); err != nil {
+				if rowIndexes[j], err = ringCoinGetter.GetOTACoinIndex(common.ConfidentialAssetID, publicKeyBytes); err != nil {
 					utils.Logger.Log.Errorf("Getting commitment index error %v ", err)
 					return nil, nil, nil, err
 				}
@@ -189,8 +188,8 @@ func generateMlsagRingWithIndexesCA(inputCoins []privacy.PlainCoin, outputCoins
 			for j := 0; j < len(inputCoins); j++ {
 				coinDB := new(privacy.CoinV2)
 				for attempts < privacy.MaxPrivacyAttempts { // The chance of infinite loop is negligible
-					rowIndexes[j], _ = common.RandBigIntMaxRange(lenOTA)
-					coinBytes, err := statedb.GetOTACoinByIndex(params.StateDB, common.ConfidentialAssetID, rowIndexes[j].Uint64(), shardID)
+					var coinBytes []byte
+					rowIndexes[j], coinBytes, err = ringCoinGetter.RandomOTACoin(common.ConfidentialAssetID, shardID)
 					if err != nil {
 						utils.Logger.Log.Errorf("Get coinv2 by index error %v ", err)
 						return nil, nil, nil, err
@@ -249,7 +248,7 @@ func (tx *Tx) proveCA(params *tx_generic.TxPrivacyInitParams) (bool, error) {
 	_ = senderKeySet.InitFromPrivateKey(params.SenderSK)
 	b := senderKeySet.PaymentAddress.Pk[len(senderKeySet.PaymentAddress.Pk)-1]
 	for _, inf := range params.PaymentInfo {
-		c, ss, err := createUniqueOTACoinCA(inf, int(common.GetShardIDFromLastByte(b)), params.TokenID, params.StateDB)
+		c, ss, err := createUniqueOTACoinCA(inf, int(common.GetShardIDFromLastByte(b)), params.TokenID, params.StateDB, tx_generic.SkipOTAUniquenessCheck(params))
 		if err != nil {
 			utils.Logger.Log.Errorf("Cannot parse outputCoinV2 to outputCoins, error %v ", err)
 			return false, err
@@ -386,10 +385,13 @@ func (tx *Tx) verifySigCA(transactionStateDB *statedb.StateDB, shardID byte, tok
 	return mlsag.VerifyConfidentialAsset(mlsagSignature, ring, tx.Hash()[:])
 }
 
-func createUniqueOTACoinCA(paymentInfo *privacy.PaymentInfo, senderShardID int, tokenID *common.Hash, stateDB *statedb.StateDB) (*privacy.CoinV2, *privacy.Point, error) {
+func createUniqueOTACoinCA(paymentInfo *privacy.PaymentInfo, senderShardID int, tokenID *common.Hash, stateDB *statedb.StateDB, skipUniquenessCheck bool) (*privacy.CoinV2, *privacy.Point, error) {
 	if tokenID == nil {
 		tokenID = &common.PRVCoinID
 	}
+	if stateDB == nil && !skipUniquenessCheck {
+		return nil, nil, fmt.Errorf("cannot check uniqueness of OTA without state db")
+	}
 	for i := privacy.MaxPrivacyAttempts; i > 0; i-- {
 		c, sharedSecret, err := privacy.NewCoinCA(privacy.NewCoinParams().From(paymentInfo, senderShardID, privacy.CoinPrivacyTypeTransfer), tokenID)
 		if tokenID != nil && sharedSecret != nil && c != nil && c.GetAssetTag() != nil {
@@ -405,6 +407,9 @@ func createUniqueOTACoinCA(paymentInfo *privacy.PaymentInfo, senderShardID int,
 			// c.SetAssetTag(assetTag)
 			return c, nil, nil // No need to check db
 		}
+		if skipUniquenessCheck {
+			return c, sharedSecret, nil
+		}
 		// Onetimeaddress should be unique
 		publicKeyBytes := c.GetPublicKey().ToBytesS()
 		// here tokenID should always be TokenConfidentialAssetID (for db storage)
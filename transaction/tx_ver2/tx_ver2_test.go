@@ -359,6 +359,83 @@ func TestPrivacyV2TxPRV(t *testing.T) {
 	})
 }
 
+func TestPrivacyV2TxPRVWithRingCoinCache(t *testing.T) {
+	Convey("Tx PRV without state db", t, func() {
+		dummyPrivateKeys, keySets, paymentInfo := preparePaymentKeys(minPrivateKeys)
+		pastCoins := make([]coin.Coin, 10*len(dummyPrivateKeys))
+		for i := range pastCoins {
+			tempCoin, err := coin.NewCoinFromPaymentInfo(privacy.NewCoinParams().FromPaymentInfo(paymentInfo[i%len(dummyPrivateKeys)]))
+			So(err, ShouldBeNil)
+			tempCoin.ConcealOutputCoin(keySets[i%len(dummyPrivateKeys)].PaymentAddress.GetPublicView())
+			pastCoins[i] = tempCoin
+		}
+		So(storeCoins(dummyDB, pastCoins, 0, common.PRVCoinID), ShouldBeNil)
+
+		// the wallet only knows the coins it fetched from a node
+		cache := tx_generic.NewRingCoinCache()
+		inputCoin, err := pastCoins[0].Decrypt(keySets[0])
+		So(err, ShouldBeNil)
+		index, err := statedb.GetOTACoinIndex(dummyDB, common.PRVCoinID, inputCoin.GetPublicKey().ToBytesS())
+		So(err, ShouldBeNil)
+		cache.AddInputCoin(common.PRVCoinID, inputCoin.GetPublicKey().ToBytesS(), index)
+		lenOTA, err := statedb.GetOTACoinLength(dummyDB, common.PRVCoinID, shardID)
+		So(err, ShouldBeNil)
+		for i := uint64(0); i < lenOTA.Uint64(); i++ {
+			coinBytes, err := statedb.GetOTACoinByIndex(dummyDB, common.PRVCoinID, i, shardID)
+			So(err, ShouldBeNil)
+			cache.AddDecoy(common.PRVCoinID, new(big.Int).SetUint64(i), coinBytes)
+		}
+
+		paymentInfoOut := []*privacy.PaymentInfo{key.InitPaymentInfo(keySets[1].PaymentAddress, 3000, []byte("test out"))}
+		params := tx_generic.NewTxPrivacyInitParams(dummyPrivateKeys[0],
+			paymentInfoOut, []coin.PlainCoin{inputCoin},
+			100, hasPrivacyForPRV,
+			nil,
+			&common.PRVCoinID,
+			nil,
+			[]byte{},
+		)
+		params.Kvargs = map[string]interface{}{tx_generic.KvargsRingCoinGetter: cache}
+		tx := &Tx{}
+		So(tx.Init(params), ShouldBeNil)
+
+		tx, err = tx.startVerifyTx(dummyDB)
+		So(err, ShouldBeNil)
+		boolParams := map[string]bool{"hasPrivacy": hasPrivacyForPRV, "isNewTransaction": true}
+		isValid, err := tx.ValidateTxByItself(boolParams, dummyDB, nil, nil, shardID, nil, nil)
+		So(err, ShouldBeNil)
+		So(isValid, ShouldBeTrue)
+
+		// the ring cannot be formed from an empty cache
+		params.Kvargs = map[string]interface{}{tx_generic.KvargsRingCoinGetter: tx_generic.NewRingCoinCache()}
+		params.InputCoins = []coin.PlainCoin{inputCoin}
+		So((&Tx{}).Init(params), ShouldNotBeNil)
+
+		// the OTA uniqueness is only skipped for a tx formed with a ring coin getter
+		params.Kvargs = nil
+		params.InputCoins = []coin.PlainCoin{inputCoin}
+		So((&Tx{}).Init(params), ShouldNotBeNil)
+
+		// each decoy is sampled once
+		cache = tx_generic.NewRingCoinCache()
+		for i := uint64(0); i < 5; i++ {
+			coinBytes, err := statedb.GetOTACoinByIndex(dummyDB, common.PRVCoinID, i, shardID)
+			So(err, ShouldBeNil)
+			cache.AddDecoy(common.PRVCoinID, new(big.Int).SetUint64(i), coinBytes)
+			cache.AddDecoy(common.PRVCoinID, new(big.Int).SetUint64(i), coinBytes)
+		}
+		sampled := make(map[uint64]bool)
+		for i := 0; i < 5; i++ {
+			decoyIndex, _, err := cache.RandomOTACoin(common.PRVCoinID, shardID)
+			So(err, ShouldBeNil)
+			So(sampled[decoyIndex.Uint64()], ShouldBeFalse)
+			sampled[decoyIndex.Uint64()] = true
+		}
+		_, _, err = cache.RandomOTACoin(common.PRVCoinID, shardID)
+		So(err, ShouldNotBeNil)
+	})
+}
+
 func testTxV2DeletedProof(txv2 *Tx) {
 	// try setting the proof to nil, then verify
 	// it should not go through
@@ -9,7 +9,12 @@ import (
 	"github.com/incognitochain/incognito-chain/privacy"
 )
 
-func NewCoinUniqueOTABasedOnPaymentInfo(paymentInfo *privacy.PaymentInfo, senderShardID int, tokenID *common.Hash, stateDB *statedb.StateDB) (*privacy.CoinV2, error) {
+// NewCoinUniqueOTABasedOnPaymentInfo create a coin whose OTA is not on chain yet,
+// skipUniquenessCheck is set only for a tx created without state db, the uniqueness is then checked when the tx is validated
+func NewCoinUniqueOTABasedOnPaymentInfo(paymentInfo *privacy.PaymentInfo, senderShardID int, tokenID *common.Hash, stateDB *statedb.StateDB, skipUniquenessCheck bool) (*privacy.CoinV2, error) {
+	if stateDB == nil && !skipUniquenessCheck {
+		return nil, errors.New("cannot check uniqueness of OTA without state db")
+	}
 	for {
 		c, err := privacy.NewCoinFromPaymentInfo(privacy.NewCoinParams().From(paymentInfo, senderShardID, privacy.CoinPrivacyTypeTransfer))
 		if err != nil {
@@ -20,6 +25,9 @@ func NewCoinUniqueOTABasedOnPaymentInfo(paymentInfo *privacy.PaymentInfo, sender
 		if common.IsPublicKeyBurningAddress(c.GetPublicKey().ToBytesS()) {
 			return c, nil // No need to check db
 		}
+		if skipUniquenessCheck {
+			return c, nil
+		}
 		// Onetimeaddress should be unique
 		publicKeyBytes := c.GetPublicKey().ToBytesS()
 		found, _, err := statedb.HasOnetimeAddress(stateDB, *tokenID, publicKeyBytes)
@@ -33,11 +41,11 @@ func NewCoinUniqueOTABasedOnPaymentInfo(paymentInfo *privacy.PaymentInfo, sender
 	}
 }
 
-func NewCoinV2ArrayFromPaymentInfoArray(paymentInfo []*privacy.PaymentInfo, senderShardID int, tokenID *common.Hash, stateDB *statedb.StateDB) ([]*privacy.CoinV2, error) {
+func NewCoinV2ArrayFromPaymentInfoArray(paymentInfo []*privacy.PaymentInfo, senderShardID int, tokenID *common.Hash, stateDB *statedb.StateDB, skipUniquenessCheck bool) ([]*privacy.CoinV2, error) {
 	outputCoins := make([]*privacy.CoinV2, len(paymentInfo))
 	for index, info := range paymentInfo {
 		var err error
-		outputCoins[index], err = NewCoinUniqueOTABasedOnPaymentInfo(info, senderShardID, tokenID, stateDB)
+		outputCoins[index], err = NewCoinUniqueOTABasedOnPaymentInfo(info, senderShardID, tokenID, stateDB, skipUniquenessCheck)
 		if err != nil {
 			Logger.Log.Errorf("Cannot create coin with unique OTA, error: %v", err)
 			return nil, err
@@ -60,6 +68,30 @@ func ParseProof(p interface{}, ver int8, txType string) (privacy.Proof, error) {
 		return nil, nil
 	}
 
+	res, err := newProof(ver, txType)
+	if err != nil {
+		return nil, err
+	}
+	err = json.Unmarshal(proofInBytes, res)
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// ParseProofBytes parse a proof from the output of its Bytes function
+func ParseProofBytes(proofBytes []byte, ver int8, txType string) (privacy.Proof, error) {
+	res, err := newProof(ver, txType)
+	if err != nil {
+		return nil, err
+	}
+	if err := res.SetBytes(proofBytes); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+func newProof(ver int8, txType string) (privacy.Proof, error) {
 	var res privacy.Proof
 	switch txType {
 	case common.TxConversionType:
@@ -80,10 +112,5 @@ func ParseProof(p interface{}, ver int8, txType string) (privacy.Proof, error) {
 			return nil, errors.New("parseProof: Tx.Version is incorrect")
 		}
 	}
-
-	err = json.Unmarshal(proofInBytes, res)
-	if err != nil {
-		return nil, err
-	}
 	return res, nil
 }
@@ -61,6 +61,11 @@ type TxPool interface {
 		sView metadata.ShardViewRetriever,
 		bcView metadata.BeaconViewRetriever,
 	)
+	RestoreTxs(
+		cView metadata.ChainRetriever,
+		sView metadata.ShardViewRetriever,
+		bcView metadata.BeaconViewRetriever,
+	) int
 	CheckValidatedTxs(
 		txs []metadata.Transaction,
 	) (
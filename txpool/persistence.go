@@ -0,0 +1,248 @@
+package txpool
+
+import (
+	"bytes"
+	"encoding/json"
+	"time"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/dataaccessobject/statedb"
+	"github.com/incognitochain/incognito-chain/databasemp"
+	"github.com/incognitochain/incognito-chain/databasemp/lvdb"
+	"github.com/incognitochain/incognito-chain/metadata"
+	"github.com/incognitochain/incognito-chain/privacy"
+	"github.com/incognitochain/incognito-chain/transaction"
+	"github.com/pkg/errors"
+)
+
+// persistedTxDesc is stored with the tx in the mempool database.
+// StartTime and Fee have the names of the desc of the legacy mempool, so both pools share the on-disk format
+type persistedTxDesc struct {
+	StartTime time.Time
+	Fee       uint64
+	Size      uint64
+	VTime     time.Duration
+}
+
+type persistedTx struct {
+	tx   metadata.Transaction
+	desc persistedTxDesc
+}
+
+// SetDatabase make the pool store its validated txs into db, so they can be restored after restart
+func (tp *TxsPool) SetDatabase(db databasemp.DatabaseInterface) {
+	tp.db = db
+}
+
+func (tp *TxsPool) persistTx(tx metadata.Transaction, info TxInfo) {
+	if tp.db == nil {
+		return
+	}
+	startTime := time.Now()
+	if exp, ok := tp.Cacher.Get(tx.Hash().String()); ok {
+		if t, ok := exp.(time.Time); ok {
+			startTime = t
+		}
+	}
+	if err := addTxToDatabase(tp.db, tx, persistedTxDesc{
+		StartTime: startTime,
+		Fee:       info.Fee,
+		Size:      info.Size,
+		VTime:     info.VTime,
+	}); err != nil {
+		Logger.Errorf("Persist tx %v return error %v", tx.Hash().String(), err)
+	}
+}
+
+func (tp *TxsPool) unpersistTxs(txHashes []string) {
+	if tp.db == nil {
+		return
+	}
+	for _, txHash := range txHashes {
+		h, err := common.Hash{}.NewHashFromStr(txHash)
+		if err != nil {
+			continue
+		}
+		if err := tp.db.RemoveTransaction(h); err != nil {
+			Logger.Errorf("Remove persisted tx %v return error %v", txHash, err)
+		}
+	}
+}
+
+// RestoreTxs validate the txs loaded from the mempool database against the current views and add the valid ones into the pool.
+// Invalid or expired txs are dropped from the database. It return the number of restored txs
+func (tp *TxsPool) RestoreTxs(
+	cView metadata.ChainRetriever,
+	sView metadata.ShardViewRetriever,
+	bcView metadata.BeaconViewRetriever,
+) int {
+	persisted := tp.persisted
+	tp.persisted = nil
+	if len(persisted) == 0 {
+		return 0
+	}
+	sDB := sView.GetCopiedTransactionStateDB()
+	mapForChkDbSpend := map[[privacy.Ed25519KeySize]byte]struct {
+		Index  uint
+		Detail TxInfoDetail
+	}{}
+	mapForChkDbStake := map[string]interface{}{}
+	txsValid := []metadata.Transaction{}
+	infos := map[string]persistedTxDesc{}
+	txsToRemove := []string{}
+	for _, p := range persisted {
+		tx := p.tx
+		txHash := tx.Hash().String()
+		if time.Since(p.desc.StartTime) > tp.ttl {
+			txsToRemove = append(txsToRemove, txHash)
+			continue
+		}
+		if err := validatePersistedTx(tp.Verifier, tx, cView, sView, bcView, sDB); err != nil {
+			Logger.Errorf("[txTracing] Restore tx %v return error %v with sView %v\n", txHash, err, sView.GetHeight())
+			txsToRemove = append(txsToRemove, txHash)
+			continue
+		}
+		if tp.isDoubleStake(mapForChkDbStake, tx) {
+			txsToRemove = append(txsToRemove, txHash)
+			continue
+		}
+		isDoubleSpend, needToReplace, _, removeIdx := tp.CheckDoubleSpend(mapForChkDbSpend, tx, &txsValid)
+		if isDoubleSpend && !needToReplace {
+			txsToRemove = append(txsToRemove, txHash)
+			continue
+		}
+		for k := range removeIdx {
+			txsToRemove = append(txsToRemove, txsValid[k].Hash().String())
+			txsValid[k] = nil
+		}
+		txsValid = insertTxIntoList(mapForChkDbSpend, TxInfoDetail{
+			Hash: txHash,
+			Fee:  p.desc.Fee,
+			Size: p.desc.Size,
+			Tx:   tx,
+		}, txsValid)
+		infos[txHash] = p.desc
+	}
+	removeNilTx(&txsValid)
+	tp.unpersistTxs(txsToRemove)
+
+	add := func(tpTemp *TxsPool) {
+		for _, tx := range txsValid {
+			desc := infos[tx.Hash().String()]
+			_, _, _, listKeyCoin := tpTemp.CheckDoubleSpendWithCurMem(tx)
+			tpTemp.Cacher.Add(tx.Hash().String(), desc.StartTime, tpTemp.ttl-time.Since(desc.StartTime))
+			tpTemp.addTx(txInfoTemp{tx, desc.VTime}, listKeyCoin)
+		}
+	}
+	if tp.IsRunning() {
+		done := make(chan struct{})
+		tp.action <- func(tpTemp *TxsPool) {
+			add(tpTemp)
+			close(done)
+		}
+		<-done
+	} else {
+		add(tp)
+	}
+	Logger.Infof("SHARD %v | Restore %v txs from mempool database, drop %v txs", sView.GetShardID(), len(txsValid), len(txsToRemove))
+	return len(txsValid)
+}
+
+func validatePersistedTx(
+	verifier TxVerifier,
+	tx metadata.Transaction,
+	cView metadata.ChainRetriever,
+	sView metadata.ShardViewRetriever,
+	bcView metadata.BeaconViewRetriever,
+	sDB *statedb.StateDB,
+) error {
+	if ok := isTxForUser(tx); !ok {
+		return errors.Errorf("This transaction %v can not be sent by user", tx.Hash().String())
+	}
+	if ok, err := verifier.LoadCommitment(tx, sView); !ok || err != nil {
+		return errors.Errorf("load commitment failed, error %v", err)
+	}
+	if ok, err := verifier.ValidateWithoutChainstate(tx); !ok || err != nil {
+		return errors.Errorf("validate without chain state failed, error %v", err)
+	}
+	if err := tx.CheckData(sDB); err != nil {
+		return err
+	}
+	if ok, err := verifier.ValidateWithChainState(tx, cView, sView, bcView, sView.GetBeaconHeight()); !ok || err != nil {
+		return errors.Errorf("validate with chain state failed, error %v", err)
+	}
+	return nil
+}
+
+// LoadOrResetDatabase make all shard pools persist their txs into db.
+// When load is set, persisted txs are read and kept by their shard pool until RestoreTxs, otherwise db is reset
+func (pm *PoolManager) LoadOrResetDatabase(db databasemp.DatabaseInterface, load bool) error {
+	for _, txPool := range pm.ShardTxsPool {
+		if tp, ok := txPool.(*TxsPool); ok {
+			tp.SetDatabase(db)
+		}
+	}
+	if !load {
+		return db.Reset()
+	}
+	keys, values, err := db.Load()
+	if err != nil {
+		return err
+	}
+	for i, value := range values {
+		tx, desc, err := unmarshalTxFromDatabase(value)
+		if err != nil {
+			Logger.Errorf("Can not load tx %x from mempool database, error %v", keys[i], err)
+			if err := db.Delete(keys[i]); err != nil {
+				Logger.Error(err)
+			}
+			continue
+		}
+		shardID := common.GetShardIDFromLastByte(tx.GetSenderAddrLastByte())
+		if int(shardID) >= len(pm.ShardTxsPool) {
+			continue
+		}
+		if tp, ok := pm.ShardTxsPool[shardID].(*TxsPool); ok {
+			tp.persisted = append(tp.persisted, persistedTx{tx, *desc})
+		}
+	}
+	return nil
+}
+
+// addTxToDatabase store tx in the format of the legacy mempool: {type}-[-]-{tx json}-[-]-{desc json}
+func addTxToDatabase(db databasemp.DatabaseInterface, tx metadata.Transaction, desc persistedTxDesc) error {
+	valueTx, err := json.Marshal(tx)
+	if err != nil {
+		return err
+	}
+	valueDesc, err := json.Marshal(desc)
+	if err != nil {
+		return err
+	}
+	return db.AddTransaction(tx.Hash(), tx.GetType(), valueTx, valueDesc)
+}
+
+func unmarshalTxFromDatabase(value []byte) (metadata.Transaction, *persistedTxDesc, error) {
+	values := bytes.SplitN(value, lvdb.Splitter, 3)
+	if len(values) != 3 {
+		return nil, nil, errors.New("invalid persisted tx value")
+	}
+	var tx metadata.Transaction
+	var err error
+	switch string(values[0]) {
+	case common.TxNormalType, common.TxConversionType:
+		tx, err = transaction.NewTransactionFromJsonBytes(values[1])
+	case common.TxCustomTokenPrivacyType, common.TxTokenConversionType:
+		tx, err = transaction.NewTransactionTokenFromJsonBytes(values[1])
+	default:
+		err = errors.Errorf("unsupported tx type %v", string(values[0]))
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	desc := &persistedTxDesc{}
+	if err := json.Unmarshal(values[2], desc); err != nil {
+		return nil, nil, err
+	}
+	return tx, desc, nil
+}
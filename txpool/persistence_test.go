@@ -0,0 +1,122 @@
+package txpool
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/dataaccessobject/statedb"
+	"github.com/incognitochain/incognito-chain/databasemp"
+	"github.com/incognitochain/incognito-chain/metadata"
+	"github.com/incognitochain/incognito-chain/transaction/tx_generic"
+	"github.com/incognitochain/incognito-chain/transaction/tx_ver2"
+	"github.com/stretchr/testify/assert"
+)
+
+func init() {
+	Logger.Init(common.NewBackend(ioutil.Discard).Logger("Txpool log ", false))
+}
+
+type rejectVerifier struct {
+	TxVerifier
+}
+
+func (rejectVerifier) LoadCommitment(tx metadata.Transaction, sView metadata.ShardViewRetriever) (bool, error) {
+	return false, errors.New("commitment not found")
+}
+
+type testShardView struct {
+	metadata.ShardViewRetriever
+}
+
+func (testShardView) GetCopiedTransactionStateDB() *statedb.StateDB { return nil }
+func (testShardView) GetHeight() uint64                             { return 10 }
+func (testShardView) GetShardID() byte                              { return 0 }
+
+func newPersistenceTestDB(t *testing.T) (databasemp.DatabaseInterface, func()) {
+	dbPath, err := ioutil.TempDir(os.TempDir(), "test_txpool_persistence_")
+	if err != nil {
+		t.Fatal(err)
+	}
+	db, err := databasemp.Open("leveldbmempool", dbPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return db, func() {
+		db.Close()
+		os.RemoveAll(dbPath)
+	}
+}
+
+func newPersistenceTestTx(lastByte byte, lockTime int64) metadata.Transaction {
+	return &tx_ver2.Tx{TxBase: tx_generic.TxBase{
+		Version:              2,
+		Type:                 common.TxNormalType,
+		LockTime:             lockTime,
+		Fee:                  10,
+		PubKeyLastByteSender: lastByte,
+	}}
+}
+
+func TestLoadOrResetDatabase(t *testing.T) {
+	common.MaxShardNumber = 2
+	db, closeDB := newPersistenceTestDB(t)
+	defer closeDB()
+
+	pm, _ := NewPoolManager(2, nil, time.Minute)
+	assert.Nil(t, pm.LoadOrResetDatabase(db, false))
+	tx0, tx1 := newPersistenceTestTx(0, 1), newPersistenceTestTx(1, 2)
+	pm.ShardTxsPool[0].(*TxsPool).addTx(txInfoTemp{tx0, time.Millisecond}, nil)
+	pm.ShardTxsPool[1].(*TxsPool).addTx(txInfoTemp{tx1, time.Millisecond}, nil)
+	assert.Nil(t, db.AddTransaction(&common.Hash{1}, common.TxNormalType, []byte("invalid"), []byte("{}")))
+
+	pm, _ = NewPoolManager(2, nil, time.Minute)
+	assert.Nil(t, pm.LoadOrResetDatabase(db, true))
+	persisted0 := pm.ShardTxsPool[0].(*TxsPool).persisted
+	persisted1 := pm.ShardTxsPool[1].(*TxsPool).persisted
+	assert.Equal(t, 1, len(persisted0))
+	assert.Equal(t, 1, len(persisted1))
+	assert.Equal(t, tx0.Hash().String(), persisted0[0].tx.Hash().String())
+	assert.Equal(t, tx1.Hash().String(), persisted1[0].tx.Hash().String())
+	assert.Equal(t, uint64(10), persisted0[0].desc.Fee)
+	assert.Equal(t, time.Millisecond, persisted0[0].desc.VTime)
+
+	//undecodable tx is dropped
+	has, _ := db.HasTransaction(&common.Hash{1})
+	assert.False(t, has)
+
+	//reset when not loading
+	pm, _ = NewPoolManager(2, nil, time.Minute)
+	assert.Nil(t, pm.LoadOrResetDatabase(db, false))
+	_, values, _ := db.Load()
+	assert.Equal(t, 0, len(values))
+}
+
+func TestRestoreTxsDropInvalid(t *testing.T) {
+	common.MaxShardNumber = 2
+	db, closeDB := newPersistenceTestDB(t)
+	defer closeDB()
+
+	pm, _ := NewPoolManager(2, nil, time.Minute)
+	assert.Nil(t, pm.LoadOrResetDatabase(db, false))
+	tp := pm.ShardTxsPool[0].(*TxsPool)
+	invalidTx, expiredTx := newPersistenceTestTx(0, 1), newPersistenceTestTx(0, 2)
+	tp.addTx(txInfoTemp{invalidTx, 0}, nil)
+	assert.Nil(t, addTxToDatabase(db, expiredTx, persistedTxDesc{StartTime: time.Now().Add(-time.Hour)}))
+
+	pm, _ = NewPoolManager(2, nil, time.Minute)
+	assert.Nil(t, pm.LoadOrResetDatabase(db, true))
+	tp = pm.ShardTxsPool[0].(*TxsPool)
+	tp.UpdateTxVerifier(rejectVerifier{})
+	assert.Equal(t, 2, len(tp.persisted))
+
+	assert.Equal(t, 0, tp.RestoreTxs(nil, testShardView{}, nil))
+	assert.Equal(t, 0, len(tp.Data.TxByHash))
+	assert.Nil(t, tp.persisted)
+
+	_, values, _ := db.Load()
+	assert.Equal(t, 0, len(values))
+}
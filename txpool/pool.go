@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/databasemp"
 	"github.com/incognitochain/incognito-chain/metadata"
 	"github.com/incognitochain/incognito-chain/privacy"
 	"github.com/incognitochain/incognito-chain/transaction"
@@ -63,6 +64,8 @@ type TxsPool struct {
 	better    func(txA, txB metadata.Transaction) bool
 	ttl       time.Duration
 	CData     CoinsData
+	db        databasemp.DatabaseInterface
+	persisted []persistedTx
 }
 
 func NewTxsPool(
@@ -278,6 +281,7 @@ func (tp *TxsPool) addTx(validTx txInfoTemp, listCoinKey []string) {
 	for _, v := range listCoinKey {
 		tp.CData.TxHashByCoin[v] = validTx.tx.Hash().String()
 	}
+	tp.persistTx(validTx.tx, tp.Data.TxInfos[txH])
 }
 
 func (tp *TxsPool) removeDoubleSpendTx(txH string) {
@@ -291,6 +295,7 @@ func (tp *TxsPool) removeDoubleSpendTx(txH string) {
 		}
 	}
 	delete(tp.CData.CoinsByTxHash, txH)
+	tp.unpersistTxs([]string{txH})
 }
 
 func (tp *TxsPool) Stop() {
@@ -304,6 +309,7 @@ func (tp *TxsPool) RemoveTx(txHash string) {
 		Logger.Debugf("Removing tx %v at %v", txHash, time.Now())
 		delete(tpTemp.Data.TxByHash, txHash)
 		delete(tpTemp.Data.TxInfos, txHash)
+		tpTemp.unpersistTxs([]string{txHash})
 	}
 }
 
@@ -313,6 +319,7 @@ func (tp *TxsPool) RemoveTxs(txHashes []string) {
 			delete(tpTemp.Data.TxByHash, tx)
 			delete(tpTemp.Data.TxInfos, tx)
 		}
+		tpTemp.unpersistTxs(txHashes)
 	}
 }
 
@@ -25,3 +25,7 @@ const (
 	OTAKeyType		   = byte(0x3) // Serialize wallet account key into string with only OTA KEY of account keyset
 	PrivateReceivingAddressType = byte(0x4) // prefix for marshalled receiving address (coin pk + txRandom), not used for KeyWallet
 )
+
+const (
+	DefaultRestoreGapLimit = 20 // number of unused accounts in a row ending the restore of a wallet
+)
@@ -26,6 +26,9 @@ const (
 	NewMnemonicError
 	MnemonicInvalidError
 	InvalidSeserializedKey
+	InvalidKeystoreErr
+	UnsupportedKDFErr
+	AccountUsedCheckErr
 )
 
 var ErrCodeMessage = map[int]struct {
@@ -53,6 +56,9 @@ var ErrCodeMessage = map[int]struct {
 	NewMnemonicError:       {-1015, "Can not create mnemonic"},
 	MnemonicInvalidError:   {-1016, "Mnemonic is invalid"},
 	InvalidSeserializedKey: {-1016, "Serialized key is invalid"},
+	InvalidKeystoreErr:     {-1017, "Keystore is invalid"},
+	UnsupportedKDFErr:      {-1018, "Key derivation function is not supported"},
+	AccountUsedCheckErr:    {-1019, "Can not check whether the account is used"},
 }
 
 type WalletError struct {
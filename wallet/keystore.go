@@ -0,0 +1,272 @@
+package wallet
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"golang.org/x/crypto/argon2"
+	"golang.org/x/crypto/scrypt"
+)
+
+const (
+	KeystoreVersion = 1
+
+	ScryptKDF   = "scrypt"
+	Argon2idKDF = "argon2id"
+
+	kdfSaltLen = 32 // bytes
+)
+
+// KDFParams are the params of the function deriving the keystore key from the passphrase.
+// N, R, P are used by scrypt, Time, Memory (in KiB) and Threads by argon2id
+type KDFParams struct {
+	Name    string
+	Salt    string `json:",omitempty"` // hex encoded, a new salt is generated on every save
+	N       int    `json:",omitempty"`
+	R       int    `json:",omitempty"`
+	P       int    `json:",omitempty"`
+	Time    uint32 `json:",omitempty"`
+	Memory  uint32 `json:",omitempty"`
+	Threads uint8  `json:",omitempty"`
+}
+
+var (
+	DefaultScryptParams   = KDFParams{Name: ScryptKDF, N: 1 << 15, R: 8, P: 1}
+	DefaultArgon2idParams = KDFParams{Name: Argon2idKDF, Time: 1, Memory: 64 * 1024, Threads: 4}
+)
+
+// KeystoreCipher is a piece of the keystore encrypted by AES, authenticated by HMAC-SHA256
+type KeystoreCipher struct {
+	CipherText string // hex encoded
+	MAC        string // hex encoded
+}
+
+// KeystoreAccount is an account of the keystore, the payment address is kept in clear
+// so the accounts can be listed without the passphrase
+type KeystoreAccount struct {
+	Name           string
+	PaymentAddress string
+	IsImported     bool
+	PrivateKey     KeystoreCipher
+}
+
+// Keystore is the versioned file format of a wallet.
+// The key derived from the passphrase by KDF encrypts the seed and every account apart
+type Keystore struct {
+	Version  int
+	Name     string
+	KDF      KDFParams
+	Seed     KeystoreCipher
+	Accounts []KeystoreAccount
+}
+
+// keystoreSeed is the plaintext of Keystore.Seed
+type keystoreSeed struct {
+	Seed      []byte
+	Entropy   []byte
+	Mnemonic  string
+	MasterKey []byte
+}
+
+// isKeystoreData returns whether data is a json keystore, a wallet saved in the legacy format is "salt-ciphertext" in hex
+func isKeystoreData(data []byte) bool {
+	return len(bytes.TrimSpace(data)) > 0 && bytes.TrimSpace(data)[0] == '{'
+}
+
+// deriveKeystoreKey derives the AES key and the HMAC key of the keystore from passPhrase with kdf
+func deriveKeystoreKey(passPhrase string, kdf KDFParams) ([]byte, []byte, error) {
+	salt, err := hex.DecodeString(kdf.Salt)
+	if err != nil || len(salt) == 0 {
+		return nil, nil, NewWalletError(InvalidKeystoreErr, err)
+	}
+	var key []byte
+	switch kdf.Name {
+	case ScryptKDF:
+		key, err = scrypt.Key([]byte(passPhrase), salt, kdf.N, kdf.R, kdf.P, 2*common.AESKeySize)
+		if err != nil {
+			return nil, nil, NewWalletError(UnsupportedKDFErr, err)
+		}
+	case Argon2idKDF:
+		if kdf.Time == 0 || kdf.Memory == 0 || kdf.Threads == 0 {
+			return nil, nil, NewWalletError(UnsupportedKDFErr, nil)
+		}
+		key = argon2.IDKey([]byte(passPhrase), salt, kdf.Time, kdf.Memory, kdf.Threads, 2*common.AESKeySize)
+	default:
+		return nil, nil, NewWalletError(UnsupportedKDFErr, nil)
+	}
+	return key[:common.AESKeySize], key[common.AESKeySize:], nil
+}
+
+func encryptKeystoreCipher(encKey, macKey []byte, plaintext []byte) (KeystoreCipher, error) {
+	aes := common.AES{
+		Key: encKey,
+	}
+	cipherText, err := aes.Encrypt(plaintext)
+	if err != nil {
+		return KeystoreCipher{}, NewWalletError(AESEncryptErr, err)
+	}
+	mac := hmac.New(sha256.New, macKey)
+	mac.Write(cipherText)
+	return KeystoreCipher{
+		CipherText: hex.EncodeToString(cipherText),
+		MAC:        hex.EncodeToString(mac.Sum(nil)),
+	}, nil
+}
+
+// decryptKeystoreCipher checks the mac before decrypting, a wrong passphrase fails here
+func decryptKeystoreCipher(encKey, macKey []byte, c KeystoreCipher) ([]byte, error) {
+	cipherText, err := hex.DecodeString(c.CipherText)
+	if err != nil {
+		return nil, NewWalletError(InvalidKeystoreErr, err)
+	}
+	macBytes, err := hex.DecodeString(c.MAC)
+	if err != nil {
+		return nil, NewWalletError(InvalidKeystoreErr, err)
+	}
+	mac := hmac.New(sha256.New, macKey)
+	mac.Write(cipherText)
+	if !hmac.Equal(mac.Sum(nil), macBytes) {
+		return nil, NewWalletError(WrongPassphraseErr, nil)
+	}
+	aes := common.AES{
+		Key: encKey,
+	}
+	plaintext, err := aes.Decrypt(cipherText)
+	if err != nil {
+		return nil, NewWalletError(AESDecryptErr, err)
+	}
+	return plaintext, nil
+}
+
+// ToKeystore encrypts the wallet with passPhrase into a keystore, kdf gets a new random salt
+func (wallet *Wallet) ToKeystore(passPhrase string, kdf KDFParams) (*Keystore, error) {
+	salt := make([]byte, kdfSaltLen)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, NewWalletError(UnexpectedErr, err)
+	}
+	kdf.Salt = hex.EncodeToString(salt)
+	encKey, macKey, err := deriveKeystoreKey(passPhrase, kdf)
+	if err != nil {
+		return nil, err
+	}
+
+	masterKey, err := wallet.MasterAccount.Key.Serialize(PriKeyType, true)
+	if err != nil {
+		return nil, err
+	}
+	seedData, err := json.Marshal(keystoreSeed{
+		Seed:      wallet.Seed,
+		Entropy:   wallet.Entropy,
+		Mnemonic:  wallet.Mnemonic,
+		MasterKey: masterKey,
+	})
+	if err != nil {
+		return nil, NewWalletError(JsonMarshalErr, err)
+	}
+	ks := &Keystore{
+		Version:  KeystoreVersion,
+		Name:     wallet.Name,
+		KDF:      kdf,
+		Accounts: make([]KeystoreAccount, 0, len(wallet.MasterAccount.Child)),
+	}
+	if ks.Seed, err = encryptKeystoreCipher(encKey, macKey, seedData); err != nil {
+		return nil, err
+	}
+
+	for _, account := range wallet.MasterAccount.Child {
+		privateKey, err := account.Key.Serialize(PriKeyType, true)
+		if err != nil {
+			return nil, err
+		}
+		cipher, err := encryptKeystoreCipher(encKey, macKey, privateKey)
+		if err != nil {
+			return nil, err
+		}
+		ks.Accounts = append(ks.Accounts, KeystoreAccount{
+			Name:           account.Name,
+			PaymentAddress: account.Key.Base58CheckSerialize(PaymentAddressType),
+			IsImported:     account.IsImported,
+			PrivateKey:     cipher,
+		})
+	}
+	return ks, nil
+}
+
+// FromKeystore decrypts ks with passPhrase into the wallet, the wallet keeps the KDF of ks for the next saves
+func (wallet *Wallet) FromKeystore(ks *Keystore, passPhrase string) error {
+	if ks.Version != KeystoreVersion {
+		return NewWalletError(InvalidKeystoreErr, nil)
+	}
+	encKey, macKey, err := deriveKeystoreKey(passPhrase, ks.KDF)
+	if err != nil {
+		return err
+	}
+
+	seedData, err := decryptKeystoreCipher(encKey, macKey, ks.Seed)
+	if err != nil {
+		return err
+	}
+	seed := keystoreSeed{}
+	if err := json.Unmarshal(seedData, &seed); err != nil {
+		return NewWalletError(JsonUnmarshalErr, err)
+	}
+	masterKey, err := deserialize(seed.MasterKey)
+	if err != nil {
+		return err
+	}
+	masterAccount := AccountWallet{
+		Key:   *masterKey,
+		Child: make([]AccountWallet, 0, len(ks.Accounts)),
+		Name:  "master",
+	}
+
+	for _, account := range ks.Accounts {
+		privateKey, err := decryptKeystoreCipher(encKey, macKey, account.PrivateKey)
+		if err != nil {
+			return err
+		}
+		key, err := deserialize(privateKey)
+		if err != nil {
+			return err
+		}
+		masterAccount.Child = append(masterAccount.Child, AccountWallet{
+			Name:       account.Name,
+			Key:        *key,
+			Child:      make([]AccountWallet, 0),
+			IsImported: account.IsImported,
+		})
+	}
+
+	wallet.Name = ks.Name
+	wallet.Seed = seed.Seed
+	wallet.Entropy = seed.Entropy
+	wallet.Mnemonic = seed.Mnemonic
+	wallet.PassPhrase = passPhrase
+	wallet.MasterAccount = masterAccount
+	kdf := ks.KDF
+	kdf.Salt = ""
+	wallet.kdf = &kdf
+	return nil
+}
+
+// SaveKeystore saves the wallet as a keystore with kdf, the next saves keep this format
+// If kdf is nil, scrypt with the default params is used
+func (wallet *Wallet) SaveKeystore(passPhrase string, kdf *KDFParams) error {
+	if kdf == nil {
+		kdf = &DefaultScryptParams
+	}
+	old := wallet.kdf
+	temp := *kdf
+	temp.Salt = ""
+	wallet.kdf = &temp
+	if err := wallet.Save(passPhrase); err != nil {
+		wallet.kdf = old
+		return err
+	}
+	return nil
+}
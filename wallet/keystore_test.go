@@ -0,0 +1,165 @@
+package wallet
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestKeystoreWallet(t *testing.T, passPhrase string, numOfAccount uint32) (*Wallet, func()) {
+	dataDir, err := ioutil.TempDir("", "keystore")
+	assert.Nil(t, err)
+	w := new(Wallet)
+	w.SetConfig(&WalletConfig{
+		DataDir:  dataDir,
+		DataFile: "wallet",
+		DataPath: filepath.Join(dataDir, "wallet"),
+	})
+	assert.Nil(t, w.Init(passPhrase, numOfAccount, "Wallet"))
+	return w, func() { os.RemoveAll(dataDir) }
+}
+
+/*
+	Unit test for SaveKeystore function
+*/
+
+func TestKeystoreSaveAndLoad(t *testing.T) {
+	passPhrase := "12345678"
+	for _, kdf := range []KDFParams{DefaultScryptParams, DefaultArgon2idParams} {
+		w, clean := newTestKeystoreWallet(t, passPhrase, 3)
+		err := w.SaveKeystore(passPhrase, &kdf)
+		assert.Nil(t, err)
+
+		fileData, err := ioutil.ReadFile(w.config.DataPath)
+		assert.Nil(t, err)
+		ks := &Keystore{}
+		assert.Nil(t, json.Unmarshal(fileData, ks))
+		assert.Equal(t, KeystoreVersion, ks.Version)
+		assert.Equal(t, kdf.Name, ks.KDF.Name)
+		assert.Equal(t, 3, len(ks.Accounts))
+		assert.Equal(t, w.MasterAccount.Child[1].Key.Base58CheckSerialize(PaymentAddressType), ks.Accounts[1].PaymentAddress)
+		assert.False(t, bytes.Contains(fileData, []byte(w.Mnemonic)))
+
+		w2 := new(Wallet)
+		w2.SetConfig(w.config)
+		assert.Nil(t, w2.LoadWallet(passPhrase))
+		assert.Equal(t, w, w2)
+
+		w3 := new(Wallet)
+		w3.SetConfig(w.config)
+		err = w3.LoadWallet("1234")
+		assert.Equal(t, ErrCodeMessage[WrongPassphraseErr].code, err.(*WalletError).GetCode())
+		clean()
+	}
+}
+
+func TestKeystoreSaveWithUnsupportedKDF(t *testing.T) {
+	passPhrase := "12345678"
+	w, clean := newTestKeystoreWallet(t, passPhrase, 1)
+	defer clean()
+
+	err := w.SaveKeystore(passPhrase, &KDFParams{Name: "md5"})
+	assert.Equal(t, ErrCodeMessage[UnsupportedKDFErr].code, err.(*WalletError).GetCode())
+	assert.Nil(t, w.kdf)
+}
+
+func TestKeystoreTamperedAccount(t *testing.T) {
+	passPhrase := "12345678"
+	w, clean := newTestKeystoreWallet(t, passPhrase, 2)
+	defer clean()
+
+	ks, err := w.ToKeystore(passPhrase, DefaultScryptParams)
+	assert.Nil(t, err)
+	ks.Accounts[0].PrivateKey = ks.Accounts[1].PrivateKey
+	ks.Accounts[1].PrivateKey.CipherText = "00" + ks.Accounts[1].PrivateKey.CipherText[2:]
+
+	err = new(Wallet).FromKeystore(ks, passPhrase)
+	assert.Equal(t, ErrCodeMessage[WrongPassphraseErr].code, err.(*WalletError).GetCode())
+}
+
+/*
+	Unit test for ChangePassphrase function
+*/
+
+func TestWalletChangePassphrase(t *testing.T) {
+	passPhrase := "12345678"
+	newPassPhrase := "87654321"
+	w, clean := newTestKeystoreWallet(t, passPhrase, 2)
+	defer clean()
+	assert.Nil(t, w.Save(passPhrase))
+
+	err := w.ChangePassphrase(newPassPhrase, newPassPhrase)
+	assert.Equal(t, NewWalletError(WrongPassphraseErr, nil), err)
+
+	assert.Nil(t, w.ChangePassphrase(passPhrase, newPassPhrase))
+	assert.Equal(t, newPassPhrase, w.PassPhrase)
+	fileData, err := ioutil.ReadFile(w.config.DataPath)
+	assert.Nil(t, err)
+	assert.True(t, isKeystoreData(fileData))
+
+	w2 := new(Wallet)
+	w2.SetConfig(w.config)
+	err = w2.LoadWallet(passPhrase)
+	assert.Equal(t, ErrCodeMessage[WrongPassphraseErr].code, err.(*WalletError).GetCode())
+	assert.Nil(t, w2.LoadWallet(newPassPhrase))
+	assert.Equal(t, w.MasterAccount, w2.MasterAccount)
+	assert.Equal(t, w.Seed, w2.Seed)
+}
+
+/*
+	Unit test for Restore function
+*/
+
+func TestWalletRestore(t *testing.T) {
+	passPhrase := "12345678"
+	w, clean := newTestKeystoreWallet(t, passPhrase, 1)
+	defer clean()
+
+	usedIndexes := []uint32{0, 2, 5}
+	usedAddresses := map[string]bool{}
+	for _, i := range usedIndexes {
+		childKey, err := w.MasterAccount.Key.NewChildKey(i)
+		assert.Nil(t, err)
+		usedAddresses[childKey.Base58CheckSerialize(PaymentAddressType)] = true
+	}
+	isUsed := func(key *KeyWallet) (bool, error) {
+		return usedAddresses[key.Base58CheckSerialize(PaymentAddressType)], nil
+	}
+
+	data := []struct {
+		gapLimit     uint32
+		numOfAccount int
+	}{
+		{3, 6},
+		{2, 3},
+		{0, 6},
+	}
+	for _, item := range data {
+		w2 := new(Wallet)
+		err := w2.Restore(" "+w.Mnemonic+"  ", passPhrase, "Restored", item.gapLimit, isUsed)
+		assert.Nil(t, err)
+		assert.Equal(t, w.Seed, w2.Seed)
+		assert.Equal(t, w.Entropy, w2.Entropy)
+		assert.Equal(t, w.Mnemonic, w2.Mnemonic)
+		assert.Equal(t, item.numOfAccount, len(w2.MasterAccount.Child))
+		assert.Equal(t, w.MasterAccount.Child[0], w2.MasterAccount.Child[0])
+	}
+
+	// a wallet without any used account keeps one account
+	w2 := new(Wallet)
+	err := w2.Restore(w.Mnemonic, passPhrase, "Restored", 2, func(*KeyWallet) (bool, error) { return false, nil })
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(w2.MasterAccount.Child))
+
+	err = w2.Restore(w.Mnemonic, passPhrase, "Restored", 2, func(*KeyWallet) (bool, error) { return false, errors.New("no chain") })
+	assert.Equal(t, ErrCodeMessage[AccountUsedCheckErr].code, err.(*WalletError).GetCode())
+
+	err = w2.Restore("abandon abandon abandon", passPhrase, "Restored", 2, isUsed)
+	assert.Equal(t, ErrCodeMessage[MnemonicInvalidError].code, err.(*WalletError).GetCode())
+}
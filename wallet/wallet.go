@@ -8,6 +8,7 @@ import (
 	"github.com/incognitochain/incognito-chain/common"
 	"github.com/incognitochain/incognito-chain/common/base58"
 	"io/ioutil"
+	"strings"
 )
 
 type AccountWallet struct {
@@ -25,6 +26,7 @@ type Wallet struct {
 	MasterAccount AccountWallet
 	Name          string
 	config        *WalletConfig
+	kdf           *KDFParams // not nil if the wallet is saved as a keystore
 }
 
 type WalletConfig struct {
@@ -89,6 +91,94 @@ func (wallet *Wallet) Init(passPhrase string, numOfAccount uint32, name string)
 	return nil
 }
 
+// AccountUsedChecker returns whether the account of key has ever received coins on the chain
+type AccountUsedChecker func(key *KeyWallet) (bool, error)
+
+// Restore initializes the wallet from a mnemonic and the pass phrase it was created with, like Init does
+// It derives child keys until gapLimit accounts in a row are not used according to isUsed
+// and keeps the accounts up to the last used one, at least one account
+// If gapLimit equals zero, DefaultRestoreGapLimit is used
+func (wallet *Wallet) Restore(mnemonic string, passPhrase string, name string, gapLimit uint32, isUsed AccountUsedChecker) error {
+	if name == "" {
+		return NewWalletError(EmptyWalletNameErr, nil)
+	}
+	if gapLimit == 0 {
+		gapLimit = DefaultRestoreGapLimit
+	}
+
+	mnemonicGen := MnemonicGenerator{}
+	mnemonic = strings.Join(strings.Fields(mnemonic), " ")
+	entropy, err := mnemonicGen.mnemonicToByteArray(mnemonic, true)
+	if err != nil {
+		return NewWalletError(MnemonicInvalidError, err)
+	}
+	seed := mnemonicGen.NewSeed(mnemonic, passPhrase)
+	masterKey, err := NewMasterKey(seed)
+	if err != nil {
+		return err
+	}
+	masterAccount := AccountWallet{
+		Key:   *masterKey,
+		Child: make([]AccountWallet, 0),
+		Name:  "master",
+	}
+
+	children := make([]AccountWallet, 0)
+	numOfAccount := 1
+	for i, unused := uint32(0), uint32(0); unused < gapLimit; i++ {
+		childKey, err := masterAccount.Key.NewChildKey(i)
+		if err != nil {
+			return NewWalletError(NewChildKeyError, err)
+		}
+		children = append(children, AccountWallet{
+			Key:   *childKey,
+			Child: make([]AccountWallet, 0),
+			Name:  fmt.Sprintf("AccountWallet %d", i),
+		})
+		used, err := isUsed(childKey)
+		if err != nil {
+			return NewWalletError(AccountUsedCheckErr, err)
+		}
+		if used {
+			unused = 0
+			numOfAccount = len(children)
+		} else {
+			unused++
+		}
+	}
+	masterAccount.Child = children[:numOfAccount]
+	Logger.log.Infof("Restore %d accounts of wallet %s", numOfAccount, name)
+
+	wallet.Name = name
+	wallet.Entropy = entropy
+	wallet.Mnemonic = mnemonic
+	wallet.Seed = seed
+	wallet.PassPhrase = passPhrase
+	wallet.MasterAccount = masterAccount
+	return nil
+}
+
+// ChangePassphrase re-encrypts the wallet file with newPassPhrase
+// A wallet in the legacy format is saved as a keystore with the default KDF
+// The seed is not derived again, Restore still needs the pass phrase the wallet was created with
+func (wallet *Wallet) ChangePassphrase(oldPassPhrase string, newPassPhrase string) error {
+	if oldPassPhrase != wallet.PassPhrase {
+		return NewWalletError(WrongPassphraseErr, nil)
+	}
+	oldKDF := wallet.kdf
+	if wallet.kdf == nil {
+		kdf := DefaultScryptParams
+		wallet.kdf = &kdf
+	}
+	wallet.PassPhrase = newPassPhrase
+	if err := wallet.Save(newPassPhrase); err != nil {
+		wallet.PassPhrase = oldPassPhrase
+		wallet.kdf = oldKDF
+		return err
+	}
+	return nil
+}
+
 // CreateNewAccount create new account with accountName
 // it returns that new account and returns errors if accountName is existed
 // If shardID is nil, new account will belong to any shards
@@ -246,23 +336,36 @@ func (wallet *Wallet) Save(password string) error {
 		return NewWalletError(WrongPassphraseErr, nil)
 	}
 
-	// parse to byte[]
-	data, err := json.Marshal(*wallet)
-	if err != nil {
-		Logger.log.Error(err)
-		return NewWalletError(JsonMarshalErr, err)
-	}
+	var cipherTexInBytes []byte
+	if wallet.kdf != nil {
+		ks, err := wallet.ToKeystore(password, *wallet.kdf)
+		if err != nil {
+			Logger.log.Error(err)
+			return err
+		}
+		cipherTexInBytes, err = json.MarshalIndent(ks, "", "\t")
+		if err != nil {
+			return NewWalletError(JsonMarshalErr, err)
+		}
+	} else {
+		// parse to byte[]
+		data, err := json.Marshal(*wallet)
+		if err != nil {
+			Logger.log.Error(err)
+			return NewWalletError(JsonMarshalErr, err)
+		}
 
-	// encrypt data
-	cipherText, err := encryptByPassPhrase(password, data)
-	if err != nil {
-		Logger.log.Error(err)
-		return NewWalletError(UnexpectedErr, err)
+		// encrypt data
+		cipherText, err := encryptByPassPhrase(password, data)
+		if err != nil {
+			Logger.log.Error(err)
+			return NewWalletError(UnexpectedErr, err)
+		}
+		cipherTexInBytes = []byte(cipherText)
 	}
 	// and
 	// save file
-	cipherTexInBytes := []byte(cipherText)
-	err = ioutil.WriteFile(wallet.config.DataPath, cipherTexInBytes, 0644)
+	err := ioutil.WriteFile(wallet.config.DataPath, cipherTexInBytes, 0644)
 	if err != nil {
 		return NewWalletError(WriteFileErr, err)
 	}
@@ -270,6 +373,7 @@ func (wallet *Wallet) Save(password string) error {
 }
 
 // LoadWallet loads encrypted wallet from file and then decrypts it to wallet struct
+// The file can be a keystore or a wallet saved in the legacy format
 // It returns error if any
 func (wallet *Wallet) LoadWallet(password string) error {
 	// read file and decrypt
@@ -277,12 +381,20 @@ func (wallet *Wallet) LoadWallet(password string) error {
 	if err != nil {
 		return NewWalletError(ReadFileErr, err)
 	}
+	if isKeystoreData(bytesData) {
+		ks := &Keystore{}
+		if err := json.Unmarshal(bytesData, ks); err != nil {
+			return NewWalletError(InvalidKeystoreErr, err)
+		}
+		return wallet.FromKeystore(ks, password)
+	}
 	bufBytes, err := decryptByPassPhrase(password, string(bytesData))
 	if err != nil {
 		return NewWalletError(AESDecryptErr, err)
 	}
 
 	// read to struct
+	wallet.kdf = nil
 	err = json.Unmarshal(bufBytes, &wallet)
 	if err != nil {
 		return NewWalletError(JsonUnmarshalErr, err)
@@ -0,0 +1,122 @@
+package gomobile
+
+import (
+	"encoding/json"
+	"fmt"
+
+	rCommon "github.com/ethereum/go-ethereum/common"
+	"github.com/incognitochain/incognito-chain/common"
+	metadataBridge "github.com/incognitochain/incognito-chain/metadata/bridge"
+	"github.com/incognitochain/incognito-chain/privacy"
+)
+
+// CreateBridgeAggShieldTx create a request to shield into a unified token, the tx only pays the PRV fee.
+// metadata: UnifiedTokenID, Data [{NetworkID, IncTokenID, BlockHash, TxIndex, Proof}], the proof of aurora is the shielding tx hash
+func CreateBridgeAggShieldTx(args string) (string, error) {
+	mdReader := &struct {
+		Data []struct {
+			BlockHash  string      `json:"BlockHash"`
+			TxIndex    *uint       `json:"TxIndex"`
+			Proof      []string    `json:"Proof"`
+			NetworkID  uint8       `json:"NetworkID"`
+			IncTokenID common.Hash `json:"IncTokenID"`
+		} `json:"Data"`
+		UnifiedTokenID common.Hash `json:"UnifiedTokenID"`
+	}{}
+	p, err := parseTxV2ParamWithMetadata(args, mdReader)
+	if err != nil {
+		return "", err
+	}
+	data := []metadataBridge.ShieldRequestData{}
+	for _, v := range mdReader.Data {
+		temp := metadataBridge.ShieldRequestData{
+			NetworkID:  v.NetworkID,
+			IncTokenID: v.IncTokenID,
+		}
+		if v.NetworkID == common.AURORANetworkID {
+			if len(v.Proof) != 1 {
+				return "", fmt.Errorf("invalid proof for shielding aurora unified token")
+			}
+			txHash, err := common.Hash{}.NewHashFromStr(v.Proof[0])
+			if err != nil {
+				return "", fmt.Errorf("TxHash incorrect")
+			}
+			temp.Proof = txHash.Bytes()
+		} else {
+			if v.TxIndex == nil {
+				return "", fmt.Errorf("missing TxIndex of the shielding proof")
+			}
+			type EVMProof struct {
+				BlockHash rCommon.Hash `json:"BlockHash"`
+				TxIndex   uint         `json:"TxIndex"`
+				Proof     []string     `json:"Proof"`
+			}
+			temp.Proof, err = json.Marshal(EVMProof{
+				BlockHash: rCommon.HexToHash(v.BlockHash),
+				TxIndex:   *v.TxIndex,
+				Proof:     v.Proof,
+			})
+			if err != nil {
+				return "", err
+			}
+		}
+		data = append(data, temp)
+	}
+	md := metadataBridge.NewShieldRequestWithValue(data, mdReader.UnifiedTokenID)
+
+	p.tokenID = &common.PRVCoinID
+	return createTxV2(p, md)
+}
+
+// CreateBridgeAggUnshieldTx create a request to unshield a unified token to one or more networks, the total burning amount is burnt.
+// metadata: UnifiedTokenID, Data [{IncTokenID, BurningAmount, MinExpectedAmount, RemoteAddress}], AutoRoute, IsDepositToSC
+func CreateBridgeAggUnshieldTx(args string) (string, error) {
+	mdReader := &metadataBridge.UnshieldRequest{}
+	p, err := parseTxV2ParamWithMetadata(args, mdReader)
+	if err != nil {
+		return "", err
+	}
+	recv := privacy.OTAReceiver{}
+	if err := recv.FromAddress(p.paymentAddress()); err != nil {
+		return "", err
+	}
+	md := metadataBridge.NewUnshieldRequestWithValue(mdReader.UnifiedTokenID, mdReader.Data, recv, mdReader.IsDepositToSC)
+	md.AutoRoute = mdReader.AutoRoute
+	burningAmount, err := md.TotalBurningAmount()
+	if err != nil {
+		return "", err
+	}
+
+	p.tokenID = &md.UnifiedTokenID
+	if err := p.burn(md.UnifiedTokenID, burningAmount); err != nil {
+		return "", err
+	}
+	return createTxV2(p, md)
+}
+
+// CreateBridgeAggConvertTx create a request to convert a pToken into its unified token, the amount of pToken is burnt.
+// metadata: TokenID, UnifiedTokenID, Amount
+func CreateBridgeAggConvertTx(args string) (string, error) {
+	mdReader := &struct {
+		TokenID        common.Hash
+		UnifiedTokenID common.Hash
+		Amount         uint64Reader
+	}{}
+	p, err := parseTxV2ParamWithMetadata(args, mdReader)
+	if err != nil {
+		return "", err
+	}
+	recv := privacy.OTAReceiver{}
+	if err := recv.FromAddress(p.paymentAddress()); err != nil {
+		return "", err
+	}
+	md := metadataBridge.NewConvertTokenToUnifiedTokenRequestWithValue(
+		mdReader.TokenID, mdReader.UnifiedTokenID, uint64(mdReader.Amount), recv,
+	)
+
+	p.tokenID = &md.TokenID
+	if err := p.burn(md.TokenID, md.Amount); err != nil {
+		return "", err
+	}
+	return createTxV2(p, md)
+}
@@ -0,0 +1,152 @@
+package gomobile
+
+import (
+	"encoding/json"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/common/base58"
+	"github.com/incognitochain/incognito-chain/incognitokey"
+	"github.com/incognitochain/incognito-chain/privacy"
+	"github.com/incognitochain/incognito-chain/wallet"
+	"github.com/pkg/errors"
+)
+
+type keySetResult struct {
+	PaymentAddress string
+	ReadonlyKey    string
+	OTAPrivateKey  string
+	PublicKey      string
+	ShardID        byte
+}
+
+// GetKeySetFromPrivateKey derive every key of an account from its base58 private key, OTAPrivateKey lets a node or the wallet find the account coins
+func GetKeySetFromPrivateKey(privateKeyStr string) (string, error) {
+	keyWallet, err := wallet.Base58CheckDeserialize(privateKeyStr)
+	if err != nil {
+		return "", err
+	}
+	if len(keyWallet.KeySet.PrivateKey) == 0 {
+		return "", errors.New("Invalid private key")
+	}
+	if err := keyWallet.KeySet.InitFromPrivateKey(&keyWallet.KeySet.PrivateKey); err != nil {
+		return "", err
+	}
+	pk := keyWallet.KeySet.PaymentAddress.Pk
+	res, err := json.Marshal(keySetResult{
+		PaymentAddress: keyWallet.Base58CheckSerialize(wallet.PaymentAddressType),
+		ReadonlyKey:    keyWallet.Base58CheckSerialize(wallet.ReadonlyKeyType),
+		OTAPrivateKey:  keyWallet.Base58CheckSerialize(wallet.OTAKeyType),
+		PublicKey:      base58.Base58Check{}.Encode(pk, common.ZeroByte),
+		ShardID:        common.GetShardIDFromLastByte(pk[len(pk)-1]),
+	})
+	if err != nil {
+		return "", err
+	}
+	return string(res), nil
+}
+
+// CreateOTAReceiver create a new one-time receiver of a payment address, as used by the pdexv3 and bridge metadata
+func CreateOTAReceiver(paymentAddressStr string) (string, error) {
+	keyWallet, err := wallet.Base58CheckDeserialize(paymentAddressStr)
+	if err != nil {
+		return "", err
+	}
+	recv := privacy.OTAReceiver{}
+	if err := recv.FromAddress(keyWallet.KeySet.PaymentAddress); err != nil {
+		return "", err
+	}
+	return recv.String()
+}
+
+type decryptCoinParam struct {
+	PrivateKey  string   `json:"privateKey"`
+	OTAKey      string   `json:"otaKey"`
+	ReadonlyKey string   `json:"readonlyKey"`
+	Coin        string   `json:"coin"`     // base64 encoded coin bytes
+	TokenIDs    []string `json:"tokenIDs"` // candidates of the token of a confidential asset coin
+}
+
+type decryptCoinResult struct {
+	IsOwned    bool
+	PublicKey  string
+	Commitment string
+	KeyImage   string `json:",omitempty"`
+	Value      uint64 `json:",string"`
+	Info       []byte
+	TokenID    string `json:",omitempty"`
+}
+
+// DecryptCoin check if a coin ver 2 belongs to an account and decrypt its value.
+// The account is given by its private key, or by its OTA key and optionally its readonly key (the value is then kept encrypted without it).
+// The key image, used to know if the coin is spent, is only computed with the private key
+func DecryptCoin(args string) (string, error) {
+	p := &decryptCoinParam{}
+	if err := json.Unmarshal([]byte(args), p); err != nil {
+		return "", err
+	}
+	keySet := &incognitokey.KeySet{}
+	if p.PrivateKey != "" {
+		keyWallet, err := wallet.Base58CheckDeserialize(p.PrivateKey)
+		if err != nil {
+			return "", errors.Wrap(err, "Invalid private key")
+		}
+		if err := keySet.InitFromPrivateKey(&keyWallet.KeySet.PrivateKey); err != nil {
+			return "", err
+		}
+	} else {
+		keyWallet, err := wallet.Base58CheckDeserialize(p.OTAKey)
+		if err != nil {
+			return "", errors.Wrap(err, "Invalid OTA key")
+		}
+		keySet.OTAKey = keyWallet.KeySet.OTAKey
+		if p.ReadonlyKey != "" {
+			keyWallet, err := wallet.Base58CheckDeserialize(p.ReadonlyKey)
+			if err != nil {
+				return "", errors.Wrap(err, "Invalid readonly key")
+			}
+			keySet.ReadonlyKey = keyWallet.KeySet.ReadonlyKey
+		}
+	}
+	if keySet.OTAKey.GetPublicSpend() == nil || keySet.OTAKey.GetOTASecretKey() == nil {
+		return "", errors.New("Missing OTA key")
+	}
+
+	c, err := parseCoinV2(p.Coin)
+	if err != nil {
+		return "", errors.Wrap(err, "Invalid coin")
+	}
+	result := decryptCoinResult{
+		PublicKey:  base58.Base58Check{}.Encode(c.GetPublicKey().ToBytesS(), common.ZeroByte),
+		Commitment: base58.Base58Check{}.Encode(c.GetCommitment().ToBytesS(), common.ZeroByte),
+	}
+	result.IsOwned, _ = c.DoesCoinBelongToKeySet(keySet)
+	if result.IsOwned {
+		rawAssetTags := make(map[string]*common.Hash)
+		for _, tokenIDStr := range append(p.TokenIDs, common.PRVIDStr) {
+			tokenID, err := common.Hash{}.NewHashFromStr(tokenIDStr)
+			if err != nil {
+				return "", errors.Wrapf(err, "Invalid token id %v", tokenIDStr)
+			}
+			rawAssetTags[privacy.HashToPoint(tokenID[:]).String()] = tokenID
+		}
+		if tokenID, err := c.GetTokenId(keySet, rawAssetTags); err == nil {
+			result.TokenID = tokenID.String()
+		}
+		if len(keySet.PrivateKey) > 0 || len(keySet.ReadonlyKey.Rk) > 0 {
+			plainCoin, err := c.Decrypt(keySet)
+			if err != nil {
+				return "", err
+			}
+			result.Value = plainCoin.GetValue()
+			result.Info = plainCoin.GetInfo()
+			if plainCoin.GetKeyImage() != nil {
+				result.KeyImage = base58.Base58Check{}.Encode(plainCoin.GetKeyImage().ToBytesS(), common.ZeroByte)
+			}
+		}
+	}
+	res, err := json.Marshal(result)
+	if err != nil {
+		return "", err
+	}
+	return string(res), nil
+}
@@ -0,0 +1,268 @@
+package gomobile
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/incognitochain/incognito-chain/common"
+	metadataCommon "github.com/incognitochain/incognito-chain/metadata/common"
+	metadataPdexv3 "github.com/incognitochain/incognito-chain/metadata/pdexv3"
+	"github.com/incognitochain/incognito-chain/privacy"
+	"github.com/pkg/errors"
+)
+
+// parseTxV2ParamWithMetadata parse the tx ver 2 params of args and its field "metadata" into md
+func parseTxV2ParamWithMetadata(args string, md interface{}) (*txV2Param, error) {
+	p, err := parseTxV2Param(args)
+	if err != nil {
+		return nil, err
+	}
+	mdReader := &struct {
+		Metadata interface{} `json:"metadata"`
+	}{md}
+	if err := json.Unmarshal([]byte(args), mdReader); err != nil {
+		return nil, errors.Wrap(err, "Invalid metadata param")
+	}
+	return p, nil
+}
+
+func generateOTAReceivers(tokens []common.Hash, addr privacy.PaymentAddress) (map[common.Hash]privacy.OTAReceiver, error) {
+	result := map[common.Hash]privacy.OTAReceiver{}
+	for _, tokenID := range tokens {
+		temp := privacy.OTAReceiver{}
+		if err := temp.FromAddress(addr); err != nil {
+			return nil, err
+		}
+		result[tokenID] = temp
+	}
+	return result, nil
+}
+
+func generateOTAReceiverStr(addr privacy.PaymentAddress) (string, error) {
+	temp := privacy.OTAReceiver{}
+	if err := temp.FromAddress(addr); err != nil {
+		return "", err
+	}
+	return temp.String()
+}
+
+// CreatePdexv3TradeTx create a pdex v3 trade request, the sell amount and the trading fee are burnt.
+// metadata: TradePath, TokenToSell, TokenToBuy, SellAmount, MinAcceptableAmount, TradingFee, FeeInPRV
+func CreatePdexv3TradeTx(args string) (string, error) {
+	mdReader := &struct {
+		TradePath           []string
+		TokenToSell         common.Hash
+		TokenToBuy          common.Hash
+		SellAmount          uint64Reader
+		MinAcceptableAmount uint64Reader
+		TradingFee          uint64Reader
+		FeeInPRV            bool
+	}{}
+	p, err := parseTxV2ParamWithMetadata(args, mdReader)
+	if err != nil {
+		return "", err
+	}
+	md, _ := metadataPdexv3.NewTradeRequest(
+		mdReader.TradePath, mdReader.TokenToSell, uint64(mdReader.SellAmount),
+		uint64(mdReader.MinAcceptableAmount), uint64(mdReader.TradingFee), nil,
+		metadataCommon.Pdexv3TradeRequestMeta,
+	)
+	isPRV := md.TokenToSell == common.PRVCoinID
+	tokenList := []common.Hash{md.TokenToSell, mdReader.TokenToBuy}
+	if mdReader.FeeInPRV && !isPRV && mdReader.TokenToBuy != common.PRVCoinID {
+		tokenList = append(tokenList, common.PRVCoinID)
+	}
+	if md.Receiver, err = generateOTAReceivers(tokenList, p.paymentAddress()); err != nil {
+		return "", err
+	}
+
+	p.tokenID = &md.TokenToSell
+	switch {
+	case isPRV:
+		err = p.burn(common.PRVCoinID, md.SellAmount+md.TradingFee)
+	case mdReader.FeeInPRV:
+		if err = p.burn(md.TokenToSell, md.SellAmount); err == nil {
+			err = p.burn(common.PRVCoinID, md.TradingFee)
+		}
+	default:
+		err = p.burn(md.TokenToSell, md.SellAmount+md.TradingFee)
+	}
+	if err != nil {
+		return "", err
+	}
+	return createTxV2(p, md)
+}
+
+// CreatePdexv3AddOrderTx create a pdex v3 limit order, the sell amount is burnt.
+// metadata: TokenToSell, TokenToBuy, PoolPairID, SellAmount, MinAcceptableAmount, NftID
+func CreatePdexv3AddOrderTx(args string) (string, error) {
+	mdReader := &struct {
+		TokenToSell         common.Hash
+		TokenToBuy          common.Hash
+		PoolPairID          string
+		SellAmount          uint64Reader
+		MinAcceptableAmount uint64Reader
+		NftID               common.Hash
+	}{}
+	p, err := parseTxV2ParamWithMetadata(args, mdReader)
+	if err != nil {
+		return "", err
+	}
+	md, _ := metadataPdexv3.NewAddOrderRequest(
+		mdReader.TokenToSell, mdReader.PoolPairID, uint64(mdReader.SellAmount),
+		uint64(mdReader.MinAcceptableAmount), nil,
+		mdReader.NftID, metadataCommon.Pdexv3AddOrderRequestMeta,
+	)
+	if md.Receiver, err = generateOTAReceivers([]common.Hash{md.TokenToSell, mdReader.TokenToBuy}, p.paymentAddress()); err != nil {
+		return "", err
+	}
+
+	p.tokenID = &md.TokenToSell
+	if err := p.burn(md.TokenToSell, md.SellAmount); err != nil {
+		return "", err
+	}
+	return createTxV2(p, md)
+}
+
+// CreatePdexv3WithdrawOrderTx withdraw one or both tokens of a pdex v3 order, 1 NFT is burnt to prove the order owner.
+// metadata: PoolPairID, OrderID, WithdrawTokenIDs, Amount, NftID
+func CreatePdexv3WithdrawOrderTx(args string) (string, error) {
+	mdReader := &struct {
+		PoolPairID       string
+		OrderID          string
+		WithdrawTokenIDs []common.Hash
+		Amount           uint64Reader
+		NftID            common.Hash
+	}{}
+	p, err := parseTxV2ParamWithMetadata(args, mdReader)
+	if err != nil {
+		return "", err
+	}
+	for _, tokenID := range mdReader.WithdrawTokenIDs {
+		if tokenID.IsZeroValue() {
+			return "", fmt.Errorf("Invalid WithdrawTokenID %v", tokenID)
+		}
+	}
+	switch len(mdReader.WithdrawTokenIDs) {
+	case 1:
+	case 2:
+		// withdraw both tokens from order: set withdraw amount to 0
+		mdReader.Amount = 0
+	default:
+		return "", fmt.Errorf("Invalid WithdrawTokenIDs count %d, expect 1 or 2", len(mdReader.WithdrawTokenIDs))
+	}
+	if mdReader.NftID == common.PRVCoinID {
+		return "", errors.New("Cannot use PRV for withdrawOrder TX")
+	}
+	md, _ := metadataPdexv3.NewWithdrawOrderRequest(
+		mdReader.PoolPairID, mdReader.OrderID, uint64(mdReader.Amount),
+		nil, mdReader.NftID, metadataCommon.Pdexv3WithdrawOrderRequestMeta)
+	if md.Receiver, err = generateOTAReceivers(append(mdReader.WithdrawTokenIDs, md.NftID), p.paymentAddress()); err != nil {
+		return "", err
+	}
+
+	p.tokenID = &md.NftID
+	if err := p.burn(md.NftID, 1); err != nil {
+		return "", err
+	}
+	return createTxV2(p, md)
+}
+
+// CreatePdexv3AddLiquidityTx contribute one token of a pdex v3 pool pair, the contributed amount is burnt.
+// metadata: PoolPairID, PairHash, TokenID, NftID, ContributedAmount, Amplifier
+func CreatePdexv3AddLiquidityTx(args string) (string, error) {
+	mdReader := &struct {
+		PoolPairID        string
+		PairHash          string
+		TokenID           string
+		NftID             string
+		ContributedAmount uint64Reader
+		Amplifier         uint64Reader
+	}{}
+	p, err := parseTxV2ParamWithMetadata(args, mdReader)
+	if err != nil {
+		return "", err
+	}
+	tokenID, err := common.Hash{}.NewHashFromStr(mdReader.TokenID)
+	if err != nil {
+		return "", errors.Wrap(err, "Invalid token id")
+	}
+	otaReceiver, err := generateOTAReceiverStr(p.paymentAddress())
+	if err != nil {
+		return "", err
+	}
+	md := metadataPdexv3.NewAddLiquidityRequestWithValue(
+		mdReader.PoolPairID, mdReader.PairHash, otaReceiver, mdReader.TokenID, mdReader.NftID,
+		uint64(mdReader.ContributedAmount), uint(mdReader.Amplifier))
+
+	p.tokenID = tokenID
+	if err := p.burn(*tokenID, md.TokenAmount()); err != nil {
+		return "", err
+	}
+	return createTxV2(p, md)
+}
+
+// CreatePdexv3WithdrawLiquidityTx withdraw a share of a pdex v3 pool pair, 1 NFT is burnt to prove the share owner.
+// metadata: PoolPairID, NftID, ShareAmount
+func CreatePdexv3WithdrawLiquidityTx(args string) (string, error) {
+	mdReader := &struct {
+		PoolPairID  string
+		NftID       string
+		ShareAmount uint64Reader
+	}{}
+	p, err := parseTxV2ParamWithMetadata(args, mdReader)
+	if err != nil {
+		return "", err
+	}
+	nftID, err := common.Hash{}.NewHashFromStr(mdReader.NftID)
+	if err != nil {
+		return "", errors.Wrap(err, "Invalid nft id")
+	}
+	if *nftID == common.PRVCoinID {
+		return "", errors.New("Cannot use PRV for withdrawLiquidity tx")
+	}
+	// pool pair id is token0ID-token1ID-txHash
+	tokenIDs := strings.Split(mdReader.PoolPairID, "-")
+	if len(tokenIDs) != 3 {
+		return "", fmt.Errorf("Invalid pool pair id %v", mdReader.PoolPairID)
+	}
+	otaReceivers := make(map[string]string)
+	for _, tokenID := range []string{tokenIDs[0], tokenIDs[1], mdReader.NftID} {
+		if otaReceivers[tokenID], err = generateOTAReceiverStr(p.paymentAddress()); err != nil {
+			return "", err
+		}
+	}
+	md := metadataPdexv3.NewWithdrawLiquidityRequestWithValue(
+		mdReader.PoolPairID, mdReader.NftID, otaReceivers, uint64(mdReader.ShareAmount),
+	)
+
+	p.tokenID = nftID
+	if err := p.burn(*nftID, 1); err != nil {
+		return "", err
+	}
+	return createTxV2(p, md)
+}
+
+// CreatePdexv3MintNftTx mint a pdex v3 NFT, the amount (the mintNftRequireAmount of pdexv3_getParams) is burnt in PRV.
+// metadata: Amount
+func CreatePdexv3MintNftTx(args string) (string, error) {
+	mdReader := &struct {
+		Amount uint64Reader
+	}{}
+	p, err := parseTxV2ParamWithMetadata(args, mdReader)
+	if err != nil {
+		return "", err
+	}
+	otaReceiver, err := generateOTAReceiverStr(p.paymentAddress())
+	if err != nil {
+		return "", err
+	}
+	md := metadataPdexv3.NewUserMintNftRequestWithValue(otaReceiver, uint64(mdReader.Amount))
+
+	p.tokenID = &common.PRVCoinID
+	if err := p.burn(common.PRVCoinID, md.Amount()); err != nil {
+		return "", err
+	}
+	return createTxV2(p, md)
+}
@@ -0,0 +1,293 @@
+package gomobile
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strconv"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/common/base58"
+	"github.com/incognitochain/incognito-chain/incognitokey"
+	"github.com/incognitochain/incognito-chain/metadata"
+	"github.com/incognitochain/incognito-chain/privacy"
+	"github.com/incognitochain/incognito-chain/privacy/coin"
+	"github.com/incognitochain/incognito-chain/transaction"
+	"github.com/incognitochain/incognito-chain/transaction/utils"
+	"github.com/incognitochain/incognito-chain/wallet"
+	"github.com/pkg/errors"
+)
+
+func init() {
+	// a wallet loads neither the chain params nor the node loggers the tx builders rely on
+	utils.Logger.Init(common.NewBackend(nil).Logger("gomobile", true))
+	if common.MaxShardNumber == 0 {
+		common.MaxShardNumber = 8
+	}
+}
+
+// uint64Reader accept a number or a numeric string, js can not hold every uint64 as a number
+type uint64Reader uint64
+
+func (u *uint64Reader) UnmarshalJSON(raw []byte) error {
+	var theNum uint64
+	if err := json.Unmarshal(raw, &theNum); err == nil {
+		*u = uint64Reader(theNum)
+		return nil
+	}
+	var theStr string
+	if err := json.Unmarshal(raw, &theStr); err != nil {
+		return fmt.Errorf("invalid number %s - must be uint64 or string", string(raw))
+	}
+	temp, err := strconv.ParseUint(theStr, 10, 64)
+	*u = uint64Reader(temp)
+	return err
+}
+
+type paymentInfoParam struct {
+	PaymentAddress string       `json:"paymentAddress"`
+	Amount         uint64Reader `json:"amount"`
+	Message        string       `json:"message"` // base64 encoded
+}
+
+// coinParam is an on-chain coin ver 2 and its index, as returned by the rpc listoutputcoinsfromcache and getotacoinsbyindices
+type coinParam struct {
+	Coin  string       `json:"coin"` // base64 encoded coin bytes
+	Index uint64Reader `json:"index"`
+}
+
+// txV2Param are the params shared by all tx ver 2 builders.
+// The PRV fee is paid with inputCoins, the token of tokenID (if any) is paid with tokenInputCoins.
+// decoys and tokenDecoys are random coins of the sender shard, fetched with getotacoinsbyindices for PRV and the confidential asset ID
+type txV2Param struct {
+	SenderSK          string             `json:"senderSK"`
+	Fee               uint64Reader       `json:"fee"`
+	Info              string             `json:"info"`
+	PaymentInfos      []paymentInfoParam `json:"paymentInfos"`
+	InputCoins        []coinParam        `json:"inputCoins"`
+	Decoys            []coinParam        `json:"decoys"`
+	TokenID           string             `json:"tokenID"`
+	TokenPaymentInfos []paymentInfoParam `json:"tokenPaymentInfos"`
+	TokenInputCoins   []coinParam        `json:"tokenInputCoins"`
+	TokenDecoys       []coinParam        `json:"tokenDecoys"`
+	BurnAddress       string             `json:"burnAddress"`
+
+	keySet    *incognitokey.KeySet
+	tokenID   *common.Hash
+	prvBurn   *privacy.PaymentInfo
+	tokenBurn *privacy.PaymentInfo
+}
+
+// txV2Result is the result of all tx ver 2 builders, Base58CheckData can be sent with sendrawtransaction or sendrawprivacycustomtokentransaction
+type txV2Result struct {
+	TxID            string
+	Base58CheckData string
+	IsToken         bool
+}
+
+func parseTxV2Param(args string) (*txV2Param, error) {
+	p := &txV2Param{}
+	if err := json.Unmarshal([]byte(args), p); err != nil {
+		return nil, err
+	}
+	keyWallet, err := wallet.Base58CheckDeserialize(p.SenderSK)
+	if err != nil {
+		return nil, errors.Wrap(err, "Invalid sender private key")
+	}
+	if len(keyWallet.KeySet.PrivateKey) == 0 {
+		return nil, errors.New("Invalid sender private key")
+	}
+	p.keySet = new(incognitokey.KeySet)
+	if err := p.keySet.InitFromPrivateKey(&keyWallet.KeySet.PrivateKey); err != nil {
+		return nil, err
+	}
+	p.tokenID = &common.PRVCoinID
+	if p.TokenID != "" {
+		p.tokenID, err = common.Hash{}.NewHashFromStr(p.TokenID)
+		if err != nil {
+			return nil, errors.Wrap(err, "Invalid token id")
+		}
+	}
+	if p.BurnAddress == "" {
+		p.BurnAddress = common.BurningAddress2
+	}
+	return p, nil
+}
+
+// isToken return whether the tx transfers a token other than PRV
+func (p *txV2Param) isToken() bool {
+	return *p.tokenID != common.PRVCoinID
+}
+
+func (p *txV2Param) senderShardID() byte {
+	pk := p.keySet.PaymentAddress.Pk
+	return common.GetShardIDFromLastByte(pk[len(pk)-1])
+}
+
+func (p *txV2Param) paymentAddress() privacy.PaymentAddress {
+	return p.keySet.PaymentAddress
+}
+
+// burn make the tx burn amount of tokenID instead of paying the receivers of tokenID in the args
+func (p *txV2Param) burn(tokenID common.Hash, amount uint64) error {
+	w, err := wallet.Base58CheckDeserialize(p.BurnAddress)
+	if err != nil {
+		return errors.Wrap(err, "Invalid burn address")
+	}
+	burn := &privacy.PaymentInfo{PaymentAddress: w.KeySet.PaymentAddress, Amount: amount}
+	if tokenID == common.PRVCoinID {
+		p.PaymentInfos = nil
+		p.prvBurn = burn
+	} else {
+		p.TokenPaymentInfos = nil
+		p.tokenBurn = burn
+	}
+	return nil
+}
+
+func parsePaymentInfos(params []paymentInfoParam) ([]*privacy.PaymentInfo, error) {
+	paymentInfos := make([]*privacy.PaymentInfo, 0, len(params))
+	for _, param := range params {
+		keyWallet, err := wallet.Base58CheckDeserialize(param.PaymentAddress)
+		if err != nil {
+			return nil, errors.Wrapf(err, "Invalid payment address %v", param.PaymentAddress)
+		}
+		msg := []byte{}
+		if param.Message != "" {
+			if msg, err = base64.StdEncoding.DecodeString(param.Message); err != nil {
+				return nil, errors.Wrap(err, "Invalid payment info message")
+			}
+		}
+		paymentInfos = append(paymentInfos, &privacy.PaymentInfo{
+			PaymentAddress: keyWallet.KeySet.PaymentAddress,
+			Amount:         uint64(param.Amount),
+			Message:        msg,
+		})
+	}
+	return paymentInfos, nil
+}
+
+func parseCoinV2(coinStr string) (*coin.CoinV2, error) {
+	coinBytes, err := base64.StdEncoding.DecodeString(coinStr)
+	if err != nil {
+		return nil, err
+	}
+	c := new(coin.CoinV2)
+	if err := c.SetBytes(coinBytes); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// parseInputCoins decrypt the input coins with the sender key set and add their indexes and the decoys to cache under dbTokenID,
+// which is PRV for PRV coins and the confidential asset ID for token coins
+func parseInputCoins(keySet *incognitokey.KeySet, inputs, decoys []coinParam, dbTokenID common.Hash, cache *transaction.RingCoinCache) ([]privacy.PlainCoin, error) {
+	inputCoins := make([]privacy.PlainCoin, 0, len(inputs))
+	for _, input := range inputs {
+		c, err := parseCoinV2(input.Coin)
+		if err != nil {
+			return nil, errors.Wrap(err, "Invalid input coin")
+		}
+		plainCoin, err := c.Decrypt(keySet)
+		if err != nil {
+			return nil, errors.Wrap(err, "Can not decrypt input coin")
+		}
+		cache.AddInputCoin(dbTokenID, c.GetPublicKey().ToBytesS(), new(big.Int).SetUint64(uint64(input.Index)))
+		inputCoins = append(inputCoins, plainCoin)
+	}
+	for _, decoy := range decoys {
+		coinBytes, err := base64.StdEncoding.DecodeString(decoy.Coin)
+		if err != nil {
+			return nil, errors.Wrap(err, "Invalid decoy coin")
+		}
+		cache.AddDecoy(dbTokenID, new(big.Int).SetUint64(uint64(decoy.Index)), coinBytes)
+	}
+	return inputCoins, nil
+}
+
+// createTxV2 create a signed tx ver 2 with the PRV or token transfers of p and the metadata md (can be nil)
+func createTxV2(p *txV2Param, md metadata.Metadata) (string, error) {
+	cache := transaction.NewRingCoinCache()
+	kvargs := map[string]interface{}{transaction.KvargsRingCoinGetter: cache}
+	paymentInfos, err := parsePaymentInfos(p.PaymentInfos)
+	if err != nil {
+		return "", err
+	}
+	if p.prvBurn != nil {
+		paymentInfos = append(paymentInfos, p.prvBurn)
+	}
+	inputCoins, err := parseInputCoins(p.keySet, p.InputCoins, p.Decoys, common.PRVCoinID, cache)
+	if err != nil {
+		return "", err
+	}
+	info := []byte(p.Info)
+
+	var tx metadata.Transaction
+	if !p.isToken() {
+		params := transaction.NewTxPrivacyInitParams(&p.keySet.PrivateKey, paymentInfos, inputCoins, uint64(p.Fee), true, nil, &common.PRVCoinID, md, info)
+		params.Kvargs = kvargs
+		txNormal := new(transaction.TxVersion2)
+		if err := txNormal.Init(params); err != nil {
+			println("Can not create tx: ", err.Error())
+			return "", err
+		}
+		tx = txNormal
+	} else {
+		tokenPaymentInfos, err := parsePaymentInfos(p.TokenPaymentInfos)
+		if err != nil {
+			return "", err
+		}
+		if p.tokenBurn != nil {
+			tokenPaymentInfos = append(tokenPaymentInfos, p.tokenBurn)
+		}
+		tokenInputCoins, err := parseInputCoins(p.keySet, p.TokenInputCoins, p.TokenDecoys, common.ConfidentialAssetID, cache)
+		if err != nil {
+			return "", err
+		}
+		tokenAmount := uint64(0)
+		for _, paymentInfo := range tokenPaymentInfos {
+			tokenAmount += paymentInfo.Amount
+		}
+		tokenParams := &transaction.TokenParam{
+			PropertyID:  p.tokenID.String(),
+			Amount:      tokenAmount,
+			TokenTxType: transaction.CustomTokenTransfer,
+			Receiver:    tokenPaymentInfos,
+			TokenInput:  tokenInputCoins,
+		}
+		params := transaction.NewTxTokenParams(&p.keySet.PrivateKey, paymentInfos, inputCoins, uint64(p.Fee), tokenParams, nil, md, true, true, p.senderShardID(), info, nil)
+		params.Kvargs = kvargs
+		txToken := new(transaction.TxTokenVersion2)
+		if err := txToken.Init(params); err != nil {
+			println("Can not create tx: ", err.Error())
+			return "", err
+		}
+		tx = txToken
+	}
+
+	txJson, err := json.Marshal(tx)
+	if err != nil {
+		println("Can not marshal tx: ", err.Error())
+		return "", err
+	}
+	res, err := json.Marshal(txV2Result{
+		TxID:            tx.Hash().String(),
+		Base58CheckData: base58.Base58Check{}.Encode(txJson, common.ZeroByte),
+		IsToken:         p.isToken(),
+	})
+	if err != nil {
+		return "", err
+	}
+	return string(res), nil
+}
+
+// CreateTransactionV2 create a PRV or token transfer tx ver 2, the receivers get one-time addresses.
+// args is the json of txV2Param
+func CreateTransactionV2(args string) (string, error) {
+	p, err := parseTxV2Param(args)
+	if err != nil {
+		return "", err
+	}
+	return createTxV2(p, nil)
+}
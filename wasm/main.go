@@ -211,6 +211,123 @@ func verifySign(_ js.Value, args []js.Value) interface{} {
 	return result
 }
 
+func createTransactionV2(_ js.Value, args []js.Value) interface{} {
+	result, err := gomobile.CreateTransactionV2(args[0].String())
+	if err != nil {
+		return nil
+	}
+
+	return result
+}
+
+func getKeySetFromPrivateKey(_ js.Value, args []js.Value) interface{} {
+	result, err := gomobile.GetKeySetFromPrivateKey(args[0].String())
+	if err != nil {
+		return nil
+	}
+
+	return result
+}
+
+func createOTAReceiver(_ js.Value, args []js.Value) interface{} {
+	result, err := gomobile.CreateOTAReceiver(args[0].String())
+	if err != nil {
+		return nil
+	}
+
+	return result
+}
+
+func decryptCoin(_ js.Value, args []js.Value) interface{} {
+	result, err := gomobile.DecryptCoin(args[0].String())
+	if err != nil {
+		return nil
+	}
+
+	return result
+}
+
+func createPdexv3TradeTx(_ js.Value, args []js.Value) interface{} {
+	result, err := gomobile.CreatePdexv3TradeTx(args[0].String())
+	if err != nil {
+		return nil
+	}
+
+	return result
+}
+
+func createPdexv3AddOrderTx(_ js.Value, args []js.Value) interface{} {
+	result, err := gomobile.CreatePdexv3AddOrderTx(args[0].String())
+	if err != nil {
+		return nil
+	}
+
+	return result
+}
+
+func createPdexv3WithdrawOrderTx(_ js.Value, args []js.Value) interface{} {
+	result, err := gomobile.CreatePdexv3WithdrawOrderTx(args[0].String())
+	if err != nil {
+		return nil
+	}
+
+	return result
+}
+
+func createPdexv3AddLiquidityTx(_ js.Value, args []js.Value) interface{} {
+	result, err := gomobile.CreatePdexv3AddLiquidityTx(args[0].String())
+	if err != nil {
+		return nil
+	}
+
+	return result
+}
+
+func createPdexv3WithdrawLiquidityTx(_ js.Value, args []js.Value) interface{} {
+	result, err := gomobile.CreatePdexv3WithdrawLiquidityTx(args[0].String())
+	if err != nil {
+		return nil
+	}
+
+	return result
+}
+
+func createPdexv3MintNftTx(_ js.Value, args []js.Value) interface{} {
+	result, err := gomobile.CreatePdexv3MintNftTx(args[0].String())
+	if err != nil {
+		return nil
+	}
+
+	return result
+}
+
+func createBridgeAggShieldTx(_ js.Value, args []js.Value) interface{} {
+	result, err := gomobile.CreateBridgeAggShieldTx(args[0].String())
+	if err != nil {
+		return nil
+	}
+
+	return result
+}
+
+func createBridgeAggUnshieldTx(_ js.Value, args []js.Value) interface{} {
+	result, err := gomobile.CreateBridgeAggUnshieldTx(args[0].String())
+	if err != nil {
+		return nil
+	}
+
+	return result
+}
+
+func createBridgeAggConvertTx(_ js.Value, args []js.Value) interface{} {
+	result, err := gomobile.CreateBridgeAggConvertTx(args[0].String())
+	if err != nil {
+		return nil
+	}
+
+	return result
+}
+
 func main() {
 	c := make(chan struct{}, 0)
 	println("Hello WASM")
@@ -243,5 +360,21 @@ func main() {
 	js.Global().Set("signPoolWithdraw", js.FuncOf(signPoolWithdraw))
 	js.Global().Set("verifySign", js.FuncOf(verifySign))
 
+	js.Global().Set("createTransactionV2", js.FuncOf(createTransactionV2))
+	js.Global().Set("getKeySetFromPrivateKey", js.FuncOf(getKeySetFromPrivateKey))
+	js.Global().Set("createOTAReceiver", js.FuncOf(createOTAReceiver))
+	js.Global().Set("decryptCoin", js.FuncOf(decryptCoin))
+
+	js.Global().Set("createPdexv3TradeTx", js.FuncOf(createPdexv3TradeTx))
+	js.Global().Set("createPdexv3AddOrderTx", js.FuncOf(createPdexv3AddOrderTx))
+	js.Global().Set("createPdexv3WithdrawOrderTx", js.FuncOf(createPdexv3WithdrawOrderTx))
+	js.Global().Set("createPdexv3AddLiquidityTx", js.FuncOf(createPdexv3AddLiquidityTx))
+	js.Global().Set("createPdexv3WithdrawLiquidityTx", js.FuncOf(createPdexv3WithdrawLiquidityTx))
+	js.Global().Set("createPdexv3MintNftTx", js.FuncOf(createPdexv3MintNftTx))
+
+	js.Global().Set("createBridgeAggShieldTx", js.FuncOf(createBridgeAggShieldTx))
+	js.Global().Set("createBridgeAggUnshieldTx", js.FuncOf(createBridgeAggUnshieldTx))
+	js.Global().Set("createBridgeAggConvertTx", js.FuncOf(createBridgeAggConvertTx))
+
 	<-c
 }
@@ -0,0 +1,48 @@
+package wire
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+
+	"github.com/incognitochain/incognito-chain/blockchain/types"
+)
+
+// UseBinaryBlockEncoding send blocks in block messages with the binary block codec instead of json.
+// Receiving always accept both, so it is only enabled once all peers can decode binary blocks
+var UseBinaryBlockEncoding = false
+
+// marshalBlockField return the json value of a block field of a message,
+// binary encoded blocks are sent as base64 string
+func marshalBlockField(blk types.BlockInterface) (json.RawMessage, error) {
+	if !UseBinaryBlockEncoding {
+		return json.Marshal(blk)
+	}
+	data, err := types.EncodeBlock(blk)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(base64.StdEncoding.EncodeToString(data))
+}
+
+// unmarshalBlockField decode a block field written by marshalBlockField or a legacy json block
+func unmarshalBlockField(raw json.RawMessage, chainID int) (types.BlockInterface, error) {
+	if len(raw) == 0 || string(raw) == "null" {
+		return nil, nil
+	}
+	if raw[0] != '"' {
+		return types.DecodeBlock(raw, chainID)
+	}
+	encoded := ""
+	if err := json.Unmarshal(raw, &encoded); err != nil {
+		return nil, err
+	}
+	data, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, err
+	}
+	if !types.IsBinaryEncodedBlock(data) {
+		return nil, errors.New("block field is not binary encoded")
+	}
+	return types.DecodeBlock(data, chainID)
+}
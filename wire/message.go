@@ -35,8 +35,9 @@ const (
 	CmdMsgCheckResp = "msgcheckresp"
 
 	// validator state messages
-	CmdMsgFinishSync  = "finishsync"
-	CmdMsgFeatureStat = "featurestat"
+	CmdMsgFinishSync   = "finishsync"
+	CmdMsgFeatureStat  = "featurestat"
+	CmdMsgEquivocation = "equivocation"
 )
 
 // Interface for message wire on P2P network
@@ -137,6 +138,9 @@ func MakeEmptyMessage(messageType string) (Message, error) {
 	case CmdMsgFeatureStat:
 		msg = &MessageFeature{}
 		break
+	case CmdMsgEquivocation:
+		msg = &MessageEquivocation{}
+		break
 	default:
 		return nil, fmt.Errorf("unhandled this message type [%s]", messageType)
 	}
@@ -197,6 +201,8 @@ func GetCmdType(msgType reflect.Type) (string, error) {
 		return CmdMsgFinishSync, nil
 	case reflect.TypeOf(&MessageFeature{}):
 		return CmdMsgFeatureStat, nil
+	case reflect.TypeOf(&MessageEquivocation{}):
+		return CmdMsgEquivocation, nil
 	default:
 		return utils.EmptyString, fmt.Errorf("unhandled this message type [%s]", msgType)
 	}
@@ -2,6 +2,7 @@ package wire
 
 import (
 	"encoding/json"
+	"errors"
 	"github.com/incognitochain/incognito-chain/blockchain/types"
 
 	"github.com/incognitochain/incognito-chain/common"
@@ -33,6 +34,42 @@ func (msg *MessageBlockBeacon) MaxPayloadLength(pver int) int {
 	return MaxBlockPayload
 }
 
+func (msg *MessageBlockBeacon) MarshalJSON() ([]byte, error) {
+	temp := struct {
+		Block json.RawMessage
+	}{
+		Block: json.RawMessage("null"),
+	}
+	if msg.Block != nil {
+		block, err := marshalBlockField(msg.Block)
+		if err != nil {
+			return nil, err
+		}
+		temp.Block = block
+	}
+	return json.Marshal(temp)
+}
+
+func (msg *MessageBlockBeacon) UnmarshalJSON(data []byte) error {
+	temp := struct {
+		Block json.RawMessage
+	}{}
+	if err := json.Unmarshal(data, &temp); err != nil {
+		return err
+	}
+	msg.Block = nil
+	blk, err := unmarshalBlockField(temp.Block, common.BeaconChainID)
+	if err != nil || blk == nil {
+		return err
+	}
+	beaconBlock, ok := blk.(*types.BeaconBlock)
+	if !ok {
+		return errors.New("block of message is not a beacon block")
+	}
+	msg.Block = beaconBlock
+	return nil
+}
+
 func (msg *MessageBlockBeacon) JsonSerialize() ([]byte, error) {
 	jsonBytes, err := json.Marshal(msg)
 	return jsonBytes, err
@@ -2,6 +2,7 @@ package wire
 
 import (
 	"encoding/json"
+	"errors"
 	"github.com/incognitochain/incognito-chain/blockchain/types"
 
 	"github.com/incognitochain/incognito-chain/common"
@@ -30,6 +31,46 @@ func (msg *MessageBlockShard) MaxPayloadLength(pver int) int {
 	return MaxBlockPayload
 }
 
+func (msg *MessageBlockShard) MarshalJSON() ([]byte, error) {
+	temp := struct {
+		Block                  json.RawMessage
+		PreviousValidationData string
+	}{
+		Block:                  json.RawMessage("null"),
+		PreviousValidationData: msg.PreviousValidationData,
+	}
+	if msg.Block != nil {
+		block, err := marshalBlockField(msg.Block)
+		if err != nil {
+			return nil, err
+		}
+		temp.Block = block
+	}
+	return json.Marshal(temp)
+}
+
+func (msg *MessageBlockShard) UnmarshalJSON(data []byte) error {
+	temp := struct {
+		Block                  json.RawMessage
+		PreviousValidationData string
+	}{}
+	if err := json.Unmarshal(data, &temp); err != nil {
+		return err
+	}
+	msg.PreviousValidationData = temp.PreviousValidationData
+	msg.Block = nil
+	blk, err := unmarshalBlockField(temp.Block, 0)
+	if err != nil || blk == nil {
+		return err
+	}
+	shardBlock, ok := blk.(*types.ShardBlock)
+	if !ok {
+		return errors.New("block of message is not a shard block")
+	}
+	msg.Block = shardBlock
+	return nil
+}
+
 func (msg *MessageBlockShard) JsonSerialize() ([]byte, error) {
 	jsonBytes, err := json.Marshal(msg)
 	return jsonBytes, err
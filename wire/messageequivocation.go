@@ -0,0 +1,59 @@
+package wire
+
+import (
+	"encoding/hex"
+	"encoding/json"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/incognitokey"
+	peer "github.com/libp2p/go-libp2p-peer"
+)
+
+// MessageEquivocation carry an evidence of double vote, evidence is json of consensustypes.EquivocationEvidence
+type MessageEquivocation struct {
+	Evidence []byte
+	ShardID  byte
+}
+
+func NewMessageEquivocation(evidence []byte, shardID byte) *MessageEquivocation {
+	return &MessageEquivocation{Evidence: evidence, ShardID: shardID}
+}
+
+func (msg *MessageEquivocation) Hash() string {
+	rawBytes, err := msg.JsonSerialize()
+	if err != nil {
+		return ""
+	}
+	return common.HashH(rawBytes).String()
+}
+
+func (msg *MessageEquivocation) MessageType() string {
+	return CmdMsgEquivocation
+}
+
+func (msg *MessageEquivocation) MaxPayloadLength(pver int) int {
+	return MaxTxPayload
+}
+
+func (msg *MessageEquivocation) JsonSerialize() ([]byte, error) {
+	jsonBytes, err := json.Marshal(msg)
+	return jsonBytes, err
+}
+
+func (msg *MessageEquivocation) JsonDeserialize(jsonStr string) error {
+	jsonDecodeString, _ := hex.DecodeString(jsonStr)
+	err := json.Unmarshal([]byte(jsonDecodeString), msg)
+	return err
+}
+
+func (msg *MessageEquivocation) SetSenderID(senderID peer.ID) error {
+	return nil
+}
+
+func (msg *MessageEquivocation) SignMsg(_ *incognitokey.KeySet) error {
+	return nil
+}
+
+func (msg *MessageEquivocation) VerifyMsgSanity() error {
+	return nil
+}